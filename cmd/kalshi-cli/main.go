@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/6missedcalls/kalshi-cli/internal/cmd"
@@ -15,6 +16,10 @@ var (
 func main() {
 	cmd.SetVersionInfo(version, commit, date)
 	if err := cmd.Execute(); err != nil {
+		var exitErr *cmd.ExitStatusError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		cmd.PrintError(err)
 		os.Exit(1)
 	}
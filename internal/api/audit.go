@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// AuditEvent describes a completed (or refused) state-changing request
+type AuditEvent struct {
+	Time       time.Time
+	Method     string
+	Path       string
+	Body       json.RawMessage
+	StatusCode int
+	Err        error
+	RequestID  string
+}
+
+// AuditFunc receives an event for every POST, PUT, PATCH, and DELETE request
+type AuditFunc func(AuditEvent)
+
+// SetAudit registers fn to be called once per mutating request, after retries.
+// Read-only GET requests are never audited.
+func (c *Client) SetAudit(fn AuditFunc) {
+	c.audit = fn
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// auditSuccess records a mutating request that received an HTTP response
+func (c *Client) auditSuccess(_ *resty.Client, resp *resty.Response) {
+	if c.audit == nil || resp.Request == nil || !isMutatingMethod(resp.Request.Method) {
+		return
+	}
+	c.audit(newAuditEvent(resp.Request, resp.StatusCode(), nil))
+}
+
+// auditError records a mutating request that failed or was refused locally
+func (c *Client) auditError(req *resty.Request, err error) {
+	if c.audit == nil || req == nil || !isMutatingMethod(req.Method) {
+		return
+	}
+
+	status := 0
+	var respErr *resty.ResponseError
+	if errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.RawResponse != nil {
+		status = respErr.Response.StatusCode()
+	}
+	c.audit(newAuditEvent(req, status, err))
+}
+
+func newAuditEvent(req *resty.Request, status int, err error) AuditEvent {
+	event := AuditEvent{
+		Time:       time.Now().UTC(),
+		Method:     req.Method,
+		Path:       requestPath(req.URL),
+		StatusCode: status,
+		Err:        err,
+		RequestID:  RequestID(req),
+	}
+
+	switch body := req.Body.(type) {
+	case nil:
+	case []byte:
+		if json.Valid(body) {
+			event.Body = body
+		}
+	case string:
+		if json.Valid([]byte(body)) {
+			event.Body = json.RawMessage(body)
+		}
+	default:
+		if data, err := json.Marshal(body); err == nil {
+			event.Body = data
+		}
+	}
+
+	return event
+}
+
+// requestPath strips the scheme and host that resty adds once a request has run
+func requestPath(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() {
+		return raw
+	}
+	if u.RawQuery != "" {
+		return u.Path + "?" + u.RawQuery
+	}
+	return u.Path
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Audit_RecordsMutatingRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"not_found","message":"no such order"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	var events []AuditEvent
+	client.SetAudit(func(e AuditEvent) {
+		events = append(events, e)
+	})
+
+	ctx := context.Background()
+	client.GetJSON(ctx, "/trade-api/v2/portfolio/balance", nil)
+	client.PostJSON(ctx, "/trade-api/v2/portfolio/orders", map[string]int{"count": 1}, nil)
+	client.DeleteJSON(ctx, "/trade-api/v2/portfolio/orders/abc", nil)
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 audit events (GET excluded), got %d", len(events))
+	}
+
+	post := events[0]
+	if post.Method != http.MethodPost || post.Path != "/trade-api/v2/portfolio/orders" {
+		t.Errorf("unexpected POST event: %+v", post)
+	}
+	if string(post.Body) != `{"count":1}` {
+		t.Errorf("expected request body to be recorded, got %s", post.Body)
+	}
+	if post.StatusCode != http.StatusOK || post.Err != nil {
+		t.Errorf("expected successful POST event, got status=%d err=%v", post.StatusCode, post.Err)
+	}
+
+	del := events[1]
+	if del.Method != http.MethodDelete || del.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected DELETE event: %+v", del)
+	}
+}
+
+func TestClient_Audit_RecordsRefusedRequests(t *testing.T) {
+	client := createTestClientWithURL(t, "http://127.0.0.1:0")
+	client.SetReadOnly(true)
+
+	var events []AuditEvent
+	client.SetAudit(func(e AuditEvent) {
+		events = append(events, e)
+	})
+
+	client.PostJSON(context.Background(), "/trade-api/v2/portfolio/orders", map[string]int{"count": 1}, nil)
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(events))
+	}
+	if !errors.Is(events[0].Err, ErrReadOnly) || events[0].StatusCode != 0 {
+		t.Errorf("expected refused event without status, got status=%d err=%v", events[0].StatusCode, events[0].Err)
+	}
+}
@@ -15,21 +15,37 @@ import (
 
 // Signer handles RSA signature generation for Kalshi API authentication
 type Signer struct {
-	apiKeyID   string
-	privateKey *rsa.PrivateKey
+	apiKeyID  string
+	key       crypto.Signer
+	publicKey *rsa.PublicKey
 }
 
 // NewSigner creates a new signer with the given API key ID and private key
 func NewSigner(apiKeyID string, privateKey *rsa.PrivateKey) (*Signer, error) {
+	if privateKey == nil {
+		return nil, errors.New("private key is required")
+	}
+	return NewSignerFromCrypto(apiKeyID, privateKey)
+}
+
+// NewSignerFromCrypto creates a signer backed by any crypto.Signer holding an
+// RSA key, such as a hardware token whose private key never leaves the device.
+// The key must support RSA-PSS signing via *rsa.PSSOptions.
+func NewSignerFromCrypto(apiKeyID string, key crypto.Signer) (*Signer, error) {
 	if apiKeyID == "" {
 		return nil, errors.New("API key ID is required")
 	}
-	if privateKey == nil {
+	if key == nil {
 		return nil, errors.New("private key is required")
 	}
+	publicKey, ok := key.Public().(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA")
+	}
 	return &Signer{
-		apiKeyID:   apiKeyID,
-		privateKey: privateKey,
+		apiKeyID:  apiKeyID,
+		key:       key,
+		publicKey: publicKey,
 	}, nil
 }
 
@@ -72,6 +88,11 @@ func (s *Signer) APIKeyID() string {
 	return s.apiKeyID
 }
 
+// PublicKey returns the RSA public key matching the signing key
+func (s *Signer) PublicKey() *rsa.PublicKey {
+	return s.publicKey
+}
+
 // Sign generates a signature for the given request parameters.
 // Uses RSA-PSS with SHA-256 and millisecond Unix timestamp, matching Kalshi's API spec.
 func (s *Signer) Sign(timestamp time.Time, method, path string) (string, error) {
@@ -83,8 +104,9 @@ func (s *Signer) Sign(timestamp time.Time, method, path string) (string, error)
 	h.Write(msgBytes)
 	hashed := h.Sum(nil)
 
-	signature, err := rsa.SignPSS(rand.Reader, s.privateKey, crypto.SHA256, hashed, &rsa.PSSOptions{
+	signature, err := s.key.Sign(rand.Reader, hashed, &rsa.PSSOptions{
 		SaltLength: rsa.PSSSaltLengthEqualsHash,
+		Hash:       crypto.SHA256,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to sign message: %w", err)
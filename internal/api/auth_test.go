@@ -1,9 +1,14 @@
 package api
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"strconv"
 	"testing"
@@ -58,6 +63,51 @@ func TestNewSignerFromPEM_InvalidPEM(t *testing.T) {
 	}
 }
 
+// opaqueSigner hides the concrete key type, like a hardware-backed crypto.Signer
+type opaqueSigner struct {
+	crypto.Signer
+}
+
+func TestNewSignerFromCrypto(t *testing.T) {
+	privateKey, err := generateTestKey()
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+
+	signer, err := NewSignerFromCrypto("test-api-key-id", opaqueSigner{privateKey})
+	if err != nil {
+		t.Fatalf("NewSignerFromCrypto failed: %v", err)
+	}
+
+	ts := time.Now()
+	signature, err := signer.Sign(ts, "GET", "/trade-api/v2/portfolio/balance")
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		t.Fatalf("failed to decode signature: %v", err)
+	}
+	hashed := sha256.Sum256([]byte(BuildAuthMessage(ts, "GET", "/trade-api/v2/portfolio/balance")))
+	if err := rsa.VerifyPSS(&privateKey.PublicKey, crypto.SHA256, hashed[:], sigBytes, &rsa.PSSOptions{
+		SaltLength: rsa.PSSSaltLengthEqualsHash,
+	}); err != nil {
+		t.Errorf("signature verification failed: %v", err)
+	}
+}
+
+func TestNewSignerFromCrypto_RejectsNonRSA(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+
+	if _, err := NewSignerFromCrypto("test-api-key-id", ecKey); err == nil {
+		t.Fatal("expected error for non-RSA key")
+	}
+}
+
 func TestSign(t *testing.T) {
 	privateKey, err := generateTestKey()
 	if err != nil {
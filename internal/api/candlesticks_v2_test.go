@@ -137,6 +137,9 @@ func TestGetCandlesticks_RealV2Format(t *testing.T) {
 	if c.OpenInterest != 500 {
 		t.Errorf("expected OpenInterest=500, got %d", c.OpenInterest)
 	}
+	if c.YesBid != 46 || c.YesAsk != 48 {
+		t.Errorf("expected closing YesBid/YesAsk=46/48, got %d/%d", c.YesBid, c.YesAsk)
+	}
 
 	// Verify timestamp parsed from end_period_ts (unix seconds)
 	expectedTime := time.Unix(1704067200, 0).UTC()
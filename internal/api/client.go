@@ -3,12 +3,14 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"math"
+	"io"
+	"net"
 	"net/http"
 	"strings"
 	"net/url"
-	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/6missedcalls/kalshi-cli/internal/config"
@@ -34,6 +36,20 @@ type Client struct {
 	signer  *Signer
 	baseURL string
 	timeout time.Duration
+	metrics *Metrics
+
+	readOnly     bool
+	strictDecode bool
+	audit        AuditFunc
+
+	requestLog io.Writer
+
+	retry   RetryPolicy
+	limiter *RateLimiter
+
+	credentialErr error
+
+	signPool atomic.Pointer[SignPool]
 }
 
 // ClientOption is a functional option for configuring the client (legacy support)
@@ -61,23 +77,46 @@ func NewClientLegacy(signer *Signer, opts ...ClientOption) *Client {
 	client.resty.SetTimeout(defaultTimeout)
 	client.resty.SetHeader("Content-Type", "application/json")
 	client.resty.SetHeader("Accept", "application/json")
+	client.resty.SetJSONUnmarshaler(client.decodeJSON)
+
+	// Tag every call with an ID for logs, audit entries and errors
+	client.resty.OnBeforeRequest(client.tagRequest)
+
+	// Refuse writes before anything is signed or counted
+	client.resty.OnBeforeRequest(client.checkReadOnly)
+
+	// Refuse account endpoints when there are no credentials to sign with
+	client.resty.OnBeforeRequest(client.checkCredentials)
+
+	// Queue behind the rate limiter before signing, so the timestamp is fresh
+	client.resty.OnBeforeRequest(client.waitRateLimit)
+	client.resty.OnAfterResponse(client.observeRateLimit)
+
+	// Count requests for usage statistics before signing, so a request the
+	// budget refuses does not use up a presigned signature
+	client.resty.OnBeforeRequest(client.countRequest)
+	client.resty.OnAfterResponse(client.countResponse)
+	client.resty.OnError(client.countError)
 
 	// Add request signing middleware
 	client.resty.OnBeforeRequest(client.signRequest)
 
-	// Add retry configuration for rate limiting with exponential backoff
-	client.resty.SetRetryCount(maxRetries)
-	client.resty.SetRetryWaitTime(baseRetryDelay)
-	client.resty.SetRetryMaxWaitTime(maxRetryDelay)
-	client.resty.AddRetryCondition(func(resp *resty.Response, err error) bool {
-		if err != nil {
-			return true
-		}
-		return IsRateLimitError(resp.StatusCode()) || IsServerError(resp.StatusCode())
-	})
-	client.resty.SetRetryAfter(func(c *resty.Client, resp *resty.Response) (time.Duration, error) {
-		return calculateBackoff(resp)
+	// Report mutating requests to the audit hook
+	client.resty.OnSuccess(client.auditSuccess)
+	client.resty.OnError(client.auditError)
+
+	// Log each attempt when a request log is set
+	client.resty.OnAfterResponse(client.logResponse)
+	client.resty.OnError(client.logError)
+
+	// Retry 429s, and 5xx and network errors where safe, with jittered
+	// exponential backoff
+	client.SetRetryPolicy(DefaultRetryPolicy())
+	client.resty.AddRetryCondition(shouldRetry)
+	client.resty.SetRetryAfter(func(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+		return client.retryDelay(resp), nil
 	})
+	client.resty.AddRetryHook(client.logRetry)
 
 	// Apply options
 	for _, opt := range opts {
@@ -88,7 +127,7 @@ func NewClientLegacy(signer *Signer, opts ...ClientOption) *Client {
 }
 
 // NewClient creates a new API client
-func NewClient(cfg *config.Config, signer *Signer) *Client {
+func NewClient(cfg *config.Config, signer *Signer, opts ...ClientOption) *Client {
 	baseURL := config.DemoBaseURL
 	timeout := defaultTimeout
 
@@ -110,27 +149,106 @@ func NewClient(cfg *config.Config, signer *Signer) *Client {
 	client.resty.SetTimeout(timeout)
 	client.resty.SetHeader("Content-Type", "application/json")
 	client.resty.SetHeader("Accept", "application/json")
+	if cfg != nil {
+		// Gateway headers; signing still covers only the canonical Kalshi path
+		client.resty.SetHeaders(cfg.ExtraHeaders)
+	}
+	client.resty.SetJSONUnmarshaler(client.decodeJSON)
+
+	// Tag every call with an ID for logs, audit entries and errors
+	client.resty.OnBeforeRequest(client.tagRequest)
+
+	// Refuse writes before anything is signed or counted
+	client.resty.OnBeforeRequest(client.checkReadOnly)
+
+	// Refuse account endpoints when there are no credentials to sign with
+	client.resty.OnBeforeRequest(client.checkCredentials)
+
+	// Queue behind the rate limiter before signing, so the timestamp is fresh
+	client.resty.OnBeforeRequest(client.waitRateLimit)
+	client.resty.OnAfterResponse(client.observeRateLimit)
+
+	// Count requests for usage statistics before signing, so a request the
+	// budget refuses does not use up a presigned signature
+	client.resty.OnBeforeRequest(client.countRequest)
+	client.resty.OnAfterResponse(client.countResponse)
+	client.resty.OnError(client.countError)
 
 	// Add request signing middleware
 	client.resty.OnBeforeRequest(client.signRequest)
 
-	// Add retry configuration for rate limiting with exponential backoff
-	client.resty.SetRetryCount(maxRetries)
-	client.resty.SetRetryWaitTime(baseRetryDelay)
-	client.resty.SetRetryMaxWaitTime(maxRetryDelay)
-	client.resty.AddRetryCondition(func(resp *resty.Response, err error) bool {
-		if err != nil {
-			return true
-		}
-		return IsRateLimitError(resp.StatusCode()) || IsServerError(resp.StatusCode())
-	})
-	client.resty.SetRetryAfter(func(c *resty.Client, resp *resty.Response) (time.Duration, error) {
-		return calculateBackoff(resp)
+	// Report mutating requests to the audit hook
+	client.resty.OnSuccess(client.auditSuccess)
+	client.resty.OnError(client.auditError)
+
+	// Log each attempt when a request log is set
+	client.resty.OnAfterResponse(client.logResponse)
+	client.resty.OnError(client.logError)
+
+	// Retry 429s, and 5xx and network errors where safe, with jittered
+	// exponential backoff
+	client.SetRetryPolicy(DefaultRetryPolicy())
+	client.resty.AddRetryCondition(shouldRetry)
+	client.resty.SetRetryAfter(func(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+		return client.retryDelay(resp), nil
 	})
+	client.resty.AddRetryHook(client.logRetry)
+
+	for _, opt := range opts {
+		opt(client)
+	}
 
 	return client
 }
 
+// shouldRetry reports whether a request is retried. Reads, PUTs and DELETEs
+// are retried on a 429, a 5xx or a network error. POSTs and PATCHes create
+// orders and change counts, and a 5xx or a dropped connection can come after
+// the exchange has acted on them, so they are retried only on a 429 or when
+// they never reached the server.
+func shouldRetry(resp *resty.Response, err error) bool {
+	idempotent := resp != nil && resp.Request != nil && isIdempotent(resp.Request.Method)
+	if err != nil {
+		// Without a response the request failed in a hook (signing,
+		// credentials, read-only) and would fail the same way again
+		if resp == nil || isPermanentError(err) {
+			return false
+		}
+		return idempotent || notSent(err)
+	}
+	if IsRateLimitError(resp.StatusCode()) {
+		return true
+	}
+	return idempotent && IsServerError(resp.StatusCode())
+}
+
+// isIdempotent reports whether sending a request of method twice has the
+// same effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch:
+		return false
+	}
+	return true
+}
+
+// notSent reports whether err happened before the request reached the
+// server, so it cannot have been acted on
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// isPermanentError reports whether err was raised locally and must not be retried
+func isPermanentError(err error) bool {
+	var schemaErr *SchemaError
+	return errors.Is(err, ErrRequestBudgetExceeded) || errors.Is(err, ErrReadOnly) || errors.Is(err, ErrNotAuthenticated) || errors.As(err, &schemaErr)
+}
+
 // signRequest adds authentication headers to requests
 func (c *Client) signRequest(client *resty.Client, req *resty.Request) error {
 	if c.signer == nil {
@@ -145,7 +263,17 @@ func (c *Client) signRequest(client *resty.Client, req *resty.Request) error {
 		path = path[:idx]
 	}
 
-	// Kalshi signs: timestamp_ms + METHOD + path (NO body)
+	// Kalshi signs: timestamp_ms + METHOD + path (NO body), so a signature
+	// presigned for the same method and path will do
+	if pool := c.signPool.Load(); pool != nil {
+		if s, ok := pool.take(req.Method, path); ok {
+			req.SetHeader(headerTimestamp, TimestampHeader(s.timestamp))
+			req.SetHeader(headerAccessKey, c.signer.APIKeyID())
+			req.SetHeader(headerSignature, s.signature)
+			return nil
+		}
+	}
+
 	signature, err := c.signer.Sign(timestamp, req.Method, path)
 	if err != nil {
 		return fmt.Errorf("failed to sign request: %w", err)
@@ -158,26 +286,6 @@ func (c *Client) signRequest(client *resty.Client, req *resty.Request) error {
 	return nil
 }
 
-// calculateBackoff determines the retry delay using exponential backoff
-func calculateBackoff(resp *resty.Response) (time.Duration, error) {
-	// Check for Retry-After header
-	if retryAfter := resp.Header().Get("Retry-After"); retryAfter != "" {
-		seconds, err := strconv.Atoi(retryAfter)
-		if err == nil && seconds > 0 {
-			return time.Duration(seconds) * time.Second, nil
-		}
-	}
-
-	// Use exponential backoff
-	attempt := resp.Request.Attempt
-	delay := float64(baseRetryDelay) * math.Pow(retryMultiplier, float64(attempt-1))
-	if delay > float64(maxRetryDelay) {
-		delay = float64(maxRetryDelay)
-	}
-
-	return time.Duration(delay), nil
-}
-
 // BaseURL returns the base URL of the API
 func (c *Client) BaseURL() string {
 	return c.baseURL
@@ -261,14 +369,19 @@ type APIError struct {
 	Code       string `json:"code"`
 	Message    string `json:"message"`
 	StatusCode int    `json:"-"`
+	RequestID  string `json:"-"`
 }
 
 // Error implements the error interface
 func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API error [%d]: %s", e.StatusCode, e.Message)
 	if e.Code != "" {
-		return fmt.Sprintf("API error [%d] %s: %s", e.StatusCode, e.Code, e.Message)
+		msg = fmt.Sprintf("API error [%d] %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
 	}
-	return fmt.Sprintf("API error [%d]: %s", e.StatusCode, e.Message)
+	return msg
 }
 
 // ParseAPIError extracts an APIError from a response
@@ -283,10 +396,12 @@ func ParseAPIError(resp *resty.Response) *APIError {
 			Code:       "UNKNOWN",
 			Message:    string(resp.Body()),
 			StatusCode: resp.StatusCode(),
+			RequestID:  RequestID(resp.Request),
 		}
 	}
 
 	apiErr.StatusCode = resp.StatusCode()
+	apiErr.RequestID = RequestID(resp.Request)
 	return &apiErr
 }
 
@@ -300,6 +415,12 @@ func IsServerError(statusCode int) bool {
 	return statusCode >= 500 && statusCode < 600
 }
 
+// IsNotFound reports whether err is an API error with a 404 status
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
 // BuildQueryString builds a query string from a map of parameters
 func BuildQueryString(params map[string]string) string {
 	if len(params) == 0 {
@@ -351,6 +472,9 @@ func (c *Client) DoRequest(ctx context.Context, method, path string, body interf
 	}
 
 	if err != nil {
+		if id := RequestID(req); id != "" {
+			return fmt.Errorf("request failed (request id %s): %w", id, err)
+		}
 		return fmt.Errorf("request failed: %w", err)
 	}
 
@@ -402,11 +526,20 @@ type apiKeysResponse struct {
 	APIKeys []APIKey `json:"api_keys"`
 }
 
-// CreateAPIKeyRequest is the request to create an API key
+// CreateAPIKeyRequest is the request to create an API key. Without Scopes
+// the key has full access; without ExpiresTime it does not expire.
 type CreateAPIKeyRequest struct {
-	Name string `json:"name,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Scopes      []string  `json:"scopes,omitempty"`
+	ExpiresTime *JSONTime `json:"expires_time,omitempty"`
 }
 
+// API key scopes accepted by CreateAPIKeyRequest
+const (
+	ScopeRead  = "read"
+	ScopeTrade = "trade"
+)
+
 // CreateAPIKeyResponse is the response from creating an API key
 type CreateAPIKeyResponse struct {
 	APIKey     APIKey `json:"api_key"`
@@ -493,6 +626,9 @@ func (t *JSONTime) UnmarshalJSON(data []byte) error {
 	if s == "null" || s == `""` {
 		return nil
 	}
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return fmt.Errorf("invalid time %s: want a quoted string", s)
+	}
 	s = s[1 : len(s)-1]
 
 	parsed, err := time.Parse(time.RFC3339, s)
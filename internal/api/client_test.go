@@ -7,6 +7,8 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -326,7 +328,7 @@ func TestClient_SigningExcludesQueryParams(t *testing.T) {
 	hashed := h.Sum(nil)
 
 	// Verify: if signing excluded query params, this should succeed
-	err = rsa.VerifyPSS(&signer.privateKey.PublicKey, crypto.SHA256, hashed, sigBytes, &rsa.PSSOptions{
+	err = rsa.VerifyPSS(signer.PublicKey(), crypto.SHA256, hashed, sigBytes, &rsa.PSSOptions{
 		SaltLength: rsa.PSSSaltLengthEqualsHash,
 	})
 	if err != nil {
@@ -541,6 +543,19 @@ func TestIsServerError(t *testing.T) {
 	}
 }
 
+func TestIsNotFound(t *testing.T) {
+	notFound := &APIError{StatusCode: http.StatusNotFound, Message: "market not found"}
+	if !IsNotFound(notFound) {
+		t.Error("expected a 404 APIError to be not found")
+	}
+	if !IsNotFound(fmt.Errorf("failed to get market: %w", notFound)) {
+		t.Error("expected a wrapped 404 APIError to be not found")
+	}
+	if IsNotFound(&APIError{StatusCode: http.StatusBadRequest}) || IsNotFound(errors.New("boom")) || IsNotFound(nil) {
+		t.Error("expected other errors not to be not found")
+	}
+}
+
 // Helper functions
 
 func createTestSigner(t *testing.T) *Signer {
@@ -590,3 +605,40 @@ func createTestClientWithURLAndSigner(t *testing.T, baseURL string, signer *Sign
 
 	return client
 }
+
+func TestNewClient_GatewayBaseURLAndHeaders(t *testing.T) {
+	signer := createTestSigner(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gw/kalshi/trade-api/v2/exchange/status" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.Header.Get("X-Gateway-Token"); got != "secret" {
+			t.Errorf("X-Gateway-Token = %q", got)
+		}
+
+		// The signature covers the canonical Kalshi path, not the gateway path
+		ts, _ := strconv.ParseInt(r.Header.Get(headerTimestamp), 10, 64)
+		message := BuildAuthMessage(time.UnixMilli(ts), r.Method, "/trade-api/v2/exchange/status")
+		hashed := crypto.SHA256.New()
+		hashed.Write([]byte(message))
+		sig, _ := base64.StdEncoding.DecodeString(r.Header.Get(headerSignature))
+		if err := rsa.VerifyPSS(signer.PublicKey(), crypto.SHA256, hashed.Sum(nil), sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			t.Errorf("signature does not cover the canonical path: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]bool{"exchange_active": true})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		API:          config.APIConfig{BaseURL: server.URL + "/gw/kalshi/"},
+		ExtraHeaders: map[string]string{"x-gateway-token": "secret"},
+	}
+	client := NewClient(cfg, signer)
+
+	if _, err := client.GetExchangeStatus(context.Background()); err != nil {
+		t.Fatalf("GetExchangeStatus: %v", err)
+	}
+}
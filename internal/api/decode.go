@@ -0,0 +1,313 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// maxSchemaIssues caps the differences listed in a SchemaError
+const maxSchemaIssues = 10
+
+// SchemaError is returned under strict decoding when a response does not
+// match the types kalshi-cli expects, e.g. because the API has changed
+type SchemaError struct {
+	Issues []string
+}
+
+func (e *SchemaError) Error() string {
+	msg := "response does not match the expected schema: " + strings.Join(e.Issues, "; ")
+	if len(e.Issues) == maxSchemaIssues {
+		msg += "; ..."
+	}
+	return msg
+}
+
+// SetStrictDecode makes responses with fields kalshi-cli does not know, or
+// with values of an unexpected type, fail with a SchemaError. By default
+// unknown fields are kept or ignored and a mistyped value is left empty.
+func (c *Client) SetStrictDecode(strict bool) {
+	c.strictDecode = strict
+}
+
+// StrictDecode reports whether strict decoding is on
+func (c *Client) StrictDecode() bool {
+	return c.strictDecode
+}
+
+// decodeJSON decodes every JSON response body
+func (c *Client) decodeJSON(data []byte, v interface{}) error {
+	return DecodeJSON(data, v, c.strictDecode)
+}
+
+// DecodeJSON decodes data into v. When strict, data must match v exactly;
+// otherwise a value of the wrong type is left at its zero value and the rest
+// of the response is still decoded.
+func DecodeJSON(data []byte, v interface{}, strict bool) error {
+	if strict {
+		if issues := SchemaIssues(data, v); len(issues) > 0 {
+			return &SchemaError{Issues: issues}
+		}
+	}
+	err := json.Unmarshal(data, v)
+	var typeErr *json.UnmarshalTypeError
+	if !strict && errors.As(err, &typeErr) {
+		return nil
+	}
+	return err
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	extraType       = reflect.TypeOf(models.Extra{})
+)
+
+// schemaOverrides are fields whose custom decoding accepts more than their
+// declared type; their values are not checked
+var schemaOverrides = map[reflect.Type]map[string]bool{
+	// markets is a list of tickers, or of market objects with nested markets
+	reflect.TypeOf(models.Event{}): {"markets": true},
+}
+
+// SchemaIssues lists how data differs from the type of v: fields v does not
+// declare and values of the wrong JSON type, by path, e.g.
+// "markets[0].yes_bid: got string, want number". Malformed JSON is left for
+// the decoder to report.
+func SchemaIssues(data []byte, v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil
+	}
+	s := &schemaCheck{}
+	s.check(value, reflect.TypeOf(v), "")
+	return s.issues
+}
+
+type schemaCheck struct {
+	issues []string
+}
+
+func (s *schemaCheck) add(path, format string, args ...interface{}) {
+	if len(s.issues) >= maxSchemaIssues {
+		return
+	}
+	if path == "" {
+		path = "(response)"
+	}
+	s.issues = append(s.issues, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (s *schemaCheck) check(value interface{}, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if value == nil {
+		return
+	}
+
+	switch {
+	case t == timeType:
+		str, ok := value.(string)
+		if !ok {
+			s.add(path, "got %s, want time string", jsonKind(value))
+		} else if _, err := time.Parse(time.RFC3339, str); err != nil {
+			s.add(path, "invalid time %q", str)
+		}
+		return
+	case t == rawMessageType, t.Kind() == reflect.Interface:
+		return
+	case reflect.PointerTo(t).Implements(unmarshalerType) && !hasExtra(t):
+		// Custom wire formats are checked by their own decoder
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			s.add(path, "got %s, want object", jsonKind(value))
+			return
+		}
+		fields := jsonFields(t)
+		for _, name := range sortedKeys(obj) {
+			f, ok := lookupField(fields, name)
+			if !ok {
+				s.add(joinPath(path, name), "unknown field")
+				continue
+			}
+			if schemaOverrides[t][f.name] {
+				continue
+			}
+			if f.quoted {
+				if _, isString := obj[name].(string); !isString && obj[name] != nil {
+					s.add(joinPath(path, name), "got %s, want quoted value", jsonKind(obj[name]))
+				}
+				continue
+			}
+			s.check(obj[name], f.typ, joinPath(path, name))
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := value.(string); !ok {
+				s.add(path, "got %s, want base64 string", jsonKind(value))
+			}
+			return
+		}
+		list, ok := value.([]interface{})
+		if !ok {
+			s.add(path, "got %s, want array", jsonKind(value))
+			return
+		}
+		for i, item := range list {
+			s.check(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			s.add(path, "got %s, want object", jsonKind(value))
+			return
+		}
+		for _, name := range sortedKeys(obj) {
+			s.check(obj[name], t.Elem(), joinPath(path, name))
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			s.add(path, "got %s, want string", jsonKind(value))
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			s.add(path, "got %s, want boolean", jsonKind(value))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := value.(json.Number)
+		if !ok {
+			s.add(path, "got %s, want integer", jsonKind(value))
+		} else if _, err := n.Int64(); err != nil {
+			s.add(path, "got %s, want integer", n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			s.add(path, "got %s, want number", jsonKind(value))
+		}
+	}
+}
+
+// schemaField is a struct field as seen by encoding/json
+type schemaField struct {
+	name   string
+	typ    reflect.Type
+	quoted bool
+}
+
+// jsonFields returns the JSON fields of struct type t, including those of
+// embedded structs, keyed by name
+func jsonFields(t reflect.Type) map[string]schemaField {
+	fields := make(map[string]schemaField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			et := f.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				for n, ef := range jsonFields(et) {
+					if _, ok := fields[n]; !ok {
+						fields[n] = ef
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() || f.Type == extraType {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = schemaField{name: name, typ: f.Type, quoted: strings.Contains(","+opts+",", ",string,")}
+	}
+	return fields
+}
+
+// lookupField finds a field by name, falling back to a case-insensitive
+// match as encoding/json does
+func lookupField(fields map[string]schemaField, name string) (schemaField, bool) {
+	if f, ok := fields[name]; ok {
+		return f, true
+	}
+	for n, f := range fields {
+		if strings.EqualFold(n, name) {
+			return f, true
+		}
+	}
+	return schemaField{}, false
+}
+
+// hasExtra reports whether struct type t keeps unknown fields in an Extra
+// field, so its declared fields can be checked despite its own decoder
+func hasExtra(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type == extraType {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
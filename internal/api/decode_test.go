@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// decodeTargets are the response types the corpus in testdata/responses is
+// decoded into, by file name
+var decodeTargets = map[string]func() interface{}{
+	"markets.json":       func() interface{} { return &models.MarketsResponse{} },
+	"events_nested.json": func() interface{} { return &models.EventsResponse{} },
+	"orders.json":        func() interface{} { return &models.OrdersResponse{} },
+	"fills.json":         func() interface{} { return &models.FillsResponse{} },
+	"trades.json":        func() interface{} { return &models.TradesResponse{} },
+}
+
+func readCorpus(t testing.TB) map[string][]byte {
+	t.Helper()
+	corpus := make(map[string][]byte)
+	for name := range decodeTargets {
+		data, err := os.ReadFile(filepath.Join("testdata", "responses", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		corpus[name] = data
+	}
+	return corpus
+}
+
+func TestDecodeCorpusStrict(t *testing.T) {
+	for name, data := range readCorpus(t) {
+		if err := DecodeJSON(data, decodeTargets[name](), true); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestDecodeJSONDrift(t *testing.T) {
+	data := []byte(`{"markets":[{"ticker":"A","yes_bid":"41","new_field":1},{"ticker":"B","yes_bid":40}],"cursor":"","extra_top":true}`)
+
+	var lenient models.MarketsResponse
+	if err := DecodeJSON(data, &lenient, false); err != nil {
+		t.Fatalf("lenient decode failed: %v", err)
+	}
+	if len(lenient.Markets) != 2 || lenient.Markets[0].Ticker != "A" || lenient.Markets[0].YesBid != 0 || lenient.Markets[1].YesBid != 40 {
+		t.Errorf("lenient decode = %+v", lenient.Markets)
+	}
+	if _, ok := lenient.Markets[0].Extra["new_field"]; !ok {
+		t.Error("lenient decode should keep unknown market fields in Extra")
+	}
+
+	var strict models.MarketsResponse
+	err := DecodeJSON(data, &strict, true)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("strict decode error = %v, want SchemaError", err)
+	}
+	want := []string{
+		"extra_top: unknown field",
+		"markets[0].new_field: unknown field",
+		"markets[0].yes_bid: got string, want integer",
+	}
+	for _, w := range want {
+		found := false
+		for _, issue := range schemaErr.Issues {
+			found = found || issue == w
+		}
+		if !found {
+			t.Errorf("issues %q missing %q", schemaErr.Issues, w)
+		}
+	}
+}
+
+func TestSchemaIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		v    interface{}
+		want string
+	}{
+		{"float for int", `{"count":1.5}`, &models.Trade{}, "count: got 1.5, want integer"},
+		{"bad time", `{"created_time":"yesterday"}`, &models.Trade{}, `created_time: invalid time "yesterday"`},
+		{"object for array", `{"trades":{}}`, &models.TradesResponse{}, "trades: got object, want array"},
+		{"wrong root", `[]`, &models.TradesResponse{}, "(response): got array, want object"},
+		{"nested market", `{"events":[{"markets":[{"ticker":"A"}]}]}`, &models.EventsResponse{}, ""},
+		{"null pointer", `{"expiration_time":null}`, &models.Order{}, ""},
+		{"case-insensitive", `{"Ticker":"A"}`, &models.Trade{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := SchemaIssues([]byte(tt.data), tt.v)
+			got := strings.Join(issues, "; ")
+			if got != tt.want {
+				t.Errorf("issues = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrictDecodeClient(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"markets":[{"ticker":"A","renamed_field":1}],"cursor":""}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil)
+	client.SetBaseURL(server.URL)
+	if _, err := client.ListMarkets(context.Background(), ListMarketsParams{}); err != nil {
+		t.Fatalf("lenient client: %v", err)
+	}
+
+	client.SetStrictDecode(true)
+	calls.Store(0)
+	_, err := client.ListMarkets(context.Background(), ListMarketsParams{})
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("strict client error = %v, want SchemaError", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("schema errors should not be retried, got %d calls", n)
+	}
+}
+
+func TestJSONTimeMalformed(t *testing.T) {
+	for _, data := range []string{`1`, `"`, `{}`, `"not a time"`} {
+		var jt JSONTime
+		if err := jt.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("%s should fail", data)
+		}
+	}
+}
+
+// FuzzDecodeJSON decodes arbitrary bodies into every response type, seeded
+// with the corpus. Decoding must never panic, and a body accepted strictly
+// must be accepted leniently.
+func FuzzDecodeJSON(f *testing.F) {
+	for _, data := range readCorpus(f) {
+		f.Add(data)
+	}
+	f.Add([]byte(`{"markets":[{"ticker":1}]}`))
+	f.Add([]byte(`{"events":[{"markets":"x"}]}`))
+	f.Add([]byte(`{"created_time":"2026-01-01T00:00:00Z","expiration_time":1}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for name, target := range decodeTargets {
+			strictErr := DecodeJSON(data, target(), true)
+			lenientErr := DecodeJSON(data, target(), false)
+			if strictErr == nil && lenientErr != nil {
+				t.Errorf("%s: strict accepted what lenient rejected: %v", name, lenientErr)
+			}
+		}
+		var jt JSONTime
+		_ = jt.UnmarshalJSON(data)
+	})
+}
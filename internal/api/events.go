@@ -10,9 +10,13 @@ import (
 
 // ListEventsParams contains parameters for listing events
 type ListEventsParams struct {
-	Status string
-	Limit  int
-	Cursor string
+	Status            string
+	Limit             int
+	Cursor            string
+	SeriesTicker      string
+	WithNestedMarkets bool
+	MinCloseTs        int64
+	MaxCloseTs        int64
 }
 
 // CandlesticksParams contains parameters for getting candlesticks
@@ -51,6 +55,18 @@ func (c *Client) ListEvents(ctx context.Context, params ListEventsParams) ([]mod
 	if params.Cursor != "" {
 		queryParams["cursor"] = params.Cursor
 	}
+	if params.SeriesTicker != "" {
+		queryParams["series_ticker"] = params.SeriesTicker
+	}
+	if params.WithNestedMarkets {
+		queryParams["with_nested_markets"] = "true"
+	}
+	if params.MinCloseTs > 0 {
+		queryParams["min_close_ts"] = strconv.FormatInt(params.MinCloseTs, 10)
+	}
+	if params.MaxCloseTs > 0 {
+		queryParams["max_close_ts"] = strconv.FormatInt(params.MaxCloseTs, 10)
+	}
 
 	path := TradeAPIPrefix + "/events" + BuildQueryString(queryParams)
 
@@ -125,6 +125,45 @@ func TestListEvents(t *testing.T) {
 	}
 }
 
+func TestListEvents_SeriesAndNestedMarkets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		want := map[string]string{
+			"series_ticker":       "INXD",
+			"with_nested_markets": "true",
+			"min_close_ts":        "1767225600",
+			"max_close_ts":        "1767312000",
+		}
+		for key, value := range want {
+			if got := q.Get(key); got != value {
+				t.Errorf("expected %s=%s, got %q", key, value, got)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"events":[{"event_ticker":"INXD-26JAN01","markets":[{"ticker":"INXD-26JAN01-B1","yes_bid":40},{"ticker":"INXD-26JAN01-B2","yes_bid":55}]}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	events, _, err := client.ListEvents(context.Background(), ListEventsParams{
+		SeriesTicker:      "INXD",
+		WithNestedMarkets: true,
+		MinCloseTs:        1767225600,
+		MaxCloseTs:        1767312000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || len(events[0].NestedMarkets) != 2 {
+		t.Fatalf("expected 1 event with 2 nested markets, got %+v", events)
+	}
+	e := events[0]
+	if e.NestedMarkets[1].YesBid != 55 || len(e.Markets) != 2 || e.Markets[0] != "INXD-26JAN01-B1" {
+		t.Errorf("unexpected nested markets: %+v / %v", e.NestedMarkets, e.Markets)
+	}
+}
+
 func TestGetEvent(t *testing.T) {
 	tests := []struct {
 		name           string
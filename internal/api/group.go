@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultGroupLimit caps how many calls a group runs at once when the client
+// has no rate limiter
+const defaultGroupLimit = 8
+
+// CallError is the failure of one named call in a Group
+type CallError struct {
+	Name string
+	Err  error
+}
+
+func (e *CallError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Name, e.Err)
+}
+
+func (e *CallError) Unwrap() error {
+	return e.Err
+}
+
+// Errors are the failed calls of a Group, in the order the calls were started
+type Errors []*CallError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ce := range e {
+		msgs[i] = ce.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Err returns e as an error, or nil when no call failed
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Get returns the error of the call named name, or nil if it succeeded
+func (e Errors) Get(name string) error {
+	for _, ce := range e {
+		if ce.Name == name {
+			return ce.Err
+		}
+	}
+	return nil
+}
+
+// Map returns each failed call's message by name, for JSON output
+func (e Errors) Map() map[string]string {
+	if len(e) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(e))
+	for _, ce := range e {
+		m[ce.Name] = ce.Err.Error()
+	}
+	return m
+}
+
+// Group runs named calls concurrently for commands that fan out over several
+// endpoints. Unlike an errgroup, one failure does not cancel the others:
+// every call runs to completion, and Wait returns the failures by name so a
+// command can show what it did fetch. Calls wait for a slot before they
+// start, so a per-call timeout does not run down while queued.
+type Group struct {
+	ctx   context.Context
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	mu   sync.Mutex
+	next int
+	errs map[int]*CallError
+}
+
+// NewGroup returns a group running up to limit calls at once (one when limit
+// is zero or less), each given ctx
+func NewGroup(ctx context.Context, limit int) *Group {
+	return &Group{
+		ctx:   ctx,
+		slots: make(chan struct{}, max(limit, 1)),
+		errs:  make(map[int]*CallError),
+	}
+}
+
+// Group returns a group for calls made with c. It runs as many calls at once
+// as the rate limiter's burst allows, so calls are not left holding a slot
+// while they queue behind the limiter.
+func (c *Client) Group(ctx context.Context) *Group {
+	limit := defaultGroupLimit
+	if c.limiter != nil {
+		limit = c.limiter.Burst()
+	}
+	return NewGroup(ctx, limit)
+}
+
+// Go runs fn under name. fn may itself call Go to start a follow-up call. A
+// call that is still waiting for a slot when the
+// group's context is done fails with the context's error without running.
+func (g *Group) Go(name string, fn func(ctx context.Context) error) {
+	g.mu.Lock()
+	id := g.next
+	g.next++
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		select {
+		case g.slots <- struct{}{}:
+		case <-g.ctx.Done():
+			g.fail(id, name, g.ctx.Err())
+			return
+		}
+		defer func() { <-g.slots }()
+		if err := g.ctx.Err(); err != nil {
+			g.fail(id, name, err)
+			return
+		}
+		if err := fn(g.ctx); err != nil {
+			g.fail(id, name, err)
+		}
+	}()
+}
+
+func (g *Group) fail(id int, name string, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.errs[id] = &CallError{Name: name, Err: err}
+}
+
+// Wait waits for every call and returns those that failed, or nil
+func (g *Group) Wait() Errors {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.errs) == 0 {
+		return nil
+	}
+	errs := make(Errors, 0, len(g.errs))
+	for id := 0; id < g.next; id++ {
+		if ce, ok := g.errs[id]; ok {
+			errs = append(errs, ce)
+		}
+	}
+	return errs
+}
+
+// Collect runs fn for each key on g, named by its key, and waits for the
+// group. It returns the results of the calls that succeeded by key, and the
+// failures.
+func Collect[T any](g *Group, keys []string, fn func(ctx context.Context, key string) (T, error)) (map[string]T, Errors) {
+	var mu sync.Mutex
+	results := make(map[string]T, len(keys))
+	for _, key := range keys {
+		g.Go(key, func(ctx context.Context) error {
+			v, err := fn(ctx, key)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			results[key] = v
+			return nil
+		})
+	}
+	errs := g.Wait()
+	return results, errs
+}
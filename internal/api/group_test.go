@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_CollectsPartialResults(t *testing.T) {
+	g := NewGroup(context.Background(), 2)
+	var running, peak atomic.Int32
+	keys := []string{"A", "B", "C", "D", "E"}
+	results, errs := Collect(g, keys, func(ctx context.Context, key string) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if key == "B" || key == "D" {
+			return 0, errors.New("boom")
+		}
+		return len(key), nil
+	})
+
+	if len(results) != 3 || results["A"] != 1 || results["E"] != 1 {
+		t.Errorf("results = %v, want A, C and E", results)
+	}
+	if len(errs) != 2 || errs[0].Name != "B" || errs[1].Name != "D" {
+		t.Fatalf("errs = %v, want B then D", errs)
+	}
+	if errs.Get("D") == nil || errs.Get("A") != nil {
+		t.Errorf("Get(D) = %v, Get(A) = %v", errs.Get("D"), errs.Get("A"))
+	}
+	if m := errs.Map(); m["B"] != "boom" {
+		t.Errorf("Map() = %v", m)
+	}
+	if errs.Err() == nil || Errors(nil).Err() != nil {
+		t.Errorf("Err() should be nil only without failures")
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("ran %d calls at once, want at most 2", p)
+	}
+}
+
+func TestGroup_FollowUpCallsAndCancel(t *testing.T) {
+	g := NewGroup(context.Background(), 1)
+	var second atomic.Bool
+	g.Go("first", func(ctx context.Context) error {
+		g.Go("second", func(ctx context.Context) error {
+			second.Store(true)
+			return nil
+		})
+		return nil
+	})
+	if errs := g.Wait(); errs != nil || !second.Load() {
+		t.Errorf("Wait() = %v, second ran = %v", errs, second.Load())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g = NewGroup(ctx, 1)
+	release := make(chan struct{})
+	g.Go("slow", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	var ran atomic.Bool
+	g.Go("queued", func(ctx context.Context) error {
+		ran.Store(true)
+		return nil
+	})
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	errs := g.Wait()
+	if ran.Load() || !errors.Is(errs.Get("queued"), context.Canceled) {
+		t.Errorf("queued call ran = %v, err = %v; want it skipped as canceled", ran.Load(), errs.Get("queued"))
+	}
+}
+
+func TestClient_GroupUsesLimiterBurst(t *testing.T) {
+	c := NewClientLegacy(nil, WithRateLimit(10, 3))
+	if got := cap(c.Group(context.Background()).slots); got != 3 {
+		t.Errorf("group slots = %d, want the burst of 3", got)
+	}
+	c.SetRateLimiter(nil)
+	if got := cap(c.Group(context.Background()).slots); got != defaultGroupLimit {
+		t.Errorf("group slots = %d, want %d without a limiter", got, defaultGroupLimit)
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// historyPageSize is the page size used when following every page of fills
+// or settlements
+const historyPageSize = 200
+
+// windowsPerWorker splits a history pull into more windows than workers, so
+// a worker that drew a quiet window moves on to another one
+const windowsPerWorker = 4
+
+// PageFunc is told about each page fetched, with the number of items it held
+// and the cursor to the next. It is never called concurrently.
+type PageFunc func(items int, cursor string)
+
+// HistoryOptions controls how GetAllFills and GetAllSettlements page
+type HistoryOptions struct {
+	// Concurrency is the number of time windows fetched at once. Values
+	// above 1 need a lower time bound to split on.
+	Concurrency int
+	// OnPage, if set, is told about each page
+	OnPage PageFunc
+}
+
+// timeWindow is an inclusive range of Unix seconds
+type timeWindow struct {
+	min, max int64
+}
+
+// partitionWindows splits [from, to] into up to n windows of whole seconds,
+// newest first, that together cover it without overlapping
+func partitionWindows(from, to int64, n int) []timeWindow {
+	span := to - from + 1
+	if n < 1 || span <= 0 {
+		return nil
+	}
+	if int64(n) > span {
+		n = int(span)
+	}
+	windows := make([]timeWindow, n)
+	hi := to
+	for i := range windows {
+		// Spread the remainder over the newest windows
+		width := span / int64(n)
+		if int64(i) < span%int64(n) {
+			width++
+		}
+		windows[i] = timeWindow{min: hi - width + 1, max: hi}
+		hi -= width
+	}
+	return windows
+}
+
+// fetchHistory pages every item in [from, to], splitting the range into
+// windows paged by up to opts.Concurrency workers. Items come back newest
+// first, as the API returns them, with duplicates by key removed. Zero
+// bounds are left open; without a lower bound there is nothing to split on
+// and the range is paged in one go.
+func fetchHistory[T any](ctx context.Context, from, to int64, opts HistoryOptions, key func(T) string,
+	page func(ctx context.Context, w timeWindow, cursor string) ([]T, string, error)) ([]T, error) {
+	windows := []timeWindow{{min: from, max: to}}
+	if opts.Concurrency > 1 && from > 0 {
+		if to == 0 {
+			to = time.Now().Unix()
+		}
+		if split := partitionWindows(from, to, opts.Concurrency*windowsPerWorker); len(split) > 0 {
+			windows = split
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+	onPage := func(items int, cursor string) {
+		if opts.OnPage == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		opts.OnPage(items, cursor)
+	}
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	results := make([][]T, len(windows))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range max(min(opts.Concurrency, len(windows)), 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				var cursor string
+				for {
+					items, nextCursor, err := page(ctx, windows[i], cursor)
+					if err != nil {
+						fail(err)
+						return
+					}
+					results[i] = append(results[i], items...)
+					onPage(len(items), nextCursor)
+					if nextCursor == "" || len(items) == 0 {
+						break
+					}
+					cursor = nextCursor
+				}
+			}
+		}()
+	}
+feed:
+	for i := range windows {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var merged []T
+	seen := make(map[string]bool)
+	for _, items := range results {
+		for _, item := range items {
+			k := key(item)
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			merged = append(merged, item)
+		}
+	}
+	return merged, nil
+}
+
+// GetAllFills follows every page of fills matching opts, newest first. With
+// a MinTS and a Concurrency above 1 the time range is split into windows
+// fetched concurrently, then merged in order with duplicates removed.
+func (c *Client) GetAllFills(ctx context.Context, opts FillsOptions, history HistoryOptions) ([]models.Fill, error) {
+	if opts.Limit == 0 {
+		opts.Limit = historyPageSize
+	}
+	key := func(f models.Fill) string {
+		if f.TradeID != "" {
+			return f.TradeID
+		}
+		return f.OrderID + "|" + f.CreatedTime.String() + "|" + strconv.Itoa(f.Count)
+	}
+	return fetchHistory(ctx, opts.MinTS, opts.MaxTS, history, key,
+		func(ctx context.Context, w timeWindow, cursor string) ([]models.Fill, string, error) {
+			pageOpts := opts
+			pageOpts.MinTS, pageOpts.MaxTS, pageOpts.Cursor = w.min, w.max, cursor
+			resp, err := c.GetFills(ctx, pageOpts)
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Fills, resp.Cursor, nil
+		})
+}
+
+// GetAllSettlements follows every page of settlements matching opts, newest
+// first, splitting the time range like GetAllFills
+func (c *Client) GetAllSettlements(ctx context.Context, opts SettlementsOptions, history HistoryOptions) ([]models.Settlement, error) {
+	if opts.Limit == 0 {
+		opts.Limit = historyPageSize
+	}
+	key := func(s models.Settlement) string {
+		return s.Ticker + "|" + s.SettledTime.String()
+	}
+	return fetchHistory(ctx, opts.MinTS, opts.MaxTS, history, key,
+		func(ctx context.Context, w timeWindow, cursor string) ([]models.Settlement, string, error) {
+			pageOpts := opts
+			pageOpts.MinTS, pageOpts.MaxTS, pageOpts.Cursor = w.min, w.max, cursor
+			resp, err := c.GetSettlements(ctx, pageOpts)
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Settlements, resp.Cursor, nil
+		})
+}
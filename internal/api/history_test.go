@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestPartitionWindows(t *testing.T) {
+	windows := partitionWindows(100, 109, 3)
+	want := []timeWindow{{106, 109}, {103, 105}, {100, 102}}
+	if fmt.Sprint(windows) != fmt.Sprint(want) {
+		t.Errorf("windows = %v, want %v", windows, want)
+	}
+	if got := partitionWindows(100, 101, 8); len(got) != 2 {
+		t.Errorf("windows = %v, want one per second", got)
+	}
+	if got := partitionWindows(100, 99, 4); got != nil {
+		t.Errorf("windows = %v, want none for an empty range", got)
+	}
+}
+
+// fillServer serves one fill per second in [from, to], newest first, in
+// pages of two, repeating the last fill of a window in the next one's page
+// the way overlapping bounds would
+func fillServer(t *testing.T, from, to int64, requests *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		q := r.URL.Query()
+		lo, _ := strconv.ParseInt(q.Get("min_ts"), 10, 64)
+		hi, _ := strconv.ParseInt(q.Get("max_ts"), 10, 64)
+		if lo == 0 {
+			lo = from
+		}
+		if hi == 0 || hi > to {
+			hi = to
+		}
+		if lo > from {
+			lo-- // overlap into the next window
+		}
+		offset, _ := strconv.Atoi(q.Get("cursor"))
+
+		var fills []models.Fill
+		for ts := hi - int64(offset); ts >= lo && len(fills) < 2; ts-- {
+			fills = append(fills, models.Fill{TradeID: fmt.Sprintf("t%d", ts), CreatedTime: time.Unix(ts, 0)})
+		}
+		cursor := ""
+		if hi-int64(offset)-int64(len(fills)) >= lo {
+			cursor = strconv.Itoa(offset + len(fills))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.FillsResponse{Fills: fills, Cursor: cursor})
+	}))
+}
+
+func TestGetAllFills_ConcurrentMatchesSequential(t *testing.T) {
+	var requests int32
+	server := fillServer(t, 1000, 1039, &requests)
+	defer server.Close()
+	client := createTestClientWithURL(t, server.URL)
+	opts := FillsOptions{MinTS: 1000, MaxTS: 1039}
+
+	sequential, err := client.GetAllFills(context.Background(), opts, HistoryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pages int
+	concurrent, err := client.GetAllFills(context.Background(), opts, HistoryOptions{
+		Concurrency: 3,
+		OnPage:      func(items int, cursor string) { pages++ },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sequential) != 40 || len(concurrent) != 40 {
+		t.Fatalf("got %d sequential and %d concurrent fills, want 40 each", len(sequential), len(concurrent))
+	}
+	for i := range concurrent {
+		if concurrent[i].TradeID != sequential[i].TradeID {
+			t.Fatalf("fill %d = %s, want %s", i, concurrent[i].TradeID, sequential[i].TradeID)
+		}
+	}
+	if concurrent[0].TradeID != "t1039" || concurrent[39].TradeID != "t1000" {
+		t.Errorf("not newest first: %s ... %s", concurrent[0].TradeID, concurrent[39].TradeID)
+	}
+	if pages < 12 {
+		t.Errorf("pages = %d, want every window paged", pages)
+	}
+}
+
+func TestGetAllFills_NoLowerBoundIsSequential(t *testing.T) {
+	var minTS []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		minTS = append(minTS, r.URL.Query().Get("min_ts"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.FillsResponse{Fills: []models.Fill{{TradeID: "a"}}})
+	}))
+	defer server.Close()
+	client := createTestClientWithURL(t, server.URL)
+
+	fills, err := client.GetAllFills(context.Background(), FillsOptions{OrderID: "o1"}, HistoryOptions{Concurrency: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != 1 || len(minTS) != 1 || minTS[0] != "" {
+		t.Errorf("fills = %v, min_ts sent = %q", fills, minTS)
+	}
+}
+
+func TestGetAllSettlements_ErrorStopsWorkers(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"bad_request","message":"nope"}`))
+	}))
+	defer server.Close()
+	client := createTestClientWithURL(t, server.URL)
+
+	_, err := client.GetAllSettlements(context.Background(), SettlementsOptions{MinTS: 1000, MaxTS: 100000}, HistoryOptions{Concurrency: 4})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an API error", err)
+	}
+	if got := atomic.LoadInt32(&requests); got > 4 {
+		t.Errorf("requests = %d, want the remaining windows skipped", got)
+	}
+}
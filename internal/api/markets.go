@@ -189,9 +189,11 @@ func periodToInterval(period string) string {
 
 // ListSeriesParams contains parameters for listing series
 type ListSeriesParams struct {
-	Category string
-	Limit    int
-	Cursor   string
+	Category               string
+	Tags                   []string
+	IncludeProductMetadata bool
+	Limit                  int
+	Cursor                 string
 }
 
 // ListSeries retrieves a list of series
@@ -201,6 +203,12 @@ func (c *Client) ListSeries(ctx context.Context, params ListSeriesParams) (*mode
 	if params.Category != "" {
 		queryParams["category"] = params.Category
 	}
+	if len(params.Tags) > 0 {
+		queryParams["tags"] = strings.Join(params.Tags, ",")
+	}
+	if params.IncludeProductMetadata {
+		queryParams["include_product_metadata"] = "true"
+	}
 	if params.Limit > 0 {
 		queryParams["limit"] = strconv.Itoa(params.Limit)
 	}
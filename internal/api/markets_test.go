@@ -556,6 +556,18 @@ func TestListSeries(t *testing.T) {
 			wantErr:      false,
 			wantCount:    1,
 		},
+		{
+			name:   "returns series with tags and product metadata",
+			params: ListSeriesParams{Tags: []string{"Fed", "Rates"}, IncludeProductMetadata: true},
+			serverResponse: models.SeriesResponse{
+				Series: []models.Series{
+					{Ticker: "FED-RATES", FeeType: models.FeeTypeQuadraticWithMakerFees, FeeMultiplier: 1, ProductMetadata: map[string]interface{}{"unit": "bps"}},
+				},
+			},
+			serverStatus: http.StatusOK,
+			wantErr:      false,
+			wantCount:    1,
+		},
 		{
 			name:           "handles server error",
 			params:         ListSeriesParams{},
@@ -578,6 +590,18 @@ func TestListSeries(t *testing.T) {
 					}
 				}
 
+				if len(tt.params.Tags) > 0 {
+					if got := r.URL.Query().Get("tags"); got != "Fed,Rates" {
+						t.Errorf("expected tags=Fed,Rates, got %s", got)
+					}
+				}
+
+				if tt.params.IncludeProductMetadata {
+					if got := r.URL.Query().Get("include_product_metadata"); got != "true" {
+						t.Errorf("expected include_product_metadata=true, got %s", got)
+					}
+				}
+
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(tt.serverStatus)
 				if tt.serverStatus == http.StatusOK {
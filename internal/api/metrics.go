@@ -0,0 +1,126 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrRequestBudgetExceeded is returned when a request would exceed the Metrics limit
+var ErrRequestBudgetExceeded = errors.New("request budget exceeded")
+
+// Metrics counts HTTP activity issued by one or more clients.
+// It is safe for concurrent use and may be shared between clients.
+type Metrics struct {
+	requests    atomic.Int64
+	retries     atomic.Int64
+	rateLimited atomic.Int64
+	errors      atomic.Int64
+	limit       atomic.Int64
+
+	// Unix nanoseconds of the last successful and unauthorized responses
+	lastSuccess      atomic.Int64
+	lastUnauthorized atomic.Int64
+}
+
+// SetLimit caps the number of HTTP requests (including retries) that
+// clients sharing this collector may issue. Zero disables the cap.
+func (m *Metrics) SetLimit(n int64) {
+	m.limit.Store(n)
+}
+
+// MetricsSnapshot is a point-in-time copy of Metrics counters
+type MetricsSnapshot struct {
+	Requests    int64 `json:"requests"`
+	Retries     int64 `json:"retries"`
+	RateLimited int64 `json:"rate_limited"`
+	Errors      int64 `json:"errors"`
+}
+
+// Snapshot returns the current counter values
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	return MetricsSnapshot{
+		Requests:    m.requests.Load(),
+		Retries:     m.retries.Load(),
+		RateLimited: m.rateLimited.Load(),
+		Errors:      m.errors.Load(),
+	}
+}
+
+// LastSuccess returns when a response last succeeded, or the zero time
+func (m *Metrics) LastSuccess() time.Time {
+	return unixNanoTime(m.lastSuccess.Load())
+}
+
+// LastUnauthorized returns when a response was last 401 Unauthorized, or the
+// zero time
+func (m *Metrics) LastUnauthorized() time.Time {
+	return unixNanoTime(m.lastUnauthorized.Load())
+}
+
+func unixNanoTime(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+// SetMetrics attaches a metrics collector to the client.
+// Every HTTP attempt (including retries) is counted.
+func (c *Client) SetMetrics(m *Metrics) {
+	c.metrics = m
+}
+
+// Metrics returns the attached metrics collector, or nil
+func (c *Client) Metrics() *Metrics {
+	return c.metrics
+}
+
+// countRequest records an outgoing HTTP attempt, refusing it once the limit is reached
+func (c *Client) countRequest(_ *resty.Client, _ *resty.Request) error {
+	if c.metrics == nil {
+		return nil
+	}
+
+	n := c.metrics.requests.Add(1)
+	if limit := c.metrics.limit.Load(); limit > 0 && n > limit {
+		c.metrics.requests.Add(-1)
+		return fmt.Errorf("%w: limit of %d HTTP requests reached", ErrRequestBudgetExceeded, limit)
+	}
+	return nil
+}
+
+// countResponse records rate-limit and error responses
+func (c *Client) countResponse(_ *resty.Client, resp *resty.Response) error {
+	if c.metrics == nil {
+		return nil
+	}
+	if isRateLimited(resp) {
+		c.metrics.rateLimited.Add(1)
+	}
+	if resp.StatusCode() >= 400 {
+		c.metrics.errors.Add(1)
+	} else {
+		c.metrics.lastSuccess.Store(time.Now().UnixNano())
+	}
+	if resp.StatusCode() == http.StatusUnauthorized {
+		c.metrics.lastUnauthorized.Store(time.Now().UnixNano())
+	}
+	return nil
+}
+
+// countError records requests that failed without an HTTP response
+func (c *Client) countError(_ *resty.Request, err error) {
+	if c.metrics == nil {
+		return
+	}
+	if respErr, ok := err.(*resty.ResponseError); ok && respErr.Response != nil && respErr.Response.RawResponse != nil {
+		// Already counted by countResponse
+		return
+	}
+	c.metrics.errors.Add(1)
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_Metrics_CountsRequestsAndErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/limited":
+			// Rate limit the first attempt, then succeed
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"not_found","message":"missing"}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	metrics := &Metrics{}
+	client.SetMetrics(metrics)
+
+	ctx := context.Background()
+	if err := client.GetJSON(ctx, "/ok", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.GetJSON(ctx, "/limited", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.GetJSON(ctx, "/missing", nil); err == nil {
+		t.Fatal("expected error for 404")
+	}
+
+	snap := metrics.Snapshot()
+	if snap.Requests != 4 {
+		t.Errorf("expected 4 requests (including retry), got %d", snap.Requests)
+	}
+	if snap.RateLimited != 1 {
+		t.Errorf("expected 1 rate-limited response, got %d", snap.RateLimited)
+	}
+	if snap.Errors != 2 {
+		t.Errorf("expected 2 error responses, got %d", snap.Errors)
+	}
+}
+
+func TestClient_Metrics_NilIsSafe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	if client.Metrics() != nil {
+		t.Fatal("expected no metrics by default")
+	}
+	if err := client.GetJSON(context.Background(), "/ok", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_Metrics_LimitAbortsRequests(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	metrics := &Metrics{}
+	metrics.SetLimit(2)
+	client.SetMetrics(metrics)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := client.GetJSON(ctx, "/ok", nil); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	err := client.GetJSON(ctx, "/ok", nil)
+	if !errors.Is(err, ErrRequestBudgetExceeded) {
+		t.Fatalf("expected ErrRequestBudgetExceeded, got %v", err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected server to see 2 requests, got %d", hits.Load())
+	}
+	if got := metrics.Snapshot().Requests; got != 2 {
+		t.Errorf("expected 2 counted requests, got %d", got)
+	}
+}
+
+func TestClient_Metrics_LastSuccessAndUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/denied" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":"unauthorized","message":"bad key"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	metrics := &Metrics{}
+	client.SetMetrics(metrics)
+	if !metrics.LastSuccess().IsZero() || !metrics.LastUnauthorized().IsZero() {
+		t.Fatal("expected zero times before any request")
+	}
+
+	ctx := context.Background()
+	if err := client.GetJSON(ctx, "/ok", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metrics.LastSuccess().IsZero() {
+		t.Error("expected LastSuccess after a 200")
+	}
+	if !metrics.LastUnauthorized().IsZero() {
+		t.Error("expected no LastUnauthorized after a 200")
+	}
+
+	if err := client.GetJSON(ctx, "/denied", nil); err == nil {
+		t.Fatal("expected error for 401")
+	}
+	if metrics.LastUnauthorized().IsZero() {
+		t.Error("expected LastUnauthorized after a 401")
+	}
+}
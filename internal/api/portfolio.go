@@ -89,6 +89,8 @@ func (o FillsOptions) toQueryParams() map[string]string {
 type SettlementsOptions struct {
 	Cursor       string
 	Limit        int
+	MinTS        int64
+	MaxTS        int64
 	SubaccountID int
 }
 
@@ -101,6 +103,12 @@ func (o SettlementsOptions) toQueryParams() map[string]string {
 	if o.Limit > 0 {
 		params["limit"] = strconv.Itoa(o.Limit)
 	}
+	if o.MinTS > 0 {
+		params["min_ts"] = strconv.FormatInt(o.MinTS, 10)
+	}
+	if o.MaxTS > 0 {
+		params["max_ts"] = strconv.FormatInt(o.MaxTS, 10)
+	}
 	if o.SubaccountID > 0 {
 		params["subaccount_id"] = strconv.Itoa(o.SubaccountID)
 	}
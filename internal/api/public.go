@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrNotAuthenticated is returned when a client without credentials calls an
+// endpoint that requires them
+var ErrNotAuthenticated = errors.New("credentials required")
+
+// authenticatedPrefixes are the endpoints, under TradeAPIPrefix, that require
+// credentials even to read
+var authenticatedPrefixes = []string{"/portfolio", "/communications", "/api-keys", "/account"}
+
+// PathRequiresAuth returns true if a request of method to path requires
+// credentials. Market data is public to read; account data and every write
+// are not.
+func PathRequiresAuth(method, path string) bool {
+	if method != http.MethodGet && method != http.MethodHead {
+		return true
+	}
+	if i := strings.Index(path, "?"); i != -1 {
+		path = path[:i]
+	}
+	path = strings.TrimPrefix(path, TradeAPIPrefix)
+	for _, prefix := range authenticatedPrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCredentialError makes a client without a signer refuse endpoints that
+// require credentials before anything is sent, reporting err as the reason
+// it has none. Public endpoints are still called unsigned.
+func (c *Client) SetCredentialError(err error) {
+	c.credentialErr = err
+}
+
+// checkCredentials refuses requests that require credentials the client
+// lacks
+func (c *Client) checkCredentials(_ *resty.Client, req *resty.Request) error {
+	if c.signer != nil || c.credentialErr == nil || !PathRequiresAuth(req.Method, req.URL) {
+		return nil
+	}
+	return fmt.Errorf("%w for %s %s: %v", ErrNotAuthenticated, req.Method, req.URL, c.credentialErr)
+}
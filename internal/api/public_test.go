@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPathRequiresAuth(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodGet, "/trade-api/v2/markets?limit=5", false},
+		{http.MethodGet, "/trade-api/v2/events/KXA", false},
+		{http.MethodGet, "/trade-api/v2/series/KXBTC/markets/KXA/candlesticks", false},
+		{http.MethodGet, "/trade-api/v2/exchange/status", false},
+		{http.MethodGet, "/trade-api/v2/portfolio/balance", true},
+		{http.MethodGet, "/trade-api/v2/communications/rfqs", true},
+		{http.MethodGet, "/trade-api/v2/api-keys", true},
+		{http.MethodGet, "/trade-api/v2/account/api-limits", true},
+		{http.MethodGet, "/trade-api/v2/portfolios-of-the-week", false},
+		{http.MethodPost, "/trade-api/v2/multivariate-collections/KXC", true},
+	}
+	for _, tt := range tests {
+		if got := PathRequiresAuth(tt.method, tt.path); got != tt.want {
+			t.Errorf("PathRequiresAuth(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestClient_WithoutCredentials(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.Header.Get(headerSignature) != "" {
+			t.Errorf("request %s was signed without a signer", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil)
+	client.SetBaseURL(server.URL)
+	client.SetCredentialError(errors.New("not logged in"))
+
+	ctx := context.Background()
+	if err := client.GetJSON(ctx, "/trade-api/v2/markets", nil); err != nil {
+		t.Fatalf("public GET failed: %v", err)
+	}
+
+	err := client.GetJSON(ctx, "/trade-api/v2/portfolio/balance", nil)
+	if !errors.Is(err, ErrNotAuthenticated) || !strings.Contains(err.Error(), "not logged in") {
+		t.Fatalf("err = %v, want ErrNotAuthenticated with the reason", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected only the public GET to reach the server (no retries), got %d requests", got)
+	}
+
+	// Without a credential error, as in paper mode, nothing is refused
+	client.SetCredentialError(nil)
+	if err := client.GetJSON(ctx, "/trade-api/v2/portfolio/balance", nil); err != nil {
+		t.Errorf("GET without a credential error failed: %v", err)
+	}
+}
@@ -0,0 +1,379 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryPolicy controls how requests that fail with a 429, a 5xx or a network
+// error are retried
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// BaseDelay is the wait before the first retry; it doubles on each one
+	BaseDelay time.Duration
+	// MaxDelay caps the wait, including waits asked for by Retry-After
+	MaxDelay time.Duration
+	// Jitter is the fraction of each backoff that is randomized, from 0 to 1
+	Jitter float64
+}
+
+// DefaultRetryPolicy is the policy clients use unless WithRetryPolicy is set
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: maxRetries,
+		BaseDelay:  baseRetryDelay,
+		MaxDelay:   maxRetryDelay,
+		Jitter:     0.5,
+	}
+}
+
+// backoff returns the jittered exponential delay before retry attempt n
+// (1 for the first retry). rnd returns a number in [0, 1).
+func (p RetryPolicy) backoff(n int, rnd func() float64) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(retryMultiplier, float64(n-1))
+	if delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	jitter := math.Min(math.Max(p.Jitter, 0), 1)
+	delay *= 1 - jitter + jitter*rnd()
+	return time.Duration(delay)
+}
+
+// WithRetryPolicy sets how failed requests are retried
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.SetRetryPolicy(p)
+	}
+}
+
+// WithRateLimit queues requests through a token bucket allowing perSecond
+// requests with bursts of up to burst. Zero perSecond disables the limit.
+func WithRateLimit(perSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		if perSecond <= 0 {
+			c.SetRateLimiter(nil)
+			return
+		}
+		c.SetRateLimiter(NewRateLimiter(perSecond, burst))
+	}
+}
+
+// SetRetryPolicy sets how failed requests are retried
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+	c.resty.SetRetryCount(max(p.MaxRetries, 0))
+	// Resty raises shorter waits to this, so allow the smallest jittered one
+	c.resty.SetRetryWaitTime(time.Duration(float64(p.BaseDelay) * (1 - math.Min(math.Max(p.Jitter, 0), 1))))
+	c.resty.SetRetryMaxWaitTime(p.MaxDelay)
+}
+
+// RetryPolicy returns how failed requests are retried
+func (c *Client) RetryPolicy() RetryPolicy {
+	return c.retry
+}
+
+// SetRateLimiter queues requests through l, which may be shared between
+// clients; nil removes the limit
+func (c *Client) SetRateLimiter(l *RateLimiter) {
+	c.limiter = l
+}
+
+// Adaptive pacing is AIMD: each rate-limit response halves the rate, at most
+// once per aimdCooldown so a burst of 429s counts once, and each successful
+// response after that adds back aimdIncrease of the configured rate. The
+// rate never drops below aimdFloor of the configured rate.
+const (
+	aimdDecrease = 0.5
+	aimdIncrease = 0.05
+	aimdFloor    = 0.1
+	aimdCooldown = time.Second
+)
+
+// RateLimiter is a token bucket that paces requests. Besides its own rate it
+// honors the server: a 429 or an exhausted rate-limit header pauses every
+// request until the server says the window resets, and rate-limit responses
+// lower the rate until requests succeed again. It is safe for concurrent
+// use.
+type RateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	limit       float64
+	lowest      float64
+	backoffs    int64
+	lastBackoff time.Time
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// NewRateLimiter allows perSecond requests with bursts of up to burst
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	b := math.Max(float64(burst), 1)
+	return &RateLimiter{rate: perSecond, limit: perSecond, lowest: perSecond, burst: b, tokens: b, now: time.Now}
+}
+
+// Burst returns how many requests may be sent at once
+func (l *RateLimiter) Burst() int {
+	return int(l.burst)
+}
+
+// refill adds the tokens earned since the last request; l.mu must be held
+func (l *RateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+}
+
+// reserve takes a token and returns how long to wait before using it
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.refill(now)
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if pause := l.pausedUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+	return wait
+}
+
+// Backoff lowers the rate after a rate-limit response and returns the new
+// rate and whether it changed. Saved-up tokens are dropped so the next
+// requests are spaced at the lower rate rather than sent as a burst.
+func (l *RateLimiter) Backoff() (float64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	floor := l.limit * aimdFloor
+	if now.Sub(l.lastBackoff) < aimdCooldown || l.rate <= floor {
+		return l.rate, false
+	}
+	l.refill(now)
+	l.tokens = math.Min(l.tokens, 0)
+	l.rate = math.Max(l.rate*aimdDecrease, floor)
+	l.lowest = math.Min(l.lowest, l.rate)
+	l.backoffs++
+	l.lastBackoff = now
+	return l.rate, true
+}
+
+// Recover raises the rate after a successful response, up to the configured
+// rate, and returns the new rate and whether it changed
+func (l *RateLimiter) Recover() (float64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if l.rate >= l.limit || now.Sub(l.lastBackoff) < aimdCooldown {
+		return l.rate, false
+	}
+	l.refill(now)
+	l.rate = math.Min(l.rate+l.limit*aimdIncrease, l.limit)
+	return l.rate, true
+}
+
+// Pace is the state of adaptive pacing
+type Pace struct {
+	// Rate is the current requests per second
+	Rate float64 `json:"rate"`
+	// Limit is the configured requests per second the rate recovers to
+	Limit float64 `json:"limit"`
+	// Lowest is the lowest rate reached
+	Lowest float64 `json:"lowest"`
+	// Backoffs counts how often the rate was lowered
+	Backoffs int64 `json:"backoffs"`
+}
+
+// Pace returns the current state of adaptive pacing
+func (l *RateLimiter) Pace() Pace {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return Pace{Rate: l.rate, Limit: l.limit, Lowest: l.lowest, Backoffs: l.backoffs}
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PauseUntil holds every request until t
+func (l *RateLimiter) PauseUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+// waitRateLimit queues a request attempt behind the limiter
+func (c *Client) waitRateLimit(_ *resty.Client, req *resty.Request) error {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.Wait(req.Context())
+}
+
+// observeRateLimit paces the limiter from each response: a rate-limit
+// response lowers the rate and a success raises it again, and the server
+// reporting the window exhausted pauses every request until it resets
+func (c *Client) observeRateLimit(_ *resty.Client, resp *resty.Response) error {
+	if c.limiter == nil {
+		return nil
+	}
+	now := time.Now()
+	if isRateLimited(resp) {
+		if rate, ok := c.limiter.Backoff(); ok {
+			c.logPace(fmt.Sprintf("lowered to %.1f req/s after %d", rate, resp.StatusCode()))
+		}
+		if d, ok := retryAfter(resp.Header(), now); ok {
+			c.limiter.PauseUntil(now.Add(d))
+			return nil
+		}
+	} else if resp.IsSuccess() {
+		if rate, ok := c.limiter.Recover(); ok && rate >= c.limiter.Pace().Limit {
+			c.logPace(fmt.Sprintf("back to %.1f req/s", rate))
+		}
+	}
+	if remaining, reset, ok := rateLimitHeaders(resp.Header(), now); ok && remaining == 0 {
+		c.limiter.PauseUntil(reset)
+	}
+	return nil
+}
+
+// logPace writes a change of pace to the request log
+func (c *Client) logPace(change string) {
+	if c.requestLog != nil {
+		fmt.Fprintf(c.requestLog, "[rate] pace %s\n", change)
+	}
+}
+
+// rateLimitCode is the error code Kalshi sends with rate-limit responses
+const rateLimitCode = "rate_limited"
+
+// isRateLimited reports whether resp is a 429, or an error carrying the
+// rate_limited code under another status
+func isRateLimited(resp *resty.Response) bool {
+	if IsRateLimitError(resp.StatusCode()) {
+		return true
+	}
+	if resp.StatusCode() < 400 {
+		return false
+	}
+	apiErr := ParseAPIError(resp)
+	return apiErr != nil && strings.EqualFold(apiErr.Code, rateLimitCode)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(h.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now), true
+	}
+	return 0, false
+}
+
+// rateLimitHeaders reads the remaining requests and when the window resets
+// from X-RateLimit-* or RateLimit-* headers. The reset may be seconds from
+// now or a Unix timestamp.
+func rateLimitHeaders(h http.Header, now time.Time) (int, time.Time, bool) {
+	remainingValue := firstHeader(h, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	resetValue := firstHeader(h, "X-RateLimit-Reset", "RateLimit-Reset")
+	if remainingValue == "" || resetValue == "" {
+		return 0, time.Time{}, false
+	}
+	remaining, err := strconv.Atoi(remainingValue)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	reset, err := strconv.ParseFloat(resetValue, 64)
+	if err != nil || reset < 0 {
+		return 0, time.Time{}, false
+	}
+	// Anything past 2001 is a timestamp rather than a delay
+	if reset > 1e9 {
+		return remaining, time.Unix(0, int64(reset*float64(time.Second))), true
+	}
+	return remaining, now.Add(time.Duration(reset * float64(time.Second))), true
+}
+
+func firstHeader(h http.Header, names ...string) string {
+	for _, name := range names {
+		if v := strings.TrimSpace(h.Get(name)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// retryDelay is the wait before retrying resp: the server's Retry-After when
+// given, otherwise the policy's jittered backoff, capped at MaxDelay
+func (c *Client) retryDelay(resp *resty.Response) time.Duration {
+	now := time.Now()
+	if d, ok := retryAfter(resp.Header(), now); ok {
+		return min(d, c.retry.MaxDelay)
+	}
+	if remaining, reset, ok := rateLimitHeaders(resp.Header(), now); ok && remaining == 0 && reset.After(now) {
+		return min(reset.Sub(now), c.retry.MaxDelay)
+	}
+	return c.retry.backoff(max(resp.Request.Attempt, 1), rand.Float64)
+}
+
+// logRetry writes each retry to the request log
+func (c *Client) logRetry(resp *resty.Response, err error) {
+	// Resty also calls retry hooks after the last attempt, when nothing follows
+	if resp == nil || resp.Request == nil || resp.Request.Attempt > c.retry.MaxRetries {
+		return
+	}
+	if c.metrics != nil {
+		c.metrics.retries.Add(1)
+	}
+	if c.requestLog == nil {
+		return
+	}
+	reason := ""
+	if err != nil {
+		reason = err.Error()
+	} else {
+		reason = strconv.Itoa(resp.StatusCode())
+	}
+	fmt.Fprintf(c.requestLog, "[request %s] retry %d/%d of %s %s after %s\n",
+		RequestID(resp.Request), resp.Request.Attempt, c.retry.MaxRetries,
+		resp.Request.Method, requestPath(resp.Request.URL), reason)
+}
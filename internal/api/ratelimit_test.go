@@ -0,0 +1,314 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestRetryPolicy_BackoffJitter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}
+
+	if got := p.backoff(1, func() float64 { return 0 }); got != 50*time.Millisecond {
+		t.Errorf("lowest first backoff = %v, want 50ms", got)
+	}
+	if got := p.backoff(2, func() float64 { return 0.999999 }); got < 199*time.Millisecond || got > 200*time.Millisecond {
+		t.Errorf("highest second backoff = %v, want about 200ms", got)
+	}
+	if got := p.backoff(10, func() float64 { return 1 }); got != time.Second {
+		t.Errorf("capped backoff = %v, want 1s", got)
+	}
+
+	p.Jitter = 0
+	if got := p.backoff(3, func() float64 { return 0.3 }); got != 400*time.Millisecond {
+		t.Errorf("unjittered backoff = %v, want 400ms", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"2", 2 * time.Second, true},
+		{"0.5", 500 * time.Millisecond, true},
+		{now.Add(3 * time.Second).Format(http.TimeFormat), 3 * time.Second, true},
+		{"", 0, false},
+		{"soon", 0, false},
+		{"0", 0, false},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		h.Set("Retry-After", tt.value)
+		got, ok := retryAfter(h, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "2")
+	remaining, reset, ok := rateLimitHeaders(h, now)
+	if !ok || remaining != 0 || !reset.Equal(now.Add(2*time.Second)) {
+		t.Errorf("relative reset = %d, %v, %v", remaining, reset, ok)
+	}
+
+	h = http.Header{}
+	h.Set("RateLimit-Remaining", "7")
+	h.Set("RateLimit-Reset", "1772366405")
+	remaining, reset, ok = rateLimitHeaders(h, now)
+	if !ok || remaining != 7 || !reset.Equal(time.Unix(1772366405, 0)) {
+		t.Errorf("timestamp reset = %d, %v, %v", remaining, reset, ok)
+	}
+
+	h = http.Header{}
+	h.Set("X-RateLimit-Remaining", "3")
+	if _, _, ok := rateLimitHeaders(h, now); ok {
+		t.Error("expected no result without a reset header")
+	}
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(10, 2)
+	l.now = func() time.Time { return clock }
+
+	for i := 0; i < 2; i++ {
+		if wait := l.reserve(); wait != 0 {
+			t.Fatalf("burst request %d waited %v", i, wait)
+		}
+	}
+	if wait := l.reserve(); wait != 100*time.Millisecond {
+		t.Errorf("third request waited %v, want 100ms", wait)
+	}
+
+	clock = clock.Add(time.Second)
+	if wait := l.reserve(); wait != 0 {
+		t.Errorf("after refill waited %v, want none", wait)
+	}
+
+	l.PauseUntil(clock.Add(3 * time.Second))
+	if wait := l.reserve(); wait != 3*time.Second {
+		t.Errorf("paused request waited %v, want 3s", wait)
+	}
+}
+
+func TestRateLimiter_AdaptivePacing(t *testing.T) {
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(10, 10)
+	l.now = func() time.Time { return clock }
+
+	if _, ok := l.Recover(); ok {
+		t.Error("the rate should not rise above the limit")
+	}
+	if rate, ok := l.Backoff(); !ok || rate != 5 {
+		t.Errorf("Backoff = %v, %v; want 5", rate, ok)
+	}
+	if wait := l.reserve(); wait != 200*time.Millisecond {
+		t.Errorf("first request after backoff waited %v, want 200ms at the lower rate", wait)
+	}
+	clock = clock.Add(500 * time.Millisecond)
+	if _, ok := l.Backoff(); ok {
+		t.Error("a second 429 within the cooldown should not lower the rate again")
+	}
+	if _, ok := l.Recover(); ok {
+		t.Error("the rate should not rise within the cooldown")
+	}
+
+	for range 5 {
+		clock = clock.Add(time.Second)
+		l.Backoff()
+	}
+	if p := l.Pace(); p.Rate != 1 || p.Lowest != 1 || p.Backoffs != 4 {
+		t.Errorf("pace = %+v, want floored at 1 req/s after 4 backoffs", p)
+	}
+
+	clock = clock.Add(time.Second)
+	var rate float64
+	for range 18 {
+		rate, _ = l.Recover()
+	}
+	if rate != 10 {
+		t.Errorf("rate after 18 successes = %v, want back to 10", rate)
+	}
+	if p := l.Pace(); p.Rate != 10 || p.Lowest != 1 {
+		t.Errorf("pace = %+v", p)
+	}
+}
+
+func TestClient_BacksOffOnRateLimitedCode(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"RATE_LIMITED","message":"too many requests"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(100, 5)
+	client := NewClient(nil, nil, WithRetryPolicy(RetryPolicy{MaxRetries: 0}))
+	client.SetRateLimiter(limiter)
+	client.SetBaseURL(server.URL)
+	var log bytes.Buffer
+	client.SetRequestLog(&log)
+	metrics := &Metrics{}
+	client.SetMetrics(metrics)
+
+	client.Get(context.Background(), "/test")
+	client.Get(context.Background(), "/test")
+
+	if p := limiter.Pace(); p.Rate != 50 || p.Backoffs != 1 {
+		t.Errorf("pace = %+v, want halved once", p)
+	}
+	if !strings.Contains(log.String(), "[rate] pace lowered to 50.0 req/s after 400") {
+		t.Errorf("request log missing pace line:\n%s", log.String())
+	}
+	if got := metrics.Snapshot().RateLimited; got != 1 {
+		t.Errorf("rate limited = %d, want 1", got)
+	}
+}
+
+func TestRateLimiter_WaitHonorsContext(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	l.PauseUntil(time.Now().Add(time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("err = %v, want deadline exceeded", err)
+	}
+}
+
+func TestClient_RetryPolicyLogsRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil,
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+		WithRateLimit(100, 5),
+	)
+	client.SetBaseURL(server.URL)
+	var log bytes.Buffer
+	client.SetRequestLog(&log)
+	metrics := &Metrics{}
+	client.SetMetrics(metrics)
+
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode())
+	}
+	if got := metrics.Snapshot().Retries; got != 1 {
+		t.Errorf("retries = %d, want 1", got)
+	}
+	if !strings.Contains(log.String(), "retry 1/2 of GET /test after 503") {
+		t.Errorf("request log missing retry line:\n%s", log.String())
+	}
+	if client.RetryPolicy().MaxRetries != 2 {
+		t.Errorf("policy = %+v", client.RetryPolicy())
+	}
+}
+
+func TestClient_RetryPolicyStopsAtMaxRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil, WithRetryPolicy(RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}))
+	client.SetBaseURL(server.URL)
+	metrics := &Metrics{}
+	client.SetMetrics(metrics)
+
+	resp, _ := client.Get(context.Background(), "/test")
+	if resp.StatusCode() != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429", resp.StatusCode())
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+	if got := metrics.Snapshot().Retries; got != 1 {
+		t.Errorf("retries = %d, want 1", got)
+	}
+}
+
+func TestClient_DoesNotResendOrdersAfterServerError(t *testing.T) {
+	var posts, gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&posts, 1)
+		} else {
+			atomic.AddInt32(&gets, 1)
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil, WithRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}))
+	client.SetBaseURL(server.URL)
+
+	err := client.PostJSON(context.Background(), "/trade-api/v2/portfolio/orders", map[string]any{"ticker": "KXA"}, nil)
+	if err == nil {
+		t.Fatal("expected the 502 to be returned")
+	}
+	if got := atomic.LoadInt32(&posts); got != 1 {
+		t.Errorf("order POST sent %d times, want once", got)
+	}
+
+	client.Get(context.Background(), "/test")
+	if got := atomic.LoadInt32(&gets); got != 3 {
+		t.Errorf("GET sent %d times, want 3 with retries", got)
+	}
+}
+
+func TestShouldRetry_UnsentWrites(t *testing.T) {
+	dial := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	read := &net.OpError{Op: "read", Err: errors.New("connection reset")}
+	post := &resty.Response{Request: &resty.Request{Method: http.MethodPost}}
+	if !shouldRetry(post, dial) {
+		t.Error("a POST that never connected should be retried")
+	}
+	if shouldRetry(post, read) {
+		t.Error("a POST whose connection dropped after sending should not be retried")
+	}
+	if !shouldRetry(&resty.Response{Request: &resty.Request{Method: http.MethodGet}}, read) {
+		t.Error("a GET should be retried after a network error")
+	}
+}
+
+func TestShouldRetry_HookErrors(t *testing.T) {
+	if shouldRetry(nil, errors.New("failed to sign request")) {
+		t.Error("a request that failed in a hook should not be retried")
+	}
+}
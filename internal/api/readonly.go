@@ -0,0 +1,36 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrReadOnly is returned when a write request is attempted in read-only mode
+var ErrReadOnly = errors.New("read-only mode")
+
+// SetReadOnly makes the client refuse every request that could change
+// account state (POST, PUT, PATCH, DELETE) before it is signed or sent.
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
+// ReadOnly reports whether the client refuses write requests
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkReadOnly rejects write requests when read-only mode is enabled
+func (c *Client) checkReadOnly(_ *resty.Client, req *resty.Request) error {
+	if !c.readOnly {
+		return nil
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+	return fmt.Errorf("%w: refusing %s %s", ErrReadOnly, req.Method, req.URL)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_ReadOnly_RefusesWrites(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	client.SetReadOnly(true)
+
+	ctx := context.Background()
+	if err := client.GetJSON(ctx, "/trade-api/v2/portfolio/balance", nil); err != nil {
+		t.Fatalf("GET should be allowed in read-only mode: %v", err)
+	}
+
+	err := client.PostJSON(ctx, "/trade-api/v2/portfolio/orders", map[string]string{}, nil)
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly for POST, got %v", err)
+	}
+
+	err = client.DoRequest(ctx, http.MethodDelete, "/trade-api/v2/portfolio/orders/abc", nil, nil)
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly for DELETE, got %v", err)
+	}
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected only the GET to reach the server (no retries), got %d requests", got)
+	}
+}
+
+func TestClient_ReadOnly_DisabledByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	if client.ReadOnly() {
+		t.Fatal("expected read-only mode to be disabled by default")
+	}
+	if err := client.PostJSON(context.Background(), "/trade-api/v2/portfolio/orders", map[string]string{}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// headerRequestID carries the client-side request ID. Retries of a call reuse it.
+const headerRequestID = "X-Request-ID"
+
+// SetRequestLog writes one line per HTTP attempt to w, tagged with the request
+// ID. A nil w disables the log.
+func (c *Client) SetRequestLog(w io.Writer) {
+	c.requestLog = w
+}
+
+// newRequestID returns a random 16-character hex ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// tagRequest gives each call an ID, keeping the one set on earlier attempts
+func (c *Client) tagRequest(_ *resty.Client, req *resty.Request) error {
+	if req.Header.Get(headerRequestID) == "" {
+		req.SetHeader(headerRequestID, newRequestID())
+	}
+	return nil
+}
+
+// RequestID returns the client-side ID sent with req, or ""
+func RequestID(req *resty.Request) string {
+	if req == nil {
+		return ""
+	}
+	return req.Header.Get(headerRequestID)
+}
+
+// logResponse writes a completed attempt to the request log
+func (c *Client) logResponse(_ *resty.Client, resp *resty.Response) error {
+	if c.requestLog == nil || resp.Request == nil {
+		return nil
+	}
+	fmt.Fprintf(c.requestLog, "[request %s] %s %s -> %d (%s)\n",
+		RequestID(resp.Request), resp.Request.Method, requestPath(resp.Request.URL),
+		resp.StatusCode(), resp.Time().Round(time.Millisecond))
+	return nil
+}
+
+// logError writes a failed call to the request log
+func (c *Client) logError(req *resty.Request, err error) {
+	if c.requestLog == nil || req == nil {
+		return
+	}
+	fmt.Fprintf(c.requestLog, "[request %s] %s %s failed: %v\n",
+		RequestID(req), req.Method, requestPath(req.URL), err)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_RequestID_SharedAcrossRetriesAndErrors(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(headerRequestID))
+		if len(seen) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"invalid_order","message":"bad price"}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	var events []AuditEvent
+	client.SetAudit(func(e AuditEvent) { events = append(events, e) })
+	var log bytes.Buffer
+	client.SetRequestLog(&log)
+
+	err := client.PostJSON(context.Background(), "/trade-api/v2/portfolio/orders", map[string]int{"count": 1}, nil)
+
+	if len(seen) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(seen))
+	}
+	id := seen[0]
+	if len(id) != 16 || seen[1] != id {
+		t.Fatalf("expected one 16-character ID reused on retry, got %q", seen)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != id {
+		t.Fatalf("expected APIError with request ID %s, got %v", id, err)
+	}
+	if !strings.Contains(err.Error(), "(request id "+id+")") {
+		t.Errorf("expected request ID in error message, got %q", err.Error())
+	}
+	if len(events) != 1 || events[0].RequestID != id {
+		t.Errorf("expected audit event with request ID %s, got %+v", id, events)
+	}
+	if strings.Count(log.String(), "[request "+id+"] POST /trade-api/v2/portfolio/orders") != 2 {
+		t.Errorf("expected both attempts in the request log, got:\n%s", log.String())
+	}
+}
+
+func TestClient_RequestID_UniquePerCall(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(headerRequestID))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURL(t, server.URL)
+	ctx := context.Background()
+	client.GetJSON(ctx, "/trade-api/v2/exchange/status", nil)
+	client.GetJSON(ctx, "/trade-api/v2/exchange/status", nil)
+
+	if len(seen) != 2 || seen[0] == "" || seen[0] == seen[1] {
+		t.Errorf("expected a distinct ID per call, got %q", seen)
+	}
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultPresignMaxAge is how long a presigned signature is used for. Kalshi
+// rejects stale timestamps, so signatures older than this are discarded and
+// the request is signed when it is sent instead.
+const DefaultPresignMaxAge = 2 * time.Second
+
+// presigned is a signature made ahead of the request that will carry it
+type presigned struct {
+	timestamp time.Time
+	signature string
+}
+
+// SignPool signs requests ahead of a burst on several workers at once. Kalshi
+// signs only the timestamp, method and path, never the body, so signatures
+// for one endpoint are interchangeable between requests: a burst of orders
+// can be signed in parallel before the first is sent, instead of each
+// request paying for an RSA signature in turn. It is safe for concurrent use.
+type SignPool struct {
+	signer  *Signer
+	workers int
+	maxAge  time.Duration
+	now     func() time.Time
+
+	mu    sync.Mutex
+	ready map[string][]presigned
+}
+
+// NewSignPool returns a pool signing with signer on up to workers goroutines
+// (GOMAXPROCS when zero or less) whose signatures are used for up to maxAge
+// (DefaultPresignMaxAge when zero or less)
+func NewSignPool(signer *Signer, workers int, maxAge time.Duration) *SignPool {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultPresignMaxAge
+	}
+	return &SignPool{
+		signer:  signer,
+		workers: workers,
+		maxAge:  maxAge,
+		now:     time.Now,
+		ready:   make(map[string][]presigned),
+	}
+}
+
+// signKey identifies the requests a signature is good for
+func signKey(method, path string) string {
+	return method + " " + path
+}
+
+// Presign signs n requests to method and path across the pool's workers.
+// Each signature carries the time it was made, so the ones used last are
+// the freshest. Signatures made before an error are kept.
+func (p *SignPool) Presign(ctx context.Context, method, path string, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	jobs := make(chan struct{})
+	signed := make(chan presigned)
+	errs := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	for range min(p.workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				ts := p.now().UTC()
+				sig, err := p.signer.Sign(ts, method, path)
+				if err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					continue
+				}
+				signed <- presigned{timestamp: ts, signature: sig}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for range n {
+			select {
+			case jobs <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(signed)
+	}()
+
+	var batch []presigned
+	for s := range signed {
+		batch = append(batch, s)
+	}
+	p.add(signKey(method, path), batch)
+
+	select {
+	case err := <-errs:
+		return err
+	default:
+	}
+	return ctx.Err()
+}
+
+// add stores signatures for key, oldest first
+func (p *SignPool) add(key string, batch []presigned) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	all := append(p.ready[key], batch...)
+	sort.SliceStable(all, func(i, j int) bool { return all[i].timestamp.Before(all[j].timestamp) })
+	p.ready[key] = all
+}
+
+// take returns the oldest signature for method and path that is still fresh,
+// discarding stale ones
+func (p *SignPool) take(method, path string) (presigned, bool) {
+	key := signKey(method, path)
+	cutoff := p.now().Add(-p.maxAge)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	queue := p.ready[key]
+	for len(queue) > 0 && queue[0].timestamp.Before(cutoff) {
+		queue = queue[1:]
+	}
+	if len(queue) == 0 {
+		delete(p.ready, key)
+		return presigned{}, false
+	}
+	s := queue[0]
+	p.ready[key] = queue[1:]
+	return s, true
+}
+
+// Len returns the number of signatures held for method and path, fresh or not
+func (p *SignPool) Len(method, path string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.ready[signKey(method, path)])
+}
+
+// WithSignPool signs requests from pool when it holds a fresh signature for
+// them
+func WithSignPool(pool *SignPool) ClientOption {
+	return func(c *Client) {
+		c.signPool.Store(pool)
+	}
+}
+
+// Presign signs n requests to method and path in parallel ahead of a burst,
+// so sending them does not wait on signing. path is the full signed path,
+// e.g. TradeAPIPrefix + "/portfolio/orders". A client without credentials
+// has nothing to sign and returns nil.
+func (c *Client) Presign(ctx context.Context, method, path string, n int) error {
+	if c.signer == nil {
+		return nil
+	}
+	pool := c.signPool.Load()
+	if pool == nil {
+		c.signPool.CompareAndSwap(nil, NewSignPool(c.signer, 0, 0))
+		pool = c.signPool.Load()
+	}
+	return pool.Presign(ctx, method, path, n)
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func verifySignature(t *testing.T, signer *Signer, timestamp, method, path, signature string) {
+	t.Helper()
+	ms, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		t.Fatalf("bad timestamp %q", timestamp)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		t.Fatalf("bad signature %q", signature)
+	}
+	hashed := sha256.Sum256([]byte(BuildAuthMessage(time.UnixMilli(ms), method, path)))
+	opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+	if err := rsa.VerifyPSS(signer.PublicKey(), crypto.SHA256, hashed[:], sig, opts); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+}
+
+func TestSignPool_TakesOldestFreshSignature(t *testing.T) {
+	signer := createTestSigner(t)
+	pool := NewSignPool(signer, 4, time.Second)
+	now := time.Unix(1000, 0)
+	pool.now = func() time.Time { return now }
+
+	pool.add(signKey("POST", "/p"), []presigned{
+		{timestamp: now.Add(-300 * time.Millisecond), signature: "b"},
+		{timestamp: now.Add(-2 * time.Second), signature: "stale"},
+		{timestamp: now.Add(-500 * time.Millisecond), signature: "a"},
+	})
+
+	for _, want := range []string{"a", "b"} {
+		s, ok := pool.take("POST", "/p")
+		if !ok || s.signature != want {
+			t.Errorf("took %q, %v; want %q", s.signature, ok, want)
+		}
+	}
+	if _, ok := pool.take("POST", "/p"); ok {
+		t.Error("expected the pool to be empty")
+	}
+	if _, ok := pool.take("DELETE", "/p"); ok {
+		t.Error("expected no signatures for another method")
+	}
+}
+
+func TestSignPool_PresignSignsInParallel(t *testing.T) {
+	signer := createTestSigner(t)
+	pool := NewSignPool(signer, 4, 0)
+	if err := pool.Presign(context.Background(), "POST", ordersBasePath, 10); err != nil {
+		t.Fatal(err)
+	}
+	if got := pool.Len("POST", ordersBasePath); got != 10 {
+		t.Fatalf("presigned %d, want 10", got)
+	}
+
+	var last time.Time
+	for range 10 {
+		s, ok := pool.take("POST", ordersBasePath)
+		if !ok {
+			t.Fatal("ran out of signatures")
+		}
+		if s.timestamp.Before(last) {
+			t.Error("signatures not handed out oldest first")
+		}
+		last = s.timestamp
+		verifySignature(t, signer, TimestampHeader(s.timestamp), "POST", ordersBasePath, s.signature)
+	}
+}
+
+func TestClient_UsesPresignedSignatures(t *testing.T) {
+	var mu sync.Mutex
+	var timestamps, signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		timestamps = append(timestamps, r.Header.Get(headerTimestamp))
+		signatures = append(signatures, r.Header.Get(headerSignature))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"order": map[string]any{"order_id": "o1"}})
+	}))
+	defer server.Close()
+
+	signer := createTestSigner(t)
+	client := createTestClientWithURLAndSigner(t, server.URL, signer)
+	if err := client.Presign(context.Background(), "POST", ordersBasePath, 2); err != nil {
+		t.Fatal(err)
+	}
+	pool := client.signPool.Load()
+
+	for range 3 {
+		var out map[string]any
+		if err := client.PostJSON(context.Background(), ordersBasePath, map[string]any{}, &out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pool.Len("POST", ordersBasePath) != 0 {
+		t.Error("expected the presigned signatures to be used")
+	}
+	if len(signatures) != 3 {
+		t.Fatalf("got %d requests, want 3", len(signatures))
+	}
+	// The first two came from the pool and the third was signed inline
+	for i := range signatures {
+		verifySignature(t, signer, timestamps[i], "POST", ordersBasePath, signatures[i])
+	}
+}
+
+func TestClient_BudgetRefusalKeepsPresignedSignature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := createTestClientWithURLAndSigner(t, server.URL, createTestSigner(t))
+	metrics := &Metrics{}
+	metrics.SetLimit(1)
+	client.SetMetrics(metrics)
+	if err := client.GetJSON(context.Background(), ordersBasePath, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Presign(context.Background(), "POST", ordersBasePath, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	err := client.PostJSON(context.Background(), ordersBasePath, map[string]any{}, nil)
+	if !errors.Is(err, ErrRequestBudgetExceeded) {
+		t.Fatalf("expected ErrRequestBudgetExceeded, got %v", err)
+	}
+	if got := client.signPool.Load().Len("POST", ordersBasePath); got != 1 {
+		t.Errorf("refused request used a presigned signature (%d left, want 1)", got)
+	}
+}
+
+func TestClient_PresignWithoutSigner(t *testing.T) {
+	client := createTestClientWithURL(t, "http://localhost")
+	if err := client.Presign(context.Background(), "POST", ordersBasePath, 5); err != nil || client.signPool.Load() != nil {
+		t.Errorf("err = %v, want nothing to presign", err)
+	}
+}
+
+// burstSize is the number of signed requests in a benchmarked burst
+const burstSize = 48
+
+func benchmarkSigner(b *testing.B) *Signer {
+	b.Helper()
+	key, err := generateTestKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+	signer, err := NewSigner("bench", key)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return signer
+}
+
+// BenchmarkBurstSign_Inline signs a burst one request at a time, as requests
+// sent back to back are signed
+func BenchmarkBurstSign_Inline(b *testing.B) {
+	signer := benchmarkSigner(b)
+	b.ResetTimer()
+	for range b.N {
+		for range burstSize {
+			if _, err := signer.Sign(time.Now(), "POST", ordersBasePath); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkBurstSign_Pool presigns the same burst on the pool's workers and
+// then takes every signature
+func BenchmarkBurstSign_Pool(b *testing.B) {
+	pool := NewSignPool(benchmarkSigner(b), 0, time.Minute)
+	b.ResetTimer()
+	for range b.N {
+		if err := pool.Presign(context.Background(), "POST", ordersBasePath, burstSize); err != nil {
+			b.Fatal(err)
+		}
+		for range burstSize {
+			if _, ok := pool.take("POST", ordersBasePath); !ok {
+				b.Fatal("ran out of signatures")
+			}
+		}
+	}
+}
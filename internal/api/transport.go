@@ -0,0 +1,72 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportOptions configures how connections reach the API
+type TransportOptions struct {
+	// Proxy is an http, https, socks5 or socks5h URL. Empty uses
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY from the environment.
+	Proxy string
+	// CABundle is a PEM file of root certificates trusted in addition to
+	// the system roots
+	CABundle string
+	// InsecureSkipVerify disables TLS certificate verification
+	InsecureSkipVerify bool
+}
+
+// NewTransport builds an HTTP transport for both REST requests and the
+// WebSocket handshake
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q (expected http, https, socks5 or socks5h)", proxyURL.Scheme)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	} else {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+
+	if opts.CABundle == "" && !opts.InsecureSkipVerify {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.CABundle != "" {
+		pemData, err := os.ReadFile(opts.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", opts.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	tlsConfig.InsecureSkipVerify = opts.InsecureSkipVerify
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// SetTransport replaces the HTTP transport used for API requests
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.resty.SetTransport(transport)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTransportProxy(t *testing.T) {
+	transport, err := NewTransport(TransportOptions{Proxy: "socks5://127.0.0.1:1080"})
+	if err != nil {
+		t.Fatalf("NewTransport: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.elections.kalshi.com", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil || proxy == nil || proxy.String() != "socks5://127.0.0.1:1080" {
+		t.Errorf("proxy = %v, %v", proxy, err)
+	}
+
+	if _, err := NewTransport(TransportOptions{Proxy: "ftp://proxy"}); err == nil {
+		t.Error("expected an error for an unsupported proxy scheme")
+	}
+}
+
+func TestNewTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	transport, err := NewTransport(TransportOptions{CABundle: bundle})
+	if err != nil {
+		t.Fatalf("NewTransport: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with CA bundle failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := (&http.Client{Transport: http.DefaultTransport}).Get(server.URL); err == nil {
+		t.Error("expected the default transport to reject the test certificate")
+	}
+
+	empty := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTransport(TransportOptions{CABundle: empty}); err == nil {
+		t.Error("expected an error for a bundle without certificates")
+	}
+}
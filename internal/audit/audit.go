@@ -0,0 +1,231 @@
+// Package audit keeps a tamper-evident, append-only log of every
+// state-changing API request. Each entry stores the hash of the previous
+// entry, so editing or deleting a line breaks the chain and is detected by Verify.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/filelock"
+)
+
+const fileName = "audit.jsonl"
+
+// tailChunk is how much of the log is read at a time looking for its last line
+const tailChunk = 4096
+
+// Entry is a single audited request
+type Entry struct {
+	Seq         int64           `json:"seq"`
+	Time        time.Time       `json:"time"`
+	Environment string          `json:"environment"`
+	Command     string          `json:"command,omitempty"`
+	APIKeyID    string          `json:"api_key_id,omitempty"`
+	Method      string          `json:"method"`
+	Path        string          `json:"path"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	Status      int             `json:"status,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	RequestID   string          `json:"request_id,omitempty"`
+	PrevHash    string          `json:"prev_hash"`
+	Hash        string          `json:"hash"`
+}
+
+// computeHash returns the hex SHA-256 of the entry with Hash cleared
+func (e Entry) computeHash() (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Log is an append-only, hash-chained JSONL file. The CLI, the daemon and
+// the dead man's switch may append to it at once, so nothing about its end
+// is cached: each append locks the file and reads the last entry afresh.
+type Log struct {
+	path string
+}
+
+// NewLog returns a log stored at path
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// DefaultPath returns the audit log location inside the data directory
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, fileName)
+}
+
+// Path returns the log file location
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append chains e onto the end of the log, assigning Seq, PrevHash, and Hash
+func (l *Log) Append(e Entry) (Entry, error) {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return e, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0600)
+	if err != nil {
+		return e, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if err := filelock.Lock(f); err != nil {
+		return e, err
+	}
+	defer filelock.Unlock(f)
+
+	last, err := lastEntry(f)
+	if err != nil {
+		return e, err
+	}
+
+	e.Time = e.Time.UTC()
+	e.Seq = last.Seq + 1
+	e.PrevHash = last.Hash
+
+	hash, err := e.computeHash()
+	if err != nil {
+		return e, fmt.Errorf("failed to hash audit entry: %w", err)
+	}
+	e.Hash = hash
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return e, fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return e, fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return e, nil
+}
+
+// lastEntry returns the final entry of the log open in f, or the zero entry
+// when it is empty. It reads back from the end only as far as the last line.
+func lastEntry(f *os.File) (Entry, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	var tail []byte
+	for off := info.Size(); off > 0; {
+		n := min(int64(tailChunk), off)
+		off -= n
+		buf := make([]byte, n)
+		if _, err := f.ReadAt(buf, off); err != nil {
+			return Entry{}, fmt.Errorf("failed to read audit log: %w", err)
+		}
+		tail = append(buf, tail...)
+
+		trimmed := bytes.TrimRight(tail, "\n")
+		i := bytes.LastIndexByte(trimmed, '\n')
+		if i < 0 && off > 0 {
+			continue
+		}
+		line := trimmed[i+1:]
+		if len(line) == 0 {
+			return Entry{}, nil
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return Entry{}, fmt.Errorf("last audit log line is malformed: %w", err)
+		}
+		return e, nil
+	}
+	return Entry{}, nil
+}
+
+// Load reads every entry in the log. Unlike usage stats, a malformed line is
+// an error: the audit log must be complete to be trusted.
+func (l *Log) Load() ([]Entry, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("audit log line %d is malformed: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// VerifyResult reports the outcome of a chain verification
+type VerifyResult struct {
+	Entries  int    `json:"entries"`
+	Valid    bool   `json:"valid"`
+	BrokenAt int64  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Verify recomputes every hash and checks that each entry links to its predecessor
+func (l *Log) Verify() (VerifyResult, error) {
+	entries, err := l.Load()
+	if err != nil {
+		return VerifyResult{Valid: false, Reason: err.Error()}, nil
+	}
+
+	result := VerifyResult{Entries: len(entries), Valid: true}
+	prevHash := ""
+	var prevSeq int64
+
+	for _, e := range entries {
+		hash, err := e.computeHash()
+		if err != nil {
+			return result, err
+		}
+
+		var reason string
+		switch {
+		case e.Seq != prevSeq+1:
+			reason = fmt.Sprintf("expected sequence %d, found %d", prevSeq+1, e.Seq)
+		case e.PrevHash != prevHash:
+			reason = "previous-hash link does not match"
+		case e.Hash != hash:
+			reason = "entry hash does not match its contents"
+		}
+
+		if reason != "" {
+			result.Valid = false
+			result.BrokenAt = e.Seq
+			result.Reason = reason
+			return result, nil
+		}
+
+		prevHash = e.Hash
+		prevSeq = e.Seq
+	}
+
+	return result, nil
+}
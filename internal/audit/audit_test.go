@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestLog(t *testing.T) *Log {
+	t.Helper()
+	return NewLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+}
+
+func appendEntries(t *testing.T, log *Log, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		_, err := log.Append(Entry{
+			Time:        time.Now(),
+			Environment: "demo",
+			Method:      "POST",
+			Path:        "/trade-api/v2/portfolio/orders",
+			Body:        json.RawMessage(`{"ticker": "KXTEST", "count": 1}`),
+			Status:      201,
+		})
+		if err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+}
+
+func TestLog_AppendChainsEntries(t *testing.T) {
+	log := newTestLog(t)
+	appendEntries(t, log, 3)
+
+	entries, err := log.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	if entries[0].Seq != 1 || entries[0].PrevHash != "" {
+		t.Errorf("unexpected first entry: seq=%d prev=%q", entries[0].Seq, entries[0].PrevHash)
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].PrevHash != entries[i-1].Hash {
+			t.Errorf("entry %d does not link to its predecessor", entries[i].Seq)
+		}
+	}
+
+	result, err := log.Verify()
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !result.Valid || result.Entries != 3 {
+		t.Errorf("expected valid chain of 3, got %+v", result)
+	}
+}
+
+func TestLog_ContinuesChainAcrossInstances(t *testing.T) {
+	log := newTestLog(t)
+	appendEntries(t, log, 2)
+
+	reopened := NewLog(log.Path())
+	appendEntries(t, reopened, 1)
+
+	result, err := reopened.Verify()
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !result.Valid || result.Entries != 3 {
+		t.Errorf("expected valid chain of 3, got %+v", result)
+	}
+}
+
+func TestLog_ConcurrentWritersKeepChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	// Separate logs stand in for the CLI, the daemon and the dead man's
+	// switch, each with its own handle on the file
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			appendEntries(t, NewLog(path), 10)
+		}()
+	}
+	wg.Wait()
+
+	// An entry longer than one tail read still chains
+	big := NewLog(path)
+	if _, err := big.Append(Entry{Method: "POST", Path: "/x", Body: json.RawMessage(`"` + strings.Repeat("a", 3*tailChunk) + `"`)}); err != nil {
+		t.Fatal(err)
+	}
+	appendEntries(t, NewLog(path), 1)
+
+	result, err := NewLog(path).Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || result.Entries != 42 {
+		t.Errorf("Verify() = %+v, want 42 chained entries", result)
+	}
+}
+
+func TestLog_VerifyDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+		broken int64
+	}{
+		{
+			name: "edited entry",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"count":1`, `"count":100`, 1)
+				return lines
+			},
+			broken: 2,
+		},
+		{
+			name: "deleted entry",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+			broken: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := newTestLog(t)
+			appendEntries(t, log, 3)
+
+			data, err := os.ReadFile(log.Path())
+			if err != nil {
+				t.Fatalf("failed to read log: %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			lines = tt.tamper(lines)
+			if err := os.WriteFile(log.Path(), []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+				t.Fatalf("failed to write log: %v", err)
+			}
+
+			result, err := log.Verify()
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if result.Valid {
+				t.Fatal("expected tampering to be detected")
+			}
+			if result.BrokenAt != tt.broken {
+				t.Errorf("expected break at %d, got %d (%s)", tt.broken, result.BrokenAt, result.Reason)
+			}
+		})
+	}
+}
+
+func TestLog_MissingFile(t *testing.T) {
+	log := newTestLog(t)
+
+	result, err := log.Verify()
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !result.Valid || result.Entries != 0 {
+		t.Errorf("expected empty valid log, got %+v", result)
+	}
+}
@@ -0,0 +1,69 @@
+// Package clipboard places text on the system clipboard through the
+// platform's clipboard tool (pbcopy, clip, wl-copy, xclip or xsel).
+package clipboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// tool is a clipboard command that reads the text from stdin
+type tool struct {
+	name string
+	args []string
+}
+
+// tools lists the clipboard commands to try on goos, most specific first.
+// wayland reports whether a Wayland session is running.
+func tools(goos string, wayland bool) []tool {
+	switch goos {
+	case "darwin":
+		return []tool{{name: "pbcopy"}}
+	case "windows":
+		return []tool{{name: "clip.exe"}}
+	}
+	var list []tool
+	if wayland {
+		list = append(list, tool{name: "wl-copy"})
+	}
+	return append(list,
+		tool{name: "xclip", args: []string{"-selection", "clipboard"}},
+		tool{name: "xsel", args: []string{"--clipboard", "--input"}},
+	)
+}
+
+// lookPath is exec.LookPath, replaced in tests
+var lookPath = exec.LookPath
+
+// find returns the first clipboard tool installed on this system
+func find() (tool, error) {
+	list := tools(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "")
+	names := make([]string, len(list))
+	for i, t := range list {
+		if _, err := lookPath(t.name); err == nil {
+			return t, nil
+		}
+		names[i] = t.name
+	}
+	return tool{}, fmt.Errorf("no clipboard tool found (install one of: %s)", strings.Join(names, ", "))
+}
+
+// Write replaces the clipboard contents with text
+func Write(ctx context.Context, text string) error {
+	t, err := find()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, t.name, t.args...)
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", t.name, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
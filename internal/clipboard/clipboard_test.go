@@ -0,0 +1,41 @@
+package clipboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTools(t *testing.T) {
+	tests := []struct {
+		goos    string
+		wayland bool
+		want    string
+	}{
+		{"darwin", false, "pbcopy"},
+		{"windows", false, "clip.exe"},
+		{"linux", false, "xclip xsel"},
+		{"linux", true, "wl-copy xclip xsel"},
+		{"freebsd", false, "xclip xsel"},
+	}
+	for _, tt := range tests {
+		var names []string
+		for _, tool := range tools(tt.goos, tt.wayland) {
+			names = append(names, tool.name)
+		}
+		if got := strings.Join(names, " "); got != tt.want {
+			t.Errorf("tools(%q, %v) = %q, want %q", tt.goos, tt.wayland, got, tt.want)
+		}
+	}
+}
+
+func TestFind_NoneInstalled(t *testing.T) {
+	orig := lookPath
+	lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	defer func() { lookPath = orig }()
+
+	_, err := find()
+	if err == nil || !strings.Contains(err.Error(), "no clipboard tool found") {
+		t.Errorf("find() error = %v, want no clipboard tool found", err)
+	}
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/notify"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Run alerts that notify you about account conditions",
+	Long: `Run long-lived alerts that poll the API and notify you when a condition is met.
+
+Notifications are printed to the terminal and, when configured, delivered to a
+webhook, a Slack incoming webhook, or the desktop. Defaults come from the
+alerts.* configuration keys and can be overridden per run with flags.`,
+}
+
+var alertsBalanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Alert when available balance drops below a threshold",
+	Long: `Poll the account balance and notify when it drops below the threshold,
+e.g. after fills consume available cash. The alert fires once per crossing
+and re-arms when the balance recovers to or above the threshold.`,
+	Example: `  kalshi-cli alerts balance --below 5000
+  kalshi-cli alerts balance --below 5000 --interval 30s --slack https://hooks.slack.com/services/...
+  kalshi-cli alerts balance --below 5000 --desktop`,
+	RunE: runAlertsBalance,
+}
+
+var (
+	alertsBelow    int
+	alertsInterval time.Duration
+	alertsWebhook  string
+	alertsSlack    string
+	alertsDesktop  bool
+)
+
+func init() {
+	rootCmd.AddCommand(alertsCmd)
+	alertsCmd.AddCommand(alertsBalanceCmd)
+
+	alertsCmd.PersistentFlags().DurationVar(&alertsInterval, "interval", time.Minute, "polling interval")
+	alertsCmd.PersistentFlags().StringVar(&alertsWebhook, "webhook", "", "webhook URL to POST alerts to (default: alerts.webhook_url)")
+	alertsCmd.PersistentFlags().StringVar(&alertsSlack, "slack", "", "Slack incoming webhook URL (default: alerts.slack_webhook_url)")
+	alertsCmd.PersistentFlags().BoolVar(&alertsDesktop, "desktop", false, "show desktop notifications (default: alerts.desktop)")
+	alertsCmd.PersistentFlags().StringVar(&summaryWebhook, "summary-webhook", "", "POST the end-of-session summary as JSON to this URL")
+
+	alertsBalanceCmd.Flags().IntVar(&alertsBelow, "below", 0, "threshold in cents (required)")
+	alertsBalanceCmd.MarkFlagRequired("below")
+}
+
+// buildNotifier assembles the notification destinations from flags and config
+func buildNotifier() notify.Notifier {
+	webhook := alertsWebhook
+	if webhook == "" {
+		webhook = cfg.Alerts.WebhookURL
+	}
+	slack := alertsSlack
+	if slack == "" {
+		slack = cfg.Alerts.SlackWebhookURL
+	}
+
+	var notifiers notify.Multi
+	if webhook != "" {
+		notifiers = append(notifiers, &notify.Webhook{URL: webhook})
+	}
+	if slack != "" {
+		notifiers = append(notifiers, &notify.Slack{WebhookURL: slack})
+	}
+	if alertsDesktop || cfg.Alerts.Desktop {
+		notifiers = append(notifiers, notify.Desktop{})
+	}
+	return notifiers
+}
+
+// emitAlert prints the alert and delivers it to the configured notifiers.
+// Delivery failures are reported but never stop the alert loop.
+func emitAlert(ctx context.Context, notifier notify.Notifier, msg notify.Message) {
+	switch GetOutputFormat() {
+	case ui.FormatJSON:
+		printJSONLine(msg)
+	case ui.FormatPlain:
+		fmt.Fprintf(ui.Writer(), "%s alert=%q %s\n", msg.Time.Format(time.RFC3339), msg.Title, msg.Body)
+	default:
+		fmt.Fprintln(ui.Writer(), ui.WarningStyle.Render(fmt.Sprintf("[%s] %s: %s", formatTimestamp(), msg.Title, msg.Body)))
+	}
+
+	if err := notifier.Notify(ctx, msg); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to deliver alert: %v\n", err)
+	}
+}
+
+// alertContext returns a context cancelled on SIGINT/SIGTERM
+func alertContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// thresholdTrigger fires once when a value drops below the threshold and
+// re-arms once it recovers
+type thresholdTrigger struct {
+	threshold int
+	fired     bool
+}
+
+// observe records a value and reports whether the alert should fire
+func (t *thresholdTrigger) observe(value int) bool {
+	if value >= t.threshold {
+		t.fired = false
+		return false
+	}
+	if t.fired {
+		return false
+	}
+	t.fired = true
+	return true
+}
+
+// availableBalance returns the available balance of the active subaccount
+func availableBalance(ctx context.Context, client *api.Client) (int, error) {
+	if sub := ActiveSubaccount(); sub > 0 {
+		balance, err := getSubaccountBalance(ctx, client, sub)
+		if err != nil {
+			return 0, err
+		}
+		return balance.AvailableBalance, nil
+	}
+
+	balance, err := client.GetBalance(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return balance.Balance, nil
+}
+
+func runAlertsBalance(cmd *cobra.Command, args []string) error {
+	if alertsBelow <= 0 {
+		return fmt.Errorf("--below must be a positive number of cents")
+	}
+	if alertsInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := alertContext()
+	defer stop()
+
+	notifier := buildNotifier()
+	trigger := &thresholdTrigger{threshold: alertsBelow}
+	tracker := newSessionTracker()
+	tracker.captureStartBalance(client)
+
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Watching %s balance (threshold %s, every %s)\n",
+			cfg.Environment(), formatCents(alertsBelow), alertsInterval)
+	}
+
+	ticker := time.NewTicker(alertsInterval)
+	defer ticker.Stop()
+
+	for {
+		reqCtx, cancel := withTimeout(ctx)
+		balance, err := availableBalance(reqCtx, client)
+		cancel()
+		tracker.messages.Add(1)
+
+		switch {
+		case err != nil && ctx.Err() == nil:
+			fmt.Fprintf(os.Stderr, "Warning: failed to get balance: %v\n", err)
+		case err == nil && trigger.observe(balance):
+			emitAlert(ctx, notifier, notify.Message{
+				Title: "Kalshi balance below threshold",
+				Body: fmt.Sprintf("Available balance %s is below %s (%s)",
+					formatCents(balance), formatCents(alertsBelow), cfg.Environment()),
+				Time: time.Now().UTC(),
+				Data: map[string]any{
+					"balance":     balance,
+					"threshold":   alertsBelow,
+					"subaccount":  ActiveSubaccount(),
+					"environment": cfg.Environment(),
+				},
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			tracker.finish(client)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import "testing"
+
+func TestThresholdTrigger(t *testing.T) {
+	trigger := &thresholdTrigger{threshold: 5000}
+
+	steps := []struct {
+		value    int
+		expected bool
+	}{
+		{value: 6000, expected: false},
+		{value: 4000, expected: true},
+		{value: 3000, expected: false}, // still below, already fired
+		{value: 5000, expected: false}, // recovered, re-arms
+		{value: 4999, expected: true},
+	}
+
+	for i, step := range steps {
+		if got := trigger.observe(step.value); got != step.expected {
+			t.Errorf("step %d: observe(%d) = %v, expected %v", i, step.value, got, step.expected)
+		}
+	}
+}
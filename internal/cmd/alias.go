@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage command aliases",
+	Long: `Manage shorthand aliases for frequently used commands.
+
+Aliases are stored under 'aliases' in the config file (see 'config paths')
+and expand to a command plus any leading flags, quoted as in a shell.
+Arguments after the alias are appended:
+
+  aliases:
+    ob: markets orderbook
+    buy: orders create --action buy
+    recent: markets trades --start "2h ago"
+
+  kalshi-cli ob KXBTC-26FEB12-B97000
+  kalshi-cli buy --market KXBTC-26FEB12-B97000 --side yes --qty 10 --price 50
+
+Built-in shorthands: m (markets), o (orders), p (portfolio), w (watch),
+og (order-groups). Aliases cannot shadow a command or built-in shorthand.`,
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List built-in and user-defined aliases",
+	RunE:  runAliasList,
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <expansion>",
+	Short: "Create or replace an alias",
+	Example: `  kalshi-cli alias set ob "markets orderbook"
+  kalshi-cli alias set buy "orders create --action buy"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAliasSet,
+}
+
+var aliasDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete an alias",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAliasDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasDeleteCmd)
+}
+
+// aliasNamePattern matches valid alias names; viper lowercases map keys
+var aliasNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// expandAliases rewrites args when the first command word is a user alias.
+// Root flags before the alias are kept in place. Expansion is a single pass,
+// so an alias that refers to another alias is not expanded again. An
+// expansion that cannot be split is left unexpanded.
+func expandAliases(args []string, aliases map[string]string, flags *pflag.FlagSet) []string {
+	if len(aliases) == 0 {
+		return args
+	}
+
+	i := commandWordIndex(args, flags)
+	if i < 0 {
+		return args
+	}
+
+	expansion, ok := aliases[args[i]]
+	if !ok || isCommandName(args[i]) {
+		return args
+	}
+	words, err := splitCommandLine(expansion)
+	if err != nil {
+		return args
+	}
+
+	expanded := make([]string, 0, len(args)+4)
+	expanded = append(expanded, args[:i]...)
+	expanded = append(expanded, words...)
+	expanded = append(expanded, args[i+1:]...)
+	return expanded
+}
+
+// commandWordIndex returns the index of the first non-flag argument, skipping
+// the values of root flags that take one, or -1 if there is none
+func commandWordIndex(args []string, flags *pflag.FlagSet) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return i
+		}
+		if strings.Contains(arg, "=") {
+			continue
+		}
+
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			flag = flags.Lookup(strings.TrimPrefix(arg, "--"))
+		} else if len(arg) == 2 {
+			flag = flags.ShorthandLookup(arg[1:])
+		}
+		if flag != nil && flag.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return -1
+}
+
+// isCommandName reports whether name is a top-level command or one of its aliases
+func isCommandName(name string) bool {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// userAliases loads aliases from the config file named by --config (if any)
+// before cobra parses flags. Load errors are left for initConfig to report.
+func userAliases(args []string) map[string]string {
+	path, dir := "", ""
+	for i, arg := range args {
+		if arg == "--config" && i+1 < len(args) {
+			path = args[i+1]
+		} else if strings.HasPrefix(arg, "--config=") {
+			path = strings.TrimPrefix(arg, "--config=")
+		} else if arg == "--config-dir" && i+1 < len(args) {
+			dir = args[i+1]
+		} else if strings.HasPrefix(arg, "--config-dir=") {
+			dir = strings.TrimPrefix(arg, "--config-dir=")
+		}
+	}
+	config.SetDirOverride(dir)
+
+	loaded, err := config.Load(path)
+	if err != nil {
+		return nil
+	}
+	return loaded.Aliases
+}
+
+// builtinShorthands returns the cobra aliases of every top-level command
+func builtinShorthands() map[string]string {
+	shorthands := make(map[string]string)
+	for _, c := range rootCmd.Commands() {
+		for _, alias := range c.Aliases {
+			shorthands[alias] = c.Name()
+		}
+	}
+	return shorthands
+}
+
+func runAliasList(cmd *cobra.Command, args []string) error {
+	builtins := builtinShorthands()
+	user := GetConfig().Aliases
+
+	type aliasEntry struct {
+		Name      string `json:"name"`
+		Expansion string `json:"expansion"`
+		Builtin   bool   `json:"builtin"`
+	}
+
+	entries := make([]aliasEntry, 0, len(builtins)+len(user))
+	for name, expansion := range builtins {
+		entries = append(entries, aliasEntry{Name: name, Expansion: expansion, Builtin: true})
+	}
+	for name, expansion := range user {
+		entries = append(entries, aliasEntry{Name: name, Expansion: expansion})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Builtin != entries[j].Builtin {
+			return entries[i].Builtin
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			rows := make([][]string, len(entries))
+			for i, e := range entries {
+				source := "config"
+				if e.Builtin {
+					source = "built-in"
+				}
+				rows[i] = []string{e.Name, e.Expansion, source}
+			}
+			ui.RenderTable([]string{"Alias", "Expands To", "Source"}, rows)
+		},
+		entries,
+		func() {
+			for _, e := range entries {
+				ui.PrintPlain("%s=%s", e.Name, e.Expansion)
+			}
+		},
+	)
+}
+
+func runAliasSet(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	expansion := strings.TrimSpace(args[1])
+
+	if !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q: use lowercase letters, digits and dashes", name)
+	}
+	if isCommandName(name) {
+		return fmt.Errorf("alias %q would shadow a built-in command", name)
+	}
+	if expansion == "" {
+		return fmt.Errorf("alias expansion cannot be empty")
+	}
+	words, err := splitCommandLine(expansion)
+	if err != nil {
+		return fmt.Errorf("invalid alias expansion: %w", err)
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("alias expansion cannot be empty")
+	}
+	if !isCommandName(words[0]) {
+		return fmt.Errorf("alias must expand to a command, %q is not one", words[0])
+	}
+
+	aliases := copyAliases(GetConfig().Aliases)
+	aliases[name] = expansion
+	if err := config.SaveAliases(aliases); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			PrintSuccess(fmt.Sprintf("Set alias %s = %s", name, expansion))
+		},
+		map[string]string{"name": name, "expansion": expansion},
+		func() {
+			ui.PrintPlain("%s=%s", name, expansion)
+		},
+	)
+}
+
+func runAliasDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	aliases := copyAliases(GetConfig().Aliases)
+	if _, ok := aliases[name]; !ok {
+		return fmt.Errorf("no alias named %q", name)
+	}
+	delete(aliases, name)
+
+	if err := config.SaveAliases(aliases); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			PrintSuccess(fmt.Sprintf("Deleted alias %s", name))
+		},
+		map[string]string{"name": name, "status": "deleted"},
+		func() {
+			ui.PrintPlain("deleted=%s", name)
+		},
+	)
+}
+
+func copyAliases(aliases map[string]string) map[string]string {
+	copied := make(map[string]string, len(aliases))
+	for k, v := range aliases {
+		copied[k] = v
+	}
+	return copied
+}
+
+// aliasArgs returns the process arguments with any user alias expanded
+func aliasArgs() []string {
+	args := os.Args[1:]
+	return expandAliases(args, userAliases(args), rootCmd.PersistentFlags())
+}
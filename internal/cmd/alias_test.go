@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandAliases(t *testing.T) {
+	aliases := map[string]string{
+		"ob":     "markets orderbook",
+		"buy":    "orders create --action buy",
+		"w":      "markets list",
+		"recent": `markets trades --start "2h ago"`,
+		"bad":    `markets trades --start "2h ago`,
+	}
+	flags := rootCmd.PersistentFlags()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "simple alias",
+			args:     []string{"ob", "KXBTC"},
+			expected: []string{"markets", "orderbook", "KXBTC"},
+		},
+		{
+			name:     "alias with flags in expansion",
+			args:     []string{"buy", "--market", "KXBTC", "--qty", "10"},
+			expected: []string{"orders", "create", "--action", "buy", "--market", "KXBTC", "--qty", "10"},
+		},
+		{
+			name:     "quoted words in expansion",
+			args:     []string{"recent", "KXBTC"},
+			expected: []string{"markets", "trades", "--start", "2h ago", "KXBTC"},
+		},
+		{
+			name:     "unterminated quote is not expanded",
+			args:     []string{"bad", "KXBTC"},
+			expected: []string{"bad", "KXBTC"},
+		},
+		{
+			name:     "root flags before alias",
+			args:     []string{"--prod", "--config", "/tmp/c.yaml", "ob", "KXBTC"},
+			expected: []string{"--prod", "--config", "/tmp/c.yaml", "markets", "orderbook", "KXBTC"},
+		},
+		{
+			name:     "flag value that looks like an alias",
+			args:     []string{"--config", "ob", "markets", "list"},
+			expected: []string{"--config", "ob", "markets", "list"},
+		},
+		{
+			name:     "alias cannot shadow a built-in shorthand",
+			args:     []string{"w", "ticker", "KXBTC"},
+			expected: []string{"w", "ticker", "KXBTC"},
+		},
+		{
+			name:     "not an alias",
+			args:     []string{"markets", "get", "ob"},
+			expected: []string{"markets", "get", "ob"},
+		},
+		{
+			name:     "no command word",
+			args:     []string{"--json"},
+			expected: []string{"--json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandAliases(tt.args, aliases, flags)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expandAliases(%v) = %v, expected %v", tt.args, got, tt.expected)
+			}
+		})
+	}
+}
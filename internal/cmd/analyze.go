@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Analyze orders against market activity",
+}
+
+var analyzeQueueSimCmd = &cobra.Command{
+	Use:   "queue-sim <order-id>",
+	Short: "Estimate whether a resting order would have filled",
+	Long: `Replay recent public trades on the order's market against its current queue
+position, to estimate whether and when the order would have filled had it
+been resting there the whole time. This helps decide between amending the
+price and waiting.
+
+Only trades where the taker hit the order's side count. Trades at the
+order's price use up the contracts ahead of it first, then fill it. A trade
+through the price (worse for the taker) means the level was cleared and the
+order would have filled in full. From the rate at which the level traded,
+the time to fill from the current queue position is estimated.
+
+This is an estimate: it assumes the queue ahead stays as it is now and that
+the same trades would have happened with the order resting.`,
+	Example: `  kalshi-cli analyze queue-sim 4f1c9e0a-7b1d-4a36-9d2f-1c0b6a8e5d21
+  kalshi-cli analyze queue-sim 4f1c9e0a-7b1d-4a36-9d2f-1c0b6a8e5d21 --since 6h --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyzeQueueSim,
+}
+
+var queueSimSince string
+
+// queueSimPageSize is how many trades are fetched per request
+const queueSimPageSize = 1000
+
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+	analyzeCmd.AddCommand(analyzeQueueSimCmd)
+
+	analyzeQueueSimCmd.Flags().StringVar(&queueSimSince, "since", "24h", "replay trades from this time: "+timeArgHelp)
+}
+
+// queueSim is the result of replaying trades against a resting order
+type queueSim struct {
+	OrderID   string    `json:"order_id"`
+	Ticker    string    `json:"ticker"`
+	Side      string    `json:"side"`
+	Action    string    `json:"action"`
+	Price     int       `json:"price"`
+	Remaining int       `json:"remaining"`
+	Ahead     int       `json:"queue_ahead"`
+	Since     time.Time `json:"since"`
+	Trades    int       `json:"trades"`
+	// LevelVolume traded at the order's price, ThroughVolume past it
+	LevelVolume   int        `json:"level_volume"`
+	ThroughVolume int        `json:"through_volume"`
+	WouldFill     int        `json:"would_fill"`
+	FirstFill     *time.Time `json:"first_fill,omitempty"`
+	FullFill      *time.Time `json:"full_fill,omitempty"`
+	// RatePerHour is the contracts traded at or through the price per hour
+	RatePerHour float64 `json:"rate_per_hour"`
+	// EstimatedHours is how long the current queue ahead plus the order
+	// would take to trade at that rate; absent when the level never traded
+	EstimatedHours *float64 `json:"estimated_hours,omitempty"`
+}
+
+// yesBid reports whether an order rests as a bid for YES, and its price in
+// YES terms. Selling NO bids for YES; buying NO offers YES.
+func yesBid(o models.Order) (bid bool, yesPrice int) {
+	if o.Side == models.OrderSideYes {
+		return o.Action == models.OrderActionBuy, o.YesPrice
+	}
+	return o.Action == models.OrderActionSell, 100 - o.NoPrice
+}
+
+// simulateQueue replays trades, oldest first, against an order resting at
+// yesPrice behind ahead contracts. Trade prices are YES prices; a "no" taker
+// sells YES into bids and a "yes" taker buys YES from offers.
+func simulateQueue(trades []models.Trade, bid bool, yesPrice, ahead, remaining int, since, now time.Time) queueSim {
+	sim := queueSim{Ahead: ahead, Remaining: remaining, Since: since}
+	left := ahead
+	for _, t := range trades {
+		var atLevel, through bool
+		if bid {
+			atLevel = t.TakerSide == "no" && t.Price == yesPrice
+			through = t.TakerSide == "no" && t.Price < yesPrice
+		} else {
+			atLevel = t.TakerSide == "yes" && t.Price == yesPrice
+			through = t.TakerSide == "yes" && t.Price > yesPrice
+		}
+		if !atLevel && !through {
+			continue
+		}
+		sim.Trades++
+
+		filled := 0
+		if through {
+			sim.ThroughVolume += t.Count
+			left = 0
+			filled = remaining - sim.WouldFill
+		} else {
+			sim.LevelVolume += t.Count
+			used := min(left, t.Count)
+			left -= used
+			filled = min(t.Count-used, remaining-sim.WouldFill)
+		}
+		if filled <= 0 {
+			continue
+		}
+		at := t.CreatedTime
+		if sim.WouldFill == 0 {
+			sim.FirstFill = &at
+		}
+		sim.WouldFill += filled
+		if sim.WouldFill == remaining {
+			sim.FullFill = &at
+		}
+	}
+
+	if hours := now.Sub(since).Hours(); hours > 0 {
+		sim.RatePerHour = float64(sim.LevelVolume+sim.ThroughVolume) / hours
+	}
+	if sim.RatePerHour > 0 {
+		est := float64(ahead+remaining) / sim.RatePerHour
+		sim.EstimatedHours = &est
+	}
+	return sim
+}
+
+// tradesSince returns every trade on a market since a time, oldest first
+func tradesSince(ctx context.Context, client *api.Client, ticker string, since time.Time) ([]models.Trade, error) {
+	var trades []models.Trade
+	params := api.GetTradesParams{Ticker: ticker, Limit: queueSimPageSize, MinTs: since.Unix()}
+	progress := ui.NewProgress("trades", 0)
+	defer progress.Done()
+	for {
+		reqCtx, cancel := withTimeout(ctx)
+		page, err := client.GetTrades(reqCtx, params)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trades: %w", err)
+		}
+		trades = append(trades, page.Trades...)
+		progress.Page(len(page.Trades), page.Cursor)
+		if page.Cursor == "" || len(page.Trades) == 0 {
+			break
+		}
+		params.Cursor = page.Cursor
+	}
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].CreatedTime.Before(trades[j].CreatedTime) })
+	return trades, nil
+}
+
+func runAnalyzeQueueSim(cmd *cobra.Command, args []string) error {
+	since, err := parseSinceArg(queueSimSince)
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	resp, err := client.GetOrder(reqCtx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	order := resp.Order
+	if order.Status != models.OrderStatusResting {
+		return fmt.Errorf("order %s is %s; queue-sim needs a resting order", order.OrderID, order.Status)
+	}
+	queue, err := client.GetQueuePosition(reqCtx, order.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to get queue position: %w", err)
+	}
+
+	trades, err := tradesSince(ctx, client, order.Ticker, since)
+	if err != nil {
+		return err
+	}
+
+	bid, yesPrice := yesBid(order)
+	sim := simulateQueue(trades, bid, yesPrice, queue.QueuePosition, order.RemainingCount, since, time.Now())
+	sim.OrderID = order.OrderID
+	sim.Ticker = order.Ticker
+	sim.Side = string(order.Side)
+	sim.Action = string(order.Action)
+	sim.Price = orderPrice(order)
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderQueueSim(sim) },
+		sim,
+		func() {
+			ui.PrintPlain("%s\t%d\t%d\t%d\t%d\t%.2f", sim.OrderID, sim.Ahead, sim.Remaining,
+				sim.WouldFill, sim.LevelVolume+sim.ThroughVolume, sim.RatePerHour)
+		},
+	)
+}
+
+// orderPrice is an order's limit price on its own side
+func orderPrice(o models.Order) int {
+	if o.Side == models.OrderSideYes {
+		return o.YesPrice
+	}
+	return o.NoPrice
+}
+
+func renderQueueSim(sim queueSim) {
+	verdict := ui.WarningStyle.Render("would not have filled")
+	switch {
+	case sim.FullFill != nil:
+		verdict = ui.SuccessStyle.Render("would have filled in full at " + formatMarketTime(*sim.FullFill))
+	case sim.WouldFill > 0:
+		verdict = ui.WarningStyle.Render(fmt.Sprintf("would have filled %d of %d", sim.WouldFill, sim.Remaining))
+	}
+
+	estimate := "-"
+	if sim.EstimatedHours != nil {
+		estimate = "~" + time.Duration(*sim.EstimatedHours*float64(time.Hour)).Round(time.Minute).String()
+	}
+
+	pairs := [][]string{
+		{ui.BoldStyle.Render("Order:"), fmt.Sprintf("%s %s %s @ %s", sim.Action, sim.Side, sim.Ticker, formatCents(sim.Price))},
+		{ui.BoldStyle.Render("Remaining:"), strconv.Itoa(sim.Remaining)},
+		{ui.BoldStyle.Render("Queue Ahead:"), strconv.Itoa(sim.Ahead)},
+		{ui.BoldStyle.Render("Replayed Since:"), formatMarketTime(sim.Since)},
+		{ui.BoldStyle.Render("Traded At Price:"), fmt.Sprintf("%d contracts (%d through)", sim.LevelVolume+sim.ThroughVolume, sim.ThroughVolume)},
+		{ui.BoldStyle.Render("Result:"), verdict},
+	}
+	if sim.FirstFill != nil {
+		pairs = append(pairs, []string{ui.BoldStyle.Render("First Fill:"), formatMarketTime(*sim.FirstFill)})
+	}
+	pairs = append(pairs,
+		[]string{ui.BoldStyle.Render("Rate:"), fmt.Sprintf("%.1f contracts/hour", sim.RatePerHour)},
+		[]string{ui.BoldStyle.Render("Est. Time To Fill:"), estimate},
+	)
+	ui.RenderKeyValue(pairs)
+}
@@ -0,0 +1,446 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var analyzeMicrostructureCmd = &cobra.Command{
+	Use:   "microstructure <ticker>",
+	Short: "Spread, book imbalance and trade flow statistics for a market",
+	Long: `Measure a market's microstructure over a lookback window:
+
+  quoted spread        best ask minus best bid at the end of each candle
+                       (1-minute candles up to 3 days, hourly beyond)
+  effective spread     twice the distance of each trade from the quoted mid
+                       of the candle before it
+  book imbalance       (bid depth - ask depth) / (bid depth + ask depth) in
+                       the live book, over the top 5 levels and the whole book
+  trade sign autocorr  autocorrelation of taker direction (+1 YES, -1 NO) at
+                       lags 1 to 5; positive values mean order flow persists
+
+Prices are YES prices in cents.`,
+	Example: `  kalshi-cli analyze microstructure KXBTC-26FEB12-B97000
+  kalshi-cli analyze microstructure KXBTC-26FEB12-B97000 --lookback 6h --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyzeMicrostructure,
+}
+
+var microLookback string
+
+const (
+	// microMaxLag is the longest lag of trade sign autocorrelation
+	microMaxLag = 5
+	// microTopLevels is how many levels the top-of-book imbalance covers
+	microTopLevels = 5
+	// microChartPoints is how many buckets each chart shows at most
+	microChartPoints = 40
+)
+
+func init() {
+	analyzeCmd.AddCommand(analyzeMicrostructureCmd)
+
+	analyzeMicrostructureCmd.Flags().StringVar(&microLookback, "lookback", "1d", "window to measure, e.g. 6h, 1d, 2w")
+}
+
+// spreadPoint is the quoted spread at one time
+type spreadPoint struct {
+	Time   time.Time `json:"time"`
+	Bid    int       `json:"bid"`
+	Ask    int       `json:"ask"`
+	Spread int       `json:"spread"`
+}
+
+// effectivePoint is one trade's effective spread
+type effectivePoint struct {
+	Time   time.Time
+	Count  int
+	Spread float64
+}
+
+// spreadSummary describes a set of spreads in cents
+type spreadSummary struct {
+	Samples int     `json:"samples"`
+	Mean    float64 `json:"mean"`
+	Median  float64 `json:"median"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	// VolumeWeighted weights each trade by its contracts; effective spread only
+	VolumeWeighted float64 `json:"volume_weighted,omitempty"`
+}
+
+// bookImbalance is how lopsided the live book is, from -1 (all asks) to 1
+// (all bids)
+type bookImbalance struct {
+	TopBidDepth int     `json:"top_bid_depth"`
+	TopAskDepth int     `json:"top_ask_depth"`
+	Top         float64 `json:"top"`
+	BidDepth    int     `json:"bid_depth"`
+	AskDepth    int     `json:"ask_depth"`
+	Total       float64 `json:"total"`
+}
+
+// microstructure is the analyze microstructure result
+type microstructure struct {
+	Ticker          string        `json:"ticker"`
+	From            time.Time     `json:"from"`
+	To              time.Time     `json:"to"`
+	CandlePeriod    string        `json:"candle_period"`
+	QuotedSpread    spreadSummary `json:"quoted_spread"`
+	EffectiveSpread spreadSummary `json:"effective_spread"`
+	Imbalance       bookImbalance `json:"book_imbalance"`
+	Trades          int           `json:"trades"`
+	// BuyShare is the share of trades with a YES taker
+	BuyShare float64 `json:"buy_share"`
+	// SignAutocorrelation holds lags 1 and up
+	SignAutocorrelation []float64     `json:"sign_autocorrelation"`
+	QuotedSpreads       []spreadPoint `json:"quoted_spreads"`
+
+	effective []effectivePoint
+}
+
+// quotedSpreads returns the spread at the end of each candle with quotes on
+// both sides
+func quotedSpreads(candles []models.Candlestick) []spreadPoint {
+	points := []spreadPoint{}
+	for _, c := range candles {
+		if c.YesBid <= 0 || c.YesAsk >= 100 || c.YesAsk <= c.YesBid {
+			continue
+		}
+		points = append(points, spreadPoint{Time: c.PeriodEnd, Bid: c.YesBid, Ask: c.YesAsk, Spread: c.YesAsk - c.YesBid})
+	}
+	return points
+}
+
+// effectiveSpreads measures each trade, oldest first, against the last quote
+// at or before it. Trades before the first quote are skipped.
+func effectiveSpreads(trades []models.Trade, quotes []spreadPoint) []effectivePoint {
+	var points []effectivePoint
+	q := -1
+	for _, t := range trades {
+		for q+1 < len(quotes) && !quotes[q+1].Time.After(t.CreatedTime) {
+			q++
+		}
+		if q < 0 {
+			continue
+		}
+		mid := float64(quotes[q].Bid+quotes[q].Ask) / 2
+		points = append(points, effectivePoint{Time: t.CreatedTime, Count: t.Count, Spread: 2 * math.Abs(float64(t.Price)-mid)})
+	}
+	return points
+}
+
+// summarize describes values; weights, if given, also give a weighted mean
+func summarize(values []float64, weights []int) spreadSummary {
+	s := spreadSummary{Samples: len(values)}
+	if len(values) == 0 {
+		return s
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	s.Min, s.Max = sorted[0], sorted[len(sorted)-1]
+	if n := len(sorted); n%2 == 1 {
+		s.Median = sorted[n/2]
+	} else {
+		s.Median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	sum, weighted, weight := 0.0, 0.0, 0
+	for i, v := range values {
+		sum += v
+		if weights != nil {
+			weighted += v * float64(weights[i])
+			weight += weights[i]
+		}
+	}
+	s.Mean = sum / float64(len(values))
+	if weight > 0 {
+		s.VolumeWeighted = weighted / float64(weight)
+	}
+	return s
+}
+
+// tradeSigns returns +1 for each YES taker and -1 for each NO taker
+func tradeSigns(trades []models.Trade) []float64 {
+	signs := make([]float64, 0, len(trades))
+	for _, t := range trades {
+		switch t.TakerSide {
+		case "yes":
+			signs = append(signs, 1)
+		case "no":
+			signs = append(signs, -1)
+		}
+	}
+	return signs
+}
+
+// autocorrelation returns the autocorrelation of xs at lags 1 to maxLag. Lags
+// without enough data, or data with no variance, are 0.
+func autocorrelation(xs []float64, maxLag int) []float64 {
+	out := make([]float64, maxLag)
+	if len(xs) < 2 {
+		return out
+	}
+	mean := 0.0
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+
+	variance := 0.0
+	for _, x := range xs {
+		variance += (x - mean) * (x - mean)
+	}
+	if variance == 0 {
+		return out
+	}
+	for lag := 1; lag <= maxLag && lag < len(xs); lag++ {
+		cov := 0.0
+		for i := 0; i+lag < len(xs); i++ {
+			cov += (xs[i] - mean) * (xs[i+lag] - mean)
+		}
+		out[lag-1] = cov / variance
+	}
+	return out
+}
+
+// imbalance measures the book over the top levels and in full
+func imbalance(ob *models.Orderbook, top int) bookImbalance {
+	var b bookImbalance
+	b.TopBidDepth, b.BidDepth = bookDepth(ob.YesBids, top)
+	b.TopAskDepth, b.AskDepth = bookDepth(ob.YesAsks, top)
+	b.Top = imbalanceRatio(b.TopBidDepth, b.TopAskDepth)
+	b.Total = imbalanceRatio(b.BidDepth, b.AskDepth)
+	return b
+}
+
+// bookDepth returns the contracts in the first top levels and in all levels
+func bookDepth(levels []models.OrderbookLevel, top int) (int, int) {
+	topDepth, total := 0, 0
+	for i, l := range levels {
+		if i < top {
+			topDepth += l.Quantity
+		}
+		total += l.Quantity
+	}
+	return topDepth, total
+}
+
+func imbalanceRatio(bid, ask int) float64 {
+	if bid+ask == 0 {
+		return 0
+	}
+	return float64(bid-ask) / float64(bid+ask)
+}
+
+// bucketMeans splits [from, to) into n equal buckets and averages the values
+// in each, skipping empty buckets. It returns each bucket's start and mean.
+func bucketMeans(times []time.Time, values []float64, from, to time.Time, n int) ([]time.Time, []float64) {
+	width := to.Sub(from) / time.Duration(n)
+	if width <= 0 {
+		return nil, nil
+	}
+	sums := make([]float64, n)
+	counts := make([]int, n)
+	for i, t := range times {
+		b := int(t.Sub(from) / width)
+		if b < 0 || b >= n {
+			continue
+		}
+		sums[b] += values[i]
+		counts[b]++
+	}
+
+	var starts []time.Time
+	var means []float64
+	for b := range sums {
+		if counts[b] > 0 {
+			starts = append(starts, from.Add(time.Duration(b)*width))
+			means = append(means, sums[b]/float64(counts[b]))
+		}
+	}
+	return starts, means
+}
+
+// chartTimeLayout labels chart points with times for spans under two days
+// and dates otherwise
+func chartTimeLayout(span time.Duration) string {
+	if span < 48*time.Hour {
+		return "15:04"
+	}
+	return "01-02"
+}
+
+func runAnalyzeMicrostructure(cmd *cobra.Command, args []string) error {
+	lookback, err := parseSpan(microLookback)
+	if err != nil {
+		return fmt.Errorf("invalid --lookback: %w", err)
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	cache := loadSeriesCache()
+	defer saveSeriesCache(cache)
+
+	now := time.Now()
+	m, err := analyzeMarket(context.Background(), client, args[0], now.Add(-lookback), now, candlePeriodFor(lookback), func(ctx context.Context, eventTicker string) (string, error) {
+		return resolveSeriesTicker(ctx, client, cache, eventTicker, "")
+	})
+	if err != nil {
+		return err
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderMicrostructure(m) },
+		m,
+		func() {
+			ui.PrintPlain("%s\t%.2f\t%.2f\t%.4f\t%.4f\t%.4f", m.Ticker, m.QuotedSpread.Mean, m.EffectiveSpread.Mean,
+				m.Imbalance.Top, m.BuyShare, m.SignAutocorrelation[0])
+		},
+	)
+}
+
+// analyzeMarket fetches a market's candles, trades and book and measures them
+func analyzeMarket(ctx context.Context, client *api.Client, ticker string, from, to time.Time, period string, series func(context.Context, string) (string, error)) (microstructure, error) {
+	m := microstructure{Ticker: ticker, From: from, To: to, CandlePeriod: period}
+
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	market, err := client.GetMarket(reqCtx, ticker)
+	if err != nil {
+		return m, fmt.Errorf("failed to get market: %w", err)
+	}
+	seriesTicker, err := series(reqCtx, market.EventTicker)
+	if err != nil {
+		return m, err
+	}
+	candles, err := client.GetCandlesticks(reqCtx, api.GetCandlesticksParams{
+		SeriesTicker: seriesTicker,
+		Ticker:       ticker,
+		Period:       period,
+		StartTime:    from.Unix(),
+		EndTime:      to.Unix(),
+	})
+	if err != nil {
+		return m, fmt.Errorf("failed to get candlesticks: %w", err)
+	}
+	book, err := client.GetOrderbook(reqCtx, ticker)
+	if err != nil {
+		return m, err
+	}
+	trades, err := tradesSince(ctx, client, ticker, from)
+	if err != nil {
+		return m, err
+	}
+
+	m.measure(candles.Candlesticks, trades, book)
+	return m, nil
+}
+
+// measure computes every statistic from candles, trades (oldest first) and
+// the live book
+func (m *microstructure) measure(candles []models.Candlestick, trades []models.Trade, book *models.Orderbook) {
+	m.QuotedSpreads = quotedSpreads(candles)
+	quoted := make([]float64, len(m.QuotedSpreads))
+	for i, p := range m.QuotedSpreads {
+		quoted[i] = float64(p.Spread)
+	}
+	m.QuotedSpread = summarize(quoted, nil)
+
+	m.effective = effectiveSpreads(trades, m.QuotedSpreads)
+	effective := make([]float64, len(m.effective))
+	counts := make([]int, len(m.effective))
+	for i, p := range m.effective {
+		effective[i], counts[i] = p.Spread, p.Count
+	}
+	m.EffectiveSpread = summarize(effective, counts)
+
+	m.Imbalance = imbalance(book, microTopLevels)
+
+	signs := tradeSigns(trades)
+	m.Trades = len(trades)
+	if len(signs) > 0 {
+		buys := 0
+		for _, s := range signs {
+			if s > 0 {
+				buys++
+			}
+		}
+		m.BuyShare = float64(buys) / float64(len(signs))
+	}
+	m.SignAutocorrelation = autocorrelation(signs, microMaxLag)
+}
+
+func renderMicrostructure(m microstructure) {
+	cents := func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) + "¢" }
+	spreadRow := func(name string, s spreadSummary) []string {
+		if s.Samples == 0 {
+			return []string{name, "0", "-", "-", "-", "-"}
+		}
+		return []string{name, strconv.Itoa(s.Samples), cents(s.Mean), cents(s.Median), cents(s.Min), cents(s.Max)}
+	}
+
+	fmt.Fprintln(ui.Writer(), ui.TitleStyle.Render(fmt.Sprintf("%s, %s to %s", m.Ticker,
+		m.From.Local().Format("2006-01-02 15:04"), m.To.Local().Format("2006-01-02 15:04"))))
+	ui.RenderTable(
+		[]string{"Spread", "Samples", "Mean", "Median", "Min", "Max"},
+		[][]string{spreadRow("Quoted", m.QuotedSpread), spreadRow("Effective", m.EffectiveSpread)},
+	)
+	if m.EffectiveSpread.Samples > 0 {
+		fmt.Fprintf(ui.Writer(), "Volume-weighted effective spread: %s\n", cents(m.EffectiveSpread.VolumeWeighted))
+	}
+
+	fmt.Fprintln(ui.Writer())
+	ui.RenderKeyValue([][]string{
+		{ui.BoldStyle.Render("Book Imbalance (top 5):"), fmt.Sprintf("%+.2f (%d bid / %d ask)", m.Imbalance.Top, m.Imbalance.TopBidDepth, m.Imbalance.TopAskDepth)},
+		{ui.BoldStyle.Render("Book Imbalance (all):"), fmt.Sprintf("%+.2f (%d bid / %d ask)", m.Imbalance.Total, m.Imbalance.BidDepth, m.Imbalance.AskDepth)},
+		{ui.BoldStyle.Render("Trades:"), strconv.Itoa(m.Trades)},
+		{ui.BoldStyle.Render("YES Taker Share:"), ui.FormatPercent(m.BuyShare)},
+	})
+
+	fmt.Fprintln(ui.Writer())
+	rows := make([][]string, len(m.SignAutocorrelation))
+	for i, ac := range m.SignAutocorrelation {
+		rows[i] = []string{strconv.Itoa(i + 1), fmt.Sprintf("%+.3f", ac)}
+	}
+	ui.RenderTable([]string{"Lag", "Trade Sign Autocorrelation"}, rows)
+
+	layout := chartTimeLayout(m.To.Sub(m.From))
+	chart := func(title string, times []time.Time, values []float64) {
+		starts, means := bucketMeans(times, values, m.From, m.To, microChartPoints)
+		labels := make([]string, len(starts))
+		ints := make([]int, len(means))
+		for i := range starts {
+			labels[i] = starts[i].Local().Format(layout)
+			ints[i] = int(math.Round(means[i]))
+		}
+		ui.RenderBarChart(title, labels, ints)
+	}
+
+	times := make([]time.Time, len(m.QuotedSpreads))
+	values := make([]float64, len(m.QuotedSpreads))
+	for i, p := range m.QuotedSpreads {
+		times[i], values[i] = p.Time, float64(p.Spread)
+	}
+	chart("Quoted Spread (cents)", times, values)
+
+	times = make([]time.Time, len(m.effective))
+	values = make([]float64, len(m.effective))
+	for i, p := range m.effective {
+		times[i], values[i] = p.Time, p.Spread
+	}
+	chart("Effective Spread (cents)", times, values)
+}
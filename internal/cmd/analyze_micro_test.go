@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestQuotedAndEffectiveSpreads(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return start.Add(time.Duration(m) * time.Minute) }
+
+	quotes := quotedSpreads([]models.Candlestick{
+		{PeriodEnd: at(1), YesBid: 40, YesAsk: 44},
+		{PeriodEnd: at(2), YesBid: 0, YesAsk: 44}, // no bid
+		{PeriodEnd: at(3), YesBid: 45, YesAsk: 47},
+	})
+	if len(quotes) != 2 || quotes[0].Spread != 4 || quotes[1].Spread != 2 {
+		t.Fatalf("quotes = %+v", quotes)
+	}
+
+	trades := []models.Trade{
+		{Price: 50, Count: 1, CreatedTime: at(0)}, // before any quote
+		{Price: 44, Count: 3, CreatedTime: at(1)},
+		{Price: 45, Count: 1, CreatedTime: at(4)},
+	}
+	eff := effectiveSpreads(trades, quotes)
+	if len(eff) != 2 || eff[0].Spread != 4 || eff[1].Spread != 2 {
+		t.Fatalf("effective = %+v", eff)
+	}
+
+	s := summarize([]float64{eff[0].Spread, eff[1].Spread}, []int{3, 1})
+	if s.Mean != 3 || s.Median != 3 || s.Min != 2 || s.Max != 4 || s.VolumeWeighted != 3.5 {
+		t.Errorf("summary = %+v", s)
+	}
+}
+
+func TestAutocorrelation(t *testing.T) {
+	alternating := autocorrelation([]float64{1, -1, 1, -1, 1, -1}, 2)
+	if alternating[0] >= 0 || alternating[1] <= 0 {
+		t.Errorf("alternating = %v, want negative then positive", alternating)
+	}
+	if got := autocorrelation([]float64{1, 1, 1}, 2); got[0] != 0 || got[1] != 0 {
+		t.Errorf("constant = %v, want zeros", got)
+	}
+	if got := autocorrelation([]float64{1, -1}, 3); len(got) != 3 || got[2] != 0 {
+		t.Errorf("short = %v", got)
+	}
+}
+
+func TestImbalance(t *testing.T) {
+	book := &models.Orderbook{
+		YesBids: []models.OrderbookLevel{{Price: 45, Quantity: 30}, {Price: 44, Quantity: 10}},
+		YesAsks: []models.OrderbookLevel{{Price: 47, Quantity: 10}, {Price: 48, Quantity: 50}},
+	}
+	b := imbalance(book, 1)
+	if b.Top != 0.5 || b.BidDepth != 40 || b.AskDepth != 60 || math.Abs(b.Total+0.2) > 1e-9 {
+		t.Errorf("imbalance = %+v", b)
+	}
+	if got := imbalance(&models.Orderbook{}, 5); got.Top != 0 || got.Total != 0 {
+		t.Errorf("empty book = %+v", got)
+	}
+}
+
+func TestBucketMeans(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{from, from.Add(time.Hour), from.Add(3 * time.Hour)}
+	starts, means := bucketMeans(times, []float64{2, 4, 6}, from, from.Add(4*time.Hour), 2)
+	if len(starts) != 2 || means[0] != 3 || means[1] != 6 || !starts[1].Equal(from.Add(2*time.Hour)) {
+		t.Errorf("buckets = %v %v", starts, means)
+	}
+}
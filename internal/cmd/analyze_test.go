@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestYesBid(t *testing.T) {
+	tests := []struct {
+		side   models.OrderSide
+		action models.OrderAction
+		bid    bool
+		price  int
+	}{
+		{models.OrderSideYes, models.OrderActionBuy, true, 40},
+		{models.OrderSideYes, models.OrderActionSell, false, 40},
+		{models.OrderSideNo, models.OrderActionBuy, false, 70},
+		{models.OrderSideNo, models.OrderActionSell, true, 70},
+	}
+	for _, tt := range tests {
+		bid, price := yesBid(models.Order{Side: tt.side, Action: tt.action, YesPrice: 40, NoPrice: 30})
+		if bid != tt.bid || price != tt.price {
+			t.Errorf("yesBid(%s %s) = %v, %d; want %v, %d", tt.action, tt.side, bid, price, tt.bid, tt.price)
+		}
+	}
+}
+
+func TestSimulateQueue(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := since.Add(10 * time.Hour)
+	trade := func(h, price, count int, taker string) models.Trade {
+		return models.Trade{Price: price, Count: count, TakerSide: taker, CreatedTime: since.Add(time.Duration(h) * time.Hour)}
+	}
+
+	t.Run("queue then fill at level", func(t *testing.T) {
+		trades := []models.Trade{
+			trade(1, 40, 30, "no"),
+			trade(2, 41, 99, "no"),  // above a 40 bid: not ours
+			trade(3, 40, 50, "yes"), // taker bought: hits offers, not bids
+			trade(4, 40, 25, "no"),
+			trade(6, 40, 10, "no"),
+		}
+		sim := simulateQueue(trades, true, 40, 50, 10, since, now)
+		if sim.WouldFill != 10 || sim.Trades != 3 || sim.LevelVolume != 65 {
+			t.Fatalf("sim = %+v", sim)
+		}
+		if !sim.FirstFill.Equal(since.Add(4*time.Hour)) || !sim.FullFill.Equal(since.Add(6*time.Hour)) {
+			t.Errorf("first/full fill = %v/%v", sim.FirstFill, sim.FullFill)
+		}
+		if sim.RatePerHour != 6.5 || *sim.EstimatedHours != 60.0/6.5 {
+			t.Errorf("rate/estimate = %v/%v", sim.RatePerHour, *sim.EstimatedHours)
+		}
+	})
+
+	t.Run("trade through clears the level", func(t *testing.T) {
+		sim := simulateQueue([]models.Trade{trade(2, 62, 1, "yes")}, false, 60, 500, 20, since, now)
+		if sim.WouldFill != 20 || sim.ThroughVolume != 1 || sim.FullFill == nil {
+			t.Errorf("sim = %+v", sim)
+		}
+	})
+
+	t.Run("never reaches the order", func(t *testing.T) {
+		sim := simulateQueue([]models.Trade{trade(1, 40, 5, "no")}, true, 40, 50, 10, since, now)
+		if sim.WouldFill != 0 || sim.FirstFill != nil || sim.FullFill != nil {
+			t.Errorf("sim = %+v", sim)
+		}
+		if sim := simulateQueue(nil, true, 40, 50, 10, since, now); sim.EstimatedHours != nil {
+			t.Errorf("estimate with no trades = %v, want none", *sim.EstimatedHours)
+		}
+	})
+}
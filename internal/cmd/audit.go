@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/audit"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the local audit log of mutating requests",
+	Long: `Every order create/amend/cancel, transfer, RFQ/quote, and API key operation is
+recorded to ~/.kalshi/audit.jsonl, including requests refused locally.
+
+Entries are hash-chained: each entry stores the hash of the one before it, so
+edits or deletions are detected by 'kalshi-cli audit verify'. Disable recording
+with 'kalshi-cli config set audit.enabled false'.`,
+}
+
+var auditShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show recorded audit entries",
+	Example: `  kalshi-cli audit show
+  kalshi-cli audit show --since 24h --limit 20
+  kalshi-cli audit show --request-id 3f9c2a7e41d08b65
+  kalshi-cli audit show --json`,
+	RunE: runAuditShow,
+}
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the audit log hash chain",
+	Long:  `Recompute every entry hash and check the chain. Exits non-zero if the log was modified.`,
+	RunE:  runAuditVerify,
+}
+
+var (
+	auditSince     string
+	auditLimit     int
+	auditRequestID string
+)
+
+// currentCommand is the command path of this invocation (e.g. "orders create")
+var currentCommand string
+
+var (
+	sessionAuditOnce sync.Once
+	sessionAuditLog  *audit.Log
+)
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditShowCmd)
+	auditCmd.AddCommand(auditVerifyCmd)
+
+	auditShowCmd.Flags().StringVar(&auditSince, "since", "", "only show entries at or after this time (e.g. 24h, 7d, yesterday, last monday)")
+	auditShowCmd.Flags().IntVar(&auditLimit, "limit", 0, "show only the most recent N entries (0 = all)")
+	auditShowCmd.Flags().StringVar(&auditRequestID, "request-id", "", "only show the entry for this request ID (as printed in errors and --verbose logs)")
+}
+
+func auditLog() (*audit.Log, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return audit.NewLog(audit.DefaultPath(dir)), nil
+}
+
+// auditHook returns an api.AuditFunc that appends to the shared audit log.
+// A failed write is always reported, since a silent gap defeats the log.
+func auditHook(signer *api.Signer) api.AuditFunc {
+	sessionAuditOnce.Do(func() {
+		log, err := auditLog()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: audit log unavailable: %v\n", err)
+			return
+		}
+		sessionAuditLog = log
+	})
+
+	apiKeyID := ""
+	if signer != nil {
+		apiKeyID = signer.APIKeyID()
+	}
+
+	return func(event api.AuditEvent) {
+		if sessionAuditLog == nil {
+			return
+		}
+
+		entry := audit.Entry{
+			Time:        event.Time,
+			Environment: cfg.Environment(),
+			Command:     currentCommand,
+			APIKeyID:    apiKeyID,
+			Method:      event.Method,
+			Path:        event.Path,
+			Body:        event.Body,
+			Status:      event.StatusCode,
+			RequestID:   event.RequestID,
+		}
+		if event.Err != nil {
+			entry.Error = event.Err.Error()
+		}
+
+		if _, err := sessionAuditLog.Append(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to write audit log: %v\n", err)
+		}
+	}
+}
+
+func runAuditShow(cmd *cobra.Command, args []string) error {
+	var since time.Time
+	if auditSince != "" {
+		t, err := parseSinceArg(auditSince)
+		if err != nil {
+			return err
+		}
+		since = t
+	}
+
+	log, err := auditLog()
+	if err != nil {
+		return err
+	}
+
+	entries, err := log.Load()
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]audit.Entry, 0, len(entries))
+	for _, e := range entries {
+		if auditRequestID != "" && e.RequestID != auditRequestID {
+			continue
+		}
+		if since.IsZero() || !e.Time.Before(since) {
+			filtered = append(filtered, e)
+		}
+	}
+	if auditLimit > 0 && len(filtered) > auditLimit {
+		filtered = filtered[len(filtered)-auditLimit:]
+	}
+
+	if len(filtered) == 0 && GetOutputFormat() != ui.FormatJSON {
+		PrintWarning("No audit entries found")
+		return nil
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderAuditTable(filtered) },
+		filtered,
+		func() { renderAuditPlain(filtered) },
+	)
+}
+
+func renderAuditTable(entries []audit.Entry) {
+	headers := []string{"Seq", "Time", "Env", "Command", "Method", "Path", "Status", "Request ID", "Error"}
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		status := ""
+		if e.Status > 0 {
+			status = strconv.Itoa(e.Status)
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(e.Seq, 10),
+			formatTimeStr(e.Time.Local()),
+			e.Environment,
+			e.Command,
+			e.Method,
+			e.Path,
+			status,
+			e.RequestID,
+			e.Error,
+		})
+	}
+	ui.RenderTable(headers, rows)
+}
+
+func renderAuditPlain(entries []audit.Entry) {
+	for _, e := range entries {
+		ui.PrintPlain("%d\t%s\t%s\t%s\t%s\t%d\t%s\t%s",
+			e.Seq,
+			e.Time.Format(time.RFC3339),
+			e.Environment,
+			e.Method,
+			e.Path,
+			e.Status,
+			e.Error,
+			e.RequestID,
+		)
+	}
+}
+
+func runAuditVerify(cmd *cobra.Command, args []string) error {
+	log, err := auditLog()
+	if err != nil {
+		return err
+	}
+
+	result, err := log.Verify()
+	if err != nil {
+		return err
+	}
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() {
+			if result.Valid {
+				PrintSuccess(fmt.Sprintf("Audit log intact (%d entries)", result.Entries))
+			} else {
+				fmt.Fprintln(ui.Writer(), ui.ErrorStyle.Render(fmt.Sprintf("Audit log broken at entry %d: %s", result.BrokenAt, result.Reason)))
+			}
+		},
+		result,
+		func() {
+			ui.PrintPlain("valid=%v entries=%d broken_at=%d", result.Valid, result.Entries, result.BrokenAt)
+		},
+	); err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("audit log verification failed")
+	}
+	return nil
+}
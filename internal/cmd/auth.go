@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/6missedcalls/kalshi-cli/internal/api"
 	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/hsm"
 	"github.com/6missedcalls/kalshi-cli/internal/ui"
 )
 
@@ -46,9 +48,25 @@ Non-interactive mode (for bots/automation):
   kalshi-cli auth login --api-key-id <id> --private-key-file /path/to/key.pem
   kalshi-cli auth login --api-key-id <id> --private-key "$(cat key.pem)"
 
+Hardware-backed key (PKCS#11 token such as a YubiKey, HSM, SoftHSM or a TPM
+via tpm2-pkcs11; requires a build with -tags pkcs11):
+  kalshi-cli auth login --api-key-id <id> --pkcs11 /usr/lib/libykcs11.so --pkcs11-key kalshi
+
+The private key never leaves the token. Its public key is printed so it can be
+registered with Kalshi, and the token location is saved to config.yaml.
+
+Locally generated key (the private key never leaves this machine):
+  kalshi-cli auth login --generate-key
+  kalshi-cli auth login --generate-key --key-file ~/.kalshi/bot.pem --key-name bot
+
+The public key is registered through the API when you are already logged in,
+and otherwise printed for you to add on the Kalshi website. Only api_key_id
+and private_key_path are saved to config.yaml.
+
 Environment variables:
   KALSHI_API_KEY_ID    - API Key ID
-  KALSHI_PRIVATE_KEY   - Private key PEM content`,
+  KALSHI_PRIVATE_KEY   - Private key PEM content
+  KALSHI_PKCS11_PIN    - PKCS#11 user PIN`,
 	RunE: runLogin,
 }
 
@@ -62,8 +80,15 @@ var logoutCmd = &cobra.Command{
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show authentication status",
-	Long:  `Display the current authentication status and environment.`,
-	RunE:  runStatus,
+	Long: `Display the current authentication status and environment.
+
+When authenticated, API key expiration dates are included. Use
+--fail-if-expiring to exit non-zero when the active key expires within
+the given window, e.g. from cron.`,
+	Example: `  kalshi-cli auth status
+  kalshi-cli auth status --json
+  kalshi-cli auth status --fail-if-expiring 7d`,
+	RunE: runStatus,
 }
 
 var keysCmd = &cobra.Command{
@@ -82,8 +107,19 @@ var keysListCmd = &cobra.Command{
 var keysCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new API key",
-	Long:  `Create a new API key for your Kalshi account.`,
-	RunE:  runKeysCreate,
+	Long: `Create a new API key for your Kalshi account.
+
+The private key is shown only once. With --save-key-file it is written to a
+new file readable only by you and is not printed in any output format.
+
+--scopes limits what the key can do: read for data and portfolio reads,
+trade to also place and cancel orders. A key created without --scopes has
+full access, and a warning is printed. --expires-in sets an expiry so
+automation keys do not outlive their use.`,
+	Example: `  kalshi-cli auth keys create --name bot
+  kalshi-cli auth keys create --name dashboard --scopes read --expires-in 90d
+  kalshi-cli auth keys create --name bot --save-key-file ~/.kalshi/bot.pem --copy`,
+	RunE: runKeysCreate,
 }
 
 var keysDeleteCmd = &cobra.Command{
@@ -96,9 +132,16 @@ var keysDeleteCmd = &cobra.Command{
 
 var (
 	keyName        string
+	keySaveFile    string
+	keyScopes      []string
+	keyExpiresIn   string
 	loginAPIKeyID  string
 	loginPrivKey   string
 	loginPrivKeyFile string
+	failIfExpiring   string
+	loginPKCS11      string
+	loginPKCS11Token string
+	loginPKCS11Key   string
 )
 
 func init() {
@@ -117,11 +160,30 @@ func init() {
 	loginCmd.Flags().StringVar(&loginAPIKeyID, "api-key-id", "", "API Key ID from Kalshi (or set KALSHI_API_KEY_ID env var)")
 	loginCmd.Flags().StringVar(&loginPrivKey, "private-key", "", "Private key PEM content (or set KALSHI_PRIVATE_KEY env var)")
 	loginCmd.Flags().StringVar(&loginPrivKeyFile, "private-key-file", "", "Path to private key PEM file")
+	loginCmd.Flags().StringVar(&loginPKCS11, "pkcs11", "", "Path to a PKCS#11 module holding the private key")
+	loginCmd.Flags().StringVar(&loginPKCS11Token, "pkcs11-token", "", "PKCS#11 token label (default: first token)")
+	loginCmd.Flags().StringVar(&loginPKCS11Key, "pkcs11-key", "", "PKCS#11 private key label (default: only RSA key)")
+
+	statusCmd.Flags().StringVar(&failIfExpiring, "fail-if-expiring", "", "exit non-zero if the active API key expires within this window (e.g. 7d, 72h)")
 
 	keysCreateCmd.Flags().StringVar(&keyName, "name", "", "name for the new API key")
+	keysCreateCmd.Flags().StringSliceVar(&keyScopes, "scopes", nil, "comma-separated scopes for the key: read, trade (default: full access)")
+	keysCreateCmd.Flags().StringVar(&keyExpiresIn, "expires-in", "", "expire the key after this long (e.g. 90d, 12w)")
+	keysCreateCmd.Flags().StringVar(&keySaveFile, "save-key-file", "", "write the private key to this new file (mode 0600) instead of printing it")
+	addCopyFlag(keysCreateCmd, "API key ID")
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
+	if loginGenerateKey {
+		if loginPKCS11 != "" || loginPrivKey != "" || loginPrivKeyFile != "" {
+			return fmt.Errorf("--generate-key cannot be combined with --private-key, --private-key-file or --pkcs11")
+		}
+		return runLoginGenerate()
+	}
+	if loginPKCS11 != "" {
+		return runLoginPKCS11()
+	}
+
 	keyring, err := config.NewKeyringStore()
 	if err != nil {
 		return fmt.Errorf("failed to access keyring: %w", err)
@@ -148,8 +210,8 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save credentials: %w", err)
 	}
 
-	fmt.Println()
-	fmt.Println("Testing authentication...")
+	fmt.Fprintln(ui.Writer())
+	fmt.Fprintln(ui.Writer(), "Testing authentication...")
 
 	client, err := createAuthenticatedClient(creds)
 	if err != nil {
@@ -170,11 +232,78 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("authentication test failed: %w", err)
 	}
 
-	fmt.Println()
+	fmt.Fprintln(ui.Writer())
+	PrintSuccess("Authentication successful!")
+	fmt.Fprintf(ui.Writer(), "Exchange Active: %v\n", status.ExchangeActive)
+	fmt.Fprintf(ui.Writer(), "Trading Active: %v\n", status.TradingActive)
+	fmt.Fprintf(ui.Writer(), "Environment: %s\n", cfg.Environment())
+
+	return nil
+}
+
+// runLoginPKCS11 verifies a token-held key and saves its location to config.
+// Nothing secret is stored: the PIN is read from KALSHI_PKCS11_PIN on each run.
+func runLoginPKCS11() error {
+	apiKeyID := loginAPIKeyID
+	if apiKeyID == "" {
+		apiKeyID = os.Getenv("KALSHI_API_KEY_ID")
+	}
+	if apiKeyID == "" {
+		return fmt.Errorf("--api-key-id (or KALSHI_API_KEY_ID) is required with --pkcs11")
+	}
+
+	p11 := hsm.PKCS11Config{
+		Module:     loginPKCS11,
+		TokenLabel: loginPKCS11Token,
+		KeyLabel:   loginPKCS11Key,
+		PIN:        os.Getenv("KALSHI_PKCS11_PIN"),
+	}
+
+	key, err := hsm.OpenPKCS11(p11)
+	if err != nil {
+		return fmt.Errorf("failed to open PKCS#11 key: %w", err)
+	}
+	defer key.Close()
+
+	signer, err := api.NewSignerFromCrypto(apiKeyID, key)
+	if err != nil {
+		return fmt.Errorf("invalid PKCS#11 key: %w", err)
+	}
+
+	publicKeyPEM, err := api.EncodePublicKeyPEM(signer.PublicKey())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(ui.Writer(), ui.TitleStyle.Render("Kalshi API Authentication (PKCS#11)"))
+	fmt.Fprintf(ui.Writer(), "API Key ID: %s\n", apiKeyID)
+	fmt.Fprintln(ui.Writer())
+	fmt.Fprintln(ui.Writer(), "Public key (register this with Kalshi if you have not already):")
+	fmt.Fprintln(ui.Writer(), publicKeyPEM)
+
+	fmt.Fprintln(ui.Writer(), "Testing authentication...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client := newAPIClient(signer)
+	if _, err := client.GetBalance(ctx); err != nil {
+		return fmt.Errorf("authentication test failed: %w", err)
+	}
+
+	if err := config.Save(map[string]any{
+		"api_key_id":         apiKeyID,
+		"pkcs11.module":      p11.Module,
+		"pkcs11.token_label": p11.TokenLabel,
+		"pkcs11.key_label":   p11.KeyLabel,
+	}); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Fprintln(ui.Writer())
 	PrintSuccess("Authentication successful!")
-	fmt.Printf("Exchange Active: %v\n", status.ExchangeActive)
-	fmt.Printf("Trading Active: %v\n", status.TradingActive)
-	fmt.Printf("Environment: %s\n", cfg.Environment())
+	fmt.Fprintf(ui.Writer(), "Environment: %s\n", cfg.Environment())
+	fmt.Fprintln(ui.Writer(), "Token location saved to ~/.kalshi/config.yaml; set KALSHI_PKCS11_PIN for each run if the token requires a PIN.")
 
 	return nil
 }
@@ -185,18 +314,16 @@ func resolveLoginCredentials(keyring *config.KeyringStore) (apiKeyID, privateKey
 	if keyring.HasCredentials() {
 		existingCreds, err := keyring.GetCredentials()
 		if err == nil && existingCreds != nil {
-			fmt.Println(ui.WarningStyle.Render("You are already logged in."))
-			fmt.Printf("API Key ID: %s\n", existingCreds.APIKeyID)
-			fmt.Println()
-
-			if !SkipConfirmation() {
-				fmt.Print("Do you want to log out and enter new credentials? [y/N]: ")
-				reader := bufio.NewReader(os.Stdin)
-				response, _ := reader.ReadString('\n')
-				response = strings.TrimSpace(strings.ToLower(response))
-				if response != "y" && response != "yes" {
-					return "", "", fmt.Errorf("login cancelled")
-				}
+			fmt.Fprintln(ui.Writer(), ui.WarningStyle.Render("You are already logged in."))
+			fmt.Fprintf(ui.Writer(), "API Key ID: %s\n", existingCreds.APIKeyID)
+			fmt.Fprintln(ui.Writer())
+
+			confirmed, err := confirmAction("Do you want to log out and enter new credentials?")
+			if err != nil {
+				return "", "", err
+			}
+			if !confirmed {
+				return "", "", fmt.Errorf("login cancelled")
 			}
 
 			if err := keyring.DeleteCredentials(); err != nil {
@@ -226,12 +353,16 @@ func resolveLoginCredentials(keyring *config.KeyringStore) (apiKeyID, privateKey
 
 	// If we have both from non-interactive sources, we're done
 	if apiKeyID != "" && privateKeyPEM != "" {
-		fmt.Println(ui.TitleStyle.Render("Kalshi API Authentication (non-interactive)"))
-		fmt.Printf("API Key ID: %s\n", apiKeyID)
+		fmt.Fprintln(ui.Writer(), ui.TitleStyle.Render("Kalshi API Authentication (non-interactive)"))
+		fmt.Fprintf(ui.Writer(), "API Key ID: %s\n", apiKeyID)
 		return apiKeyID, privateKeyPEM, nil
 	}
 
 	// Interactive mode
+	if err := requireInput("API key ID and private key (use --api-key-id and --private-key-file)"); err != nil {
+		return "", "", err
+	}
+
 	fmt.Println(ui.TitleStyle.Render("Kalshi API Authentication"))
 	fmt.Println()
 	fmt.Println("API keys are provisioned by Kalshi. If you don't have credentials yet:")
@@ -327,19 +458,17 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	}
 
 	if !keyring.HasCredentials() {
-		fmt.Println("You are not logged in.")
+		fmt.Fprintln(ui.Writer(), "You are not logged in.")
 		return nil
 	}
 
-	if !SkipConfirmation() {
-		fmt.Print("Are you sure you want to log out? [y/N]: ")
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "y" && response != "yes" {
-			fmt.Println("Logout cancelled.")
-			return nil
-		}
+	confirmed, err := confirmAction("Are you sure you want to log out?")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Fprintln(ui.Writer(), "Logout cancelled.")
+		return nil
 	}
 
 	if err := keyring.DeleteCredentials(); err != nil {
@@ -351,44 +480,143 @@ func runLogout(cmd *cobra.Command, args []string) error {
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
-	keyring, err := config.NewKeyringStore()
-	if err != nil {
-		return fmt.Errorf("failed to access keyring: %w", err)
+	var expiryWindow time.Duration
+	if failIfExpiring != "" {
+		window, err := parseLookback(failIfExpiring)
+		if err != nil {
+			return fmt.Errorf("invalid --fail-if-expiring value: %w", err)
+		}
+		expiryWindow = window
 	}
 
-	creds, err := keyring.GetCredentials()
-	if err != nil {
-		return fmt.Errorf("failed to get credentials: %w", err)
-	}
+	statusData := authStatusData{Environment: cfg.Environment()}
+	var client *api.Client
+	if config.KeyringDisabled() {
+		// Without the keyring, report on the credentials commands would use
+		if signer, _, err := resolveSigner(); err == nil {
+			statusData.LoggedIn = true
+			statusData.APIKeyID = signer.APIKeyID()
+			client = newAPIClient(signer)
+		}
+	} else {
+		keyring, err := config.NewKeyringStore()
+		if err != nil {
+			return fmt.Errorf("failed to access keyring: %w", err)
+		}
 
-	statusData := authStatusData{
-		LoggedIn:    creds != nil,
-		Environment: cfg.Environment(),
+		creds, err := keyring.GetCredentials()
+		if err != nil {
+			return fmt.Errorf("failed to get credentials: %w", err)
+		}
+		if creds != nil {
+			statusData.LoggedIn = true
+			statusData.APIKeyID = creds.APIKeyID
+			client, _ = createAuthenticatedClient(*creds)
+		}
 	}
 
-	if creds != nil {
-		statusData.APIKeyID = creds.APIKeyID
-
-		client, err := createAuthenticatedClient(*creds)
-		if err == nil {
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
-
-			exchangeStatus, err := client.GetExchangeStatus(ctx)
-			if err == nil {
-				statusData.ExchangeActive = exchangeStatus.ExchangeActive
-				statusData.TradingActive = exchangeStatus.TradingActive
-				statusData.Authenticated = true
-			}
-		}
+	var keysErr error
+	if client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		keysErr = fetchAuthStatus(ctx, client, &statusData, time.Now(), expiryWindow)
 	}
 
-	return ui.Output(
+	if err := ui.Output(
 		outputFmt,
 		func() { renderStatusTable(statusData) },
 		statusData,
 		func() { renderStatusPlain(statusData) },
-	)
+	); err != nil {
+		return err
+	}
+
+	if failIfExpiring == "" {
+		return nil
+	}
+	return keyExpiryError(statusData, keysErr)
+}
+
+// fetchAuthStatus fills in whether the credentials are accepted, the
+// exchange status and the expiry of the account's API keys. Authentication
+// is judged by listing the API keys, a signed call, since the exchange
+// status is public and succeeds with any key. The listing's error is
+// returned.
+func fetchAuthStatus(ctx context.Context, client *api.Client, data *authStatusData, now time.Time, window time.Duration) error {
+	if exchangeStatus, err := client.GetExchangeStatus(ctx); err == nil {
+		data.ExchangeActive = exchangeStatus.ExchangeActive
+		data.TradingActive = exchangeStatus.TradingActive
+	}
+
+	keys, err := client.ListAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+	data.Authenticated = true
+	for _, key := range keys {
+		keyStatus := newAPIKeyStatus(key, data.APIKeyID, now, window)
+		if keyStatus.Active {
+			data.KeyExpiresTime = keyStatus.ExpiresTime
+			data.DaysUntilExpiry = keyStatus.DaysUntilExpiry
+			data.Expiring = keyStatus.Expiring
+		}
+		data.Keys = append(data.Keys, keyStatus)
+	}
+	return nil
+}
+
+// keyExpiryError is the --fail-if-expiring verdict: an error unless the
+// active key was found among the account's keys and is not expiring
+func keyExpiryError(data authStatusData, keysErr error) error {
+	if !data.LoggedIn {
+		return fmt.Errorf("cannot check key expiry: not logged in")
+	}
+	if keysErr != nil {
+		return fmt.Errorf("cannot check key expiry: failed to list API keys: %w", keysErr)
+	}
+	active := false
+	for _, k := range data.Keys {
+		active = active || k.Active
+	}
+	if !active {
+		return fmt.Errorf("cannot check key expiry: API key %s is not among the account's API keys", data.APIKeyID)
+	}
+	if data.Expiring {
+		return fmt.Errorf("API key %s expires in %d day(s) (%s)",
+			data.APIKeyID, *data.DaysUntilExpiry, data.KeyExpiresTime.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// apiKeyStatus describes an API key and how soon it expires
+type apiKeyStatus struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Active          bool       `json:"active"`
+	ExpiresTime     *time.Time `json:"expires_time,omitempty"`
+	DaysUntilExpiry *int       `json:"days_until_expiry,omitempty"`
+	Expiring        bool       `json:"expiring"`
+}
+
+// newAPIKeyStatus computes expiry information for key. A key is expiring when
+// it expires within window; keys without an expiration never expire.
+func newAPIKeyStatus(key api.APIKey, activeKeyID string, now time.Time, window time.Duration) apiKeyStatus {
+	status := apiKeyStatus{
+		ID:     key.ID,
+		Name:   key.Name,
+		Active: key.ID == activeKeyID,
+	}
+
+	if key.ExpiresTime.IsZero() {
+		return status
+	}
+
+	expires := key.ExpiresTime.Time
+	days := int(math.Floor(expires.Sub(now).Hours() / 24))
+	status.ExpiresTime = &expires
+	status.DaysUntilExpiry = &days
+	status.Expiring = window > 0 && expires.Sub(now) <= window
+	return status
 }
 
 type authStatusData struct {
@@ -398,6 +626,11 @@ type authStatusData struct {
 	Authenticated  bool   `json:"authenticated"`
 	ExchangeActive bool   `json:"exchange_active"`
 	TradingActive  bool   `json:"trading_active"`
+
+	KeyExpiresTime  *time.Time     `json:"key_expires_time,omitempty"`
+	DaysUntilExpiry *int           `json:"days_until_expiry,omitempty"`
+	Expiring        bool           `json:"expiring"`
+	Keys            []apiKeyStatus `json:"keys,omitempty"`
 }
 
 func renderStatusTable(data authStatusData) {
@@ -434,6 +667,16 @@ func renderStatusTable(data authStatusData) {
 				tradingStatus = "Active"
 			}
 			pairs = append(pairs, []string{"Trading", tradingStatus})
+
+			if data.KeyExpiresTime != nil {
+				expiry := fmt.Sprintf("%s (%d days)", formatTimeStr(data.KeyExpiresTime.Local()), *data.DaysUntilExpiry)
+				if data.Expiring || *data.DaysUntilExpiry < 0 {
+					expiry = ui.ErrorStyle.Render(expiry)
+				}
+				pairs = append(pairs, []string{"Key Expires", expiry})
+			} else if len(data.Keys) > 0 {
+				pairs = append(pairs, []string{"Key Expires", "Never"})
+			}
 		}
 	}
 
@@ -442,17 +685,22 @@ func renderStatusTable(data authStatusData) {
 
 func renderStatusPlain(data authStatusData) {
 	if data.LoggedIn {
-		fmt.Printf("logged_in=true\n")
-		fmt.Printf("api_key_id=%s\n", data.APIKeyID)
-		fmt.Printf("environment=%s\n", data.Environment)
-		fmt.Printf("authenticated=%v\n", data.Authenticated)
+		fmt.Fprintf(ui.Writer(), "logged_in=true\n")
+		fmt.Fprintf(ui.Writer(), "api_key_id=%s\n", data.APIKeyID)
+		fmt.Fprintf(ui.Writer(), "environment=%s\n", data.Environment)
+		fmt.Fprintf(ui.Writer(), "authenticated=%v\n", data.Authenticated)
 		if data.Authenticated {
-			fmt.Printf("exchange_active=%v\n", data.ExchangeActive)
-			fmt.Printf("trading_active=%v\n", data.TradingActive)
+			fmt.Fprintf(ui.Writer(), "exchange_active=%v\n", data.ExchangeActive)
+			fmt.Fprintf(ui.Writer(), "trading_active=%v\n", data.TradingActive)
+			if data.KeyExpiresTime != nil {
+				fmt.Fprintf(ui.Writer(), "key_expires_time=%s\n", data.KeyExpiresTime.Format(time.RFC3339))
+				fmt.Fprintf(ui.Writer(), "days_until_expiry=%d\n", *data.DaysUntilExpiry)
+				fmt.Fprintf(ui.Writer(), "expiring=%v\n", data.Expiring)
+			}
 		}
 	} else {
-		fmt.Printf("logged_in=false\n")
-		fmt.Printf("environment=%s\n", data.Environment)
+		fmt.Fprintf(ui.Writer(), "logged_in=false\n")
+		fmt.Fprintf(ui.Writer(), "environment=%s\n", data.Environment)
 	}
 }
 
@@ -505,71 +753,208 @@ func renderKeysTable(keys []api.APIKey) {
 
 func renderKeysPlain(keys []api.APIKey) {
 	for _, key := range keys {
-		fmt.Printf("%s\t%s\t%s\n", key.ID, key.Name, key.CreatedTime.Format("2006-01-02"))
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\n", key.ID, key.Name, key.CreatedTime.Format("2006-01-02"))
 	}
 }
 
 func runKeysCreate(cmd *cobra.Command, args []string) error {
+	req, err := buildCreateAPIKeyRequest(keyName, keyScopes, keyExpiresIn, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(req.Scopes) == 0 {
+		fmt.Fprintln(os.Stderr, ui.WarningStyle.Render("Warning: creating an unscoped key with full account access; use --scopes read or --scopes read,trade to limit it"))
+	}
+
 	client, err := getAuthenticatedClient()
 	if err != nil {
 		return err
 	}
 
+	// Create the key file first so a bad path fails before the key exists
+	var keyFile *os.File
+	if keySaveFile != "" {
+		keyFile, err = createKeyFile(keySaveFile)
+		if err != nil {
+			return err
+		}
+		defer keyFile.Close()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	req := api.CreateAPIKeyRequest{
-		Name: keyName,
-	}
-
 	resp, err := client.CreateAPIKey(ctx, req)
 	if err != nil {
+		if keyFile != nil {
+			keyFile.Close()
+			os.Remove(keyFile.Name())
+		}
 		return fmt.Errorf("failed to create API key: %w", err)
 	}
 
-	return ui.Output(
+	if keyFile != nil {
+		if err := writeKeyFile(keyFile, resp.PrivateKey); err != nil {
+			// The key cannot be fetched again, so fall back to showing it
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintln(os.Stderr, "The private key is printed below instead.")
+		} else {
+			saved := keyCreatedSaved{APIKey: resp.APIKey, PrivateKeyFile: keyFile.Name()}
+			err := ui.Output(
+				outputFmt,
+				func() { renderKeySavedTable(saved) },
+				saved,
+				func() { renderKeySavedPlain(saved) },
+			)
+			copyValue("API key ID", resp.APIKey.ID)
+			return err
+		}
+	}
+
+	err = ui.Output(
 		outputFmt,
 		func() { renderKeyCreatedTable(resp) },
 		resp,
 		func() { renderKeyCreatedPlain(resp) },
 	)
+	copyValue("API key ID", resp.APIKey.ID)
+	return err
+}
+
+// buildCreateAPIKeyRequest validates the keys create flags. Scopes are
+// lowercased and deduplicated; expiresIn is a span such as 90d, counted
+// from now.
+func buildCreateAPIKeyRequest(name string, scopes []string, expiresIn string, now time.Time) (api.CreateAPIKeyRequest, error) {
+	req := api.CreateAPIKeyRequest{Name: name}
+
+	seen := make(map[string]bool)
+	for _, s := range scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		switch s {
+		case "":
+			continue
+		case api.ScopeRead, api.ScopeTrade:
+		default:
+			return req, fmt.Errorf("unknown scope %q (use %s or %s)", s, api.ScopeRead, api.ScopeTrade)
+		}
+		if !seen[s] {
+			seen[s] = true
+			req.Scopes = append(req.Scopes, s)
+		}
+	}
+
+	if expiresIn != "" {
+		d, err := parseSpan(expiresIn)
+		if err != nil || d <= 0 {
+			return req, fmt.Errorf("invalid --expires-in %q: use a positive duration such as 90d or 12w", expiresIn)
+		}
+		req.ExpiresTime = &api.JSONTime{Time: now.Add(d).UTC().Truncate(time.Second)}
+	}
+	return req, nil
+}
+
+// keyCreatedSaved is the output of keys create when the private key was
+// written to a file
+type keyCreatedSaved struct {
+	APIKey         api.APIKey `json:"api_key"`
+	PrivateKeyFile string     `json:"private_key_file"`
+}
+
+// createKeyFile creates a new file for a private key, readable only by the
+// owner. An existing file is never overwritten.
+func createKeyFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, fmt.Errorf("key file %s already exists", path)
+		}
+		return nil, fmt.Errorf("failed to create key file: %w", err)
+	}
+	return f, nil
+}
+
+// writeKeyFile writes a PEM to a file from createKeyFile and syncs it
+func writeKeyFile(f *os.File, pem string) error {
+	if !strings.HasSuffix(pem, "\n") {
+		pem += "\n"
+	}
+	if _, err := f.WriteString(pem); err != nil {
+		return fmt.Errorf("failed to write key file %s: %w", f.Name(), err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("failed to write key file %s: %w", f.Name(), err)
+	}
+	return nil
+}
+
+// keyLimitPairs describes a new key's scopes and expiry
+func keyLimitPairs(key api.APIKey) [][]string {
+	scopes := strings.Join(key.Scopes, ", ")
+	if scopes == "" {
+		scopes = "full access"
+	}
+	expires := "never"
+	if !key.ExpiresTime.IsZero() {
+		expires = key.ExpiresTime.Format("2006-01-02 15:04:05")
+	}
+	return [][]string{{"Scopes", scopes}, {"Expires", expires}}
 }
 
 func renderKeyCreatedTable(resp *api.CreateAPIKeyResponse) {
 	PrintSuccess("API key created successfully!")
-	fmt.Println()
+	fmt.Fprintln(ui.Writer())
 
 	pairs := [][]string{
 		{"ID", resp.APIKey.ID},
 		{"Name", resp.APIKey.Name},
 		{"Created", resp.APIKey.CreatedTime.Format("2006-01-02 15:04:05")},
 	}
-	ui.RenderKeyValue(pairs)
+	ui.RenderKeyValue(append(pairs, keyLimitPairs(resp.APIKey)...))
 
-	fmt.Println()
-	fmt.Println(ui.WarningStyle.Render("IMPORTANT: Save the private key below. It will not be shown again!"))
-	fmt.Println()
-	fmt.Println(resp.PrivateKey)
+	fmt.Fprintln(ui.Writer())
+	fmt.Fprintln(ui.Writer(), ui.WarningStyle.Render("IMPORTANT: Save the private key below. It will not be shown again!"))
+	fmt.Fprintln(ui.Writer())
+	fmt.Fprintln(ui.Writer(), resp.PrivateKey)
 }
 
 func renderKeyCreatedPlain(resp *api.CreateAPIKeyResponse) {
-	fmt.Printf("id=%s\n", resp.APIKey.ID)
-	fmt.Printf("name=%s\n", resp.APIKey.Name)
-	fmt.Printf("private_key=%s\n", resp.PrivateKey)
+	fmt.Fprintf(ui.Writer(), "id=%s\n", resp.APIKey.ID)
+	fmt.Fprintf(ui.Writer(), "name=%s\n", resp.APIKey.Name)
+	fmt.Fprintf(ui.Writer(), "private_key=%s\n", resp.PrivateKey)
+}
+
+func renderKeySavedTable(saved keyCreatedSaved) {
+	PrintSuccess("API key created successfully!")
+	fmt.Fprintln(ui.Writer())
+
+	pairs := [][]string{
+		{"ID", saved.APIKey.ID},
+		{"Name", saved.APIKey.Name},
+		{"Created", saved.APIKey.CreatedTime.Format("2006-01-02 15:04:05")},
+	}
+	pairs = append(pairs, keyLimitPairs(saved.APIKey)...)
+	ui.RenderKeyValue(append(pairs, []string{"Private Key", saved.PrivateKeyFile}))
+
+	fmt.Fprintln(ui.Writer())
+	fmt.Fprintln(ui.Writer(), ui.WarningStyle.Render("Keep the private key file safe. It cannot be downloaded again."))
+}
+
+func renderKeySavedPlain(saved keyCreatedSaved) {
+	fmt.Fprintf(ui.Writer(), "id=%s\n", saved.APIKey.ID)
+	fmt.Fprintf(ui.Writer(), "name=%s\n", saved.APIKey.Name)
+	fmt.Fprintf(ui.Writer(), "private_key_file=%s\n", saved.PrivateKeyFile)
 }
 
 func runKeysDelete(cmd *cobra.Command, args []string) error {
 	keyID := args[0]
 
-	if !SkipConfirmation() {
-		fmt.Printf("Are you sure you want to delete API key '%s'? [y/N]: ", keyID)
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "y" && response != "yes" {
-			fmt.Println("Delete cancelled.")
-			return nil
-		}
+	confirmed, err := confirmAction(fmt.Sprintf("Are you sure you want to delete API key '%s'?", keyID))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Fprintln(ui.Writer(), "Delete cancelled.")
+		return nil
 	}
 
 	client, err := getAuthenticatedClient()
@@ -593,11 +978,14 @@ func runKeysDelete(cmd *cobra.Command, args []string) error {
 		outputFmt,
 		func() { PrintSuccess(fmt.Sprintf("API key '%s' deleted successfully.", keyID)) },
 		result,
-		func() { fmt.Printf("deleted=%s\n", keyID) },
+		func() { fmt.Fprintf(ui.Writer(), "deleted=%s\n", keyID) },
 	)
 }
 
 func getAuthenticatedClient() (*api.Client, error) {
+	if config.KeyringDisabled() {
+		return createClient()
+	}
 	keyring, err := config.NewKeyringStore()
 	if err != nil {
 		return nil, fmt.Errorf("failed to access keyring: %w", err)
@@ -621,6 +1009,6 @@ func createAuthenticatedClient(creds config.Credentials) (*api.Client, error) {
 		return nil, fmt.Errorf("failed to create signer: %w", err)
 	}
 
-	client := api.NewClient(cfg, signer)
+	client := newAPIClient(signer)
 	return client, nil
 }
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var authExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export credentials and config to an encrypted bundle",
+	Long: `Write the keyring credentials, config.yaml and the private key file it
+references (private_key_path) to a single passphrase-encrypted bundle, so the
+setup can be moved to another machine with 'auth import' without
+re-provisioning API keys.
+
+The bundle is encrypted with AES-256-GCM under a key derived from the
+passphrase (PBKDF2-SHA256). Keys held on a PKCS#11 token never leave the
+token; only the token location in config.yaml is exported.
+
+Environment variables:
+  KALSHI_BUNDLE_PASSPHRASE  - passphrase, instead of prompting`,
+	Example: `  kalshi-cli auth export --encrypt laptop.kalshi`,
+	RunE:    runAuthExport,
+}
+
+var authImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import credentials and config from an encrypted bundle",
+	Long: `Decrypt a bundle written by 'auth export' and install it: credentials are
+saved to the system keyring, config.yaml is written to the config file in use
+(--config, or config.yaml in the config directory; see 'config paths'), and
+the private key file is restored to the config directory, with
+private_key_path updated to point at it. An existing key file with different
+contents is never overwritten.
+
+Environment variables:
+  KALSHI_BUNDLE_PASSPHRASE  - passphrase, instead of prompting`,
+	Example: `  kalshi-cli auth import laptop.kalshi`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runAuthImport,
+}
+
+var exportEncrypt string
+
+func init() {
+	authCmd.AddCommand(authExportCmd)
+	authCmd.AddCommand(authImportCmd)
+
+	authExportCmd.Flags().StringVar(&exportEncrypt, "encrypt", "", "path of the encrypted bundle to write (required)")
+	authExportCmd.MarkFlagRequired("encrypt")
+}
+
+// readPassphrase reads the bundle passphrase from KALSHI_BUNDLE_PASSPHRASE or
+// the terminal without echo, asking twice when confirm is set
+func readPassphrase(confirm bool) (string, error) {
+	if p := os.Getenv("KALSHI_BUNDLE_PASSPHRASE"); p != "" {
+		return p, nil
+	}
+	if err := requireInput("bundle passphrase (set KALSHI_BUNDLE_PASSPHRASE)"); err != nil {
+		return "", err
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("cannot prompt for a passphrase without a terminal; set KALSHI_BUNDLE_PASSPHRASE")
+	}
+
+	fmt.Fprint(os.Stderr, "Bundle passphrase: ")
+	p, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if len(p) == 0 {
+		return "", fmt.Errorf("passphrase is required")
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Confirm passphrase: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		if string(again) != string(p) {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return string(p), nil
+}
+
+// collectBundle gathers everything auth export writes
+func collectBundle() (config.Bundle, error) {
+	b := config.Bundle{CreatedAt: time.Now().UTC()}
+
+	// Without a keyring there are no keyring credentials, but config.yaml
+	// and the key file can still be exported
+	if keyring, err := config.NewKeyringStore(); err != nil {
+		if !errors.Is(err, config.ErrKeyringDisabled) {
+			PrintWarning(fmt.Sprintf("Keyring unavailable, exporting without keyring credentials: %v", err))
+		}
+	} else if b.Credentials, err = keyring.GetCredentials(); err != nil {
+		PrintWarning(fmt.Sprintf("Keyring unavailable, exporting without keyring credentials: %v", err))
+	}
+
+	configPath, err := config.ConfigFile()
+	if err != nil {
+		return b, err
+	}
+	if data, err := os.ReadFile(configPath); err == nil {
+		b.Config = data
+	} else if !os.IsNotExist(err) {
+		return b, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if keyPath := viper.GetString("private_key_path"); keyPath != "" {
+		pem, err := os.ReadFile(keyPath)
+		if err != nil {
+			return b, fmt.Errorf("failed to read private key file %s: %w", keyPath, err)
+		}
+		b.KeyFile = &config.KeyFile{Path: keyPath, PEM: string(pem)}
+	}
+
+	if b.Credentials == nil && b.Config == nil && b.KeyFile == nil {
+		return b, fmt.Errorf("nothing to export: no keyring credentials or config file found")
+	}
+	return b, nil
+}
+
+func runAuthExport(cmd *cobra.Command, args []string) error {
+	b, err := collectBundle()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(exportEncrypt); err == nil {
+		confirmed, err := confirmAction(fmt.Sprintf("%s already exists. Overwrite it?", exportEncrypt))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			PrintWarning("Export cancelled")
+			return nil
+		}
+	}
+
+	passphrase, err := readPassphrase(true)
+	if err != nil {
+		return err
+	}
+
+	data, err := config.SealBundle(b, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(exportEncrypt, data, 0600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	PrintSuccess(fmt.Sprintf("Exported bundle to %s", exportEncrypt))
+	renderBundleContents(b)
+	return nil
+}
+
+func runAuthImport(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	passphrase, err := readPassphrase(false)
+	if err != nil {
+		return err
+	}
+	b, err := config.OpenBundle(data, passphrase)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(ui.Writer(), ui.TitleStyle.Render("Bundle Contents"))
+	renderBundleContents(*b)
+
+	// Check where the key goes before anything is installed
+	var keyPath string
+	var keyExists bool
+	if b.KeyFile != nil {
+		if keyPath, keyExists, err = importKeyPath(*b.KeyFile); err != nil {
+			return err
+		}
+	}
+
+	confirmed, err := confirmAction("Install this bundle? Existing credentials and config.yaml will be replaced")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Import cancelled")
+		return nil
+	}
+
+	if b.Credentials != nil {
+		keyring, err := config.NewKeyringStore()
+		if err != nil {
+			return fmt.Errorf("failed to access keyring: %w", err)
+		}
+		if err := keyring.SaveCredentials(*b.Credentials); err != nil {
+			return err
+		}
+	}
+
+	if b.Config != nil {
+		configPath, err := config.ConfigFile()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := os.WriteFile(configPath, b.Config, 0600); err != nil {
+			return fmt.Errorf("failed to write config: %w", err)
+		}
+	}
+
+	if b.KeyFile != nil {
+		if keyExists {
+			PrintWarning(fmt.Sprintf("%s already holds this key, not rewriting it", keyPath))
+		} else if err := os.WriteFile(keyPath, []byte(b.KeyFile.PEM), 0600); err != nil {
+			return fmt.Errorf("failed to write private key file: %w", err)
+		}
+		// The bundle records the key's path on the machine it came from
+		if err := config.Save(map[string]any{"private_key_path": keyPath}); err != nil {
+			return fmt.Errorf("failed to update private_key_path: %w", err)
+		}
+	}
+
+	PrintSuccess("Bundle imported. Run 'kalshi-cli auth status' to verify.")
+	return nil
+}
+
+func renderBundleContents(b config.Bundle) {
+	credentials := "none"
+	if b.Credentials != nil {
+		credentials = "API key " + b.Credentials.APIKeyID
+	}
+	configFile := "none"
+	if b.Config != nil {
+		configFile = fmt.Sprintf("%d bytes", len(b.Config))
+	}
+	keyFile := "none"
+	if b.KeyFile != nil {
+		keyFile = b.KeyFile.Path
+	}
+
+	ui.RenderKeyValue([][]string{
+		{"Created", b.CreatedAt.Local().Format("2006-01-02 15:04:05")},
+		{"Keyring Credentials", credentials},
+		{"Config", configFile},
+		{"Private Key File", keyFile},
+	})
+}
+
+// importKeyPath returns where an imported key file goes: the config
+// directory, under the file's original name. exists is set when the file is
+// already there with the same key; a different key there is an error.
+func importKeyPath(kf config.KeyFile) (path string, exists bool, err error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", false, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", false, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	name := filepath.Base(filepath.FromSlash(kf.Path))
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		name = "private_key.pem"
+	}
+	path = filepath.Join(dir, name)
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return path, false, nil
+	case err != nil:
+		return "", false, fmt.Errorf("failed to read %s: %w", path, err)
+	case string(existing) != kf.PEM:
+		return "", false, fmt.Errorf("%s already holds a different key; move it aside and import again", path)
+	}
+	return path, true, nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+)
+
+func TestImportKeyPath_UsesLocalConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	config.SetDirOverride(dir)
+	t.Cleanup(func() { config.SetDirOverride("") })
+
+	kf := config.KeyFile{Path: "/home/someone-else/.kalshi/kalshi.pem", PEM: "KEY"}
+	path, exists, err := importKeyPath(kf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "kalshi.pem"); path != want || exists {
+		t.Fatalf("importKeyPath = %q, %v; want %q, false", path, exists, want)
+	}
+
+	if err := os.WriteFile(path, []byte("KEY"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists, err := importKeyPath(kf); err != nil || !exists {
+		t.Errorf("same key already in place: exists = %v, err = %v", exists, err)
+	}
+
+	kf.PEM = "OTHER KEY"
+	if _, _, err := importKeyPath(kf); err == nil || !strings.Contains(err.Error(), "different key") {
+		t.Errorf("different key in place: err = %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var (
+	loginGenerateKey bool
+	loginKeyFile     string
+	loginKeyName     string
+)
+
+func init() {
+	loginCmd.Flags().BoolVar(&loginGenerateKey, "generate-key", false, "generate a new RSA key pair locally and register its public key")
+	loginCmd.Flags().StringVar(&loginKeyFile, "key-file", "", "with --generate-key, where to write the private key (default private_key.pem in the config directory)")
+	loginCmd.Flags().StringVar(&loginKeyName, "key-name", "", "with --generate-key, name for the registered API key")
+}
+
+// generateLoginKey writes a new RSA private key to a new file at path (mode
+// 0600) and returns the key
+func generateLoginKey(path string) (*rsa.PrivateKey, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create key directory: %w", err)
+	}
+	f, err := createKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	key, err := api.GenerateKeyPair()
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+	if err := writeKeyFile(f, api.EncodePrivateKeyPEM(key)); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	return key, nil
+}
+
+// runLoginGenerate creates a key pair on this machine and logs in with it.
+// The public key is registered through the API when other credentials are
+// already available, and otherwise printed for registration on the Kalshi
+// website. Only the key ID and key path are saved to config.yaml.
+func runLoginGenerate() error {
+	path := loginKeyFile
+	if path == "" {
+		dir, err := config.ConfigDir()
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(dir, "private_key.pem")
+	}
+
+	// Existing credentials, if any, are used to register the new key
+	var uploader *api.Client
+	if signer, src, err := resolveSigner(); err == nil {
+		uploader = newAPIClient(signer)
+		fmt.Fprintf(ui.Writer(), "Registering the new key with the credentials from %s (key %s)\n", src.Detail, src.APIKeyID)
+	} else if err := requireInput("API key ID for the new public key (log in with existing credentials first so it can be registered automatically)"); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(ui.Writer(), ui.TitleStyle.Render("Kalshi API Authentication (generated key)"))
+	fmt.Fprintln(ui.Writer(), "Generating RSA key pair...")
+	key, err := generateLoginKey(path)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ui.Writer(), "Private key written to %s\n", path)
+
+	publicKeyPEM, err := api.EncodePublicKeyPEM(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ui.Writer())
+	fmt.Fprintln(ui.Writer(), "Public key:")
+	fmt.Fprintln(ui.Writer(), publicKeyPEM)
+
+	var apiKeyID string
+	if uploader != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		resp, err := uploader.CreateAPIKeyWithPublicKey(ctx, api.CreateAPIKeyWithPublicKeyRequest{
+			Name:      loginKeyName,
+			PublicKey: publicKeyPEM,
+		})
+		cancel()
+		if err != nil {
+			os.Remove(path)
+			return fmt.Errorf("failed to register public key: %w", err)
+		}
+		apiKeyID = resp.APIKey.ID
+		fmt.Fprintf(ui.Writer(), "Registered as API key %s\n", apiKeyID)
+	} else {
+		fmt.Println("Register this public key with Kalshi:")
+		fmt.Println("  1. Go to https://kalshi.com/account/api (or demo: https://demo.kalshi.com/account/api)")
+		fmt.Println("  2. Choose to add your own public key and paste the key above")
+		fmt.Println()
+		fmt.Print("Enter the API Key ID Kalshi assigns: ")
+		input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		apiKeyID = strings.TrimSpace(input)
+		if err != nil && apiKeyID == "" {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		if apiKeyID == "" {
+			return fmt.Errorf("API Key ID is required; the private key was kept at %s", path)
+		}
+	}
+
+	fmt.Fprintln(ui.Writer())
+	fmt.Fprintln(ui.Writer(), "Testing authentication...")
+
+	signer, err := api.NewSigner(apiKeyID, key)
+	if err != nil {
+		return fmt.Errorf("failed to create signer: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := newAPIClient(signer).GetBalance(ctx); err != nil {
+		return fmt.Errorf("authentication test failed (the private key was kept at %s): %w", path, err)
+	}
+
+	if err := config.Save(map[string]any{"api_key_id": apiKeyID, "private_key_path": path}); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Fprintln(ui.Writer())
+	PrintSuccess("Authentication successful!")
+	fmt.Fprintf(ui.Writer(), "Environment: %s\n", cfg.Environment())
+	fmt.Fprintf(ui.Writer(), "API key ID and key path saved to ~/.kalshi/config.yaml; the private key stays in %s.\n", path)
+	return nil
+}
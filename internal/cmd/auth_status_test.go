@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+)
+
+func TestNewAPIKeyStatus(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name         string
+		expires      time.Time
+		window       time.Duration
+		expectedDays *int
+		expiring     bool
+	}{
+		{name: "no expiry", window: week},
+		{name: "expires in 3 days", expires: now.Add(3*24*time.Hour + time.Hour), window: week, expectedDays: intPtr(3), expiring: true},
+		{name: "expires in 30 days", expires: now.Add(30 * 24 * time.Hour), window: week, expectedDays: intPtr(30)},
+		{name: "already expired", expires: now.Add(-36 * time.Hour), window: week, expectedDays: intPtr(-2), expiring: true},
+		{name: "no window", expires: now.Add(time.Hour), expectedDays: intPtr(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := api.APIKey{ID: "key-1", ExpiresTime: api.JSONTime{Time: tt.expires}}
+			status := newAPIKeyStatus(key, "key-1", now, tt.window)
+
+			if !status.Active {
+				t.Error("expected key to be active")
+			}
+			if tt.expectedDays == nil {
+				if status.DaysUntilExpiry != nil || status.ExpiresTime != nil {
+					t.Errorf("expected no expiry, got %v days, expires %v", status.DaysUntilExpiry, status.ExpiresTime)
+				}
+			} else if status.DaysUntilExpiry == nil || *status.DaysUntilExpiry != *tt.expectedDays {
+				t.Errorf("expected %d days until expiry, got %v", *tt.expectedDays, status.DaysUntilExpiry)
+			}
+			if status.Expiring != tt.expiring {
+				t.Errorf("expected expiring=%v, got %v", tt.expiring, status.Expiring)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestFetchAuthStatus_FailIfExpiring(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		keys      string
+		status    int
+		wantAuth  bool
+		wantError string
+	}{
+		{name: "valid", keys: `{"api_keys":[{"id":"key-1","expires_time":"2026-03-01T00:00:00Z"}]}`, status: http.StatusOK, wantAuth: true},
+		{name: "expiring", keys: `{"api_keys":[{"id":"key-1","expires_time":"2026-01-03T00:00:00Z"}]}`, status: http.StatusOK, wantAuth: true, wantError: "expires in 1 day"},
+		{name: "key not listed", keys: `{"api_keys":[{"id":"key-2"}]}`, status: http.StatusOK, wantAuth: true, wantError: "not among"},
+		{name: "rejected", keys: `{"code":"unauthorized","message":"expired key"}`, status: http.StatusUnauthorized, wantError: "failed to list API keys"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasSuffix(r.URL.Path, "/api-keys") {
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.keys))
+					return
+				}
+				w.Write([]byte(`{"exchange_active":true,"trading_active":true}`))
+			}))
+			defer server.Close()
+
+			data := authStatusData{LoggedIn: true, APIKeyID: "key-1"}
+			keysErr := fetchAuthStatus(context.Background(), newCmdTestClient(t, server.URL), &data, now, week)
+			if data.Authenticated != tt.wantAuth {
+				t.Errorf("authenticated = %v, want %v", data.Authenticated, tt.wantAuth)
+			}
+			if !data.ExchangeActive {
+				t.Error("exchange status not filled in")
+			}
+
+			err := keyExpiryError(data, keysErr)
+			switch {
+			case tt.wantError == "" && err != nil:
+				t.Errorf("keyExpiryError() = %v, want nil", err)
+			case tt.wantError != "" && (err == nil || !strings.Contains(err.Error(), tt.wantError)):
+				t.Errorf("keyExpiryError() = %v, want %q", err, tt.wantError)
+			}
+		})
+	}
+}
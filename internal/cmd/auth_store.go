@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var authMigrateStoreCmd = &cobra.Command{
+	Use:   "migrate-store",
+	Short: "Move credentials between the keyring and a key file",
+	Long: `Move the stored credentials from one backend to the other.
+
+--to file writes the private key from the system keyring to --key-file (mode
+0600), sets api_key_id and private_key_path in config.yaml, and removes the
+keyring entry. Useful on headless machines where the keyring is unavailable.
+
+--to keyring saves the key named by api_key_id and private_key_path to the
+system keyring, clears both settings from config.yaml, and deletes the key
+file.
+
+The source is only removed after the destination has been written and read
+back.`,
+	Example: `  kalshi-cli auth migrate-store --to file
+  kalshi-cli auth migrate-store --to file --key-file ~/.kalshi/bot.pem
+  kalshi-cli auth migrate-store --to keyring`,
+	RunE: runAuthMigrateStore,
+}
+
+var authVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Sign a test request and show which credentials were used",
+	Long: `Resolve credentials the same way every other command does, sign an
+authenticated request with them, and report which source supplied them:
+a PKCS#11 token, a credentials provider, config.yaml, environment variables
+or the system keyring. The keyring is also probed so a broken or locked
+keyring shows up even when another source is in use.
+
+Exits non-zero if no credentials are found or the signed request fails.`,
+	Example: `  kalshi-cli auth verify
+  kalshi-cli auth verify --json`,
+	RunE: runAuthVerify,
+}
+
+var (
+	migrateTo      string
+	migrateKeyFile string
+)
+
+// keyringProbeTimeout bounds the keyring health check, which can hang in
+// headless environments
+const keyringProbeTimeout = 5 * time.Second
+
+func init() {
+	authCmd.AddCommand(authMigrateStoreCmd)
+	authCmd.AddCommand(authVerifyCmd)
+
+	authMigrateStoreCmd.Flags().StringVar(&migrateTo, "to", "", "destination backend: file or keyring (required)")
+	authMigrateStoreCmd.Flags().StringVar(&migrateKeyFile, "key-file", "", "private key path for --to file (default private_key.pem in the config directory)")
+	authMigrateStoreCmd.MarkFlagRequired("to")
+}
+
+func runAuthMigrateStore(cmd *cobra.Command, args []string) error {
+	switch migrateTo {
+	case "file":
+		return migrateToFile()
+	case "keyring":
+		return migrateToKeyring()
+	default:
+		return fmt.Errorf("--to must be 'file' or 'keyring', got '%s'", migrateTo)
+	}
+}
+
+func migrateToFile() error {
+	keyring, err := config.NewKeyringStore()
+	if err != nil {
+		return fmt.Errorf("failed to access keyring: %w", err)
+	}
+	creds, err := keyring.GetCredentials()
+	if err != nil {
+		return err
+	}
+	if creds == nil {
+		return fmt.Errorf("no credentials in the keyring to migrate")
+	}
+
+	path := migrateKeyFile
+	if path == "" {
+		dir, err := config.ConfigDir()
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(dir, "private_key.pem")
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists; choose another --key-file", path)
+	}
+
+	confirmed, err := confirmAction(fmt.Sprintf("Move API key %s from the keyring to %s?", creds.APIKeyID, path))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Migration cancelled")
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(creds.PrivateKey), 0600); err != nil {
+		return fmt.Errorf("failed to write private key file: %w", err)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil || string(written) != creds.PrivateKey {
+		return fmt.Errorf("private key file %s did not read back correctly; keyring left unchanged", path)
+	}
+
+	if err := config.Save(map[string]any{"api_key_id": creds.APIKeyID, "private_key_path": path}); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	if err := keyring.DeleteCredentials(); err != nil {
+		return fmt.Errorf("credentials copied, but removing them from the keyring failed: %w", err)
+	}
+
+	PrintSuccess(fmt.Sprintf("Credentials moved to %s", path))
+	return nil
+}
+
+func migrateToKeyring() error {
+	apiKeyID := viper.GetString("api_key_id")
+	path := viper.GetString("private_key_path")
+	if apiKeyID == "" || path == "" {
+		return fmt.Errorf("api_key_id and private_key_path must both be set in config.yaml to migrate to the keyring")
+	}
+
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read private key file %s: %w", path, err)
+	}
+	creds := config.Credentials{APIKeyID: apiKeyID, PrivateKey: string(pem)}
+	if _, err := api.NewSignerFromPEM(creds.APIKeyID, creds.PrivateKey); err != nil {
+		return fmt.Errorf("invalid private key format: %w", err)
+	}
+
+	keyring, err := config.NewKeyringStore()
+	if err != nil {
+		return fmt.Errorf("failed to access keyring: %w", err)
+	}
+	if keyring.HasCredentials() {
+		PrintWarning("The keyring already holds credentials; they will be replaced")
+	}
+
+	confirmed, err := confirmAction(fmt.Sprintf("Move API key %s from %s to the keyring and delete the file?", apiKeyID, path))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Migration cancelled")
+		return nil
+	}
+
+	if err := keyring.SaveCredentials(creds); err != nil {
+		return err
+	}
+	saved, err := keyring.GetCredentials()
+	if err != nil || saved == nil || *saved != creds {
+		return fmt.Errorf("keyring did not read back the saved credentials; %s left in place", path)
+	}
+
+	if err := config.Save(map[string]any{"api_key_id": "", "private_key_path": ""}); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		PrintWarning(fmt.Sprintf("Credentials moved, but %s could not be deleted: %v", path, err))
+	}
+
+	PrintSuccess("Credentials moved to the system keyring")
+	return nil
+}
+
+// authVerifyResult is the outcome of auth verify
+type authVerifyResult struct {
+	Source      credentialSource `json:"source"`
+	Environment string           `json:"environment"`
+	Verified    bool             `json:"verified"`
+	Latency     string           `json:"latency,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	Keyring     string           `json:"keyring"`
+}
+
+// probeKeyring reports whether the keyring can be opened and what it holds
+func probeKeyring() string {
+	done := make(chan string, 1)
+	go func() {
+		keyring, err := config.NewKeyringStore()
+		if err != nil {
+			done <- "unavailable: " + err.Error()
+			return
+		}
+		creds, err := keyring.GetCredentials()
+		switch {
+		case err != nil:
+			done <- "error: " + err.Error()
+		case creds == nil:
+			done <- "ok, empty"
+		default:
+			done <- "ok, holds API key " + creds.APIKeyID
+		}
+	}()
+
+	select {
+	case status := <-done:
+		return status
+	case <-time.After(keyringProbeTimeout):
+		return fmt.Sprintf("no response after %s", keyringProbeTimeout)
+	}
+}
+
+func runAuthVerify(cmd *cobra.Command, args []string) error {
+	result := authVerifyResult{Environment: cfg.Environment()}
+
+	signer, src, err := resolveSigner()
+	result.Source = src
+	if err == nil {
+		ctx, cancel := withTimeout(context.Background())
+		defer cancel()
+
+		start := time.Now()
+		_, err = newAPIClient(signer).GetBalance(ctx)
+		result.Latency = time.Since(start).Round(time.Millisecond).String()
+	}
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Verified = true
+	}
+	result.Keyring = probeKeyring()
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() { renderAuthVerify(result) },
+		result,
+		func() {
+			ui.PrintPlain("%t\t%s\t%s\t%s", result.Verified, result.Source.Backend, result.Source.APIKeyID, result.Source.Detail)
+		},
+	); err != nil {
+		return err
+	}
+
+	if !result.Verified {
+		return fmt.Errorf("credential verification failed: %s", result.Error)
+	}
+	return nil
+}
+
+func renderAuthVerify(r authVerifyResult) {
+	status := ui.SuccessStyle.Render("signed request accepted (" + r.Latency + ")")
+	if !r.Verified {
+		status = ui.ErrorStyle.Render(r.Error)
+	}
+	source := r.Source.Backend
+	if source == "" {
+		source = "none"
+	}
+
+	ui.RenderKeyValue([][]string{
+		{"Environment", r.Environment},
+		{"Source", source},
+		{"API Key ID", r.Source.APIKeyID},
+		{"Found In", r.Source.Detail},
+		{"Verification", status},
+		{"Keyring", r.Keyring},
+	})
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestResolveSignerSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(keyPath, []byte(keyPEM), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]string
+		env     map[string]string
+		backend string
+	}{
+		{
+			name:    "config file",
+			config:  map[string]string{"api_key_id": "cfg-key", "private_key_path": keyPath},
+			backend: "config",
+		},
+		{
+			name:    "env key file",
+			env:     map[string]string{"KALSHI_API_KEY_ID": "env-key", "KALSHI_PRIVATE_KEY_FILE": keyPath},
+			backend: "env",
+		},
+		{
+			name:    "config id, env key file",
+			config:  map[string]string{"api_key_id": "cfg-key"},
+			env:     map[string]string{"KALSHI_PRIVATE_KEY_FILE": keyPath},
+			backend: "config+env",
+		},
+		{
+			name:    "env PEM",
+			env:     map[string]string{"KALSHI_API_KEY_ID": "env-key", "KALSHI_PRIVATE_KEY": keyPEM},
+			backend: "env",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"api_key_id", "private_key_path"} {
+				viper.Set(k, tt.config[k])
+			}
+			t.Cleanup(func() {
+				viper.Set("api_key_id", "")
+				viper.Set("private_key_path", "")
+			})
+			for _, k := range []string{"KALSHI_API_KEY_ID", "KALSHI_PRIVATE_KEY_FILE", "KALSHI_PRIVATE_KEY"} {
+				t.Setenv(k, tt.env[k])
+			}
+
+			signer, src, err := resolveSigner()
+			if err != nil {
+				t.Fatalf("resolveSigner: %v", err)
+			}
+			if src.Backend != tt.backend {
+				t.Errorf("backend = %q, want %q (%s)", src.Backend, tt.backend, src.Detail)
+			}
+			if src.APIKeyID != signer.APIKeyID() {
+				t.Errorf("source key %q does not match signer key %q", src.APIKeyID, signer.APIKeyID())
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+// annotationMutating marks commands that can change account state
+const annotationMutating = "kalshi-cli/mutating"
+
+// noBanner suppresses the production banner unless require_env_banner is set
+var noBanner bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noBanner, "no-banner", false, "suppress the PRODUCTION banner (ignored when require_env_banner is set)")
+
+	markMutating(
+		ordersCreateCmd, ordersCancelCmd, ordersCancelAllCmd, ordersAmendCmd, ordersBatchCreateCmd, ordersLadderCmd, ordersPairCmd,
+		orderGroupsCreateCmd, orderGroupsDeleteCmd, orderGroupsResetCmd, orderGroupsTriggerCmd, orderGroupsUpdateLimitCmd,
+		subaccountsCreateCmd, subaccountsTransferCmd,
+		rfqCreateCmd, rfqDeleteCmd, quotesCreateCmd, quotesAcceptCmd, quotesConfirmCmd,
+		keysCreateCmd, keysDeleteCmd,
+		promoteCmd,
+	)
+}
+
+func markMutating(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations[annotationMutating] = "true"
+	}
+}
+
+// isMutating reports whether cmd can change account state
+func isMutating(cmd *cobra.Command) bool {
+	return cmd.Annotations[annotationMutating] == "true"
+}
+
+// showEnvBanner reports whether the production banner should be shown
+func showEnvBanner() bool {
+	if cfg == nil || !cfg.API.Production {
+		return false
+	}
+	return !noBanner || cfg.RequireEnvBanner
+}
+
+// printEnvBanner writes the production banner to stderr so it never mixes
+// with --json or --plain output on stdout
+func printEnvBanner(cmd *cobra.Command) {
+	if !isMutating(cmd) || !showEnvBanner() {
+		return
+	}
+	fmt.Fprintln(os.Stderr, envStatusLine())
+}
+
+// envStatusLine is the environment indicator for banners and status bars
+func envStatusLine() string {
+	if paperMode {
+		return ui.DemoStyle.Render(" PAPER ")
+	}
+	if cfg != nil && cfg.API.Production {
+		return ui.ProdBannerStyle.Render(" PRODUCTION - real money ")
+	}
+	return ui.DemoStyle.Render(" DEMO ")
+}
+
+// envPrompt colors confirmation prompts red in production
+func envPrompt(prompt string) string {
+	if cfg != nil && cfg.API.Production {
+		return ui.ErrorStyle.Render(prompt)
+	}
+	return prompt
+}
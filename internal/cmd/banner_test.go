@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+)
+
+func TestShowEnvBanner(t *testing.T) {
+	defer func(c *config.Config, nb bool) { cfg, noBanner = c, nb }(cfg, noBanner)
+
+	tests := []struct {
+		name       string
+		production bool
+		noBanner   bool
+		require    bool
+		expected   bool
+	}{
+		{name: "demo", production: false, expected: false},
+		{name: "production", production: true, expected: true},
+		{name: "production suppressed", production: true, noBanner: true, expected: false},
+		{name: "production required", production: true, noBanner: true, require: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = &config.Config{
+				API:              config.APIConfig{Production: tt.production},
+				RequireEnvBanner: tt.require,
+			}
+			noBanner = tt.noBanner
+			if got := showEnvBanner(); got != tt.expected {
+				t.Errorf("showEnvBanner() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMutatingCommands(t *testing.T) {
+	if !isMutating(ordersCreateCmd) || !isMutating(subaccountsTransferCmd) || !isMutating(keysDeleteCmd) {
+		t.Error("expected order, transfer and key deletion commands to be marked mutating")
+	}
+	if isMutating(ordersListCmd) || isMutating(watchTickerCmd) {
+		t.Error("expected read-only commands not to be marked mutating")
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// candlePeriodDuration returns the length of a candlestick period: 1m, 1h,
+// 1d, or a number of minutes as accepted by the API
+func candlePeriodDuration(period string) (time.Duration, error) {
+	switch period {
+	case "1m":
+		return time.Minute, nil
+	case "1h":
+		return time.Hour, nil
+	case "1d":
+		return 24 * time.Hour, nil
+	}
+	minutes, err := strconv.Atoi(period)
+	if err != nil || minutes < 1 {
+		return 0, fmt.Errorf("invalid period %q: use 1m, 1h, 1d, or a number of minutes", period)
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
+
+// fillCandleGaps inserts a zero-volume candle for every period Kalshi omitted
+// between two candles of the same market. Filled candles are flat at the
+// previous close and carry its open interest forward. Periods before a
+// market's first candle are left out since there is no close to carry.
+// A zero step leaves candles as they are.
+func fillCandleGaps(candles []models.Candlestick, step time.Duration) []models.Candlestick {
+	if step <= 0 || len(candles) == 0 {
+		return candles
+	}
+
+	filled := make([]models.Candlestick, 0, len(candles))
+	last := make(map[string]models.Candlestick)
+	for _, c := range candles {
+		if prev, ok := last[c.Ticker]; ok {
+			for t := prev.PeriodEnd.Add(step); t.Before(c.PeriodEnd); t = t.Add(step) {
+				filled = append(filled, models.Candlestick{
+					Ticker:       c.Ticker,
+					Open:         prev.Close,
+					High:         prev.Close,
+					Low:          prev.Close,
+					Close:        prev.Close,
+					OpenInterest: prev.OpenInterest,
+					PeriodEnd:    t,
+				})
+			}
+		}
+		filled = append(filled, c)
+		last[c.Ticker] = c
+	}
+	return filled
+}
+
+// candleGapStep returns the period to fill gaps at, or zero when --fill-gaps
+// is not set
+func candleGapStep(fill bool, period string) (time.Duration, error) {
+	if !fill {
+		return 0, nil
+	}
+	return candlePeriodDuration(period)
+}
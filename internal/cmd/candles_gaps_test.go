@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestCandlePeriodDuration(t *testing.T) {
+	tests := map[string]time.Duration{"1m": time.Minute, "1h": time.Hour, "1d": 24 * time.Hour, "15": 15 * time.Minute}
+	for in, want := range tests {
+		if got, err := candlePeriodDuration(in); err != nil || got != want {
+			t.Errorf("candlePeriodDuration(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "1w", "0", "-5"} {
+		if _, err := candlePeriodDuration(in); err == nil {
+			t.Errorf("candlePeriodDuration(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestFillCandleGaps(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	candles := []models.Candlestick{
+		{Ticker: "A", Open: 40, High: 45, Low: 38, Close: 44, Volume: 10, OpenInterest: 100, PeriodEnd: t0},
+		{Ticker: "A", Open: 46, High: 50, Low: 46, Close: 48, Volume: 5, OpenInterest: 105, PeriodEnd: t0.Add(3 * time.Hour)},
+		{Ticker: "B", Close: 60, Volume: 1, PeriodEnd: t0.Add(time.Hour)},
+		{Ticker: "B", Close: 61, Volume: 2, PeriodEnd: t0.Add(2 * time.Hour)},
+	}
+
+	got := fillCandleGaps(candles, time.Hour)
+	if len(got) != 6 {
+		t.Fatalf("fillCandleGaps() returned %d candles, want 6: %+v", len(got), got)
+	}
+	for i, hours := range []int{1, 2} {
+		c := got[1+i]
+		want := models.Candlestick{Ticker: "A", Open: 44, High: 44, Low: 44, Close: 44, OpenInterest: 100, PeriodEnd: t0.Add(time.Duration(hours) * time.Hour)}
+		if c != want {
+			t.Errorf("filled candle %d = %+v, want %+v", i, c, want)
+		}
+	}
+	if got[3].Volume != 5 || got[4].Ticker != "B" || got[5].Close != 61 {
+		t.Errorf("original candles not preserved in order: %+v", got)
+	}
+
+	if same := fillCandleGaps(candles, 0); len(same) != len(candles) {
+		t.Errorf("zero step changed candles: %+v", same)
+	}
+}
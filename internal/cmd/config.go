@@ -16,6 +16,18 @@ var validConfigKeys = map[string]struct {
 	description string
 	validate    func(string) error
 }{
+	"api.read_only": {
+		description: "Refuse all write requests such as orders (true, false)",
+		validate:    validateBool,
+	},
+	"api.base_url": {
+		description: "REST base URL, e.g. an internal gateway (\"\" = Kalshi)",
+		validate:    validateURL,
+	},
+	"websocket.url": {
+		description: "WebSocket URL, e.g. an internal gateway (\"\" = Kalshi)",
+		validate:    validateWebSocketURL,
+	},
 	"output.format": {
 		description: "Output format (table, json, plain)",
 		validate:    validateOutputFormat,
@@ -24,10 +36,62 @@ var validConfigKeys = map[string]struct {
 		description: "Enable colored output (true, false)",
 		validate:    validateBool,
 	},
+	"output.price_format": {
+		description: "Price display in watch (dollars, cents, percent, decimal)",
+		validate:    validatePriceFormat,
+	},
+	"output.show_mid": {
+		description: "Show the bid/ask mid-price in watch (true, false)",
+		validate:    validateBool,
+	},
 	"defaults.limit": {
 		description: "Default limit for list commands (number)",
 		validate:    validatePositiveInt,
 	},
+	"defaults.subaccount": {
+		description: "Subaccount used for trading and portfolio queries (0 = primary)",
+		validate:    validateNonNegativeInt,
+	},
+	"usage.enabled": {
+		description: "Record local usage statistics (true, false)",
+		validate:    validateBool,
+	},
+	"audit.enabled": {
+		description: "Record mutating requests to the audit log (true, false)",
+		validate:    validateBool,
+	},
+	"alerts.webhook_url": {
+		description: "Default webhook URL for alerts (URL)",
+		validate:    validateURL,
+	},
+	"alerts.slack_webhook_url": {
+		description: "Default Slack incoming webhook for alerts (URL)",
+		validate:    validateURL,
+	},
+	"alerts.desktop": {
+		description: "Send desktop notifications for alerts (true, false)",
+		validate:    validateBool,
+	},
+	"risk.max_exposure": {
+		description: "Exposure ceiling in cents checked by --precheck (0 = none)",
+		validate:    validateNonNegativeInt,
+	},
+	"network.proxy": {
+		description: "http(s) or socks5 proxy URL (\"\" = HTTP(S)_PROXY from the environment)",
+		validate:    validateProxyURL,
+	},
+	"network.ca_bundle": {
+		description: "PEM file of extra root certificates to trust (path)",
+		validate:    validateAny,
+	},
+	"network.insecure_skip_verify": {
+		description: "Skip TLS certificate verification, demo only (true, false)",
+		validate:    validateBool,
+	},
+	"require_env_banner": {
+		description: "Always show the PRODUCTION banner, ignoring --no-banner (true, false)",
+		validate:    validateBool,
+	},
 }
 
 var configCmd = &cobra.Command{
@@ -35,12 +99,29 @@ var configCmd = &cobra.Command{
 	Short: "Manage configuration settings",
 	Long: `Manage kalshi-cli configuration settings.
 
-Configuration is stored in ~/.kalshi/config.yaml.
+Configuration is stored in config.yaml in the config directory
+(see 'kalshi-cli config paths').
 
 Available configuration keys:
+  api.read_only   Refuse all write requests such as orders (true, false)
+  api.base_url    REST base URL, e.g. an internal gateway
+  websocket.url   WebSocket URL, e.g. an internal gateway
   output.format   Output format (table, json, plain)
   output.color    Enable colored output (true, false)
-  defaults.limit  Default limit for list commands (number)`,
+  output.price_format  Price display in watch (dollars, cents, percent, decimal)
+  output.show_mid      Show the bid/ask mid-price in watch (true, false)
+  defaults.limit  Default limit for list commands (number)
+  defaults.subaccount  Subaccount for trading and portfolio queries (0 = primary)
+  usage.enabled   Record local usage statistics (true, false)
+  audit.enabled   Record mutating requests to the audit log (true, false)
+  alerts.webhook_url        Default webhook URL for alerts
+  alerts.slack_webhook_url  Default Slack incoming webhook for alerts
+  alerts.desktop            Send desktop notifications for alerts (true, false)
+  risk.max_exposure         Exposure ceiling in cents checked by --precheck (0 = none)
+  network.proxy             http(s) or socks5 proxy URL
+  network.ca_bundle         PEM file of extra root certificates to trust
+  network.insecure_skip_verify  Skip TLS verification, demo only (true, false)
+  require_env_banner        Always show the PRODUCTION banner (true, false)`,
 }
 
 var configShowCmd = &cobra.Command{
@@ -56,9 +137,25 @@ var configGetCmd = &cobra.Command{
 	Long: `Get the value of a specific configuration key.
 
 Available keys:
+  api.read_only   Refuse all write requests such as orders
+  api.base_url    REST base URL, e.g. an internal gateway
+  websocket.url   WebSocket URL, e.g. an internal gateway
   output.format   Output format (table, json, plain)
   output.color    Enable colored output (true, false)
-  defaults.limit  Default limit for list commands`,
+  output.price_format  Price display in watch
+  output.show_mid      Show the bid/ask mid-price in watch
+  defaults.limit  Default limit for list commands
+  defaults.subaccount  Subaccount for trading and portfolio queries
+  usage.enabled   Record local usage statistics
+  audit.enabled   Record mutating requests to the audit log
+  alerts.webhook_url        Default webhook URL for alerts
+  alerts.slack_webhook_url  Default Slack incoming webhook for alerts
+  alerts.desktop            Send desktop notifications for alerts
+  risk.max_exposure         Exposure ceiling in cents checked by --precheck
+  network.proxy             http(s) or socks5 proxy URL
+  network.ca_bundle         PEM file of extra root certificates to trust
+  network.insecure_skip_verify  Skip TLS verification, demo only
+  require_env_banner        Always show the PRODUCTION banner, ignoring --no-banner`,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigGet,
 }
@@ -69,9 +166,25 @@ var configSetCmd = &cobra.Command{
 	Long: `Set a configuration value.
 
 Available keys and values:
+  api.read_only   true, false
+  api.base_url    http(s) URL without /trade-api/v2, or "" to unset
+  websocket.url   ws(s) URL, or "" to unset
   output.format   table, json, plain
   output.color    true, false
-  defaults.limit  Any positive integer`,
+  output.price_format  dollars, cents, percent, decimal
+  output.show_mid      true, false
+  defaults.limit  Any positive integer
+  defaults.subaccount  0 (primary) or a subaccount number
+  usage.enabled   true, false
+  audit.enabled   true, false
+  alerts.webhook_url        http(s) URL, or "" to unset
+  alerts.slack_webhook_url  http(s) URL, or "" to unset
+  alerts.desktop            true, false
+  risk.max_exposure         0 (none) or a number of cents
+  network.proxy             http://, https://, socks5:// URL, or "" to unset
+  network.ca_bundle         path to a PEM file, or "" to unset
+  network.insecure_skip_verify  true, false
+  require_env_banner        true, false`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
@@ -87,9 +200,25 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	currentConfig := GetConfig()
 
 	configData := map[string]interface{}{
+		"api.read_only":  currentConfig.API.ReadOnly,
+		"api.base_url":   currentConfig.API.BaseURL,
+		"websocket.url":  currentConfig.WebSocket.URL,
 		"output.format":  currentConfig.Output.Format,
 		"output.color":   currentConfig.Output.Color,
+		"output.price_format": currentConfig.Output.PriceFormat,
+		"output.show_mid":     currentConfig.Output.ShowMid,
 		"defaults.limit": currentConfig.Defaults.Limit,
+		"defaults.subaccount": currentConfig.Defaults.Subaccount,
+		"usage.enabled":  currentConfig.Usage.Enabled,
+		"audit.enabled":            currentConfig.Audit.Enabled,
+		"alerts.webhook_url":       currentConfig.Alerts.WebhookURL,
+		"alerts.slack_webhook_url": currentConfig.Alerts.SlackWebhookURL,
+		"alerts.desktop":           currentConfig.Alerts.Desktop,
+		"risk.max_exposure":        currentConfig.Risk.MaxExposure,
+		"network.proxy":            currentConfig.Network.Proxy,
+		"network.ca_bundle":        currentConfig.Network.CABundle,
+		"network.insecure_skip_verify": currentConfig.Network.InsecureSkipVerify,
+		"require_env_banner":       currentConfig.RequireEnvBanner,
 	}
 
 	configPath, err := config.ConfigDir()
@@ -149,7 +278,7 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	currentConfig := GetConfig()
 	updatedConfig := applyConfigValue(currentConfig, key, value)
 
-	if err := config.Save(updatedConfig); err != nil {
+	if err := config.Save(map[string]any{key: getConfigValue(updatedConfig, key)}); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
@@ -178,6 +307,15 @@ func validateOutputFormat(value string) error {
 	return fmt.Errorf("must be one of: %s", strings.Join(validFormats, ", "))
 }
 
+func validatePriceFormat(value string) error {
+	for _, format := range priceFormats {
+		if value == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of: %s", strings.Join(priceFormats, ", "))
+}
+
 func validateBool(value string) error {
 	if value == "true" || value == "false" {
 		return nil
@@ -185,6 +323,29 @@ func validateBool(value string) error {
 	return fmt.Errorf("must be true or false")
 }
 
+func validateURL(value string) error {
+	if value == "" || strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		return nil
+	}
+	return fmt.Errorf("must be an http(s) URL or empty")
+}
+
+func validateWebSocketURL(value string) error {
+	if value == "" || strings.HasPrefix(value, "ws://") || strings.HasPrefix(value, "wss://") {
+		return nil
+	}
+	return fmt.Errorf("must be a ws(s) URL or empty")
+}
+
+func validateProxyURL(value string) error {
+	for _, scheme := range []string{"http://", "https://", "socks5://", "socks5h://"} {
+		if value == "" || strings.HasPrefix(value, scheme) {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be an http(s) or socks5 URL or empty")
+}
+
 func validatePositiveInt(value string) error {
 	n, err := strconv.Atoi(value)
 	if err != nil {
@@ -196,6 +357,17 @@ func validatePositiveInt(value string) error {
 	return nil
 }
 
+func validateNonNegativeInt(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("must be a valid number")
+	}
+	if n < 0 {
+		return fmt.Errorf("must be zero or a positive number")
+	}
+	return nil
+}
+
 func getValidKeysList() string {
 	keys := make([]string, 0, len(validConfigKeys))
 	for key := range validConfigKeys {
@@ -206,12 +378,44 @@ func getValidKeysList() string {
 
 func getConfigValue(cfg *config.Config, key string) interface{} {
 	switch key {
+	case "api.read_only":
+		return cfg.API.ReadOnly
+	case "api.base_url":
+		return cfg.API.BaseURL
+	case "websocket.url":
+		return cfg.WebSocket.URL
 	case "output.format":
 		return cfg.Output.Format
 	case "output.color":
 		return cfg.Output.Color
+	case "output.price_format":
+		return cfg.Output.PriceFormat
+	case "output.show_mid":
+		return cfg.Output.ShowMid
 	case "defaults.limit":
 		return cfg.Defaults.Limit
+	case "defaults.subaccount":
+		return cfg.Defaults.Subaccount
+	case "usage.enabled":
+		return cfg.Usage.Enabled
+	case "audit.enabled":
+		return cfg.Audit.Enabled
+	case "alerts.webhook_url":
+		return cfg.Alerts.WebhookURL
+	case "alerts.slack_webhook_url":
+		return cfg.Alerts.SlackWebhookURL
+	case "alerts.desktop":
+		return cfg.Alerts.Desktop
+	case "risk.max_exposure":
+		return cfg.Risk.MaxExposure
+	case "network.proxy":
+		return cfg.Network.Proxy
+	case "network.ca_bundle":
+		return cfg.Network.CABundle
+	case "network.insecure_skip_verify":
+		return cfg.Network.InsecureSkipVerify
+	case "require_env_banner":
+		return cfg.RequireEnvBanner
 	default:
 		return viper.Get(key)
 	}
@@ -219,9 +423,62 @@ func getConfigValue(cfg *config.Config, key string) interface{} {
 
 func applyConfigValue(cfg *config.Config, key string, value string) *config.Config {
 	return &config.Config{
-		API: cfg.API,
+		API: applyAPIConfigValue(cfg.API, key, value),
 		Output: applyOutputConfigValue(cfg.Output, key, value),
 		Defaults: applyDefaultsConfigValue(cfg.Defaults, key, value),
+		Usage: applyUsageConfigValue(cfg.Usage, key, value),
+		Alerts: applyAlertsConfigValue(cfg.Alerts, key, value),
+		Audit: applyAuditConfigValue(cfg.Audit, key, value),
+		Risk: applyRiskConfigValue(cfg.Risk, key, value),
+		Network: applyNetworkConfigValue(cfg.Network, key, value),
+		WebSocket: applyWebSocketConfigValue(cfg.WebSocket, key, value),
+		Aliases: cfg.Aliases,
+		ExtraHeaders: cfg.ExtraHeaders,
+
+		RequireEnvBanner: applyBoolConfigValue(cfg.RequireEnvBanner, "require_env_banner", key, value),
+	}
+}
+
+func applyBoolConfigValue(current bool, name string, key string, value string) bool {
+	if key != name {
+		return current
+	}
+	return value == "true"
+}
+
+func applyAPIConfigValue(api config.APIConfig, key string, value string) config.APIConfig {
+	switch key {
+	case "api.read_only":
+		return config.APIConfig{
+			Production: api.Production,
+			Timeout:    api.Timeout,
+			ReadOnly:   value == "true",
+			BaseURL:    api.BaseURL,
+			RateLimit:  api.RateLimit,
+			MaxRetries: api.MaxRetries,
+		}
+	case "api.base_url":
+		return config.APIConfig{
+			Production: api.Production,
+			Timeout:    api.Timeout,
+			ReadOnly:   api.ReadOnly,
+			BaseURL:    value,
+			RateLimit:  api.RateLimit,
+			MaxRetries: api.MaxRetries,
+		}
+	default:
+		return api
+	}
+}
+
+func applyWebSocketConfigValue(ws config.WebSocketConfig, key string, value string) config.WebSocketConfig {
+	switch key {
+	case "websocket.url":
+		return config.WebSocketConfig{
+			URL: value,
+		}
+	default:
+		return ws
 	}
 }
 
@@ -229,13 +486,31 @@ func applyOutputConfigValue(output config.OutputConfig, key string, value string
 	switch key {
 	case "output.format":
 		return config.OutputConfig{
-			Format: value,
-			Color:  output.Color,
+			Format:      value,
+			Color:       output.Color,
+			PriceFormat: output.PriceFormat,
+			ShowMid:     output.ShowMid,
 		}
 	case "output.color":
 		return config.OutputConfig{
-			Format: output.Format,
-			Color:  value == "true",
+			Format:      output.Format,
+			Color:       value == "true",
+			PriceFormat: output.PriceFormat,
+			ShowMid:     output.ShowMid,
+		}
+	case "output.price_format":
+		return config.OutputConfig{
+			Format:      output.Format,
+			Color:       output.Color,
+			PriceFormat: value,
+			ShowMid:     output.ShowMid,
+		}
+	case "output.show_mid":
+		return config.OutputConfig{
+			Format:      output.Format,
+			Color:       output.Color,
+			PriceFormat: output.PriceFormat,
+			ShowMid:     value == "true",
 		}
 	default:
 		return output
@@ -247,27 +522,150 @@ func applyDefaultsConfigValue(defaults config.DefaultsConfig, key string, value
 	case "defaults.limit":
 		limit, _ := strconv.Atoi(value)
 		return config.DefaultsConfig{
-			Limit: limit,
+			Limit:      limit,
+			Subaccount: defaults.Subaccount,
+		}
+	case "defaults.subaccount":
+		subaccount, _ := strconv.Atoi(value)
+		return config.DefaultsConfig{
+			Limit:      defaults.Limit,
+			Subaccount: subaccount,
 		}
 	default:
 		return defaults
 	}
 }
 
+func applyUsageConfigValue(usage config.UsageConfig, key string, value string) config.UsageConfig {
+	switch key {
+	case "usage.enabled":
+		return config.UsageConfig{
+			Enabled: value == "true",
+		}
+	default:
+		return usage
+	}
+}
+
+func applyAuditConfigValue(audit config.AuditConfig, key string, value string) config.AuditConfig {
+	switch key {
+	case "audit.enabled":
+		return config.AuditConfig{
+			Enabled: value == "true",
+		}
+	default:
+		return audit
+	}
+}
+
+func applyRiskConfigValue(risk config.RiskConfig, key string, value string) config.RiskConfig {
+	switch key {
+	case "risk.max_exposure":
+		maxExposure, _ := strconv.Atoi(value)
+		return config.RiskConfig{
+			MaxExposure: maxExposure,
+		}
+	default:
+		return risk
+	}
+}
+
+func applyNetworkConfigValue(network config.NetworkConfig, key string, value string) config.NetworkConfig {
+	switch key {
+	case "network.proxy":
+		return config.NetworkConfig{
+			Proxy:              value,
+			CABundle:           network.CABundle,
+			InsecureSkipVerify: network.InsecureSkipVerify,
+		}
+	case "network.ca_bundle":
+		return config.NetworkConfig{
+			Proxy:              network.Proxy,
+			CABundle:           value,
+			InsecureSkipVerify: network.InsecureSkipVerify,
+		}
+	case "network.insecure_skip_verify":
+		return config.NetworkConfig{
+			Proxy:              network.Proxy,
+			CABundle:           network.CABundle,
+			InsecureSkipVerify: value == "true",
+		}
+	default:
+		return network
+	}
+}
+
+func applyAlertsConfigValue(alerts config.AlertsConfig, key string, value string) config.AlertsConfig {
+	switch key {
+	case "alerts.webhook_url":
+		return config.AlertsConfig{
+			WebhookURL:      value,
+			SlackWebhookURL: alerts.SlackWebhookURL,
+			Desktop:         alerts.Desktop,
+		}
+	case "alerts.slack_webhook_url":
+		return config.AlertsConfig{
+			WebhookURL:      alerts.WebhookURL,
+			SlackWebhookURL: value,
+			Desktop:         alerts.Desktop,
+		}
+	case "alerts.desktop":
+		return config.AlertsConfig{
+			WebhookURL:      alerts.WebhookURL,
+			SlackWebhookURL: alerts.SlackWebhookURL,
+			Desktop:         value == "true",
+		}
+	default:
+		return alerts
+	}
+}
+
 func renderConfigTable(configData map[string]interface{}, configPath string) {
-	fmt.Printf("Configuration file: %s/config.yaml\n\n", configPath)
+	fmt.Fprintf(ui.Writer(), "Configuration file: %s/config.yaml\n\n", configPath)
 
 	rows := [][]string{
+		{"api.read_only", fmt.Sprintf("%v", configData["api.read_only"]), validConfigKeys["api.read_only"].description},
+		{"api.base_url", fmt.Sprintf("%v", configData["api.base_url"]), validConfigKeys["api.base_url"].description},
+		{"websocket.url", fmt.Sprintf("%v", configData["websocket.url"]), validConfigKeys["websocket.url"].description},
 		{"output.format", fmt.Sprintf("%v", configData["output.format"]), validConfigKeys["output.format"].description},
 		{"output.color", fmt.Sprintf("%v", configData["output.color"]), validConfigKeys["output.color"].description},
+		{"output.price_format", fmt.Sprintf("%v", configData["output.price_format"]), validConfigKeys["output.price_format"].description},
+		{"output.show_mid", fmt.Sprintf("%v", configData["output.show_mid"]), validConfigKeys["output.show_mid"].description},
 		{"defaults.limit", fmt.Sprintf("%v", configData["defaults.limit"]), validConfigKeys["defaults.limit"].description},
+		{"defaults.subaccount", fmt.Sprintf("%v", configData["defaults.subaccount"]), validConfigKeys["defaults.subaccount"].description},
+		{"usage.enabled", fmt.Sprintf("%v", configData["usage.enabled"]), validConfigKeys["usage.enabled"].description},
+		{"audit.enabled", fmt.Sprintf("%v", configData["audit.enabled"]), validConfigKeys["audit.enabled"].description},
+		{"alerts.webhook_url", fmt.Sprintf("%v", configData["alerts.webhook_url"]), validConfigKeys["alerts.webhook_url"].description},
+		{"alerts.slack_webhook_url", fmt.Sprintf("%v", configData["alerts.slack_webhook_url"]), validConfigKeys["alerts.slack_webhook_url"].description},
+		{"alerts.desktop", fmt.Sprintf("%v", configData["alerts.desktop"]), validConfigKeys["alerts.desktop"].description},
+		{"risk.max_exposure", fmt.Sprintf("%v", configData["risk.max_exposure"]), validConfigKeys["risk.max_exposure"].description},
+		{"network.proxy", fmt.Sprintf("%v", configData["network.proxy"]), validConfigKeys["network.proxy"].description},
+		{"network.ca_bundle", fmt.Sprintf("%v", configData["network.ca_bundle"]), validConfigKeys["network.ca_bundle"].description},
+		{"network.insecure_skip_verify", fmt.Sprintf("%v", configData["network.insecure_skip_verify"]), validConfigKeys["network.insecure_skip_verify"].description},
+		{"require_env_banner", fmt.Sprintf("%v", configData["require_env_banner"]), validConfigKeys["require_env_banner"].description},
 	}
 
 	ui.RenderTable([]string{"Key", "Value", "Description"}, rows)
 }
 
 func printConfigPlain(configData map[string]interface{}) {
+	ui.PrintPlain("api.read_only=%v", configData["api.read_only"])
+	ui.PrintPlain("api.base_url=%v", configData["api.base_url"])
+	ui.PrintPlain("websocket.url=%v", configData["websocket.url"])
 	ui.PrintPlain("output.format=%v", configData["output.format"])
 	ui.PrintPlain("output.color=%v", configData["output.color"])
+	ui.PrintPlain("output.price_format=%v", configData["output.price_format"])
+	ui.PrintPlain("output.show_mid=%v", configData["output.show_mid"])
 	ui.PrintPlain("defaults.limit=%v", configData["defaults.limit"])
+	ui.PrintPlain("defaults.subaccount=%v", configData["defaults.subaccount"])
+	ui.PrintPlain("usage.enabled=%v", configData["usage.enabled"])
+	ui.PrintPlain("audit.enabled=%v", configData["audit.enabled"])
+	ui.PrintPlain("alerts.webhook_url=%v", configData["alerts.webhook_url"])
+	ui.PrintPlain("alerts.slack_webhook_url=%v", configData["alerts.slack_webhook_url"])
+	ui.PrintPlain("alerts.desktop=%v", configData["alerts.desktop"])
+	ui.PrintPlain("risk.max_exposure=%v", configData["risk.max_exposure"])
+	ui.PrintPlain("network.proxy=%v", configData["network.proxy"])
+	ui.PrintPlain("network.ca_bundle=%v", configData["network.ca_bundle"])
+	ui.PrintPlain("network.insecure_skip_verify=%v", configData["network.insecure_skip_verify"])
+	ui.PrintPlain("require_env_banner=%v", configData["require_env_banner"])
 }
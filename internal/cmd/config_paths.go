@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/audit"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/daemon"
+	"github.com/6missedcalls/kalshi-cli/internal/equity"
+	"github.com/6missedcalls/kalshi-cli/internal/fillstore"
+	"github.com/6missedcalls/kalshi-cli/internal/notes"
+	"github.com/6missedcalls/kalshi-cli/internal/seriescache"
+	"github.com/6missedcalls/kalshi-cli/internal/snapshot"
+	"github.com/6missedcalls/kalshi-cli/internal/triggers"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/usage"
+)
+
+var configPathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "Show where config, logs, snapshots and cache are stored",
+	Long: `Print the resolved file locations and how they were chosen.
+
+Locations are resolved in order:
+  1. --config-dir or KALSHI_CONFIG_DIR: everything in that directory
+  2. ~/.kalshi, if it already exists: everything in that directory
+  3. XDG_CONFIG_HOME, XDG_DATA_HOME and XDG_CACHE_HOME, each falling back to
+     the platform default: ~/.config, ~/.local/share and ~/.cache on Linux,
+     ~/Library/Application Support and ~/Library/Caches on macOS, and
+     %APPDATA% and %LOCALAPPDATA% on Windows
+
+--config still overrides the config file alone.`,
+	Example: `  kalshi-cli config paths
+  kalshi-cli config paths --config-dir /srv/kalshi-bot --json`,
+	RunE: runConfigPaths,
+}
+
+func init() {
+	configCmd.AddCommand(configPathsCmd)
+}
+
+// resolvedPaths is every file location kalshi-cli uses
+type resolvedPaths struct {
+	config.Paths
+	ConfigFile  string `json:"config_file"`
+	AuditLog    string `json:"audit_log"`
+	UsageLog    string `json:"usage_log"`
+	Snapshots   string `json:"snapshots"`
+	FillStore   string `json:"fill_store"`
+	Daemons     string `json:"daemons"`
+	Notes       string `json:"notes"`
+	Equity      string `json:"equity"`
+	Triggers    string `json:"triggers"`
+	SeriesCache string `json:"series_cache"`
+}
+
+func runConfigPaths(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	configFile := cfgFile
+	if configFile == "" {
+		configFile = filepath.Join(paths.Config, "config.yaml")
+	}
+	resolved := resolvedPaths{
+		Paths:       paths,
+		ConfigFile:  configFile,
+		AuditLog:    audit.DefaultPath(paths.Data),
+		UsageLog:    usage.DefaultPath(paths.Data),
+		Snapshots:   snapshot.DefaultDir(paths.Data),
+		FillStore:   fillstore.DefaultPath(paths.Data),
+		Daemons:     daemon.DefaultDir(paths.Data),
+		Notes:       notes.DefaultPath(paths.Data),
+		Equity:      equity.DefaultPath(paths.Data),
+		Triggers:    triggers.DefaultPath(paths.Data),
+		SeriesCache: seriescache.DefaultPath(paths.Cache),
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			ui.RenderKeyValue([][]string{
+				{"Resolved From", resolved.Source},
+				{"Config Dir", resolved.Config},
+				{"Config File", resolved.ConfigFile},
+				{"Data Dir", resolved.Data},
+				{"Audit Log", resolved.AuditLog},
+				{"Usage Log", resolved.UsageLog},
+				{"Snapshots", resolved.Snapshots},
+				{"Fill Store", resolved.FillStore},
+				{"Daemons", resolved.Daemons},
+				{"Market Notes", resolved.Notes},
+				{"Equity History", resolved.Equity},
+				{"Exit Triggers", resolved.Triggers},
+				{"Cache Dir", resolved.Cache},
+				{"Series Cache", resolved.SeriesCache},
+			})
+		},
+		resolved,
+		func() {
+			ui.PrintPlain("config\t%s", resolved.Config)
+			ui.PrintPlain("config_file\t%s", resolved.ConfigFile)
+			ui.PrintPlain("data\t%s", resolved.Data)
+			ui.PrintPlain("audit_log\t%s", resolved.AuditLog)
+			ui.PrintPlain("usage_log\t%s", resolved.UsageLog)
+			ui.PrintPlain("snapshots\t%s", resolved.Snapshots)
+			ui.PrintPlain("fill_store\t%s", resolved.FillStore)
+			ui.PrintPlain("daemons\t%s", resolved.Daemons)
+			ui.PrintPlain("notes\t%s", resolved.Notes)
+			ui.PrintPlain("equity\t%s", resolved.Equity)
+			ui.PrintPlain("triggers\t%s", resolved.Triggers)
+			ui.PrintPlain("cache\t%s", resolved.Cache)
+			ui.PrintPlain("series_cache\t%s", resolved.SeriesCache)
+		},
+	)
+}
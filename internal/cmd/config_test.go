@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+)
+
+// loadTestConfig loads config from an empty config directory as initConfig
+// would and returns the config.yaml path
+func loadTestConfig(t *testing.T) string {
+	t.Helper()
+	oldCfg := cfg
+	viper.Reset()
+	t.Cleanup(func() {
+		cfg = oldCfg
+		viper.Reset()
+		config.SetDirOverride("")
+	})
+	dir := t.TempDir()
+	config.SetDirOverride(dir)
+
+	var err error
+	if cfg, err = config.Load(""); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+func TestConfigSet_KeepsSubaccountFlagOut(t *testing.T) {
+	path := loadTestConfig(t)
+	// --subaccount 4
+	cfg.Defaults.Subaccount = 4
+
+	if err := runConfigSet(configSetCmd, []string{"output.format", "json"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "subaccount") {
+		t.Errorf("--subaccount was saved to the config file:\n%s", data)
+	}
+}
+
+func TestConfigSet_KeepsReadOnlyFlagOut(t *testing.T) {
+	path := loadTestConfig(t)
+	// --read-only --prod
+	cfg.API.ReadOnly = true
+	cfg.API.Production = true
+
+	if err := runConfigSet(configSetCmd, []string{"defaults.limit", "20"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "read_only") || strings.Contains(string(data), "production") {
+		t.Errorf("runtime overrides were saved to the config file:\n%s", data)
+	}
+	if !strings.Contains(string(data), "limit: 20") {
+		t.Errorf("config file missing the new limit:\n%s", data)
+	}
+}
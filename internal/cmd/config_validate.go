@@ -0,0 +1,428 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented default config file",
+	Long: `Write a config file containing every setting at its default value, with a
+comment explaining each one. Optional settings such as credentials are
+included commented out.
+
+The file is written to ~/.kalshi/config.yaml (or the --config path) and is
+never overwritten unless --force is set. Use --print to write it to stdout
+instead.`,
+	Example: `  kalshi-cli config init
+  kalshi-cli config init --print > config.yaml`,
+	RunE: runConfigInit,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Check a config file for unknown keys and invalid values",
+	Long: `Check a config file against the settings kalshi-cli understands.
+
+Every problem is reported with its line number: unknown keys (usually typos,
+which are otherwise silently ignored), values of the wrong type, values
+outside an allowed set such as output.format, and malformed timeouts.
+
+Validates ~/.kalshi/config.yaml (or the --config path) unless a file is
+given. Exits non-zero if any problem is found.`,
+	Example: `  kalshi-cli config validate
+  kalshi-cli config validate ./bot-config.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigValidate,
+}
+
+var (
+	configInitForce bool
+	configInitPrint bool
+)
+
+func init() {
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configValidateCmd)
+
+	configInitCmd.Flags().BoolVar(&configInitForce, "force", false, "overwrite an existing config file")
+	configInitCmd.Flags().BoolVar(&configInitPrint, "print", false, "write the default config to stdout")
+}
+
+// defaultConfigTemplate is the file written by config init. Every key in it
+// must pass config validate.
+const defaultConfigTemplate = `# kalshi-cli configuration
+# Check this file with 'kalshi-cli config validate'.
+
+api:
+  # Use the production API (real money) instead of demo. Same as --prod.
+  production: false
+  # Timeout for each API request, e.g. 30s or 1m.
+  timeout: 30s
+  # Refuse all write requests such as orders.
+  read_only: false
+  # Most requests per second this process sends (0 = no limit). A 429 or
+  # an exhausted rate-limit header also pauses requests until the window
+  # resets.
+  rate_limit: 10
+  # Retries of a request that failed with a 429, a 5xx or a network error,
+  # with jittered exponential backoff.
+  max_retries: 5
+  # REST base URL, e.g. an internal gateway. Empty uses Kalshi for the
+  # selected environment. Requests are still signed for the Kalshi path.
+  base_url: ""
+
+websocket:
+  # WebSocket URL, e.g. an internal gateway. Empty uses Kalshi.
+  url: ""
+
+output:
+  # Default output format: table, json or plain.
+  format: table
+  # Enable colored output.
+  color: true
+  # Price display in watch: dollars ($0.45), cents (45¢), percent (45%) or
+  # decimal (0.45).
+  price_format: dollars
+  # Show the bid/ask mid-price in watch.
+  show_mid: false
+
+defaults:
+  # Default limit for list commands.
+  limit: 50
+  # Subaccount used for trading and portfolio queries (0 = primary).
+  subaccount: 0
+
+usage:
+  # Record local usage statistics (see 'kalshi-cli stats').
+  enabled: true
+
+audit:
+  # Record mutating requests to the audit log (see 'kalshi-cli audit').
+  enabled: true
+
+alerts:
+  # Default webhook URL for alerts.
+  webhook_url: ""
+  # Default Slack incoming webhook for alerts.
+  slack_webhook_url: ""
+  # Send desktop notifications for alerts.
+  desktop: false
+
+risk:
+  # Exposure ceiling in cents checked by --precheck (0 = none).
+  max_exposure: 0
+
+network:
+  # http(s) or socks5 proxy URL. Empty uses HTTP_PROXY/HTTPS_PROXY/NO_PROXY.
+  proxy: ""
+  # PEM file of extra root certificates to trust, e.g. a corporate CA.
+  ca_bundle: ""
+  # Skip TLS certificate verification. Only allowed against demo.
+  insecure_skip_verify: false
+
+# Always show the PRODUCTION banner, ignoring --no-banner.
+require_env_banner: false
+
+# Credentials from a key file instead of the system keyring.
+# api_key_id: your-key-id
+# private_key_path: /path/to/key.pem
+
+# Credentials fetched from a secret manager: 1password, vault or aws.
+# credentials_provider: 1password
+# credentials_path: op://Private/Kalshi
+# credentials_region: us-east-1
+
+# Private key held on a hardware token (see 'auth login --pkcs11').
+# pkcs11:
+#   module: /usr/lib/libykcs11.so
+#   token_label: ""
+#   key_label: kalshi
+
+# Headers added to every request, e.g. for an internal gateway.
+# extra_headers:
+#   X-Gateway-Token: secret
+
+# Command aliases (see 'kalshi-cli alias').
+# aliases:
+#   buy: orders create --action buy
+
+# Saved command lines with {placeholders} (see 'kalshi-cli query').
+# queries:
+#   open-series: markets list --series {series} --status open --limit {limit=20}
+
+# Named lists of market tickers (see 'kalshi-cli watch grid --watchlist').
+# watchlists:
+#   mine: [KXBTC-26FEB12-B97000, INXD-25FEB07-B5523.99]
+`
+
+// configSchema maps every config key to its validator. Sections are the
+// prefixes of dotted keys; aliases, queries and extra_headers are free-form
+// maps of strings and watchlists a free-form map of string lists.
+func configSchema() map[string]func(string) error {
+	schema := map[string]func(string) error{
+		"api.production":       validateBool,
+		"api.timeout":          validateDuration,
+		"api.rate_limit":       validateNonNegativeInt,
+		"api.max_retries":      validateNonNegativeInt,
+		"api_key_id":           validateAny,
+		"private_key_path":     validateAny,
+		"credentials_provider": validateCredentialsProvider,
+		"credentials_path":     validateAny,
+		"credentials_region":   validateAny,
+		"pkcs11.module":        validateAny,
+		"pkcs11.token_label":   validateAny,
+		"pkcs11.key_label":     validateAny,
+		"no_keyring":           validateBool,
+	}
+	for key, kc := range validConfigKeys {
+		schema[key] = kc.validate
+	}
+	return schema
+}
+
+func validateAny(string) error {
+	return nil
+}
+
+// validateDuration accepts a Go duration such as 30s, or a number of
+// nanoseconds as written by 'config set'
+func validateDuration(value string) error {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return fmt.Errorf("must be positive")
+		}
+		return nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && n > 0 {
+		return nil
+	}
+	return fmt.Errorf("must be a duration such as 30s or 1m")
+}
+
+func validateCredentialsProvider(value string) error {
+	switch strings.ToLower(value) {
+	case "", "1password", "op", "vault", "aws", "aws-secrets-manager":
+		return nil
+	}
+	return fmt.Errorf("must be one of: 1password, vault, aws")
+}
+
+// configIssue is one problem found by config validate
+type configIssue struct {
+	Line    int    `json:"line"`
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// validateConfigYAML checks a YAML config document against configSchema
+func validateConfigYAML(data []byte) ([]configIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []configIssue{{Line: root.Line, Message: "config must be a mapping of keys to values"}}, nil
+	}
+
+	schema := configSchema()
+	sections := make(map[string]bool)
+	for key := range schema {
+		if i := strings.LastIndex(key, "."); i > 0 {
+			sections[key[:i]] = true
+		}
+	}
+
+	var issues []configIssue
+	var walk func(prefix string, m *yaml.Node)
+	walk = func(prefix string, m *yaml.Node) {
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			k, v := m.Content[i], m.Content[i+1]
+			key := k.Value
+			if prefix != "" {
+				key = prefix + "." + k.Value
+			}
+
+			switch {
+			case key == "aliases" || key == "queries" || key == "extra_headers":
+				issues = append(issues, validateStringMapNode(key, v)...)
+			case key == "watchlists":
+				issues = append(issues, validateWatchlistsNode(key, v)...)
+			case sections[key]:
+				if v.Kind != yaml.MappingNode {
+					issues = append(issues, configIssue{Line: v.Line, Key: key, Message: "must be a section of settings"})
+					continue
+				}
+				walk(key, v)
+			case schema[key] != nil:
+				if v.Kind != yaml.ScalarNode {
+					issues = append(issues, configIssue{Line: v.Line, Key: key, Message: "must be a single value"})
+					continue
+				}
+				if err := schema[key](v.Value); err != nil {
+					issues = append(issues, configIssue{Line: v.Line, Key: key, Message: fmt.Sprintf("invalid value %q: %v", v.Value, err)})
+				}
+			default:
+				issues = append(issues, configIssue{Line: k.Line, Key: key, Message: "unknown key"})
+			}
+		}
+	}
+	walk("", root)
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues, nil
+}
+
+// validateStringMapNode checks a free-form map of strings such as aliases
+func validateStringMapNode(key string, v *yaml.Node) []configIssue {
+	if v.Kind == yaml.ScalarNode && v.Tag == "!!null" {
+		return nil
+	}
+	if v.Kind != yaml.MappingNode {
+		return []configIssue{{Line: v.Line, Key: key, Message: "must be a mapping of names to strings"}}
+	}
+	var issues []configIssue
+	for i := 0; i+1 < len(v.Content); i += 2 {
+		name, value := v.Content[i], v.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			issues = append(issues, configIssue{Line: value.Line, Key: key + "." + name.Value, Message: "must be a string"})
+		}
+	}
+	return issues
+}
+
+// validateWatchlistsNode checks a map of names to lists of tickers
+func validateWatchlistsNode(key string, v *yaml.Node) []configIssue {
+	if v.Kind == yaml.ScalarNode && v.Tag == "!!null" {
+		return nil
+	}
+	if v.Kind != yaml.MappingNode {
+		return []configIssue{{Line: v.Line, Key: key, Message: "must be a mapping of names to lists of tickers"}}
+	}
+	var issues []configIssue
+	for i := 0; i+1 < len(v.Content); i += 2 {
+		name, list := v.Content[i], v.Content[i+1]
+		if list.Kind != yaml.SequenceNode {
+			issues = append(issues, configIssue{Line: list.Line, Key: key + "." + name.Value, Message: "must be a list of tickers"})
+			continue
+		}
+		for _, item := range list.Content {
+			if item.Kind != yaml.ScalarNode {
+				issues = append(issues, configIssue{Line: item.Line, Key: key + "." + name.Value, Message: "must be a list of tickers"})
+				continue
+			}
+			if _, err := normalizeTicker(item.Value); err != nil {
+				issues = append(issues, configIssue{Line: item.Line, Key: key + "." + name.Value, Message: err.Error()})
+			}
+		}
+	}
+	return issues
+}
+
+// defaultConfigPath is the config file in use, or ~/.kalshi/config.yaml
+func defaultConfigPath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	if configInitPrint {
+		fmt.Fprint(ui.Writer(), defaultConfigTemplate)
+		return nil
+	}
+
+	path, err := defaultConfigPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil && !configInitForce {
+		return fmt.Errorf("%s already exists; use --force to overwrite it or --print to see the defaults", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(defaultConfigTemplate), 0600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	PrintSuccess(fmt.Sprintf("Wrote default config to %s", path))
+	return nil
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	var path string
+	if len(args) == 1 {
+		path = args[0]
+	} else {
+		var err error
+		if path, err = defaultConfigPath(); err != nil {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	issues, err := validateConfigYAML(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if issues == nil {
+		issues = []configIssue{}
+	}
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() {
+			if len(issues) == 0 {
+				PrintSuccess(fmt.Sprintf("%s is valid", path))
+				return
+			}
+			headers := []string{"Line", "Key", "Problem"}
+			rows := make([][]string, len(issues))
+			for i, issue := range issues {
+				rows[i] = []string{fmt.Sprintf("%d", issue.Line), issue.Key, issue.Message}
+			}
+			ui.RenderTable(headers, rows)
+		},
+		map[string]interface{}{"file": path, "valid": len(issues) == 0, "issues": issues},
+		func() {
+			for _, issue := range issues {
+				ui.PrintPlain("%s:%d\t%s\t%s", path, issue.Line, issue.Key, issue.Message)
+			}
+		},
+	); err != nil {
+		return err
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("%s has %d problem(s)", path, len(issues))
+	}
+	return nil
+}
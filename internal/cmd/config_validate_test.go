@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+)
+
+func TestDefaultConfigTemplateIsValid(t *testing.T) {
+	issues, err := validateConfigYAML([]byte(defaultConfigTemplate))
+	if err != nil {
+		t.Fatalf("validateConfigYAML: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("default config has issues: %+v", issues)
+	}
+}
+
+func TestValidateConfigYAML(t *testing.T) {
+	data := []byte(`api:
+  production: maybe
+  timout: 30s
+output:
+  format: xml
+defaults: 5
+aliases:
+  b: orders create
+risk:
+  max_exposure: -1
+credentials_provider: lastpass
+`)
+
+	issues, err := validateConfigYAML(data)
+	if err != nil {
+		t.Fatalf("validateConfigYAML: %v", err)
+	}
+
+	want := []configIssue{
+		{Line: 2, Key: "api.production"},
+		{Line: 3, Key: "api.timout"},
+		{Line: 5, Key: "output.format"},
+		{Line: 6, Key: "defaults"},
+		{Line: 10, Key: "risk.max_exposure"},
+		{Line: 11, Key: "credentials_provider"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues, want %d: %+v", len(issues), len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Line != w.Line || issues[i].Key != w.Key {
+			t.Errorf("issue %d = line %d %s, want line %d %s", i, issues[i].Line, issues[i].Key, w.Line, w.Key)
+		}
+	}
+}
+
+func TestValidateConfigYAMLInvalid(t *testing.T) {
+	if _, err := validateConfigYAML([]byte("api: [unclosed")); err == nil {
+		t.Error("expected an error for malformed YAML")
+	}
+}
+
+func TestValidateConfigYAMLWatchlists(t *testing.T) {
+	data := []byte(`watchlists:
+  mine: [KXBTC-26FEB12-B97000, INXD-25FEB07-B5523.99]
+  bad: [KXBTC--X]
+  flat: KXBTC-26FEB12-B97000
+`)
+
+	issues, err := validateConfigYAML(data)
+	if err != nil {
+		t.Fatalf("validateConfigYAML: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Key != "watchlists.bad" || issues[1].Key != "watchlists.flat" {
+		t.Errorf("issues = %+v, want watchlists.bad and watchlists.flat", issues)
+	}
+}
+
+func TestEnvConfigTemplateCoversSettings(t *testing.T) {
+	for key := range validConfigKeys {
+		name := config.EnvName(key)
+		if alias := config.EnvAlias(key); alias != "" {
+			name = alias
+		}
+		if !strings.Contains(envConfigTemplate, name+"=") {
+			t.Errorf("config env template is missing %s (%s)", name, key)
+		}
+	}
+}
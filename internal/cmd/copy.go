@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/clipboard"
+)
+
+var copyOutput bool
+
+// addCopyFlag registers --copy on a command whose main result is what
+func addCopyFlag(cmd *cobra.Command, what string) {
+	cmd.Flags().BoolVar(&copyOutput, "copy", false, "copy the "+what+" to the system clipboard")
+}
+
+// clipboardWrite is clipboard.Write, replaced in tests
+var clipboardWrite = clipboard.Write
+
+// copyValue puts value on the clipboard when --copy is set. The command has
+// already done its work, so a failure is reported as a warning on stderr.
+func copyValue(what, value string) {
+	if !copyOutput || value == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := clipboardWrite(ctx, value); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not copy %s to the clipboard: %v\n", what, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Copied %s to the clipboard\n", what)
+}
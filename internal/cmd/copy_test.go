@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCopyValue(t *testing.T) {
+	var got []string
+	orig := clipboardWrite
+	clipboardWrite = func(_ context.Context, text string) error {
+		got = append(got, text)
+		return nil
+	}
+	defer func() { clipboardWrite = orig; copyOutput = false }()
+
+	copyOutput = false
+	copyValue("order ID", "ord-1")
+	if len(got) != 0 {
+		t.Fatalf("copyValue without --copy wrote %v", got)
+	}
+
+	copyOutput = true
+	copyValue("order ID", "")
+	copyValue("order ID", "ord-2")
+	if len(got) != 1 || got[0] != "ord-2" {
+		t.Errorf("clipboard writes = %v, want [ord-2]", got)
+	}
+}
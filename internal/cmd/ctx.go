@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var ctxCmd = &cobra.Command{
+	Use:   "ctx <market-ticker>",
+	Short: "Show a market, its book, trades, your stake and its event on one screen",
+	Long: `Show everything about a market worth seeing before trading it: the market
+details, the top of the book, the latest trades, your position and resting
+orders, and the other markets in its event.
+
+The requests are made in parallel. Only the market itself is required; if
+any other section cannot be fetched it is left out with a warning (in --json,
+under errors).`,
+	Example: `  kalshi-cli ctx KXBTC-26FEB12-B97000
+  kalshi-cli ctx KXBTC-26FEB12-B97000 --depth 5 --trades 20
+  kalshi-cli ctx KXBTC-26FEB12-B97000 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCtx,
+}
+
+var (
+	ctxDepth  int
+	ctxTrades int
+)
+
+func init() {
+	rootCmd.AddCommand(ctxCmd)
+
+	ctxCmd.Flags().IntVar(&ctxDepth, "depth", 3, "orderbook levels to show on each side")
+	ctxCmd.Flags().IntVar(&ctxTrades, "trades", 10, "number of recent trades to show")
+}
+
+// marketContext is everything ctx shows about one market
+type marketContext struct {
+	Market    *models.Market    `json:"market"`
+	Orderbook *models.Orderbook `json:"orderbook,omitempty"`
+	Trades    []models.Trade    `json:"trades"`
+	Mine      *myMarket         `json:"mine,omitempty"`
+	Siblings  []models.Market   `json:"siblings"`
+	// Errors holds the sections that could not be fetched, by name
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+func runCtx(cmd *cobra.Command, args []string) error {
+	ticker, err := normalizeTicker(args[0])
+	if err != nil {
+		return err
+	}
+	if ctxDepth <= 0 {
+		return fmt.Errorf("--depth must be positive")
+	}
+	if ctxTrades < 0 {
+		return fmt.Errorf("--trades cannot be negative")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	mc, err := fetchMarketContext(context.Background(), client, ticker, ctxTrades)
+	if err != nil {
+		return err
+	}
+	mc.Orderbook = topOfBook(mc.Orderbook, ctxDepth)
+	return outputMarketContext(mc)
+}
+
+// fetchMarketContext makes every request for ticker in parallel. The event's
+// markets are listed as soon as the market names its event. Failing to get
+// the market is an error; any other failure is recorded in Errors.
+func fetchMarketContext(ctx context.Context, client *api.Client, ticker string, trades int) (*marketContext, error) {
+	mc := &marketContext{Trades: []models.Trade{}, Siblings: []models.Market{}}
+	g := client.Group(ctx)
+
+	g.Go("market", func(ctx context.Context) error {
+		reqCtx, cancel := withTimeout(ctx)
+		market, err := client.GetMarket(reqCtx, ticker)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get market: %w", marketNotFound(ctx, client, ticker, err))
+		}
+		mc.Market = market
+		if market.EventTicker == "" {
+			return nil
+		}
+
+		g.Go("siblings", func(ctx context.Context) error {
+			reqCtx, cancel := withTimeout(ctx)
+			defer cancel()
+			result, err := client.ListMarkets(reqCtx, api.ListMarketsParams{EventTicker: market.EventTicker, Limit: 200})
+			if err != nil {
+				return fmt.Errorf("failed to list event markets: %w", err)
+			}
+			mc.Siblings = result.Markets
+			sortIfDeterministic(mc.Siblings, func(i, j int) bool { return mc.Siblings[i].Ticker < mc.Siblings[j].Ticker })
+			return nil
+		})
+		return nil
+	})
+	g.Go("orderbook", func(ctx context.Context) error {
+		reqCtx, cancel := withTimeout(ctx)
+		defer cancel()
+		orderbook, err := client.GetOrderbook(reqCtx, ticker)
+		if err != nil {
+			return fmt.Errorf("failed to get orderbook: %w", err)
+		}
+		mc.Orderbook = orderbook
+		return nil
+	})
+	if trades > 0 {
+		g.Go("trades", func(ctx context.Context) error {
+			reqCtx, cancel := withTimeout(ctx)
+			defer cancel()
+			result, err := client.GetTrades(reqCtx, api.GetTradesParams{Ticker: ticker, Limit: trades})
+			if err != nil {
+				return fmt.Errorf("failed to get trades: %w", err)
+			}
+			mc.Trades = append(mc.Trades, result.Trades...)
+			return nil
+		})
+	}
+	g.Go("mine", func(ctx context.Context) error {
+		mine, err := fetchMyMarket(ctx, client, ticker)
+		if err != nil {
+			return err
+		}
+		mc.Mine = mine
+		return nil
+	})
+
+	errs := g.Wait()
+	if err := errs.Get("market"); err != nil {
+		return nil, err
+	}
+	mc.Errors = errs.Map()
+	return mc, nil
+}
+
+// topOfBook returns a copy of ob with at most depth levels on each side
+func topOfBook(ob *models.Orderbook, depth int) *models.Orderbook {
+	if ob == nil {
+		return nil
+	}
+	top := *ob
+	top.YesBids = ob.YesBids[:min(depth, len(ob.YesBids))]
+	top.YesAsks = ob.YesAsks[:min(depth, len(ob.YesAsks))]
+	top.NoBids = ob.NoBids[:min(depth, len(ob.NoBids))]
+	top.NoAsks = ob.NoAsks[:min(depth, len(ob.NoAsks))]
+	return &top
+}
+
+// ctxBookRows lays the top of the book out as rows of bid and ask levels,
+// with the resting quantity of mine at each
+func ctxBookRows(ob *models.Orderbook, mine *myMarket) [][]string {
+	myBids, myAsks := mine.restingAt()
+	level := func(levels []models.OrderbookLevel, i int, resting map[int]int) (price, qty, my string) {
+		if i >= len(levels) {
+			return "", "", ""
+		}
+		l := levels[i]
+		if n := resting[l.Price]; n > 0 {
+			my = fmt.Sprintf("%d", n)
+		}
+		return formatCents(l.Price), fmt.Sprintf("%d", l.Quantity), my
+	}
+
+	var rows [][]string
+	for i := 0; i < max(len(ob.YesBids), len(ob.YesAsks)); i++ {
+		bid, bidQty, myBid := level(ob.YesBids, i, myBids)
+		ask, askQty, myAsk := level(ob.YesAsks, i, myAsks)
+		rows = append(rows, []string{myBid, bidQty, bid, ask, askQty, myAsk})
+	}
+	return rows
+}
+
+// ctxSiblingRows lists the event's markets, marking ticker
+func ctxSiblingRows(siblings []models.Market, ticker string) [][]string {
+	rows := make([][]string, 0, len(siblings))
+	for _, m := range siblings {
+		mark := ""
+		if m.Ticker == ticker {
+			mark = "*"
+		}
+		rows = append(rows, []string{
+			mark,
+			m.Ticker,
+			truncateMarketString(m.Subtitle, 40),
+			formatCents(m.YesBid),
+			formatCents(m.YesAsk),
+			formatCents(m.LastPrice),
+			fmt.Sprintf("%d", m.Volume),
+		})
+	}
+	return rows
+}
+
+func outputMarketContext(mc *marketContext) error {
+	m := mc.Market
+
+	tableFunc := func() {
+		fmt.Fprintf(ui.Writer(), "\n%s  %s\n\n", ui.TitleStyle.Render(m.Ticker), m.Title)
+		ui.RenderKeyValue([][]string{
+			{"Status", formatMarketStatus(m.Status)},
+			{"Yes Bid/Ask", formatCents(m.YesBid) + " / " + formatCents(m.YesAsk)},
+			{"Last Price", formatCents(m.LastPrice)},
+			{"Volume", fmt.Sprintf("%d (24h %d)", m.Volume, m.Volume24H)},
+			{"Open Interest", fmt.Sprintf("%d", m.OpenInterest)},
+			{"Close Time", formatMarketTime(m.CloseTime)},
+		})
+
+		if mc.Orderbook != nil {
+			fmt.Fprintf(ui.Writer(), "\n%s\n", ui.BoldStyle.Render("Top of book"))
+			rows := ctxBookRows(mc.Orderbook, mc.Mine)
+			if mc.Mine == nil || len(mc.Mine.Orders) == 0 {
+				// Without resting orders the Mine columns would be empty
+				for i, r := range rows {
+					rows[i] = r[1:5]
+				}
+				ui.RenderTable([]string{"Bid Qty", "Bid", "Ask", "Ask Qty"}, rows)
+			} else {
+				ui.RenderTable([]string{"Mine", "Bid Qty", "Bid", "Ask", "Ask Qty", "Mine"}, rows)
+			}
+		}
+
+		if len(mc.Trades) > 0 {
+			fmt.Fprintf(ui.Writer(), "\n%s\n", ui.BoldStyle.Render("Recent trades"))
+			rows := make([][]string, 0, len(mc.Trades))
+			for _, t := range mc.Trades {
+				rows = append(rows, []string{formatMarketTime(t.CreatedTime), formatCents(t.Price), fmt.Sprintf("%d", t.Count), formatTradeSide(t.TakerSide)})
+			}
+			ui.RenderTable([]string{"Time", "Price", "Quantity", "Side"}, rows)
+		}
+
+		if mc.Mine != nil {
+			fmt.Fprintf(ui.Writer(), "\n%s\n", ui.BoldStyle.Render("Your stake"))
+			ui.RenderKeyValue(mc.Mine.pairs())
+		}
+
+		if len(mc.Siblings) > 0 {
+			fmt.Fprintf(ui.Writer(), "\n%s\n", ui.BoldStyle.Render("Event "+m.EventTicker))
+			ui.RenderTable([]string{"", "Ticker", "Subtitle", "Yes Bid", "Yes Ask", "Last", "Volume"}, ctxSiblingRows(mc.Siblings, m.Ticker))
+		}
+
+		for _, section := range sortedKeys(mc.Errors) {
+			fmt.Fprintln(ui.Writer())
+			PrintWarning(fmt.Sprintf("Warning: %s", mc.Errors[section]))
+		}
+	}
+
+	plainFunc := func() {
+		fmt.Fprintf(ui.Writer(), "Ticker: %s\n", m.Ticker)
+		fmt.Fprintf(ui.Writer(), "Title: %s\n", m.Title)
+		fmt.Fprintf(ui.Writer(), "Status: %s\n", m.Status)
+		fmt.Fprintf(ui.Writer(), "Yes Bid/Ask: %s / %s\n", formatCents(m.YesBid), formatCents(m.YesAsk))
+		fmt.Fprintf(ui.Writer(), "Last Price: %s\n", formatCents(m.LastPrice))
+		if ob := mc.Orderbook; ob != nil {
+			myBids, myAsks := mc.Mine.restingAt()
+			for _, l := range ob.YesBids {
+				fmt.Fprintf(ui.Writer(), "Bid: %s x %d%s\n", formatCents(l.Price), l.Quantity, myPlainLevel(myBids[l.Price]))
+			}
+			for _, l := range ob.YesAsks {
+				fmt.Fprintf(ui.Writer(), "Ask: %s x %d%s\n", formatCents(l.Price), l.Quantity, myPlainLevel(myAsks[l.Price]))
+			}
+		}
+		for _, t := range mc.Trades {
+			fmt.Fprintf(ui.Writer(), "Trade: %s %s x %d %s\n", t.CreatedTime.Format(time.RFC3339), formatCents(t.Price), t.Count, t.TakerSide)
+		}
+		if mc.Mine != nil {
+			mc.Mine.printPlain()
+		}
+		for _, s := range mc.Siblings {
+			fmt.Fprintf(ui.Writer(), "Sibling: %s\t%s\t%s\t%s\n", s.Ticker, formatCents(s.YesBid), formatCents(s.YesAsk), formatCents(s.LastPrice))
+		}
+		for _, section := range sortedKeys(mc.Errors) {
+			fmt.Fprintf(ui.Writer(), "Error: %s\n", mc.Errors[section])
+		}
+	}
+
+	return ui.Output(GetOutputFormat(), tableFunc, mc, plainFunc)
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestTopOfBook(t *testing.T) {
+	ob := &models.Orderbook{
+		Ticker:  "KXA",
+		YesBids: []models.OrderbookLevel{{Price: 45, Quantity: 1}, {Price: 44, Quantity: 2}, {Price: 43, Quantity: 3}},
+		YesAsks: []models.OrderbookLevel{{Price: 48, Quantity: 4}},
+	}
+	top := topOfBook(ob, 2)
+	if len(top.YesBids) != 2 || len(top.YesAsks) != 1 || len(ob.YesBids) != 3 {
+		t.Errorf("top = %+v, book = %+v", top, ob)
+	}
+	if topOfBook(nil, 2) != nil {
+		t.Error("nil book should stay nil")
+	}
+}
+
+func TestCtxBookRows(t *testing.T) {
+	ob := &models.Orderbook{
+		YesBids: []models.OrderbookLevel{{Price: 45, Quantity: 100}, {Price: 44, Quantity: 20}},
+		YesAsks: []models.OrderbookLevel{{Price: 48, Quantity: 30}},
+	}
+	mine := &myMarket{Orders: []models.Order{
+		{Side: models.OrderSideYes, Action: models.OrderActionBuy, YesPrice: 44, RemainingCount: 3},
+		{Side: models.OrderSideNo, Action: models.OrderActionBuy, NoPrice: 52, RemainingCount: 7},
+	}}
+	want := [][]string{
+		{"", "100", formatCents(45), formatCents(48), "30", "7"},
+		{"3", "20", formatCents(44), "", "", ""},
+	}
+	if got := ctxBookRows(ob, mine); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestCtxSiblingRows(t *testing.T) {
+	rows := ctxSiblingRows([]models.Market{{Ticker: "KXA-1"}, {Ticker: "KXA-2"}}, "KXA-2")
+	if rows[0][0] != "" || rows[1][0] != "*" {
+		t.Errorf("marks = %q, %q", rows[0][0], rows[1][0])
+	}
+}
@@ -0,0 +1,477 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/daemon"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run long-running commands in the background",
+	Long: `Run watch, alerts, schedule and other long-running kalshi-cli commands as
+background jobs with a pid file, a log file and automatic restarts.
+
+Each job has a name. Its state lives in the daemons directory under the data
+directory (see 'config paths'): <name>.json, <name>.pid and <name>.log.`,
+}
+
+var daemonStartCmd = &cobra.Command{
+	Use:   "start <name> [-- <command> [args...]]",
+	Short: "Start a command as a named background job",
+	Long: `Start a kalshi-cli command in the background under a supervisor that
+restarts it according to --restart, waiting 1s, 2s, 4s... up to a minute
+between failures. The wait resets after a run that lasted a minute.
+
+The command is everything after --, without the leading kalshi-cli. Global
+flags given before 'daemon start', such as --prod or --config-dir, are passed
+on to the job. Jobs always run with --no-input. Without a command, the job's
+previous command is started again.`,
+	Example: `  kalshi-cli daemon start fills -- watch fills --dedupe --persist
+  kalshi-cli --prod daemon start btc-alerts -- watch ticker KXBTC-26FEB12-B97000 --alert-move 5 --notify
+  kalshi-cli daemon start fills`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDaemonStart,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop <name>",
+	Short: "Stop a background job",
+	Long: `Stop a background job and its supervisor. The job is sent SIGTERM and
+given 10 seconds to exit before it is killed. On Windows it is killed
+right away.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDaemonStop,
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status [name]",
+	Short: "Show background jobs",
+	Long: `Show every background job, or one, with its state, pids, restart count and
+how its last run ended.`,
+	Example: `  kalshi-cli daemon status
+  kalshi-cli daemon status fills --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDaemonStatus,
+}
+
+var daemonLogsCmd = &cobra.Command{
+	Use:   "logs <name>",
+	Short: "Show a background job's log",
+	Example: `  kalshi-cli daemon logs fills
+  kalshi-cli daemon logs fills -n 200 -f`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDaemonLogs,
+}
+
+var daemonSuperviseCmd = &cobra.Command{
+	Use:    "supervise <name>",
+	Short:  "Run a job's supervisor in the foreground (used by daemon start)",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE:   runDaemonSupervise,
+}
+
+var (
+	daemonRestart    string
+	daemonLogLines   int
+	daemonLogsFollow bool
+)
+
+// daemonStopTimeout is how long stop waits for a supervisor to exit
+const daemonStopTimeout = 15 * time.Second
+
+// daemonInheritedFlags are the global flags passed on from daemon start to
+// the job
+var daemonInheritedFlags = []string{"config", "config-dir", "prod", "verbose", "subaccount", "read-only", "max-requests", "no-keyring", "strict-decode"}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(daemonStartCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonStatusCmd)
+	daemonCmd.AddCommand(daemonLogsCmd)
+	daemonCmd.AddCommand(daemonSuperviseCmd)
+
+	daemonStartCmd.Flags().StringVar(&daemonRestart, "restart", daemon.RestartOnFailure, "when to restart the command: on-failure, always or never")
+	daemonLogsCmd.Flags().IntVarP(&daemonLogLines, "lines", "n", 50, "number of lines to show")
+	daemonLogsCmd.Flags().BoolVarP(&daemonLogsFollow, "follow", "f", false, "keep printing new lines until interrupted")
+}
+
+// daemonManager returns the manager for the daemons directory
+func daemonManager() (*daemon.Manager, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return daemon.NewManager(daemon.DefaultDir(dir)), nil
+}
+
+// daemonJobArgs checks a job's command and prefixes the global flags set on
+// cmd. A leading "kalshi-cli" is dropped.
+func daemonJobArgs(cmd *cobra.Command, command []string) ([]string, error) {
+	if len(command) > 0 && strings.TrimSuffix(filepath.Base(command[0]), ".exe") == rootCmd.Name() {
+		command = command[1:]
+	}
+	if len(command) == 0 {
+		return nil, fmt.Errorf("no command given after --")
+	}
+	target, _, err := rootCmd.Find(command)
+	if err != nil || target == rootCmd {
+		return nil, fmt.Errorf("unknown command %q", command[0])
+	}
+	if target.HasParent() && target.Parent() == daemonCmd {
+		return nil, fmt.Errorf("a daemon job cannot run 'daemon %s'", target.Name())
+	}
+
+	var args []string
+	for _, name := range daemonInheritedFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			args = append(args, "--"+name+"="+f.Value.String())
+		}
+	}
+	return append(args, command...), nil
+}
+
+func runDaemonStart(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := daemon.ValidName(name); err != nil {
+		return err
+	}
+	if err := daemon.ValidRestart(daemonRestart); err != nil {
+		return err
+	}
+	if dash := cmd.ArgsLenAtDash(); len(args) > 1 && dash != 1 {
+		return fmt.Errorf("put the command after --, e.g. kalshi-cli daemon start %s -- watch fills", name)
+	}
+
+	m, err := daemonManager()
+	if err != nil {
+		return err
+	}
+	if pid := m.Running(name); pid != 0 {
+		return fmt.Errorf("daemon %s is already running (pid %d)", name, pid)
+	}
+
+	var spec *daemon.Spec
+	if len(args) == 1 {
+		spec, err = m.Load(name)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("no daemon named %q yet: give its command after --", name)
+			}
+			return err
+		}
+		if cmd.Flags().Changed("restart") {
+			spec.Restart = daemonRestart
+		}
+	} else {
+		jobArgs, err := daemonJobArgs(cmd, args[1:])
+		if err != nil {
+			return err
+		}
+		wd, _ := os.Getwd()
+		spec = &daemon.Spec{Name: name, Args: jobArgs, Restart: daemonRestart, WorkDir: wd}
+	}
+	spec.Created = time.Now().UTC()
+	spec.Started = time.Time{}
+	spec.Restarts = 0
+	spec.ChildPID = 0
+	spec.LastExit = ""
+	spec.ExitedAt = time.Time{}
+	spec.Stopped = false
+	spec.LogFile = m.LogPath(name)
+	spec.PIDFile = m.PIDPath(name)
+	if err := m.Save(spec); err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the kalshi-cli binary: %w", err)
+	}
+	logFile, err := os.OpenFile(spec.LogFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	supervise := []string{"daemon", "supervise", name}
+	if cfgDir != "" {
+		supervise = append(supervise, "--config-dir", cfgDir)
+	}
+	c := exec.Command(exe, supervise...)
+	c.Stdout = logFile
+	c.Stderr = logFile
+	c.Dir = spec.WorkDir
+	c.SysProcAttr = daemon.DetachAttr()
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("failed to start supervisor: %w", err)
+	}
+	pid := c.Process.Pid
+	// The supervisor writes the same pid; writing it here too means status
+	// sees the job straight away
+	if err := m.WritePID(name, pid); err != nil {
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+	c.Process.Release()
+
+	result := map[string]any{"name": name, "pid": pid, "args": spec.Args, "log_file": spec.LogFile}
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			PrintSuccess(fmt.Sprintf("Started %s (pid %d)", name, pid))
+			fmt.Fprintf(ui.Writer(), "Command: %s %s\n", rootCmd.Name(), strings.Join(spec.Args, " "))
+			fmt.Fprintf(ui.Writer(), "Logs:    %s\n", spec.LogFile)
+		},
+		result,
+		func() { fmt.Fprintf(ui.Writer(), "%s\t%d\t%s\n", name, pid, spec.LogFile) },
+	)
+}
+
+func runDaemonStop(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	m, err := daemonManager()
+	if err != nil {
+		return err
+	}
+	spec, err := m.Load(name)
+	if err != nil {
+		return err
+	}
+
+	pid := m.Running(name)
+	if pid == 0 {
+		PrintWarning(fmt.Sprintf("%s is not running", name))
+		return nil
+	}
+	gone, err := daemon.Stop(pid, daemonStopTimeout)
+	if err != nil {
+		return err
+	}
+	if !gone {
+		return fmt.Errorf("supervisor of %s (pid %d) did not exit within %s", name, pid, daemonStopTimeout)
+	}
+	m.RemovePID(name)
+
+	// Where the supervisor cannot pass the stop on (Windows), stop the job too
+	if spec.ChildPID != 0 && daemon.Alive(spec.ChildPID) {
+		if p, err := os.FindProcess(spec.ChildPID); err == nil {
+			daemon.Terminate(p)
+		}
+	}
+
+	PrintSuccess(fmt.Sprintf("Stopped %s", name))
+	return nil
+}
+
+// daemonStatus is one row of daemon status
+type daemonStatus struct {
+	daemon.Spec
+	State         string `json:"state"`
+	SupervisorPID int    `json:"supervisor_pid,omitempty"`
+}
+
+// daemonState names a job's state from its supervisor and spec
+func daemonState(supervisorPID int, spec daemon.Spec) string {
+	switch {
+	case supervisorPID != 0 && spec.ChildPID != 0:
+		return "running"
+	case supervisorPID != 0:
+		return "restarting"
+	case spec.Stopped:
+		return "stopped"
+	case spec.LastExit != "":
+		return "exited"
+	}
+	return "dead"
+}
+
+func runDaemonStatus(cmd *cobra.Command, args []string) error {
+	m, err := daemonManager()
+	if err != nil {
+		return err
+	}
+
+	var specs []daemon.Spec
+	if len(args) == 1 {
+		spec, err := m.Load(args[0])
+		if err != nil {
+			return err
+		}
+		specs = []daemon.Spec{*spec}
+	} else if specs, err = m.List(); err != nil {
+		return err
+	}
+
+	statuses := make([]daemonStatus, 0, len(specs))
+	for _, spec := range specs {
+		pid := m.Running(spec.Name)
+		if pid == 0 {
+			spec.ChildPID = 0
+		}
+		statuses = append(statuses, daemonStatus{Spec: spec, State: daemonState(pid, spec), SupervisorPID: pid})
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderDaemonStatusTable(statuses) },
+		statuses,
+		func() {
+			for _, s := range statuses {
+				fmt.Fprintf(ui.Writer(), "%s\t%s\t%d\t%d\t%s\n", s.Name, s.State, s.ChildPID, s.Restarts, strings.Join(s.Args, " "))
+			}
+		},
+	)
+}
+
+func renderDaemonStatusTable(statuses []daemonStatus) {
+	if len(statuses) == 0 {
+		fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("No daemons. Start one with 'kalshi-cli daemon start <name> -- <command>'."))
+		return
+	}
+	headers := []string{"Name", "State", "PID", "Restarts", "Since", "Last Exit", "Command"}
+	var rows [][]string
+	for _, s := range statuses {
+		pid, since := "-", "-"
+		if s.ChildPID != 0 {
+			pid = fmt.Sprint(s.ChildPID)
+		}
+		switch {
+		case s.SupervisorPID != 0 && !s.Started.IsZero():
+			since = formatTimeStr(s.Started.Local())
+		case !s.ExitedAt.IsZero():
+			since = formatTimeStr(s.ExitedAt.Local())
+		}
+		lastExit := s.LastExit
+		if lastExit == "" {
+			lastExit = "-"
+		}
+		rows = append(rows, []string{
+			s.Name, s.State, pid, fmt.Sprint(s.Restarts), since, lastExit,
+			truncateStr(strings.Join(s.Args, " "), 50),
+		})
+	}
+	ui.RenderTable(headers, rows)
+}
+
+// lastLines returns up to n final lines of r
+func lastLines(r io.Reader, n int) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func runDaemonLogs(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if daemonLogLines < 0 {
+		return fmt.Errorf("--lines must be zero or positive")
+	}
+	m, err := daemonManager()
+	if err != nil {
+		return err
+	}
+	if _, err := m.Load(name); err != nil {
+		return err
+	}
+
+	f, err := os.Open(m.LogPath(name))
+	if err != nil {
+		return fmt.Errorf("failed to open log: %w", err)
+	}
+	defer f.Close()
+
+	lines, err := lastLines(f, daemonLogLines)
+	if err != nil {
+		return fmt.Errorf("failed to read log: %w", err)
+	}
+	for _, line := range lines {
+		fmt.Fprintln(ui.Writer(), line)
+	}
+	if !daemonLogsFollow {
+		return nil
+	}
+
+	// The scan left the file at its end; print whatever is appended
+	ctx, stop := alertContext()
+	defer stop()
+	return followLog(ctx, f, ui.Writer())
+}
+
+// followLog copies data appended to f to w until ctx is done
+func followLog(ctx context.Context, f *os.File, w io.Writer) error {
+	t := time.NewTicker(500 * time.Millisecond)
+	defer t.Stop()
+	for {
+		if _, err := io.Copy(w, f); err != nil {
+			return fmt.Errorf("failed to read log: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+func runDaemonSupervise(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	m, err := daemonManager()
+	if err != nil {
+		return err
+	}
+	spec, err := m.Load(name)
+	if err != nil {
+		return err
+	}
+	if pid := m.Running(name); pid != 0 && pid != os.Getpid() {
+		return fmt.Errorf("daemon %s is already running (pid %d)", name, pid)
+	}
+	if err := m.WritePID(name, os.Getpid()); err != nil {
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+	defer m.RemovePID(name)
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the kalshi-cli binary: %w", err)
+	}
+
+	ctx, stop := alertContext()
+	defer stop()
+
+	sup := &daemon.Supervisor{
+		Manager: m,
+		Spec:    spec,
+		Log:     os.Stdout,
+		Command: func(ctx context.Context) *exec.Cmd {
+			c := exec.CommandContext(ctx, exe, append([]string{"--no-input"}, spec.Args...)...)
+			c.Dir = spec.WorkDir
+			c.Stdout = os.Stdout
+			c.Stderr = os.Stderr
+			c.Env = append(os.Environ(), "KALSHI_DAEMON="+name)
+			return c
+		},
+	}
+	return sup.Run(ctx)
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/daemon"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var daemonInstallCmd = &cobra.Command{
+	Use:   "install --name <name> -- <command> [args...]",
+	Short: "Generate a systemd service or launchd plist for a command",
+	Long: `Generate a unit that has the system's service manager run a kalshi-cli
+command at login and restart it according to --restart. The unit is printed
+unless --install is given, in which case it is written to the user's systemd
+unit directory or ~/Library/LaunchAgents.
+
+The command is everything after --, as for 'daemon start'. Global flags given
+before 'daemon install' and KALSHI_* environment variables are carried into
+the unit, except secrets such as KALSHI_PRIVATE_KEY and KALSHI_PKCS11_PIN.
+Under systemd the job logs to the journal; under launchd to the same log file
+'daemon logs' would use.`,
+	Example: `  kalshi-cli daemon install --name fills-watch -- watch fills --persist
+  kalshi-cli --prod daemon install --name fills-watch --install -- watch fills --persist
+  kalshi-cli daemon install --name alerts --format launchd --restart always -- alerts watch`,
+	RunE: runDaemonInstall,
+}
+
+var (
+	daemonInstallName    string
+	daemonInstallFormat  string
+	daemonInstallRestart string
+	daemonInstallWrite   bool
+	daemonInstallForce   bool
+)
+
+// daemonSecretEnv are KALSHI_* variables never written into a unit file
+var daemonSecretEnv = map[string]bool{
+	"KALSHI_PRIVATE_KEY":       true,
+	"KALSHI_PKCS11_PIN":        true,
+	"KALSHI_BUNDLE_PASSPHRASE": true,
+}
+
+// daemonRunEnv are KALSHI_* variables that describe the current run and are
+// not carried into a unit
+var daemonRunEnv = map[string]bool{
+	"KALSHI_DAEMON": true,
+	"KALSHI_MARKET": true,
+	"KALSHI_HOOK":   true,
+	"KALSHI_CLI":    true,
+}
+
+func init() {
+	daemonCmd.AddCommand(daemonInstallCmd)
+
+	daemonInstallCmd.Flags().StringVar(&daemonInstallName, "name", "", "job name, used for the unit name (required)")
+	daemonInstallCmd.Flags().StringVar(&daemonInstallFormat, "format", defaultUnitFormat(), "unit format: systemd or launchd")
+	daemonInstallCmd.Flags().StringVar(&daemonInstallRestart, "restart", daemon.RestartOnFailure, "when to restart the command: on-failure, always or never")
+	daemonInstallCmd.Flags().BoolVar(&daemonInstallWrite, "install", false, "write the unit to the service manager's user directory instead of printing it")
+	daemonInstallCmd.Flags().BoolVar(&daemonInstallForce, "force", false, "overwrite an existing unit file with --install")
+	daemonInstallCmd.MarkFlagRequired("name")
+}
+
+// defaultUnitFormat is launchd on macOS and systemd elsewhere
+func defaultUnitFormat() string {
+	if runtime.GOOS == "darwin" {
+		return daemon.FormatLaunchd
+	}
+	return daemon.FormatSystemd
+}
+
+// daemonUnitEnv returns the environment for a unit: KALSHI_DAEMON, PATH and
+// the KALSHI_* variables in environ, less secrets, which are returned apart
+func daemonUnitEnv(name string, environ []string) (env, skipped []string) {
+	env = []string{"KALSHI_DAEMON=" + name}
+	for _, kv := range environ {
+		k, _, _ := strings.Cut(kv, "=")
+		switch {
+		case daemonSecretEnv[k]:
+			skipped = append(skipped, k)
+		case k == "PATH", strings.HasPrefix(k, "KALSHI_") && !daemonRunEnv[k]:
+			env = append(env, kv)
+		}
+	}
+	sort.Strings(env[1:])
+	sort.Strings(skipped)
+	return env, skipped
+}
+
+func runDaemonInstall(cmd *cobra.Command, args []string) error {
+	name := daemonInstallName
+	if err := daemon.ValidName(name); err != nil {
+		return err
+	}
+	if len(args) > 0 && cmd.ArgsLenAtDash() != 0 {
+		return fmt.Errorf("put the command after --, e.g. kalshi-cli daemon install --name %s -- watch fills", name)
+	}
+	jobArgs, err := daemonJobArgs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the kalshi-cli binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	m, err := daemonManager()
+	if err != nil {
+		return err
+	}
+	wd, _ := os.Getwd()
+	env, skipped := daemonUnitEnv(name, os.Environ())
+
+	unit := daemon.Unit{
+		Name:    name,
+		Exec:    append([]string{exe, "--no-input"}, jobArgs...),
+		Env:     env,
+		WorkDir: wd,
+		Restart: daemonInstallRestart,
+	}
+	if daemonInstallFormat == daemon.FormatLaunchd {
+		unit.LogFile = m.LogPath(name)
+	}
+	text, err := unit.Render(daemonInstallFormat)
+	if err != nil {
+		return err
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: not writing %s into the unit; the job will need another way to get them\n", strings.Join(skipped, ", "))
+	}
+
+	if !daemonInstallWrite {
+		result := map[string]any{"name": name, "format": daemonInstallFormat, "unit": text}
+		return ui.Output(
+			GetOutputFormat(),
+			func() { fmt.Fprint(ui.Writer(), text) },
+			result,
+			func() { fmt.Fprint(ui.Writer(), text) },
+		)
+	}
+
+	path, err := daemon.InstallPath(daemonInstallFormat, name)
+	if err != nil {
+		return err
+	}
+	if err := writeUnitFile(path, text, daemonInstallForce); err != nil {
+		return err
+	}
+	if unit.LogFile != "" {
+		// launchd does not create the log file's directory
+		if err := os.MkdirAll(m.Dir(), 0700); err != nil {
+			return fmt.Errorf("failed to create daemons directory: %w", err)
+		}
+	}
+
+	next := unitNextSteps(daemonInstallFormat, name, path)
+	result := map[string]any{"name": name, "format": daemonInstallFormat, "path": path, "next": next}
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			PrintSuccess(fmt.Sprintf("Wrote %s", path))
+			fmt.Fprintln(ui.Writer(), "To start it now and at every login:")
+			for _, step := range next {
+				fmt.Fprintln(ui.Writer(), "  "+step)
+			}
+		},
+		result,
+		func() { fmt.Fprintln(ui.Writer(), path) },
+	)
+}
+
+// writeUnitFile writes a unit, refusing to replace one unless force is set
+func writeUnitFile(path, text string, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists: use --force to overwrite it", path)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to check unit file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create unit directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return fmt.Errorf("failed to write unit file: %w", err)
+	}
+	return nil
+}
+
+// unitNextSteps returns the commands that load an installed unit
+func unitNextSteps(format, name, path string) []string {
+	if format == daemon.FormatLaunchd {
+		return []string{"launchctl load -w " + path}
+	}
+	return []string{
+		"systemctl --user daemon-reload",
+		"systemctl --user enable --now " + daemon.ServiceName(name),
+	}
+}
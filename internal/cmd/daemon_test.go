@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/daemon"
+)
+
+func TestDaemonJobArgs(t *testing.T) {
+	got, err := daemonJobArgs(daemonStartCmd, []string{"kalshi-cli", "watch", "fills", "--dedupe"})
+	if err != nil {
+		t.Fatalf("daemonJobArgs failed: %v", err)
+	}
+	if strings.Join(got, " ") != "watch fills --dedupe" {
+		t.Errorf("daemonJobArgs() = %v", got)
+	}
+
+	for _, tt := range []struct {
+		command []string
+		wantErr string
+	}{
+		{nil, "no command"},
+		{[]string{"bogus"}, "unknown command"},
+		{[]string{"daemon", "status"}, "cannot run"},
+	} {
+		_, err := daemonJobArgs(daemonStartCmd, tt.command)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("daemonJobArgs(%v) error = %v, want %q", tt.command, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDaemonState(t *testing.T) {
+	tests := []struct {
+		pid  int
+		spec daemon.Spec
+		want string
+	}{
+		{10, daemon.Spec{ChildPID: 11}, "running"},
+		{10, daemon.Spec{LastExit: "exit 1"}, "restarting"},
+		{0, daemon.Spec{Stopped: true, LastExit: "exit 0"}, "stopped"},
+		{0, daemon.Spec{LastExit: "exit 0"}, "exited"},
+		{0, daemon.Spec{}, "dead"},
+	}
+	for _, tt := range tests {
+		if got := daemonState(tt.pid, tt.spec); got != tt.want {
+			t.Errorf("daemonState(%d, %+v) = %q, want %q", tt.pid, tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	got, err := lastLines(strings.NewReader("a\nb\nc\nd\n"), 2)
+	if err != nil || strings.Join(got, ",") != "c,d" {
+		t.Errorf("lastLines() = %v, %v", got, err)
+	}
+	if got, _ := lastLines(strings.NewReader("a\n"), 0); len(got) != 0 {
+		t.Errorf("lastLines(n=0) = %v", got)
+	}
+}
+
+func TestDaemonUnitEnv(t *testing.T) {
+	env, skipped := daemonUnitEnv("fills", []string{
+		"HOME=/home/me",
+		"PATH=/usr/bin",
+		"KALSHI_CONFIG_DIR=/etc/kalshi",
+		"KALSHI_PRIVATE_KEY=secret",
+		"KALSHI_DAEMON=other",
+		"KALSHI_API_KEY_ID=abc",
+	})
+	if got := strings.Join(env, " "); got != "KALSHI_DAEMON=fills KALSHI_API_KEY_ID=abc KALSHI_CONFIG_DIR=/etc/kalshi PATH=/usr/bin" {
+		t.Errorf("env = %s", got)
+	}
+	if strings.Join(skipped, " ") != "KALSHI_PRIVATE_KEY" {
+		t.Errorf("skipped = %v", skipped)
+	}
+}
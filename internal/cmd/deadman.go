@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var (
+	cancelOnExit bool
+	heartbeatTTL time.Duration
+)
+
+// addCancelOnExitFlag registers --cancel-on-exit on a command that keeps
+// running while its orders rest
+func addCancelOnExitFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&cancelOnExit, "cancel-on-exit", false, "stay running and cancel every order placed when the command exits")
+}
+
+// addHeartbeatFlag registers --heartbeat-ttl on a long-running order command
+func addHeartbeatFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&heartbeatTTL, "heartbeat-ttl", 0, "cancel every order placed if the WebSocket connection is down for this long (0 to disable)")
+}
+
+// heartbeat tracks when the exchange connection was last seen up
+type heartbeat struct {
+	ttl      time.Duration
+	lastSeen time.Time
+}
+
+// observe records the connection state at now and reports whether the
+// connection has been down for longer than the ttl
+func (h *heartbeat) observe(connected bool, now time.Time) bool {
+	if connected {
+		h.lastSeen = now
+		return false
+	}
+	return now.Sub(h.lastSeen) > h.ttl
+}
+
+// deadMansSwitch cancels the orders a command placed when the command exits
+// or its WebSocket connection stays down for longer than the heartbeat ttl.
+// Nothing can run if the process is killed outright, so pair it with an
+// order group limit to bound fills server-side.
+type deadMansSwitch struct {
+	client       *api.Client
+	cancelOnExit bool
+	ttl          time.Duration
+
+	mu     sync.Mutex
+	orders []models.Order
+
+	tripped  chan struct{}
+	tripOnce sync.Once
+	tripErr  error
+}
+
+func newDeadMansSwitch(client *api.Client, cancelOnExit bool, ttl time.Duration) *deadMansSwitch {
+	return &deadMansSwitch{
+		client:       client,
+		cancelOnExit: cancelOnExit,
+		ttl:          ttl,
+		tripped:      make(chan struct{}),
+	}
+}
+
+// track adds orders to cancel when the switch trips or the command exits
+func (d *deadMansSwitch) track(orders ...models.Order) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.orders = append(d.orders, orders...)
+}
+
+// Tripped is closed once the heartbeat is lost
+func (d *deadMansSwitch) Tripped() <-chan struct{} {
+	return d.tripped
+}
+
+// Err returns the orders the switch failed to cancel when it tripped. It is
+// only set once Tripped is closed.
+func (d *deadMansSwitch) Err() error {
+	return d.tripErr
+}
+
+// start opens the heartbeat WebSocket and watches it until ctx is done or
+// the switch trips. It is a no-op without a heartbeat ttl.
+func (d *deadMansSwitch) start(ctx context.Context) error {
+	if d.ttl <= 0 {
+		return nil
+	}
+
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return err
+	}
+	ws := newWebSocketClient(opts)
+	// Heartbeat errors are what trip the switch, so they are always shown
+	ws.OnError(func(err error) {
+		fmt.Fprintf(os.Stderr, "Warning: heartbeat: %v\n", err)
+	})
+	if err := ws.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect heartbeat WebSocket: %w", err)
+	}
+
+	// A connection lost during known exchange downtime does not trip the switch
+	calendar := loadExchangeCalendar(ctx, d.client)
+
+	go func() {
+		defer ws.Close()
+
+		hb := &heartbeat{ttl: d.ttl, lastSeen: time.Now()}
+		ticker := time.NewTicker(max(d.ttl/4, time.Second))
+		defer ticker.Stop()
+
+		paused := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if calendar.pause(now, &paused, "heartbeat checks") {
+					hb.observe(true, now)
+					continue
+				}
+				if hb.observe(ws.IsConnected(), now) {
+					PrintWarning(fmt.Sprintf("WebSocket down for more than %s, cancelling orders", d.ttl))
+					d.trip()
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// trip cancels every tracked order and closes Tripped
+func (d *deadMansSwitch) trip() {
+	d.tripOnce.Do(func() {
+		d.tripErr = d.cancelAll()
+		close(d.tripped)
+	})
+}
+
+// exit cancels every tracked order if --cancel-on-exit was set, returning
+// an error if any could not be cancelled
+func (d *deadMansSwitch) exit() error {
+	if !d.cancelOnExit {
+		return nil
+	}
+	return d.cancelAll()
+}
+
+// cancelAll cancels every tracked order. Each order that cannot be cancelled
+// is reported on stderr with its market and ID, since it may still be
+// resting, and the count of them is returned as an error. Orders that
+// already filled or were cancelled are not found, and only counted.
+func (d *deadMansSwitch) cancelAll() error {
+	d.mu.Lock()
+	orders := d.orders
+	d.orders = nil
+	d.mu.Unlock()
+
+	cancelled, gone, failed := 0, 0, 0
+	for _, o := range orders {
+		// The command context is usually already cancelled at this point
+		ctx, cancel := withTimeout(context.Background())
+		_, err := d.client.CancelOrder(ctx, o.OrderID)
+		cancel()
+		switch {
+		case err == nil:
+			cancelled++
+		case api.IsNotFound(err):
+			gone++
+		default:
+			failed++
+			fmt.Fprintf(os.Stderr, "Error: failed to cancel order %s on %s: %v\n", o.OrderID, o.Ticker, err)
+		}
+	}
+	if cancelled > 0 || gone > 0 {
+		PrintWarning(fmt.Sprintf("Cancelled %d resting orders; %d had already filled or been cancelled", cancelled, gone))
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to cancel %d of %d orders, which may still be resting", failed, len(orders))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestHeartbeatObserve(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	hb := &heartbeat{ttl: 30 * time.Second, lastSeen: t0}
+
+	if hb.observe(false, t0.Add(20*time.Second)) {
+		t.Error("expected no trip while within the ttl")
+	}
+	if hb.observe(true, t0.Add(25*time.Second)) {
+		t.Error("expected no trip while connected")
+	}
+	if hb.observe(false, t0.Add(50*time.Second)) {
+		t.Error("expected reconnect to reset the ttl")
+	}
+	if !hb.observe(false, t0.Add(56*time.Second)) {
+		t.Error("expected trip once down for longer than the ttl")
+	}
+}
+
+func TestDeadMansSwitch_ReportsFailedCancels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ok"):
+			w.Write([]byte(`{"order":{"order_id":"ok","status":"canceled"},"reduced_by":1}`))
+		case strings.HasSuffix(r.URL.Path, "/filled"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"not_found","message":"not found"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"bad_request","message":"rejected"}`))
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient(nil, nil)
+	client.SetBaseURL(server.URL)
+
+	d := newDeadMansSwitch(client, true, 0)
+	d.track(
+		models.Order{OrderID: "ok", Ticker: "KXA"},
+		models.Order{OrderID: "filled", Ticker: "KXA"},
+		models.Order{OrderID: "stuck", Ticker: "KXB"},
+	)
+	err := d.exit()
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("exit() = %v, want the one order left uncancelled", err)
+	}
+	if err := d.exit(); err != nil {
+		t.Errorf("second exit() = %v, want nothing left to cancel", err)
+	}
+}
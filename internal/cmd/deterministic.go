@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"sort"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+// deterministicTime is shown wherever output would include the current time
+// under --deterministic
+var deterministicTime = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// IsDeterministic reports whether --deterministic is set
+func IsDeterministic() bool {
+	return deterministic
+}
+
+// applyDeterministic makes output reproducible across runs and machines:
+// times are shown in UTC and styles render without color
+func applyDeterministic() {
+	time.Local = time.UTC
+	ui.DisableColor()
+}
+
+// outputNow returns the current time for display, which is
+// deterministicTime under --deterministic
+func outputNow() time.Time {
+	if deterministic {
+		return deterministicTime
+	}
+	return time.Now()
+}
+
+// sortIfDeterministic sorts a slice under --deterministic, so API lists whose
+// order can change between calls are always shown in the same order
+func sortIfDeterministic(x any, less func(i, j int) bool) {
+	if deterministic {
+		sort.SliceStable(x, less)
+	}
+}
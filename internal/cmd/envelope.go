@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+// listEnvelope is the --json output of a list command under --envelope, so
+// every list has the same shape and a script can resume from its cursor
+type listEnvelope struct {
+	Items     interface{} `json:"items"`
+	Cursor    string      `json:"cursor"`
+	Count     int         `json:"count"`
+	FetchedAt time.Time   `json:"fetched_at"`
+}
+
+// listJSON returns the --json output of a list command: data unchanged, or
+// items in a listEnvelope with the cursor of the next page under --envelope
+func listJSON(data, items interface{}, cursor string) interface{} {
+	if !envelope {
+		return data
+	}
+	v := reflect.ValueOf(items)
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		items = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+		v = reflect.ValueOf(items)
+	}
+	count := 0
+	if v.Kind() == reflect.Slice {
+		count = v.Len()
+	}
+	return listEnvelope{
+		Items:     items,
+		Cursor:    cursor,
+		Count:     count,
+		FetchedAt: outputNow().UTC(),
+	}
+}
+
+// emptyListWarning prints msg for a list with no items and reports whether
+// it did. Under --json --envelope nothing is printed, so scripts still get
+// an envelope for an empty page.
+func emptyListWarning(n int, msg string) bool {
+	if n > 0 || (envelope && GetOutputFormat() == ui.FormatJSON) {
+		return false
+	}
+	PrintWarning(msg)
+	return true
+}
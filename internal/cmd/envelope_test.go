@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestListJSON(t *testing.T) {
+	oldEnvelope, oldDeterministic := envelope, deterministic
+	defer func() { envelope, deterministic = oldEnvelope, oldDeterministic }()
+	deterministic = true
+
+	resp := &models.FillsResponse{Fills: []models.Fill{{TradeID: "t1"}, {TradeID: "t2"}}, Cursor: "next"}
+
+	envelope = false
+	if got := listJSON(resp, resp.Fills, resp.Cursor); got != resp {
+		t.Errorf("without --envelope the response should be unchanged, got %#v", got)
+	}
+
+	envelope = true
+	got, ok := listJSON(resp, resp.Fills, resp.Cursor).(listEnvelope)
+	if !ok {
+		t.Fatalf("listJSON = %T, want listEnvelope", got)
+	}
+	if got.Count != 2 || got.Cursor != "next" || !got.FetchedAt.Equal(deterministicTime) {
+		t.Errorf("envelope = %+v", got)
+	}
+}
+
+func TestListJSONEmpty(t *testing.T) {
+	old := envelope
+	defer func() { envelope = old }()
+	envelope = true
+
+	var fills []models.Fill
+	data, err := json.Marshal(listJSON(nil, fills, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if string(out["items"]) != "[]" || string(out["count"]) != "0" || string(out["cursor"]) != `""` {
+		t.Errorf("empty envelope = %s", data)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/seriescache"
 	"github.com/6missedcalls/kalshi-cli/internal/ui"
 	"github.com/6missedcalls/kalshi-cli/pkg/models"
 )
@@ -29,9 +30,14 @@ multiple price-bracket markets under it).`,
 var eventsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List events",
-	Long:  `List events with optional filtering by status.`,
+	Long: `List events with optional filtering by status, series and close time.
+
+--with-markets asks the API to include each event's markets and shows them
+under the event, so a series can be browsed without a markets call per event.`,
 	Example: `  kalshi-cli events list
   kalshi-cli events list --status active --limit 20
+  kalshi-cli events list --series INXD --with-markets
+  kalshi-cli events list --min-close now --max-close +7d
   kalshi-cli events list --json`,
 	RunE: runEventsList,
 }
@@ -48,18 +54,28 @@ Use 'kalshi-cli events list' to find event tickers.`,
 }
 
 var eventsCandlesticksCmd = &cobra.Command{
-	Use:   "candlesticks <event-ticker>",
+	Use:   "candlesticks [event-ticker]",
 	Short: "Get event candlesticks",
 	Long: `Get candlestick (OHLCV) data for an event.
 
 The --series flag is optional; if omitted, the series ticker is
-auto-resolved from the event. Requires --start and --end timestamps.
+auto-resolved from the event. Requires --start and --end, which accept
+RFC3339 or shorthand such as -7d, yesterday, last monday or now.
+
+Supported periods: 1m, 1h, 1d
 
-Supported periods: 1m, 1h, 1d`,
+Kalshi omits periods with no trades. --fill-gaps inserts a zero-volume candle
+for each missing period of each market, flat at the previous close.
+
+--events fetches several events concurrently and shows their candles
+together, each under its event and series. Series looked up from events are
+cached (see 'config paths'), so later runs skip the lookup.`,
 	Example: `  kalshi-cli events candlesticks INXD-25FEB07 --start 2025-02-01T00:00:00Z --end 2025-02-07T00:00:00Z
   kalshi-cli events candlesticks INXD-25FEB07 --period 1d --start 2025-01-01T00:00:00Z --end 2025-02-01T00:00:00Z
-  kalshi-cli events candlesticks INXD-25FEB07 --series INXD --period 1h --start 2025-02-06T00:00:00Z --end 2025-02-07T00:00:00Z`,
-	Args: cobra.ExactArgs(1),
+  kalshi-cli events candlesticks INXD-25FEB07 --series INXD --period 1h --start 2025-02-06T00:00:00Z --end 2025-02-07T00:00:00Z
+  kalshi-cli events candlesticks INXD-25FEB07 --start -2d --end now
+  kalshi-cli events candlesticks --events INXD-25FEB07,INXD-25FEB08 --period 1d --start -7d --end now --json`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runEventsCandlesticks,
 }
 
@@ -88,10 +104,16 @@ var (
 	eventsStatus          string
 	eventsLimit           int
 	eventsCursor          string
+	eventsSeries          string
+	eventsWithMarkets     bool
+	eventsMinClose        string
+	eventsMaxClose        string
 	eventSeriesTicker     string
 	candlesticksPeriod    string
 	candlesticksStartTime string
 	candlesticksEndTime   string
+	candlesticksFillGaps  bool
+	candlesticksEvents    []string
 	multivariateStatus    string
 	multivariateLimit     int
 	multivariateCursor    string
@@ -103,11 +125,17 @@ func init() {
 	eventsListCmd.Flags().StringVar(&eventsStatus, "status", "", "filter by status (active, closed, settled)")
 	eventsListCmd.Flags().IntVar(&eventsLimit, "limit", 50, "maximum number of events to return")
 	eventsListCmd.Flags().StringVar(&eventsCursor, "cursor", "", "pagination cursor")
+	eventsListCmd.Flags().StringVar(&eventsSeries, "series", "", "filter by series ticker")
+	eventsListCmd.Flags().BoolVar(&eventsWithMarkets, "with-markets", false, "include each event's markets")
+	eventsListCmd.Flags().StringVar(&eventsMinClose, "min-close", "", "only events with a market closing at or after this time: "+timeArgHelp)
+	eventsListCmd.Flags().StringVar(&eventsMaxClose, "max-close", "", "only events with a market closing at or before this time: "+timeArgHelp)
 
 	eventsCandlesticksCmd.Flags().StringVar(&eventSeriesTicker, "series", "", "series ticker (auto-resolved from event if not provided)")
 	eventsCandlesticksCmd.Flags().StringVar(&candlesticksPeriod, "period", "1h", "candlestick period (1m, 1h, 1d)")
-	eventsCandlesticksCmd.Flags().StringVar(&candlesticksStartTime, "start", "", "start time (RFC3339 format)")
-	eventsCandlesticksCmd.Flags().StringVar(&candlesticksEndTime, "end", "", "end time (RFC3339 format)")
+	eventsCandlesticksCmd.Flags().StringVar(&candlesticksStartTime, "start", "", "start time: "+timeArgHelp)
+	eventsCandlesticksCmd.Flags().StringVar(&candlesticksEndTime, "end", "", "end time: "+timeArgHelp)
+	eventsCandlesticksCmd.Flags().BoolVar(&candlesticksFillGaps, "fill-gaps", false, "insert zero-volume candles for periods with no trades, carrying the close forward")
+	eventsCandlesticksCmd.Flags().StringSliceVar(&candlesticksEvents, "events", nil, "fetch several events at once instead of one (comma-separated event tickers)")
 
 	multivariateListCmd.Flags().StringVar(&multivariateStatus, "status", "", "filter by status")
 	multivariateListCmd.Flags().IntVar(&multivariateLimit, "limit", 50, "maximum number of events to return")
@@ -123,15 +151,20 @@ func init() {
 }
 
 func runEventsList(cmd *cobra.Command, args []string) error {
-	client, err := createClient()
-	if err != nil {
+	params := api.ListEventsParams{
+		Status:            eventsStatus,
+		Limit:             eventsLimit,
+		Cursor:            eventsCursor,
+		SeriesTicker:      eventsSeries,
+		WithNestedMarkets: eventsWithMarkets,
+	}
+	if err := applyEventCloseFilters(&params, eventsMinClose, eventsMaxClose, time.Now()); err != nil {
 		return err
 	}
 
-	params := api.ListEventsParams{
-		Status: eventsStatus,
-		Limit:  eventsLimit,
-		Cursor: eventsCursor,
+	client, err := createClient()
+	if err != nil {
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -141,14 +174,15 @@ func runEventsList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to list events: %w", err)
 	}
+	sortIfDeterministic(events, func(i, j int) bool { return events[i].EventTicker < events[j].EventTicker })
 
 	outputFormat := GetOutputFormat()
 
 	return ui.Output(
 		outputFormat,
-		func() { renderEventsTable(events, cursor) },
-		createEventsResponse(events, cursor),
-		func() { renderEventsPlain(events) },
+		func() { renderEventsTable(events, cursor, eventsWithMarkets) },
+		listJSON(createEventsResponse(events, cursor), events, cursor),
+		func() { renderEventsPlain(events, eventsWithMarkets) },
 	)
 }
 
@@ -179,48 +213,49 @@ func runEventsGet(cmd *cobra.Command, args []string) error {
 }
 
 func runEventsCandlesticks(cmd *cobra.Command, args []string) error {
-	ticker := args[0]
+	tickers, err := candleEventTickers(args, candlesticksEvents)
+	if err != nil {
+		return err
+	}
 
-	client, err := createClient()
+	gapStep, err := candleGapStep(candlesticksFillGaps, candlesticksPeriod)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	start, end, err := timeRangeArgs(candlesticksStartTime, candlesticksEndTime, time.Now())
+	if err != nil {
+		return err
+	}
 
-	seriesTicker, err := resolveSeriesTicker(ctx, client, ticker, eventSeriesTicker)
+	client, err := createClient()
 	if err != nil {
 		return err
 	}
 
 	params := api.CandlesticksParams{
-		Ticker:       ticker,
-		SeriesTicker: seriesTicker,
-		Period:       candlesticksPeriod,
+		Period: candlesticksPeriod,
 	}
 
-	if candlesticksStartTime != "" {
-		t, parseErr := time.Parse(time.RFC3339, candlesticksStartTime)
-		if parseErr != nil {
-			return fmt.Errorf("invalid start time format: %w", parseErr)
-		}
-		params.StartTime = &t
+	if !start.IsZero() {
+		params.StartTime = &start
 	}
-
-	if candlesticksEndTime != "" {
-		t, parseErr := time.Parse(time.RFC3339, candlesticksEndTime)
-		if parseErr != nil {
-			return fmt.Errorf("invalid end time format: %w", parseErr)
-		}
-		params.EndTime = &t
+	if !end.IsZero() {
+		params.EndTime = &end
 	}
 
-	candlesticks, err := client.GetEventCandlesticks(ctx, params)
+	cache := loadSeriesCache()
+	defer saveSeriesCache(cache)
+
+	results, err := fetchEventCandles(context.Background(), client, cache, tickers, params, eventSeriesTicker, gapStep)
 	if err != nil {
-		return fmt.Errorf("failed to get candlesticks: %w", err)
+		return err
+	}
+	if len(args) == 0 {
+		return outputEventCandlesBatch(results)
 	}
 
+	candlesticks := results[0].Candlesticks
 	outputFormat := GetOutputFormat()
 
 	return ui.Output(
@@ -233,11 +268,17 @@ func runEventsCandlesticks(cmd *cobra.Command, args []string) error {
 
 // resolveSeriesTicker returns the series ticker for the candlesticks API call.
 // If explicitSeries is provided (via --series flag), it is returned directly.
-// Otherwise, the event is fetched to extract its SeriesTicker field.
-func resolveSeriesTicker(ctx context.Context, client *api.Client, ticker string, explicitSeries string) (string, error) {
+// Otherwise it is taken from cache, or the event is fetched to extract its
+// SeriesTicker field and the result is cached. cache may be nil.
+func resolveSeriesTicker(ctx context.Context, client *api.Client, cache *seriescache.Cache, ticker string, explicitSeries string) (string, error) {
 	if explicitSeries != "" {
 		return explicitSeries, nil
 	}
+	if cache != nil {
+		if series, ok := cache.Get(ticker); ok {
+			return series, nil
+		}
+	}
 
 	event, err := client.GetEvent(ctx, ticker)
 	if err != nil {
@@ -248,6 +289,9 @@ func resolveSeriesTicker(ctx context.Context, client *api.Client, ticker string,
 		return "", fmt.Errorf("event %s has no series ticker; please provide --series explicitly", ticker)
 	}
 
+	if cache != nil {
+		cache.Put(ticker, event.SeriesTicker)
+	}
 	return event.SeriesTicker, nil
 }
 
@@ -276,7 +320,7 @@ func runMultivariateList(cmd *cobra.Command, args []string) error {
 	return ui.Output(
 		outputFormat,
 		func() { renderMultivariateEventsTable(events, cursor) },
-		createMultivariateResponse(events, cursor),
+		listJSON(createMultivariateResponse(events, cursor), events, cursor),
 		func() { renderMultivariateEventsPlain(events) },
 	)
 }
@@ -309,7 +353,35 @@ func runMultivariateGet(cmd *cobra.Command, args []string) error {
 
 // Table Rendering
 
-func renderEventsTable(events []models.Event, cursor string) {
+// applyEventCloseFilters sets the close time bounds of params from
+// --min-close and --max-close
+func applyEventCloseFilters(params *api.ListEventsParams, minClose, maxClose string, now time.Time) error {
+	if minClose != "" {
+		t, err := parseTimeArg(minClose, now)
+		if err != nil {
+			return fmt.Errorf("invalid --min-close value: %w", err)
+		}
+		params.MinCloseTs = t.Unix()
+	}
+	if maxClose != "" {
+		t, err := parseTimeArg(maxClose, now)
+		if err != nil {
+			return fmt.Errorf("invalid --max-close value: %w", err)
+		}
+		params.MaxCloseTs = t.Unix()
+	}
+	if params.MinCloseTs > 0 && params.MaxCloseTs > 0 && params.MaxCloseTs < params.MinCloseTs {
+		return fmt.Errorf("--max-close must not be before --min-close")
+	}
+	return nil
+}
+
+func renderEventsTable(events []models.Event, cursor string, withMarkets bool) {
+	if withMarkets {
+		renderEventsWithMarkets(events, cursor)
+		return
+	}
+
 	headers := []string{"Ticker", "Title", "Category", "Markets"}
 	rows := make([][]string, 0, len(events))
 
@@ -325,7 +397,43 @@ func renderEventsTable(events []models.Event, cursor string) {
 	ui.RenderTable(headers, rows)
 
 	if cursor != "" {
-		fmt.Printf("\nMore results available. Use --cursor %s to continue.\n", cursor)
+		fmt.Fprintf(ui.Writer(), "\nMore results available. Use --cursor %s to continue.\n", cursor)
+	}
+}
+
+// renderEventsWithMarkets shows each event followed by a table of its markets
+func renderEventsWithMarkets(events []models.Event, cursor string) {
+	headers := []string{"Ticker", "Title", "Status", "Yes Bid", "Yes Ask", "Last", "Volume"}
+	for i, e := range events {
+		if i > 0 {
+			fmt.Fprintln(ui.Writer())
+		}
+		fmt.Fprintf(ui.Writer(), "%s  %s  (%d markets)\n", ui.BoldStyle.Render(e.EventTicker), e.Title, len(e.NestedMarkets))
+		if len(e.NestedMarkets) == 0 {
+			continue
+		}
+
+		rows := make([][]string, 0, len(e.NestedMarkets))
+		for _, m := range e.NestedMarkets {
+			title := m.Subtitle
+			if title == "" {
+				title = m.Title
+			}
+			rows = append(rows, []string{
+				m.Ticker,
+				truncateEventString(title, 40),
+				formatMarketStatus(m.Status),
+				formatCents(m.YesBid),
+				formatCents(m.YesAsk),
+				formatCents(m.LastPrice),
+				strconv.Itoa(m.Volume),
+			})
+		}
+		ui.RenderTable(headers, rows)
+	}
+
+	if cursor != "" {
+		fmt.Fprintf(ui.Writer(), "\nMore results available. Use --cursor %s to continue.\n", cursor)
 	}
 }
 
@@ -344,9 +452,9 @@ func renderEventDetails(event *models.Event) {
 	ui.RenderKeyValue(pairs)
 
 	if len(event.Markets) > 0 {
-		fmt.Println("\nMarkets:")
+		fmt.Fprintln(ui.Writer(), "\nMarkets:")
 		for _, m := range event.Markets {
-			fmt.Printf("  - %s\n", m)
+			fmt.Fprintf(ui.Writer(), "  - %s\n", m)
 		}
 	}
 }
@@ -403,7 +511,7 @@ func renderMultivariateEventsTable(events []models.MultivariateEvent, cursor str
 	ui.RenderTable(headers, rows)
 
 	if cursor != "" {
-		fmt.Printf("\nMore results available. Use --cursor %s to continue.\n", cursor)
+		fmt.Fprintf(ui.Writer(), "\nMore results available. Use --cursor %s to continue.\n", cursor)
 	}
 }
 
@@ -419,36 +527,45 @@ func renderMultivariateEventDetails(event *models.MultivariateEvent) {
 	ui.RenderKeyValue(pairs)
 
 	if len(event.LookupTable) > 0 {
-		fmt.Println("\nLookup Table:")
+		fmt.Fprintln(ui.Writer(), "\nLookup Table:")
 		for i, item := range event.LookupTable {
-			fmt.Printf("  %d. %s\n", i+1, item)
+			fmt.Fprintf(ui.Writer(), "  %d. %s\n", i+1, item)
 		}
 	}
 }
 
 // Plain Rendering
 
-func renderEventsPlain(events []models.Event) {
+// renderEventsPlain prints one line per event. With markets, each event is
+// followed by one tab-indented line per market.
+func renderEventsPlain(events []models.Event, withMarkets bool) {
 	for _, e := range events {
-		fmt.Printf("%s\t%s\t%s\t%d\n",
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%d\n",
 			e.EventTicker, e.Title, e.Category, len(e.Markets))
+		if !withMarkets {
+			continue
+		}
+		for _, m := range e.NestedMarkets {
+			fmt.Fprintf(ui.Writer(), "\t%s\t%s\t%s\t%s\t%s\t%d\n",
+				m.Ticker, m.Status, formatCents(m.YesBid), formatCents(m.YesAsk), formatCents(m.LastPrice), m.Volume)
+		}
 	}
 }
 
 func renderEventPlain(event *models.Event) {
-	fmt.Printf("ticker=%s\n", event.EventTicker)
-	fmt.Printf("series=%s\n", event.SeriesTicker)
-	fmt.Printf("title=%s\n", event.Title)
-	fmt.Printf("category=%s\n", event.Category)
-	fmt.Printf("markets_count=%d\n", len(event.Markets))
+	fmt.Fprintf(ui.Writer(), "ticker=%s\n", event.EventTicker)
+	fmt.Fprintf(ui.Writer(), "series=%s\n", event.SeriesTicker)
+	fmt.Fprintf(ui.Writer(), "title=%s\n", event.Title)
+	fmt.Fprintf(ui.Writer(), "category=%s\n", event.Category)
+	fmt.Fprintf(ui.Writer(), "markets_count=%d\n", len(event.Markets))
 	if len(event.Markets) > 0 {
-		fmt.Printf("markets=%s\n", strings.Join(event.Markets, ","))
+		fmt.Fprintf(ui.Writer(), "markets=%s\n", strings.Join(event.Markets, ","))
 	}
 }
 
 func renderCandlesticksPlain(candlesticks []models.Candlestick) {
 	for _, c := range candlesticks {
-		fmt.Printf("%s\t%d\t%d\t%d\t%d\t%d\t%d\n",
+		fmt.Fprintf(ui.Writer(), "%s\t%d\t%d\t%d\t%d\t%d\t%d\n",
 			c.PeriodEnd.Format(time.RFC3339),
 			c.Open, c.High, c.Low, c.Close, c.Volume, c.OpenInterest)
 	}
@@ -456,16 +573,16 @@ func renderCandlesticksPlain(candlesticks []models.Candlestick) {
 
 func renderMultivariateEventsPlain(events []models.MultivariateEvent) {
 	for _, e := range events {
-		fmt.Printf("%s\t%s\t%s\t%s\n", e.Ticker, e.Title, e.Status, e.LookupType)
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%s\n", e.Ticker, e.Title, e.Status, e.LookupType)
 	}
 }
 
 func renderMultivariateEventPlain(event *models.MultivariateEvent) {
-	fmt.Printf("ticker=%s\n", event.Ticker)
-	fmt.Printf("title=%s\n", event.Title)
-	fmt.Printf("description=%s\n", event.Description)
-	fmt.Printf("status=%s\n", event.Status)
-	fmt.Printf("lookup_type=%s\n", event.LookupType)
+	fmt.Fprintf(ui.Writer(), "ticker=%s\n", event.Ticker)
+	fmt.Fprintf(ui.Writer(), "title=%s\n", event.Title)
+	fmt.Fprintf(ui.Writer(), "description=%s\n", event.Description)
+	fmt.Fprintf(ui.Writer(), "status=%s\n", event.Status)
+	fmt.Fprintf(ui.Writer(), "lookup_type=%s\n", event.LookupType)
 }
 
 // Response Helpers
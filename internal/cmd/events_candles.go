@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/seriescache"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// candleBatchWorkers is how many events events candlesticks --events fetches
+// at once
+const candleBatchWorkers = 4
+
+// seriesCachePath returns the event to series cache location
+func seriesCachePath() (string, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return seriescache.DefaultPath(dir), nil
+}
+
+// loadSeriesCache returns the event to series cache, which is empty when it
+// cannot be read
+func loadSeriesCache() *seriescache.Cache {
+	path, err := seriesCachePath()
+	if err != nil {
+		return &seriescache.Cache{}
+	}
+	return seriescache.Load(path)
+}
+
+// saveSeriesCache writes series resolved by this command. Failures are only
+// shown with --verbose, since the cache never stops a command.
+func saveSeriesCache(cache *seriescache.Cache) {
+	path, err := seriesCachePath()
+	if err == nil {
+		err = seriescache.Save(path, cache)
+	}
+	if err != nil && IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// eventCandles is one event's candles in events candlesticks --events output
+type eventCandles struct {
+	EventTicker  string               `json:"event_ticker"`
+	SeriesTicker string               `json:"series_ticker"`
+	Candlesticks []models.Candlestick `json:"candlesticks"`
+}
+
+// candleEventTickers returns the events to fetch: the argument, or the
+// --events list without blanks and repeats
+func candleEventTickers(args, events []string) ([]string, error) {
+	if len(args) > 0 && len(events) > 0 {
+		return nil, fmt.Errorf("give an event ticker or --events, not both")
+	}
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	var tickers []string
+	seen := make(map[string]bool)
+	for _, t := range events {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		tickers = append(tickers, t)
+	}
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("give an event ticker or --events")
+	}
+	return tickers, nil
+}
+
+// fetchEventCandles resolves each event's series and fetches its candles,
+// up to candleBatchWorkers events at a time. Results are in the order of
+// tickers; if any event fails, the first failure in that order is returned.
+func fetchEventCandles(ctx context.Context, client *api.Client, cache *seriescache.Cache, tickers []string, params api.CandlesticksParams, explicitSeries string, gapStep time.Duration) ([]eventCandles, error) {
+	results := make([]eventCandles, len(tickers))
+	errs := make([]error, len(tickers))
+	sem := make(chan struct{}, candleBatchWorkers)
+	var wg sync.WaitGroup
+
+	for i, ticker := range tickers {
+		wg.Add(1)
+		go func(i int, ticker string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			reqCtx, cancel := withTimeout(ctx)
+			defer cancel()
+
+			seriesTicker, err := resolveSeriesTicker(reqCtx, client, cache, ticker, explicitSeries)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			p := params
+			p.Ticker = ticker
+			p.SeriesTicker = seriesTicker
+			candles, err := client.GetEventCandlesticks(reqCtx, p)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to get candlesticks for %s: %w", ticker, err)
+				return
+			}
+			if candles == nil {
+				candles = []models.Candlestick{}
+			}
+			results[i] = eventCandles{
+				EventTicker:  ticker,
+				SeriesTicker: seriesTicker,
+				Candlesticks: fillCandleGaps(candles, gapStep),
+			}
+		}(i, ticker)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func outputEventCandlesBatch(results []eventCandles) error {
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			for i, ev := range results {
+				if i > 0 {
+					fmt.Fprintln(ui.Writer())
+				}
+				fmt.Fprintln(ui.Writer(), ui.TitleStyle.Render(fmt.Sprintf("%s (%s)", ev.EventTicker, ev.SeriesTicker)))
+				if len(ev.Candlesticks) == 0 {
+					fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("No candlesticks in this range."))
+					continue
+				}
+				renderCandlesticksTable(ev.Candlesticks)
+			}
+		},
+		results,
+		func() {
+			for _, ev := range results {
+				for _, c := range ev.Candlesticks {
+					fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n",
+						ev.EventTicker, c.Ticker, c.PeriodEnd.Format(time.RFC3339),
+						c.Open, c.High, c.Low, c.Close, c.Volume, c.OpenInterest)
+				}
+			}
+		},
+	)
+}
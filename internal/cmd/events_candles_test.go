@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/seriescache"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestCandleEventTickers(t *testing.T) {
+	got, err := candleEventTickers(nil, []string{"INXD-25FEB07", " INXD-25FEB08", "", "INXD-25FEB07"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "INXD-25FEB07,INXD-25FEB08" {
+		t.Errorf("tickers = %v", got)
+	}
+	if _, err := candleEventTickers([]string{"INXD-25FEB07"}, []string{"INXD-25FEB08"}); err == nil {
+		t.Error("expected an error for an argument and --events together")
+	}
+	if _, err := candleEventTickers(nil, nil); err == nil {
+		t.Error("expected an error without any event")
+	}
+}
+
+func TestFetchEventCandles(t *testing.T) {
+	var eventLookups atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, api.TradeAPIPrefix)
+		switch {
+		case strings.HasPrefix(path, "/events/"):
+			eventLookups.Add(1)
+			ticker := strings.TrimPrefix(path, "/events/")
+			json.NewEncoder(w).Encode(models.EventResponse{
+				Event: models.Event{EventTicker: ticker, SeriesTicker: strings.SplitN(ticker, "-", 2)[0]},
+			})
+		case strings.HasSuffix(path, "/candlesticks"):
+			parts := strings.Split(path, "/")
+			event := parts[4]
+			fmt.Fprintf(w, `{"market_tickers":["%s-T1"],"market_candlesticks":[[{"end_period_ts":1738886400,"price":{"open":40,"high":45,"low":38,"close":44},"volume":10}]]}`, event)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newCmdTestClient(t, server.URL)
+	cache := &seriescache.Cache{}
+	cache.Put("KXFED-26MAR", "KXFED")
+	tickers := []string{"INXD-25FEB07", "KXFED-26MAR", "KXCPI-26MAR"}
+
+	results, err := fetchEventCandles(context.Background(), client, cache, tickers, api.CandlesticksParams{Period: "1d"}, "", 0)
+	if err != nil {
+		t.Fatalf("fetchEventCandles failed: %v", err)
+	}
+	if got := eventLookups.Load(); got != 2 {
+		t.Errorf("event lookups = %d, want 2 (KXFED-26MAR is cached)", got)
+	}
+	for i, ticker := range tickers {
+		r := results[i]
+		if r.EventTicker != ticker || r.SeriesTicker != strings.SplitN(ticker, "-", 2)[0] {
+			t.Errorf("results[%d] = %s/%s", i, r.EventTicker, r.SeriesTicker)
+		}
+		if len(r.Candlesticks) != 1 || r.Candlesticks[0].Ticker != ticker+"-T1" {
+			t.Errorf("results[%d] candles = %+v", i, r.Candlesticks)
+		}
+	}
+	if series, ok := cache.Get("KXCPI-26MAR"); !ok || series != "KXCPI" {
+		t.Errorf("resolved series should be cached, got %q", series)
+	}
+
+	eventLookups.Store(0)
+	if _, err := fetchEventCandles(context.Background(), client, cache, tickers, api.CandlesticksParams{Period: "1d"}, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := eventLookups.Load(); got != 0 {
+		t.Errorf("event lookups on the second run = %d, want 0", got)
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var eventsCheckCmd = &cobra.Command{
+	Use:   "check <event-ticker>",
+	Short: "Check a mutually exclusive event's brackets for consistency",
+	Long: `Check that the YES prices of a mutually exclusive event's markets are
+consistent. Exactly one bracket pays out, so:
+
+  - the YES bid/ask midpoints should sum to about 100¢ (the excess is the vig)
+  - the YES asks should sum to at least 100¢, and the YES bids to at most 100¢;
+    otherwise a complete basket is an arbitrage
+
+Brackets with no quotes, a crossed book (bid at or above ask), a spread wider
+than --max-spread, or a status other than open are flagged.
+
+The cheapest complete basket is also reported: buying YES on every bracket
+pays 100¢, buying NO on every bracket pays 100¢ × (brackets − 1).
+
+Exits non-zero if the event is inconsistent or any bracket is flagged.`,
+	Example: `  kalshi-cli events check INXD-25FEB07
+  kalshi-cli events check KXFED-26MAR --tolerance 3 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEventsCheck,
+}
+
+var (
+	eventCheckTolerance int
+	eventCheckMaxSpread int
+)
+
+func init() {
+	eventsCmd.AddCommand(eventsCheckCmd)
+
+	eventsCheckCmd.Flags().IntVar(&eventCheckTolerance, "tolerance", 5, "allowed distance of the midpoint sum from 100, in cents")
+	eventsCheckCmd.Flags().IntVar(&eventCheckMaxSpread, "max-spread", 10, "flag brackets whose yes spread exceeds this many cents")
+}
+
+// bracketCheck is one market of an event check
+type bracketCheck struct {
+	Ticker string   `json:"ticker"`
+	Title  string   `json:"title"`
+	Status string   `json:"status"`
+	YesBid int      `json:"yes_bid"`
+	YesAsk int      `json:"yes_ask"`
+	NoAsk  int      `json:"no_ask"`
+	Flags  []string `json:"flags,omitempty"`
+}
+
+// basket is the cost and payout of buying one side of every bracket
+type basket struct {
+	Side     string `json:"side"`
+	Complete bool   `json:"complete"`
+	Cost     int    `json:"cost"`
+	Payout   int    `json:"payout"`
+	Edge     int    `json:"edge"`
+}
+
+// eventCheck is the output of 'events check'
+type eventCheck struct {
+	EventTicker       string         `json:"event_ticker"`
+	MutuallyExclusive bool           `json:"mutually_exclusive"`
+	Brackets          []bracketCheck `json:"brackets"`
+	BidSum            int            `json:"bid_sum"`
+	AskSum            int            `json:"ask_sum"`
+	MidSum            float64        `json:"mid_sum"`
+	Vig               float64        `json:"vig"`
+	Issues            []string       `json:"issues"`
+	Cheapest          *basket        `json:"cheapest_basket,omitempty"`
+	Baskets           []basket       `json:"baskets"`
+}
+
+// Consistent reports whether no issues or bracket flags were found
+func (c eventCheck) Consistent() bool {
+	if len(c.Issues) > 0 {
+		return false
+	}
+	for _, b := range c.Brackets {
+		if len(b.Flags) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// checkEvent evaluates the markets of one event
+func checkEvent(event models.Event, markets []models.Market, tolerance, maxSpread int) eventCheck {
+	check := eventCheck{
+		EventTicker:       event.EventTicker,
+		MutuallyExclusive: event.MutuallyExclusive,
+		Issues:            []string{},
+	}
+
+	yes := basket{Side: "yes", Complete: true, Payout: 100}
+	no := basket{Side: "no", Complete: true, Payout: 100 * (len(markets) - 1)}
+	quoted := true
+
+	for _, m := range markets {
+		b := bracketCheck{Ticker: m.Ticker, Title: m.Subtitle, Status: m.Status, YesBid: m.YesBid, YesAsk: m.YesAsk, NoAsk: m.NoAsk}
+		if b.Title == "" {
+			b.Title = m.Title
+		}
+
+		switch {
+		case m.YesBid == 0 && m.YesAsk == 0:
+			b.Flags = append(b.Flags, "no quotes")
+		case m.YesBid > 0 && m.YesAsk > 0 && m.YesBid >= m.YesAsk:
+			b.Flags = append(b.Flags, "crossed")
+		case m.YesBid > 0 && m.YesAsk > 0 && m.YesAsk-m.YesBid > maxSpread:
+			b.Flags = append(b.Flags, fmt.Sprintf("wide spread (%d¢)", m.YesAsk-m.YesBid))
+		}
+		if !marketIsOpen(m.Status) {
+			b.Flags = append(b.Flags, "not open ("+m.Status+")")
+		}
+
+		check.BidSum += m.YesBid
+		check.AskSum += m.YesAsk
+		if m.YesBid > 0 && m.YesAsk > 0 {
+			check.MidSum += float64(m.YesBid+m.YesAsk) / 2
+		} else {
+			quoted = false
+		}
+
+		yes.Complete = yes.Complete && m.YesAsk > 0
+		yes.Cost += m.YesAsk
+		no.Complete = no.Complete && m.NoAsk > 0
+		no.Cost += m.NoAsk
+
+		check.Brackets = append(check.Brackets, b)
+	}
+
+	if !event.MutuallyExclusive {
+		check.Issues = append(check.Issues, "event is not mutually exclusive; bracket prices need not sum to 100")
+	}
+	if len(markets) < 2 {
+		check.Issues = append(check.Issues, "event has fewer than two markets")
+		return check
+	}
+
+	if quoted {
+		check.Vig = check.MidSum - 100
+		if check.Vig > float64(tolerance) || check.Vig < -float64(tolerance) {
+			check.Issues = append(check.Issues, fmt.Sprintf("midpoints sum to %.1f¢, more than %d¢ from 100", check.MidSum, tolerance))
+		}
+	} else {
+		check.Issues = append(check.Issues, "not every bracket has a two-sided quote; midpoint sum is incomplete")
+	}
+	if yes.Complete && check.AskSum < 100 {
+		check.Issues = append(check.Issues, fmt.Sprintf("YES asks sum to %d¢, below 100: buying every bracket is an arbitrage", check.AskSum))
+	}
+	if check.BidSum > 100 {
+		check.Issues = append(check.Issues, fmt.Sprintf("YES bids sum to %d¢, above 100: selling every bracket is an arbitrage", check.BidSum))
+	}
+
+	for _, b := range []basket{yes, no} {
+		b.Edge = b.Payout - b.Cost
+		check.Baskets = append(check.Baskets, b)
+		if b.Complete && (check.Cheapest == nil || b.Edge > check.Cheapest.Edge) {
+			cheapest := b
+			check.Cheapest = &cheapest
+		}
+	}
+
+	return check
+}
+
+func runEventsCheck(cmd *cobra.Command, args []string) error {
+	ticker := args[0]
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	event, err := client.GetEvent(ctx, ticker)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	result, err := client.ListMarkets(ctx, api.ListMarketsParams{EventTicker: ticker, Limit: 1000})
+	if err != nil {
+		return fmt.Errorf("failed to list event markets: %w", err)
+	}
+
+	check := checkEvent(*event, result.Markets, eventCheckTolerance, eventCheckMaxSpread)
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() { renderEventCheck(check) },
+		check,
+		func() { renderEventCheckPlain(check) },
+	); err != nil {
+		return err
+	}
+
+	if !check.Consistent() {
+		return fmt.Errorf("event %s failed consistency checks", ticker)
+	}
+	return nil
+}
+
+func renderEventCheck(c eventCheck) {
+	headers := []string{"Market", "Bracket", "Yes Bid", "Yes Ask", "No Ask", "Flags"}
+	rows := make([][]string, 0, len(c.Brackets))
+	for _, b := range c.Brackets {
+		flags := ui.SuccessStyle.Render("ok")
+		if len(b.Flags) > 0 {
+			flags = ui.WarningStyle.Render(strings.Join(b.Flags, ", "))
+		}
+		rows = append(rows, []string{
+			b.Ticker,
+			truncateMarketString(b.Title, 30),
+			fmt.Sprintf("%d¢", b.YesBid),
+			fmt.Sprintf("%d¢", b.YesAsk),
+			fmt.Sprintf("%d¢", b.NoAsk),
+			flags,
+		})
+	}
+	ui.RenderTable(headers, rows)
+
+	fmt.Fprintln(ui.Writer())
+	pairs := [][]string{
+		{"YES Bid Sum", fmt.Sprintf("%d¢", c.BidSum)},
+		{"YES Ask Sum", fmt.Sprintf("%d¢", c.AskSum)},
+		{"Midpoint Sum", fmt.Sprintf("%.1f¢ (vig %+.1f¢)", c.MidSum, c.Vig)},
+	}
+	if c.Cheapest != nil {
+		pairs = append(pairs, []string{"Cheapest Basket", fmt.Sprintf("buy %s on every bracket: cost %d¢, pays %d¢, edge %+d¢",
+			strings.ToUpper(c.Cheapest.Side), c.Cheapest.Cost, c.Cheapest.Payout, c.Cheapest.Edge)})
+	} else {
+		pairs = append(pairs, []string{"Cheapest Basket", "n/a (missing asks)"})
+	}
+	ui.RenderKeyValue(pairs)
+
+	fmt.Fprintln(ui.Writer())
+	if c.Consistent() {
+		PrintSuccess("Event is consistent")
+		return
+	}
+	for _, issue := range c.Issues {
+		PrintWarning(issue)
+	}
+}
+
+func renderEventCheckPlain(c eventCheck) {
+	for _, b := range c.Brackets {
+		ui.PrintPlain("%s\t%d\t%d\t%d\t%s", b.Ticker, b.YesBid, b.YesAsk, b.NoAsk, strings.Join(b.Flags, ","))
+	}
+	ui.PrintPlain("bid_sum=%d ask_sum=%d mid_sum=%.1f vig=%.1f consistent=%v", c.BidSum, c.AskSum, c.MidSum, c.Vig, c.Consistent())
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestCheckEventConsistent(t *testing.T) {
+	event := models.Event{EventTicker: "E", MutuallyExclusive: true}
+	markets := []models.Market{
+		{Ticker: "E-A", Status: "active", YesBid: 20, YesAsk: 22, NoAsk: 80},
+		{Ticker: "E-B", Status: "active", YesBid: 50, YesAsk: 53, NoAsk: 50},
+		{Ticker: "E-C", Status: "active", YesBid: 28, YesAsk: 30, NoAsk: 72},
+	}
+
+	c := checkEvent(event, markets, 5, 10)
+	if !c.Consistent() {
+		t.Fatalf("checkEvent() issues = %v, brackets = %+v", c.Issues, c.Brackets)
+	}
+	if c.BidSum != 98 || c.AskSum != 105 || c.MidSum != 101.5 || c.Vig != 1.5 {
+		t.Errorf("sums = bid %d ask %d mid %v vig %v", c.BidSum, c.AskSum, c.MidSum, c.Vig)
+	}
+	// YES basket: cost 105 for 100 (edge -5); NO basket: cost 202 for 200 (edge -2)
+	if c.Cheapest == nil || c.Cheapest.Side != "no" || c.Cheapest.Edge != -2 {
+		t.Errorf("cheapest = %+v, want no basket with edge -2", c.Cheapest)
+	}
+}
+
+func TestCheckEventIssues(t *testing.T) {
+	event := models.Event{EventTicker: "E", MutuallyExclusive: true}
+	markets := []models.Market{
+		{Ticker: "E-A", Status: "active", YesBid: 45, YesAsk: 40, NoAsk: 60},
+		{Ticker: "E-B", Status: "active", YesBid: 10, YesAsk: 40, NoAsk: 90},
+		{Ticker: "E-C", Status: "closed"},
+	}
+
+	c := checkEvent(event, markets, 5, 10)
+	if c.Consistent() {
+		t.Fatal("checkEvent() reported an inconsistent event as consistent")
+	}
+
+	want := map[string]string{"E-A": "crossed", "E-B": "wide spread", "E-C": "no quotes"}
+	for _, b := range c.Brackets {
+		if !strings.Contains(strings.Join(b.Flags, ","), want[b.Ticker]) {
+			t.Errorf("%s flags = %v, want %q", b.Ticker, b.Flags, want[b.Ticker])
+		}
+	}
+	if !strings.Contains(strings.Join(c.Brackets[2].Flags, ","), "not open") {
+		t.Errorf("closed bracket flags = %v, want not open", c.Brackets[2].Flags)
+	}
+	if c.Cheapest != nil {
+		t.Errorf("cheapest = %+v, want nil when a bracket has no asks", c.Cheapest)
+	}
+}
+
+func TestCheckEventArbitrage(t *testing.T) {
+	event := models.Event{EventTicker: "E", MutuallyExclusive: true}
+	markets := []models.Market{
+		{Ticker: "E-A", Status: "active", YesBid: 40, YesAsk: 42, NoAsk: 60},
+		{Ticker: "E-B", Status: "active", YesBid: 50, YesAsk: 52, NoAsk: 50},
+	}
+
+	c := checkEvent(event, markets, 10, 10)
+	if len(c.Issues) != 1 || !strings.Contains(c.Issues[0], "arbitrage") {
+		t.Errorf("issues = %v, want one arbitrage issue", c.Issues)
+	}
+	if c.Cheapest == nil || c.Cheapest.Side != "yes" || c.Cheapest.Edge != 6 {
+		t.Errorf("cheapest = %+v, want yes basket with edge 6", c.Cheapest)
+	}
+}
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var eventsHeatmapCmd = &cobra.Command{
+	Use:   "heatmap <event-ticker>",
+	Short: "Show an event's brackets colored by implied probability",
+	Long: `Show every market of an event as one row of a strip, ordered by strike,
+with its implied probability as a colored cell and a bar. The probability is
+the yes bid/ask midpoint, or the last price when the market is one-sided.
+
+This suits scalar events such as index closes, where the brackets together
+form a distribution. With --watch the strip follows the ticker feed and is
+redrawn in place every --refresh.`,
+	Example: `  kalshi-cli events heatmap INXD-25FEB07
+  kalshi-cli events heatmap KXBTC-26FEB12 --watch
+  kalshi-cli events heatmap INXD-25FEB07 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEventsHeatmap,
+}
+
+var (
+	heatmapWatch   bool
+	heatmapRefresh time.Duration
+	heatmapWidth   int
+)
+
+func init() {
+	eventsCmd.AddCommand(eventsHeatmapCmd)
+
+	eventsHeatmapCmd.Flags().BoolVar(&heatmapWatch, "watch", false, "follow live prices from the ticker channel")
+	eventsHeatmapCmd.Flags().DurationVar(&heatmapRefresh, "refresh", time.Second, "with --watch, how often to redraw")
+	eventsHeatmapCmd.Flags().IntVar(&heatmapWidth, "width", 40, "width of a 100% bar in characters")
+}
+
+// heatBracket is one market of an event heatmap. Probability is in percent.
+type heatBracket struct {
+	Ticker      string   `json:"ticker"`
+	Label       string   `json:"label"`
+	FloorStrike *float64 `json:"floor_strike,omitempty"`
+	CapStrike   *float64 `json:"cap_strike,omitempty"`
+	YesBid      int      `json:"yes_bid"`
+	YesAsk      int      `json:"yes_ask"`
+	Last        int      `json:"last"`
+	Probability float64  `json:"probability"`
+}
+
+// setQuote updates the prices and the implied probability
+func (b *heatBracket) setQuote(bid, ask, last int) {
+	b.YesBid, b.YesAsk = bid, ask
+	if last > 0 {
+		b.Last = last
+	}
+	b.Probability = impliedProbability(b.YesBid, b.YesAsk, b.Last)
+}
+
+// impliedProbability is the yes midpoint, or the last price when the book
+// is one-sided, in percent
+func impliedProbability(bid, ask, last int) float64 {
+	if bid > 0 && ask > 0 {
+		return float64(bid+ask) / 2
+	}
+	return float64(last)
+}
+
+// formatStrike renders a strike without trailing zeros
+func formatStrike(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// bracketLabel describes the range a market covers, from its strikes when
+// present and otherwise its subtitle
+func bracketLabel(m models.Market) string {
+	switch {
+	case m.FloorStrike != nil && m.CapStrike != nil:
+		return formatStrike(*m.FloorStrike) + " to " + formatStrike(*m.CapStrike)
+	case m.FloorStrike != nil:
+		return "above " + formatStrike(*m.FloorStrike)
+	case m.CapStrike != nil:
+		return "below " + formatStrike(*m.CapStrike)
+	case m.Subtitle != "":
+		return m.Subtitle
+	case m.Title != "":
+		return m.Title
+	}
+	return m.Ticker
+}
+
+// heatBrackets builds the brackets of an event ordered by strike. Brackets
+// open at the bottom come first and those without strikes last, by ticker.
+func heatBrackets(markets []models.Market) []heatBracket {
+	brackets := make([]heatBracket, 0, len(markets))
+	for _, m := range markets {
+		b := heatBracket{Ticker: m.Ticker, Label: bracketLabel(m), FloorStrike: m.FloorStrike, CapStrike: m.CapStrike}
+		b.setQuote(m.YesBid, m.YesAsk, m.LastPrice)
+		brackets = append(brackets, b)
+	}
+
+	key := func(b heatBracket) (bool, float64) {
+		switch {
+		case b.FloorStrike != nil:
+			return true, *b.FloorStrike
+		case b.CapStrike != nil:
+			return true, math.Inf(-1)
+		}
+		return false, 0
+	}
+	sort.SliceStable(brackets, func(i, j int) bool {
+		iok, ik := key(brackets[i])
+		jok, jk := key(brackets[j])
+		switch {
+		case iok != jok:
+			return iok
+		case ik != jk:
+			return ik < jk
+		}
+		return brackets[i].Ticker < brackets[j].Ticker
+	})
+	return brackets
+}
+
+// eventHeatmap holds the live brackets of an event
+type eventHeatmap struct {
+	mu       sync.Mutex
+	brackets []heatBracket
+	index    map[string]int
+	dirty    bool
+}
+
+func newEventHeatmap(brackets []heatBracket) *eventHeatmap {
+	h := &eventHeatmap{brackets: brackets, index: make(map[string]int), dirty: true}
+	for i, b := range brackets {
+		h.index[b.Ticker] = i
+	}
+	return h
+}
+
+// observe applies a ticker update and reports whether the market is a bracket
+func (h *eventHeatmap) observe(data websocket.TickerData) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i, ok := h.index[data.Ticker]
+	if !ok {
+		return false
+	}
+	h.brackets[i].setQuote(data.YesBid, data.YesAsk, data.YesPrice)
+	h.dirty = true
+	return true
+}
+
+// snapshot copies the brackets if anything changed since the last snapshot
+func (h *eventHeatmap) snapshot() ([]heatBracket, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.dirty {
+		return nil, false
+	}
+	h.dirty = false
+	return append([]heatBracket(nil), h.brackets...), true
+}
+
+// heatmapHandler feeds ticker messages into a heatmap
+type heatmapHandler struct {
+	heatmap *eventHeatmap
+}
+
+func (h *heatmapHandler) HandleMessage(msg websocket.Message) error {
+	var data websocket.TickerData
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return fmt.Errorf("failed to parse ticker data: %w", err)
+	}
+	h.heatmap.observe(data)
+	return nil
+}
+
+// renderHeatmap writes one row per bracket: its label, a cell colored by
+// probability and a bar scaled so 100% is width characters. Each line is
+// followed by eol, which clears the rest of the line when redrawing in place.
+func renderHeatmap(w io.Writer, title string, brackets []heatBracket, width int, eol string) {
+	labelWidth := 0
+	for _, b := range brackets {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(b.Label))
+	}
+
+	fmt.Fprint(w, ui.BoldStyle.Render(title)+eol+"\n")
+	total := 0.0
+	for _, b := range brackets {
+		total += b.Probability
+		bar := int(math.Round(b.Probability / 100 * float64(width)))
+		fmt.Fprintf(w, "%*s %s %s%s\n",
+			labelWidth, b.Label,
+			ui.HeatStyle(b.Probability).Render(fmt.Sprintf(" %5.1f%% ", b.Probability)),
+			lipgloss.NewStyle().Foreground(ui.HeatColor(b.Probability)).Render(strings.Repeat("█", bar)),
+			eol)
+	}
+	fmt.Fprint(w, ui.MutedStyle.Render(fmt.Sprintf("%*s %.1f%%", labelWidth, "total", total))+eol+"\n")
+}
+
+// printHeatmapPlain writes one tab-separated line per bracket
+func printHeatmapPlain(brackets []heatBracket, prefix string) {
+	for _, b := range brackets {
+		ui.PrintPlain("%s%s\t%s\t%.1f", prefix, b.Ticker, b.Label, b.Probability)
+	}
+}
+
+func runEventsHeatmap(cmd *cobra.Command, args []string) error {
+	if heatmapWidth < 1 {
+		return fmt.Errorf("--width must be at least 1")
+	}
+	if heatmapWatch && heatmapRefresh <= 0 {
+		return fmt.Errorf("--refresh must be positive")
+	}
+	ticker := strings.ToUpper(args[0])
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := withTimeout(context.Background())
+	defer cancel()
+
+	event, err := client.GetEvent(ctx, ticker)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+	result, err := client.ListMarkets(ctx, api.ListMarketsParams{EventTicker: ticker, Limit: candidateLimit})
+	if err != nil {
+		return fmt.Errorf("failed to list event markets: %w", err)
+	}
+	if len(result.Markets) == 0 {
+		return fmt.Errorf("event %s has no markets", ticker)
+	}
+
+	title := event.Title
+	if title == "" {
+		title = ticker
+	}
+	brackets := heatBrackets(result.Markets)
+
+	if !heatmapWatch {
+		return ui.Output(
+			GetOutputFormat(),
+			func() { renderHeatmap(ui.Writer(), title, brackets, heatmapWidth, "") },
+			brackets,
+			func() { printHeatmapPlain(brackets, "") },
+		)
+	}
+
+	heatmap := newEventHeatmap(brackets)
+	return runLiveView(&heatmapHandler{heatmap: heatmap}, heatmapRefresh, func(inPlace bool) {
+		brackets, changed := heatmap.snapshot()
+		if !changed {
+			return
+		}
+		switch {
+		case GetOutputFormat() == ui.FormatJSON:
+			printJSONLine(brackets)
+		case GetOutputFormat() == ui.FormatPlain:
+			printHeatmapPlain(brackets, formatTimestamp()+"\t")
+		case inPlace:
+			var b strings.Builder
+			b.WriteString(redrawHome)
+			renderHeatmap(&b, title, brackets, heatmapWidth, redrawEOL)
+			b.WriteString(redrawFooter())
+			fmt.Fprint(ui.Writer(), b.String())
+		default:
+			renderHeatmap(ui.Writer(), title, brackets, heatmapWidth, "")
+			fmt.Fprintln(ui.Writer())
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func strike(v float64) *float64 {
+	return &v
+}
+
+func TestHeatBrackets_OrderedByStrike(t *testing.T) {
+	markets := []models.Market{
+		{Ticker: "EV-B5550", FloorStrike: strike(5550), CapStrike: strike(5574.99), YesBid: 30, YesAsk: 34},
+		{Ticker: "EV-T5600", FloorStrike: strike(5600), LastPrice: 7},
+		{Ticker: "EV-X", Subtitle: "Other"},
+		{Ticker: "EV-T5500", CapStrike: strike(5500), YesBid: 2, YesAsk: 4},
+		{Ticker: "EV-B5575", FloorStrike: strike(5575), CapStrike: strike(5599.99), YesBid: 40, YesAsk: 44, LastPrice: 50},
+	}
+
+	brackets := heatBrackets(markets)
+	order := make([]string, len(brackets))
+	for i, b := range brackets {
+		order[i] = b.Ticker
+	}
+	if got, want := strings.Join(order, ","), "EV-T5500,EV-B5550,EV-B5575,EV-T5600,EV-X"; got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+
+	want := []struct {
+		label string
+		prob  float64
+	}{
+		{"below 5500", 3},
+		{"5550 to 5574.99", 32},
+		{"5575 to 5599.99", 42},
+		{"above 5600", 7},
+		{"Other", 0},
+	}
+	for i, w := range want {
+		if brackets[i].Label != w.label || brackets[i].Probability != w.prob {
+			t.Errorf("bracket %d = %q %.1f%%, want %q %.1f%%", i, brackets[i].Label, brackets[i].Probability, w.label, w.prob)
+		}
+	}
+}
+
+func TestEventHeatmap_Observe(t *testing.T) {
+	heatmap := newEventHeatmap(heatBrackets([]models.Market{{Ticker: "A", YesBid: 10, YesAsk: 12}}))
+	heatmap.snapshot()
+
+	if heatmap.observe(websocket.TickerData{Ticker: "B", YesBid: 50, YesAsk: 52}) {
+		t.Error("markets outside the event should be ignored")
+	}
+	if _, changed := heatmap.snapshot(); changed {
+		t.Error("an ignored update should not mark the heatmap changed")
+	}
+
+	heatmap.observe(websocket.TickerData{Ticker: "A", YesBid: 20, YesAsk: 0, YesPrice: 21})
+	brackets, changed := heatmap.snapshot()
+	if !changed || brackets[0].Probability != 21 {
+		t.Errorf("snapshot() = %+v, %v; want one-sided bracket at its last price 21", brackets, changed)
+	}
+}
+
+func TestRenderHeatmap(t *testing.T) {
+	brackets := []heatBracket{{Label: "below 5500", Probability: 25}, {Label: "above 5500", Probability: 75}}
+	var b strings.Builder
+	renderHeatmap(&b, "S&P close", brackets, 20, "")
+
+	out := b.String()
+	for _, want := range []string{"S&P close", " 25.0% ", strings.Repeat("█", 15), "100.0%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("renderHeatmap() missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -38,7 +38,7 @@ func TestResolveSeriesTicker_ExplicitSeriesUsedDirectly(t *testing.T) {
 	client := newCmdTestClient(t, server.URL)
 	ctx := context.Background()
 
-	series, err := resolveSeriesTicker(ctx, client, "KXELONMARS-99", "KXELONMARS")
+	series, err := resolveSeriesTicker(ctx, client, nil, "KXELONMARS-99", "KXELONMARS")
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -71,7 +71,7 @@ func TestResolveSeriesTicker_AutoResolvesFromEvent(t *testing.T) {
 	client := newCmdTestClient(t, server.URL)
 	ctx := context.Background()
 
-	series, err := resolveSeriesTicker(ctx, client, "KXELONMARS-99", "")
+	series, err := resolveSeriesTicker(ctx, client, nil, "KXELONMARS-99", "")
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -95,7 +95,7 @@ func TestResolveSeriesTicker_EventNotFound(t *testing.T) {
 	client := newCmdTestClient(t, server.URL)
 	ctx := context.Background()
 
-	_, err := resolveSeriesTicker(ctx, client, "NONEXISTENT-EVENT", "")
+	_, err := resolveSeriesTicker(ctx, client, nil, "NONEXISTENT-EVENT", "")
 
 	if err == nil {
 		t.Fatal("expected error when event is not found, got nil")
@@ -119,7 +119,7 @@ func TestResolveSeriesTicker_EventHasEmptySeriesTicker(t *testing.T) {
 	client := newCmdTestClient(t, server.URL)
 	ctx := context.Background()
 
-	_, err := resolveSeriesTicker(ctx, client, "BROKEN-EVENT", "")
+	_, err := resolveSeriesTicker(ctx, client, nil, "BROKEN-EVENT", "")
 
 	if err == nil {
 		t.Fatal("expected error when event has empty series ticker, got nil")
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+)
+
+func TestApplyEventCloseFilters(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	var params api.ListEventsParams
+	if err := applyEventCloseFilters(&params, "now", "+7d", now); err != nil {
+		t.Fatalf("applyEventCloseFilters() error: %v", err)
+	}
+	if params.MinCloseTs != now.Unix() || params.MaxCloseTs != now.Add(7*24*time.Hour).Unix() {
+		t.Errorf("close bounds = %d..%d", params.MinCloseTs, params.MaxCloseTs)
+	}
+
+	params = api.ListEventsParams{}
+	if err := applyEventCloseFilters(&params, "", "", now); err != nil || params.MinCloseTs != 0 || params.MaxCloseTs != 0 {
+		t.Errorf("expected no bounds, got %+v, %v", params, err)
+	}
+	if err := applyEventCloseFilters(&params, "+2d", "+1d", now); err == nil {
+		t.Error("expected --max-close before --min-close to be rejected")
+	}
+	if err := applyEventCloseFilters(&params, "soon", "", now); err == nil {
+		t.Error("expected an invalid --min-close to be rejected")
+	}
+}
@@ -93,9 +93,9 @@ func renderExchangeStatusPlain(status *api.ExchangeStatusResponse, cfg *config.C
 	tradingActive := boolToYesNo(status.TradingActive)
 	environment := cfg.Environment()
 
-	fmt.Printf("exchange_active=%s\n", exchangeActive)
-	fmt.Printf("trading_active=%s\n", tradingActive)
-	fmt.Printf("environment=%s\n", environment)
+	fmt.Fprintf(ui.Writer(), "exchange_active=%s\n", exchangeActive)
+	fmt.Fprintf(ui.Writer(), "trading_active=%s\n", tradingActive)
+	fmt.Fprintf(ui.Writer(), "environment=%s\n", environment)
 }
 
 func formatStatusBool(active bool) string {
@@ -145,9 +145,9 @@ func runExchangeSchedule(cmd *cobra.Command, args []string) error {
 
 func renderScheduleTable(schedule *models.ExchangeScheduleResponse) {
 	if len(schedule.Schedule.StandardHours) > 0 {
-		fmt.Println(ui.HeaderStyle.Render("Standard Hours"))
+		fmt.Fprintln(ui.Writer(), ui.HeaderStyle.Render("Standard Hours"))
 		for _, week := range schedule.Schedule.StandardHours {
-			fmt.Printf("  Period: %s to %s\n", week.StartTime, week.EndTime)
+			fmt.Fprintf(ui.Writer(), "  Period: %s to %s\n", week.StartTime, week.EndTime)
 			showDay("Monday", week.Monday)
 			showDay("Tuesday", week.Tuesday)
 			showDay("Wednesday", week.Wednesday)
@@ -159,14 +159,14 @@ func renderScheduleTable(schedule *models.ExchangeScheduleResponse) {
 	}
 
 	if len(schedule.Schedule.MaintenanceWindows) > 0 {
-		fmt.Println(ui.HeaderStyle.Render("Maintenance Windows"))
+		fmt.Fprintln(ui.Writer(), ui.HeaderStyle.Render("Maintenance Windows"))
 		for _, mw := range schedule.Schedule.MaintenanceWindows {
-			fmt.Printf("  %s to %s\n", mw.StartDatetime, mw.EndDatetime)
+			fmt.Fprintf(ui.Writer(), "  %s to %s\n", mw.StartDatetime, mw.EndDatetime)
 		}
 	}
 
 	if len(schedule.Schedule.StandardHours) == 0 && len(schedule.Schedule.MaintenanceWindows) == 0 {
-		fmt.Println(ui.MutedStyle.Render("No schedule entries found."))
+		fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("No schedule entries found."))
 	}
 }
 
@@ -175,14 +175,14 @@ func showDay(name string, slots []models.DailySchedule) {
 		return
 	}
 	for _, s := range slots {
-		fmt.Printf("    %s: %s - %s\n", name, s.OpenTime, s.CloseTime)
+		fmt.Fprintf(ui.Writer(), "    %s: %s - %s\n", name, s.OpenTime, s.CloseTime)
 	}
 }
 
 func renderSchedulePlain(schedule *models.ExchangeScheduleResponse) {
 	for i, week := range schedule.Schedule.StandardHours {
-		fmt.Printf("week_%d_start=%s\n", i, week.StartTime)
-		fmt.Printf("week_%d_end=%s\n", i, week.EndTime)
+		fmt.Fprintf(ui.Writer(), "week_%d_start=%s\n", i, week.StartTime)
+		fmt.Fprintf(ui.Writer(), "week_%d_end=%s\n", i, week.EndTime)
 		printDayPlain(i, "monday", week.Monday)
 		printDayPlain(i, "tuesday", week.Tuesday)
 		printDayPlain(i, "wednesday", week.Wednesday)
@@ -192,15 +192,15 @@ func renderSchedulePlain(schedule *models.ExchangeScheduleResponse) {
 		printDayPlain(i, "sunday", week.Sunday)
 	}
 	for i, mw := range schedule.Schedule.MaintenanceWindows {
-		fmt.Printf("maintenance_%d_start=%s\n", i, mw.StartDatetime)
-		fmt.Printf("maintenance_%d_end=%s\n", i, mw.EndDatetime)
+		fmt.Fprintf(ui.Writer(), "maintenance_%d_start=%s\n", i, mw.StartDatetime)
+		fmt.Fprintf(ui.Writer(), "maintenance_%d_end=%s\n", i, mw.EndDatetime)
 	}
 }
 
 func printDayPlain(weekIdx int, day string, slots []models.DailySchedule) {
 	for j, s := range slots {
-		fmt.Printf("week_%d_%s_%d_open=%s\n", weekIdx, day, j, s.OpenTime)
-		fmt.Printf("week_%d_%s_%d_close=%s\n", weekIdx, day, j, s.CloseTime)
+		fmt.Fprintf(ui.Writer(), "week_%d_%s_%d_open=%s\n", weekIdx, day, j, s.OpenTime)
+		fmt.Fprintf(ui.Writer(), "week_%d_%s_%d_close=%s\n", weekIdx, day, j, s.CloseTime)
 	}
 }
 
@@ -237,7 +237,7 @@ func runExchangeAnnouncements(cmd *cobra.Command, args []string) error {
 
 func renderAnnouncementsTable(announcements *models.AnnouncementsResponse) {
 	if len(announcements.Announcements) == 0 {
-		fmt.Println(ui.MutedStyle.Render("No announcements found."))
+		fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("No announcements found."))
 		return
 	}
 
@@ -260,11 +260,11 @@ func renderAnnouncementsTable(announcements *models.AnnouncementsResponse) {
 
 func renderAnnouncementsPlain(announcements *models.AnnouncementsResponse) {
 	for i, ann := range announcements.Announcements {
-		fmt.Printf("announcement_%d_id=%s\n", i, ann.ID)
-		fmt.Printf("announcement_%d_title=%s\n", i, ann.Title)
-		fmt.Printf("announcement_%d_type=%s\n", i, ann.Type)
-		fmt.Printf("announcement_%d_status=%s\n", i, ann.Status)
-		fmt.Printf("announcement_%d_delivery_time=%s\n", i, ann.DeliveryTime.Format(time.RFC3339))
+		fmt.Fprintf(ui.Writer(), "announcement_%d_id=%s\n", i, ann.ID)
+		fmt.Fprintf(ui.Writer(), "announcement_%d_title=%s\n", i, ann.Title)
+		fmt.Fprintf(ui.Writer(), "announcement_%d_type=%s\n", i, ann.Type)
+		fmt.Fprintf(ui.Writer(), "announcement_%d_status=%s\n", i, ann.Status)
+		fmt.Fprintf(ui.Writer(), "announcement_%d_delivery_time=%s\n", i, ann.DeliveryTime.Format(time.RFC3339))
 	}
 }
 
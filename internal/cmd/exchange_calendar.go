@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+	_ "time/tzdata" // the schedule is in US Eastern time, which must resolve everywhere
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// exchangeTimeZone is the zone of the daily open and close times in the
+// exchange schedule
+const exchangeTimeZone = "America/New_York"
+
+// calendarLookahead bounds the search for the next trading session
+const calendarLookahead = 8
+
+// exchangeWindow is a span of time, such as a maintenance window
+type exchangeWindow struct {
+	start, end time.Time
+}
+
+// exchangeCalendar answers whether the exchange is in known downtime: a
+// maintenance window or outside its standard trading hours. A nil calendar
+// is never down, so callers fail open when the schedule is unavailable.
+type exchangeCalendar struct {
+	loc         *time.Location
+	hours       []models.WeeklySchedule
+	maintenance []exchangeWindow
+}
+
+// newExchangeCalendar builds a calendar from the schedule endpoint's response.
+// Entries whose times do not parse are ignored.
+func newExchangeCalendar(s models.ExchangeSchedule) *exchangeCalendar {
+	loc, err := time.LoadLocation(exchangeTimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	c := &exchangeCalendar{loc: loc, hours: s.StandardHours}
+	for _, mw := range s.MaintenanceWindows {
+		start, err1 := time.Parse(time.RFC3339, mw.StartDatetime)
+		end, err2 := time.Parse(time.RFC3339, mw.EndDatetime)
+		if err1 != nil || err2 != nil || !end.After(start) {
+			continue
+		}
+		c.maintenance = append(c.maintenance, exchangeWindow{start: start, end: end})
+	}
+	return c
+}
+
+// loadExchangeCalendar fetches the exchange schedule. It returns nil, and
+// with --verbose says why, when the schedule cannot be fetched.
+func loadExchangeCalendar(ctx context.Context, client *api.Client) *exchangeCalendar {
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	resp, err := client.GetExchangeSchedule(reqCtx)
+	if err != nil {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Warning: exchange schedule unavailable, downtime will not be detected: %v\n", err)
+		}
+		return nil
+	}
+	return newExchangeCalendar(resp.Schedule)
+}
+
+// downtime reports whether now falls in known downtime, why, and when it
+// ends. An unknown end is the zero time.
+func (c *exchangeCalendar) downtime(now time.Time) (reason string, until time.Time, down bool) {
+	if c == nil {
+		return "", time.Time{}, false
+	}
+	for _, w := range c.maintenance {
+		if !now.Before(w.start) && now.Before(w.end) {
+			return "maintenance", w.end, true
+		}
+	}
+
+	day := midnight(now.In(c.loc))
+	if _, ok := c.sessionsOK(day); !ok {
+		// No standard hours cover today, so nothing is known about it
+		return "", time.Time{}, false
+	}
+
+	// Start a day early: yesterday's sessions can run past midnight
+	var next time.Time
+	for i := -1; i < calendarLookahead; i++ {
+		for _, s := range c.sessions(day.AddDate(0, 0, i)) {
+			if !now.Before(s.start) && now.Before(s.end) {
+				return "", time.Time{}, false
+			}
+			if s.start.After(now) && (next.IsZero() || s.start.Before(next)) {
+				next = s.start
+			}
+		}
+	}
+	return "outside trading hours", next, true
+}
+
+// sessions returns the trading sessions starting on day
+func (c *exchangeCalendar) sessions(day time.Time) []exchangeWindow {
+	sessions, _ := c.sessionsOK(day)
+	return sessions
+}
+
+// sessionsOK returns the trading sessions starting on day, a midnight in the
+// exchange zone, and whether any standard hours cover that day
+func (c *exchangeCalendar) sessionsOK(day time.Time) ([]exchangeWindow, bool) {
+	for _, week := range c.hours {
+		if !weekCovers(week, day) {
+			continue
+		}
+		var sessions []exchangeWindow
+		for _, d := range weekdaySchedule(week, day.Weekday()) {
+			open, err1 := time.Parse("15:04", d.OpenTime)
+			closing, err2 := time.Parse("15:04", d.CloseTime)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			start := day.Add(time.Duration(open.Hour())*time.Hour + time.Duration(open.Minute())*time.Minute)
+			end := day.Add(time.Duration(closing.Hour())*time.Hour + time.Duration(closing.Minute())*time.Minute)
+			if d.CloseTime == "23:59" {
+				// Written for a session running to midnight
+				end = day.AddDate(0, 0, 1)
+			} else if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+			sessions = append(sessions, exchangeWindow{start: start, end: end})
+		}
+		return sessions, true
+	}
+	return nil, false
+}
+
+// weekCovers reports whether a standard hours block is in effect on day. A
+// block without dates is always in effect.
+func weekCovers(week models.WeeklySchedule, day time.Time) bool {
+	if start, err := time.Parse(time.RFC3339, week.StartTime); err == nil && day.AddDate(0, 0, 1).Before(start) {
+		return false
+	}
+	if end, err := time.Parse(time.RFC3339, week.EndTime); err == nil && !day.Before(end) {
+		return false
+	}
+	return true
+}
+
+func weekdaySchedule(week models.WeeklySchedule, d time.Weekday) []models.DailySchedule {
+	switch d {
+	case time.Monday:
+		return week.Monday
+	case time.Tuesday:
+		return week.Tuesday
+	case time.Wednesday:
+		return week.Wednesday
+	case time.Thursday:
+		return week.Thursday
+	case time.Friday:
+		return week.Friday
+	case time.Saturday:
+		return week.Saturday
+	}
+	return week.Sunday
+}
+
+// describeDowntime renders a downtime reason with its end, for notes on stderr
+func describeDowntime(reason string, until time.Time) string {
+	if until.IsZero() {
+		return "exchange " + reason
+	}
+	return fmt.Sprintf("exchange %s until %s", reason, until.Local().Format("2006-01-02 15:04 MST"))
+}
+
+// pause reports whether work should stop at now for known downtime, noting
+// on stderr when a pause starts and ends. paused carries the state between
+// calls.
+func (c *exchangeCalendar) pause(now time.Time, paused *bool, what string) bool {
+	reason, until, down := c.downtime(now)
+	switch {
+	case down && !*paused:
+		fmt.Fprintf(os.Stderr, "Pausing %s: %s\n", what, describeDowntime(reason, until))
+	case !down && *paused:
+		fmt.Fprintf(os.Stderr, "Resuming %s\n", what)
+	}
+	*paused = down
+	return down
+}
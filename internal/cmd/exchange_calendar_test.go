@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestExchangeCalendarDowntime(t *testing.T) {
+	weekday := []models.DailySchedule{{OpenTime: "08:00", CloseTime: "03:00"}}
+	cal := newExchangeCalendar(models.ExchangeSchedule{
+		StandardHours: []models.WeeklySchedule{{
+			StartTime: "2026-01-01T00:00:00Z",
+			EndTime:   "2027-01-01T00:00:00Z",
+			Monday:    weekday, Tuesday: weekday, Wednesday: weekday, Thursday: weekday, Friday: weekday,
+			Saturday: []models.DailySchedule{{OpenTime: "00:00", CloseTime: "23:59"}},
+		}},
+		MaintenanceWindows: []models.MaintenanceWindow{
+			{StartDatetime: "2026-03-05T08:00:00Z", EndDatetime: "2026-03-05T10:00:00Z"},
+			{StartDatetime: "bad", EndDatetime: "2026-03-05T10:00:00Z"},
+		},
+	})
+	et := cal.loc
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantReason string
+		wantUntil  time.Time
+	}{
+		{"in session", time.Date(2026, 3, 4, 12, 0, 0, 0, et), "", time.Time{}},
+		{"overnight from the previous day", time.Date(2026, 3, 5, 1, 0, 0, 0, et), "", time.Time{}},
+		{"maintenance", time.Date(2026, 3, 5, 3, 30, 0, 0, et), "maintenance", time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)},
+		{"between sessions", time.Date(2026, 3, 5, 6, 0, 0, 0, et), "outside trading hours", time.Date(2026, 3, 5, 8, 0, 0, 0, et)},
+		{"saturday runs to midnight", time.Date(2026, 3, 7, 23, 59, 30, 0, et), "", time.Time{}},
+		{"sunday closed until monday", time.Date(2026, 3, 8, 12, 0, 0, 0, et), "outside trading hours", time.Date(2026, 3, 9, 8, 0, 0, 0, et)},
+		{"no hours cover the day", time.Date(2027, 3, 8, 12, 0, 0, 0, et), "", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, until, down := cal.downtime(tt.now)
+			if down != (tt.wantReason != "") || reason != tt.wantReason || !until.Equal(tt.wantUntil) {
+				t.Errorf("downtime() = %q, %v, %v; want %q until %v", reason, until, down, tt.wantReason, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func TestExchangeCalendarNilNeverDown(t *testing.T) {
+	var cal *exchangeCalendar
+	if _, _, down := cal.downtime(time.Now()); down {
+		t.Error("nil calendar should never be down")
+	}
+	paused := false
+	if cal.pause(time.Now(), &paused, "work") || paused {
+		t.Error("nil calendar should never pause")
+	}
+}
+
+func TestMarketSchedulerHoldsHookInDowntime(t *testing.T) {
+	rec := &hookRecorder{}
+	s := newMarketScheduler("MKT", "echo open", "", 0, rec.run)
+	defer s.stop()
+
+	now := time.Now().UTC()
+	s.calendar = newExchangeCalendar(models.ExchangeSchedule{
+		MaintenanceWindows: []models.MaintenanceWindow{{
+			StartDatetime: now.Add(-time.Minute).Format(time.RFC3339),
+			EndDatetime:   now.Add(time.Second).Format(time.RFC3339),
+		}},
+	})
+
+	s.observeStatus("open")
+	if got := rec.get(); len(got) != 0 {
+		t.Fatalf("hooks during maintenance = %v, want none yet", got)
+	}
+	waitDone(t, s)
+	if got := rec.get(); len(got) != 1 || got[0] != hookOpen {
+		t.Errorf("hooks = %v, expected [open] after maintenance", got)
+	}
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var fillsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export every fill in a date range to CSV or JSONL",
+	Long: `Write every fill in a date range to a file, oldest first, following every
+page of results. Rows have one shape for both formats so the file can go
+straight into tax and P&L tooling:
+
+  trade_id, order_id, time (RFC3339, UTC), unix_ts, ticker, side, action,
+  type, role (taker or maker), count, price, cost, fee
+
+price is what was paid per contract on the side bought or sold, cost is
+count x price and fee is the exchange fee, all in dollars with four decimal
+places. A --to given as a bare date includes that whole day. Use --file - to
+write to stdout.`,
+	Example: `  kalshi-cli portfolio fills export --from 2024-01-01 --to 2024-12-31 --file fills-2024.csv
+  kalshi-cli portfolio fills export --from 2024-01-01 --to 2024-12-31 --format jsonl --file fills-2024.jsonl
+  kalshi-cli portfolio fills export --from "last monday" --market KXBTC-26FEB12-B97000 --file -`,
+	Args: cobra.NoArgs,
+	RunE: runFillsExport,
+}
+
+var (
+	fillsExportFrom   string
+	fillsExportTo     string
+	fillsExportFormat string
+	fillsExportFile   string
+	fillsExportMarket string
+)
+
+// Fill export formats
+const (
+	fillsFormatCSV   = "csv"
+	fillsFormatJSONL = "jsonl"
+)
+
+// fillsExportHeader is the CSV header, matching fillExportRow's JSON names
+var fillsExportHeader = []string{"trade_id", "order_id", "time", "unix_ts", "ticker", "side", "action", "type", "role", "count", "price", "cost", "fee"}
+
+func init() {
+	fillsCmd.AddCommand(fillsExportCmd)
+
+	fillsExportCmd.Flags().StringVar(&fillsExportFrom, "from", "", "only fills at or after this time: "+timeArgHelp)
+	fillsExportCmd.Flags().StringVar(&fillsExportTo, "to", "", "only fills before this time; a bare date includes the whole day")
+	fillsExportCmd.Flags().StringVar(&fillsExportFormat, "format", fillsFormatCSV, "file format: csv or jsonl")
+	fillsExportCmd.Flags().StringVar(&fillsExportFile, "file", "", "file to write, or - for stdout (required)")
+	fillsExportCmd.Flags().StringVar(&fillsExportMarket, "market", "", "only fills in this market")
+	addConcurrencyFlag(fillsExportCmd)
+	fillsExportCmd.MarkFlagRequired("file")
+}
+
+// fillExportRow is one fill normalized for export
+type fillExportRow struct {
+	TradeID string          `json:"trade_id"`
+	OrderID string          `json:"order_id"`
+	Time    string          `json:"time"`
+	UnixTS  int64           `json:"unix_ts"`
+	Ticker  string          `json:"ticker"`
+	Side    string          `json:"side"`
+	Action  string          `json:"action"`
+	Type    string          `json:"type"`
+	Role    string          `json:"role"`
+	Count   int             `json:"count"`
+	Price   models.SubCents `json:"price"`
+	Cost    models.SubCents `json:"cost"`
+	Fee     models.SubCents `json:"fee"`
+}
+
+// newFillExportRow normalizes a fill: the time in UTC, the price of the side
+// traded and amounts in exact dollars
+func newFillExportRow(f models.Fill) fillExportRow {
+	price := f.YesPrice
+	if f.Side == string(models.OrderSideNo) {
+		price = f.NoPrice
+	}
+	role := "maker"
+	if f.IsTaker {
+		role = "taker"
+	}
+	perContract := models.SubCents(price) * 100
+	return fillExportRow{
+		TradeID: f.TradeID,
+		OrderID: f.OrderID,
+		Time:    f.CreatedTime.UTC().Format(time.RFC3339),
+		UnixTS:  f.CreatedTime.Unix(),
+		Ticker:  f.Ticker,
+		Side:    f.Side,
+		Action:  f.Action,
+		Type:    f.Type,
+		Role:    role,
+		Count:   f.Count,
+		Price:   perContract,
+		Cost:    perContract * models.SubCents(f.Count),
+		Fee:     f.Fee(),
+	}
+}
+
+// fillsExportRange parses --from and --to. A --to that is a bare date is
+// moved to the end of that day so the day is included.
+func fillsExportRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+	if from != "" {
+		if start, err = parseTimeArg(from, now); err != nil {
+			return start, end, fmt.Errorf("invalid --from value: %w", err)
+		}
+	}
+	if to != "" {
+		if end, err = parseTimeArg(to, now); err != nil {
+			return start, end, fmt.Errorf("invalid --to value: %w", err)
+		}
+		if _, err := time.ParseInLocation("2006-01-02", to, now.Location()); err == nil {
+			end = end.AddDate(0, 0, 1)
+		}
+	}
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		return start, end, fmt.Errorf("--to (%s) must be after --from (%s)", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	return start, end, nil
+}
+
+// exportFillRows sorts fills oldest first and keeps those in [start, end);
+// zero bounds are open
+func exportFillRows(fills []models.Fill, start, end time.Time) []fillExportRow {
+	sorted := append([]models.Fill(nil), fills...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedTime.Before(sorted[j].CreatedTime)
+	})
+	rows := make([]fillExportRow, 0, len(sorted))
+	for _, f := range sorted {
+		if !start.IsZero() && f.CreatedTime.Before(start) || !end.IsZero() && !f.CreatedTime.Before(end) {
+			continue
+		}
+		rows = append(rows, newFillExportRow(f))
+	}
+	return rows
+}
+
+// writeFillRows writes rows to w as CSV with a header, or as JSON lines
+func writeFillRows(w io.Writer, format string, rows []fillExportRow) error {
+	bw := bufio.NewWriter(w)
+	if format == fillsFormatJSONL {
+		enc := json.NewEncoder(bw)
+		for _, r := range rows {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	}
+
+	cw := csv.NewWriter(bw)
+	cw.Write(fillsExportHeader)
+	for _, r := range rows {
+		cw.Write([]string{
+			r.TradeID, r.OrderID, r.Time, strconv.FormatInt(r.UnixTS, 10),
+			r.Ticker, r.Side, r.Action, r.Type, r.Role, strconv.Itoa(r.Count),
+			r.Price.FixedPoint(), r.Cost.FixedPoint(), r.Fee.FixedPoint(),
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func runFillsExport(cmd *cobra.Command, args []string) error {
+	if fillsExportFormat != fillsFormatCSV && fillsExportFormat != fillsFormatJSONL {
+		return fmt.Errorf("invalid --format %q: use %s or %s", fillsExportFormat, fillsFormatCSV, fillsFormatJSONL)
+	}
+	start, end, err := fillsExportRange(fillsExportFrom, fillsExportTo, time.Now())
+	if err != nil {
+		return err
+	}
+	ticker := ""
+	if fillsExportMarket != "" {
+		if ticker, err = normalizeTicker(fillsExportMarket); err != nil {
+			return err
+		}
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	opts := api.FillsOptions{Ticker: ticker}
+	if !start.IsZero() {
+		opts.MinTS = start.Unix()
+	}
+	if !end.IsZero() {
+		// max_ts is inclusive and end is not
+		opts.MaxTS = end.Unix() - 1
+	}
+	fills, err := allFills(context.Background(), client, opts)
+	if err != nil {
+		return fmt.Errorf("failed to get fills: %w", err)
+	}
+	rows := exportFillRows(fills, start, end)
+
+	if fillsExportFile == "-" {
+		return writeFillRows(ui.Writer(), fillsExportFormat, rows)
+	}
+	f, err := os.Create(fillsExportFile)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", fillsExportFile, err)
+	}
+	defer f.Close()
+	if err := writeFillRows(f, fillsExportFormat, rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fillsExportFile, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fillsExportFile, err)
+	}
+
+	summary := map[string]any{"file": fillsExportFile, "format": fillsExportFormat, "fills": len(rows)}
+	return ui.Output(
+		GetOutputFormat(),
+		func() { PrintSuccess(fmt.Sprintf("Exported %d fills to %s", len(rows), fillsExportFile)) },
+		summary,
+		func() { fmt.Fprintf(ui.Writer(), "%s\t%d\n", fillsExportFile, len(rows)) },
+	)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestFillsExportRange(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	start, end, err := fillsExportRange("2024-01-01", "2024-12-31", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !start.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("range = %v to %v, want all of 2024", start, end)
+	}
+	if _, end, _ := fillsExportRange("", "2024-12-31T18:00:00Z", now); end.Hour() != 18 {
+		t.Errorf("end = %v, want a full time left as is", end)
+	}
+	if _, _, err := fillsExportRange("2024-12-31", "2024-01-01", now); err == nil || !strings.Contains(err.Error(), "--to") {
+		t.Errorf("err = %v, want --to before --from rejected", err)
+	}
+}
+
+func TestWriteFillRows(t *testing.T) {
+	est := time.FixedZone("EST", -5*3600)
+	fills := []models.Fill{
+		{TradeID: "t2", Ticker: "KXA", Side: "no", Action: "sell", Type: "limit", NoPrice: 40, YesPrice: 60, Count: 3, FeeCost: "0.0175", CreatedTime: time.Date(2024, 3, 2, 9, 0, 0, 0, est)},
+		{TradeID: "t1", Ticker: "KXA", Side: "yes", Action: "buy", Type: "market", YesPrice: 55, Count: 10, IsTaker: true, CreatedTime: time.Date(2024, 3, 1, 9, 0, 0, 0, est)},
+		{TradeID: "t0", CreatedTime: time.Date(2023, 12, 31, 9, 0, 0, 0, est)},
+	}
+	rows := exportFillRows(fills, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+
+	var buf bytes.Buffer
+	if err := writeFillRows(&buf, fillsFormatCSV, rows); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		strings.Join(fillsExportHeader, ","),
+		"t1,,2024-03-01T14:00:00Z,1709301600,KXA,yes,buy,market,taker,10,0.5500,5.5000,0.0000",
+		"t2,,2024-03-02T14:00:00Z,1709388000,KXA,no,sell,limit,maker,3,0.4000,1.2000,0.0175",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := writeFillRows(&buf, fillsFormatJSONL, rows[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"time":"2024-03-01T14:00:00Z"`) || !strings.Contains(buf.String(), `"cost":5.50,`) {
+		t.Errorf("jsonl = %s", buf.String())
+	}
+}
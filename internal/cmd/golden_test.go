@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/golden"
+	"github.com/6missedcalls/kalshi-cli/internal/notes"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// assertGoldenOutput renders with --deterministic in the given format and
+// compares the output with testdata/golden/<name>.golden. Run
+// go test ./internal/cmd -update to rewrite the golden files.
+func assertGoldenOutput(t *testing.T, name string, format ui.OutputFormat, render func() error) {
+	t.Helper()
+	oldDeterministic, oldFormat, oldLocal := deterministic, outputFmt, time.Local
+	defer func() { deterministic, outputFmt, time.Local = oldDeterministic, oldFormat, oldLocal }()
+	deterministic, outputFmt = true, format
+	applyDeterministic()
+
+	var err error
+	out := golden.Capture(t, func() { err = render() })
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	golden.Assert(t, name, out)
+}
+
+// goldenFormats are the output formats every golden renderer is checked in
+var goldenFormats = map[string]ui.OutputFormat{
+	"table": ui.FormatTable,
+	"json":  ui.FormatJSON,
+	"plain": ui.FormatPlain,
+}
+
+func goldenMarkets() []models.Market {
+	return []models.Market{
+		{Ticker: "KXCPI-26MAR-T0.3", Title: "CPI above 0.3% in March?", Status: "open", YesBid: 41, YesAsk: 44, Volume: 1520},
+		{Ticker: "KXBTC-26FEB12-B97000", Title: "Bitcoin price between $97,000 and $97,499.99 on Feb 12, 2026 at 5pm EST?", Status: "closed", YesBid: 3, YesAsk: 5, Volume: 88},
+	}
+}
+
+func TestGoldenMarketsList(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("KALSHI_CONFIG_DIR", dir)
+	path, err := notesPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	book := &notes.Book{}
+	book.AddNote("KXCPI-26MAR-T0.3", "watch the core print", time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC))
+	book.AddTags("KXCPI-26MAR-T0.3", "cpi")
+	if err := notes.Save(path, book); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, format := range goldenFormats {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenOutput(t, "markets_list_"+name, format, func() error {
+				return outputMarketsList(goldenMarkets(), "")
+			})
+		})
+	}
+
+	t.Run("envelope", func(t *testing.T) {
+		old := envelope
+		defer func() { envelope = old }()
+		envelope = true
+		assertGoldenOutput(t, "markets_list_envelope", ui.FormatJSON, func() error {
+			return outputMarketsList(goldenMarkets(), "CgwI4K2")
+		})
+	})
+}
+
+func TestGoldenMarketsNote(t *testing.T) {
+	e := noteEntry{Ticker: "KXCPI-26MAR-T0.3"}
+	e.Notes = []notes.Note{
+		{Text: "watch the core print", Added: time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)},
+		{Text: "consensus moved to 0.2", Added: time.Date(2026, 3, 9, 9, 5, 0, 0, time.UTC)},
+	}
+	e.Tags = []string{"cpi", "macro"}
+
+	for name, format := range goldenFormats {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenOutput(t, "markets_note_"+name, format, func() error {
+				return outputNoteEntry(e)
+			})
+		})
+	}
+}
+
+func TestGoldenPositions(t *testing.T) {
+	positions := []models.MarketPosition{
+		{Ticker: "KXCPI-26MAR-T0.3", Position: 10, TotalTraded: 420, MarketExposure: 420, RealizedPnl: 35},
+		{Ticker: "KXBTC-26FEB12-B97000", Position: -4, TotalTraded: 20, MarketExposure: 380, RealizedPnl: -12},
+	}
+	assertGoldenOutput(t, "positions_table", ui.FormatTable, func() error {
+		renderPositionsTable(positions)
+		return nil
+	})
+}
+
+func TestOutputNowDeterministic(t *testing.T) {
+	old := deterministic
+	defer func() { deterministic = old }()
+
+	deterministic = true
+	if !outputNow().Equal(deterministicTime) {
+		t.Errorf("outputNow = %v, want %v", outputNow(), deterministicTime)
+	}
+	deterministic = false
+	if outputNow().Equal(deterministicTime) {
+		t.Error("outputNow should be the current time without --deterministic")
+	}
+}
+
+func TestSortIfDeterministic(t *testing.T) {
+	old := deterministic
+	defer func() { deterministic = old }()
+
+	tickers := []string{"B", "A"}
+	less := func(i, j int) bool { return tickers[i] < tickers[j] }
+	deterministic = false
+	sortIfDeterministic(tickers, less)
+	if tickers[0] != "B" {
+		t.Error("order should be kept without --deterministic")
+	}
+	deterministic = true
+	sortIfDeterministic(tickers, less)
+	if tickers[0] != "A" {
+		t.Error("order should be sorted under --deterministic")
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/daemon"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck [name]",
+	Short: "Exit 0 if a background job is healthy, 1 if not",
+	Long: `Check a job started with 'daemon start', or any command run with
+KALSHI_DAEMON=<name> in its environment, from the health it reports. Nothing
+is sent to Kalshi, so the check is quick enough for Docker HEALTHCHECK and
+Kubernetes probes.
+
+A job reports every few seconds when it last had a successful REST response
+or WebSocket frame, and whether its last word from Kalshi was a 401. It is
+healthy while its process is alive, its credentials have not been rejected,
+and it has had a success within --max-age, or started within --max-age.
+
+The name defaults to $KALSHI_DAEMON.`,
+	Example: `  kalshi-cli healthcheck fills
+  KALSHI_DAEMON=fills kalshi-cli healthcheck --max-age 30s
+
+  # Dockerfile
+  ENV KALSHI_DAEMON=fills
+  HEALTHCHECK --interval=30s CMD ["kalshi-cli", "healthcheck"]
+  CMD ["kalshi-cli", "watch", "fills", "--persist"]`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHealthcheck,
+}
+
+var healthcheckMaxAge time.Duration
+
+// healthReportInterval is how often a job writes its health
+const healthReportInterval = 5 * time.Second
+
+func init() {
+	rootCmd.AddCommand(healthcheckCmd)
+	healthcheckCmd.Flags().DurationVar(&healthcheckMaxAge, "max-age", 2*time.Minute, "longest time without a successful API call before the job is unhealthy")
+}
+
+// healthResult is the output of healthcheck
+type healthResult struct {
+	Name    string         `json:"name"`
+	Healthy bool           `json:"healthy"`
+	Reason  string         `json:"reason"`
+	Health  *daemon.Health `json:"health,omitempty"`
+}
+
+// evaluateHealth decides whether a job's report is healthy at now
+func evaluateHealth(h daemon.Health, now time.Time, maxAge time.Duration, alive func(int) bool) (bool, string) {
+	switch {
+	case h.PID != 0 && !alive(h.PID):
+		return false, fmt.Sprintf("process %d is not running", h.PID)
+	case h.AuthFailed():
+		return false, fmt.Sprintf("credentials rejected (401) at %s", h.LastUnauthorized.Local().Format(time.RFC3339))
+	case !h.LastSuccess.IsZero() && now.Sub(h.LastSuccess) <= maxAge:
+		return true, fmt.Sprintf("last API success %s ago", now.Sub(h.LastSuccess).Round(time.Second))
+	case !h.LastSuccess.IsZero():
+		return false, fmt.Sprintf("no API success for %s", now.Sub(h.LastSuccess).Round(time.Second))
+	case now.Sub(h.Started) <= maxAge:
+		return true, "starting"
+	}
+	return false, fmt.Sprintf("no API success since starting %s ago", now.Sub(h.Started).Round(time.Second))
+}
+
+func runHealthcheck(cmd *cobra.Command, args []string) error {
+	name := os.Getenv("KALSHI_DAEMON")
+	if len(args) == 1 {
+		name = args[0]
+	}
+	if name == "" {
+		return fmt.Errorf("give a daemon name or set KALSHI_DAEMON")
+	}
+	if err := daemon.ValidName(name); err != nil {
+		return err
+	}
+	if healthcheckMaxAge <= 0 {
+		return fmt.Errorf("--max-age must be positive")
+	}
+
+	m, err := daemonManager()
+	if err != nil {
+		return err
+	}
+	result := healthResult{Name: name}
+	h, err := m.LoadHealth(name)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		result.Reason = "no health reported yet"
+	case err != nil:
+		return err
+	default:
+		result.Health = h
+		result.Healthy, result.Reason = evaluateHealth(*h, time.Now(), healthcheckMaxAge, daemon.Alive)
+	}
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() {
+			if result.Healthy {
+				PrintSuccess(fmt.Sprintf("%s is healthy: %s", name, result.Reason))
+			}
+		},
+		result,
+		func() {
+			if result.Healthy {
+				fmt.Fprintf(ui.Writer(), "healthy\t%s\n", result.Reason)
+			}
+		},
+	); err != nil {
+		return err
+	}
+	if !result.Healthy {
+		return fmt.Errorf("%s is unhealthy: %s", name, result.Reason)
+	}
+	return nil
+}
+
+// healthReporter writes the health of a process running as a daemon job
+type healthReporter struct {
+	manager *daemon.Manager
+	name    string
+	started time.Time
+
+	mu         sync.Mutex
+	websockets []*websocket.Client
+}
+
+// reporter is set when this process reports its health
+var reporter *healthReporter
+
+// startHealthReporter starts reporting health when KALSHI_DAEMON names a job.
+// healthcheck and daemon commands never report, nor does a process while
+// another live one is reporting for the same job, e.g. a one-off command run
+// in the job's container.
+func startHealthReporter(cmd *cobra.Command) {
+	name := os.Getenv("KALSHI_DAEMON")
+	if name == "" || reporter != nil || cmd == healthcheckCmd || cmd.Parent() == daemonCmd || daemon.ValidName(name) != nil {
+		return
+	}
+	m, err := daemonManager()
+	if err != nil {
+		return
+	}
+	if h, err := m.LoadHealth(name); err == nil && h.PID != os.Getpid() && h.PID != 0 && daemon.Alive(h.PID) {
+		return
+	}
+
+	reporter = &healthReporter{manager: m, name: name, started: time.Now()}
+	reporter.report()
+	go func() {
+		t := time.NewTicker(healthReportInterval)
+		defer t.Stop()
+		for range t.C {
+			reporter.report()
+		}
+	}()
+}
+
+// report writes the current health. Failures are only shown with --verbose,
+// since reporting must never stop the job.
+func (r *healthReporter) report() {
+	h := daemon.Health{
+		PID:              os.Getpid(),
+		Started:          r.started,
+		Updated:          time.Now(),
+		LastSuccess:      sessionMetrics.LastSuccess(),
+		LastUnauthorized: sessionMetrics.LastUnauthorized(),
+	}
+	r.mu.Lock()
+	for _, ws := range r.websockets {
+		if t := ws.LastMessage(); t.After(h.LastSuccess) {
+			h.LastSuccess = t
+		}
+	}
+	r.mu.Unlock()
+	if err := r.manager.SaveHealth(r.name, h); err != nil && IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Warning: failed to report health: %v\n", err)
+	}
+}
+
+// newWebSocketClient creates a WebSocket client whose frames count as API
+// successes in the health report
+func newWebSocketClient(opts websocket.ClientOptions) *websocket.Client {
+	client := websocket.NewClient(opts)
+	if r := reporter; r != nil {
+		r.mu.Lock()
+		r.websockets = append(r.websockets, client)
+		r.mu.Unlock()
+	}
+	return client
+}
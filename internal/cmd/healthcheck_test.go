@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/daemon"
+)
+
+func TestEvaluateHealth(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	alive := func(pid int) bool { return pid == 1 }
+	tests := []struct {
+		name    string
+		h       daemon.Health
+		healthy bool
+		reason  string
+	}{
+		{"recent success", daemon.Health{PID: 1, Started: now.Add(-time.Hour), LastSuccess: now.Add(-10 * time.Second)}, true, "10s ago"},
+		{"stale", daemon.Health{PID: 1, Started: now.Add(-time.Hour), LastSuccess: now.Add(-5 * time.Minute)}, false, "no API success for 5m0s"},
+		{"dead", daemon.Health{PID: 2, LastSuccess: now}, false, "not running"},
+		{"rejected", daemon.Health{PID: 1, LastSuccess: now.Add(-time.Minute), LastUnauthorized: now.Add(-time.Second)}, false, "401"},
+		{"recovered", daemon.Health{PID: 1, LastSuccess: now.Add(-time.Second), LastUnauthorized: now.Add(-time.Minute)}, true, "1s ago"},
+		{"starting", daemon.Health{PID: 1, Started: now.Add(-30 * time.Second)}, true, "starting"},
+		{"never succeeded", daemon.Health{PID: 1, Started: now.Add(-10 * time.Minute)}, false, "since starting"},
+	}
+	for _, tt := range tests {
+		healthy, reason := evaluateHealth(tt.h, now, 2*time.Minute, alive)
+		if healthy != tt.healthy || !strings.Contains(reason, tt.reason) {
+			t.Errorf("%s: evaluateHealth() = %v, %q; want %v, %q", tt.name, healthy, reason, tt.healthy, tt.reason)
+		}
+	}
+}
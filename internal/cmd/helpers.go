@@ -1,73 +1,84 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/6missedcalls/kalshi-cli/internal/api"
-	"github.com/6missedcalls/kalshi-cli/internal/config"
-	"github.com/spf13/viper"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
 )
 
 // Common helper functions shared across commands
 
-// createClient creates an API client using stored credentials.
-// It tries config file credentials first (api_key_id + private_key_path),
-// then environment variables, then the keyring as a last resort.
-// Config/env is checked first because keyring can hang in headless environments.
+// createClient creates an API client using stored credentials, found by
+// walking the credential chain in resolveSigner. Without credentials it
+// returns an unsigned client: market data is public, so commands reading it
+// still work, and endpoints that need credentials fail with the reason there
+// are none when they are called.
 func createClient() (*api.Client, error) {
-	// Try config file first (fast, no GUI prompts)
-	apiKeyID := viper.GetString("api_key_id")
-	privateKeyPath := viper.GetString("private_key_path")
-
-	// Also check env vars
-	if apiKeyID == "" {
-		apiKeyID = os.Getenv("KALSHI_API_KEY_ID")
-	}
-	if privateKeyPath == "" {
-		privateKeyPath = os.Getenv("KALSHI_PRIVATE_KEY_FILE")
-	}
-
-	if apiKeyID != "" && privateKeyPath != "" {
-		pemData, err := os.ReadFile(privateKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read private key file %s: %w", privateKeyPath, err)
+	signer, _, err := resolveSigner()
+	if err != nil {
+		client := newAPIClient(nil)
+		// Paper orders and portfolio stay local, so paper trading needs no
+		// credentials at all
+		if !paperMode {
+			client.SetCredentialError(err)
 		}
+		return client, nil
+	}
+	return newAPIClient(signer), nil
+}
 
-		signer, err := api.NewSignerFromPEM(apiKeyID, string(pemData))
-		if err != nil {
-			return nil, fmt.Errorf("failed to create signer from key file: %w", err)
-		}
+// sessionMetrics counts HTTP activity across every client created during this invocation
+var sessionMetrics = &api.Metrics{}
 
-		return api.NewClient(cfg, signer), nil
-	}
+var (
+	sessionLimiterOnce sync.Once
+	sessionLimiter     *api.RateLimiter
+)
 
-	// Also support KALSHI_PRIVATE_KEY env var (PEM content directly)
-	privateKeyPEM := os.Getenv("KALSHI_PRIVATE_KEY")
-	if apiKeyID != "" && privateKeyPEM != "" {
-		signer, err := api.NewSignerFromPEM(apiKeyID, privateKeyPEM)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create signer from env var: %w", err)
+// rateLimiter returns the token bucket shared by every client in this
+// invocation, or nil when api.rate_limit is 0
+func rateLimiter() *api.RateLimiter {
+	sessionLimiterOnce.Do(func() {
+		if cfg.API.RateLimit > 0 {
+			sessionLimiter = api.NewRateLimiter(float64(cfg.API.RateLimit), cfg.API.RateLimit)
 		}
+	})
+	return sessionLimiter
+}
 
-		return api.NewClient(cfg, signer), nil
+// newAPIClient builds an API client wired to the session-wide metrics collector
+func newAPIClient(signer *api.Signer) *api.Client {
+	client := api.NewClient(cfg, signer)
+	client.SetMetrics(sessionMetrics)
+	client.SetRateLimiter(rateLimiter())
+	retry := api.DefaultRetryPolicy()
+	retry.MaxRetries = cfg.API.MaxRetries
+	client.SetRetryPolicy(retry)
+	// Paper writes never reach the exchange, so read-only mode has nothing to guard
+	client.SetReadOnly(cfg.API.ReadOnly && !paperMode)
+	client.SetStrictDecode(strictDecode)
+	if httpTransport != nil {
+		client.SetTransport(httpTransport)
 	}
-
-	// Last resort: try keyring (may hang in headless environments)
-	keyring, err := config.NewKeyringStore()
-	if err == nil {
-		creds, err := keyring.GetCredentials()
-		if err == nil && creds != nil {
-			signer, err := api.NewSignerFromPEM(creds.APIKeyID, creds.PrivateKey)
-			if err == nil {
-				return api.NewClient(cfg, signer), nil
-			}
-		}
+	if paperMode {
+		usePaper(client)
 	}
-
-	return nil, fmt.Errorf("not logged in. Set api_key_id + private_key_path in ~/.kalshi/config.yaml, or run 'kalshi-cli auth login'")
+	if cfg.Audit.Enabled && !paperMode {
+		client.SetAudit(auditHook(signer))
+	}
+	if IsVerbose() {
+		client.SetRequestLog(os.Stderr)
+	}
+	return client
 }
 
 // formatTimeStr formats a time.Time for display
@@ -117,19 +128,36 @@ func formatSideStr(side string) string {
 
 // formatCents formats cents as dollars
 func formatCents(cents int) string {
-	return fmt.Sprintf("$%.2f", float64(cents)/100)
+	return models.Cents(cents).String()
 }
 
-// confirmAction prompts for confirmation unless --yes flag is set
-func confirmAction(action string) bool {
+// errNoInput is returned when a command needs to prompt but --no-input is set
+var errNoInput = errors.New("input required but --no-input is set")
+
+// confirmAction asks a yes/no question unless --yes is set.
+// With --no-input it fails instead of blocking on stdin.
+func confirmAction(prompt string) (bool, error) {
 	if yesFlag {
-		return true
+		return true, nil
+	}
+	if err := requireInput(prompt); err != nil {
+		return false, fmt.Errorf("%w (pass --yes to confirm)", err)
 	}
 
-	fmt.Printf("Are you sure you want to %s? [y/N]: ", action)
-	var response string
-	fmt.Scanln(&response)
-	return response == "y" || response == "Y" || response == "yes" || response == "Yes"
+	fmt.Fprintf(ui.Terminal(), "%s [y/N]: ", envPrompt(prompt))
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes", nil
+}
+
+// requireInput returns an error naming what would have been prompted for
+// when --no-input is set, so callers can fail before reading stdin
+func requireInput(what string) error {
+	if noInput {
+		return fmt.Errorf("%w: %s", errNoInput, what)
+	}
+	return nil
 }
 
 // withTimeout returns a context with the configured timeout
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfirmActionNoInput(t *testing.T) {
+	defer func(yes, no bool) { yesFlag, noInput = yes, no }(yesFlag, noInput)
+
+	yesFlag, noInput = false, true
+	confirmed, err := confirmAction("Cancel ALL resting orders?")
+	if !errors.Is(err, errNoInput) {
+		t.Fatalf("expected errNoInput, got %v", err)
+	}
+	if confirmed {
+		t.Error("expected no confirmation without --yes")
+	}
+
+	yesFlag = true
+	confirmed, err = confirmAction("Cancel ALL resting orders?")
+	if err != nil || !confirmed {
+		t.Errorf("--yes should confirm even with --no-input, got %v, %v", confirmed, err)
+	}
+}
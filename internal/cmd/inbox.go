@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "Show announcements, RFQ and quote activity, and order group updates in one feed",
+	Long: `Show a single chronological feed of what needs your attention:
+
+  announcement  exchange announcements
+  quote         quotes on your RFQs, and your quotes being accepted,
+                confirmed or executed
+  rfq           your RFQs being closed or cancelled
+  order_group   your order groups and their fill counts
+
+RFQs and quotes are matched to you by your communications ID. With --watch,
+the feed keeps running and adds communications and order group updates from
+the WebSocket as they arrive.`,
+	Example: `  kalshi-cli inbox
+  kalshi-cli inbox --since 1d --kind quote,order_group
+  kalshi-cli inbox --watch`,
+	RunE: runInbox,
+}
+
+var (
+	inboxSince string
+	inboxKinds []string
+	inboxLimit int
+	inboxWatch bool
+)
+
+// Inbox item kinds
+const (
+	inboxAnnouncement = "announcement"
+	inboxQuote        = "quote"
+	inboxRFQ          = "rfq"
+	inboxOrderGroup   = "order_group"
+)
+
+// inboxPageSize is how many RFQs, quotes and order groups are read per source
+const inboxPageSize = 200
+
+func init() {
+	rootCmd.AddCommand(inboxCmd)
+
+	inboxCmd.Flags().StringVar(&inboxSince, "since", "7d", "only items at or after this time: "+timeArgHelp)
+	inboxCmd.Flags().StringSliceVar(&inboxKinds, "kind", nil, "only these kinds, comma-separated: announcement, quote, rfq, order_group")
+	inboxCmd.Flags().IntVar(&inboxLimit, "limit", 50, "show at most this many of the latest items (0 for all)")
+	inboxCmd.Flags().BoolVar(&inboxWatch, "watch", false, "keep streaming communications and order group updates")
+}
+
+// inboxItem is one entry in the feed
+type inboxItem struct {
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"`
+	ID     string    `json:"id,omitempty"`
+	Ticker string    `json:"ticker,omitempty"`
+	Text   string    `json:"text"`
+}
+
+// parseAPITime reads an RFC3339 timestamp from an API string field, zero if
+// it is empty or malformed
+func parseAPITime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func announcementItems(announcements []models.Announcement) []inboxItem {
+	items := make([]inboxItem, 0, len(announcements))
+	for _, a := range announcements {
+		at := a.DeliveryTime
+		if at.IsZero() {
+			at = a.CreatedTime
+		}
+		text := a.Title
+		if a.Message != "" {
+			text += ": " + a.Message
+		}
+		items = append(items, inboxItem{Time: at, Kind: inboxAnnouncement, ID: a.ID, Text: text})
+	}
+	return items
+}
+
+// quoteItems picks the quotes addressed to me: quotes on my RFQs, and my own
+// quotes once the RFQ creator acts on them
+func quoteItems(quotes []models.Quote, me string) []inboxItem {
+	var items []inboxItem
+	for _, q := range quotes {
+		bids := fmt.Sprintf("%d contracts, YES %s / NO %s", q.Contracts, formatCents(q.YesBid), formatCents(q.NoBid))
+		var text string
+		switch {
+		case q.RFQCreatorID == me:
+			text = fmt.Sprintf("Quote on your RFQ %s (%s): %s", truncateID(q.RFQID, 8), q.Status, bids)
+		case q.CreatorID == me && q.Status != "open":
+			text = fmt.Sprintf("Your quote %s: %s", truncateID(q.ID, 8), q.Status)
+			if q.Status == "accepted" {
+				text += fmt.Sprintf(" on %s, confirm with 'quotes confirm %s'", strings.ToUpper(q.AcceptedSide), q.ID)
+			}
+			if q.CancellationReason != "" {
+				text += " (" + q.CancellationReason + ")"
+			}
+		default:
+			continue
+		}
+		at := parseAPITime(q.UpdatedTs)
+		if at.IsZero() {
+			at = parseAPITime(q.CreatedTs)
+		}
+		items = append(items, inboxItem{Time: at, Kind: inboxQuote, ID: q.ID, Ticker: q.MarketTicker, Text: text})
+	}
+	return items
+}
+
+// rfqItems reports my RFQs that are no longer open
+func rfqItems(rfqs []models.RFQ, me string) []inboxItem {
+	var items []inboxItem
+	for _, r := range rfqs {
+		if r.CreatorID != me || r.Status == "open" {
+			continue
+		}
+		text := fmt.Sprintf("Your RFQ for %d contracts: %s", r.Contracts, r.Status)
+		if r.CancellationReason != "" {
+			text += " (" + r.CancellationReason + ")"
+		}
+		at := parseAPITime(r.CancelledTs)
+		if at.IsZero() {
+			at = parseAPITime(r.UpdatedTs)
+		}
+		items = append(items, inboxItem{Time: at, Kind: inboxRFQ, ID: r.ID, Ticker: r.MarketTicker, Text: text})
+	}
+	return items
+}
+
+func orderGroupItems(groups []models.OrderGroup) []inboxItem {
+	items := make([]inboxItem, 0, len(groups))
+	for _, g := range groups {
+		at := g.LastUpdateTime
+		if at.IsZero() {
+			at = g.CreatedTime
+		}
+		items = append(items, inboxItem{
+			Time: at,
+			Kind: inboxOrderGroup,
+			ID:   g.GroupID,
+			Text: fmt.Sprintf("Order group %s: %s (%d/%d filled)", truncateID(g.GroupID, 8), g.Status, g.FilledCount, g.Limit),
+		})
+	}
+	return items
+}
+
+// buildInbox filters items to kinds (all when empty) at or after since, sorts
+// them oldest first and keeps the latest limit (all when 0)
+func buildInbox(items []inboxItem, kinds []string, since time.Time, limit int) []inboxItem {
+	want := map[string]bool{}
+	for _, k := range kinds {
+		want[k] = true
+	}
+	var feed []inboxItem
+	for _, it := range items {
+		if len(want) > 0 && !want[it.Kind] {
+			continue
+		}
+		if !it.Time.IsZero() && it.Time.Before(since) {
+			continue
+		}
+		feed = append(feed, it)
+	}
+	sort.SliceStable(feed, func(i, j int) bool { return feed[i].Time.Before(feed[j].Time) })
+	if limit > 0 && len(feed) > limit {
+		feed = feed[len(feed)-limit:]
+	}
+	return feed
+}
+
+// fetchInbox reads every source; RFQs and quotes are matched to the
+// communications ID
+func fetchInbox(ctx context.Context, client *api.Client) ([]inboxItem, error) {
+	var (
+		id            *models.CommunicationsIDResponse
+		announcements *models.AnnouncementsResponse
+		quotes        *models.QuotesResponse
+		rfqs          *models.RFQsResponse
+		groups        *models.OrderGroupsResponse
+	)
+	requests := []struct {
+		what string
+		call func(context.Context) error
+	}{
+		{"communications ID", func(c context.Context) (err error) { id, err = client.GetCommunicationsID(c); return }},
+		{"announcements", func(c context.Context) (err error) { announcements, err = client.GetAnnouncements(c); return }},
+		{"quotes", func(c context.Context) (err error) {
+			quotes, err = client.GetQuotes(c, api.QuotesOptions{Limit: inboxPageSize})
+			return
+		}},
+		{"RFQs", func(c context.Context) (err error) {
+			rfqs, err = client.GetRFQs(c, api.RFQsOptions{Limit: inboxPageSize})
+			return
+		}},
+		{"order groups", func(c context.Context) (err error) {
+			groups, err = client.GetOrderGroups(c, api.OrderGroupsOptions{Limit: inboxPageSize})
+			return
+		}},
+	}
+	for _, r := range requests {
+		reqCtx, cancel := withTimeout(ctx)
+		err := r.call(reqCtx)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", r.what, err)
+		}
+	}
+
+	var items []inboxItem
+	items = append(items, announcementItems(announcements.Announcements)...)
+	items = append(items, quoteItems(quotes.Quotes, id.CommunicationsID)...)
+	items = append(items, rfqItems(rfqs.RFQs, id.CommunicationsID)...)
+	items = append(items, orderGroupItems(groups.OrderGroups)...)
+	return items, nil
+}
+
+func validateInboxKinds(kinds []string) error {
+	for _, k := range kinds {
+		switch k {
+		case inboxAnnouncement, inboxQuote, inboxRFQ, inboxOrderGroup:
+		default:
+			return fmt.Errorf("unknown --kind %q: use announcement, quote, rfq or order_group", k)
+		}
+	}
+	return nil
+}
+
+func runInbox(cmd *cobra.Command, args []string) error {
+	if err := validateInboxKinds(inboxKinds); err != nil {
+		return err
+	}
+	if inboxLimit < 0 {
+		return fmt.Errorf("--limit cannot be negative")
+	}
+	since, err := parseTimeArg(inboxSince, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	items, err := fetchInbox(context.Background(), client)
+	if err != nil {
+		return err
+	}
+	feed := buildInbox(items, inboxKinds, since, inboxLimit)
+
+	if inboxWatch {
+		for _, it := range feed {
+			if err := printInboxItem(it); err != nil {
+				return err
+			}
+		}
+		return watchInbox()
+	}
+
+	if feed == nil {
+		feed = []inboxItem{}
+	}
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			if len(feed) == 0 {
+				PrintWarning("Inbox is empty")
+				return
+			}
+			rows := make([][]string, 0, len(feed))
+			for _, it := range feed {
+				rows = append(rows, []string{formatMarketTime(it.Time), it.Kind, it.Ticker, truncateStr(it.Text, 80)})
+			}
+			ui.RenderTable([]string{"Time", "Kind", "Market", "Text"}, rows)
+		},
+		listJSON(feed, feed, ""),
+		func() {
+			for _, it := range feed {
+				fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%s\n", it.Time.Format(time.RFC3339), it.Kind, it.Ticker, it.Text)
+			}
+		},
+	)
+}
+
+// printInboxItem prints one item as a line, for the --watch stream
+func printInboxItem(it inboxItem) error {
+	switch GetOutputFormat() {
+	case ui.FormatJSON:
+		return printJSONLine(it)
+	case ui.FormatPlain:
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%s\n", it.Time.Format(time.RFC3339), it.Kind, it.Ticker, it.Text)
+	default:
+		ticker := ""
+		if it.Ticker != "" {
+			ticker = " " + it.Ticker
+		}
+		fmt.Fprintf(ui.Writer(), "[%s] %s%s: %s\n", it.Time.Local().Format("15:04:05"), ui.BoldStyle.Render(it.Kind), ticker, it.Text)
+	}
+	return nil
+}
+
+// communicationItem turns a communications message into an inbox item
+func communicationItem(data websocket.CommunicationData, now time.Time) inboxItem {
+	text := strings.ReplaceAll(data.Type, "_", " ")
+	if data.Quantity > 0 {
+		text += fmt.Sprintf(": %s %d @ %s", strings.ToUpper(data.Side), data.Quantity, formatCents(data.Price))
+	}
+	kind := inboxQuote
+	if strings.HasPrefix(data.Type, "rfq") {
+		kind = inboxRFQ
+	}
+	return inboxItem{Time: now, Kind: kind, Ticker: data.Ticker, Text: text}
+}
+
+// orderGroupUpdateItem turns an order group update into an inbox item
+func orderGroupUpdateItem(data websocket.OrderGroupUpdateData, now time.Time) inboxItem {
+	return inboxItem{
+		Time: now,
+		Kind: inboxOrderGroup,
+		ID:   data.OrderGroupID,
+		Text: fmt.Sprintf("Order group %s: %s (%d/%d filled)", truncateID(data.OrderGroupID, 8), data.Status, data.FilledOrders, data.TotalOrders),
+	}
+}
+
+// watchInbox streams communications and order group updates until
+// interrupted
+func watchInbox() error {
+	ctx, stop := alertContext()
+	defer stop()
+
+	want := map[string]bool{}
+	for _, k := range inboxKinds {
+		want[k] = true
+	}
+	emit := func(it inboxItem) error {
+		if len(want) > 0 && !want[it.Kind] {
+			return nil
+		}
+		return printInboxItem(it)
+	}
+
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return err
+	}
+	wsClient := newWebSocketClient(opts)
+	wsClient.OnError(func(err error) {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	})
+	wsClient.RegisterHandler(websocket.ChannelCommunications, websocket.HandlerFunc(func(msg websocket.Message) error {
+		var data websocket.CommunicationData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse communication data: %w", err)
+		}
+		return emit(communicationItem(data, time.Now()))
+	}))
+	wsClient.RegisterHandler(websocket.ChannelOrderGroupUpdates, websocket.HandlerFunc(func(msg websocket.Message) error {
+		var data websocket.OrderGroupUpdateData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse order group data: %w", err)
+		}
+		return emit(orderGroupUpdateItem(data, time.Now()))
+	}))
+
+	if err := wsClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer wsClient.Close()
+	for _, ch := range []websocket.Channel{websocket.ChannelCommunications, websocket.ChannelOrderGroupUpdates} {
+		if err := wsClient.Subscribe(ctx, ch, nil); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", ch, err)
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "Watching for communications and order group updates (Ctrl+C to stop)")
+	<-ctx.Done()
+	return nil
+}
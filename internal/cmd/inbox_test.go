@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestQuoteAndRFQItems(t *testing.T) {
+	quotes := []models.Quote{
+		{ID: "q1", RFQID: "r1", RFQCreatorID: "me", CreatorID: "mm", Status: "open", Contracts: 10, YesBid: 40, NoBid: 55, CreatedTs: "2026-03-01T10:00:00Z"},
+		{ID: "q2", CreatorID: "me", Status: "accepted", AcceptedSide: "yes", UpdatedTs: "2026-03-01T11:00:00Z"},
+		{ID: "q3", CreatorID: "me", Status: "open"},
+		{ID: "q4", CreatorID: "other", RFQCreatorID: "other", Status: "accepted"},
+	}
+	items := quoteItems(quotes, "me")
+	if len(items) != 2 || items[0].ID != "q1" || items[1].ID != "q2" {
+		t.Fatalf("items = %+v", items)
+	}
+	if !strings.Contains(items[1].Text, "quotes confirm q2") || !items[1].Time.Equal(time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("accepted quote = %+v", items[1])
+	}
+
+	rfqs := []models.RFQ{
+		{ID: "r1", CreatorID: "me", Status: "open"},
+		{ID: "r2", CreatorID: "me", Status: "cancelled", CancellationReason: "expired"},
+		{ID: "r3", CreatorID: "other", Status: "cancelled"},
+	}
+	if items := rfqItems(rfqs, "me"); len(items) != 1 || items[0].ID != "r2" || !strings.Contains(items[0].Text, "expired") {
+		t.Errorf("rfq items = %+v", items)
+	}
+}
+
+func TestBuildInbox(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 3, 1, h, 0, 0, 0, time.UTC) }
+	items := []inboxItem{
+		{Time: at(12), Kind: inboxQuote, ID: "late"},
+		{Time: at(8), Kind: inboxAnnouncement, ID: "old"},
+		{Time: at(10), Kind: inboxOrderGroup, ID: "mid"},
+		{Time: at(11), Kind: inboxAnnouncement, ID: "news"},
+	}
+
+	ids := func(feed []inboxItem) string {
+		var s []string
+		for _, it := range feed {
+			s = append(s, it.ID)
+		}
+		return strings.Join(s, ",")
+	}
+	if got := ids(buildInbox(items, nil, at(9), 0)); got != "mid,news,late" {
+		t.Errorf("since = %s", got)
+	}
+	if got := ids(buildInbox(items, []string{inboxAnnouncement}, time.Time{}, 0)); got != "old,news" {
+		t.Errorf("kind = %s", got)
+	}
+	if got := ids(buildInbox(items, nil, time.Time{}, 2)); got != "news,late" {
+		t.Errorf("limit = %s", got)
+	}
+}
@@ -0,0 +1,483 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/daemon"
+	"github.com/6missedcalls/kalshi-cli/internal/jobs"
+	"github.com/6missedcalls/kalshi-cli/internal/seriescache"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Run resumable multi-day exports",
+	Long: `Run historical pulls too large for one session as named jobs. A job
+fetches in chunks and saves a checkpoint after each one, so a job stopped by
+Ctrl+C, a crash or a reboot is picked up with 'jobs resume' without
+refetching or duplicating rows.
+
+Job state lives in the jobs directory under the data directory (see
+'config paths'). Run a job in the background with 'daemon start'.`,
+}
+
+var jobsRunCmd = &cobra.Command{
+	Use:   "run <kind>",
+	Short: "Start a job from a spec file",
+	Long: `Start a job described by a YAML (or JSON) spec file. The only kind is
+export-candles, which writes candlesticks for a list of markets to a CSV or
+JSON Lines file, one market after another:
+
+  name: btc-2025            # defaults to the file name
+  tickers:
+    - KXBTC-25DEC31-B100000
+    - KXBTC-25DEC31-B105000
+  series: KXBTC             # looked up from each market when omitted
+  period: 1m                # 1m, 1h, 1d or a number of minutes
+  start: 2025-01-01
+  end: 2025-12-31           # defaults to now
+  destination: btc-2025.csv
+  format: csv               # csv or jsonl; defaults from the extension
+  chunk: 1000               # periods per request
+
+Start and end take the same values as --start and --end elsewhere. A job
+with the same name that is done or canceled is replaced; an unfinished one
+must be resumed or canceled first.
+
+Here --config names the job spec, so the CLI's own config.yaml is read from
+the config directory (set with --config-dir or KALSHI_CONFIG_DIR).`,
+	Example: `  kalshi-cli jobs run export-candles --config job.yaml
+  kalshi-cli daemon start btc-export -- jobs run export-candles --config job.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runJobsRun,
+}
+
+var jobsStatusCmd = &cobra.Command{
+	Use:   "status [name]",
+	Short: "Show jobs and their progress",
+	Example: `  kalshi-cli jobs status
+  kalshi-cli jobs status btc-2025 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runJobsStatus,
+}
+
+var jobsResumeCmd = &cobra.Command{
+	Use:   "resume <name>",
+	Short: "Continue an interrupted or failed job from its checkpoint",
+	Long: `Continue a job from its last checkpoint. Rows written to the destination
+after the checkpoint are dropped before the job carries on, so nothing is
+duplicated.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runJobsResume,
+}
+
+var jobsCancelCmd = &cobra.Command{
+	Use:   "cancel <name>",
+	Short: "Cancel a job",
+	Long: `Cancel a job so it cannot be resumed. A job running in another process
+stops after its current chunk. The destination keeps the rows written so far.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runJobsCancel,
+}
+
+var (
+	jobsConfig string
+	jobsName   string
+)
+
+func init() {
+	rootCmd.AddCommand(jobsCmd)
+	jobsCmd.AddCommand(jobsRunCmd)
+	jobsCmd.AddCommand(jobsStatusCmd)
+	jobsCmd.AddCommand(jobsResumeCmd)
+	jobsCmd.AddCommand(jobsCancelCmd)
+
+	jobsRunCmd.Flags().StringVar(&jobsConfig, "config", "", "path to the job spec (required)")
+	jobsRunCmd.Flags().StringVar(&jobsName, "name", "", "job name, overriding the spec")
+	jobsRunCmd.MarkFlagRequired("config")
+}
+
+// jobSpec is a job spec file
+type jobSpec struct {
+	Name        string   `mapstructure:"name"`
+	Tickers     []string `mapstructure:"tickers"`
+	Series      string   `mapstructure:"series"`
+	Period      string   `mapstructure:"period"`
+	Start       any      `mapstructure:"start"`
+	End         any      `mapstructure:"end"`
+	Destination string   `mapstructure:"destination"`
+	Format      string   `mapstructure:"format"`
+	Chunk       int      `mapstructure:"chunk"`
+}
+
+// jobStatus is a job in jobs status output
+type jobStatus struct {
+	jobs.Job
+	Percent float64 `json:"percent"`
+}
+
+func jobsManager() (*jobs.Manager, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return jobs.NewManager(jobs.DefaultDir(dir)), nil
+}
+
+// loadJobSpec reads a spec file and builds the job it describes; a
+// non-empty name overrides the spec's
+func loadJobSpec(path, kind, name string, now time.Time) (*jobs.Job, error) {
+	var spec jobSpec
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read job spec: %w", err)
+	}
+	if err := v.Unmarshal(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse job spec: %w", err)
+	}
+	if name != "" {
+		spec.Name = name
+	}
+	return newJob(spec, kind, path, now)
+}
+
+// newJob resolves a spec's times, paths and defaults
+func newJob(spec jobSpec, kind, path string, now time.Time) (*jobs.Job, error) {
+	name := spec.Name
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if spec.Period == "" {
+		spec.Period = "1h"
+	}
+	step, err := candlePeriodDuration(spec.Period)
+	if err != nil {
+		return nil, err
+	}
+	startArg, endArg := specTimeArg(spec.Start), specTimeArg(spec.End)
+	if startArg == "" {
+		return nil, fmt.Errorf("job spec has no start")
+	}
+	if endArg == "" {
+		endArg = "now"
+	}
+	start, end, err := timeRangeArgs(startArg, endArg, now)
+	if err != nil {
+		return nil, err
+	}
+
+	tickers := make([]string, 0, len(spec.Tickers))
+	for _, t := range spec.Tickers {
+		ticker, err := normalizeTicker(t)
+		if err != nil {
+			return nil, err
+		}
+		tickers = append(tickers, ticker)
+	}
+
+	destination := spec.Destination
+	if destination != "" {
+		if destination, err = filepath.Abs(destination); err != nil {
+			return nil, fmt.Errorf("invalid destination: %w", err)
+		}
+	}
+	format := strings.ToLower(spec.Format)
+	if format == "" {
+		format = jobs.FormatCSV
+		switch strings.ToLower(filepath.Ext(destination)) {
+		case ".jsonl", ".ndjson":
+			format = jobs.FormatJSONL
+		}
+	}
+	chunk := spec.Chunk
+	if chunk == 0 {
+		chunk = jobs.DefaultChunk
+	}
+
+	job := &jobs.Job{
+		Name:        name,
+		Kind:        kind,
+		Tickers:     tickers,
+		Series:      spec.Series,
+		Period:      spec.Period,
+		Step:        step,
+		Start:       start.UTC(),
+		End:         end.UTC(),
+		Destination: destination,
+		Format:      format,
+		Chunk:       chunk,
+		Created:     now.UTC(),
+		Updated:     now.UTC(),
+	}
+	if err := job.Validate(); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// specTimeArg returns a spec time as a time argument. YAML turns unquoted
+// dates such as 2025-01-01 into times.
+func specTimeArg(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return t.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func runJobsRun(cmd *cobra.Command, args []string) error {
+	job, err := loadJobSpec(jobsConfig, args[0], jobsName, time.Now())
+	if err != nil {
+		return err
+	}
+
+	m, err := jobsManager()
+	if err != nil {
+		return err
+	}
+	existing, err := m.Load(job.Name)
+	switch {
+	case err == nil && !existing.Finished():
+		return fmt.Errorf("job %q is %s; resume it with 'kalshi-cli jobs resume %s' or cancel it first", job.Name, jobState(existing), job.Name)
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+	return runJob(m, job)
+}
+
+func runJobsResume(cmd *cobra.Command, args []string) error {
+	m, err := jobsManager()
+	if err != nil {
+		return err
+	}
+	job, err := m.Load(args[0])
+	if err != nil {
+		return err
+	}
+	switch state := jobState(job); state {
+	case jobs.StatusDone, jobs.StatusCanceled:
+		return fmt.Errorf("job %q is %s and cannot be resumed", job.Name, state)
+	case jobs.StatusRunning:
+		return fmt.Errorf("job %q is already running (pid %d)", job.Name, job.PID)
+	}
+	return runJob(m, job)
+}
+
+// runJob runs a job in the foreground until it ends or is interrupted
+func runJob(m *jobs.Manager, job *jobs.Job) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := alertContext()
+	defer stop()
+
+	cache := loadSeriesCache()
+	defer saveSeriesCache(cache)
+	// series holds each market's series so it is looked up once per run
+	series := make(map[string]string)
+	fetch := func(ctx context.Context, ticker string, start, end time.Time) ([]models.Candlestick, error) {
+		if series[ticker] == "" {
+			s, err := jobSeries(ctx, client, cache, job.Series, ticker)
+			if err != nil {
+				return nil, err
+			}
+			series[ticker] = s
+		}
+		ctx, cancel := withTimeout(ctx)
+		defer cancel()
+		result, err := client.GetCandlesticks(ctx, api.GetCandlesticksParams{
+			SeriesTicker: series[ticker],
+			Ticker:       ticker,
+			Period:       job.Period,
+			StartTime:    start.Unix(),
+			EndTime:      end.Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Candlesticks, nil
+	}
+
+	progress := ui.NewProgress(job.Name, 0)
+	rows := job.Rows
+	onChunk := func(j *jobs.Job) {
+		progress.Page(int(j.Rows-rows), fmt.Sprintf("%.1f%%", 100*j.Progress()))
+		rows = j.Rows
+	}
+	err = jobs.Run(ctx, m, job, fetch, onChunk)
+	progress.Done()
+	if err != nil {
+		return err
+	}
+
+	switch job.Status {
+	case jobs.StatusInterrupted:
+		PrintWarning(fmt.Sprintf("Job %s interrupted at %.1f%%; continue with 'kalshi-cli jobs resume %s'", job.Name, 100*job.Progress(), job.Name))
+	case jobs.StatusCanceled:
+		PrintWarning(fmt.Sprintf("Job %s canceled after %d rows", job.Name, job.Rows))
+	default:
+		PrintSuccess(fmt.Sprintf("Job %s done: %d rows written to %s", job.Name, job.Rows, job.Destination))
+	}
+	return nil
+}
+
+// jobSeries returns the series of a job's market: the spec's when set,
+// otherwise the series of the market's event
+func jobSeries(ctx context.Context, client *api.Client, cache *seriescache.Cache, series, ticker string) (string, error) {
+	if series != "" {
+		return series, nil
+	}
+	lookupCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	market, err := client.GetMarket(lookupCtx, ticker)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s: %w", ticker, err)
+	}
+	return resolveSeriesTicker(lookupCtx, client, cache, market.EventTicker, "")
+}
+
+// jobState is a job's status, reporting a job left running by a process
+// that has died as interrupted
+func jobState(job *jobs.Job) string {
+	if job.Status == jobs.StatusRunning && (job.PID == 0 || !daemon.Alive(job.PID)) {
+		return jobs.StatusInterrupted
+	}
+	return job.Status
+}
+
+func runJobsCancel(cmd *cobra.Command, args []string) error {
+	m, err := jobsManager()
+	if err != nil {
+		return err
+	}
+	job, err := m.Load(args[0])
+	if err != nil {
+		return err
+	}
+	if job.Finished() {
+		return fmt.Errorf("job %q is already %s", job.Name, job.Status)
+	}
+	running := jobState(job) == jobs.StatusRunning
+
+	job.Status = jobs.StatusCanceled
+	job.Updated = time.Now().UTC()
+	if !running {
+		job.PID = 0
+	}
+	if err := m.Save(job); err != nil {
+		return err
+	}
+	if running {
+		PrintSuccess(fmt.Sprintf("Job %s canceled; pid %d stops after its current chunk", job.Name, job.PID))
+		return nil
+	}
+	PrintSuccess(fmt.Sprintf("Job %s canceled", job.Name))
+	return nil
+}
+
+func runJobsStatus(cmd *cobra.Command, args []string) error {
+	m, err := jobsManager()
+	if err != nil {
+		return err
+	}
+
+	var list []jobs.Job
+	if len(args) == 1 {
+		job, err := m.Load(args[0])
+		if err != nil {
+			return err
+		}
+		list = []jobs.Job{*job}
+	} else if list, err = m.List(); err != nil {
+		return err
+	}
+
+	statuses := make([]jobStatus, 0, len(list))
+	for _, job := range list {
+		job.Status = jobState(&job)
+		if job.Status != jobs.StatusRunning {
+			job.PID = 0
+		}
+		statuses = append(statuses, jobStatus{Job: job, Percent: 100 * job.Progress()})
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			if len(args) == 1 {
+				renderJobDetail(statuses[0])
+				return
+			}
+			renderJobsTable(statuses)
+		},
+		statuses,
+		func() {
+			for _, s := range statuses {
+				fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%.1f\t%d\t%s\n", s.Name, s.Kind, s.Status, s.Percent, s.Rows, s.Destination)
+			}
+		},
+	)
+}
+
+func renderJobsTable(statuses []jobStatus) {
+	if len(statuses) == 0 {
+		fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("No jobs. Start one with 'kalshi-cli jobs run export-candles --config <file>'."))
+		return
+	}
+	headers := []string{"Name", "Kind", "Status", "Progress", "Rows", "Updated", "Destination"}
+	var rows [][]string
+	for _, s := range statuses {
+		rows = append(rows, []string{
+			s.Name, s.Kind, s.Status, fmt.Sprintf("%.1f%%", s.Percent), fmt.Sprint(s.Rows),
+			formatTimeStr(s.Updated.Local()), truncateStr(s.Destination, 40),
+		})
+	}
+	ui.RenderTable(headers, rows)
+}
+
+func renderJobDetail(s jobStatus) {
+	position := "-"
+	if s.Ticker < len(s.Tickers) {
+		position = fmt.Sprintf("%s (%d of %d)", s.Tickers[s.Ticker], s.Ticker+1, len(s.Tickers))
+		if !s.Cursor.IsZero() {
+			position += " through " + formatTimeStr(s.Cursor.Local())
+		}
+	}
+	pairs := [][]string{
+		{"Name", s.Name},
+		{"Kind", s.Kind},
+		{"Status", s.Status},
+		{"Progress", fmt.Sprintf("%.1f%%", s.Percent)},
+		{"Position", position},
+		{"Range", fmt.Sprintf("%s to %s, %s periods", formatTimeStr(s.Start.Local()), formatTimeStr(s.End.Local()), s.Period)},
+		{"Rows", fmt.Sprint(s.Rows)},
+		{"Chunks", fmt.Sprint(s.Chunks)},
+		{"Destination", fmt.Sprintf("%s (%s)", s.Destination, s.Format)},
+		{"Updated", formatTimeStr(s.Updated.Local())},
+	}
+	if s.PID != 0 {
+		pairs = append(pairs, []string{"PID", fmt.Sprint(s.PID)})
+	}
+	if s.Error != "" {
+		pairs = append(pairs, []string{"Error", s.Error})
+	}
+	ui.RenderKeyValue(pairs)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/jobs"
+)
+
+func TestLoadJobSpec(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "btc-history.yaml")
+	spec := `tickers:
+  - kxbtc-25dec31-b100000
+period: 1m
+start: 2025-01-01
+end: "2025-01-02"
+destination: ` + filepath.Join(dir, "out.jsonl") + `
+`
+	if err := os.WriteFile(path, []byte(spec), 0600); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	job, err := loadJobSpec(path, jobs.KindExportCandles, "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Name != "btc-history" || job.Tickers[0] != "KXBTC-25DEC31-B100000" {
+		t.Errorf("job = %+v", job)
+	}
+	if job.Step != time.Minute || job.Format != jobs.FormatJSONL || job.Chunk != jobs.DefaultChunk {
+		t.Errorf("defaults = %v %s %d", job.Step, job.Format, job.Chunk)
+	}
+	if job.End.Sub(job.Start) != 24*time.Hour {
+		t.Errorf("range = %v to %v", job.Start, job.End)
+	}
+
+	if job, err := loadJobSpec(path, jobs.KindExportCandles, "renamed", now); err != nil || job.Name != "renamed" {
+		t.Errorf("renamed job = %v, %v", job, err)
+	}
+	if _, err := loadJobSpec(path, "export-trades", "", now); err == nil || !strings.Contains(err.Error(), "unknown job kind") {
+		t.Errorf("err = %v, want unknown kind", err)
+	}
+}
+
+func TestJobState(t *testing.T) {
+	job := &jobs.Job{Status: jobs.StatusRunning, PID: os.Getpid()}
+	if got := jobState(job); got != jobs.StatusRunning {
+		t.Errorf("state = %s, want running", got)
+	}
+	job.PID = 0
+	if got := jobState(job); got != jobs.StatusInterrupted {
+		t.Errorf("state = %s, want interrupted for a dead runner", got)
+	}
+}
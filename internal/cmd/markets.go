@@ -3,19 +3,23 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/notes"
 	"github.com/6missedcalls/kalshi-cli/internal/ui"
 	"github.com/6missedcalls/kalshi-cli/pkg/models"
 )
 
 var marketsCmd = &cobra.Command{
-	Use:   "markets",
-	Short: "Manage and view markets",
+	Use:     "markets",
+	Aliases: []string{"m"},
+	Short:   "Manage and view markets",
 	Long:  `Commands for listing, viewing, and analyzing prediction markets.`,
 	Example: `  kalshi-cli markets list --status open
   kalshi-cli markets get INXD-25FEB07-B5523.99
@@ -26,10 +30,13 @@ var marketsCmd = &cobra.Command{
 var marketsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List markets",
-	Long:  `List markets with optional filtering by status and series.`,
+	Long: `List markets with optional filtering by status and series, or the markets
+in a watchlist from the config file. Local notes and tags (see 'markets note')
+are shown in a Notes column.`,
 	Example: `  kalshi-cli markets list
   kalshi-cli markets list --status open --limit 20
-  kalshi-cli markets list --series INXD --json`,
+  kalshi-cli markets list --series INXD --json
+  kalshi-cli markets list --watchlist mine`,
 	RunE: runMarketsList,
 }
 
@@ -38,8 +45,10 @@ var marketsGetCmd = &cobra.Command{
 	Short: "Get market details",
 	Long: `Get detailed information about a specific market.
 
-Use 'kalshi-cli markets list' to find market tickers.`,
-	Example: `  kalshi-cli markets get INXD-25FEB07-B5523.99`,
+Use 'kalshi-cli markets list' to find market tickers. Use --mine to include
+your position and resting orders in the market.`,
+	Example: `  kalshi-cli markets get INXD-25FEB07-B5523.99
+  kalshi-cli markets get INXD-25FEB07-B5523.99 --mine`,
 	Args:    cobra.ExactArgs(1),
 	RunE:    runMarketsGet,
 }
@@ -49,9 +58,12 @@ var marketsOrderbookCmd = &cobra.Command{
 	Short: "Get market orderbook",
 	Long: `Get the orderbook for a specific market with visual display.
 
-Shows YES bids and asks with quantities at each price level.`,
+Shows YES bids and asks with quantities at each price level. With --mine,
+your resting quantity is marked at each level and your position is shown
+below the book.`,
 	Example: `  kalshi-cli markets orderbook INXD-25FEB07-B5523.99
-  kalshi-cli markets orderbook INXD-25FEB07-B5523.99 --json`,
+  kalshi-cli markets orderbook INXD-25FEB07-B5523.99 --json
+  kalshi-cli markets orderbook INXD-25FEB07-B5523.99 --mine`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMarketsOrderbook,
 }
@@ -61,7 +73,8 @@ var marketsTradesCmd = &cobra.Command{
 	Short: "Get market trades",
 	Long:  `Get recent trades for a specific market.`,
 	Example: `  kalshi-cli markets trades INXD-25FEB07-B5523.99
-  kalshi-cli markets trades INXD-25FEB07-B5523.99 --limit 20`,
+  kalshi-cli markets trades INXD-25FEB07-B5523.99 --limit 20
+  kalshi-cli markets trades INXD-25FEB07-B5523.99 --start yesterday --end today`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMarketsTrades,
 }
@@ -72,9 +85,21 @@ var marketsCandlesticksCmd = &cobra.Command{
 	Long: `Get candlestick (OHLCV) data for a specific market.
 
 Requires --series flag with the series ticker.
-Supported periods: 1m, 1h, 1d`,
+Supported periods: 1m, 1h, 1d
+
+Kalshi omits periods with no trades. --fill-gaps inserts a zero-volume candle
+for each missing period, flat at the previous close, so the series is regular.
+
+--live seeds the chart from these candles, then follows the market's ticker
+stream: the latest bar's high, low, close and volume update as prices print
+and a new bar opens when the period rolls over. The chart is redrawn in place
+every --refresh; with --json or --plain the latest bar is printed each time
+it changes.`,
 	Example: `  kalshi-cli markets candlesticks INXD-25FEB07-B5523.99 --series INXD
-  kalshi-cli markets candlesticks INXD-25FEB07-B5523.99 --series INXD --period 1d`,
+  kalshi-cli markets candlesticks INXD-25FEB07-B5523.99 --series INXD --period 1d
+  kalshi-cli markets candlesticks INXD-25FEB07-B5523.99 --series INXD --period 1m --fill-gaps
+  kalshi-cli markets candlesticks INXD-25FEB07-B5523.99 --series INXD --start -7d --end now
+  kalshi-cli markets candlesticks INXD-25FEB07-B5523.99 --series INXD --period 1m --start -1h --live`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMarketsCandlesticks,
 }
@@ -88,16 +113,20 @@ var seriesCmd = &cobra.Command{
 var seriesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List series",
-	Long:  `List market series with optional category filtering.`,
+	Long: `List market series with optional category and tag filtering. The fee type
+column shows how the series charges trading fees.`,
 	Example: `  kalshi-cli markets series list
-  kalshi-cli markets series list --category economics`,
+  kalshi-cli markets series list --category economics
+  kalshi-cli markets series list --tags Fed,Inflation --product-metadata --json`,
 	RunE: runSeriesList,
 }
 
 var seriesGetCmd = &cobra.Command{
 	Use:     "get <series-ticker>",
 	Short:   "Get series details",
-	Long:    `Get detailed information about a specific series.`,
+	Long:    `Get detailed information about a specific series, including its fee
+structure (fee type, multiplier, and whether makers pay fees), settlement
+sources and contract terms.`,
 	Example: `  kalshi-cli markets series get INXD`,
 	Args:    cobra.ExactArgs(1),
 	RunE:    runSeriesGet,
@@ -105,13 +134,24 @@ var seriesGetCmd = &cobra.Command{
 
 // Command flags
 var (
-	marketStatus   string
-	marketLimit    int
-	seriesTicker   string
-	tradesLimit    int
+	marketStatus       string
+	marketLimit        int
+	seriesTicker       string
+	marketWatchlist    string
+	marketCursor       string
+	tradesLimit        int
+	tradesCursor       string
 	candlePeriod       string
 	candleSeriesTicker string
+	candleFillGaps     bool
+	candleStart        string
+	candleEnd          string
+	tradesStart        string
+	tradesEnd          string
 	seriesCategory     string
+	seriesTags         []string
+	seriesMetadata     bool
+	seriesCursor       string
 	seriesLimit    int
 )
 
@@ -119,15 +159,26 @@ func init() {
 	marketsListCmd.Flags().StringVar(&marketStatus, "status", "", "filter by status (open, closed, settled)")
 	marketsListCmd.Flags().IntVar(&marketLimit, "limit", 50, "maximum number of markets to return")
 	marketsListCmd.Flags().StringVar(&seriesTicker, "series", "", "filter by series ticker")
+	marketsListCmd.Flags().StringVar(&marketWatchlist, "watchlist", "", "list the markets in a watchlist from the config file")
+	marketsListCmd.Flags().StringVar(&marketCursor, "cursor", "", "pagination cursor")
 
 	marketsTradesCmd.Flags().IntVar(&tradesLimit, "limit", 100, "maximum number of trades to return")
+	marketsTradesCmd.Flags().StringVar(&tradesStart, "start", "", "only trades at or after this time: "+timeArgHelp)
+	marketsTradesCmd.Flags().StringVar(&tradesEnd, "end", "", "only trades before this time: "+timeArgHelp)
+	marketsTradesCmd.Flags().StringVar(&tradesCursor, "cursor", "", "pagination cursor")
 
 	marketsCandlesticksCmd.Flags().StringVar(&candlePeriod, "period", "1h", "candlestick period (1m, 1h, 1d)")
 	marketsCandlesticksCmd.Flags().StringVar(&candleSeriesTicker, "series", "", "series ticker (required for candlesticks)")
+	marketsCandlesticksCmd.Flags().StringVar(&candleStart, "start", "", "start time: "+timeArgHelp)
+	marketsCandlesticksCmd.Flags().StringVar(&candleEnd, "end", "", "end time: "+timeArgHelp)
+	marketsCandlesticksCmd.Flags().BoolVar(&candleFillGaps, "fill-gaps", false, "insert zero-volume candles for periods with no trades, carrying the close forward")
 	marketsCandlesticksCmd.MarkFlagRequired("series")
 
 	seriesListCmd.Flags().StringVar(&seriesCategory, "category", "", "filter by category")
+	seriesListCmd.Flags().StringSliceVar(&seriesTags, "tags", nil, "filter by tags (comma-separated)")
+	seriesListCmd.Flags().BoolVar(&seriesMetadata, "product-metadata", false, "include product metadata (shown with --json)")
 	seriesListCmd.Flags().IntVar(&seriesLimit, "limit", 50, "maximum number of series to return")
+	seriesListCmd.Flags().StringVar(&seriesCursor, "cursor", "", "pagination cursor")
 
 	seriesCmd.AddCommand(seriesListCmd)
 	seriesCmd.AddCommand(seriesGetCmd)
@@ -143,6 +194,10 @@ func init() {
 }
 
 func runMarketsList(cmd *cobra.Command, args []string) error {
+	if err := validateMarketsAllFlags(); err != nil {
+		return err
+	}
+
 	client, err := createClient()
 	if err != nil {
 		return err
@@ -153,6 +208,18 @@ func runMarketsList(cmd *cobra.Command, args []string) error {
 		Status:       marketStatus,
 		SeriesTicker: seriesTicker,
 		Limit:        marketLimit,
+		Cursor:       marketCursor,
+	}
+	if marketWatchlist != "" {
+		tickers, err := gridTickers(nil, marketWatchlist, GetConfig().Watchlists)
+		if err != nil {
+			return err
+		}
+		params.Tickers = tickers
+		params.Limit = max(params.Limit, len(tickers))
+	}
+	if marketAll {
+		return runMarketsListAll(client, params)
 	}
 
 	result, err := client.ListMarkets(ctx, params)
@@ -160,49 +227,93 @@ func runMarketsList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list markets: %w", err)
 	}
 
-	return outputMarketsList(result.Markets)
+	markets := result.Markets
+	if len(params.Tickers) > 0 {
+		markets = orderByTickers(markets, params.Tickers)
+	} else {
+		sortIfDeterministic(markets, func(i, j int) bool { return markets[i].Ticker < markets[j].Ticker })
+	}
+	return outputMarketsList(markets, result.Cursor)
 }
 
-func outputMarketsList(markets []models.Market) error {
-	format := GetOutputFormat()
+// orderByTickers puts markets in the order of tickers, as in a watchlist
+func orderByTickers(markets []models.Market, tickers []string) []models.Market {
+	rank := make(map[string]int, len(tickers))
+	for i, t := range tickers {
+		rank[t] = i
+	}
+	sorted := append([]models.Market(nil), markets...)
+	sort.SliceStable(sorted, func(i, j int) bool { return rank[sorted[i].Ticker] < rank[sorted[j].Ticker] })
+	return sorted
+}
 
-	tableFunc := func() {
-		headers := []string{"Ticker", "Title", "Status", "Yes Bid", "Yes Ask", "Volume"}
-		var rows [][]string
+func outputMarketsList(markets []models.Market, cursor string) error {
+	format := GetOutputFormat()
 
-		for _, m := range markets {
-			title := truncateMarketString(m.Title, 50)
-			rows = append(rows, []string{
-				m.Ticker,
-				title,
-				formatMarketStatus(m.Status),
-				formatCents(m.YesBid),
-				formatCents(m.YesAsk),
-				fmt.Sprintf("%d", m.Volume),
-			})
-		}
+	book := loadNotes()
+	annotated := make([]models.Market, len(markets))
+	hasNotes := false
+	for i, m := range markets {
+		e := book.Get(m.Ticker)
+		hasNotes = hasNotes || !e.Empty()
+		annotated[i] = withLocalNotes(m, e)
+	}
 
-		ui.RenderTable(headers, rows)
+	tableFunc := func() {
+		renderMarketsTable(markets, book, hasNotes)
 	}
 
 	plainFunc := func() {
-		for _, m := range markets {
-			fmt.Printf("%s\t%s\t%s\t%s\t%s\t%d\n",
-				m.Ticker,
-				m.Title,
-				m.Status,
-				formatCents(m.YesBid),
-				formatCents(m.YesAsk),
-				m.Volume,
-			)
+		renderMarketsPlain(markets)
+	}
+
+	return ui.Output(format, tableFunc, listJSON(annotated, annotated, cursor), plainFunc)
+}
+
+func renderMarketsTable(markets []models.Market, book *notes.Book, hasNotes bool) {
+	headers := []string{"Ticker", "Title", "Status", "Yes Bid", "Yes Ask", "Volume"}
+	if hasNotes {
+		headers = append(headers, "Notes")
+	}
+	var rows [][]string
+
+	for _, m := range markets {
+		title := truncateMarketString(m.Title, 50)
+		row := []string{
+			m.Ticker,
+			title,
+			formatMarketStatus(m.Status),
+			formatCents(m.YesBid),
+			formatCents(m.YesAsk),
+			fmt.Sprintf("%d", m.Volume),
 		}
+		if hasNotes {
+			row = append(row, truncateStr(book.Get(m.Ticker).Summary(), 40))
+		}
+		rows = append(rows, row)
 	}
 
-	return ui.Output(format, tableFunc, markets, plainFunc)
+	ui.RenderTable(headers, rows)
+}
+
+func renderMarketsPlain(markets []models.Market) {
+	for _, m := range markets {
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%s\t%s\t%d\n",
+			m.Ticker,
+			m.Title,
+			m.Status,
+			formatCents(m.YesBid),
+			formatCents(m.YesAsk),
+			m.Volume,
+		)
+	}
 }
 
 func runMarketsGet(cmd *cobra.Command, args []string) error {
-	ticker := args[0]
+	ticker, err := normalizeTicker(args[0])
+	if err != nil {
+		return err
+	}
 
 	client, err := createClient()
 	if err != nil {
@@ -212,14 +323,23 @@ func runMarketsGet(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	market, err := client.GetMarket(ctx, ticker)
 	if err != nil {
-		return fmt.Errorf("failed to get market: %w", err)
+		return fmt.Errorf("failed to get market: %w", marketNotFound(ctx, client, ticker, err))
+	}
+
+	var mine *myMarket
+	if marketsMine {
+		if mine, err = fetchMyMarket(ctx, client, ticker); err != nil {
+			return err
+		}
 	}
 
-	return outputMarketDetails(market)
+	return outputMarketDetails(market, mine)
 }
 
-func outputMarketDetails(market *models.Market) error {
+func outputMarketDetails(market *models.Market, mine *myMarket) error {
 	format := GetOutputFormat()
+	localNotes := loadNotes().Get(market.Ticker)
+	annotated := withMyMarket(withLocalNotes(*market, localNotes), mine)
 
 	tableFunc := func() {
 		pairs := [][]string{
@@ -244,25 +364,46 @@ func outputMarketDetails(market *models.Market) error {
 		if market.Result != "" {
 			pairs = append(pairs, []string{"Result", market.Result})
 		}
+		if len(localNotes.Tags) > 0 {
+			pairs = append(pairs, []string{"Tags", strings.Join(localNotes.Tags, ", ")})
+		}
+		for _, n := range localNotes.Notes {
+			pairs = append(pairs, []string{"Note", n.Text})
+		}
+		if mine != nil {
+			pairs = append(pairs, mine.pairs()...)
+		}
 
 		ui.RenderKeyValue(pairs)
 	}
 
 	plainFunc := func() {
-		fmt.Printf("Ticker: %s\n", market.Ticker)
-		fmt.Printf("Title: %s\n", market.Title)
-		fmt.Printf("Status: %s\n", market.Status)
-		fmt.Printf("Yes Bid/Ask: %s / %s\n", formatCents(market.YesBid), formatCents(market.YesAsk))
-		fmt.Printf("No Bid/Ask: %s / %s\n", formatCents(market.NoBid), formatCents(market.NoAsk))
-		fmt.Printf("Last Price: %s\n", formatCents(market.LastPrice))
-		fmt.Printf("Volume: %d\n", market.Volume)
+		fmt.Fprintf(ui.Writer(), "Ticker: %s\n", market.Ticker)
+		fmt.Fprintf(ui.Writer(), "Title: %s\n", market.Title)
+		fmt.Fprintf(ui.Writer(), "Status: %s\n", market.Status)
+		fmt.Fprintf(ui.Writer(), "Yes Bid/Ask: %s / %s\n", formatCents(market.YesBid), formatCents(market.YesAsk))
+		fmt.Fprintf(ui.Writer(), "No Bid/Ask: %s / %s\n", formatCents(market.NoBid), formatCents(market.NoAsk))
+		fmt.Fprintf(ui.Writer(), "Last Price: %s\n", formatCents(market.LastPrice))
+		fmt.Fprintf(ui.Writer(), "Volume: %d\n", market.Volume)
+		if len(localNotes.Tags) > 0 {
+			fmt.Fprintf(ui.Writer(), "Tags: %s\n", strings.Join(localNotes.Tags, ", "))
+		}
+		for _, n := range localNotes.Notes {
+			fmt.Fprintf(ui.Writer(), "Note: %s\n", n.Text)
+		}
+		if mine != nil {
+			mine.printPlain()
+		}
 	}
 
-	return ui.Output(format, tableFunc, market, plainFunc)
+	return ui.Output(format, tableFunc, annotated, plainFunc)
 }
 
 func runMarketsOrderbook(cmd *cobra.Command, args []string) error {
-	ticker := args[0]
+	ticker, err := normalizeTicker(args[0])
+	if err != nil {
+		return err
+	}
 
 	client, err := createClient()
 	if err != nil {
@@ -272,63 +413,102 @@ func runMarketsOrderbook(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	orderbook, err := client.GetOrderbook(ctx, ticker)
 	if err != nil {
-		return fmt.Errorf("failed to get orderbook: %w", err)
+		return fmt.Errorf("failed to get orderbook: %w", marketNotFound(ctx, client, ticker, err))
 	}
 
-	return outputOrderbook(orderbook)
+	var mine *myMarket
+	if marketsMine {
+		if mine, err = fetchMyMarket(ctx, client, ticker); err != nil {
+			return err
+		}
+	}
+
+	return outputOrderbook(orderbook, mine)
 }
 
-func outputOrderbook(ob *models.Orderbook) error {
+func outputOrderbook(ob *models.Orderbook, mine *myMarket) error {
 	format := GetOutputFormat()
+	myBids, myAsks := mine.restingAt()
 
 	tableFunc := func() {
-		fmt.Printf("\n%s Orderbook for %s\n\n", ui.TitleStyle.Render("YES"), ob.Ticker)
+		fmt.Fprintf(ui.Writer(), "\n%s Orderbook for %s\n\n", ui.TitleStyle.Render("YES"), ob.Ticker)
 
 		// YES side - Bids on left, Asks on right
-		fmt.Println(ui.HeaderStyle.Render("         BIDS                    ASKS"))
-		fmt.Println(ui.MutedStyle.Render("   Qty    Price           Price    Qty"))
-		fmt.Println(strings.Repeat("-", 50))
+		if mine != nil {
+			fmt.Fprintln(ui.Writer(), ui.HeaderStyle.Render("               BIDS                    ASKS"))
+			fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("  Mine   Qty    Price           Price    Qty   Mine"))
+			fmt.Fprintln(ui.Writer(), strings.Repeat("-", 62))
+		} else {
+			fmt.Fprintln(ui.Writer(), ui.HeaderStyle.Render("         BIDS                    ASKS"))
+			fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("   Qty    Price           Price    Qty"))
+			fmt.Fprintln(ui.Writer(), strings.Repeat("-", 50))
+		}
 
 		maxRows := maxInt(len(ob.YesBids), len(ob.YesAsks))
 		for i := 0; i < maxRows; i++ {
 			bidStr := "                    "
 			askStr := "                    "
+			myBid, myAsk := 0, 0
 
 			if i < len(ob.YesBids) {
 				bid := ob.YesBids[i]
 				bidStr = fmt.Sprintf("%5d    %s", bid.Quantity, formatCents(bid.Price))
 				bidStr = ui.PriceUpStyle.Render(bidStr)
+				myBid = myBids[bid.Price]
 			}
 
 			if i < len(ob.YesAsks) {
 				ask := ob.YesAsks[i]
 				askStr = fmt.Sprintf("%s    %5d", formatCents(ask.Price), ask.Quantity)
 				askStr = ui.PriceDownStyle.Render(askStr)
+				myAsk = myAsks[ask.Price]
 			}
 
-			fmt.Printf("%s       %s\n", bidStr, askStr)
+			if mine != nil {
+				fmt.Fprintf(ui.Writer(), "%s %s       %s %s\n", myLevel(myBid), bidStr, askStr, myLevel(myAsk))
+			} else {
+				fmt.Fprintf(ui.Writer(), "%s       %s\n", bidStr, askStr)
+			}
 		}
 
-		fmt.Println()
+		fmt.Fprintln(ui.Writer())
+		if mine != nil {
+			ui.RenderKeyValue(mine.pairs())
+		}
 	}
 
 	plainFunc := func() {
-		fmt.Printf("Ticker: %s\n", ob.Ticker)
-		fmt.Println("YES BIDS:")
+		fmt.Fprintf(ui.Writer(), "Ticker: %s\n", ob.Ticker)
+		fmt.Fprintln(ui.Writer(), "YES BIDS:")
 		for _, bid := range ob.YesBids {
-			fmt.Printf("  %s x %d\n", formatCents(bid.Price), bid.Quantity)
+			fmt.Fprintf(ui.Writer(), "  %s x %d%s\n", formatCents(bid.Price), bid.Quantity, myPlainLevel(myBids[bid.Price]))
 		}
-		fmt.Println("YES ASKS:")
+		fmt.Fprintln(ui.Writer(), "YES ASKS:")
 		for _, ask := range ob.YesAsks {
-			fmt.Printf("  %s x %d\n", formatCents(ask.Price), ask.Quantity)
+			fmt.Fprintf(ui.Writer(), "  %s x %d%s\n", formatCents(ask.Price), ask.Quantity, myPlainLevel(myAsks[ask.Price]))
+		}
+		if mine != nil {
+			mine.printPlain()
 		}
 	}
 
-	return ui.Output(format, tableFunc, ob, plainFunc)
+	var jsonData interface{} = ob
+	if mine != nil {
+		jsonData = orderbookWithMine{Orderbook: ob, Mine: mine}
+	}
+	return ui.Output(format, tableFunc, jsonData, plainFunc)
 }
 
 func runMarketsTrades(cmd *cobra.Command, args []string) error {
-	ticker := args[0]
+	ticker, err := normalizeTicker(args[0])
+	if err != nil {
+		return err
+	}
+
+	start, end, err := timeRangeArgs(tradesStart, tradesEnd, time.Now())
+	if err != nil {
+		return err
+	}
 
 	client, err := createClient()
 	if err != nil {
@@ -339,6 +519,13 @@ func runMarketsTrades(cmd *cobra.Command, args []string) error {
 	params := api.GetTradesParams{
 		Ticker: ticker,
 		Limit:  tradesLimit,
+		Cursor: tradesCursor,
+	}
+	if !start.IsZero() {
+		params.MinTs = start.Unix()
+	}
+	if !end.IsZero() {
+		params.MaxTs = end.Unix()
 	}
 
 	result, err := client.GetTrades(ctx, params)
@@ -346,10 +533,10 @@ func runMarketsTrades(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get trades: %w", err)
 	}
 
-	return outputTrades(result.Trades)
+	return outputTrades(result.Trades, result.Cursor)
 }
 
-func outputTrades(trades []models.Trade) error {
+func outputTrades(trades []models.Trade, cursor string) error {
 	format := GetOutputFormat()
 
 	tableFunc := func() {
@@ -371,7 +558,7 @@ func outputTrades(trades []models.Trade) error {
 
 	plainFunc := func() {
 		for _, t := range trades {
-			fmt.Printf("%s\t%s\t%d\t%s\n",
+			fmt.Fprintf(ui.Writer(), "%s\t%s\t%d\t%s\n",
 				t.CreatedTime.Format(time.RFC3339),
 				formatCents(t.Price),
 				t.Count,
@@ -380,11 +567,27 @@ func outputTrades(trades []models.Trade) error {
 		}
 	}
 
-	return ui.Output(format, tableFunc, trades, plainFunc)
+	return ui.Output(format, tableFunc, listJSON(trades, trades, cursor), plainFunc)
 }
 
 func runMarketsCandlesticks(cmd *cobra.Command, args []string) error {
-	ticker := args[0]
+	ticker, err := normalizeTicker(args[0])
+	if err != nil {
+		return err
+	}
+
+	gapStep, err := candleGapStep(candleFillGaps, candlePeriod)
+	if err != nil {
+		return err
+	}
+	if err := validateCandleLiveFlags(); err != nil {
+		return err
+	}
+
+	start, end, err := timeRangeArgs(candleStart, candleEnd, time.Now())
+	if err != nil {
+		return err
+	}
 
 	client, err := createClient()
 	if err != nil {
@@ -397,13 +600,24 @@ func runMarketsCandlesticks(cmd *cobra.Command, args []string) error {
 		Ticker:       ticker,
 		Period:       candlePeriod,
 	}
+	if !start.IsZero() {
+		params.StartTime = start.Unix()
+	}
+	if !end.IsZero() {
+		params.EndTime = end.Unix()
+	}
 
 	result, err := client.GetCandlesticks(ctx, params)
 	if err != nil {
 		return fmt.Errorf("failed to get candlesticks: %w", err)
 	}
+	if candleLive {
+		return runLiveCandles(ticker, result.Candlesticks, gapStep)
+	}
+
+	candles := fillCandleGaps(result.Candlesticks, gapStep)
 
-	return outputCandlesticks(result.Candlesticks)
+	return outputCandlesticks(candles)
 }
 
 func outputCandlesticks(candles []models.Candlestick) error {
@@ -431,7 +645,7 @@ func outputCandlesticks(candles []models.Candlestick) error {
 
 	plainFunc := func() {
 		for _, c := range candles {
-			fmt.Printf("%s\t%s\t%s\t%s\t%s\t%d\n",
+			fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%s\t%s\t%d\n",
 				c.PeriodEnd.Format(time.RFC3339),
 				formatCents(c.Open),
 				formatCents(c.High),
@@ -453,8 +667,11 @@ func runSeriesList(cmd *cobra.Command, args []string) error {
 
 	ctx := context.Background()
 	params := api.ListSeriesParams{
-		Category: seriesCategory,
-		Limit:    seriesLimit,
+		Category:               seriesCategory,
+		Tags:                   seriesTags,
+		IncludeProductMetadata: seriesMetadata,
+		Limit:                  seriesLimit,
+		Cursor:                 seriesCursor,
 	}
 
 	result, err := client.ListSeries(ctx, params)
@@ -462,14 +679,14 @@ func runSeriesList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list series: %w", err)
 	}
 
-	return outputSeriesList(result.Series)
+	return outputSeriesList(result.Series, result.Cursor)
 }
 
-func outputSeriesList(series []models.Series) error {
+func outputSeriesList(series []models.Series, cursor string) error {
 	format := GetOutputFormat()
 
 	tableFunc := func() {
-		headers := []string{"Ticker", "Title", "Category", "Frequency"}
+		headers := []string{"Ticker", "Title", "Category", "Frequency", "Fee Type"}
 		var rows [][]string
 
 		for _, s := range series {
@@ -479,6 +696,7 @@ func outputSeriesList(series []models.Series) error {
 				title,
 				s.Category,
 				s.Frequency,
+				formatFeeType(s.FeeType),
 			})
 		}
 
@@ -487,16 +705,17 @@ func outputSeriesList(series []models.Series) error {
 
 	plainFunc := func() {
 		for _, s := range series {
-			fmt.Printf("%s\t%s\t%s\t%s\n",
+			fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%s\t%s\n",
 				s.Ticker,
 				s.Title,
 				s.Category,
 				s.Frequency,
+				s.FeeType,
 			)
 		}
 	}
 
-	return ui.Output(format, tableFunc, series, plainFunc)
+	return ui.Output(format, tableFunc, listJSON(series, series, cursor), plainFunc)
 }
 
 func runSeriesGet(cmd *cobra.Command, args []string) error {
@@ -526,22 +745,72 @@ func outputSeriesDetails(series *models.Series) error {
 			{"Category", series.Category},
 			{"Frequency", series.Frequency},
 			{"Tags", strings.Join(series.Tags, ", ")},
+			{"Fee Type", formatFeeType(series.FeeType)},
+			{"Fee Multiplier", formatFeeMultiplier(series.FeeMultiplier)},
+			{"Maker Fees", makerFeeLabel(series.FeeType)},
+		}
+		for _, src := range series.SettlementSources {
+			pairs = append(pairs, []string{"Settlement Source", strings.TrimSpace(src.Name + " " + src.URL)})
+		}
+		if series.ContractURL != "" {
+			pairs = append(pairs, []string{"Contract", series.ContractURL})
+		}
+		if series.ContractTermsURL != "" {
+			pairs = append(pairs, []string{"Contract Terms", series.ContractTermsURL})
 		}
 
 		ui.RenderKeyValue(pairs)
 	}
 
 	plainFunc := func() {
-		fmt.Printf("Ticker: %s\n", series.Ticker)
-		fmt.Printf("Title: %s\n", series.Title)
-		fmt.Printf("Category: %s\n", series.Category)
-		fmt.Printf("Frequency: %s\n", series.Frequency)
-		fmt.Printf("Tags: %s\n", strings.Join(series.Tags, ", "))
+		fmt.Fprintf(ui.Writer(), "Ticker: %s\n", series.Ticker)
+		fmt.Fprintf(ui.Writer(), "Title: %s\n", series.Title)
+		fmt.Fprintf(ui.Writer(), "Category: %s\n", series.Category)
+		fmt.Fprintf(ui.Writer(), "Frequency: %s\n", series.Frequency)
+		fmt.Fprintf(ui.Writer(), "Tags: %s\n", strings.Join(series.Tags, ", "))
+		fmt.Fprintf(ui.Writer(), "Fee Type: %s\n", series.FeeType)
+		fmt.Fprintf(ui.Writer(), "Fee Multiplier: %s\n", formatFeeMultiplier(series.FeeMultiplier))
+		fmt.Fprintf(ui.Writer(), "Maker Fees: %s\n", makerFeeLabel(series.FeeType))
 	}
 
 	return ui.Output(format, tableFunc, series, plainFunc)
 }
 
+// formatFeeType renders a series fee type for display
+func formatFeeType(feeType string) string {
+	switch feeType {
+	case models.FeeTypeQuadratic:
+		return "Quadratic"
+	case models.FeeTypeQuadraticWithMakerFees:
+		return "Quadratic + maker"
+	case models.FeeTypeFlat:
+		return "Flat"
+	case "":
+		return "-"
+	}
+	return feeType
+}
+
+// formatFeeMultiplier renders a fee multiplier, or "-" if the API sent none
+func formatFeeMultiplier(m float64) string {
+	if m == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(m, 'f', -1, 64)
+}
+
+// makerFeeLabel says whether resting orders pay fees under a fee type, or
+// "-" when the fee type does not say
+func makerFeeLabel(feeType string) string {
+	switch feeType {
+	case models.FeeTypeQuadraticWithMakerFees:
+		return "yes"
+	case models.FeeTypeQuadratic:
+		return "no"
+	}
+	return "-"
+}
+
 // Helper functions for markets commands
 
 
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var (
+	marketAll bool
+	marketMax int
+)
+
+func init() {
+	marketsListCmd.Flags().BoolVar(&marketAll, "all", false, "follow the cursor through every page, printing each page as it arrives")
+	marketsListCmd.Flags().IntVar(&marketMax, "max", 0, "with --all, stop after this many markets (0 for no cap)")
+}
+
+// validateMarketsAllFlags checks --all and --max against the other list flags
+func validateMarketsAllFlags() error {
+	if marketMax < 0 {
+		return fmt.Errorf("--max cannot be negative")
+	}
+	if !marketAll {
+		if marketMax > 0 {
+			return fmt.Errorf("--max requires --all")
+		}
+		return nil
+	}
+	if marketWatchlist != "" {
+		return fmt.Errorf("--all cannot be used with --watchlist")
+	}
+	if marketLimit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+	return nil
+}
+
+// marketPageFunc fetches one page of markets starting at cursor
+type marketPageFunc func(ctx context.Context, cursor string, limit int) (*models.MarketsResponse, error)
+
+// paginateMarkets calls emit with each page of markets until the cursor runs
+// out or maxMarkets (0 for no cap) have been emitted. It returns the number
+// emitted and the cursor to resume from, empty once the listing is exhausted.
+func paginateMarkets(ctx context.Context, fetch marketPageFunc, cursor string, pageSize, maxMarkets int, emit func([]models.Market) error) (int, string, error) {
+	total := 0
+	seen := map[string]bool{}
+	for {
+		limit := pageSize
+		if maxMarkets > 0 {
+			limit = min(limit, maxMarkets-total)
+		}
+		page, err := fetch(ctx, cursor, limit)
+		if err != nil {
+			return total, cursor, err
+		}
+
+		markets := page.Markets
+		if maxMarkets > 0 && len(markets) > maxMarkets-total {
+			markets = markets[:maxMarkets-total]
+		}
+		if len(markets) > 0 {
+			if err := emit(markets); err != nil {
+				return total, cursor, err
+			}
+			total += len(markets)
+		}
+
+		// A repeated cursor would loop forever, so treat it as the end
+		if page.Cursor == "" || len(page.Markets) == 0 || seen[page.Cursor] {
+			return total, "", nil
+		}
+		seen[page.Cursor] = true
+		cursor = page.Cursor
+		if maxMarkets > 0 && total >= maxMarkets {
+			return total, cursor, nil
+		}
+	}
+}
+
+// runMarketsListAll streams every page of markets. Table output prints a
+// table per page, --plain prints rows as they arrive and --json prints one
+// market per line.
+func runMarketsListAll(client *api.Client, params api.ListMarketsParams) error {
+	ctx := context.Background()
+	progress := ui.NewProgress("markets", marketMax)
+	defer progress.Done()
+	var pageCursor string
+	fetch := func(ctx context.Context, cursor string, limit int) (*models.MarketsResponse, error) {
+		p := params
+		p.Cursor = cursor
+		p.Limit = limit
+		reqCtx, cancel := withTimeout(ctx)
+		defer cancel()
+		result, err := client.ListMarkets(reqCtx, p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list markets: %w", err)
+		}
+		pageCursor = result.Cursor
+		return result, nil
+	}
+
+	book := loadNotes()
+	format := GetOutputFormat()
+	emit := func(markets []models.Market) error {
+		// Report each page after printing it, so streamed output and the
+		// progress line do not overlap
+		progress.Clear()
+		defer progress.Page(len(markets), pageCursor)
+		switch format {
+		case ui.FormatJSON:
+			for _, m := range markets {
+				if err := printJSONLine(withLocalNotes(m, book.Get(m.Ticker))); err != nil {
+					return err
+				}
+			}
+		case ui.FormatPlain:
+			renderMarketsPlain(markets)
+		default:
+			hasNotes := false
+			for _, m := range markets {
+				hasNotes = hasNotes || !book.Get(m.Ticker).Empty()
+			}
+			renderMarketsTable(markets, book, hasNotes)
+		}
+		return nil
+	}
+
+	total, next, err := paginateMarkets(ctx, fetch, params.Cursor, params.Limit, marketMax, emit)
+	if err != nil {
+		return err
+	}
+
+	if format == ui.FormatTable {
+		summary := fmt.Sprintf("%d markets", total)
+		if next != "" {
+			summary += fmt.Sprintf(" (stopped at --max; resume with --cursor %s)", next)
+		}
+		fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render(summary))
+	}
+	return nil
+}
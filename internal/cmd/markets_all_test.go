@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// fakeMarketPages serves n markets in pages, recording the limits asked for
+func fakeMarketPages(n int, limits *[]int) marketPageFunc {
+	return func(_ context.Context, cursor string, limit int) (*models.MarketsResponse, error) {
+		*limits = append(*limits, limit)
+		start := 0
+		if cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+		end := min(start+limit, n)
+		resp := &models.MarketsResponse{}
+		for i := start; i < end; i++ {
+			resp.Markets = append(resp.Markets, models.Market{Ticker: fmt.Sprintf("KX%d", i)})
+		}
+		if end < n {
+			resp.Cursor = strconv.Itoa(end)
+		}
+		return resp, nil
+	}
+}
+
+func TestPaginateMarkets(t *testing.T) {
+	tests := []struct {
+		name       string
+		max        int
+		wantTotal  int
+		wantLimits []int
+		wantNext   string
+	}{
+		{"every page", 0, 7, []int{3, 3, 3}, ""},
+		{"capped mid page", 5, 5, []int{3, 2}, "5"},
+		{"cap past the end", 10, 7, []int{3, 3, 3}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var limits []int
+			var tickers []string
+			total, next, err := paginateMarkets(context.Background(), fakeMarketPages(7, &limits), "", 3, tt.max, func(markets []models.Market) error {
+				for _, m := range markets {
+					tickers = append(tickers, m.Ticker)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != tt.wantTotal || len(tickers) != tt.wantTotal || next != tt.wantNext {
+				t.Errorf("total = %d, emitted %v, next %q", total, tickers, next)
+			}
+			if fmt.Sprint(limits) != fmt.Sprint(tt.wantLimits) {
+				t.Errorf("limits = %v, want %v", limits, tt.wantLimits)
+			}
+		})
+	}
+}
+
+func TestPaginateMarkets_RepeatedCursor(t *testing.T) {
+	calls := 0
+	fetch := func(_ context.Context, cursor string, limit int) (*models.MarketsResponse, error) {
+		calls++
+		return &models.MarketsResponse{Markets: []models.Market{{Ticker: "KXA"}}, Cursor: "same"}, nil
+	}
+	total, _, err := paginateMarkets(context.Background(), fetch, "", 1, 0, func([]models.Market) error { return nil })
+	if err != nil || total != 2 || calls != 2 {
+		t.Errorf("total = %d, calls = %d, err = %v", total, calls, err)
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var (
+	candleLive        bool
+	candleLiveRefresh time.Duration
+)
+
+func init() {
+	marketsCandlesticksCmd.Flags().BoolVar(&candleLive, "live", false, "keep the latest candle updating from the ticker stream and redraw the chart")
+	marketsCandlesticksCmd.Flags().DurationVar(&candleLiveRefresh, "refresh", time.Second, "with --live, how often to redraw")
+}
+
+// validateCandleLiveFlags checks --live against the range flags
+func validateCandleLiveFlags() error {
+	if !candleLive {
+		return nil
+	}
+	if candleEnd != "" {
+		return fmt.Errorf("--end cannot be used with --live")
+	}
+	if candleLiveRefresh <= 0 {
+		return fmt.Errorf("--refresh must be positive")
+	}
+	return nil
+}
+
+// liveCandles extends REST candles with ticker updates. Each update moves the
+// close of the bar its receipt time falls in, opening a new bar when a period
+// rolls over.
+type liveCandles struct {
+	ticker string
+	step   time.Duration
+
+	mu         sync.Mutex
+	candles    []models.Candlestick
+	lastVolume int
+	dirty      bool
+}
+
+func newLiveCandles(ticker string, step time.Duration, seed []models.Candlestick) *liveCandles {
+	candles := append([]models.Candlestick(nil), seed...)
+	// Gap filling groups candles by ticker, so seeded and live bars must match
+	for i := range candles {
+		candles[i].Ticker = ticker
+	}
+	return &liveCandles{ticker: ticker, step: step, candles: candles, dirty: true}
+}
+
+// observe applies a ticker update received at now
+func (l *liveCandles) observe(data websocket.TickerData, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Ticker volume is the market's running total, so the bar gets the
+	// difference from the previous update
+	traded := 0
+	if l.lastVolume > 0 && data.Volume > l.lastVolume {
+		traded = data.Volume - l.lastVolume
+	}
+	if data.Volume > 0 {
+		l.lastVolume = data.Volume
+	}
+
+	price := data.YesPrice
+	if price <= 0 {
+		return
+	}
+
+	// Candles are stamped with the end of their period
+	end := now.Truncate(l.step).Add(l.step)
+	n := len(l.candles)
+	switch {
+	case n > 0 && l.candles[n-1].PeriodEnd.Equal(end):
+		c := &l.candles[n-1]
+		c.High = max(c.High, price)
+		c.Low = min(c.Low, price)
+		c.Close = price
+		c.Volume += traded
+		c.OpenInterest = data.OpenInterest
+	case n == 0 || l.candles[n-1].PeriodEnd.Before(end):
+		l.candles = append(l.candles, models.Candlestick{
+			Ticker:       l.ticker,
+			Open:         price,
+			High:         price,
+			Low:          price,
+			Close:        price,
+			Volume:       traded,
+			OpenInterest: data.OpenInterest,
+			PeriodEnd:    end,
+		})
+	default:
+		return
+	}
+	l.dirty = true
+}
+
+// snapshot copies the candles if anything changed since the last snapshot
+func (l *liveCandles) snapshot() ([]models.Candlestick, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.dirty {
+		return nil, false
+	}
+	l.dirty = false
+	return append([]models.Candlestick(nil), l.candles...), true
+}
+
+// liveCandlesHandler feeds one market's ticker messages into live candles
+type liveCandlesHandler struct {
+	candles *liveCandles
+}
+
+func (h *liveCandlesHandler) HandleMessage(msg websocket.Message) error {
+	var data websocket.TickerData
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return fmt.Errorf("failed to parse ticker data: %w", err)
+	}
+	if data.Ticker != h.candles.ticker {
+		return nil
+	}
+	h.candles.observe(data, time.Now())
+	return nil
+}
+
+// runLiveCandles redraws the candlestick chart as the latest bar updates.
+// Table output redraws the chart; --json and --plain print the latest bar
+// each time it changes.
+func runLiveCandles(ticker string, seed []models.Candlestick, gapStep time.Duration) error {
+	step, err := candlePeriodDuration(candlePeriod)
+	if err != nil {
+		return err
+	}
+	live := newLiveCandles(ticker, step, seed)
+
+	return runLiveView(&liveCandlesHandler{candles: live}, candleLiveRefresh, func(inPlace bool) {
+		candles, changed := live.snapshot()
+		if !changed {
+			return
+		}
+		candles = fillCandleGaps(candles, gapStep)
+
+		switch {
+		case len(candles) == 0:
+			return
+		case GetOutputFormat() == ui.FormatJSON:
+			printJSONLine(candles[len(candles)-1])
+		case GetOutputFormat() == ui.FormatPlain:
+			c := candles[len(candles)-1]
+			fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%s\t%s\t%d\n", c.PeriodEnd.Format(time.RFC3339),
+				formatCents(c.Open), formatCents(c.High), formatCents(c.Low), formatCents(c.Close), c.Volume)
+		case inPlace:
+			// The chart prints straight to stdout, so clear the screen
+			// rather than each line
+			fmt.Fprint(ui.Writer(), redrawHome+redrawBelow)
+			ui.RenderCandlestickChart(candlesToChartData(candles), fmt.Sprintf("%s (%s, live)", ticker, candlePeriod))
+			fmt.Fprint(ui.Writer(), redrawFooter())
+		default:
+			ui.RenderCandlestickChart(candlesToChartData(candles), fmt.Sprintf("%s (%s, live)", ticker, candlePeriod))
+		}
+	})
+}
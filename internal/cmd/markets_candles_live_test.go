@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestLiveCandles(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	live := newLiveCandles("KXA", time.Minute, []models.Candlestick{
+		{Open: 40, High: 42, Low: 39, Close: 41, Volume: 10, PeriodEnd: start.Add(time.Minute)},
+	})
+	tick := func(price, volume int) websocket.TickerData {
+		return websocket.TickerData{Ticker: "KXA", YesPrice: price, Volume: volume}
+	}
+
+	// Updates inside the seeded bar extend it; the first only sets the
+	// running volume
+	live.observe(tick(44, 500), start.Add(20*time.Second))
+	live.observe(tick(38, 507), start.Add(40*time.Second))
+	candles, changed := live.snapshot()
+	if !changed || len(candles) != 1 {
+		t.Fatalf("candles = %+v, changed = %v", candles, changed)
+	}
+	if c := candles[0]; c.Open != 40 || c.High != 44 || c.Low != 38 || c.Close != 38 || c.Volume != 17 || c.Ticker != "KXA" {
+		t.Errorf("updated bar = %+v", c)
+	}
+
+	// A new period opens a bar at the first price seen in it
+	live.observe(tick(45, 510), start.Add(90*time.Second))
+	candles, _ = live.snapshot()
+	if len(candles) != 2 {
+		t.Fatalf("candles = %+v", candles)
+	}
+	if c := candles[1]; c.Open != 45 || c.Close != 45 || c.Volume != 3 || !c.PeriodEnd.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("new bar = %+v", c)
+	}
+
+	// Updates without a price change nothing
+	live.observe(tick(0, 510), start.Add(100*time.Second))
+	if _, changed := live.snapshot(); changed {
+		t.Error("update without a price marked the candles changed")
+	}
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/seriescache"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var marketsCompareCmd = &cobra.Command{
+	Use:   "compare <ticker> <ticker> [ticker...]",
+	Short: "Compare markets side by side",
+	Long: `Show two or more markets side by side: price, bid/ask spread, volume, open
+interest, close time, and implied probability.
+
+Implied probability is the yes bid/ask midpoint, or the last price when
+either side of the book is empty.
+
+With --chart, candlestick closes for every market are overlaid on one chart.
+Series tickers are resolved from each market's event.`,
+	Example: `  kalshi-cli markets compare KXBTC-26FEB12-B97000 KXBTC-26FEB12-B99000
+  kalshi-cli markets compare KXBTC-26FEB12-B97000 KXBTC-26FEB12-B99000 --chart --period 1d`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runMarketsCompare,
+}
+
+var (
+	compareChart  bool
+	comparePeriod string
+)
+
+func init() {
+	marketsCmd.AddCommand(marketsCompareCmd)
+
+	marketsCompareCmd.Flags().BoolVar(&compareChart, "chart", false, "overlay candlestick closes for every market")
+	marketsCompareCmd.Flags().StringVar(&comparePeriod, "period", "1h", "candlestick period for --chart (1m, 1h, 1d)")
+}
+
+// marketComparison is one column of 'markets compare'
+type marketComparison struct {
+	Ticker             string    `json:"ticker"`
+	Title              string    `json:"title"`
+	Status             string    `json:"status"`
+	LastPrice          int       `json:"last_price"`
+	YesBid             int       `json:"yes_bid"`
+	YesAsk             int       `json:"yes_ask"`
+	Spread             int       `json:"spread"`
+	ImpliedProbability float64   `json:"implied_probability"`
+	Volume             int       `json:"volume"`
+	Volume24H          int       `json:"volume_24h"`
+	OpenInterest       int       `json:"open_interest"`
+	CloseTime          time.Time `json:"close_time"`
+	Closes             []int     `json:"closes,omitempty"`
+}
+
+func newMarketComparison(m models.Market) marketComparison {
+	c := marketComparison{
+		Ticker:       m.Ticker,
+		Title:        m.Title,
+		Status:       m.Status,
+		LastPrice:    m.LastPrice,
+		YesBid:       m.YesBid,
+		YesAsk:       m.YesAsk,
+		Volume:       m.Volume,
+		Volume24H:    m.Volume24H,
+		OpenInterest: m.OpenInterest,
+		CloseTime:    m.CloseTime,
+	}
+
+	if m.YesBid > 0 && m.YesAsk > 0 {
+		c.Spread = m.YesAsk - m.YesBid
+		c.ImpliedProbability = float64(m.YesBid+m.YesAsk) / 200
+	} else {
+		c.ImpliedProbability = float64(m.LastPrice) / 100
+	}
+	return c
+}
+
+func runMarketsCompare(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	markets := make([]models.Market, 0, len(args))
+	comparisons := make([]marketComparison, 0, len(args))
+	for _, ticker := range args {
+		market, err := client.GetMarket(ctx, ticker)
+		if err != nil {
+			return fmt.Errorf("failed to get market %s: %w", ticker, err)
+		}
+		markets = append(markets, *market)
+		comparisons = append(comparisons, newMarketComparison(*market))
+	}
+
+	var labels []string
+	var series []ui.LineSeries
+	if compareChart {
+		cache := loadSeriesCache()
+		defer saveSeriesCache(cache)
+		candles := make([][]models.Candlestick, len(markets))
+		for i, m := range markets {
+			candles[i], err = marketCandles(ctx, client, cache, m)
+			if err != nil {
+				return err
+			}
+		}
+
+		var closes [][]int
+		labels, closes = alignCloses(candles)
+		for i := range comparisons {
+			comparisons[i].Closes = closes[i]
+			series = append(series, ui.LineSeries{Name: comparisons[i].Ticker, Values: closes[i]})
+		}
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			renderComparisonTable(comparisons)
+			if compareChart {
+				ui.RenderOverlayChart("Closes ("+comparePeriod+")", labels, series)
+			}
+		},
+		comparisons,
+		func() { renderComparisonPlain(comparisons) },
+	)
+}
+
+// marketCandles fetches candlesticks for a market, resolving its series
+// through the market's event
+func marketCandles(ctx context.Context, client *api.Client, cache *seriescache.Cache, m models.Market) ([]models.Candlestick, error) {
+	seriesTicker, err := resolveSeriesTicker(ctx, client, cache, m.EventTicker, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.GetCandlesticks(ctx, api.GetCandlesticksParams{
+		SeriesTicker: seriesTicker,
+		Ticker:       m.Ticker,
+		Period:       comparePeriod,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candlesticks for %s: %w", m.Ticker, err)
+	}
+	return result.Candlesticks, nil
+}
+
+// alignCloses puts every market's closes on the union of their period end
+// times. A market with no candle for a period gets 0 there.
+func alignCloses(candles [][]models.Candlestick) ([]string, [][]int) {
+	seen := make(map[time.Time]bool)
+	var times []time.Time
+	for _, cs := range candles {
+		for _, c := range cs {
+			if !seen[c.PeriodEnd] {
+				seen[c.PeriodEnd] = true
+				times = append(times, c.PeriodEnd)
+			}
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	index := make(map[time.Time]int, len(times))
+	labels := make([]string, len(times))
+	for i, t := range times {
+		index[t] = i
+		labels[i] = t.Format("01/02 15:04")
+	}
+
+	closes := make([][]int, len(candles))
+	for i, cs := range candles {
+		closes[i] = make([]int, len(times))
+		for _, c := range cs {
+			closes[i][index[c.PeriodEnd]] = c.Close
+		}
+	}
+	return labels, closes
+}
+
+func renderComparisonTable(comparisons []marketComparison) {
+	headers := []string{""}
+	for _, c := range comparisons {
+		headers = append(headers, c.Ticker)
+	}
+
+	field := func(name string, value func(marketComparison) string) []string {
+		row := []string{name}
+		for _, c := range comparisons {
+			row = append(row, value(c))
+		}
+		return row
+	}
+
+	rows := [][]string{
+		field("Title", func(c marketComparison) string { return truncateMarketString(c.Title, 30) }),
+		field("Status", func(c marketComparison) string { return formatMarketStatus(c.Status) }),
+		field("Last Price", func(c marketComparison) string { return formatCents(c.LastPrice) }),
+		field("Yes Bid / Ask", func(c marketComparison) string {
+			return formatCents(c.YesBid) + " / " + formatCents(c.YesAsk)
+		}),
+		field("Spread", func(c marketComparison) string {
+			if c.YesBid == 0 || c.YesAsk == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%d¢", c.Spread)
+		}),
+		field("Implied Prob", func(c marketComparison) string { return fmt.Sprintf("%.1f%%", c.ImpliedProbability*100) }),
+		field("Volume", func(c marketComparison) string { return fmt.Sprintf("%d", c.Volume) }),
+		field("Volume 24h", func(c marketComparison) string { return fmt.Sprintf("%d", c.Volume24H) }),
+		field("Open Interest", func(c marketComparison) string { return fmt.Sprintf("%d", c.OpenInterest) }),
+		field("Close Time", func(c marketComparison) string { return formatMarketTime(c.CloseTime) }),
+	}
+
+	ui.RenderTable(headers, rows)
+}
+
+func renderComparisonPlain(comparisons []marketComparison) {
+	for _, c := range comparisons {
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%d\t%d\t%d\t%d\t%.3f\t%d\t%d\t%s\n",
+			c.Ticker,
+			c.Status,
+			c.LastPrice,
+			c.YesBid,
+			c.YesAsk,
+			c.Spread,
+			c.ImpliedProbability,
+			c.Volume,
+			c.OpenInterest,
+			c.CloseTime.Format(time.RFC3339),
+		)
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestNewMarketComparison(t *testing.T) {
+	c := newMarketComparison(models.Market{Ticker: "A", YesBid: 40, YesAsk: 44, LastPrice: 41})
+	if c.Spread != 4 || c.ImpliedProbability != 0.42 {
+		t.Errorf("two-sided book: spread %d, implied %v; want 4, 0.42", c.Spread, c.ImpliedProbability)
+	}
+
+	c = newMarketComparison(models.Market{Ticker: "B", YesBid: 0, YesAsk: 60, LastPrice: 55})
+	if c.Spread != 0 || c.ImpliedProbability != 0.55 {
+		t.Errorf("one-sided book: spread %d, implied %v; want 0, 0.55", c.Spread, c.ImpliedProbability)
+	}
+}
+
+func TestAlignCloses(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	candles := [][]models.Candlestick{
+		{{PeriodEnd: t0, Close: 40}, {PeriodEnd: t0.Add(2 * time.Hour), Close: 45}},
+		{{PeriodEnd: t0.Add(time.Hour), Close: 60}, {PeriodEnd: t0, Close: 58}},
+	}
+
+	labels, closes := alignCloses(candles)
+	if len(labels) != 3 {
+		t.Fatalf("labels = %v, want 3", labels)
+	}
+	want := [][]int{{40, 0, 45}, {58, 60, 0}}
+	for i := range want {
+		for j := range want[i] {
+			if closes[i][j] != want[i][j] {
+				t.Errorf("closes = %v, want %v", closes, want)
+				return
+			}
+		}
+	}
+}
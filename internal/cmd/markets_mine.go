@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var marketsMine bool
+
+func init() {
+	marketsGetCmd.Flags().BoolVar(&marketsMine, "mine", false, "include your position and resting orders in this market")
+	marketsOrderbookCmd.Flags().BoolVar(&marketsMine, "mine", false, "mark your resting orders on the book and show your position")
+}
+
+// myMarket is the active account's stake in one market
+type myMarket struct {
+	Position *models.MarketPosition `json:"position,omitempty"`
+	Orders   []models.Order         `json:"resting_orders"`
+}
+
+// fetchMyMarket loads the position and resting orders held in ticker
+func fetchMyMarket(ctx context.Context, client *api.Client, ticker string) (*myMarket, error) {
+	mine := &myMarket{Orders: []models.Order{}}
+
+	reqCtx, cancel := withTimeout(ctx)
+	positions, err := client.GetPositions(reqCtx, api.PositionsOptions{Ticker: ticker, SubaccountID: ActiveSubaccount()})
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position: %w", err)
+	}
+	for i, p := range positions.Positions {
+		if p.Ticker == ticker && p.Position != 0 {
+			mine.Position = &positions.Positions[i]
+			break
+		}
+	}
+
+	orders, err := restingOrders(ctx, client, ticker)
+	if err != nil {
+		return nil, err
+	}
+	mine.Orders = append(mine.Orders, orders...)
+	return mine, nil
+}
+
+// restingOrders reads every resting order, in ticker or in every market when
+// ticker is empty
+func restingOrders(ctx context.Context, client *api.Client, ticker string) ([]models.Order, error) {
+	var orders []models.Order
+	opts := api.OrdersOptions{Ticker: ticker, Status: string(models.OrderStatusResting), Limit: reconcilePageSize, SubaccountID: ActiveSubaccount()}
+	for {
+		reqCtx, cancel := withTimeout(ctx)
+		page, err := client.GetOrders(reqCtx, opts)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resting orders: %w", err)
+		}
+		orders = append(orders, page.Orders...)
+		if page.Cursor == "" || len(page.Orders) == 0 {
+			return orders, nil
+		}
+		opts.Cursor = page.Cursor
+	}
+}
+
+// restingAt totals resting contracts by YES price, split into bids and
+// offers the way the book shows them
+func (m *myMarket) restingAt() (bids, asks map[int]int) {
+	bids, asks = map[int]int{}, map[int]int{}
+	if m == nil {
+		return bids, asks
+	}
+	for _, o := range m.Orders {
+		if bid, price := yesBid(o); bid {
+			bids[price] += o.RemainingCount
+		} else {
+			asks[price] += o.RemainingCount
+		}
+	}
+	return bids, asks
+}
+
+// positionLabel describes the position as a side and count
+func (m *myMarket) positionLabel() string {
+	switch {
+	case m.Position == nil:
+		return "flat"
+	case m.Position.Position > 0:
+		return fmt.Sprintf("%d YES", m.Position.Position)
+	default:
+		return fmt.Sprintf("%d NO", -m.Position.Position)
+	}
+}
+
+// orderLabel describes a resting order, e.g. "buy 5 YES @ $0.44"
+func orderLabel(o models.Order) string {
+	side := "YES"
+	if o.Side == models.OrderSideNo {
+		side = "NO"
+	}
+	return fmt.Sprintf("%s %d %s @ %s", o.Action, o.RemainingCount, side, formatCents(orderPrice(o)))
+}
+
+// pairs lists the position and resting orders as detail rows
+func (m *myMarket) pairs() [][]string {
+	pairs := [][]string{{"Position", m.positionLabel()}}
+	if p := m.Position; p != nil {
+		pairs = append(pairs,
+			[]string{"Avg Cost", ui.FormatPrice(calculateAvgCost(*p))},
+			[]string{"Exposure", ui.FormatPrice(p.MarketExposure)},
+			[]string{"Realized P&L", ui.FormatPriceStyled(p.RealizedPnl, p.RealizedPnl >= 0)},
+		)
+	}
+	if len(m.Orders) == 0 {
+		return append(pairs, []string{"Resting Orders", "none"})
+	}
+	for _, o := range m.Orders {
+		pairs = append(pairs, []string{"Resting Order", fmt.Sprintf("%s (%s)", orderLabel(o), truncateID(o.OrderID, 12))})
+	}
+	return pairs
+}
+
+// printPlain prints the position and resting orders as plain lines
+func (m *myMarket) printPlain() {
+	fmt.Fprintf(ui.Writer(), "Position: %s\n", m.positionLabel())
+	if p := m.Position; p != nil {
+		fmt.Fprintf(ui.Writer(), "Exposure: %s\n", ui.FormatPrice(p.MarketExposure))
+		fmt.Fprintf(ui.Writer(), "Realized P&L: %s\n", ui.FormatPrice(p.RealizedPnl))
+	}
+	for _, o := range m.Orders {
+		fmt.Fprintf(ui.Writer(), "Resting Order: %s %s\n", o.OrderID, orderLabel(o))
+	}
+}
+
+// withMyMarket returns a copy of m carrying the stake as mine in --json
+// output
+func withMyMarket(m models.Market, mine *myMarket) models.Market {
+	if mine == nil {
+		return m
+	}
+	data, err := json.Marshal(mine)
+	if err != nil {
+		return m
+	}
+	extra := make(models.Extra, len(m.Extra)+1)
+	for k, v := range m.Extra {
+		extra[k] = v
+	}
+	extra["mine"] = data
+	m.Extra = extra
+	return m
+}
+
+// orderbookWithMine is the --json shape of an orderbook fetched with --mine
+type orderbookWithMine struct {
+	*models.Orderbook
+	Mine *myMarket `json:"mine"`
+}
+
+// myLevel formats the resting quantity at a level, blank when there is none
+func myLevel(qty int) string {
+	if qty == 0 {
+		return "      "
+	}
+	return ui.WarningStyle.Render(fmt.Sprintf("%5d*", qty))
+}
+
+// myPlainLevel suffixes a plain book level with the resting quantity
+func myPlainLevel(qty int) string {
+	if qty == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (mine %d)", qty)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestMyMarket_RestingAt(t *testing.T) {
+	mine := &myMarket{Orders: []models.Order{
+		{Side: models.OrderSideYes, Action: models.OrderActionBuy, YesPrice: 44, RemainingCount: 3},
+		{Side: models.OrderSideYes, Action: models.OrderActionBuy, YesPrice: 44, RemainingCount: 2},
+		{Side: models.OrderSideNo, Action: models.OrderActionBuy, NoPrice: 52, RemainingCount: 7},
+		{Side: models.OrderSideYes, Action: models.OrderActionSell, YesPrice: 50, RemainingCount: 1},
+	}}
+	bids, asks := mine.restingAt()
+	if bids[44] != 5 || len(bids) != 1 {
+		t.Errorf("bids = %v", bids)
+	}
+	if asks[48] != 7 || asks[50] != 1 || len(asks) != 2 {
+		t.Errorf("asks = %v", asks)
+	}
+
+	var none *myMarket
+	if bids, asks := none.restingAt(); len(bids)+len(asks) != 0 {
+		t.Errorf("nil stake = %v %v", bids, asks)
+	}
+}
+
+func TestMyMarket_Labels(t *testing.T) {
+	if got := (&myMarket{}).positionLabel(); got != "flat" {
+		t.Errorf("flat = %q", got)
+	}
+	if got := (&myMarket{Position: &models.MarketPosition{Position: -4}}).positionLabel(); got != "4 NO" {
+		t.Errorf("short = %q", got)
+	}
+	o := models.Order{Side: models.OrderSideNo, Action: models.OrderActionBuy, NoPrice: 52, RemainingCount: 7}
+	if got := orderLabel(o); got != "buy 7 NO @ $0.52" {
+		t.Errorf("order = %q", got)
+	}
+}
+
+func TestWithMyMarket(t *testing.T) {
+	mine := &myMarket{Position: &models.MarketPosition{Ticker: "KXA", Position: 10}, Orders: []models.Order{}}
+	data, err := json.Marshal(withMyMarket(models.Market{Ticker: "KXA"}, mine))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Ticker string `json:"ticker"`
+		Mine   struct {
+			Position struct {
+				Position int `json:"position"`
+			} `json:"position"`
+			Orders []models.Order `json:"resting_orders"`
+		} `json:"mine"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Ticker != "KXA" || got.Mine.Position.Position != 10 || got.Mine.Orders == nil {
+		t.Errorf("json = %s", data)
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/notes"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var marketsNoteCmd = &cobra.Command{
+	Use:   "note [market-ticker]",
+	Short: "Add, tag and show local notes on markets",
+	Long: `Keep research notes and tags on markets. They are stored only on this
+machine, in notes.json in the data directory (see 'config paths'), and are
+shown in 'markets list' and 'markets get', and in their --json output as
+local_notes.
+
+With a ticker and no changes, the market's notes and tags are shown. Without
+a ticker, every market with notes or tags is listed.`,
+	Example: `  kalshi-cli markets note KXCPI-26MAR-T0.3 --add "watch CPI print"
+  kalshi-cli markets note KXCPI-26MAR-T0.3 --tag cpi,macro
+  kalshi-cli markets note KXCPI-26MAR-T0.3
+  kalshi-cli markets note KXCPI-26MAR-T0.3 --delete 1 --untag macro
+  kalshi-cli markets note --tagged cpi`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMarketsNote,
+}
+
+var (
+	noteAdd    []string
+	noteTags   []string
+	noteUntags []string
+	noteDelete int
+	noteClear  bool
+	noteTagged string
+)
+
+func init() {
+	marketsCmd.AddCommand(marketsNoteCmd)
+
+	marketsNoteCmd.Flags().StringArrayVar(&noteAdd, "add", nil, "add a note (repeatable)")
+	marketsNoteCmd.Flags().StringSliceVar(&noteTags, "tag", nil, "add tags (comma-separated)")
+	marketsNoteCmd.Flags().StringSliceVar(&noteUntags, "untag", nil, "remove tags (comma-separated)")
+	marketsNoteCmd.Flags().IntVar(&noteDelete, "delete", 0, "delete a note by its number")
+	marketsNoteCmd.Flags().BoolVar(&noteClear, "clear", false, "delete every note and tag on the market")
+	marketsNoteCmd.Flags().StringVar(&noteTagged, "tagged", "", "without a ticker, list only markets with this tag")
+}
+
+// notesPath returns the notes file location
+func notesPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return notes.DefaultPath(dir), nil
+}
+
+// loadNotes returns the local notes for display next to market data. When
+// they cannot be read, a warning is printed and there are no notes, so notes
+// never stop market data from showing.
+func loadNotes() *notes.Book {
+	empty := &notes.Book{Markets: map[string]notes.Entry{}}
+	path, err := notesPath()
+	if err != nil {
+		return empty
+	}
+	book, err := notes.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return empty
+	}
+	return book
+}
+
+// withLocalNotes returns a copy of m carrying its notes as local_notes in
+// --json output
+func withLocalNotes(m models.Market, e notes.Entry) models.Market {
+	if e.Empty() {
+		return m
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return m
+	}
+	extra := make(models.Extra, len(m.Extra)+1)
+	for k, v := range m.Extra {
+		extra[k] = v
+	}
+	extra["local_notes"] = data
+	m.Extra = extra
+	return m
+}
+
+// noteEntry is one market in markets note output
+type noteEntry struct {
+	Ticker string `json:"ticker"`
+	notes.Entry
+}
+
+func runMarketsNote(cmd *cobra.Command, args []string) error {
+	changing := len(noteAdd) > 0 || len(noteTags) > 0 || len(noteUntags) > 0 || noteDelete != 0 || noteClear
+	if len(args) == 0 {
+		if changing {
+			return fmt.Errorf("give the market ticker to change")
+		}
+	} else if noteTagged != "" {
+		return fmt.Errorf("--tagged only applies when listing, without a ticker")
+	}
+
+	path, err := notesPath()
+	if err != nil {
+		return err
+	}
+	book, err := notes.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		entries := []noteEntry{}
+		for _, ticker := range book.Tickers(noteTagged) {
+			entries = append(entries, noteEntry{Ticker: ticker, Entry: book.Get(ticker)})
+		}
+		return outputNoteEntries(entries)
+	}
+
+	ticker, err := normalizeTicker(args[0])
+	if err != nil {
+		return err
+	}
+	if changing {
+		if err := applyNoteChanges(book, ticker, time.Now()); err != nil {
+			return err
+		}
+		if err := notes.Save(path, book); err != nil {
+			return err
+		}
+	}
+	return outputNoteEntry(noteEntry{Ticker: ticker, Entry: book.Get(ticker)})
+}
+
+// applyNoteChanges applies the change flags to ticker: clear first, then
+// delete, then additions, so a note can be replaced in one command
+func applyNoteChanges(book *notes.Book, ticker string, now time.Time) error {
+	if noteClear {
+		book.Clear(ticker)
+	}
+	if noteDelete != 0 {
+		if err := book.DeleteNote(ticker, noteDelete); err != nil {
+			return err
+		}
+	}
+	book.RemoveTags(ticker, noteUntags...)
+	for _, text := range noteAdd {
+		if err := book.AddNote(ticker, text, now); err != nil {
+			return err
+		}
+	}
+	return book.AddTags(ticker, noteTags...)
+}
+
+func outputNoteEntry(e noteEntry) error {
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			if e.Empty() {
+				fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render(fmt.Sprintf("No notes on %s.", e.Ticker)))
+				return
+			}
+			fmt.Fprintln(ui.Writer(), ui.TitleStyle.Render(e.Ticker))
+			if len(e.Tags) > 0 {
+				fmt.Fprintf(ui.Writer(), "Tags: %s\n", strings.Join(e.Tags, ", "))
+			}
+			if len(e.Notes) > 0 {
+				headers := []string{"#", "Added", "Note"}
+				var rows [][]string
+				for i, n := range e.Notes {
+					rows = append(rows, []string{fmt.Sprint(i + 1), formatTimeStr(n.Added.Local()), n.Text})
+				}
+				ui.RenderTable(headers, rows)
+			}
+		},
+		e,
+		func() {
+			for _, tag := range e.Tags {
+				fmt.Fprintf(ui.Writer(), "tag\t%s\n", tag)
+			}
+			for i, n := range e.Notes {
+				fmt.Fprintf(ui.Writer(), "note\t%d\t%s\t%s\n", i+1, n.Added.Format(time.RFC3339), n.Text)
+			}
+		},
+	)
+}
+
+func outputNoteEntries(entries []noteEntry) error {
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			if len(entries) == 0 {
+				fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("No market notes. Add one with 'kalshi-cli markets note <ticker> --add \"...\"'."))
+				return
+			}
+			headers := []string{"Ticker", "Tags", "Notes", "Latest"}
+			var rows [][]string
+			for _, e := range entries {
+				latest := ""
+				if n := len(e.Notes); n > 0 {
+					latest = truncateStr(e.Notes[n-1].Text, 60)
+				}
+				rows = append(rows, []string{e.Ticker, strings.Join(e.Tags, ", "), fmt.Sprint(len(e.Notes)), latest})
+			}
+			ui.RenderTable(headers, rows)
+		},
+		entries,
+		func() {
+			for _, e := range entries {
+				fmt.Fprintf(ui.Writer(), "%s\t%s\t%d\n", e.Ticker, strings.Join(e.Tags, ","), len(e.Notes))
+			}
+		},
+	)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/notes"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestApplyNoteChanges(t *testing.T) {
+	defer func() { noteAdd, noteTags, noteUntags, noteDelete, noteClear = nil, nil, nil, 0, false }()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	book := &notes.Book{}
+
+	noteAdd, noteTags = []string{"first", "second"}, []string{"CPI", "macro"}
+	if err := applyNoteChanges(book, "KXCPI", now); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the first note and a tag in one command
+	noteAdd, noteTags, noteUntags, noteDelete = []string{"third"}, nil, []string{"macro"}, 1
+	if err := applyNoteChanges(book, "KXCPI", now); err != nil {
+		t.Fatal(err)
+	}
+	e := book.Get("KXCPI")
+	if len(e.Notes) != 2 || e.Notes[0].Text != "second" || e.Notes[1].Text != "third" {
+		t.Errorf("notes = %+v", e.Notes)
+	}
+	if len(e.Tags) != 1 || e.Tags[0] != "cpi" {
+		t.Errorf("tags = %v", e.Tags)
+	}
+
+	noteAdd, noteUntags, noteDelete = nil, nil, 5
+	if err := applyNoteChanges(book, "KXCPI", now); err == nil {
+		t.Error("deleting a missing note should fail")
+	}
+
+	noteDelete, noteClear = 0, true
+	if err := applyNoteChanges(book, "KXCPI", now); err != nil {
+		t.Fatal(err)
+	}
+	if !book.Get("KXCPI").Empty() {
+		t.Error("clear should remove everything")
+	}
+}
+
+func TestWithLocalNotes(t *testing.T) {
+	m := models.Market{Ticker: "KXCPI"}
+	if got := withLocalNotes(m, notes.Entry{}); got.Extra != nil {
+		t.Errorf("no notes should leave the market unchanged, got %v", got.Extra)
+	}
+
+	data, err := json.Marshal(withLocalNotes(m, notes.Entry{Tags: []string{"cpi"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Ticker     string      `json:"ticker"`
+		LocalNotes notes.Entry `json:"local_notes"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Ticker != "KXCPI" || len(out.LocalNotes.Tags) != 1 || out.LocalNotes.Tags[0] != "cpi" {
+		t.Errorf("json = %s", data)
+	}
+	if m.Extra != nil {
+		t.Error("original market should not be modified")
+	}
+}
+
+func TestOrderByTickers(t *testing.T) {
+	markets := []models.Market{{Ticker: "B"}, {Ticker: "C"}, {Ticker: "A"}}
+	got := orderByTickers(markets, []string{"A", "B", "C"})
+	if got[0].Ticker != "A" || got[1].Ticker != "B" || got[2].Ticker != "C" {
+		t.Errorf("order = %v", got)
+	}
+}
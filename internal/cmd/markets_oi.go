@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var marketsOICmd = &cobra.Command{
+	Use:   "oi <market-ticker>",
+	Short: "Show open interest and volume history",
+	Long: `Show a market's open interest and volume over time, taken from its
+candlesticks, separately from price.
+
+The series ticker is resolved from the market's event unless --series is set.
+Use --chart for bar charts of open interest and volume, and --csv to export
+the history to a file.`,
+	Example: `  kalshi-cli markets oi KXBTC-26FEB12-B97000
+  kalshi-cli markets oi KXBTC-26FEB12-B97000 --period 1h --chart
+  kalshi-cli markets oi KXBTC-26FEB12-B97000 --csv oi.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMarketsOI,
+}
+
+var (
+	oiSeries string
+	oiPeriod string
+	oiChart  bool
+	oiCSV    string
+)
+
+func init() {
+	marketsCmd.AddCommand(marketsOICmd)
+
+	marketsOICmd.Flags().StringVar(&oiSeries, "series", "", "series ticker (auto-resolved from the market's event if not provided)")
+	marketsOICmd.Flags().StringVar(&oiPeriod, "period", "1d", "candlestick period (1m, 1h, 1d)")
+	marketsOICmd.Flags().BoolVar(&oiChart, "chart", false, "chart open interest and volume")
+	marketsOICmd.Flags().StringVar(&oiCSV, "csv", "", "also write the history to this CSV file")
+}
+
+// oiPoint is one period of open interest and volume history
+type oiPoint struct {
+	Time         time.Time `json:"time"`
+	OpenInterest int       `json:"open_interest"`
+	Volume       int       `json:"volume"`
+}
+
+func oiHistory(candles []models.Candlestick) []oiPoint {
+	points := make([]oiPoint, len(candles))
+	for i, c := range candles {
+		points[i] = oiPoint{Time: c.PeriodEnd, OpenInterest: c.OpenInterest, Volume: c.Volume}
+	}
+	return points
+}
+
+func runMarketsOI(cmd *cobra.Command, args []string) error {
+	ticker := args[0]
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	seriesTicker := oiSeries
+	if seriesTicker == "" {
+		market, err := client.GetMarket(ctx, ticker)
+		if err != nil {
+			return fmt.Errorf("failed to get market: %w", err)
+		}
+		cache := loadSeriesCache()
+		defer saveSeriesCache(cache)
+		seriesTicker, err = resolveSeriesTicker(ctx, client, cache, market.EventTicker, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := client.GetCandlesticks(ctx, api.GetCandlesticksParams{
+		SeriesTicker: seriesTicker,
+		Ticker:       ticker,
+		Period:       oiPeriod,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get candlesticks: %w", err)
+	}
+
+	points := oiHistory(result.Candlesticks)
+
+	if oiCSV != "" {
+		if err := writeOICSV(oiCSV, points); err != nil {
+			return err
+		}
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			renderOITable(points)
+			if oiChart {
+				labels := make([]string, len(points))
+				oi := make([]int, len(points))
+				volume := make([]int, len(points))
+				for i, p := range points {
+					labels[i] = p.Time.Format("01/02 15:04")
+					oi[i] = p.OpenInterest
+					volume[i] = p.Volume
+				}
+				ui.RenderBarChart("Open Interest", labels, oi)
+				ui.RenderBarChart("Volume", labels, volume)
+			}
+			if oiCSV != "" {
+				PrintSuccess(fmt.Sprintf("Wrote %d rows to %s", len(points), oiCSV))
+			}
+		},
+		points,
+		func() {
+			for _, p := range points {
+				ui.PrintPlain("%s\t%d\t%d", p.Time.Format(time.RFC3339), p.OpenInterest, p.Volume)
+			}
+		},
+	)
+}
+
+func renderOITable(points []oiPoint) {
+	if len(points) == 0 {
+		fmt.Fprintln(ui.Writer(), "No candlestick data found")
+		return
+	}
+
+	headers := []string{"Time", "Open Interest", "Δ OI", "Volume"}
+	rows := make([][]string, len(points))
+	for i, p := range points {
+		change := ""
+		if i > 0 {
+			change = fmt.Sprintf("%+d", p.OpenInterest-points[i-1].OpenInterest)
+		}
+		rows[i] = []string{
+			formatMarketTime(p.Time),
+			strconv.Itoa(p.OpenInterest),
+			change,
+			strconv.Itoa(p.Volume),
+		}
+	}
+	ui.RenderTable(headers, rows)
+}
+
+func writeOICSV(path string, points []oiPoint) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"time", "open_interest", "volume"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, p := range points {
+		if err := w.Write([]string{p.Time.Format(time.RFC3339), strconv.Itoa(p.OpenInterest), strconv.Itoa(p.Volume)}); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestWriteOICSV(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	points := oiHistory([]models.Candlestick{
+		{PeriodEnd: t0, OpenInterest: 100, Volume: 40, Close: 55},
+		{PeriodEnd: t0.Add(24 * time.Hour), OpenInterest: 130, Volume: 75, Close: 60},
+	})
+
+	path := filepath.Join(t.TempDir(), "oi.csv")
+	if err := writeOICSV(path, points); err != nil {
+		t.Fatalf("writeOICSV() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "time,open_interest,volume\n" +
+		"2026-03-01T00:00:00Z,100,40\n" +
+		"2026-03-02T00:00:00Z,130,75\n"
+	if string(data) != want {
+		t.Errorf("CSV = %q, want %q", data, want)
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var marketsResolveCmd = &cobra.Command{
+	Use:   "resolve <ticker>",
+	Short: "Find the market a mistyped or partial ticker refers to",
+	Long: `Normalize a ticker (trim and uppercase), check its syntax, and look it up.
+If no market has that exact ticker, list the closest real tickers from the
+same event, or from the same series if the event does not exist either.
+
+Candidates are ranked by edit distance to the normalized ticker. Exits
+non-zero if nothing close is found.`,
+	Example: `  kalshi-cli markets resolve kxbtc-26feb12-b97000
+  kalshi-cli markets resolve KXBTC-26FEB12-B9700 --limit 3
+  kalshi-cli markets resolve KXBTC-26FEB12-B9700 --plain | head -1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMarketsResolve,
+}
+
+var resolveLimit int
+
+// maxTickerLength bounds ticker arguments well above any real ticker
+const maxTickerLength = 100
+
+// candidateLimit is the page size used when listing candidate markets
+const candidateLimit = 1000
+
+// tickerPattern is the character set of Kalshi tickers
+var tickerPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9._-]*$`)
+
+func init() {
+	marketsCmd.AddCommand(marketsResolveCmd)
+	marketsResolveCmd.Flags().IntVar(&resolveLimit, "limit", 5, "maximum number of suggestions")
+}
+
+// normalizeTicker trims and uppercases a ticker argument and checks its syntax
+func normalizeTicker(s string) (string, error) {
+	ticker := strings.ToUpper(strings.TrimSpace(s))
+	switch {
+	case ticker == "":
+		return "", fmt.Errorf("ticker is empty")
+	case len(ticker) > maxTickerLength:
+		return "", fmt.Errorf("invalid ticker %q: longer than %d characters", s, maxTickerLength)
+	case !tickerPattern.MatchString(ticker):
+		return "", fmt.Errorf("invalid ticker %q: use letters, digits, '-', '.' and '_'", s)
+	case strings.HasSuffix(ticker, "-") || strings.Contains(ticker, "--"):
+		return "", fmt.Errorf("invalid ticker %q: empty segment", s)
+	}
+	return ticker, nil
+}
+
+// tickerSuggestion is a real market close to a requested ticker
+type tickerSuggestion struct {
+	Ticker   string `json:"ticker"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Distance int    `json:"distance"`
+}
+
+// resolveResult is the outcome of markets resolve
+type resolveResult struct {
+	Input       string             `json:"input"`
+	Ticker      string             `json:"ticker"`
+	Found       bool               `json:"found"`
+	Suggestions []tickerSuggestion `json:"suggestions"`
+}
+
+// tickerCandidates lists the markets of the event named by the ticker's first
+// two segments, falling back to the markets of its series
+func tickerCandidates(ctx context.Context, client *api.Client, ticker string) ([]models.Market, error) {
+	segments := strings.Split(ticker, "-")
+	if len(segments) >= 2 {
+		event := segments[0] + "-" + segments[1]
+		resp, err := client.ListMarkets(ctx, api.ListMarketsParams{EventTicker: event, Limit: candidateLimit})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Markets) > 0 {
+			return resp.Markets, nil
+		}
+	}
+
+	resp, err := client.ListMarkets(ctx, api.ListMarketsParams{SeriesTicker: segments[0], Limit: candidateLimit})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Markets, nil
+}
+
+// rankTickers orders markets by edit distance to ticker and keeps the n
+// closest. Markets more than half the ticker's length away are dropped.
+func rankTickers(ticker string, markets []models.Market, n int) []tickerSuggestion {
+	maxDistance := len(ticker)/2 + 1
+	var suggestions []tickerSuggestion
+	for _, m := range markets {
+		d := editDistance(ticker, m.Ticker)
+		if d > maxDistance {
+			continue
+		}
+		suggestions = append(suggestions, tickerSuggestion{Ticker: m.Ticker, Title: m.Title, Status: m.Status, Distance: d})
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Distance != suggestions[j].Distance {
+			return suggestions[i].Distance < suggestions[j].Distance
+		}
+		return suggestions[i].Ticker < suggestions[j].Ticker
+	})
+	if len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// suggestTickers returns the closest real tickers to one that was not found
+func suggestTickers(ctx context.Context, client *api.Client, ticker string, n int) ([]tickerSuggestion, error) {
+	markets, err := tickerCandidates(ctx, client, ticker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list candidate markets: %w", err)
+	}
+	return rankTickers(ticker, markets, n), nil
+}
+
+// marketNotFound adds the closest real tickers to a 404 from a market lookup
+func marketNotFound(ctx context.Context, client *api.Client, ticker string, err error) error {
+	if !api.IsNotFound(err) {
+		return err
+	}
+	suggestions, suggestErr := suggestTickers(ctx, client, ticker, 3)
+	if suggestErr != nil || len(suggestions) == 0 {
+		return err
+	}
+	names := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		names[i] = s.Ticker
+	}
+	return fmt.Errorf("%w (did you mean %s?)", err, strings.Join(names, ", "))
+}
+
+func runMarketsResolve(cmd *cobra.Command, args []string) error {
+	if resolveLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	ticker, err := normalizeTicker(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := withTimeout(context.Background())
+	defer cancel()
+
+	result := resolveResult{Input: args[0], Ticker: ticker, Suggestions: []tickerSuggestion{}}
+	market, err := client.GetMarket(ctx, ticker)
+	switch {
+	case err == nil:
+		result.Found = true
+		result.Suggestions = append(result.Suggestions, tickerSuggestion{Ticker: market.Ticker, Title: market.Title, Status: market.Status})
+	case api.IsNotFound(err):
+		suggestions, err := suggestTickers(ctx, client, ticker, resolveLimit)
+		if err != nil {
+			return err
+		}
+		if len(suggestions) == 0 {
+			return fmt.Errorf("no market matches %s", ticker)
+		}
+		result.Suggestions = suggestions
+	default:
+		return fmt.Errorf("failed to get market: %w", err)
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderResolveTable(result) },
+		result,
+		func() {
+			for _, s := range result.Suggestions {
+				ui.PrintPlain("%s", s.Ticker)
+			}
+		},
+	)
+}
+
+func renderResolveTable(result resolveResult) {
+	if result.Found {
+		PrintSuccess(fmt.Sprintf("%s is a market: %s", result.Ticker, result.Suggestions[0].Title))
+		return
+	}
+
+	fmt.Fprintf(ui.Writer(), "No market %s. Closest matches:\n\n", result.Ticker)
+	headers := []string{"Ticker", "Title", "Status", "Distance"}
+	rows := make([][]string, 0, len(result.Suggestions))
+	for _, s := range result.Suggestions {
+		rows = append(rows, []string{s.Ticker, truncateMarketString(s.Title, 50), formatMarketStatus(s.Status), fmt.Sprintf("%d", s.Distance)})
+	}
+	ui.RenderTable(headers, rows)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestNormalizeTicker(t *testing.T) {
+	valid := map[string]string{
+		" kxbtc-26feb12-b97000 ": "KXBTC-26FEB12-B97000",
+		"INXD-25FEB07-B5523.99":  "INXD-25FEB07-B5523.99",
+		"kxnba_finals":           "KXNBA_FINALS",
+	}
+	for in, want := range valid {
+		if got, err := normalizeTicker(in); err != nil || got != want {
+			t.Errorf("normalizeTicker(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "  ", "-KXBTC", "KXBTC-", "KXBTC--26FEB12", "KX BTC", "KXBTC/26", strings.Repeat("A", 101)} {
+		if _, err := normalizeTicker(in); err == nil {
+			t.Errorf("normalizeTicker(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"ABC", "", 3},
+		{"KXBTC-B9700", "KXBTC-B97000", 1},
+		{"KXBTC-B97500", "KXBTC-B97000", 1},
+		{"KITTEN", "SITTING", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRankTickers(t *testing.T) {
+	markets := []models.Market{
+		{Ticker: "KXBTC-26FEB12-B98000"},
+		{Ticker: "KXBTC-26FEB12-B97000"},
+		{Ticker: "KXBTC-26FEB12-B97500"},
+		{Ticker: "KXETH-27MAR01-T4000"},
+	}
+
+	got := rankTickers("KXBTC-26FEB12-B9700", markets, 2)
+	if len(got) != 2 || got[0].Ticker != "KXBTC-26FEB12-B97000" || got[0].Distance != 1 || got[1].Ticker != "KXBTC-26FEB12-B97500" {
+		t.Errorf("rankTickers() = %+v", got)
+	}
+	for _, s := range rankTickers("KXBTC-26FEB12-B9700", markets, 10) {
+		if s.Ticker == "KXETH-27MAR01-T4000" {
+			t.Errorf("expected distant ticker to be dropped, got %+v", s)
+		}
+	}
+}
+
+func TestMarketNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("event_ticker") != "KXBTC-26FEB12" {
+			json.NewEncoder(w).Encode(models.MarketsResponse{})
+			return
+		}
+		json.NewEncoder(w).Encode(models.MarketsResponse{Markets: []models.Market{
+			{Ticker: "KXBTC-26FEB12-B97000"},
+			{Ticker: "KXBTC-26FEB12-B99000"},
+		}})
+	}))
+	defer server.Close()
+	client := newCmdTestClient(t, server.URL)
+
+	notFound := &api.APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	err := marketNotFound(context.Background(), client, "KXBTC-26FEB12-B9700", notFound)
+	if !api.IsNotFound(err) || !strings.Contains(err.Error(), "did you mean KXBTC-26FEB12-B97000, KXBTC-26FEB12-B99000?") {
+		t.Errorf("marketNotFound() = %v", err)
+	}
+
+	other := &api.APIError{StatusCode: http.StatusInternalServerError}
+	if err := marketNotFound(context.Background(), client, "KXBTC-26FEB12-B9700", other); err != other {
+		t.Errorf("expected non-404 errors to pass through, got %v", err)
+	}
+}
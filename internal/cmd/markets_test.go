@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestSeriesFeeLabels(t *testing.T) {
+	tests := []struct {
+		feeType string
+		display string
+		maker   string
+	}{
+		{models.FeeTypeQuadratic, "Quadratic", "no"},
+		{models.FeeTypeQuadraticWithMakerFees, "Quadratic + maker", "yes"},
+		{models.FeeTypeFlat, "Flat", "-"},
+		{"", "-", "-"},
+		{"tiered", "tiered", "-"},
+	}
+	for _, tt := range tests {
+		if got := formatFeeType(tt.feeType); got != tt.display {
+			t.Errorf("formatFeeType(%q) = %q, want %q", tt.feeType, got, tt.display)
+		}
+		if got := makerFeeLabel(tt.feeType); got != tt.maker {
+			t.Errorf("makerFeeLabel(%q) = %q, want %q", tt.feeType, got, tt.maker)
+		}
+	}
+
+	if got := formatFeeMultiplier(0.5); got != "0.5" {
+		t.Errorf("formatFeeMultiplier(0.5) = %q", got)
+	}
+	if got := formatFeeMultiplier(0); got != "-" {
+		t.Errorf("formatFeeMultiplier(0) = %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+)
+
+var (
+	proxyURL           string
+	caBundle           string
+	insecureSkipVerify bool
+
+	// httpTransport carries the proxy and TLS settings for REST and WebSocket
+	// connections; nil means Go's defaults
+	httpTransport *http.Transport
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "http(s) or socks5 proxy URL (default: network.proxy, then HTTP(S)_PROXY)")
+	rootCmd.PersistentFlags().StringVar(&caBundle, "ca-bundle", "", "PEM file of extra root certificates to trust (default: network.ca_bundle)")
+	rootCmd.PersistentFlags().BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "skip TLS certificate verification (demo only)")
+}
+
+// initNetwork applies the network flags over config and builds the shared
+// transport
+func initNetwork(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	if flags.Changed("proxy") {
+		cfg.Network.Proxy = proxyURL
+	}
+	if flags.Changed("ca-bundle") {
+		cfg.Network.CABundle = caBundle
+	}
+	if insecureSkipVerify {
+		cfg.Network.InsecureSkipVerify = true
+	}
+
+	if cfg.Network.InsecureSkipVerify && cfg.API.Production {
+		return fmt.Errorf("--insecure-skip-verify (network.insecure_skip_verify) is only allowed against demo")
+	}
+
+	httpTransport = nil
+	if cfg.Network == (config.NetworkConfig{}) {
+		return nil
+	}
+
+	transport, err := api.NewTransport(api.TransportOptions{
+		Proxy:              cfg.Network.Proxy,
+		CABundle:           cfg.Network.CABundle,
+		InsecureSkipVerify: cfg.Network.InsecureSkipVerify,
+	})
+	if err != nil {
+		return fmt.Errorf("network settings: %w", err)
+	}
+	httpTransport = transport
+	return nil
+}
+
+// websocketHTTPClient returns the client for the WebSocket handshake, or nil
+// for the default
+func websocketHTTPClient() *http.Client {
+	if httpTransport == nil {
+		return nil
+	}
+	return &http.Client{Transport: httpTransport}
+}
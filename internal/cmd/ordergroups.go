@@ -84,6 +84,7 @@ var (
 	orderGroupLimit    int
 	orderGroupNewLimit int
 	orderGroupStatus   string
+	orderGroupCursor   string
 )
 
 func init() {
@@ -98,6 +99,7 @@ func init() {
 	orderGroupsCmd.AddCommand(orderGroupsUpdateLimitCmd)
 
 	orderGroupsListCmd.Flags().StringVar(&orderGroupStatus, "status", "", "filter by status")
+	orderGroupsListCmd.Flags().StringVar(&orderGroupCursor, "cursor", "", "pagination cursor")
 
 	orderGroupsCreateCmd.Flags().IntVar(&orderGroupLimit, "limit", 0, "maximum contracts to fill (required)")
 	orderGroupsCreateCmd.MarkFlagRequired("limit")
@@ -116,6 +118,7 @@ func runOrderGroupsList(cmd *cobra.Command, args []string) error {
 
 	opts := api.OrderGroupsOptions{
 		Status: orderGroupStatus,
+		Cursor: orderGroupCursor,
 	}
 
 	result, err := client.GetOrderGroups(context.Background(), opts)
@@ -123,7 +126,7 @@ func runOrderGroupsList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return outputOrderGroupsList(result.OrderGroups)
+	return outputOrderGroupsList(result.OrderGroups, result.Cursor)
 }
 
 func runOrderGroupsGet(cmd *cobra.Command, args []string) error {
@@ -165,14 +168,13 @@ func runOrderGroupsCreate(cmd *cobra.Command, args []string) error {
 func runOrderGroupsDelete(cmd *cobra.Command, args []string) error {
 	groupID := args[0]
 
-	if !SkipConfirmation() {
-		fmt.Printf("Are you sure you want to delete order group %s? (y/N): ", groupID)
-		var response string
-		fmt.Scanln(&response)
-		if response != "y" && response != "Y" {
-			PrintWarning("Deletion canceled")
-			return nil
-		}
+	confirmed, err := confirmAction(fmt.Sprintf("Are you sure you want to delete order group %s?", groupID))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Deletion canceled")
+		return nil
 	}
 
 	client, err := createClient()
@@ -243,7 +245,7 @@ func runOrderGroupsUpdateLimit(cmd *cobra.Command, args []string) error {
 	return outputOrderGroupDetails(&result.OrderGroup)
 }
 
-func outputOrderGroupsList(groups []models.OrderGroup) error {
+func outputOrderGroupsList(groups []models.OrderGroup, cursor string) error {
 	format := GetOutputFormat()
 
 	tableFunc := func() {
@@ -270,7 +272,7 @@ func outputOrderGroupsList(groups []models.OrderGroup) error {
 		}
 	}
 
-	return ui.Output(format, tableFunc, groups, plainFunc)
+	return ui.Output(format, tableFunc, listJSON(groups, groups, cursor), plainFunc)
 }
 
 func outputOrderGroupDetails(group *models.OrderGroup) error {
@@ -301,3 +303,81 @@ func outputOrderGroupDetails(group *models.OrderGroup) error {
 
 	return ui.Output(format, tableFunc, group, plainFunc)
 }
+
+// orderGroupNew is the --order-group value that creates a new group
+const orderGroupNew = "new"
+
+var (
+	orderGroupSpec      string
+	orderGroupSpecLimit int
+)
+
+// addOrderGroupFlags registers --order-group and --group-limit on a command
+// that places orders
+func addOrderGroupFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&orderGroupSpec, "order-group", "", "attach orders to an order group: 'new' or an existing group ID")
+	cmd.Flags().IntVar(&orderGroupSpecLimit, "group-limit", 0, "contract limit for --order-group new (default: total contracts)")
+}
+
+// validateOrderGroupFlags checks --order-group and --group-limit together
+func validateOrderGroupFlags() error {
+	if orderGroupSpecLimit < 0 {
+		return fmt.Errorf("group limit must be a positive integer")
+	}
+	if orderGroupSpecLimit > 0 && orderGroupSpec != orderGroupNew {
+		return fmt.Errorf("--group-limit only applies with --order-group new")
+	}
+	return nil
+}
+
+// orderGroupPreview describes --order-group for an order preview, or "" if
+// orders are not grouped
+func orderGroupPreview(contracts int) string {
+	switch orderGroupSpec {
+	case "":
+		return ""
+	case orderGroupNew:
+		return fmt.Sprintf("new, limit %d contracts", orderGroupLimitFor(contracts))
+	default:
+		return orderGroupSpec
+	}
+}
+
+// orderGroupLimitFor is the limit of a new group covering contracts
+func orderGroupLimitFor(contracts int) int {
+	if orderGroupSpecLimit > 0 {
+		return orderGroupSpecLimit
+	}
+	return contracts
+}
+
+// resolveOrderGroup returns the group ID orders should be attached to,
+// creating a new group when --order-group is "new"
+func resolveOrderGroup(ctx context.Context, client *api.Client, contracts int) (string, error) {
+	if orderGroupSpec != orderGroupNew {
+		return orderGroupSpec, nil
+	}
+
+	result, err := client.CreateOrderGroup(ctx, models.CreateOrderGroupRequest{Limit: orderGroupLimitFor(contracts)})
+	if err != nil {
+		return "", fmt.Errorf("failed to create order group: %w", err)
+	}
+	PrintSuccess(fmt.Sprintf("Created order group: %s", result.OrderGroup.GroupID))
+	return result.OrderGroup.GroupID, nil
+}
+
+// printOrderGroupStatus prints how much of an order group's limit has filled
+func printOrderGroupStatus(ctx context.Context, client *api.Client, groupID string) {
+	if groupID == "" {
+		return
+	}
+
+	result, err := client.GetOrderGroup(ctx, groupID)
+	if err != nil {
+		PrintWarning(fmt.Sprintf("Could not get order group %s: %v", groupID, err))
+		return
+	}
+	g := result.OrderGroup
+	fmt.Fprintf(ui.Writer(), "Order group %s: %s, %d/%d contracts filled across %d orders\n",
+		g.GroupID, g.Status, g.FilledCount, g.Limit, g.OrderCount)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import "testing"
+
+func TestOrderGroupFlags(t *testing.T) {
+	defer func() { orderGroupSpec, orderGroupSpecLimit = "", 0 }()
+
+	tests := []struct {
+		spec        string
+		limit       int
+		wantErr     bool
+		wantPreview string
+	}{
+		{spec: "", wantPreview: ""},
+		{spec: "new", wantPreview: "new, limit 30 contracts"},
+		{spec: "new", limit: 10, wantPreview: "new, limit 10 contracts"},
+		{spec: "og-123", wantPreview: "og-123"},
+		{spec: "og-123", limit: 10, wantErr: true},
+		{spec: "", limit: 10, wantErr: true},
+		{spec: "new", limit: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		orderGroupSpec, orderGroupSpecLimit = tt.spec, tt.limit
+		if err := validateOrderGroupFlags(); (err != nil) != tt.wantErr {
+			t.Errorf("validateOrderGroupFlags(%q, %d) error = %v, wantErr %v", tt.spec, tt.limit, err, tt.wantErr)
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got := orderGroupPreview(30); got != tt.wantPreview {
+			t.Errorf("orderGroupPreview(%q, %d) = %q, want %q", tt.spec, tt.limit, got, tt.wantPreview)
+		}
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,8 +19,9 @@ import (
 )
 
 var ordersCmd = &cobra.Command{
-	Use:   "orders",
-	Short: "Manage trading orders",
+	Use:     "orders",
+	Aliases: []string{"o"},
+	Short:   "Manage trading orders",
 	Long: `Manage trading orders on the Kalshi exchange.
 
 Commands for listing, creating, canceling, and amending orders.`,
@@ -53,8 +57,14 @@ var ordersCreateCmd = &cobra.Command{
 The order preview will be shown before submission. You must confirm
 unless the --yes flag is set.
 
-Price must be between 1-99 cents.`,
+Price must be between 1-99 cents.
+
+With --interactive, a form asks for each field instead: pick the market by
+ticker or search, choose side and action, then enter the price with the
+current book shown, and the quantity. Flags given alongside become the
+form's defaults. The usual preview and confirmation follow.`,
 	Example: `  kalshi-cli orders create --market INXD-25FEB07-B5523.99 --side yes --qty 10 --price 50
+  kalshi-cli orders create --interactive
   kalshi-cli orders create --market INXD-25FEB07-B5523.99 --side no --qty 5 --price 30 --action sell
   kalshi-cli orders create --market INXD-25FEB07-B5523.99 --side yes --qty 10 --price 50 --yes`,
 	RunE: runOrdersCreate,
@@ -97,7 +107,12 @@ The JSON file should contain an array of order objects with fields:
 - type: "limit" or "market" (required)
 - count: Quantity (required)
 - yes_price: Price in cents for yes side (optional)
-- no_price: Price in cents for no side (optional)`,
+- no_price: Price in cents for no side (optional)
+- subaccount_id: Subaccount number (optional, defaults to --subaccount)
+
+Use --file - to read the orders from stdin, e.g. from orders export. The
+confirmation prompt cannot be answered then, so --yes is required.
+Orders are submitted in batches of up to 20.`,
 	RunE: runOrdersBatchCreate,
 }
 
@@ -113,6 +128,7 @@ var ordersQueueCmd = &cobra.Command{
 var (
 	orderStatusFilter   string
 	orderMarketFilter   string
+	orderListCursor     string
 	orderCreateMarket   string
 	orderCancelAllMarket string
 	orderSide           string
@@ -140,18 +156,18 @@ func init() {
 	// List flags
 	ordersListCmd.Flags().StringVar(&orderStatusFilter, "status", "", "filter by status (resting, canceled, executed, pending)")
 	ordersListCmd.Flags().StringVar(&orderMarketFilter, "market", "", "filter by market ticker")
+	ordersListCmd.Flags().StringVar(&orderListCursor, "cursor", "", "pagination cursor")
 
 	// Create flags
-	ordersCreateCmd.Flags().StringVar(&orderCreateMarket, "market", "", "market ticker (required)")
-	ordersCreateCmd.Flags().StringVar(&orderSide, "side", "", "order side: yes or no (required)")
-	ordersCreateCmd.Flags().IntVar(&orderCreateQty, "qty", 0, "quantity (required)")
-	ordersCreateCmd.Flags().IntVar(&orderCreatePrice, "price", 0, "price in cents 1-99 (required)")
+	ordersCreateCmd.Flags().StringVar(&orderCreateMarket, "market", "", "market ticker (required unless --interactive)")
+	ordersCreateCmd.Flags().StringVar(&orderSide, "side", "", "order side: yes or no (required unless --interactive)")
+	ordersCreateCmd.Flags().IntVar(&orderCreateQty, "qty", 0, "quantity (required unless --interactive)")
+	ordersCreateCmd.Flags().IntVar(&orderCreatePrice, "price", 0, "price in cents 1-99 (required unless --interactive)")
 	ordersCreateCmd.Flags().StringVar(&orderAction, "action", "buy", "order action: buy or sell (default: buy)")
 	ordersCreateCmd.Flags().StringVar(&orderType, "type", "limit", "order type: limit or market (default: limit)")
-	ordersCreateCmd.MarkFlagRequired("market")
-	ordersCreateCmd.MarkFlagRequired("side")
-	ordersCreateCmd.MarkFlagRequired("qty")
-	ordersCreateCmd.MarkFlagRequired("price")
+	addOrderGroupFlags(ordersCreateCmd)
+	addPrecheckFlag(ordersCreateCmd)
+	addCopyFlag(ordersCreateCmd, "order ID")
 
 	// Cancel all flags
 	ordersCancelAllCmd.Flags().StringVar(&orderCancelAllMarket, "market", "", "filter by market ticker")
@@ -161,14 +177,19 @@ func init() {
 	ordersAmendCmd.Flags().IntVar(&orderAmendPrice, "price", 0, "new price in cents")
 
 	// Batch create flags
-	ordersBatchCreateCmd.Flags().StringVar(&batchFile, "file", "", "path to JSON file containing orders (required)")
+	ordersBatchCreateCmd.Flags().StringVar(&batchFile, "file", "", "path to JSON file containing orders, or - for stdin (required)")
 	ordersBatchCreateCmd.MarkFlagRequired("file")
+	addOrderGroupFlags(ordersBatchCreateCmd)
+	addPrecheckFlag(ordersBatchCreateCmd)
 }
 
 // createAPIClient is defined in helpers.go
 
 func getEnvironmentLabel() string {
 	cfg := GetConfig()
+	if paperMode {
+		return ui.DemoStyle.Render(" PAPER ")
+	}
 	if cfg.API.Production {
 		return ui.ProdStyle.Render(" PRODUCTION ")
 	}
@@ -192,6 +213,12 @@ func runOrdersList(cmd *cobra.Command, args []string) error {
 	if orderMarketFilter != "" {
 		params["ticker"] = orderMarketFilter
 	}
+	if orderListCursor != "" {
+		params["cursor"] = orderListCursor
+	}
+	if sub := ActiveSubaccount(); sub > 0 {
+		params["subaccount_id"] = strconv.Itoa(sub)
+	}
 
 	var response models.OrdersResponse
 	path := "/trade-api/v2/portfolio/orders"
@@ -202,11 +229,18 @@ func runOrdersList(cmd *cobra.Command, args []string) error {
 	if err := client.GetJSON(ctx, path, &response); err != nil {
 		return fmt.Errorf("failed to list orders: %w", err)
 	}
+	orders := response.Orders
+	sortIfDeterministic(orders, func(i, j int) bool {
+		if !orders[i].CreatedTime.Equal(orders[j].CreatedTime) {
+			return orders[i].CreatedTime.Before(orders[j].CreatedTime)
+		}
+		return orders[i].OrderID < orders[j].OrderID
+	})
 
 	return ui.Output(
 		GetOutputFormat(),
 		func() { renderOrdersTable(response.Orders) },
-		response.Orders,
+		listJSON(response.Orders, response.Orders, response.Cursor),
 		func() { renderOrdersPlain(response.Orders) },
 	)
 }
@@ -238,6 +272,14 @@ func runOrdersGet(cmd *cobra.Command, args []string) error {
 }
 
 func runOrdersCreate(cmd *cobra.Command, args []string) error {
+	if orderCreateInteractive {
+		if err := runOrderForm(); err != nil {
+			return err
+		}
+	} else if err := requireOrderCreateFlags(cmd); err != nil {
+		return err
+	}
+
 	// Validate price range
 	if orderCreatePrice < 1 || orderCreatePrice > 99 {
 		return fmt.Errorf("price must be between 1 and 99 cents, got %d", orderCreatePrice)
@@ -266,6 +308,10 @@ func runOrdersCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("quantity must be positive, got %d", orderCreateQty)
 	}
 
+	if err := validateOrderGroupFlags(); err != nil {
+		return err
+	}
+
 	// Build order request
 	orderReq := models.CreateOrderRequest{
 		Ticker: orderCreateMarket,
@@ -273,6 +319,7 @@ func runOrdersCreate(cmd *cobra.Command, args []string) error {
 		Action: models.OrderAction(action),
 		Type:   models.OrderType(oType),
 		Count:  orderCreateQty,
+		SubaccountID: ActiveSubaccount(),
 	}
 
 	if side == "yes" {
@@ -282,28 +329,34 @@ func runOrdersCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show order preview
-	fmt.Println()
-	fmt.Println(ui.HeaderStyle.Render("Order Preview"))
-	fmt.Println()
-	fmt.Printf("  Environment:  %s\n", getEnvironmentLabel())
-	fmt.Printf("  Market:       %s\n", orderReq.Ticker)
-	fmt.Printf("  Side:         %s\n", strings.ToUpper(side))
-	fmt.Printf("  Action:       %s\n", strings.ToUpper(action))
-	fmt.Printf("  Type:         %s\n", strings.ToUpper(oType))
-	fmt.Printf("  Quantity:     %d contracts\n", orderReq.Count)
-	fmt.Printf("  Price:        %d cents\n", orderCreatePrice)
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintln(ui.Terminal(), ui.HeaderStyle.Render("Order Preview"))
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintf(ui.Terminal(), "  Environment:  %s\n", getEnvironmentLabel())
+	if orderReq.SubaccountID > 0 {
+		fmt.Fprintf(ui.Terminal(), "  Subaccount:   %d\n", orderReq.SubaccountID)
+	}
+	fmt.Fprintf(ui.Terminal(), "  Market:       %s\n", orderReq.Ticker)
+	fmt.Fprintf(ui.Terminal(), "  Side:         %s\n", strings.ToUpper(side))
+	fmt.Fprintf(ui.Terminal(), "  Action:       %s\n", strings.ToUpper(action))
+	fmt.Fprintf(ui.Terminal(), "  Type:         %s\n", strings.ToUpper(oType))
+	fmt.Fprintf(ui.Terminal(), "  Quantity:     %d contracts\n", orderReq.Count)
+	fmt.Fprintf(ui.Terminal(), "  Price:        %d cents\n", orderCreatePrice)
+	if group := orderGroupPreview(orderCreateQty); group != "" {
+		fmt.Fprintf(ui.Terminal(), "  Order Group:  %s\n", group)
+	}
 
 	// Calculate potential cost/payout
 	potentialCost := orderCreateQty * orderCreatePrice
 	potentialPayout := orderCreateQty * 100
 
 	if action == "buy" {
-		fmt.Printf("  Max Cost:     %s\n", ui.FormatPrice(potentialCost))
-		fmt.Printf("  Max Payout:   %s\n", ui.FormatPrice(potentialPayout))
+		fmt.Fprintf(ui.Terminal(), "  Max Cost:     %s\n", ui.FormatPrice(potentialCost))
+		fmt.Fprintf(ui.Terminal(), "  Max Payout:   %s\n", ui.FormatPrice(potentialPayout))
 	} else {
-		fmt.Printf("  Max Credit:   %s\n", ui.FormatPrice(potentialCost))
+		fmt.Fprintf(ui.Terminal(), "  Max Credit:   %s\n", ui.FormatPrice(potentialCost))
 	}
-	fmt.Println()
+	fmt.Fprintln(ui.Terminal())
 
 	// Confirm unless --yes flag
 	cfg := GetConfig()
@@ -312,7 +365,15 @@ func runOrdersCreate(cmd *cobra.Command, args []string) error {
 		envWarning = " (PRODUCTION - real money)"
 	}
 
-	if !confirmAction(fmt.Sprintf("Submit this order%s?", envWarning)) {
+	if err := runPrecheck([]models.CreateOrderRequest{orderReq}); err != nil {
+		return err
+	}
+
+	confirmed, err := confirmAction(fmt.Sprintf("Submit this order%s?", envWarning))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		PrintWarning("Order cancelled")
 		return nil
 	}
@@ -326,17 +387,26 @@ func runOrdersCreate(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	orderReq.OrderGroupID, err = resolveOrderGroup(ctx, client, orderCreateQty)
+	if err != nil {
+		return err
+	}
+
 	var response models.CreateOrderResponse
 	if err := client.PostJSON(ctx, "/trade-api/v2/portfolio/orders", orderReq, &response); err != nil {
 		return fmt.Errorf("failed to create order: %w", err)
 	}
 
 	PrintSuccess("Order created successfully!")
-	fmt.Printf("Order ID: %s\n", response.Order.OrderID)
+	fmt.Fprintf(ui.Writer(), "Order ID: %s\n", response.Order.OrderID)
+	copyValue("order ID", response.Order.OrderID)
 
 	return ui.Output(
 		GetOutputFormat(),
-		func() { renderOrderDetails(response.Order) },
+		func() {
+			renderOrderDetails(response.Order)
+			printOrderGroupStatus(ctx, client, orderReq.OrderGroupID)
+		},
 		response.Order,
 		func() { renderOrderPlain(response.Order) },
 	)
@@ -345,7 +415,11 @@ func runOrdersCreate(cmd *cobra.Command, args []string) error {
 func runOrdersCancel(cmd *cobra.Command, args []string) error {
 	orderID := args[0]
 
-	if !confirmAction(fmt.Sprintf("Cancel order %s?", orderID)) {
+	confirmed, err := confirmAction(fmt.Sprintf("Cancel order %s?", orderID))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		PrintWarning("Cancellation aborted")
 		return nil
 	}
@@ -383,7 +457,11 @@ func runOrdersCancelAll(cmd *cobra.Command, args []string) error {
 		prompt = fmt.Sprintf("Cancel all resting orders for market %s?", ticker)
 	}
 
-	if !confirmAction(prompt) {
+	confirmed, err := confirmAction(prompt)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		PrintWarning("Cancellation aborted")
 		return nil
 	}
@@ -437,20 +515,24 @@ func runOrdersAmend(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show amendment preview
-	fmt.Println()
-	fmt.Println(ui.HeaderStyle.Render("Amend Order Preview"))
-	fmt.Println()
-	fmt.Printf("  Environment:  %s\n", getEnvironmentLabel())
-	fmt.Printf("  Order ID:     %s\n", orderID)
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintln(ui.Terminal(), ui.HeaderStyle.Render("Amend Order Preview"))
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintf(ui.Terminal(), "  Environment:  %s\n", getEnvironmentLabel())
+	fmt.Fprintf(ui.Terminal(), "  Order ID:     %s\n", orderID)
 	if orderAmendQty > 0 {
-		fmt.Printf("  New Quantity: %d contracts\n", orderAmendQty)
+		fmt.Fprintf(ui.Terminal(), "  New Quantity: %d contracts\n", orderAmendQty)
 	}
 	if orderAmendPrice > 0 {
-		fmt.Printf("  New Price:    %d cents\n", orderAmendPrice)
+		fmt.Fprintf(ui.Terminal(), "  New Price:    %d cents\n", orderAmendPrice)
 	}
-	fmt.Println()
+	fmt.Fprintln(ui.Terminal())
 
-	if !confirmAction("Amend this order?") {
+	confirmed, err := confirmAction("Amend this order?")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		PrintWarning("Amendment cancelled")
 		return nil
 	}
@@ -481,10 +563,9 @@ func runOrdersAmend(cmd *cobra.Command, args []string) error {
 }
 
 func runOrdersBatchCreate(cmd *cobra.Command, args []string) error {
-	// Read and parse the JSON file
-	data, err := os.ReadFile(batchFile)
+	data, err := readBatchFile(batchFile)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return err
 	}
 
 	var orders []models.CreateOrderRequest
@@ -496,8 +577,21 @@ func runOrdersBatchCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no orders found in file")
 	}
 
+	// Orders without an explicit subaccount_id use the active subaccount
+	for i := range orders {
+		if orders[i].SubaccountID == 0 {
+			orders[i].SubaccountID = ActiveSubaccount()
+		}
+	}
+
+	if err := validateOrderGroupFlags(); err != nil {
+		return err
+	}
+
 	// Validate all orders
+	totalContracts := 0
 	for i, order := range orders {
+		totalContracts += order.Count
 		if order.Ticker == "" {
 			return fmt.Errorf("order %d: ticker is required", i+1)
 		}
@@ -516,12 +610,15 @@ func runOrdersBatchCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show preview
-	fmt.Println()
-	fmt.Println(ui.HeaderStyle.Render("Batch Order Preview"))
-	fmt.Println()
-	fmt.Printf("  Environment:  %s\n", getEnvironmentLabel())
-	fmt.Printf("  Total Orders: %d\n", len(orders))
-	fmt.Println()
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintln(ui.Terminal(), ui.HeaderStyle.Render("Batch Order Preview"))
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintf(ui.Terminal(), "  Environment:  %s\n", getEnvironmentLabel())
+	fmt.Fprintf(ui.Terminal(), "  Total Orders: %d\n", len(orders))
+	if group := orderGroupPreview(totalContracts); group != "" {
+		fmt.Fprintf(ui.Terminal(), "  Order Group:  %s\n", group)
+	}
+	fmt.Fprintln(ui.Terminal())
 
 	// Show each order
 	for i, order := range orders {
@@ -529,7 +626,7 @@ func runOrdersBatchCreate(cmd *cobra.Command, args []string) error {
 		if order.Side == models.OrderSideNo {
 			price = order.NoPrice
 		}
-		fmt.Printf("  %d. %s %s %s @ %d cents x %d\n",
+		fmt.Fprintf(ui.Terminal(), "  %d. %s %s %s @ %d cents x %d\n",
 			i+1,
 			strings.ToUpper(string(order.Action)),
 			strings.ToUpper(string(order.Side)),
@@ -538,7 +635,7 @@ func runOrdersBatchCreate(cmd *cobra.Command, args []string) error {
 			order.Count,
 		)
 	}
-	fmt.Println()
+	fmt.Fprintln(ui.Terminal())
 
 	cfg := GetConfig()
 	envWarning := ""
@@ -546,7 +643,15 @@ func runOrdersBatchCreate(cmd *cobra.Command, args []string) error {
 		envWarning = " (PRODUCTION - real money)"
 	}
 
-	if !confirmAction(fmt.Sprintf("Submit %d orders%s?", len(orders), envWarning)) {
+	if err := runPrecheck(orders); err != nil {
+		return err
+	}
+
+	confirmed, err := confirmAction(fmt.Sprintf("Submit %d orders%s?", len(orders), envWarning))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		PrintWarning("Batch order cancelled")
 		return nil
 	}
@@ -559,23 +664,65 @@ func runOrdersBatchCreate(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	batchReq := models.BatchCreateOrdersRequest{Orders: orders}
-	var response models.BatchCreateOrdersResponse
+	// Orders without an explicit order_group_id join --order-group
+	groupID, err := resolveOrderGroup(ctx, client, totalContracts)
+	if err != nil {
+		return err
+	}
+	for i := range orders {
+		if orders[i].OrderGroupID == "" {
+			orders[i].OrderGroupID = groupID
+		}
+	}
 
-	if err := client.PostJSON(ctx, "/trade-api/v2/portfolio/orders/batched", batchReq, &response); err != nil {
-		return fmt.Errorf("failed to create batch orders: %w", err)
+	var created []models.Order
+	presignRequests(ctx, client, http.MethodPost, batchedOrdersPath, (len(orders)+ladderBatchSize-1)/ladderBatchSize)
+	for start := 0; start < len(orders); start += ladderBatchSize {
+		end := min(start+ladderBatchSize, len(orders))
+		batchReq := models.BatchCreateOrdersRequest{Orders: orders[start:end]}
+		var response models.BatchCreateOrdersResponse
+		if err := client.PostJSON(ctx, batchedOrdersPath, batchReq, &response); err != nil {
+			if len(created) > 0 {
+				PrintWarning(fmt.Sprintf("%d of %d orders were placed before the failure", len(created), len(orders)))
+			}
+			return fmt.Errorf("failed to create batch orders: %w", err)
+		}
+		created = append(created, response.Orders...)
 	}
 
-	PrintSuccess(fmt.Sprintf("Created %d orders successfully!", len(response.Orders)))
+	PrintSuccess(fmt.Sprintf("Created %d orders successfully!", len(created)))
 
 	return ui.Output(
 		GetOutputFormat(),
-		func() { renderOrdersTable(response.Orders) },
-		response.Orders,
-		func() { renderOrdersPlain(response.Orders) },
+		func() {
+			renderOrdersTable(created)
+			printOrderGroupStatus(ctx, client, groupID)
+		},
+		created,
+		func() { renderOrdersPlain(created) },
 	)
 }
 
+// readBatchFile reads the batch-create order file, or stdin for "-". Reading
+// stdin leaves nothing to answer the confirmation prompt, so --yes is required.
+func readBatchFile(path string) ([]byte, error) {
+	if path != "-" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		return data, nil
+	}
+	if !yesFlag {
+		return nil, fmt.Errorf("--file - reads orders from stdin, so --yes is required to confirm")
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return data, nil
+}
+
 func runOrdersQueue(cmd *cobra.Command, args []string) error {
 	orderID := args[0]
 
@@ -603,16 +750,16 @@ func runOrdersQueue(cmd *cobra.Command, args []string) error {
 	return ui.Output(
 		GetOutputFormat(),
 		func() {
-			fmt.Println()
-			fmt.Println(ui.HeaderStyle.Render("Queue Position"))
-			fmt.Println()
-			fmt.Printf("  Order ID:  %s\n", position.OrderID)
-			fmt.Printf("  Position:  %d\n", position.QueuePosition)
-			fmt.Println()
+			fmt.Fprintln(ui.Writer())
+			fmt.Fprintln(ui.Writer(), ui.HeaderStyle.Render("Queue Position"))
+			fmt.Fprintln(ui.Writer())
+			fmt.Fprintf(ui.Writer(), "  Order ID:  %s\n", position.OrderID)
+			fmt.Fprintf(ui.Writer(), "  Position:  %d\n", position.QueuePosition)
+			fmt.Fprintln(ui.Writer())
 		},
 		position,
 		func() {
-			fmt.Printf("%s\t%d\n", position.OrderID, position.QueuePosition)
+			fmt.Fprintf(ui.Writer(), "%s\t%d\n", position.OrderID, position.QueuePosition)
 		},
 	)
 }
@@ -621,7 +768,7 @@ func runOrdersQueue(cmd *cobra.Command, args []string) error {
 
 func renderOrdersTable(orders []models.Order) {
 	if len(orders) == 0 {
-		fmt.Println("No orders found")
+		fmt.Fprintln(ui.Writer(), "No orders found")
 		return
 	}
 
@@ -654,7 +801,7 @@ func renderOrdersPlain(orders []models.Order) {
 		if order.Side == models.OrderSideNo {
 			price = order.NoPrice
 		}
-		fmt.Printf("%s\t%s\t%s\t%d\t%d\t%s\n",
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%d\t%d\t%s\n",
 			order.OrderID,
 			order.Ticker,
 			order.Side,
@@ -706,9 +853,9 @@ func renderOrderDetails(order models.Order) {
 		pairs = append(pairs, []string{"Order Group ID", order.OrderGroupID})
 	}
 
-	fmt.Println()
+	fmt.Fprintln(ui.Writer())
 	ui.RenderKeyValue(pairs)
-	fmt.Println()
+	fmt.Fprintln(ui.Writer())
 }
 
 func renderOrderPlain(order models.Order) {
@@ -716,7 +863,7 @@ func renderOrderPlain(order models.Order) {
 	if order.Side == models.OrderSideNo {
 		price = order.NoPrice
 	}
-	fmt.Printf("%s\t%s\t%s\t%s\t%d\t%d/%d\t%s\n",
+	fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%s\t%d\t%d/%d\t%s\n",
 		order.OrderID,
 		order.Ticker,
 		order.Side,
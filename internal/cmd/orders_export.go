@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var ordersExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export orders as JSON that batch-create can replay",
+	Long: `Write orders to stdout as JSON, following every page of results.
+
+With --format batch-json (the default) each order with contracts remaining
+becomes a batch-create entry for its remaining count at the same side,
+action, type, price and expiration. Order IDs, client order IDs and order
+group IDs are left out, so the file can be replayed after cancel-all or
+against another environment; pass --order-group to batch-create to group
+the recreated orders.
+
+With --format json the orders are written as the API returns them.`,
+	Example: `  kalshi-cli orders export > book.json
+  kalshi-cli orders export --market KXBTC-26FEB12-B97000 --format json
+  kalshi-cli orders export | kalshi-cli --prod orders batch-create --file - --yes`,
+	RunE: runOrdersExport,
+}
+
+var (
+	exportStatus string
+	exportMarket string
+	exportFormat string
+)
+
+// Export formats
+const (
+	exportFormatBatchJSON = "batch-json"
+	exportFormatJSON      = "json"
+)
+
+func init() {
+	ordersCmd.AddCommand(ordersExportCmd)
+
+	ordersExportCmd.Flags().StringVar(&exportStatus, "status", string(models.OrderStatusResting), "filter by status (resting, canceled, executed, pending)")
+	ordersExportCmd.Flags().StringVar(&exportMarket, "market", "", "filter by market ticker")
+	ordersExportCmd.Flags().StringVar(&exportFormat, "format", exportFormatBatchJSON, "output format: batch-json or json")
+}
+
+// fetchAllOrders follows the order list cursor until every page is read
+func fetchAllOrders(ctx context.Context, client *api.Client, opts api.OrdersOptions) ([]models.Order, error) {
+	var orders []models.Order
+	progress := ui.NewProgress("orders", 0)
+	defer progress.Done()
+	for {
+		page, err := client.GetOrders(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, page.Orders...)
+		progress.Page(len(page.Orders), page.Cursor)
+		if page.Cursor == "" || len(page.Orders) == 0 {
+			return orders, nil
+		}
+		opts.Cursor = page.Cursor
+	}
+}
+
+// exportOrderRequests turns orders into batch-create entries for their
+// remaining contracts. Orders with nothing remaining are skipped.
+func exportOrderRequests(orders []models.Order) []models.CreateOrderRequest {
+	requests := make([]models.CreateOrderRequest, 0, len(orders))
+	for _, o := range orders {
+		if o.RemainingCount <= 0 {
+			continue
+		}
+		req := models.CreateOrderRequest{
+			Ticker: o.Ticker,
+			Side:   o.Side,
+			Action: o.Action,
+			Type:   o.Type,
+			Count:  o.RemainingCount,
+		}
+		if o.Side == models.OrderSideNo {
+			req.NoPrice = o.NoPrice
+		} else {
+			req.YesPrice = o.YesPrice
+		}
+		if o.ExpirationTime != nil {
+			req.ExpirationTs = o.ExpirationTime.Unix()
+		}
+		requests = append(requests, req)
+	}
+	return requests
+}
+
+func runOrdersExport(cmd *cobra.Command, args []string) error {
+	if exportFormat != exportFormatBatchJSON && exportFormat != exportFormatJSON {
+		return fmt.Errorf("invalid --format %q: use %s or %s", exportFormat, exportFormatBatchJSON, exportFormatJSON)
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := withTimeout(context.Background())
+	defer cancel()
+
+	opts := api.OrdersOptions{
+		Ticker:       exportMarket,
+		Status:       exportStatus,
+		Limit:        reconcilePageSize,
+		SubaccountID: ActiveSubaccount(),
+	}
+	orders, err := fetchAllOrders(ctx, client, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	var out any = orders
+	if exportFormat == exportFormatBatchJSON {
+		out = exportOrderRequests(orders)
+	} else if orders == nil {
+		out = []models.Order{}
+	}
+
+	enc := json.NewEncoder(ui.Writer())
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
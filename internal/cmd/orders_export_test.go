@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestExportOrderRequests(t *testing.T) {
+	expires := time.Unix(1767225600, 0)
+	orders := []models.Order{
+		{OrderID: "o1", Ticker: "MKT", Side: models.OrderSideYes, Action: models.OrderActionBuy, Type: models.OrderTypeLimit,
+			YesPrice: 40, NoPrice: 60, InitialCount: 10, RemainingCount: 7, ClientOrderID: "c1", OrderGroupID: "g1"},
+		{OrderID: "o2", Ticker: "MKT", Side: models.OrderSideNo, Action: models.OrderActionSell, Type: models.OrderTypeLimit,
+			YesPrice: 45, NoPrice: 55, RemainingCount: 3, ExpirationTime: &expires},
+		{OrderID: "o3", Ticker: "MKT", Side: models.OrderSideYes, Action: models.OrderActionBuy, Type: models.OrderTypeLimit,
+			YesPrice: 30, RemainingCount: 0},
+	}
+
+	got := exportOrderRequests(orders)
+	want := []models.CreateOrderRequest{
+		{Ticker: "MKT", Side: models.OrderSideYes, Action: models.OrderActionBuy, Type: models.OrderTypeLimit, Count: 7, YesPrice: 40},
+		{Ticker: "MKT", Side: models.OrderSideNo, Action: models.OrderActionSell, Type: models.OrderTypeLimit, Count: 3, NoPrice: 55, ExpirationTs: 1767225600},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("exportOrderRequests() returned %d orders, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("order %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFetchAllOrders_FollowsCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("status"); got != "resting" {
+			t.Errorf("status = %q, want resting", got)
+		}
+		cursor := r.URL.Query().Get("cursor")
+		next := ""
+		if cursor == "" {
+			next = "page2"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.OrdersResponse{
+			Orders: []models.Order{{OrderID: fmt.Sprintf("order-%s", cursor)}},
+			Cursor: next,
+		})
+	}))
+	defer server.Close()
+
+	client := newCmdTestClient(t, server.URL)
+	orders, err := fetchAllOrders(context.Background(), client, api.OrdersOptions{Status: "resting"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orders) != 2 || orders[1].OrderID != "order-page2" {
+		t.Errorf("fetchAllOrders() = %+v, want two pages", orders)
+	}
+}
+
+func TestReadBatchFile_StdinRequiresYes(t *testing.T) {
+	old := yesFlag
+	defer func() { yesFlag = old }()
+	yesFlag = false
+
+	if _, err := readBatchFile("-"); err == nil {
+		t.Error("readBatchFile(\"-\") without --yes should fail")
+	}
+}
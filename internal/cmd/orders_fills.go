@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var ordersFillsCmd = &cobra.Command{
+	Use:   "fills [order-id]",
+	Short: "Show the fills behind an order",
+	Long: `Attribute fills to the orders that produced them.
+
+With an order ID, every partial fill of that order is listed with its price,
+time, taker/maker role and fee, plus the running average fill price.
+
+With --all, fills within --since are grouped by order and summarized: fill
+count, filled quantity, average price, taker/maker split and total fees.`,
+	Example: `  kalshi-cli orders fills abc123-def456-ghi789
+  kalshi-cli orders fills --all --since 7d
+  kalshi-cli orders fills --all --market INXD-25FEB07-B5523.99 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOrdersFills,
+}
+
+var (
+	orderFillsAll    bool
+	orderFillsMarket string
+	orderFillsSince  string
+)
+
+func init() {
+	ordersCmd.AddCommand(ordersFillsCmd)
+
+	ordersFillsCmd.Flags().BoolVar(&orderFillsAll, "all", false, "summarize fills for every order instead of one")
+	ordersFillsCmd.Flags().StringVar(&orderFillsMarket, "market", "", "with --all, only include this market")
+	ordersFillsCmd.Flags().StringVar(&orderFillsSince, "since", "7d", "with --all, only include fills at or after this time (e.g. 7d, yesterday)")
+	addConcurrencyFlag(ordersFillsCmd)
+}
+
+// attributedFill is one partial fill with the order's running average price
+type attributedFill struct {
+	TradeID     string          `json:"trade_id"`
+	Time        time.Time       `json:"time"`
+	Price       int             `json:"price"`
+	Count       int             `json:"count"`
+	Role        string          `json:"role"`
+	Fee         models.SubCents `json:"fee"`
+	FilledCount int             `json:"filled_count"`
+	AvgPrice    float64         `json:"avg_price"`
+}
+
+// orderFills is every fill of one order. Prices are cents on the order's
+// side, fees are dollars. notional is the cost of the fills so far, kept in
+// whole cents so the running average does not drift.
+type orderFills struct {
+	OrderID    string           `json:"order_id"`
+	Ticker     string           `json:"ticker"`
+	Side       string           `json:"side"`
+	Action     string           `json:"action"`
+	Count      int              `json:"count"`
+	AvgPrice   float64          `json:"avg_price"`
+	TakerCount int              `json:"taker_count"`
+	MakerCount int              `json:"maker_count"`
+	Fees       models.SubCents  `json:"fees"`
+	First      time.Time        `json:"first_fill"`
+	Last       time.Time        `json:"last_fill"`
+	Fills      []attributedFill `json:"fills"`
+
+	notional models.Cents
+}
+
+func runOrdersFills(cmd *cobra.Command, args []string) error {
+	if orderFillsAll == (len(args) == 1) {
+		return fmt.Errorf("specify an order ID or --all")
+	}
+	if !orderFillsAll && (cmd.Flags().Changed("market") || cmd.Flags().Changed("since")) {
+		return fmt.Errorf("--market and --since only apply with --all")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if !orderFillsAll {
+		fills, err := allFills(ctx, client, api.FillsOptions{OrderID: args[0]})
+		if err != nil {
+			return fmt.Errorf("failed to get fills: %w", err)
+		}
+		if len(fills) == 0 {
+			PrintWarning("No fills found for this order")
+			return nil
+		}
+
+		order := attributeFills(fills)[0]
+		return ui.Output(
+			GetOutputFormat(),
+			func() { renderOrderFillsTable(order) },
+			order,
+			func() { renderOrderFillsPlain(order) },
+		)
+	}
+
+	since, err := parseSinceArg(orderFillsSince)
+	if err != nil {
+		return err
+	}
+	fills, err := allFills(ctx, client, api.FillsOptions{
+		Ticker: orderFillsMarket,
+		MinTS:  since.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get fills: %w", err)
+	}
+	if len(fills) == 0 {
+		PrintWarning("No fills found")
+		return nil
+	}
+
+	orders := attributeFills(fills)
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderOrderFillsSummary(orders) },
+		orders,
+		func() { renderOrderFillsSummaryPlain(orders) },
+	)
+}
+
+// attributeFills groups fills by order in chronological order and computes
+// running averages. Orders are returned most recently filled first.
+func attributeFills(fills []models.Fill) []orderFills {
+	sorted := make([]models.Fill, len(fills))
+	copy(sorted, fills)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedTime.Before(sorted[j].CreatedTime)
+	})
+
+	byOrder := make(map[string]*orderFills)
+	var orders []*orderFills
+	for _, f := range sorted {
+		o, ok := byOrder[f.OrderID]
+		if !ok {
+			o = &orderFills{OrderID: f.OrderID, Ticker: f.Ticker, Side: f.Side, Action: f.Action, First: f.CreatedTime}
+			byOrder[f.OrderID] = o
+			orders = append(orders, o)
+		}
+
+		price := fillPrice(f)
+		o.notional = o.notional.Add(models.Cents(price).Mul(f.Count))
+		o.Count += f.Count
+		o.AvgPrice = float64(o.notional) / float64(o.Count)
+		o.Last = f.CreatedTime
+
+		role := "maker"
+		if f.IsTaker {
+			role = "taker"
+			o.TakerCount += f.Count
+		} else {
+			o.MakerCount += f.Count
+		}
+
+		fee := f.Fee()
+		o.Fees += fee
+
+		o.Fills = append(o.Fills, attributedFill{
+			TradeID:     f.TradeID,
+			Time:        f.CreatedTime,
+			Price:       price,
+			Count:       f.Count,
+			Role:        role,
+			Fee:         fee,
+			FilledCount: o.Count,
+			AvgPrice:    o.AvgPrice,
+		})
+	}
+
+	result := make([]orderFills, len(orders))
+	for i, o := range orders {
+		result[i] = *o
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Last.After(result[j].Last)
+	})
+	return result
+}
+
+// fillPrice is the fill price on the side that was traded
+func fillPrice(f models.Fill) int {
+	if f.Side == "no" {
+		return f.NoPrice
+	}
+	return f.YesPrice
+}
+
+func renderOrderFillsTable(o orderFills) {
+	fmt.Fprintf(ui.Writer(), "Order %s  %s  %s %s\n\n", o.OrderID, o.Ticker, strings.ToUpper(o.Action), strings.ToUpper(o.Side))
+
+	headers := []string{"Time", "Trade ID", "Price", "Qty", "Role", "Fee", "Filled", "Avg Price"}
+	rows := make([][]string, 0, len(o.Fills))
+	for _, f := range o.Fills {
+		rows = append(rows, []string{
+			formatTimeStr(f.Time.Local()),
+			truncateID(f.TradeID, 12),
+			fmt.Sprintf("%d¢", f.Price),
+			strconv.Itoa(f.Count),
+			f.Role,
+			f.Fee.String(),
+			strconv.Itoa(f.FilledCount),
+			fmt.Sprintf("%.2f¢", f.AvgPrice),
+		})
+	}
+	ui.RenderTable(headers, rows)
+
+	fmt.Fprintln(ui.Writer())
+	ui.RenderKeyValue([][]string{
+		{"Filled Qty", strconv.Itoa(o.Count)},
+		{"Avg Price", fmt.Sprintf("%.2f¢", o.AvgPrice)},
+		{"Taker / Maker", fmt.Sprintf("%d / %d", o.TakerCount, o.MakerCount)},
+		{"Fees", o.Fees.String()},
+	})
+}
+
+func renderOrderFillsPlain(o orderFills) {
+	for _, f := range o.Fills {
+		ui.PrintPlain("%s\t%s\t%d\t%d\t%s\t%s\t%d\t%.2f",
+			f.Time.Format(time.RFC3339), f.TradeID, f.Price, f.Count, f.Role, f.Fee.FixedPoint(), f.FilledCount, f.AvgPrice)
+	}
+}
+
+func renderOrderFillsSummary(orders []orderFills) {
+	headers := []string{"Order ID", "Market", "Side", "Fills", "Qty", "Avg Price", "Taker/Maker", "Fees", "Last Fill"}
+	rows := make([][]string, 0, len(orders))
+	for _, o := range orders {
+		rows = append(rows, []string{
+			truncateOrderID(o.OrderID),
+			o.Ticker,
+			strings.ToUpper(o.Action + " " + o.Side),
+			strconv.Itoa(len(o.Fills)),
+			strconv.Itoa(o.Count),
+			fmt.Sprintf("%.2f¢", o.AvgPrice),
+			fmt.Sprintf("%d/%d", o.TakerCount, o.MakerCount),
+			o.Fees.String(),
+			formatTimeStr(o.Last.Local()),
+		})
+	}
+	ui.RenderTable(headers, rows)
+}
+
+func renderOrderFillsSummaryPlain(orders []orderFills) {
+	for _, o := range orders {
+		ui.PrintPlain("%s\t%s\t%s\t%s\t%d\t%d\t%.2f\t%s",
+			o.OrderID, o.Ticker, o.Action, o.Side, len(o.Fills), o.Count, o.AvgPrice, o.Fees.FixedPoint())
+	}
+}
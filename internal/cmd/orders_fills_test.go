@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestAttributeFills(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fills := []models.Fill{
+		{TradeID: "t2", OrderID: "A", Side: "yes", YesPrice: 50, Count: 30, IsTaker: true, FeeCost: "0.0200", CreatedTime: t0.Add(time.Minute)},
+		{TradeID: "t1", OrderID: "A", Side: "yes", YesPrice: 40, Count: 10, FeeCost: "0.0050", CreatedTime: t0},
+		{TradeID: "t3", OrderID: "B", Side: "no", NoPrice: 65, Count: 5, CreatedTime: t0.Add(2 * time.Minute)},
+	}
+
+	orders := attributeFills(fills)
+	if len(orders) != 2 {
+		t.Fatalf("attributeFills() returned %d orders, want 2", len(orders))
+	}
+	if orders[0].OrderID != "B" || orders[0].AvgPrice != 65 {
+		t.Errorf("most recent order = %+v, want B at 65", orders[0])
+	}
+
+	a := orders[1]
+	if a.Count != 40 || a.TakerCount != 30 || a.MakerCount != 10 {
+		t.Errorf("order A counts = %d (taker %d, maker %d), want 40 (30, 10)", a.Count, a.TakerCount, a.MakerCount)
+	}
+	if a.AvgPrice != 47.5 {
+		t.Errorf("order A AvgPrice = %v, want 47.5", a.AvgPrice)
+	}
+	if a.Fills[0].TradeID != "t1" || a.Fills[0].AvgPrice != 40 || a.Fills[1].FilledCount != 40 {
+		t.Errorf("order A fills = %+v, want t1 first with running averages", a.Fills)
+	}
+	if got := a.Fees.String(); got != "$0.025" {
+		t.Errorf("order A Fees = %q, want $0.025", got)
+	}
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var orderCreateInteractive bool
+
+// orderFormPicks is the most markets listed for one search
+const orderFormPicks = 15
+
+// orderFormDepth is the number of book levels shown next to the price prompt
+const orderFormDepth = 3
+
+func init() {
+	ordersCreateCmd.Flags().BoolVarP(&orderCreateInteractive, "interactive", "i", false, "fill in the order with a guided form; other flags become its defaults")
+}
+
+// orderCreateRequiredFlags must be set unless --interactive is used
+var orderCreateRequiredFlags = []string{"market", "side", "qty", "price"}
+
+// requireOrderCreateFlags reports missing required flags the way cobra does
+func requireOrderCreateFlags(cmd *cobra.Command) error {
+	var missing []string
+	for _, name := range orderCreateRequiredFlags {
+		if !cmd.Flags().Changed(name) {
+			missing = append(missing, strconv.Quote(name))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required flag(s) %s not set (or use --interactive)", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// orderForm asks for each field of an order on a terminal
+type orderForm struct {
+	in     *bufio.Reader
+	out    io.Writer
+	client *api.Client
+	ctx    context.Context
+}
+
+// orderFormResult is what the form collected
+type orderFormResult struct {
+	Market models.Market
+	Side   string
+	Action string
+	Price  int
+	Qty    int
+}
+
+// ask prints a prompt with its default and returns the trimmed answer, or
+// the default for an empty answer
+func (f *orderForm) ask(prompt, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(f.out, "%s [%s]: ", prompt, def)
+	} else {
+		fmt.Fprintf(f.out, "%s: ", prompt)
+	}
+	line, err := f.in.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("order form cancelled: %w", err)
+	}
+	if line == "" {
+		return def, nil
+	}
+	return line, nil
+}
+
+// choose asks until the answer is one of options, matched by prefix
+func (f *orderForm) choose(prompt string, options []string, def string) (string, error) {
+	for {
+		answer, err := f.ask(fmt.Sprintf("%s (%s)", prompt, strings.Join(options, "/")), def)
+		if err != nil {
+			return "", err
+		}
+		answer = strings.ToLower(answer)
+		for _, o := range options {
+			if answer != "" && strings.HasPrefix(o, answer) {
+				return o, nil
+			}
+		}
+		fmt.Fprintf(f.out, "  Choose one of: %s\n", strings.Join(options, ", "))
+	}
+}
+
+// number asks until the answer is an integer in [lo, hi]
+func (f *orderForm) number(prompt string, def, lo, hi int) (int, error) {
+	defText := ""
+	if def >= lo && def <= hi {
+		defText = strconv.Itoa(def)
+	}
+	for {
+		answer, err := f.ask(prompt, defText)
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= lo && n <= hi {
+			return n, nil
+		}
+		fmt.Fprintf(f.out, "  Enter a whole number from %d to %d\n", lo, hi)
+	}
+}
+
+// searchMarkets finds open markets for a query: the markets of an event or
+// series with that ticker, or else open markets whose ticker or title
+// contains it
+func (f *orderForm) searchMarkets(query string) ([]models.Market, error) {
+	upper := strings.ToUpper(query)
+	for _, params := range []api.ListMarketsParams{
+		{EventTicker: upper, Limit: candidateLimit},
+		{SeriesTicker: upper, Status: "open", Limit: candidateLimit},
+	} {
+		resp, err := f.client.ListMarkets(f.ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Markets) > 0 {
+			return resp.Markets, nil
+		}
+	}
+
+	resp, err := f.client.ListMarkets(f.ctx, api.ListMarketsParams{Status: "open", Limit: candidateLimit})
+	if err != nil {
+		return nil, err
+	}
+	lower := strings.ToLower(query)
+	var matches []models.Market
+	for _, m := range resp.Markets {
+		if strings.Contains(strings.ToLower(m.Ticker+" "+m.Title+" "+m.Subtitle), lower) {
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+// pickMarket asks for a ticker or search text until it names one market
+func (f *orderForm) pickMarket(def string) (models.Market, error) {
+	for {
+		query, err := f.ask("Market (ticker, event, series or search text)", def)
+		if err != nil {
+			return models.Market{}, err
+		}
+		def = ""
+		if query == "" {
+			continue
+		}
+
+		if ticker, err := normalizeTicker(query); err == nil {
+			if m, err := f.client.GetMarket(f.ctx, ticker); err == nil {
+				return *m, nil
+			} else if !api.IsNotFound(err) {
+				return models.Market{}, fmt.Errorf("failed to get market: %w", err)
+			}
+		}
+
+		matches, err := f.searchMarkets(query)
+		if err != nil {
+			return models.Market{}, fmt.Errorf("failed to search markets: %w", err)
+		}
+		if len(matches) == 0 {
+			fmt.Fprintf(f.out, "  No markets match %q\n", query)
+			continue
+		}
+		if len(matches) == 1 {
+			fmt.Fprintf(f.out, "  %s  %s\n", matches[0].Ticker, matches[0].Title)
+			return matches[0], nil
+		}
+
+		shown := matches[:min(len(matches), orderFormPicks)]
+		for i, m := range shown {
+			title := m.Subtitle
+			if title == "" {
+				title = m.Title
+			}
+			fmt.Fprintf(f.out, "  %2d. %-32s %s\n", i+1, m.Ticker, truncateMarketString(title, 50))
+		}
+		if len(matches) > len(shown) {
+			fmt.Fprintf(f.out, "  ... %d more; refine the search to narrow it down\n", len(matches)-len(shown))
+		}
+
+		answer, err := f.ask("Pick a number, or press Enter to search again", "")
+		if err != nil {
+			return models.Market{}, err
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(shown) {
+			return shown[n-1], nil
+		}
+	}
+}
+
+// showBook prints the side's best bid and ask, last price and top bid levels
+func (f *orderForm) showBook(m models.Market, side string) {
+	bid, ask, last := m.YesBid, m.YesAsk, m.LastPrice
+	if side == "no" {
+		bid, ask = m.NoBid, m.NoAsk
+		if last > 0 {
+			last = 100 - last
+		}
+	}
+	fmt.Fprintf(f.out, "  %s book: bid %s  ask %s  last %s\n", strings.ToUpper(side), bookPrice(bid), bookPrice(ask), bookPrice(last))
+
+	book, err := f.client.GetOrderbookWithDepth(f.ctx, m.Ticker, orderFormDepth)
+	if err != nil {
+		return
+	}
+	levels := book.YesBids
+	if side == "no" {
+		levels = book.NoBids
+	}
+	for i, l := range levels {
+		if i >= orderFormDepth {
+			break
+		}
+		fmt.Fprintf(f.out, "    bid %2d¢ x %d\n", l.Price, l.Quantity)
+	}
+}
+
+// bookPrice renders a quote in cents, or "-" when there is none
+func bookPrice(cents int) string {
+	if cents <= 0 || cents >= 100 {
+		return "-"
+	}
+	return fmt.Sprintf("%d¢", cents)
+}
+
+// refreshMarket reloads a market, keeping the old one if that fails
+func (f *orderForm) refreshMarket(m models.Market) models.Market {
+	fresh, err := f.client.GetMarket(f.ctx, m.Ticker)
+	if err != nil {
+		return m
+	}
+	return *fresh
+}
+
+// run walks through market, side, action, price and quantity. Values passed
+// as flags are offered as defaults.
+func (f *orderForm) run(defaults orderFormResult) (orderFormResult, error) {
+	var r orderFormResult
+	var err error
+
+	fmt.Fprintln(f.out, ui.HeaderStyle.Render("New Order"))
+	fmt.Fprintln(f.out)
+
+	if r.Market, err = f.pickMarket(defaults.Market.Ticker); err != nil {
+		return r, err
+	}
+	if !marketIsOpen(r.Market.Status) && r.Market.Status != "" {
+		fmt.Fprintln(f.out, ui.WarningStyle.Render(fmt.Sprintf("  %s is %s", r.Market.Ticker, r.Market.Status)))
+	}
+	if r.Side, err = f.choose("Side", []string{"yes", "no"}, defaults.Side); err != nil {
+		return r, err
+	}
+	if r.Action, err = f.choose("Action", []string{"buy", "sell"}, defaults.Action); err != nil {
+		return r, err
+	}
+
+	for {
+		r.Market = f.refreshMarket(r.Market)
+		f.showBook(r.Market, r.Side)
+		def := ""
+		if defaults.Price > 0 {
+			def = strconv.Itoa(defaults.Price)
+		}
+		answer, err := f.ask("Price in cents (1-99), or r to refresh the book", def)
+		if err != nil {
+			return r, err
+		}
+		if strings.EqualFold(answer, "r") {
+			continue
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= 99 {
+			r.Price = n
+			break
+		}
+		fmt.Fprintln(f.out, "  Enter a whole number from 1 to 99")
+	}
+
+	if r.Qty, err = f.number("Quantity (contracts)", defaults.Qty, 1, 1<<20); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// runOrderForm fills the orders create flags from the interactive form
+func runOrderForm() error {
+	if err := requireInput("interactive order form (pass --market, --side, --qty and --price instead)"); err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	// No overall deadline: the user may take a while to answer. Each request
+	// still has the client's timeout.
+	form := &orderForm{in: bufio.NewReader(os.Stdin), out: os.Stdout, client: client, ctx: context.Background()}
+	result, err := form.run(orderFormResult{
+		Market: models.Market{Ticker: orderCreateMarket},
+		Side:   strings.ToLower(orderSide),
+		Action: strings.ToLower(orderAction),
+		Price:  orderCreatePrice,
+		Qty:    orderCreateQty,
+	})
+	if err != nil {
+		return err
+	}
+
+	orderCreateMarket = result.Market.Ticker
+	orderSide = result.Side
+	orderAction = result.Action
+	orderCreatePrice = result.Price
+	orderCreateQty = result.Qty
+	return nil
+}
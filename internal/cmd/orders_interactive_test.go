@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestOrderForm_SearchAndFill(t *testing.T) {
+	markets := []models.Market{
+		{Ticker: "KXBTC-26FEB12-B97000", Title: "Bitcoin 97k", Status: "active", YesBid: 40, YesAsk: 44},
+		{Ticker: "KXBTC-26FEB12-B98000", Title: "Bitcoin 98k", Status: "active", NoBid: 70, NoAsk: 74},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/orderbook"):
+			json.NewEncoder(w).Encode(models.OrderbookResponse{Orderbook: models.Orderbook{
+				NoBids: []models.OrderbookLevel{{Price: 70, Quantity: 12}},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/markets/KXBTC-26FEB12-B98000"):
+			json.NewEncoder(w).Encode(models.MarketResponse{Market: markets[1]})
+		case strings.HasSuffix(r.URL.Path, "/markets"):
+			if r.URL.Query().Get("event_ticker") == "KXBTC-26FEB12" {
+				json.NewEncoder(w).Encode(models.MarketsResponse{Markets: markets})
+				return
+			}
+			json.NewEncoder(w).Encode(models.MarketsResponse{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	// Search by event, pick the second market, retry a bad side and price
+	input := "kxbtc-26feb12\n2\nmaybe\nn\n\n150\n71\n\n"
+	var out strings.Builder
+	form := &orderForm{
+		in:     bufio.NewReader(strings.NewReader(input)),
+		out:    &out,
+		client: newCmdTestClient(t, server.URL),
+		ctx:    context.Background(),
+	}
+
+	got, err := form.run(orderFormResult{Action: "buy", Qty: 5})
+	if err != nil {
+		t.Fatalf("run() error = %v\n%s", err, out.String())
+	}
+	if got.Market.Ticker != "KXBTC-26FEB12-B98000" || got.Side != "no" || got.Action != "buy" || got.Price != 71 || got.Qty != 5 {
+		t.Errorf("run() = %s %s %s @ %d x %d", got.Market.Ticker, got.Side, got.Action, got.Price, got.Qty)
+	}
+	for _, want := range []string{"2. KXBTC-26FEB12-B98000", "NO book: bid 70¢  ask 74¢", "bid 70¢ x 12", "Choose one of: yes, no", "from 1 to 99"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("form output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestOrderForm_EOFCancels(t *testing.T) {
+	form := &orderForm{in: bufio.NewReader(strings.NewReader("")), out: &strings.Builder{}}
+	if _, err := form.ask("Market", ""); err == nil {
+		t.Error("ask() at end of input should cancel the form")
+	}
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var ordersLadderCmd = &cobra.Command{
+	Use:   "ladder",
+	Short: "Place a ladder of limit orders across a price range",
+	Long: `Place one limit order at every --step cents from --from to --to, each for
+--qty-per contracts. The range may run in either direction; --to is included
+only if it falls on a step.
+
+Orders are submitted in batches of up to 20. Use --order-group to attach every
+rung to an existing order group, or --order-group new to create one first
+(limited to --group-limit contracts, by default the whole ladder), so the
+ladder stops filling once the limit is hit.
+
+With --cancel-on-exit or --heartbeat-ttl the command stays running after the
+ladder is placed. --cancel-on-exit cancels the ladder when the command exits
+(Ctrl-C or SIGTERM); --heartbeat-ttl cancels it if the WebSocket connection is
+down for longer than the ttl.
+
+The ladder preview will be shown before submission. You must confirm
+unless the --yes flag is set.`,
+	Example: `  kalshi-cli orders ladder --market INXD-25FEB07-B5523.99 --side yes --from 40 --to 48 --step 2 --qty-per 10
+  kalshi-cli orders ladder --market INXD-25FEB07-B5523.99 --side no --from 60 --to 50 --step 5 --qty-per 5 --order-group new --group-limit 10
+  kalshi-cli orders ladder --market INXD-25FEB07-B5523.99 --side yes --from 55 --to 60 --qty-per 3 --action sell
+  kalshi-cli orders ladder --market INXD-25FEB07-B5523.99 --side yes --from 40 --to 44 --qty-per 5 --cancel-on-exit --heartbeat-ttl 30s`,
+	RunE: runOrdersLadder,
+}
+
+var (
+	ladderMarket string
+	ladderSide   string
+	ladderAction string
+	ladderFrom   int
+	ladderTo     int
+	ladderStep   int
+	ladderQtyPer int
+)
+
+// ladderBatchSize is the most orders submitted in one batched request
+const ladderBatchSize = 20
+
+// batchedOrdersPath is where batches of orders are created
+const batchedOrdersPath = api.TradeAPIPrefix + "/portfolio/orders/batched"
+
+// presignRequests signs n requests to path in parallel before a burst, so
+// the requests are not sent one RSA signature apart. Failures are only
+// reported with --verbose since each request is then signed as it is sent.
+func presignRequests(ctx context.Context, client *api.Client, method, path string, n int) {
+	if n < 2 {
+		return
+	}
+	if err := client.Presign(ctx, method, path, n); err != nil && IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Presigning failed, signing as sent: %v\n", err)
+	}
+}
+
+func init() {
+	ordersCmd.AddCommand(ordersLadderCmd)
+
+	ordersLadderCmd.Flags().StringVar(&ladderMarket, "market", "", "market ticker (required)")
+	ordersLadderCmd.Flags().StringVar(&ladderSide, "side", "", "order side: yes or no (required)")
+	ordersLadderCmd.Flags().StringVar(&ladderAction, "action", "buy", "order action: buy or sell")
+	ordersLadderCmd.Flags().IntVar(&ladderFrom, "from", 0, "first price in cents 1-99 (required)")
+	ordersLadderCmd.Flags().IntVar(&ladderTo, "to", 0, "last price in cents 1-99 (required)")
+	ordersLadderCmd.Flags().IntVar(&ladderStep, "step", 1, "cents between rungs")
+	ordersLadderCmd.Flags().IntVar(&ladderQtyPer, "qty-per", 0, "quantity at each price (required)")
+	ordersLadderCmd.MarkFlagRequired("market")
+	ordersLadderCmd.MarkFlagRequired("side")
+	ordersLadderCmd.MarkFlagRequired("from")
+	ordersLadderCmd.MarkFlagRequired("to")
+	ordersLadderCmd.MarkFlagRequired("qty-per")
+	addOrderGroupFlags(ordersLadderCmd)
+	addCancelOnExitFlag(ordersLadderCmd)
+	addHeartbeatFlag(ordersLadderCmd)
+	addPrecheckFlag(ordersLadderCmd)
+}
+
+// ladderPrices returns every price from `from` towards `to` in steps of step
+func ladderPrices(from, to, step int) ([]int, error) {
+	if from < 1 || from > 99 || to < 1 || to > 99 {
+		return nil, fmt.Errorf("prices must be between 1 and 99 cents, got %d to %d", from, to)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("step must be positive, got %d", step)
+	}
+
+	var prices []int
+	if from <= to {
+		for p := from; p <= to; p += step {
+			prices = append(prices, p)
+		}
+	} else {
+		for p := from; p >= to; p -= step {
+			prices = append(prices, p)
+		}
+	}
+	return prices, nil
+}
+
+// buildLadder returns one limit order per price
+func buildLadder(ticker string, side models.OrderSide, action models.OrderAction, prices []int, qty int) []models.CreateOrderRequest {
+	orders := make([]models.CreateOrderRequest, len(prices))
+	for i, price := range prices {
+		orders[i] = models.CreateOrderRequest{
+			Ticker:       ticker,
+			Side:         side,
+			Action:       action,
+			Type:         models.OrderTypeLimit,
+			Count:        qty,
+			SubaccountID: ActiveSubaccount(),
+		}
+		if side == models.OrderSideYes {
+			orders[i].YesPrice = price
+		} else {
+			orders[i].NoPrice = price
+		}
+	}
+	return orders
+}
+
+func runOrdersLadder(cmd *cobra.Command, args []string) (err error) {
+	side := strings.ToLower(ladderSide)
+	if side != "yes" && side != "no" {
+		return fmt.Errorf("side must be 'yes' or 'no', got '%s'", ladderSide)
+	}
+
+	action := strings.ToLower(ladderAction)
+	if action != "buy" && action != "sell" {
+		return fmt.Errorf("action must be 'buy' or 'sell', got '%s'", ladderAction)
+	}
+
+	if ladderQtyPer <= 0 {
+		return fmt.Errorf("quantity must be positive, got %d", ladderQtyPer)
+	}
+	if err := validateOrderGroupFlags(); err != nil {
+		return err
+	}
+
+	prices, err := ladderPrices(ladderFrom, ladderTo, ladderStep)
+	if err != nil {
+		return err
+	}
+
+	orders := buildLadder(ladderMarket, models.OrderSide(side), models.OrderAction(action), prices, ladderQtyPer)
+
+	totalQty, totalCost := 0, 0
+	for _, price := range prices {
+		totalQty += ladderQtyPer
+		totalCost += ladderQtyPer * price
+	}
+
+	// Show ladder preview
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintln(ui.Terminal(), ui.HeaderStyle.Render("Ladder Preview"))
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintf(ui.Terminal(), "  Environment:  %s\n", getEnvironmentLabel())
+	if sub := ActiveSubaccount(); sub > 0 {
+		fmt.Fprintf(ui.Terminal(), "  Subaccount:   %d\n", sub)
+	}
+	fmt.Fprintf(ui.Terminal(), "  Market:       %s\n", ladderMarket)
+	fmt.Fprintf(ui.Terminal(), "  Side:         %s\n", strings.ToUpper(side))
+	fmt.Fprintf(ui.Terminal(), "  Action:       %s\n", strings.ToUpper(action))
+	fmt.Fprintf(ui.Terminal(), "  Orders:       %d x %d contracts\n", len(orders), ladderQtyPer)
+	if group := orderGroupPreview(totalQty); group != "" {
+		fmt.Fprintf(ui.Terminal(), "  Order Group:  %s\n", group)
+	}
+	fmt.Fprintln(ui.Terminal())
+
+	headers := []string{"#", "Price", "Quantity", "Cumulative Qty", "Cumulative Cost"}
+	rows := make([][]string, len(prices))
+	cumQty, cumCost := 0, 0
+	for i, price := range prices {
+		cumQty += ladderQtyPer
+		cumCost += ladderQtyPer * price
+		rows[i] = []string{
+			fmt.Sprintf("%d", i+1),
+			fmt.Sprintf("%d¢", price),
+			fmt.Sprintf("%d", ladderQtyPer),
+			fmt.Sprintf("%d", cumQty),
+			ui.FormatPrice(cumCost),
+		}
+	}
+	table := ui.NewTableWriter(ui.Terminal(), ui.TableOptions{Headers: headers})
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+	fmt.Fprintln(ui.Terminal())
+
+	if action == "buy" {
+		fmt.Fprintf(ui.Terminal(), "  Max Cost:     %s\n", ui.FormatPrice(totalCost))
+		fmt.Fprintf(ui.Terminal(), "  Max Payout:   %s\n", ui.FormatPrice(totalQty*100))
+	} else {
+		fmt.Fprintf(ui.Terminal(), "  Max Credit:   %s\n", ui.FormatPrice(totalCost))
+	}
+	fmt.Fprintln(ui.Terminal())
+
+	cfg := GetConfig()
+	envWarning := ""
+	if cfg.API.Production {
+		envWarning = " (PRODUCTION - real money)"
+	}
+
+	if err := runPrecheck(orders); err != nil {
+		return err
+	}
+
+	confirmed, err := confirmAction(fmt.Sprintf("Place %d orders%s?", len(orders), envWarning))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Ladder cancelled")
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	sigCtx, stop := alertContext()
+	defer stop()
+
+	hold := cancelOnExit || heartbeatTTL > 0
+	dms := newDeadMansSwitch(client, cancelOnExit, heartbeatTTL)
+	if err := dms.start(sigCtx); err != nil {
+		return err
+	}
+	defer func() {
+		if exitErr := dms.exit(); exitErr != nil && err == nil {
+			err = exitErr
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(sigCtx, 60*time.Second)
+	defer cancel()
+
+	groupID, err := resolveOrderGroup(ctx, client, totalQty)
+	if err != nil {
+		return err
+	}
+	for i := range orders {
+		orders[i].OrderGroupID = groupID
+	}
+
+	var created []models.Order
+	presignRequests(ctx, client, http.MethodPost, batchedOrdersPath, (len(orders)+ladderBatchSize-1)/ladderBatchSize)
+	for start := 0; start < len(orders); start += ladderBatchSize {
+		end := min(start+ladderBatchSize, len(orders))
+
+		select {
+		case <-dms.Tripped():
+			if err := dms.Err(); err != nil {
+				return fmt.Errorf("heartbeat lost while placing the ladder: %w", err)
+			}
+			return fmt.Errorf("heartbeat lost while placing the ladder")
+		default:
+		}
+
+		batchReq := models.BatchCreateOrdersRequest{Orders: orders[start:end]}
+		var response models.BatchCreateOrdersResponse
+		if err := client.PostJSON(ctx, batchedOrdersPath, batchReq, &response); err != nil {
+			if len(created) > 0 {
+				PrintWarning(fmt.Sprintf("%d of %d orders were placed before the failure", len(created), len(orders)))
+			}
+			return fmt.Errorf("failed to create ladder orders: %w", err)
+		}
+		created = append(created, response.Orders...)
+		dms.track(response.Orders...)
+	}
+
+	PrintSuccess(fmt.Sprintf("Created %d orders successfully!", len(created)))
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() {
+			renderOrdersTable(created)
+			printOrderGroupStatus(ctx, client, groupID)
+		},
+		created,
+		func() { renderOrdersPlain(created) },
+	); err != nil {
+		return err
+	}
+
+	if !hold {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Holding %d orders, press Ctrl-C to exit\n", len(created))
+	select {
+	case <-sigCtx.Done():
+		return nil
+	case <-dms.Tripped():
+		if err := dms.Err(); err != nil {
+			return fmt.Errorf("heartbeat lost: %w", err)
+		}
+		return fmt.Errorf("heartbeat lost, ladder cancelled")
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestLadderPrices(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		step     int
+		want     []int
+		wantErr  bool
+	}{
+		{name: "ascending", from: 40, to: 48, step: 2, want: []int{40, 42, 44, 46, 48}},
+		{name: "descending", from: 60, to: 50, step: 5, want: []int{60, 55, 50}},
+		{name: "to not on a step", from: 40, to: 45, step: 2, want: []int{40, 42, 44}},
+		{name: "single price", from: 50, to: 50, step: 1, want: []int{50}},
+		{name: "zero step", from: 40, to: 48, step: 0, wantErr: true},
+		{name: "price out of range", from: 90, to: 100, step: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ladderPrices(tt.from, tt.to, tt.step)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ladderPrices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ladderPrices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildLadder(t *testing.T) {
+	orders := buildLadder("MKT", models.OrderSideNo, models.OrderActionBuy, []int{30, 35}, 4)
+	if len(orders) != 2 {
+		t.Fatalf("buildLadder() returned %d orders, want 2", len(orders))
+	}
+	for i, want := range []int{30, 35} {
+		o := orders[i]
+		if o.NoPrice != want || o.YesPrice != 0 || o.Count != 4 || o.Type != models.OrderTypeLimit {
+			t.Errorf("order %d = %+v, want limit no @ %d x 4", i, o, want)
+		}
+	}
+}
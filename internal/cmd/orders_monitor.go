@@ -0,0 +1,683 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/notify"
+	"github.com/6missedcalls/kalshi-cli/internal/triggers"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var ordersMonitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Exit positions automatically at a stop loss or take profit",
+	Long: `Watch ticker updates over WebSocket and sell a position as soon as the price
+of the side held crosses a stop loss or take profit, whichever comes first.
+Prices are in cents on the side held.
+
+With --ticker a trigger is added before monitoring starts; without it the
+triggers already saved for the current environment are monitored. Triggers
+are kept in the data directory until they fire or are cancelled, so the
+monitor can be stopped and restarted. A trigger is marked firing before its
+exit order is sent and is never sent twice.
+
+The exit sells --count contracts, or the whole position when --count is not
+set or exceeds it. --type market sells at the market; --type limit sells at
+the trigger price less --slippage cents. The trigger watches the last trade
+price, or with --trigger-on bid the best bid for the side held.
+
+Because orders are sent without further prompts, monitoring starts only
+after confirmation or with --yes. --dry-run reports what would be sent and
+leaves triggers active.
+
+A limit exit can rest unfilled. --cancel-on-exit cancels the exit orders the
+monitor sent when it exits (Ctrl-C or SIGTERM); --heartbeat-ttl cancels them
+and stops the monitor if the WebSocket connection is down for longer than the
+ttl, since triggers cannot be watched without it.`,
+	Example: `  kalshi-cli orders monitor --ticker KXBTC-26FEB12-B97000 --stop-loss 30 --take-profit 80 --yes
+  kalshi-cli orders monitor --ticker KXBTC-26FEB12-B97000 --side no --stop-loss 20 --type limit --slippage 2
+  kalshi-cli orders monitor --type limit --slippage 1 --cancel-on-exit --heartbeat-ttl 30s --yes
+  kalshi-cli orders monitor --dry-run
+  kalshi-cli orders monitor list
+  kalshi-cli orders monitor cancel 3`,
+	Args: cobra.NoArgs,
+	RunE: runOrdersMonitor,
+}
+
+var ordersMonitorListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved exit triggers",
+	Example: `  kalshi-cli orders monitor list
+  kalshi-cli orders monitor list --all --json`,
+	Args: cobra.NoArgs,
+	RunE: runOrdersMonitorList,
+}
+
+var ordersMonitorCancelCmd = &cobra.Command{
+	Use:   "cancel <id>",
+	Short: "Cancel an exit trigger",
+	Long: `Cancel an exit trigger so it no longer fires. A running monitor picks the
+change up within its --interval. A trigger left firing by a monitor that
+stopped mid-exit can be cancelled too; check its market for the exit order
+first.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOrdersMonitorCancel,
+}
+
+var (
+	monitorTicker     string
+	monitorSide       string
+	monitorCount      int
+	monitorStopLoss   int
+	monitorTakeProfit int
+	monitorType       string
+	monitorSlippage   int
+	monitorTriggerOn  string
+	monitorInterval   time.Duration
+	monitorDryRun     bool
+	monitorNotify     bool
+	monitorListAll    bool
+)
+
+func init() {
+	ordersCmd.AddCommand(ordersMonitorCmd)
+	ordersMonitorCmd.AddCommand(ordersMonitorListCmd)
+	ordersMonitorCmd.AddCommand(ordersMonitorCancelCmd)
+
+	ordersMonitorCmd.Flags().StringVar(&monitorTicker, "ticker", "", "add a trigger on this market before monitoring")
+	ordersMonitorCmd.Flags().StringVar(&monitorSide, "side", "", "side held: yes or no (default: the side of your position)")
+	ordersMonitorCmd.Flags().IntVar(&monitorCount, "count", 0, "contracts to sell (default: the whole position)")
+	ordersMonitorCmd.Flags().IntVar(&monitorStopLoss, "stop-loss", 0, "sell when the price falls to this many cents or below")
+	ordersMonitorCmd.Flags().IntVar(&monitorTakeProfit, "take-profit", 0, "sell when the price rises to this many cents or above")
+	ordersMonitorCmd.Flags().StringVar(&monitorType, "type", "market", "exit order type: market or limit")
+	ordersMonitorCmd.Flags().IntVar(&monitorSlippage, "slippage", 0, "cents below the trigger price to place a limit exit")
+	ordersMonitorCmd.Flags().StringVar(&monitorTriggerOn, "trigger-on", triggers.OnLast, "price to watch: last or bid")
+	ordersMonitorCmd.Flags().DurationVar(&monitorInterval, "interval", 30*time.Second, "how often to re-read saved triggers")
+	ordersMonitorCmd.Flags().BoolVar(&monitorDryRun, "dry-run", false, "report exits instead of sending them; triggers stay active")
+	ordersMonitorCmd.Flags().BoolVar(&monitorNotify, "notify", false, "also deliver exits to the destinations configured under alerts.*")
+	addCancelOnExitFlag(ordersMonitorCmd)
+	addHeartbeatFlag(ordersMonitorCmd)
+
+	ordersMonitorListCmd.Flags().BoolVar(&monitorListAll, "all", false, "include fired, failed and cancelled triggers")
+}
+
+// triggersPath returns the triggers file location
+func triggersPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return triggers.DefaultPath(dir), nil
+}
+
+// triggerPrice returns the price a trigger watches from a ticker update, in
+// cents on the side held, or 0 when there is no quote
+func triggerPrice(t triggers.Trigger, d websocket.TickerData) int {
+	if t.On == triggers.OnBid {
+		if t.Side == string(models.OrderSideNo) {
+			if d.YesAsk <= 0 {
+				return 0
+			}
+			return 100 - d.YesAsk
+		}
+		return d.YesBid
+	}
+	if t.Side == string(models.OrderSideNo) {
+		if d.NoPrice > 0 {
+			return d.NoPrice
+		}
+		if d.YesPrice <= 0 {
+			return 0
+		}
+		return 100 - d.YesPrice
+	}
+	return d.YesPrice
+}
+
+// heldContracts returns how many contracts of side a position holds. Yes
+// positions are positive and no positions negative.
+func heldContracts(p models.MarketPosition, side string) int {
+	if side == string(models.OrderSideNo) {
+		return max(-p.Position, 0)
+	}
+	return max(p.Position, 0)
+}
+
+// exitOrder builds the order that exits a trigger's position of held
+// contracts after it fired at price
+func exitOrder(t triggers.Trigger, price, held int) (models.CreateOrderRequest, error) {
+	if held <= 0 {
+		return models.CreateOrderRequest{}, fmt.Errorf("no %s position in %s", strings.ToUpper(t.Side), t.Ticker)
+	}
+	count := t.Count
+	if count <= 0 || count > held {
+		count = held
+	}
+	req := models.CreateOrderRequest{
+		Ticker:       t.Ticker,
+		Side:         models.OrderSide(t.Side),
+		Action:       models.OrderActionSell,
+		Type:         models.OrderType(t.OrderType),
+		Count:        count,
+		SubaccountID: ActiveSubaccount(),
+	}
+	if req.Type == models.OrderTypeLimit {
+		limit := max(price-t.Slippage, 1)
+		if t.Side == string(models.OrderSideNo) {
+			req.NoPrice = limit
+		} else {
+			req.YesPrice = limit
+		}
+	}
+	return req, nil
+}
+
+// newMonitorTrigger builds the trigger described by the flags, taking the
+// side from the position in the market when --side is not set
+func newMonitorTrigger(ctx context.Context, client *api.Client) (triggers.Trigger, error) {
+	ticker, err := normalizeTicker(monitorTicker)
+	if err != nil {
+		return triggers.Trigger{}, err
+	}
+	orderType := strings.ToLower(monitorType)
+	if orderType != string(models.OrderTypeMarket) && orderType != string(models.OrderTypeLimit) {
+		return triggers.Trigger{}, fmt.Errorf("type must be 'limit' or 'market', got '%s'", monitorType)
+	}
+	on := strings.ToLower(monitorTriggerOn)
+	if on != triggers.OnLast && on != triggers.OnBid {
+		return triggers.Trigger{}, fmt.Errorf("--trigger-on must be 'last' or 'bid', got '%s'", monitorTriggerOn)
+	}
+	if monitorSlippage < 0 {
+		return triggers.Trigger{}, fmt.Errorf("--slippage must not be negative")
+	}
+
+	t := triggers.Trigger{
+		Ticker:      ticker,
+		Side:        strings.ToLower(monitorSide),
+		Count:       monitorCount,
+		StopLoss:    monitorStopLoss,
+		TakeProfit:  monitorTakeProfit,
+		OrderType:   orderType,
+		Slippage:    monitorSlippage,
+		On:          on,
+		Environment: GetConfig().Environment(),
+	}
+	if t.Side == "" {
+		position, err := marketPosition(ctx, client, ticker)
+		if err != nil {
+			return t, err
+		}
+		switch {
+		case position.Position > 0:
+			t.Side = string(models.OrderSideYes)
+		case position.Position < 0:
+			t.Side = string(models.OrderSideNo)
+		default:
+			return t, fmt.Errorf("no position in %s; pass --side to set a trigger before buying", ticker)
+		}
+	}
+	return t, t.Validate()
+}
+
+// marketPosition returns the position in one market, which is empty when
+// none is held
+func marketPosition(ctx context.Context, client *api.Client, ticker string) (models.MarketPosition, error) {
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	resp, err := client.GetPositions(reqCtx, api.PositionsOptions{Ticker: ticker, SubaccountID: ActiveSubaccount()})
+	if err != nil {
+		return models.MarketPosition{}, fmt.Errorf("failed to get position in %s: %w", ticker, err)
+	}
+	for _, p := range resp.Positions {
+		if p.Ticker == ticker {
+			return p, nil
+		}
+	}
+	return models.MarketPosition{Ticker: ticker}, nil
+}
+
+// exitMonitor fires saved triggers on ticker updates
+type exitMonitor struct {
+	client   *api.Client
+	path     string
+	env      string
+	dryRun   bool
+	notifier notify.Notifier
+	// deadman cancels the exit orders sent, nil when none are tracked
+	deadman *deadMansSwitch
+
+	mu     sync.Mutex
+	active map[string][]triggers.Trigger
+	fired  map[int]bool
+
+	// subscribed is the market_tickers filter the ticker channel is
+	// subscribed with, empty when it is not subscribed
+	subscribed string
+}
+
+// reload re-reads the active triggers, picking up ones added or cancelled
+// by other commands
+func (m *exitMonitor) reload() (int, error) {
+	book, err := triggers.Load(m.path)
+	if err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	m.active = make(map[string][]triggers.Trigger)
+	for _, t := range book.Active(m.env) {
+		// Dry-run exits leave triggers active, so skip those already fired
+		if m.fired[t.ID] {
+			continue
+		}
+		m.active[t.Ticker] = append(m.active[t.Ticker], t)
+		n++
+	}
+	return n, nil
+}
+
+// tickers returns the markets with triggers being monitored, sorted and
+// joined for a market_tickers filter
+func (m *exitMonitor) tickers() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]string, 0, len(m.active))
+	for ticker := range m.active {
+		list = append(list, ticker)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+// subscribe subscribes the ticker channel to the markets with triggers,
+// re-subscribing when they change. With no triggers left nothing stays
+// subscribed, rather than every market on the exchange.
+func (m *exitMonitor) subscribe(ctx context.Context, ws *websocket.Client) error {
+	tickers := m.tickers()
+	if tickers == m.subscribed {
+		return nil
+	}
+	if m.subscribed != "" {
+		if err := ws.Unsubscribe(ctx, websocket.ChannelMarketTicker); err != nil {
+			return fmt.Errorf("failed to unsubscribe from %s: %w", websocket.ChannelMarketTicker, err)
+		}
+		m.subscribed = ""
+	}
+	if tickers == "" {
+		return nil
+	}
+	if err := ws.Subscribe(ctx, websocket.ChannelMarketTicker, map[string]string{"market_tickers": tickers}); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", websocket.ChannelMarketTicker, err)
+	}
+	m.subscribed = tickers
+	return nil
+}
+
+// observe checks a ticker update against the market's triggers and returns
+// those that fired, which stop being monitored
+func (m *exitMonitor) observe(d websocket.TickerData) []firedTrigger {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var fired []firedTrigger
+	kept := m.active[d.Ticker][:0]
+	for _, t := range m.active[d.Ticker] {
+		price := triggerPrice(t, d)
+		if reason := t.Check(price); reason != "" {
+			fired = append(fired, firedTrigger{trigger: t, reason: reason, price: price})
+			m.fired[t.ID] = true
+			continue
+		}
+		kept = append(kept, t)
+	}
+	if len(kept) == 0 {
+		delete(m.active, d.Ticker)
+	} else {
+		m.active[d.Ticker] = kept
+	}
+	return fired
+}
+
+// firedTrigger is a trigger whose price was crossed
+type firedTrigger struct {
+	trigger triggers.Trigger
+	reason  string
+	price   int
+}
+
+// fire claims a trigger, sends its exit order and records the outcome
+func (m *exitMonitor) fire(ctx context.Context, f firedTrigger) {
+	t := f.trigger
+	if !m.dryRun {
+		err := triggers.Update(m.path, func(book *triggers.Book) error {
+			var err error
+			t, err = book.Claim(t.ID, f.reason, f.price, time.Now())
+			return err
+		})
+		if err != nil {
+			// Not claimed, so another monitor or a cancel got there first
+			fmt.Fprintf(os.Stderr, "Warning: skipping trigger %d: %v\n", f.trigger.ID, err)
+			return
+		}
+	}
+
+	orderID, err := m.exit(ctx, t, f.price)
+	if !m.dryRun {
+		recordErr := triggers.Update(m.path, func(book *triggers.Book) error {
+			return book.Finish(t.ID, orderID, err)
+		})
+		if recordErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record trigger %d: %v\n", t.ID, recordErr)
+		}
+	}
+	emitWatchAlerts(m.notifier, []notify.Message{exitMessage(t, f, orderID, err, m.dryRun)})
+}
+
+// exit sizes and sends the exit order for a fired trigger
+func (m *exitMonitor) exit(ctx context.Context, t triggers.Trigger, price int) (string, error) {
+	position, err := marketPosition(ctx, m.client, t.Ticker)
+	if err != nil {
+		return "", err
+	}
+	req, err := exitOrder(t, price, heldContracts(position, t.Side))
+	if err != nil {
+		return "", err
+	}
+	if m.dryRun {
+		return "", nil
+	}
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	resp, err := m.client.CreateOrder(reqCtx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create exit order: %w", err)
+	}
+	if m.deadman != nil {
+		m.deadman.track(resp.Order)
+	}
+	return resp.Order.OrderID, nil
+}
+
+// exitMessage renders the outcome of a fired trigger as an alert
+func exitMessage(t triggers.Trigger, f firedTrigger, orderID string, err error, dryRun bool) notify.Message {
+	label := "Stop loss"
+	if f.reason == triggers.ReasonTakeProfit {
+		label = "Take profit"
+	}
+	data := map[string]any{"alert": f.reason, "trigger_id": t.ID, "ticker": t.Ticker, "side": t.Side, "price": f.price}
+
+	title := label + " hit"
+	body := fmt.Sprintf("%s %s at %d¢: ", t.Ticker, strings.ToUpper(t.Side), f.price)
+	switch {
+	case err != nil:
+		title = label + " exit failed"
+		body += err.Error()
+		data["error"] = err.Error()
+	case dryRun:
+		body += "would sell (dry run)"
+		data["dry_run"] = true
+	default:
+		body += "sold with order " + orderID
+		data["order_id"] = orderID
+	}
+	return notify.Message{Title: title, Body: body, Time: time.Now().UTC(), Data: data}
+}
+
+func runOrdersMonitor(cmd *cobra.Command, args []string) (err error) {
+	if monitorInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	path, err := triggersPath()
+	if err != nil {
+		return err
+	}
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := alertContext()
+	defer stop()
+
+	env := GetConfig().Environment()
+	book, err := triggers.Load(path)
+	if err != nil {
+		return err
+	}
+	var added *triggers.Trigger
+	if monitorTicker != "" {
+		t, err := newMonitorTrigger(ctx, client)
+		if err != nil {
+			return err
+		}
+		added = &t
+	}
+	n := len(book.Active(env))
+	if added != nil {
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("no active triggers for %s; add one with --ticker", env)
+	}
+
+	// Confirm before saving, so a refused trigger is not left behind
+	if !monitorDryRun {
+		envWarning := ""
+		if GetConfig().API.Production {
+			envWarning = " (PRODUCTION - real money)"
+		}
+		confirmed, err := confirmAction(fmt.Sprintf("Send exit orders automatically for %d triggers%s?", n, envWarning))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			PrintWarning("Monitor not started")
+			return nil
+		}
+	}
+
+	if added != nil {
+		var t triggers.Trigger
+		err := triggers.Update(path, func(book *triggers.Book) error {
+			var err error
+			t, err = book.Add(*added, time.Now())
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		PrintSuccess(fmt.Sprintf("Added trigger %d on %s %s", t.ID, t.Ticker, strings.ToUpper(t.Side)))
+	}
+
+	dms := newDeadMansSwitch(client, cancelOnExit, heartbeatTTL)
+	if err := dms.start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if exitErr := dms.exit(); exitErr != nil && err == nil {
+			err = exitErr
+		}
+	}()
+
+	m := &exitMonitor{client: client, path: path, env: env, dryRun: monitorDryRun, notifier: notify.Multi{}, fired: make(map[int]bool), deadman: dms}
+	if monitorNotify {
+		m.notifier = buildNotifier()
+	}
+	if n, err = m.reload(); err != nil {
+		return err
+	}
+
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return err
+	}
+	wsClient := newWebSocketClient(opts)
+	wsClient.OnError(func(err error) {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	})
+	wsClient.RegisterHandler(websocket.ChannelMarketTicker, websocket.HandlerFunc(func(msg websocket.Message) error {
+		var data websocket.TickerData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse ticker data: %w", err)
+		}
+		select {
+		case <-dms.Tripped():
+			// The exit orders were cancelled; send no more
+			return nil
+		default:
+		}
+		for _, f := range m.observe(data) {
+			m.fire(ctx, f)
+		}
+		return nil
+	}))
+
+	if err := wsClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer wsClient.Close()
+	// Only the markets with triggers are subscribed; the subscription follows
+	// them as triggers are added, cancelled and fired
+	if err := m.subscribe(ctx, wsClient); err != nil {
+		return err
+	}
+
+	mode := ""
+	if monitorDryRun {
+		mode = " (dry run)"
+	}
+	fmt.Fprintf(os.Stderr, "Monitoring %d exit triggers%s (Ctrl+C to stop)\n", n, mode)
+
+	ticker := time.NewTicker(monitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-dms.Tripped():
+			if err := dms.Err(); err != nil {
+				return fmt.Errorf("heartbeat lost: %w", err)
+			}
+			return fmt.Errorf("heartbeat lost, monitor stopped")
+		case <-ticker.C:
+			if _, err := m.reload(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				continue
+			}
+			if err := m.subscribe(ctx, wsClient); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+}
+
+func runOrdersMonitorList(cmd *cobra.Command, args []string) error {
+	path, err := triggersPath()
+	if err != nil {
+		return err
+	}
+	book, err := triggers.Load(path)
+	if err != nil {
+		return err
+	}
+	env := GetConfig().Environment()
+	list := make([]triggers.Trigger, 0, len(book.Triggers))
+	for _, t := range book.Triggers {
+		if t.Environment != env {
+			continue
+		}
+		if monitorListAll || t.Status == triggers.StatusActive || t.Status == triggers.StatusFiring {
+			list = append(list, t)
+		}
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			if len(list) == 0 {
+				fmt.Fprintln(ui.Writer(), "No exit triggers")
+				return
+			}
+			rows := make([][]string, len(list))
+			for i, t := range list {
+				rows[i] = []string{
+					strconv.Itoa(t.ID), t.Ticker, strings.ToUpper(t.Side), triggerCount(t),
+					triggerCents(t.StopLoss), triggerCents(t.TakeProfit), t.OrderType, t.On,
+					string(t.Status), triggerOutcome(t),
+				}
+			}
+			ui.RenderTable([]string{"ID", "Market", "Side", "Count", "Stop", "Take", "Type", "On", "Status", "Outcome"}, rows)
+		},
+		list,
+		func() {
+			for _, t := range list {
+				fmt.Fprintf(ui.Writer(), "%d\t%s\t%s\t%d\t%d\t%d\t%s\n", t.ID, t.Ticker, t.Side, t.Count, t.StopLoss, t.TakeProfit, t.Status)
+			}
+		},
+	)
+}
+
+// triggerCount renders a trigger's count, which is the whole position when
+// unset
+func triggerCount(t triggers.Trigger) string {
+	if t.Count == 0 {
+		return "all"
+	}
+	return strconv.Itoa(t.Count)
+}
+
+// triggerCents renders a trigger price, or - when unset
+func triggerCents(c int) string {
+	if c == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d¢", c)
+}
+
+// triggerOutcome describes what happened when a trigger fired
+func triggerOutcome(t triggers.Trigger) string {
+	switch {
+	case t.Error != "":
+		return t.Error
+	case t.OrderID != "":
+		return fmt.Sprintf("%s at %d¢, order %s", t.Reason, t.Price, t.OrderID)
+	case t.Reason != "":
+		return fmt.Sprintf("%s at %d¢", t.Reason, t.Price)
+	}
+	return ""
+}
+
+func runOrdersMonitorCancel(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid trigger id %q", args[0])
+	}
+	path, err := triggersPath()
+	if err != nil {
+		return err
+	}
+	err = triggers.Update(path, func(book *triggers.Book) error {
+		return book.Cancel(id)
+	})
+	if err != nil {
+		return err
+	}
+	PrintSuccess(fmt.Sprintf("Cancelled trigger %d", id))
+	return nil
+}
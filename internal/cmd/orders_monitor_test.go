@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/triggers"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestTriggerPrice(t *testing.T) {
+	d := websocket.TickerData{Ticker: "KXA", YesPrice: 40, YesBid: 38, YesAsk: 42}
+	tests := []struct {
+		side, on string
+		want     int
+	}{
+		{"yes", triggers.OnLast, 40},
+		{"no", triggers.OnLast, 60},
+		{"yes", triggers.OnBid, 38},
+		{"no", triggers.OnBid, 58},
+	}
+	for _, tt := range tests {
+		if got := triggerPrice(triggers.Trigger{Side: tt.side, On: tt.on}, d); got != tt.want {
+			t.Errorf("triggerPrice(%s, %s) = %d, want %d", tt.side, tt.on, got, tt.want)
+		}
+	}
+	if got := triggerPrice(triggers.Trigger{Side: "no", On: triggers.OnBid}, websocket.TickerData{}); got != 0 {
+		t.Errorf("triggerPrice with no quote = %d, want 0", got)
+	}
+}
+
+func TestExitOrder(t *testing.T) {
+	tr := triggers.Trigger{Ticker: "KXA", Side: "no", Count: 50, OrderType: "limit", Slippage: 3}
+	req, err := exitOrder(tr, 25, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Action != models.OrderActionSell || req.Side != models.OrderSideNo || req.Count != 20 || req.NoPrice != 22 || req.YesPrice != 0 {
+		t.Errorf("exit order = %+v, want sell 20 NO at 22", req)
+	}
+
+	tr.OrderType, tr.Count = "market", 5
+	if req, _ := exitOrder(tr, 25, 20); req.Count != 5 || req.NoPrice != 0 || req.Type != models.OrderTypeMarket {
+		t.Errorf("market exit = %+v, want 5 at the market", req)
+	}
+	if _, err := exitOrder(tr, 25, 0); err == nil || !strings.Contains(err.Error(), "no NO position") {
+		t.Errorf("err = %v, want no position", err)
+	}
+
+	if got := heldContracts(models.MarketPosition{Position: -7}, "no"); got != 7 {
+		t.Errorf("held NO = %d, want 7", got)
+	}
+	if got := heldContracts(models.MarketPosition{Position: -7}, "yes"); got != 0 {
+		t.Errorf("held YES = %d, want 0", got)
+	}
+}
+
+func TestExitMonitor_Observe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "triggers.json")
+	book := &triggers.Book{}
+	now := time.Now()
+	book.Add(triggers.Trigger{Ticker: "KXA", Side: "yes", StopLoss: 30, TakeProfit: 80, On: triggers.OnLast, Environment: "demo"}, now)
+	book.Add(triggers.Trigger{Ticker: "KXA", Side: "no", StopLoss: 30, On: triggers.OnLast, Environment: "demo"}, now)
+	book.Add(triggers.Trigger{Ticker: "KXA", Side: "yes", StopLoss: 30, On: triggers.OnLast, Environment: "prod"}, now)
+	if err := triggers.Save(path, book); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &exitMonitor{path: path, env: "demo", dryRun: true, fired: make(map[int]bool)}
+	if n, err := m.reload(); err != nil || n != 2 {
+		t.Fatalf("reload = %d, %v; want 2 demo triggers", n, err)
+	}
+	if got := m.tickers(); got != "KXA" {
+		t.Errorf("tickers = %q, want only the demo triggers' market", got)
+	}
+	if fired := m.observe(websocket.TickerData{Ticker: "KXA", YesPrice: 50}); len(fired) != 0 {
+		t.Errorf("fired at 50: %+v", fired)
+	}
+	fired := m.observe(websocket.TickerData{Ticker: "KXA", YesPrice: 85})
+	if len(fired) != 2 || fired[0].reason != triggers.ReasonTakeProfit || fired[1].reason != triggers.ReasonStopLoss || fired[1].price != 15 {
+		t.Fatalf("fired at 85: %+v", fired)
+	}
+	if fired := m.observe(websocket.TickerData{Ticker: "KXA", YesPrice: 90}); len(fired) != 0 {
+		t.Errorf("a fired trigger fired again: %+v", fired)
+	}
+
+	// Dry runs leave triggers active in the file but not monitored
+	if n, _ := m.reload(); n != 0 {
+		t.Errorf("reload after dry-run fire = %d, want 0", n)
+	}
+	if got := m.tickers(); got != "" {
+		t.Errorf("tickers = %q, want none once every trigger fired", got)
+	}
+}
+
+func TestExitMonitor_CancelsExitOrdersOnExit(t *testing.T) {
+	var cancelled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/positions"):
+			w.Write([]byte(`{"market_positions":[{"ticker":"KXA","position":4}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/orders"):
+			w.Write([]byte(`{"order":{"order_id":"exit-1","ticker":"KXA","status":"resting"}}`))
+		case r.Method == http.MethodDelete:
+			cancelled = append(cancelled, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.Write([]byte(`{"order":{"order_id":"exit-1","status":"canceled"},"reduced_by":4}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := newCmdTestClient(t, server.URL)
+
+	dms := newDeadMansSwitch(client, true, 0)
+	m := &exitMonitor{client: client, env: "demo", fired: make(map[int]bool), deadman: dms}
+	trigger := triggers.Trigger{ID: 1, Ticker: "KXA", Side: "yes", StopLoss: 30, OrderType: "limit", On: triggers.OnLast}
+	orderID, err := m.exit(context.Background(), trigger, 30)
+	if err != nil || orderID != "exit-1" {
+		t.Fatalf("exit() = %q, %v", orderID, err)
+	}
+
+	if err := dms.exit(); err != nil {
+		t.Fatal(err)
+	}
+	if len(cancelled) != 1 || cancelled[0] != "exit-1" {
+		t.Errorf("cancelled = %v, want the resting exit order", cancelled)
+	}
+}
@@ -0,0 +1,440 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var ordersPairCmd = &cobra.Command{
+	Use:   "pair",
+	Short: "Work a buy and a sell across two markets as one spread",
+	Long: `Place a buy leg and a sell leg at the same time and work them until both are
+filled. Legs are given as TICKER@PRICE, priced in cents on --side.
+
+While the legs fill, whichever leg has fewer contracts filled is repriced one
+cent more aggressive per --interval, up to --max-legs-slippage cents from its
+starting price, to keep exposure balanced.
+
+If either leg is canceled outside this command, both are not filled by
+--timeout, the command is interrupted, or the WebSocket connection is down for
+longer than --heartbeat-ttl, the remainder of both legs is cancelled and any
+leftover imbalance is reported. Exits non-zero unless both legs fill.`,
+	Example: `  kalshi-cli orders pair --buy KXBTC-26FEB12-B97000@45 --sell KXBTC-26FEB12-B99000@55 --qty 20
+  kalshi-cli orders pair --buy T1@45 --sell T2@55 --qty 20 --max-legs-slippage 2 --timeout 10m`,
+	RunE: runOrdersPair,
+}
+
+var (
+	pairBuy         string
+	pairSell        string
+	pairSide        string
+	pairQty         int
+	pairMaxSlippage int
+	pairTimeout     time.Duration
+	pairInterval    time.Duration
+)
+
+func init() {
+	ordersCmd.AddCommand(ordersPairCmd)
+
+	ordersPairCmd.Flags().StringVar(&pairBuy, "buy", "", "buy leg as TICKER@PRICE (required)")
+	ordersPairCmd.Flags().StringVar(&pairSell, "sell", "", "sell leg as TICKER@PRICE (required)")
+	ordersPairCmd.Flags().StringVar(&pairSide, "side", "yes", "side both legs are priced on: yes or no")
+	ordersPairCmd.Flags().IntVar(&pairQty, "qty", 0, "quantity for each leg (required)")
+	ordersPairCmd.Flags().IntVar(&pairMaxSlippage, "max-legs-slippage", 0, "cents a lagging leg may be repriced to catch up")
+	ordersPairCmd.Flags().DurationVar(&pairTimeout, "timeout", 5*time.Minute, "cancel the remainder if both legs are not filled by then")
+	ordersPairCmd.Flags().DurationVar(&pairInterval, "interval", 2*time.Second, "how often to poll the legs")
+	ordersPairCmd.MarkFlagRequired("buy")
+	ordersPairCmd.MarkFlagRequired("sell")
+	ordersPairCmd.MarkFlagRequired("qty")
+	addHeartbeatFlag(ordersPairCmd)
+	addPrecheckFlag(ordersPairCmd)
+}
+
+// pairLeg is one side of a pair order
+type pairLeg struct {
+	Ticker     string             `json:"ticker"`
+	Action     models.OrderAction `json:"action"`
+	StartPrice int                `json:"start_price"`
+	Price      int                `json:"price"`
+	OrderID    string             `json:"order_id"`
+	Filled     int                `json:"filled"`
+	Status     models.OrderStatus `json:"status"`
+}
+
+// slippage is how many cents the leg has been repriced from its start
+func (l pairLeg) slippage() int {
+	if l.Action == models.OrderActionSell {
+		return l.StartPrice - l.Price
+	}
+	return l.Price - l.StartPrice
+}
+
+// nextPrice is the price one cent more aggressive than the current one
+func (l pairLeg) nextPrice() int {
+	if l.Action == models.OrderActionSell {
+		return l.Price - 1
+	}
+	return l.Price + 1
+}
+
+// pairResult is the outcome of 'orders pair'
+type pairResult struct {
+	Side      string     `json:"side"`
+	Qty       int        `json:"qty"`
+	Legs      [2]pairLeg `json:"legs"`
+	Completed bool       `json:"completed"`
+	Imbalance int        `json:"imbalance"`
+	Reason    string     `json:"reason,omitempty"`
+}
+
+// parseLegSpec parses TICKER@PRICE
+func parseLegSpec(spec string) (string, int, error) {
+	i := strings.LastIndex(spec, "@")
+	if i <= 0 || i == len(spec)-1 {
+		return "", 0, fmt.Errorf("invalid leg %q: expected TICKER@PRICE", spec)
+	}
+	price, err := strconv.Atoi(spec[i+1:])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid leg %q: price must be a whole number of cents", spec)
+	}
+	if price < 1 || price > 99 {
+		return "", 0, fmt.Errorf("invalid leg %q: price must be between 1 and 99 cents", spec)
+	}
+	return spec[:i], price, nil
+}
+
+// laggingLeg returns the index of the leg with fewer contracts filled that
+// may still be repriced, or -1 if the legs are balanced or out of slippage
+func laggingLeg(legs [2]pairLeg, maxSlippage int) int {
+	lag := -1
+	switch {
+	case legs[0].Filled < legs[1].Filled:
+		lag = 0
+	case legs[1].Filled < legs[0].Filled:
+		lag = 1
+	default:
+		return -1
+	}
+
+	next := legs[lag].nextPrice()
+	if legs[lag].slippage() >= maxSlippage || next < 1 || next > 99 {
+		return -1
+	}
+	return lag
+}
+
+// brokenLeg returns the index of a leg that ended without filling, or -1
+func brokenLeg(legs [2]pairLeg, qty int) int {
+	for i, l := range legs {
+		if l.Status == models.OrderStatusCanceled && l.Filled < qty {
+			return i
+		}
+	}
+	return -1
+}
+
+func runOrdersPair(cmd *cobra.Command, args []string) error {
+	side := strings.ToLower(pairSide)
+	if side != "yes" && side != "no" {
+		return fmt.Errorf("side must be 'yes' or 'no', got '%s'", pairSide)
+	}
+	if pairQty <= 0 {
+		return fmt.Errorf("quantity must be positive, got %d", pairQty)
+	}
+	if pairMaxSlippage < 0 {
+		return fmt.Errorf("--max-legs-slippage must not be negative")
+	}
+	if pairInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	buyTicker, buyPrice, err := parseLegSpec(pairBuy)
+	if err != nil {
+		return err
+	}
+	sellTicker, sellPrice, err := parseLegSpec(pairSell)
+	if err != nil {
+		return err
+	}
+
+	result := pairResult{
+		Side: side,
+		Qty:  pairQty,
+		Legs: [2]pairLeg{
+			{Ticker: buyTicker, Action: models.OrderActionBuy, StartPrice: buyPrice, Price: buyPrice},
+			{Ticker: sellTicker, Action: models.OrderActionSell, StartPrice: sellPrice, Price: sellPrice},
+		},
+	}
+
+	// Show pair preview
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintln(ui.Terminal(), ui.HeaderStyle.Render("Pair Order Preview"))
+	fmt.Fprintln(ui.Terminal())
+	fmt.Fprintf(ui.Terminal(), "  Environment:  %s\n", getEnvironmentLabel())
+	if sub := ActiveSubaccount(); sub > 0 {
+		fmt.Fprintf(ui.Terminal(), "  Subaccount:   %d\n", sub)
+	}
+	fmt.Fprintf(ui.Terminal(), "  Buy:          %s %s @ %d cents x %d\n", strings.ToUpper(side), buyTicker, buyPrice, pairQty)
+	fmt.Fprintf(ui.Terminal(), "  Sell:         %s %s @ %d cents x %d\n", strings.ToUpper(side), sellTicker, sellPrice, pairQty)
+	fmt.Fprintf(ui.Terminal(), "  Net Credit:   %s per pair\n", formatCents(sellPrice-buyPrice))
+	fmt.Fprintf(ui.Terminal(), "  Slippage:     up to %d cents per leg\n", pairMaxSlippage)
+	fmt.Fprintf(ui.Terminal(), "  Timeout:      %s\n", pairTimeout)
+	fmt.Fprintln(ui.Terminal())
+
+	cfg := GetConfig()
+	envWarning := ""
+	if cfg.API.Production {
+		envWarning = " (PRODUCTION - real money)"
+	}
+
+	if err := runPrecheck([]models.CreateOrderRequest{
+		pairLegRequest(result.Legs[0], side, pairQty),
+		pairLegRequest(result.Legs[1], side, pairQty),
+	}); err != nil {
+		return err
+	}
+
+	confirmed, err := confirmAction(fmt.Sprintf("Place this pair%s?", envWarning))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Pair order cancelled")
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := alertContext()
+	defer stop()
+
+	// Legs are cancelled by workPair's caller, so the switch tracks nothing
+	dms := newDeadMansSwitch(client, false, heartbeatTTL)
+	if err := dms.start(ctx); err != nil {
+		return err
+	}
+
+	if err := placePairLegs(ctx, client, &result); err != nil {
+		return err
+	}
+
+	calendar := loadExchangeCalendar(ctx, client)
+	result.Reason = workPair(ctx, client, &result, calendar, dms.Tripped())
+	result.Completed = result.Reason == ""
+	if !result.Completed {
+		cancelPairRemainder(client, &result)
+	}
+	result.Imbalance = result.Legs[0].Filled - result.Legs[1].Filled
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() { renderPairResult(result) },
+		result,
+		func() {
+			for _, l := range result.Legs {
+				ui.PrintPlain("%s\t%s\t%s\t%d\t%d\t%d\t%s", l.OrderID, l.Ticker, l.Action, l.StartPrice, l.Price, l.Filled, l.Status)
+			}
+		},
+	); err != nil {
+		return err
+	}
+
+	if !result.Completed {
+		return fmt.Errorf("pair not completed: %s", result.Reason)
+	}
+	return nil
+}
+
+// pairLegRequest is the limit order for one leg at its current price
+func pairLegRequest(leg pairLeg, side string, qty int) models.CreateOrderRequest {
+	req := models.CreateOrderRequest{
+		Ticker:       leg.Ticker,
+		Side:         models.OrderSide(side),
+		Action:       leg.Action,
+		Type:         models.OrderTypeLimit,
+		Count:        qty,
+		SubaccountID: ActiveSubaccount(),
+	}
+	if side == "yes" {
+		req.YesPrice = leg.Price
+	} else {
+		req.NoPrice = leg.Price
+	}
+	return req
+}
+
+// placePairLegs creates both legs at once. If either fails, the other is
+// cancelled.
+func placePairLegs(ctx context.Context, client *api.Client, result *pairResult) error {
+	presignRequests(ctx, client, http.MethodPost, api.TradeAPIPrefix+"/portfolio/orders", len(result.Legs))
+
+	var wg sync.WaitGroup
+	var errs [2]error
+	for i := range result.Legs {
+		wg.Add(1)
+		go func(leg *pairLeg, errp *error) {
+			defer wg.Done()
+			req := pairLegRequest(*leg, result.Side, result.Qty)
+
+			reqCtx, cancel := withTimeout(ctx)
+			defer cancel()
+			resp, err := client.CreateOrder(reqCtx, req)
+			if err != nil {
+				*errp = fmt.Errorf("failed to place %s leg on %s: %w", leg.Action, leg.Ticker, err)
+				return
+			}
+			leg.OrderID = resp.Order.OrderID
+			leg.Status = resp.Order.Status
+			leg.Filled = resp.Order.FillCount
+		}(&result.Legs[i], &errs[i])
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			cancelPairRemainder(client, result)
+			return err
+		}
+	}
+	return nil
+}
+
+// workPair polls both legs until they fill, repricing the lagging leg. It
+// returns why the pair stopped early, or "" once both legs are filled.
+// Polling and repricing pause during known exchange downtime; the timeout
+// keeps running.
+func workPair(ctx context.Context, client *api.Client, result *pairResult, calendar *exchangeCalendar, heartbeatLost <-chan struct{}) string {
+	deadline := time.After(pairTimeout)
+	ticker := time.NewTicker(pairInterval)
+	defer ticker.Stop()
+
+	paused := false
+	for {
+		if !calendar.pause(time.Now(), &paused, "repricing") {
+			if reason, done := stepPair(ctx, client, result); done {
+				return reason
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "interrupted"
+		case <-heartbeatLost:
+			return fmt.Sprintf("WebSocket down for more than %s", heartbeatTTL)
+		case <-deadline:
+			return fmt.Sprintf("timed out after %s", pairTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// stepPair refreshes both legs and reprices the lagging one. It reports
+// whether the pair is done and, if it stopped early, why.
+func stepPair(ctx context.Context, client *api.Client, result *pairResult) (string, bool) {
+	for i := range result.Legs {
+		leg := &result.Legs[i]
+		reqCtx, cancel := withTimeout(ctx)
+		resp, err := client.GetOrder(reqCtx, leg.OrderID)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to get order %s: %v\n", leg.OrderID, err)
+			}
+			continue
+		}
+		leg.Filled = resp.Order.FillCount
+		leg.Status = resp.Order.Status
+	}
+
+	if result.Legs[0].Filled >= result.Qty && result.Legs[1].Filled >= result.Qty {
+		return "", true
+	}
+	if i := brokenLeg(result.Legs, result.Qty); i >= 0 {
+		return fmt.Sprintf("%s leg on %s was canceled", result.Legs[i].Action, result.Legs[i].Ticker), true
+	}
+
+	if i := laggingLeg(result.Legs, pairMaxSlippage); i >= 0 {
+		leg := &result.Legs[i]
+		price := leg.nextPrice()
+		reqCtx, cancel := withTimeout(ctx)
+		_, err := client.AmendOrder(reqCtx, leg.OrderID, models.AmendOrderRequest{Price: price})
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to reprice %s leg: %v\n", leg.Action, err)
+			}
+		} else {
+			leg.Price = price
+			if IsVerbose() {
+				fmt.Fprintf(os.Stderr, "Repriced %s leg on %s to %d cents (%d/%d filled)\n",
+					leg.Action, leg.Ticker, price, leg.Filled, result.Qty)
+			}
+		}
+	}
+	return "", false
+}
+
+// cancelPairRemainder cancels whatever is still resting on either leg
+func cancelPairRemainder(client *api.Client, result *pairResult) {
+	for i := range result.Legs {
+		leg := &result.Legs[i]
+		if leg.OrderID == "" || leg.Status == models.OrderStatusCanceled || leg.Status == models.OrderStatusExecuted {
+			continue
+		}
+
+		// The command context may already be cancelled by Ctrl-C
+		ctx, cancel := withTimeout(context.Background())
+		resp, err := client.CancelOrder(ctx, leg.OrderID)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to cancel %s leg %s: %v\n", leg.Action, leg.OrderID, err)
+			continue
+		}
+		leg.Filled = resp.Order.FillCount
+		leg.Status = resp.Order.Status
+	}
+}
+
+func renderPairResult(result pairResult) {
+	headers := []string{"Leg", "Order ID", "Market", "Start", "Price", "Filled", "Status"}
+	rows := make([][]string, 0, len(result.Legs))
+	for _, l := range result.Legs {
+		rows = append(rows, []string{
+			strings.ToUpper(string(l.Action)),
+			truncateOrderID(l.OrderID),
+			l.Ticker,
+			fmt.Sprintf("%d¢", l.StartPrice),
+			fmt.Sprintf("%d¢", l.Price),
+			fmt.Sprintf("%d/%d", l.Filled, result.Qty),
+			string(l.Status),
+		})
+	}
+	fmt.Fprintln(ui.Writer())
+	ui.RenderTable(headers, rows)
+	fmt.Fprintln(ui.Writer())
+
+	if result.Completed {
+		PrintSuccess("Both legs filled")
+		return
+	}
+	PrintWarning(fmt.Sprintf("Stopped: %s", result.Reason))
+	if result.Imbalance != 0 {
+		PrintWarning(fmt.Sprintf("Legs are unbalanced by %d contracts", abs(result.Imbalance)))
+	}
+}
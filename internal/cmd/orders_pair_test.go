@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestParseLegSpec(t *testing.T) {
+	ticker, price, err := parseLegSpec("KXBTC-26FEB12-B97000@45")
+	if err != nil || ticker != "KXBTC-26FEB12-B97000" || price != 45 {
+		t.Errorf("parseLegSpec() = %q, %d, %v", ticker, price, err)
+	}
+
+	for _, spec := range []string{"T1", "T1@", "@45", "T1@abc", "T1@0", "T1@100"} {
+		if _, _, err := parseLegSpec(spec); err == nil {
+			t.Errorf("parseLegSpec(%q) expected error", spec)
+		}
+	}
+}
+
+func TestLaggingLeg(t *testing.T) {
+	buy := pairLeg{Action: models.OrderActionBuy, StartPrice: 45, Price: 45}
+	sell := pairLeg{Action: models.OrderActionSell, StartPrice: 55, Price: 55}
+
+	if got := laggingLeg([2]pairLeg{buy, sell}, 2); got != -1 {
+		t.Errorf("balanced legs: laggingLeg() = %d, want -1", got)
+	}
+
+	sell.Filled = 10
+	if got := laggingLeg([2]pairLeg{buy, sell}, 2); got != 0 {
+		t.Errorf("buy behind: laggingLeg() = %d, want 0", got)
+	}
+
+	buy.Price = 47
+	if got := laggingLeg([2]pairLeg{buy, sell}, 2); got != -1 {
+		t.Errorf("buy out of slippage: laggingLeg() = %d, want -1", got)
+	}
+
+	buy.Filled, sell.Filled = 10, 4
+	if got := laggingLeg([2]pairLeg{buy, sell}, 2); got != 1 {
+		t.Errorf("sell behind: laggingLeg() = %d, want 1", got)
+	}
+	if next := sell.nextPrice(); next != 54 {
+		t.Errorf("sell nextPrice() = %d, want 54", next)
+	}
+}
+
+func TestBrokenLeg(t *testing.T) {
+	legs := [2]pairLeg{
+		{Filled: 20, Status: models.OrderStatusExecuted},
+		{Filled: 5, Status: models.OrderStatusCanceled},
+	}
+	if got := brokenLeg(legs, 20); got != 1 {
+		t.Errorf("brokenLeg() = %d, want 1", got)
+	}
+	legs[1].Status = models.OrderStatusResting
+	if got := brokenLeg(legs, 20); got != -1 {
+		t.Errorf("brokenLeg() = %d, want -1", got)
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+// outputSink is the file opened for --output-file, nil when output goes to
+// stdout
+var outputSink *os.File
+
+// openOutputFile sends rendered output to --output-file, replacing the file.
+// Colors are turned off since the file is not a terminal. Order previews,
+// warnings, prompts, the environment banner and errors still go to the
+// terminal, so a confirmation never asks about something the user cannot see.
+func openOutputFile() error {
+	if outputFile == "" || outputSink != nil {
+		return nil
+	}
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to open --output-file: %w", err)
+	}
+	outputSink = f
+	ui.SetWriter(f)
+	ui.DisableColor()
+	return nil
+}
+
+// closeOutputFile flushes and closes the --output-file, restoring stdout
+func closeOutputFile() error {
+	if outputSink == nil {
+		return nil
+	}
+	f := outputSink
+	outputSink = nil
+	ui.SetWriter(nil)
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write --output-file: %w", err)
+	}
+	return nil
+}
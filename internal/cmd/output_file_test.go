@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+func TestOutputFile_OrderPreviewStaysOnTerminal(t *testing.T) {
+	oldCfg, oldFile, oldNoInput := cfg, outputFile, noInput
+	t.Cleanup(func() {
+		cfg, outputFile, noInput = oldCfg, oldFile, oldNoInput
+		ui.SetTerminal(nil)
+		for _, name := range orderCreateRequiredFlags {
+			ordersCreateCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+
+	cfg = &config.Config{API: config.APIConfig{Production: true, Timeout: 5 * time.Second}}
+	outputFile = filepath.Join(t.TempDir(), "out.txt")
+	noInput = true
+	var terminal bytes.Buffer
+	ui.SetTerminal(&terminal)
+
+	for name, value := range map[string]string{"market": "KXBTC-26FEB12-B97000", "side": "yes", "qty": "3", "price": "41"} {
+		if err := ordersCreateCmd.Flags().Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := openOutputFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	// --no-input refuses the confirmation after the preview is shown
+	err := runOrdersCreate(ordersCreateCmd, nil)
+	if closeErr := closeOutputFile(); closeErr != nil {
+		t.Fatal(closeErr)
+	}
+	if !errors.Is(err, errNoInput) {
+		t.Fatalf("runOrdersCreate() error = %v, want %v", err, errNoInput)
+	}
+
+	file, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(file), "Order Preview") || strings.Contains(string(file), "Max Cost") {
+		t.Errorf("order preview written to --output-file:\n%s", file)
+	}
+	for _, want := range []string{"Order Preview", "PRODUCTION", "KXBTC-26FEB12-B97000", "Max Cost"} {
+		if !strings.Contains(terminal.String(), want) {
+			t.Errorf("terminal missing %q:\n%s", want, terminal.String())
+		}
+	}
+}
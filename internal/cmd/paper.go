@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/paper"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var (
+	paperMode    bool
+	paperFile    string
+	paperBalance int
+)
+
+var paperCmd = &cobra.Command{
+	Use:   "paper",
+	Short: "Inspect and reset the local paper trading account",
+	Long: `With --paper, order and portfolio commands run against a simulated account
+instead of the exchange. New orders fill against the live orderbook at the
+prices they cross; the remainder of a limit order rests and fills once a
+later book crosses it. Balance, positions, orders and fills are kept in
+paper.json in the data directory (see 'config paths').
+
+Market data still comes from the live API. Fees are not simulated, and paper
+fills do not deplete the live book: an order never fills twice against the
+quantity it has already taken, but separate orders may. Writes that are not
+simulated, such as RFQs or transfers, are refused in paper mode.`,
+	Example: `  kalshi-cli --paper orders create --market KXBTC-26JAN-B100000 --side yes --qty 10 --price 45
+  kalshi-cli --paper portfolio positions
+  kalshi-cli paper status
+  kalshi-cli paper reset --balance 500`,
+}
+
+var paperStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the paper balance, positions and resting orders",
+	Args:  cobra.NoArgs,
+	RunE:  runPaperStatus,
+}
+
+var paperResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Discard the paper account and start again",
+	Args:  cobra.NoArgs,
+	RunE:  runPaperReset,
+}
+
+func init() {
+	rootCmd.AddCommand(paperCmd)
+	paperCmd.AddCommand(paperStatusCmd)
+	paperCmd.AddCommand(paperResetCmd)
+
+	rootCmd.PersistentFlags().BoolVar(&paperMode, "paper", false, "simulate orders locally against the live orderbook instead of sending them (see 'paper')")
+	paperResetCmd.Flags().IntVar(&paperBalance, "balance", paper.DefaultBalance/100, "starting balance in dollars")
+}
+
+// paperPath returns the paper account file location
+func paperPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return paper.DefaultPath(dir), nil
+}
+
+// initPaper resolves the paper account file when --paper is set
+func initPaper() error {
+	if !paperMode {
+		return nil
+	}
+	path, err := paperPath()
+	if err != nil {
+		return err
+	}
+	paperFile = path
+	return nil
+}
+
+// usePaper routes the client's order and portfolio requests to the paper
+// account, filling against books read through the client itself
+func usePaper(client *api.Client) {
+	var next http.RoundTripper
+	if httpTransport != nil {
+		next = httpTransport
+	}
+	client.SetTransport(paper.NewTransport(next, paperFile, client.GetOrderbook))
+}
+
+// paperStatus is the --json shape of 'paper status'
+type paperStatus struct {
+	StartingBalance int                     `json:"starting_balance"`
+	Balance         int                     `json:"balance"`
+	Available       int                     `json:"available"`
+	Exposure        int                     `json:"exposure"`
+	RealizedPnl     int                     `json:"realized_pnl"`
+	Positions       []models.MarketPosition `json:"positions"`
+	RestingOrders   []models.Order          `json:"resting_orders"`
+	Fills           int                     `json:"fills"`
+}
+
+func newPaperStatus(s *paper.State) paperStatus {
+	status := paperStatus{
+		StartingBalance: s.StartingBalance,
+		Balance:         s.Balance,
+		Available:       s.Available(),
+		Exposure:        s.Exposure(),
+		Positions:       []models.MarketPosition{},
+		RestingOrders:   []models.Order{},
+		Fills:           len(s.Fills),
+	}
+	for _, p := range s.MarketPositions() {
+		status.RealizedPnl += p.RealizedPnl
+		if p.Position != 0 {
+			status.Positions = append(status.Positions, p)
+		}
+	}
+	for _, o := range s.Orders {
+		if o.Status == models.OrderStatusResting {
+			status.RestingOrders = append(status.RestingOrders, o)
+		}
+	}
+	return status
+}
+
+func runPaperStatus(cmd *cobra.Command, args []string) error {
+	path, err := paperPath()
+	if err != nil {
+		return err
+	}
+	state, err := paper.Load(path)
+	if err != nil {
+		return err
+	}
+	status := newPaperStatus(state)
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			ui.RenderKeyValue([][]string{
+				{"Balance", ui.FormatPrice(status.Balance)},
+				{"Available", ui.FormatPrice(status.Available)},
+				{"Exposure", ui.FormatPrice(status.Exposure)},
+				{"Realized P&L", ui.FormatPriceStyled(status.RealizedPnl, status.RealizedPnl >= 0)},
+				{"Starting Balance", ui.FormatPrice(status.StartingBalance)},
+				{"Fills", fmt.Sprintf("%d", status.Fills)},
+			})
+			if len(status.Positions) > 0 {
+				fmt.Fprintln(ui.Writer())
+				renderPositionsTable(status.Positions)
+			}
+			if len(status.RestingOrders) > 0 {
+				fmt.Fprintln(ui.Writer())
+				renderOrdersTable(status.RestingOrders)
+			}
+		},
+		status,
+		func() {
+			fmt.Fprintf(ui.Writer(), "balance\t%d\n", status.Balance)
+			fmt.Fprintf(ui.Writer(), "available\t%d\n", status.Available)
+			fmt.Fprintf(ui.Writer(), "exposure\t%d\n", status.Exposure)
+			fmt.Fprintf(ui.Writer(), "realized_pnl\t%d\n", status.RealizedPnl)
+			for _, p := range status.Positions {
+				fmt.Fprintf(ui.Writer(), "position\t%s\t%d\t%d\n", p.Ticker, p.Position, p.MarketExposure)
+			}
+			for _, o := range status.RestingOrders {
+				fmt.Fprintf(ui.Writer(), "order\t%s\t%s\n", o.OrderID, orderLabel(o))
+			}
+		},
+	)
+}
+
+func runPaperReset(cmd *cobra.Command, args []string) error {
+	if paperBalance <= 0 {
+		return fmt.Errorf("--balance must be positive")
+	}
+	path, err := paperPath()
+	if err != nil {
+		return err
+	}
+
+	confirmed, err := confirmAction(fmt.Sprintf("Discard the paper account and start with $%d?", paperBalance))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Reset cancelled")
+		return nil
+	}
+
+	if err := paper.Update(path, func(s *paper.State) error {
+		*s = *paper.NewState(paperBalance * 100)
+		return nil
+	}); err != nil {
+		return err
+	}
+	PrintSuccess(fmt.Sprintf("Paper account reset with $%d", paperBalance))
+	return nil
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var pingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Measure REST and WebSocket latency to the API",
+	Long: `Measure round-trip latency to the Kalshi API over a fresh connection per
+sample, so DNS lookup, TCP connect and TLS handshake are timed every time.
+
+REST samples fetch the public exchange status. With --ws each sample also
+opens a signed WebSocket connection, subscribes to the ticker channel and
+times the first ticker message.
+
+The summary shows min, p50, p90, p99 and max for every phase. The proxy, CA
+bundle, base URL and extra headers in effect apply, so different network paths
+can be compared by changing them.`,
+	Example: `  kalshi-cli ping
+  kalshi-cli ping --count 20 --ws
+  kalshi-cli ping --proxy socks5://127.0.0.1:1080 --json`,
+	RunE: runPing,
+}
+
+var (
+	pingCount int
+	pingWS    bool
+)
+
+// pingWSMessageTimeout bounds the wait for the first ticker message
+const pingWSMessageTimeout = 10 * time.Second
+
+func init() {
+	rootCmd.AddCommand(pingCmd)
+
+	pingCmd.Flags().IntVar(&pingCount, "count", 5, "number of samples")
+	pingCmd.Flags().BoolVar(&pingWS, "ws", false, "also measure WebSocket connect and time to first message (requires credentials)")
+}
+
+// pingSample is the timing of one request; phases that did not happen are zero
+type pingSample struct {
+	DNS          time.Duration
+	Connect      time.Duration
+	TLS          time.Duration
+	FirstByte    time.Duration
+	Total        time.Duration
+	Status       int
+	FirstMessage time.Duration
+	Err          error
+}
+
+// latencySummary is the distribution of one phase across samples
+type latencySummary struct {
+	Phase   string  `json:"phase"`
+	Samples int     `json:"samples"`
+	MinMs   float64 `json:"min_ms"`
+	P50Ms   float64 `json:"p50_ms"`
+	P90Ms   float64 `json:"p90_ms"`
+	P99Ms   float64 `json:"p99_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
+
+// pingResult is the output of ping
+type pingResult struct {
+	Environment string           `json:"environment"`
+	RESTURL     string           `json:"rest_url"`
+	WSURL       string           `json:"ws_url,omitempty"`
+	Count       int              `json:"count"`
+	Failures    int              `json:"failures"`
+	Errors      []string         `json:"errors,omitempty"`
+	Summary     []latencySummary `json:"summary"`
+}
+
+// percentile returns the nearest-rank percentile p (0-100) of sorted samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(sorted)) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// summarizeLatency reduces one phase to its percentiles, skipping zero
+// samples; ok is false when no sample measured the phase
+func summarizeLatency(phase string, samples []time.Duration) (latencySummary, bool) {
+	var measured []time.Duration
+	for _, d := range samples {
+		if d > 0 {
+			measured = append(measured, d)
+		}
+	}
+	if len(measured) == 0 {
+		return latencySummary{}, false
+	}
+	sort.Slice(measured, func(i, j int) bool { return measured[i] < measured[j] })
+
+	ms := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+	return latencySummary{
+		Phase:   phase,
+		Samples: len(measured),
+		MinMs:   ms(measured[0]),
+		P50Ms:   ms(percentile(measured, 50)),
+		P90Ms:   ms(percentile(measured, 90)),
+		P99Ms:   ms(percentile(measured, 99)),
+		MaxMs:   ms(measured[len(measured)-1]),
+	}, true
+}
+
+// pingTransport returns a transport with the network settings in effect that
+// never reuses connections
+func pingTransport() *http.Transport {
+	var transport *http.Transport
+	if httpTransport != nil {
+		transport = httpTransport.Clone()
+	} else {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	transport.DisableKeepAlives = true
+	return transport
+}
+
+// probeREST times one GET of url, broken down by connection phase
+func probeREST(ctx context.Context, client *http.Client, url string, headers map[string]string) pingSample {
+	var s pingSample
+	var dnsStart, connectStart, tlsStart time.Time
+	start := time.Now()
+
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:           func(httptrace.DNSDoneInfo) { s.DNS = time.Since(dnsStart) },
+		ConnectStart:      func(string, string) { connectStart = time.Now() },
+		ConnectDone:       func(string, string, error) { s.Connect = time.Since(connectStart) },
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { s.TLS = time.Since(tlsStart) },
+		GotFirstResponseByte: func() {
+			s.FirstByte = time.Since(start)
+		},
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodGet, url, nil)
+	if err != nil {
+		s.Err = err
+		return s
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		s.Err = err
+		return s
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	s.Total = time.Since(start)
+	s.Status = resp.StatusCode
+	if resp.StatusCode >= 400 {
+		s.Err = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return s
+}
+
+// probeWS times one signed WebSocket handshake (Total) and the first ticker
+// message after subscribing (FirstMessage)
+func probeWS(ctx context.Context) pingSample {
+	var s pingSample
+
+	opts, err := buildClientOptions(cfg)
+	if err != nil {
+		s.Err = err
+		return s
+	}
+	client := websocket.NewClient(opts)
+
+	first := make(chan struct{}, 1)
+	client.RegisterHandler(websocket.ChannelMarketTicker, websocket.HandlerFunc(func(websocket.Message) error {
+		select {
+		case first <- struct{}{}:
+		default:
+		}
+		return nil
+	}))
+
+	start := time.Now()
+	if err := client.Connect(ctx); err != nil {
+		s.Err = err
+		return s
+	}
+	defer client.Close()
+	s.Total = time.Since(start)
+
+	subscribed := time.Now()
+	if err := client.Subscribe(ctx, websocket.ChannelMarketTicker, nil); err != nil {
+		s.Err = fmt.Errorf("failed to subscribe: %w", err)
+		return s
+	}
+	select {
+	case <-first:
+		s.FirstMessage = time.Since(subscribed)
+	case <-time.After(pingWSMessageTimeout):
+		s.Err = fmt.Errorf("no ticker message within %s", pingWSMessageTimeout)
+	case <-ctx.Done():
+		s.Err = ctx.Err()
+	}
+	return s
+}
+
+func runPing(cmd *cobra.Command, args []string) error {
+	if pingCount < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
+
+	result := pingResult{
+		Environment: cfg.Environment(),
+		RESTURL:     cfg.BaseURL() + api.TradeAPIPrefix + "/exchange/status",
+		Count:       pingCount,
+	}
+	client := &http.Client{Transport: pingTransport(), Timeout: cfg.API.Timeout}
+
+	var rest, ws []pingSample
+	fail := func(kind string, i int, err error) {
+		result.Failures++
+		result.Errors = append(result.Errors, fmt.Sprintf("%s #%d: %v", kind, i+1, err))
+		if IsVerbose() {
+			PrintWarning(fmt.Sprintf("%s sample %d failed: %v", kind, i+1, err))
+		}
+	}
+
+	for i := 0; i < pingCount; i++ {
+		ctx, cancel := withTimeout(context.Background())
+		s := probeREST(ctx, client, result.RESTURL, cfg.ExtraHeaders)
+		cancel()
+		if s.Err != nil {
+			fail("REST", i, s.Err)
+		}
+		rest = append(rest, s)
+	}
+
+	if pingWS {
+		result.WSURL = cfg.WebSocketURL()
+		for i := 0; i < pingCount; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.API.Timeout+pingWSMessageTimeout)
+			s := probeWS(ctx)
+			cancel()
+			if s.Err != nil {
+				fail("WebSocket", i, s.Err)
+			}
+			ws = append(ws, s)
+		}
+	}
+
+	phases := []struct {
+		name    string
+		samples []pingSample
+		get     func(pingSample) time.Duration
+	}{
+		{"DNS", rest, func(s pingSample) time.Duration { return s.DNS }},
+		{"TCP connect", rest, func(s pingSample) time.Duration { return s.Connect }},
+		{"TLS handshake", rest, func(s pingSample) time.Duration { return s.TLS }},
+		{"REST first byte", rest, func(s pingSample) time.Duration { return s.FirstByte }},
+		{"REST total", rest, func(s pingSample) time.Duration { return s.Total }},
+		{"WS connect", ws, func(s pingSample) time.Duration { return s.Total }},
+		{"WS first message", ws, func(s pingSample) time.Duration { return s.FirstMessage }},
+	}
+	result.Summary = []latencySummary{}
+	for _, p := range phases {
+		durations := make([]time.Duration, len(p.samples))
+		for i, s := range p.samples {
+			durations[i] = p.get(s)
+		}
+		if summary, ok := summarizeLatency(p.name, durations); ok {
+			result.Summary = append(result.Summary, summary)
+		}
+	}
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() { renderPingTable(result) },
+		result,
+		func() {
+			for _, s := range result.Summary {
+				ui.PrintPlain("%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f", s.Phase, s.Samples, s.MinMs, s.P50Ms, s.P90Ms, s.P99Ms, s.MaxMs)
+			}
+		},
+	); err != nil {
+		return err
+	}
+
+	if result.Failures == len(rest)+len(ws) {
+		return fmt.Errorf("all %d samples failed", result.Failures)
+	}
+	return nil
+}
+
+func renderPingTable(r pingResult) {
+	fmt.Fprintf(ui.Writer(), "%s  %s\n", ui.TitleStyle.Render("Ping"), r.RESTURL)
+	if r.WSURL != "" {
+		fmt.Fprintf(ui.Writer(), "      %s\n", r.WSURL)
+	}
+	fmt.Fprintln(ui.Writer())
+
+	headers := []string{"Phase", "Samples", "Min", "Median", "90%", "99%", "Max"}
+	rows := make([][]string, len(r.Summary))
+	ms := func(v float64) string { return fmt.Sprintf("%.1fms", v) }
+	for i, s := range r.Summary {
+		rows[i] = []string{s.Phase, fmt.Sprintf("%d", s.Samples), ms(s.MinMs), ms(s.P50Ms), ms(s.P90Ms), ms(s.P99Ms), ms(s.MaxMs)}
+	}
+	ui.RenderTable(headers, rows)
+
+	if r.Failures > 0 {
+		fmt.Fprintln(ui.Writer())
+		PrintWarning(fmt.Sprintf("%d sample(s) failed", r.Failures))
+		for _, e := range r.Errors {
+			fmt.Fprintln(ui.Writer(), "  "+e)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	var samples []time.Duration
+	for i := 1; i <= 10; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 1 * time.Millisecond},
+		{50, 5 * time.Millisecond},
+		{90, 9 * time.Millisecond},
+		{99, 10 * time.Millisecond},
+		{100, 10 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := percentile(samples, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile of no samples = %v, want 0", got)
+	}
+}
+
+func TestSummarizeLatency_SkipsUnmeasured(t *testing.T) {
+	s, ok := summarizeLatency("TLS handshake", []time.Duration{0, 30 * time.Millisecond, 10 * time.Millisecond, 0})
+	if !ok {
+		t.Fatal("expected a summary")
+	}
+	if s.Samples != 2 || s.MinMs != 10 || s.MaxMs != 30 || s.P50Ms != 10 {
+		t.Errorf("unexpected summary: %+v", s)
+	}
+
+	if _, ok := summarizeLatency("DNS", []time.Duration{0, 0}); ok {
+		t.Error("expected no summary when no sample measured the phase")
+	}
+}
+
+func TestProbeREST_TimesFreshTLSConnection(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Gateway-Token")
+		w.Write([]byte(`{"exchange_active":true}`))
+	}))
+	defer server.Close()
+
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		s := probeREST(context.Background(), client, server.URL, map[string]string{"X-Gateway-Token": "secret"})
+		if s.Err != nil {
+			t.Fatalf("sample %d: unexpected error: %v", i, s.Err)
+		}
+		if s.Connect <= 0 || s.TLS <= 0 || s.FirstByte <= 0 || s.Total < s.FirstByte {
+			t.Errorf("sample %d: expected connect, TLS and first byte timings, got %+v", i, s)
+		}
+	}
+	if gotHeader != "secret" {
+		t.Errorf("expected extra headers to be sent, got %q", gotHeader)
+	}
+}
+
+func TestProbeREST_HTTPErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	s := probeREST(context.Background(), server.Client(), server.URL, nil)
+	if s.Err == nil || s.Status != http.StatusBadGateway {
+		t.Errorf("expected HTTP 502 to be reported as a failure, got status=%d err=%v", s.Status, s.Err)
+	}
+}
@@ -1,12 +1,11 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -16,10 +15,11 @@ import (
 )
 
 var portfolioCmd = &cobra.Command{
-	Use:   "portfolio",
-	Short: "Manage your portfolio and account",
+	Use:     "portfolio",
+	Aliases: []string{"p"},
+	Short:   "Manage your portfolio and account",
 	Long: `View and manage your Kalshi portfolio including balance, positions,
-fills, settlements, and subaccounts.`,
+fills, settlements, P&L, and subaccounts.`,
 	Example: `  kalshi-cli portfolio balance
   kalshi-cli portfolio positions
   kalshi-cli portfolio fills --limit 10
@@ -40,9 +40,15 @@ All values are in cents.`,
 var positionsCmd = &cobra.Command{
 	Use:   "positions",
 	Short: "List positions",
-	Long:  `List your current market positions with details including average cost, P&L, and exposure.`,
+	Long: `List your current market positions with details including average cost, P&L, and exposure.
+
+With --diff-from, the current positions are saved as a snapshot under
+~/.kalshi/snapshots and compared with an earlier one: 'last' for the previous
+snapshot of this environment and subaccount, or a snapshot file path. The diff
+lists new positions, size or exposure changes, and closed positions.`,
 	Example: `  kalshi-cli portfolio positions
-  kalshi-cli portfolio positions --market INXD-25FEB07-B5523.99`,
+  kalshi-cli portfolio positions --market INXD-25FEB07-B5523.99
+  kalshi-cli portfolio positions --diff-from last`,
 	RunE: runPositions,
 }
 
@@ -51,7 +57,9 @@ var fillsCmd = &cobra.Command{
 	Short: "List fills",
 	Long:  `List your trade fills showing executed orders and their details.`,
 	Example: `  kalshi-cli portfolio fills
-  kalshi-cli portfolio fills --limit 20`,
+  kalshi-cli portfolio fills --limit 20
+  kalshi-cli portfolio fills --start "last monday" --end today
+  kalshi-cli portfolio fills --all --start 2025-01-01 --concurrency 8 --json > fills.json`,
 	RunE: runFills,
 }
 
@@ -60,7 +68,8 @@ var settlementsCmd = &cobra.Command{
 	Short: "List settlements",
 	Long:  `List your market settlements showing resolved positions and their outcomes.`,
 	Example: `  kalshi-cli portfolio settlements
-  kalshi-cli portfolio settlements --limit 10`,
+  kalshi-cli portfolio settlements --limit 10
+  kalshi-cli portfolio settlements --all --start 2025-01-01 --concurrency 8 --json`,
 	RunE: runSettlements,
 }
 
@@ -93,8 +102,18 @@ var subaccountsTransferCmd = &cobra.Command{
 
 var (
 	positionsMarket   string
+	positionsDiffFrom string
+	positionsCursor   string
 	fillsLimit        int
+	fillsStart        string
+	fillsEnd          string
+	fillsCursor       string
+	fillsAll          bool
 	settlementsLimit  int
+	settlementsStart  string
+	settlementsEnd    string
+	settlementsCursor string
+	settlementsAll    bool
 	transferFrom      int
 	transferTo        int
 	transferAmount    int
@@ -114,10 +133,22 @@ func init() {
 	subaccountsCmd.AddCommand(subaccountsTransferCmd)
 
 	positionsCmd.Flags().StringVar(&positionsMarket, "market", "", "filter by market ticker")
+	positionsCmd.Flags().StringVar(&positionsDiffFrom, "diff-from", "", "show changes since a snapshot ('last' or a snapshot file) and save a new one")
+	positionsCmd.Flags().StringVar(&positionsCursor, "cursor", "", "pagination cursor")
 
 	fillsCmd.Flags().IntVar(&fillsLimit, "limit", 100, "maximum number of fills to return")
+	fillsCmd.Flags().StringVar(&fillsStart, "start", "", "only fills at or after this time: "+timeArgHelp)
+	fillsCmd.Flags().StringVar(&fillsEnd, "end", "", "only fills before this time: "+timeArgHelp)
+	fillsCmd.Flags().StringVar(&fillsCursor, "cursor", "", "pagination cursor")
+	fillsCmd.Flags().BoolVar(&fillsAll, "all", false, "follow every page instead of returning one")
+	addConcurrencyFlag(fillsCmd)
 
 	settlementsCmd.Flags().IntVar(&settlementsLimit, "limit", 50, "maximum number of settlements to return")
+	settlementsCmd.Flags().StringVar(&settlementsStart, "start", "", "only settlements at or after this time: "+timeArgHelp)
+	settlementsCmd.Flags().StringVar(&settlementsEnd, "end", "", "only settlements before this time: "+timeArgHelp)
+	settlementsCmd.Flags().StringVar(&settlementsCursor, "cursor", "", "pagination cursor")
+	settlementsCmd.Flags().BoolVar(&settlementsAll, "all", false, "follow every page instead of returning one")
+	addConcurrencyFlag(settlementsCmd)
 
 	subaccountsTransferCmd.Flags().IntVar(&transferFrom, "from", 0, "source subaccount ID")
 	subaccountsTransferCmd.Flags().IntVar(&transferTo, "to", 0, "destination subaccount ID")
@@ -134,6 +165,20 @@ func runBalance(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := context.Background()
+
+	if sub := ActiveSubaccount(); sub > 0 {
+		balance, err := getSubaccountBalance(ctx, client, sub)
+		if err != nil {
+			return err
+		}
+		return ui.Output(
+			GetOutputFormat(),
+			func() { renderSubaccountBalanceTable(balance) },
+			balance,
+			func() { renderSubaccountBalancePlain(balance) },
+		)
+	}
+
 	balance, err := client.GetBalance(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get balance: %w", err)
@@ -163,7 +208,42 @@ func renderBalancePlain(balance *models.BalanceResponse) {
 	ui.PrintPlain("Total Balance: %s", ui.FormatPrice(balance.Balance+balance.PortfolioValue))
 }
 
+// getSubaccountBalance returns the balance entry for a single subaccount
+func getSubaccountBalance(ctx context.Context, client *api.Client, subaccountID int) (*models.SubaccountBalance, error) {
+	balances, err := client.GetSubaccountBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subaccount balances: %w", err)
+	}
+
+	for _, b := range balances.Balances {
+		if b.SubaccountID == subaccountID {
+			return &b, nil
+		}
+	}
+	return nil, fmt.Errorf("subaccount %d not found", subaccountID)
+}
+
+func renderSubaccountBalanceTable(balance *models.SubaccountBalance) {
+	pairs := [][]string{
+		{ui.BoldStyle.Render("Subaccount:"), strconv.Itoa(balance.SubaccountID)},
+		{ui.BoldStyle.Render("Available Balance:"), ui.FormatPrice(balance.AvailableBalance)},
+		{ui.BoldStyle.Render("Balance:"), ui.FormatPrice(balance.Balance)},
+	}
+
+	ui.RenderKeyValue(pairs)
+}
+
+func renderSubaccountBalancePlain(balance *models.SubaccountBalance) {
+	ui.PrintPlain("Subaccount: %d", balance.SubaccountID)
+	ui.PrintPlain("Available Balance: %s", ui.FormatPrice(balance.AvailableBalance))
+	ui.PrintPlain("Balance: %s", ui.FormatPrice(balance.Balance))
+}
+
 func runPositions(cmd *cobra.Command, args []string) error {
+	if positionsDiffFrom != "" && (positionsMarket != "" || positionsCursor != "") {
+		return fmt.Errorf("--diff-from compares full snapshots and cannot be combined with --market or --cursor")
+	}
+
 	client, err := createClient()
 	if err != nil {
 		return err
@@ -171,7 +251,9 @@ func runPositions(cmd *cobra.Command, args []string) error {
 
 	ctx := context.Background()
 	opts := api.PositionsOptions{
-		Ticker: positionsMarket,
+		Ticker:       positionsMarket,
+		SubaccountID: ActiveSubaccount(),
+		Cursor:       positionsCursor,
 	}
 
 	positions, err := client.GetPositions(ctx, opts)
@@ -179,15 +261,20 @@ func runPositions(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get positions: %w", err)
 	}
 
-	if len(positions.Positions) == 0 {
-		PrintWarning("No positions found")
+	if positionsDiffFrom != "" {
+		return runPositionsDiff(positions.Positions)
+	}
+	list := positions.Positions
+	sortIfDeterministic(list, func(i, j int) bool { return list[i].Ticker < list[j].Ticker })
+
+	if emptyListWarning(len(positions.Positions), "No positions found") {
 		return nil
 	}
 
 	return ui.Output(
 		GetOutputFormat(),
 		func() { renderPositionsTable(positions.Positions) },
-		positions,
+		listJSON(positions, positions.Positions, positions.Cursor),
 		func() { renderPositionsPlain(positions.Positions) },
 	)
 }
@@ -252,6 +339,14 @@ func abs(n int) int {
 }
 
 func runFills(cmd *cobra.Command, args []string) error {
+	if err := checkAllFlags(cmd, fillsAll); err != nil {
+		return err
+	}
+	start, end, err := timeRangeArgs(fillsStart, fillsEnd, time.Now())
+	if err != nil {
+		return err
+	}
+
 	client, err := createClient()
 	if err != nil {
 		return err
@@ -259,27 +354,52 @@ func runFills(cmd *cobra.Command, args []string) error {
 
 	ctx := context.Background()
 	opts := api.FillsOptions{
-		Limit: fillsLimit,
+		Limit:        fillsLimit,
+		SubaccountID: ActiveSubaccount(),
+		Cursor:       fillsCursor,
+	}
+	if !start.IsZero() {
+		opts.MinTS = start.Unix()
+	}
+	if !end.IsZero() {
+		opts.MaxTS = end.Unix()
 	}
 
-	fills, err := client.GetFills(ctx, opts)
-	if err != nil {
+	var fills *models.FillsResponse
+	if fillsAll {
+		all, err := allFills(ctx, client, opts)
+		if err != nil {
+			return fmt.Errorf("failed to get fills: %w", err)
+		}
+		fills = &models.FillsResponse{Fills: all}
+	} else if fills, err = client.GetFills(ctx, opts); err != nil {
 		return fmt.Errorf("failed to get fills: %w", err)
 	}
 
-	if len(fills.Fills) == 0 {
-		PrintWarning("No fills found")
+	if emptyListWarning(len(fills.Fills), "No fills found") {
 		return nil
 	}
 
 	return ui.Output(
 		GetOutputFormat(),
 		func() { renderFillsTable(fills.Fills) },
-		fills,
+		listJSON(fills, fills.Fills, fills.Cursor),
 		func() { renderFillsPlain(fills.Fills) },
 	)
 }
 
+// checkAllFlags rejects paging flags that do not apply: --cursor and --limit
+// with --all, and --concurrency without it
+func checkAllFlags(cmd *cobra.Command, all bool) error {
+	if all && (cmd.Flags().Changed("cursor") || cmd.Flags().Changed("limit")) {
+		return fmt.Errorf("--cursor and --limit do not apply with --all")
+	}
+	if !all && cmd.Flags().Changed("concurrency") {
+		return fmt.Errorf("--concurrency only applies with --all")
+	}
+	return nil
+}
+
 func renderFillsTable(fills []models.Fill) {
 	headers := []string{"Time", "Ticker", "Side", "Action", "Count", "Price", "Taker"}
 	rows := make([][]string, 0, len(fills))
@@ -327,6 +447,14 @@ func renderFillsPlain(fills []models.Fill) {
 }
 
 func runSettlements(cmd *cobra.Command, args []string) error {
+	if err := checkAllFlags(cmd, settlementsAll); err != nil {
+		return err
+	}
+	start, end, err := timeRangeArgs(settlementsStart, settlementsEnd, time.Now())
+	if err != nil {
+		return err
+	}
+
 	client, err := createClient()
 	if err != nil {
 		return err
@@ -334,23 +462,39 @@ func runSettlements(cmd *cobra.Command, args []string) error {
 
 	ctx := context.Background()
 	opts := api.SettlementsOptions{
-		Limit: settlementsLimit,
+		Limit:        settlementsLimit,
+		SubaccountID: ActiveSubaccount(),
+		Cursor:       settlementsCursor,
+	}
+	if !start.IsZero() {
+		opts.MinTS = start.Unix()
+	}
+	if !end.IsZero() {
+		opts.MaxTS = end.Unix()
 	}
 
-	settlements, err := client.GetSettlements(ctx, opts)
-	if err != nil {
+	var settlements *models.SettlementsResponse
+	if settlementsAll {
+		progress := ui.NewProgress("settlements", 0)
+		opts.Limit = reconcilePageSize
+		all, err := client.GetAllSettlements(ctx, opts, historyOptions(progress))
+		progress.Done()
+		if err != nil {
+			return fmt.Errorf("failed to get settlements: %w", err)
+		}
+		settlements = &models.SettlementsResponse{Settlements: all}
+	} else if settlements, err = client.GetSettlements(ctx, opts); err != nil {
 		return fmt.Errorf("failed to get settlements: %w", err)
 	}
 
-	if len(settlements.Settlements) == 0 {
-		PrintWarning("No settlements found")
+	if emptyListWarning(len(settlements.Settlements), "No settlements found") {
 		return nil
 	}
 
 	return ui.Output(
 		GetOutputFormat(),
 		func() { renderSettlementsTable(settlements.Settlements) },
-		settlements,
+		listJSON(settlements, settlements.Settlements, settlements.Cursor),
 		func() { renderSettlementsPlain(settlements.Settlements) },
 	)
 }
@@ -400,15 +544,14 @@ func runSubaccountsList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get subaccounts: %w", err)
 	}
 
-	if len(subaccounts.Subaccounts) == 0 {
-		PrintWarning("No subaccounts found")
+	if emptyListWarning(len(subaccounts.Subaccounts), "No subaccounts found") {
 		return nil
 	}
 
 	return ui.Output(
 		GetOutputFormat(),
 		func() { renderSubaccountsTable(subaccounts.Subaccounts) },
-		subaccounts,
+		listJSON(subaccounts, subaccounts.Subaccounts, ""),
 		func() { renderSubaccountsPlain(subaccounts.Subaccounts) },
 	)
 }
@@ -467,15 +610,13 @@ func runSubaccountsTransfer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("amount must be positive (in cents)")
 	}
 
-	if !SkipConfirmation() {
-		confirmed, err := confirmTransfer(transferFrom, transferTo, transferAmount)
-		if err != nil {
-			return err
-		}
-		if !confirmed {
-			PrintWarning("Transfer cancelled")
-			return nil
-		}
+	confirmed, err := confirmTransfer(transferFrom, transferTo, transferAmount)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Transfer cancelled")
+		return nil
 	}
 
 	client, err := createClient()
@@ -517,18 +658,17 @@ func runSubaccountsTransfer(cmd *cobra.Command, args []string) error {
 }
 
 func confirmTransfer(from, to, amount int) (bool, error) {
-	fmt.Printf("\nTransfer Details:\n")
-	fmt.Printf("  From Subaccount: %d\n", from)
-	fmt.Printf("  To Subaccount:   %d\n", to)
-	fmt.Printf("  Amount:          %s\n\n", ui.FormatPrice(amount))
-	fmt.Print("Confirm transfer? [y/N]: ")
-
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	if SkipConfirmation() {
+		return true, nil
 	}
+	if err := requireInput("transfer confirmation"); err != nil {
+		return false, fmt.Errorf("%w (pass --yes to confirm)", err)
+	}
+
+	fmt.Fprintf(ui.Terminal(), "\nTransfer Details:\n")
+	fmt.Fprintf(ui.Terminal(), "  From Subaccount: %d\n", from)
+	fmt.Fprintf(ui.Terminal(), "  To Subaccount:   %d\n", to)
+	fmt.Fprintf(ui.Terminal(), "  Amount:          %s\n\n", ui.FormatPrice(amount))
 
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y" || response == "yes", nil
+	return confirmAction("Confirm transfer?")
 }
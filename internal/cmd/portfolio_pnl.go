@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/pnl"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var pnlCmd = &cobra.Command{
+	Use:   "pnl",
+	Short: "Show realized and unrealized P&L per market, per event and overall",
+	Long: `Join positions, fills and settlements into profit and loss for every market
+traded, rolled up by event and in total.
+
+Markets still open take realized P&L and fees from their position, and the
+contracts held are marked to market with the current quote: at the bid for
+the side held by default, what selling them now would fetch, or at the
+mid-point with --mark mid. Unrealized P&L is that value less what the held
+contracts cost. Markets that settled at or after --since realize their payout
+less cost, with fees summed from the fills in the same window. A market that
+cannot be fetched is warned about and left unmarked.
+
+P&L is realized plus unrealized, net of fees.`,
+	Example: `  kalshi-cli portfolio pnl
+  kalshi-cli portfolio pnl --since 90d --mark mid
+  kalshi-cli portfolio pnl --json`,
+	Args: cobra.NoArgs,
+	RunE: runPnL,
+}
+
+var (
+	pnlSince string
+	pnlMark  string
+)
+
+func init() {
+	portfolioCmd.AddCommand(pnlCmd)
+
+	pnlCmd.Flags().StringVar(&pnlSince, "since", "30d", "include markets settled at or after this time: "+timeArgHelp)
+	pnlCmd.Flags().StringVar(&pnlMark, "mark", pnl.MarkBid, "price open positions at the bid or mid")
+	addConcurrencyFlag(pnlCmd)
+}
+
+// allPositions pages through every market position, including markets with
+// no contracts still held
+func allPositions(ctx context.Context, client *api.Client) ([]models.MarketPosition, error) {
+	var positions []models.MarketPosition
+	opts := api.PositionsOptions{Limit: reconcilePageSize, SubaccountID: ActiveSubaccount()}
+	for {
+		reqCtx, cancel := withTimeout(ctx)
+		page, err := client.GetPositions(reqCtx, opts)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get positions: %w", err)
+		}
+		positions = append(positions, page.Positions...)
+		if page.Cursor == "" || len(page.Positions) == 0 {
+			return positions, nil
+		}
+		opts.Cursor = page.Cursor
+	}
+}
+
+// pnlMarkets fetches the market of every position and settlement for its
+// quote and event, in parallel. Markets that cannot be fetched are left out
+// with a warning: their held contracts are marked at zero and they are
+// grouped under their own ticker.
+func pnlMarkets(ctx context.Context, client *api.Client, positions []models.MarketPosition, settlements []models.Settlement) map[string]models.Market {
+	var tickers []string
+	seen := make(map[string]bool)
+	add := func(ticker string) {
+		if !seen[ticker] {
+			seen[ticker] = true
+			tickers = append(tickers, ticker)
+		}
+	}
+	for _, p := range positions {
+		add(p.Ticker)
+	}
+	for _, s := range settlements {
+		add(s.Ticker)
+	}
+
+	markets, errs := api.Collect(client.Group(ctx), tickers, func(ctx context.Context, ticker string) (models.Market, error) {
+		reqCtx, cancel := withTimeout(ctx)
+		defer cancel()
+		m, err := client.GetMarket(reqCtx, ticker)
+		if err != nil {
+			return models.Market{}, err
+		}
+		return *m, nil
+	})
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get market %s, so it is not marked to market: %v\n", e.Name, e.Err)
+	}
+	return markets
+}
+
+func runPnL(cmd *cobra.Command, args []string) error {
+	if pnlMark != pnl.MarkBid && pnlMark != pnl.MarkMid {
+		return fmt.Errorf("invalid --mark %q: use bid or mid", pnlMark)
+	}
+	since, err := parseSinceArg(pnlSince)
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	positions, err := allPositions(ctx, client)
+	if err != nil {
+		return err
+	}
+	settlements, err := settlementsSince(ctx, client, since)
+	if err != nil {
+		return fmt.Errorf("failed to get settlements: %w", err)
+	}
+	fills, err := allFills(ctx, client, api.FillsOptions{MinTS: since.Unix()})
+	if err != nil {
+		return fmt.Errorf("failed to get fills: %w", err)
+	}
+	markets := pnlMarkets(ctx, client, positions, settlements)
+
+	portfolio := pnl.Join(positions, settlements, fills, markets, pnlMark)
+	if emptyListWarning(len(portfolio.Markets), "No P&L to report") {
+		return nil
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderPnLTable(portfolio, since) },
+		portfolio,
+		func() { renderPnLPlain(portfolio) },
+	)
+}
+
+// formatPnL renders signed cents, green when not negative
+func formatPnL(cents int) string {
+	return ui.FormatPriceStyled(cents, cents >= 0)
+}
+
+func renderPnLTable(p pnl.Portfolio, since time.Time) {
+	fmt.Fprintf(ui.Writer(), "P&L of open markets and markets settled since %s, marked at the %s\n\n", formatTimeStr(since), p.Mark)
+
+	rows := make([][]string, 0, len(p.Markets))
+	for _, h := range p.Markets {
+		held, mark := "-", "-"
+		if h.Contracts > 0 {
+			held = fmt.Sprintf("%d %s", h.Contracts, h.Side)
+			mark = ui.FormatPrice(h.Mark)
+		}
+		rows = append(rows, []string{
+			h.Ticker, h.Status, held, mark,
+			ui.FormatPrice(h.Cost), ui.FormatPrice(h.Value),
+			formatPnL(h.Realized), formatPnL(h.Unrealized), ui.FormatPrice(h.Fees), formatPnL(h.PnL),
+		})
+	}
+	ui.RenderTable([]string{"Market", "Status", "Held", "Mark", "Cost", "Value", "Realized", "Unrealized", "Fees", "P&L"}, rows)
+
+	fmt.Fprintln(ui.Writer())
+	rows = make([][]string, 0, len(p.Events)+1)
+	for _, e := range p.Events {
+		rows = append(rows, pnlTotalsRow(e.Event, e.Markets, e.Totals))
+	}
+	rows = append(rows, pnlTotalsRow(ui.BoldStyle.Render("Total"), len(p.Markets), p.Total))
+	ui.RenderTable([]string{"Event", "Markets", "Cost", "Value", "Realized", "Unrealized", "Fees", "P&L"}, rows)
+}
+
+func pnlTotalsRow(name string, markets int, t pnl.Totals) []string {
+	return []string{
+		name, strconv.Itoa(markets),
+		ui.FormatPrice(t.Cost), ui.FormatPrice(t.Value),
+		formatPnL(t.Realized), formatPnL(t.Unrealized), ui.FormatPrice(t.Fees), formatPnL(t.PnL),
+	}
+}
+
+// renderPnLPlain prints one line per market, then per event, then the total,
+// each led by its kind
+func renderPnLPlain(p pnl.Portfolio) {
+	for _, h := range p.Markets {
+		ui.PrintPlain("market\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d",
+			h.Ticker, h.Event, h.Status, h.Contracts, h.Mark,
+			h.Cost, h.Value, h.Realized, h.Unrealized, h.Fees, h.PnL)
+	}
+	for _, e := range p.Events {
+		ui.PrintPlain("event\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d",
+			e.Event, e.Markets, e.Cost, e.Value, e.Realized, e.Unrealized, e.Fees, e.PnL)
+	}
+	t := p.Total
+	ui.PrintPlain("total\t%d\t%d\t%d\t%d\t%d\t%d\t%d",
+		len(p.Markets), t.Cost, t.Value, t.Realized, t.Unrealized, t.Fees, t.PnL)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/snapshot"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// positionsDiff is the --json output of 'portfolio positions --diff-from'
+type positionsDiff struct {
+	From     *time.Time        `json:"from"`
+	To       time.Time         `json:"to"`
+	Snapshot string            `json:"snapshot"`
+	Changes  []snapshot.Change `json:"changes"`
+}
+
+func snapshotStore() (*snapshot.Store, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return snapshot.NewStore(snapshot.DefaultDir(dir)), nil
+}
+
+// runPositionsDiff compares positions with an earlier snapshot, then saves
+// them as the newest snapshot
+func runPositionsDiff(positions []models.MarketPosition) error {
+	store, err := snapshotStore()
+	if err != nil {
+		return err
+	}
+
+	var previous *snapshot.Snapshot
+	if positionsDiffFrom == "last" {
+		previous, err = store.Latest(cfg.Environment(), ActiveSubaccount())
+	} else {
+		previous, err = snapshot.Load(positionsDiffFrom)
+	}
+	if err != nil {
+		return err
+	}
+
+	current := snapshot.Snapshot{
+		Time:        time.Now().UTC(),
+		Environment: cfg.Environment(),
+		Subaccount:  ActiveSubaccount(),
+		Positions:   positions,
+	}
+	path, err := store.Save(current)
+	if err != nil {
+		return err
+	}
+
+	diff := positionsDiff{To: current.Time, Snapshot: path}
+	if previous != nil {
+		diff.From = &previous.Time
+		diff.Changes = snapshot.Diff(previous.Positions, positions)
+	} else {
+		diff.Changes = snapshot.Diff(nil, positions)
+	}
+	if diff.Changes == nil {
+		diff.Changes = []snapshot.Change{}
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderPositionsDiffTable(diff) },
+		diff,
+		func() { renderPositionsDiffPlain(diff) },
+	)
+}
+
+func renderPositionsDiffTable(diff positionsDiff) {
+	if diff.From == nil {
+		PrintWarning("No previous snapshot; saved a baseline. All positions are shown as new.")
+	} else {
+		fmt.Fprintf(ui.Writer(), "Changes since %s\n\n", formatTimeStr(diff.From.Local()))
+	}
+
+	if len(diff.Changes) == 0 {
+		fmt.Fprintln(ui.Writer(), "No position changes")
+		return
+	}
+
+	headers := []string{"Market", "Change", "Position", "Δ Position", "Exposure", "Δ Exposure"}
+	rows := make([][]string, 0, len(diff.Changes))
+	for _, c := range diff.Changes {
+		rows = append(rows, []string{
+			c.Ticker,
+			formatChangeKind(c.Kind),
+			fmt.Sprintf("%s → %s", formatPosition(c.PreviousPosition), formatPosition(c.Position)),
+			fmt.Sprintf("%+d", c.PositionDelta()),
+			fmt.Sprintf("%s → %s", ui.FormatPrice(c.PreviousExposure), ui.FormatPrice(c.Exposure)),
+			ui.FormatPriceStyled(c.ExposureDelta(), c.ExposureDelta() >= 0),
+		})
+	}
+	ui.RenderTable(headers, rows)
+}
+
+func renderPositionsDiffPlain(diff positionsDiff) {
+	for _, c := range diff.Changes {
+		ui.PrintPlain("%s\t%s\t%d\t%d\t%d\t%d",
+			c.Ticker, c.Kind, c.PreviousPosition, c.Position, c.PreviousExposure, c.Exposure)
+	}
+}
+
+func formatChangeKind(kind snapshot.ChangeKind) string {
+	switch kind {
+	case snapshot.ChangeNew:
+		return ui.SuccessStyle.Render("NEW")
+	case snapshot.ChangeClosed:
+		return ui.MutedStyle.Render("CLOSED")
+	default:
+		return ui.WarningStyle.Render("CHANGED")
+	}
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var precheck bool
+
+// addPrecheckFlag registers --precheck on a command that places orders
+func addPrecheckFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&precheck, "precheck", false, "refuse to submit if the orders exceed available funds or risk.max_exposure")
+}
+
+// orderMaxCost is the most an order can cost if it fills completely. A sell
+// is priced as buying the other side, since it may open a position.
+func orderMaxCost(req models.CreateOrderRequest) int {
+	price := req.YesPrice
+	if req.Side == models.OrderSideNo {
+		price = req.NoPrice
+	}
+	if req.Action == models.OrderActionSell {
+		price = 100 - price
+	}
+	return req.Count * price
+}
+
+// restingOrderValue is what a resting order still has reserved
+func restingOrderValue(o models.Order) int {
+	price := o.YesPrice
+	if o.Side == models.OrderSideNo {
+		price = o.NoPrice
+	}
+	if o.Action == models.OrderActionSell {
+		price = 100 - price
+	}
+	return o.RemainingCount * price
+}
+
+// precheckResult is the funding and exposure picture for a set of orders
+type precheckResult struct {
+	Balance     int `json:"balance"`
+	Resting     int `json:"resting"`
+	Positions   int `json:"positions"`
+	Cost        int `json:"cost"`
+	MaxExposure int `json:"max_exposure"`
+}
+
+// Available is the balance not already reserved by resting orders
+func (r precheckResult) Available() int {
+	return r.Balance - r.Resting
+}
+
+// Exposure is the exposure after the new orders fill
+func (r precheckResult) Exposure() int {
+	return r.Positions + r.Resting + r.Cost
+}
+
+// Err explains every limit the new orders would break, or returns nil
+func (r precheckResult) Err() error {
+	var problems []string
+	if r.Cost > r.Available() {
+		problems = append(problems, fmt.Sprintf("max cost %s exceeds available funds %s (balance %s - resting orders %s): short %s",
+			formatCents(r.Cost), formatCents(r.Available()), formatCents(r.Balance), formatCents(r.Resting),
+			formatCents(r.Cost-r.Available())))
+	}
+	if r.MaxExposure > 0 && r.Exposure() > r.MaxExposure {
+		problems = append(problems, fmt.Sprintf("exposure would reach %s (positions %s + resting %s + new %s), over risk.max_exposure %s by %s",
+			formatCents(r.Exposure()), formatCents(r.Positions), formatCents(r.Resting), formatCents(r.Cost),
+			formatCents(r.MaxExposure), formatCents(r.Exposure()-r.MaxExposure)))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("precheck failed:\n  %s", strings.Join(problems, "\n  "))
+}
+
+// runPrecheck fetches balance, resting orders and positions and refuses
+// orders costing more than is available or allowed. It is a no-op unless
+// --precheck is set.
+func runPrecheck(orders []models.CreateOrderRequest) error {
+	if !precheck {
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(context.Background())
+	defer cancel()
+
+	result, err := fetchPrecheck(ctx, client, orders)
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
+// fetchPrecheck gathers the balance, resting orders and positions the new
+// orders are checked against
+func fetchPrecheck(ctx context.Context, client *api.Client, orders []models.CreateOrderRequest) (precheckResult, error) {
+	var err error
+	result := precheckResult{MaxExposure: GetConfig().Risk.MaxExposure}
+	for _, o := range orders {
+		result.Cost += orderMaxCost(o)
+	}
+
+	if result.Balance, err = availableBalance(ctx, client); err != nil {
+		return result, fmt.Errorf("precheck: failed to get balance: %w", err)
+	}
+
+	opts := api.OrdersOptions{Status: string(models.OrderStatusResting), Limit: reconcilePageSize, SubaccountID: ActiveSubaccount()}
+	for {
+		page, err := client.GetOrders(ctx, opts)
+		if err != nil {
+			return result, fmt.Errorf("precheck: failed to get resting orders: %w", err)
+		}
+		for _, o := range page.Orders {
+			result.Resting += restingOrderValue(o)
+		}
+		if page.Cursor == "" || len(page.Orders) == 0 {
+			break
+		}
+		opts.Cursor = page.Cursor
+	}
+
+	positions := api.PositionsOptions{Limit: reconcilePageSize, SubaccountID: ActiveSubaccount()}
+	for {
+		page, err := client.GetPositions(ctx, positions)
+		if err != nil {
+			return result, fmt.Errorf("precheck: failed to get positions: %w", err)
+		}
+		for _, p := range page.Positions {
+			result.Positions += p.MarketExposure
+		}
+		if page.Cursor == "" || len(page.Positions) == 0 {
+			break
+		}
+		positions.Cursor = page.Cursor
+	}
+
+	return result, nil
+}
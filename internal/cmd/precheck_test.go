@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestOrderMaxCost(t *testing.T) {
+	tests := []struct {
+		req  models.CreateOrderRequest
+		want int
+	}{
+		{models.CreateOrderRequest{Side: models.OrderSideYes, Action: models.OrderActionBuy, Count: 10, YesPrice: 40}, 400},
+		{models.CreateOrderRequest{Side: models.OrderSideNo, Action: models.OrderActionBuy, Count: 5, NoPrice: 30}, 150},
+		{models.CreateOrderRequest{Side: models.OrderSideYes, Action: models.OrderActionSell, Count: 10, YesPrice: 70}, 300},
+	}
+	for _, tt := range tests {
+		if got := orderMaxCost(tt.req); got != tt.want {
+			t.Errorf("orderMaxCost(%+v) = %d, want %d", tt.req, got, tt.want)
+		}
+	}
+}
+
+func TestPrecheckResultErr(t *testing.T) {
+	ok := precheckResult{Balance: 10000, Resting: 2000, Positions: 3000, Cost: 5000, MaxExposure: 10000}
+	if err := ok.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	short := precheckResult{Balance: 10000, Resting: 6000, Cost: 5000}
+	err := short.Err()
+	if err == nil || !strings.Contains(err.Error(), "short $10.00") {
+		t.Errorf("Err() = %v, want funds shortfall of $10.00", err)
+	}
+
+	over := precheckResult{Balance: 100000, Resting: 2000, Positions: 5000, Cost: 4000, MaxExposure: 10000}
+	err = over.Err()
+	if err == nil || !strings.Contains(err.Error(), "by $10.00") || strings.Contains(err.Error(), "short") {
+		t.Errorf("Err() = %v, want only an exposure overage of $10.00", err)
+	}
+
+	uncapped := precheckResult{Balance: 100000, Positions: 90000, Cost: 4000}
+	if err := uncapped.Err(); err != nil {
+		t.Errorf("Err() with no ceiling = %v, want nil", err)
+	}
+}
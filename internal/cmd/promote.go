@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Re-validate a demo-tested order plan and place it in production",
+	Long: `Promote a plan of orders that was tested against demo to production.
+
+Every order is re-validated against production before anything is sent:
+  - the market exists and is open
+  - side, action, quantity and price (1-99 cents) are valid
+  - the total max cost fits within the balance not reserved by resting
+    orders, and within risk.max_exposure if set
+
+If every order passes, the whole plan is placed after a single confirmation.
+Promote only runs against production, so --prod is required. With --json,
+the checks and the orders placed are printed as a single object once promote
+finishes.
+
+Plan files are YAML (or JSON):
+
+  orders:
+    - ticker: KXBTC-26FEB12-B97000
+      side: yes
+      action: buy
+      count: 10
+      price: 45
+
+Each entry is a single limit order; order templates are not supported.`,
+	Example: `  kalshi-cli promote --from-demo plan.yaml --prod`,
+	RunE:    runPromote,
+}
+
+var promoteFromDemo string
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+
+	promoteCmd.Flags().StringVar(&promoteFromDemo, "from-demo", "", "path to a plan tested against demo (required)")
+	promoteCmd.MarkFlagRequired("from-demo")
+}
+
+// promotePlan is an order plan file
+type promotePlan struct {
+	Orders []promoteOrder `mapstructure:"orders"`
+}
+
+// promoteOrder is one limit order of a plan
+type promoteOrder struct {
+	Ticker string `mapstructure:"ticker"`
+	Side   string `mapstructure:"side"`
+	Action string `mapstructure:"action"`
+	Count  int    `mapstructure:"count"`
+	Price  int    `mapstructure:"price"`
+}
+
+// promoteCheck is the production validation of one plan order
+type promoteCheck struct {
+	Ticker   string   `json:"ticker"`
+	Side     string   `json:"side"`
+	Action   string   `json:"action"`
+	Count    int      `json:"count"`
+	Price    int      `json:"price"`
+	MaxCost  int      `json:"max_cost"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// promoteResult is the JSON output of promote
+type promoteResult struct {
+	Checks []promoteCheck `json:"checks"`
+	Funds  precheckResult `json:"funds"`
+	Valid  bool           `json:"valid"`
+	Orders []models.Order `json:"orders"`
+}
+
+// loadPromotePlan reads a YAML or JSON plan file
+func loadPromotePlan(path string) (promotePlan, error) {
+	var plan promotePlan
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return plan, fmt.Errorf("failed to read plan: %w", err)
+	}
+	if err := v.Unmarshal(&plan); err != nil {
+		return plan, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if len(plan.Orders) == 0 {
+		return plan, fmt.Errorf("no orders found in plan")
+	}
+	return plan, nil
+}
+
+// request converts a plan order to an order request, reporting every field
+// that is invalid
+func (o promoteOrder) request() (models.CreateOrderRequest, []string) {
+	var problems []string
+
+	side := strings.ToLower(o.Side)
+	if side != "yes" && side != "no" {
+		problems = append(problems, fmt.Sprintf("side must be yes or no, got %q", o.Side))
+	}
+	action := strings.ToLower(o.Action)
+	if action == "" {
+		action = "buy"
+	}
+	if action != "buy" && action != "sell" {
+		problems = append(problems, fmt.Sprintf("action must be buy or sell, got %q", o.Action))
+	}
+	if o.Ticker == "" {
+		problems = append(problems, "ticker is required")
+	}
+	if o.Count <= 0 {
+		problems = append(problems, "count must be positive")
+	}
+	if o.Price < 1 || o.Price > 99 {
+		problems = append(problems, fmt.Sprintf("price must be between 1 and 99, got %d", o.Price))
+	}
+
+	req := models.CreateOrderRequest{
+		Ticker:       o.Ticker,
+		Side:         models.OrderSide(side),
+		Action:       models.OrderAction(action),
+		Type:         models.OrderTypeLimit,
+		Count:        o.Count,
+		SubaccountID: ActiveSubaccount(),
+	}
+	if side == "no" {
+		req.NoPrice = o.Price
+	} else {
+		req.YesPrice = o.Price
+	}
+	return req, problems
+}
+
+func runPromote(cmd *cobra.Command, args []string) error {
+	if !GetConfig().API.Production {
+		return fmt.Errorf("promote places orders in production; re-run with --prod")
+	}
+
+	plan, err := loadPromotePlan(promoteFromDemo)
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	orders := make([]models.CreateOrderRequest, len(plan.Orders))
+	checks := make([]promoteCheck, len(plan.Orders))
+	// marketProblems caches the production market check per ticker
+	marketProblems := make(map[string]string)
+	valid := true
+	for i, o := range plan.Orders {
+		req, problems := o.request()
+		orders[i] = req
+		checks[i] = promoteCheck{
+			Ticker:  req.Ticker,
+			Side:    string(req.Side),
+			Action:  string(req.Action),
+			Count:   req.Count,
+			Price:   o.Price,
+			MaxCost: orderMaxCost(req),
+		}
+
+		if req.Ticker != "" {
+			problem, seen := marketProblems[req.Ticker]
+			if !seen {
+				market, err := client.GetMarket(ctx, req.Ticker)
+				switch {
+				case err != nil:
+					problem = fmt.Sprintf("market lookup failed: %v", err)
+				case !marketIsOpen(market.Status):
+					problem = "market is " + market.Status
+				}
+				marketProblems[req.Ticker] = problem
+			}
+			if problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+
+		checks[i].Problems = problems
+		valid = valid && len(problems) == 0
+	}
+
+	funds, err := fetchPrecheck(ctx, client, orders)
+	if err != nil {
+		return err
+	}
+	fundsErr := funds.Err()
+
+	result := promoteResult{Checks: checks, Funds: funds, Valid: valid && fundsErr == nil, Orders: []models.Order{}}
+	// finish prints the JSON result once, whether or not anything was placed
+	finish := func(err error) error {
+		if GetOutputFormat() != ui.FormatJSON {
+			return err
+		}
+		if jsonErr := ui.PrintJSON(result); err == nil {
+			err = jsonErr
+		}
+		return err
+	}
+
+	switch GetOutputFormat() {
+	case ui.FormatTable:
+		renderPromoteChecks(checks, funds)
+	case ui.FormatPlain:
+		for _, c := range checks {
+			ui.PrintPlain("%s\t%s\t%s\t%d\t%d\t%s", c.Ticker, c.Side, c.Action, c.Count, c.Price, strings.Join(c.Problems, "; "))
+		}
+	}
+
+	if !valid {
+		return finish(fmt.Errorf("plan failed production validation; nothing was placed"))
+	}
+	if fundsErr != nil {
+		return finish(fmt.Errorf("%w; nothing was placed", fundsErr))
+	}
+
+	confirmed, err := confirmAction(fmt.Sprintf("Place %d orders in PRODUCTION (real money)?", len(orders)))
+	if err != nil {
+		return finish(err)
+	}
+	if !confirmed {
+		PrintWarning("Promotion cancelled")
+		return finish(nil)
+	}
+
+	// Validation may have used most of the first timeout while waiting to confirm
+	ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	presignRequests(ctx, client, http.MethodPost, batchedOrdersPath, (len(orders)+ladderBatchSize-1)/ladderBatchSize)
+	for start := 0; start < len(orders); start += ladderBatchSize {
+		end := min(start+ladderBatchSize, len(orders))
+
+		batchReq := models.BatchCreateOrdersRequest{Orders: orders[start:end]}
+		var response models.BatchCreateOrdersResponse
+		if err := client.PostJSON(ctx, batchedOrdersPath, batchReq, &response); err != nil {
+			if len(result.Orders) > 0 {
+				PrintWarning(fmt.Sprintf("%d of %d orders were placed before the failure", len(result.Orders), len(orders)))
+			}
+			return finish(fmt.Errorf("failed to place plan orders: %w", err))
+		}
+		result.Orders = append(result.Orders, response.Orders...)
+	}
+
+	PrintSuccess(fmt.Sprintf("Promoted %d orders to production", len(result.Orders)))
+
+	switch GetOutputFormat() {
+	case ui.FormatTable:
+		renderOrdersTable(result.Orders)
+	case ui.FormatPlain:
+		renderOrdersPlain(result.Orders)
+	}
+	return finish(nil)
+}
+
+func renderPromoteChecks(checks []promoteCheck, funds precheckResult) {
+	headers := []string{"#", "Market", "Side", "Action", "Qty", "Price", "Max Cost", "Check"}
+	rows := make([][]string, len(checks))
+	for i, c := range checks {
+		check := ui.SuccessStyle.Render("ok")
+		if len(c.Problems) > 0 {
+			check = ui.ErrorStyle.Render(strings.Join(c.Problems, "; "))
+		}
+		rows[i] = []string{
+			fmt.Sprintf("%d", i+1),
+			c.Ticker,
+			strings.ToUpper(c.Side),
+			strings.ToUpper(c.Action),
+			fmt.Sprintf("%d", c.Count),
+			fmt.Sprintf("%d¢", c.Price),
+			formatCents(c.MaxCost),
+			check,
+		}
+	}
+	ui.RenderTable(headers, rows)
+	fmt.Fprintln(ui.Writer())
+
+	pairs := [][]string{
+		{"Total Max Cost", formatCents(funds.Cost)},
+		{"Available Funds", fmt.Sprintf("%s (balance %s - resting %s)", formatCents(funds.Available()), formatCents(funds.Balance), formatCents(funds.Resting))},
+	}
+	if funds.MaxExposure > 0 {
+		pairs = append(pairs, []string{"Exposure After", fmt.Sprintf("%s of %s", formatCents(funds.Exposure()), formatCents(funds.MaxExposure))})
+	}
+	ui.RenderKeyValue(pairs)
+	fmt.Fprintln(ui.Writer())
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/golden"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestLoadPromotePlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	plan := `orders:
+  - ticker: KXBTC-26FEB12-B97000
+    side: yes
+    count: 10
+    price: 45
+  - ticker: KXBTC-26FEB12-B99000
+    side: no
+    action: sell
+    count: 5
+    price: 30
+`
+	if err := os.WriteFile(path, []byte(plan), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadPromotePlan(path)
+	if err != nil {
+		t.Fatalf("loadPromotePlan() error = %v", err)
+	}
+	if len(got.Orders) != 2 || got.Orders[0].Side != "yes" || got.Orders[1].Action != "sell" || got.Orders[1].Price != 30 {
+		t.Errorf("loadPromotePlan() = %+v", got)
+	}
+
+	req, problems := got.Orders[0].request()
+	if len(problems) != 0 {
+		t.Errorf("request() problems = %v, want none", problems)
+	}
+	if req.Action != models.OrderActionBuy || req.YesPrice != 45 || req.Type != models.OrderTypeLimit {
+		t.Errorf("request() = %+v, want limit buy yes @ 45", req)
+	}
+}
+
+func TestPromoteOrderRequestProblems(t *testing.T) {
+	_, problems := promoteOrder{Side: "maybe", Action: "hold", Count: 0, Price: 100}.request()
+	if len(problems) != 5 {
+		t.Errorf("request() problems = %v, want 5", problems)
+	}
+}
+
+func TestRunPromote_JSONIsOneObject(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KALSHI_API_KEY_ID", "test-key")
+	t.Setenv("KALSHI_PRIVATE_KEY_FILE", keyPath)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/markets/KXCLOSED"):
+			fmt.Fprint(w, `{"market": {"ticker": "KXCLOSED", "status": "closed"}}`)
+		case strings.HasSuffix(r.URL.Path, "/portfolio/balance"):
+			fmt.Fprint(w, `{"balance": 100000}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	loadTestConfig(t)
+	cfg.API.Production = true
+	cfg.API.BaseURL = server.URL
+	oldFormat, oldPlan := outputFmt, promoteFromDemo
+	t.Cleanup(func() { outputFmt, promoteFromDemo = oldFormat, oldPlan })
+	outputFmt = ui.FormatJSON
+	promoteFromDemo = filepath.Join(t.TempDir(), "plan.yaml")
+	plan := "orders:\n  - {ticker: KXCLOSED, side: yes, count: 1, price: 40}\n"
+	if err := os.WriteFile(promoteFromDemo, []byte(plan), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var runErr error
+	out := golden.Capture(t, func() { runErr = runPromote(promoteCmd, nil) })
+	if runErr == nil {
+		t.Fatal("promote of a closed market succeeded")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	var result struct {
+		Checks []promoteCheck `json:"checks"`
+		Valid  bool           `json:"valid"`
+		Orders []models.Order `json:"orders"`
+	}
+	if err := dec.Decode(&result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if dec.More() {
+		t.Fatalf("output holds more than one JSON document:\n%s", out)
+	}
+	if result.Valid || len(result.Checks) != 1 || len(result.Checks[0].Problems) == 0 || result.Orders == nil {
+		t.Errorf("unexpected result:\n%s", out)
+	}
+}
@@ -0,0 +1,416 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Save and run named command lines",
+	Long: `Save command lines you run often under a name and run them later, filling
+in placeholders. Queries are stored under 'queries' in the config file:
+
+  queries:
+    open-series: markets list --series {series} --status open --limit {limit=20}
+
+{name} must be given with --set name=value when the query is run;
+{name=default} falls back to the default. Values are substituted after the
+command line is split into words, so a value may contain spaces.`,
+}
+
+var querySaveCmd = &cobra.Command{
+	Use:   "save <name> <command>",
+	Short: "Create or replace a saved query",
+	Example: `  kalshi-cli query save open-series "markets list --series {series} --status open --limit {limit=20}"
+  kalshi-cli query save fed-notes "markets note --tagged fed"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runQuerySave,
+}
+
+var queryRunCmd = &cobra.Command{
+	Use:   "run <name> [-- extra-args...]",
+	Short: "Run a saved query",
+	Long: `Run a saved query with its placeholders filled in. Arguments after -- are
+appended to the command line. Global flags such as --json, --prod and
+--config are passed on to the query.`,
+	Example: `  kalshi-cli query run open-series --set series=KXBTC
+  kalshi-cli query run open-series --set series=KXBTC --set limit=5 --json
+  kalshi-cli query run open-series --set series=KXBTC -- --limit 100
+  kalshi-cli query run open-series --set series=KXBTC --print`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runQueryRun,
+}
+
+var queryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved queries",
+	Args:  cobra.NoArgs,
+	RunE:  runQueryList,
+}
+
+var queryDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a saved query",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runQueryDelete,
+}
+
+var (
+	querySet   []string
+	queryPrint bool
+)
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+	queryCmd.AddCommand(querySaveCmd)
+	queryCmd.AddCommand(queryRunCmd)
+	queryCmd.AddCommand(queryListCmd)
+	queryCmd.AddCommand(queryDeleteCmd)
+
+	queryRunCmd.Flags().StringArrayVar(&querySet, "set", nil, "placeholder value as name=value (repeatable)")
+	queryRunCmd.Flags().BoolVar(&queryPrint, "print", false, "print the command line instead of running it")
+}
+
+// placeholderPattern matches {name} and {name=default}
+var placeholderPattern = regexp.MustCompile(`\{([a-z][a-z0-9_]*)(?:=([^{}]*))?\}`)
+
+// queryPlaceholder is one placeholder in a saved query
+type queryPlaceholder struct {
+	Name       string `json:"name"`
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"has_default"`
+}
+
+// queryPlaceholders returns the placeholders in a query in order of first use
+func queryPlaceholders(command string) []queryPlaceholder {
+	var placeholders []queryPlaceholder
+	seen := make(map[string]bool)
+	for _, m := range placeholderPattern.FindAllStringSubmatch(command, -1) {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		placeholders = append(placeholders, queryPlaceholder{
+			Name:       m[1],
+			Default:    m[2],
+			HasDefault: strings.Contains(m[0], "="),
+		})
+	}
+	return placeholders
+}
+
+// splitCommandLine splits a command line into words. Single and double
+// quotes group words and a backslash escapes the next character outside
+// single quotes, as in a shell, but nothing is expanded.
+func splitCommandLine(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// parseQuerySet parses --set name=value pairs
+func parseQuerySet(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --set %q: use name=value", pair)
+		}
+		values[strings.ToLower(name)] = value
+	}
+	return values, nil
+}
+
+// expandQuery splits a saved query into words and fills in its placeholders.
+// Every placeholder without a default needs a value, and every value must
+// name a placeholder.
+func expandQuery(command string, values map[string]string) ([]string, error) {
+	placeholders := queryPlaceholders(command)
+	known := make(map[string]bool, len(placeholders))
+	var missing []string
+	for _, p := range placeholders {
+		known[p.Name] = true
+		if _, ok := values[p.Name]; !ok && !p.HasDefault {
+			missing = append(missing, p.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing value for %s: use --set %s=...", strings.Join(missing, ", "), missing[0])
+	}
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("query has no placeholder %s", strings.Join(unknown, ", "))
+	}
+
+	words, err := splitCommandLine(command)
+	if err != nil {
+		return nil, err
+	}
+	for i, w := range words {
+		words[i] = placeholderPattern.ReplaceAllStringFunc(w, func(match string) string {
+			m := placeholderPattern.FindStringSubmatch(match)
+			if v, ok := values[m[1]]; ok {
+				return v
+			}
+			return m[2]
+		})
+	}
+	return words, nil
+}
+
+// checkQueryCommand checks that a query runs a command other than query
+func checkQueryCommand(words []string) error {
+	if len(words) == 0 {
+		return fmt.Errorf("query command cannot be empty")
+	}
+	target, _, err := rootCmd.Find(words)
+	if err != nil || target == rootCmd {
+		return fmt.Errorf("query must run a command, %q is not one", words[0])
+	}
+	for c := target; c != nil; c = c.Parent() {
+		if c == queryCmd {
+			return fmt.Errorf("a query cannot run another query")
+		}
+	}
+	return nil
+}
+
+func runQuerySave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	command := strings.TrimSpace(args[1])
+
+	if !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid query name %q: use lowercase letters, digits and dashes", name)
+	}
+	words, err := splitCommandLine(command)
+	if err != nil {
+		return fmt.Errorf("invalid query command: %w", err)
+	}
+	if len(words) > 0 && strings.TrimSuffix(filepath.Base(words[0]), ".exe") == rootCmd.Name() {
+		words = words[1:]
+		command = strings.TrimSpace(strings.TrimPrefix(command, strings.Fields(command)[0]))
+	}
+	if err := checkQueryCommand(words); err != nil {
+		return err
+	}
+
+	queries := copyAliases(GetConfig().Queries)
+	queries[name] = command
+	if err := config.SaveQueries(queries); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			PrintSuccess(fmt.Sprintf("Saved query %s = %s", name, command))
+		},
+		map[string]any{"name": name, "command": command, "placeholders": queryPlaceholders(command)},
+		func() {
+			ui.PrintPlain("%s=%s", name, command)
+		},
+	)
+}
+
+// queryRunArgs returns the arguments to run a saved query with: the global
+// flags set on cmd, the expanded query and any extra arguments
+func queryRunArgs(cmd *cobra.Command, words, extra []string) []string {
+	var args []string
+	cmd.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			args = append(args, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	args = append(args, words...)
+	return append(args, extra...)
+}
+
+func runQueryRun(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if dash := cmd.ArgsLenAtDash(); len(args) > 1 && dash != 1 {
+		return fmt.Errorf("put extra arguments after --, e.g. kalshi-cli query run %s -- --limit 10", name)
+	}
+
+	command, ok := GetConfig().Queries[name]
+	if !ok {
+		return fmt.Errorf("no query named %q (see 'kalshi-cli query list')", name)
+	}
+	values, err := parseQuerySet(querySet)
+	if err != nil {
+		return err
+	}
+	words, err := expandQuery(command, values)
+	if err != nil {
+		return fmt.Errorf("query %s: %w", name, err)
+	}
+	if err := checkQueryCommand(words); err != nil {
+		return fmt.Errorf("query %s: %w", name, err)
+	}
+
+	runArgs := queryRunArgs(cmd, words, args[1:])
+	if queryPrint {
+		quoted := make([]string, len(runArgs))
+		for i, a := range runArgs {
+			quoted[i] = shellQuote(a)
+		}
+		fmt.Fprintln(ui.Writer(), rootCmd.Name()+" "+strings.Join(quoted, " "))
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find kalshi-cli executable: %w", err)
+	}
+	c := exec.Command(exe, runArgs...)
+	c.Stdin = os.Stdin
+	c.Stdout = ui.Writer()
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		// The child has already reported its own error
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			return &ExitStatusError{Code: exitErr.ExitCode()}
+		}
+		return fmt.Errorf("query %s failed: %w", name, err)
+	}
+	return nil
+}
+
+// shellQuote quotes s for a POSIX shell when it needs it
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`|&;<>()*?[]{}#~!") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func runQueryList(cmd *cobra.Command, args []string) error {
+	type queryEntry struct {
+		Name         string             `json:"name"`
+		Command      string             `json:"command"`
+		Placeholders []queryPlaceholder `json:"placeholders"`
+	}
+
+	queries := GetConfig().Queries
+	entries := make([]queryEntry, 0, len(queries))
+	for name, command := range queries {
+		placeholders := queryPlaceholders(command)
+		if placeholders == nil {
+			placeholders = []queryPlaceholder{}
+		}
+		entries = append(entries, queryEntry{Name: name, Command: command, Placeholders: placeholders})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			if len(entries) == 0 {
+				fmt.Fprintln(ui.Writer(), ui.MutedStyle.Render("No saved queries. Save one with 'kalshi-cli query save <name> \"<command>\"'."))
+				return
+			}
+			rows := make([][]string, len(entries))
+			for i, e := range entries {
+				var names []string
+				for _, p := range e.Placeholders {
+					if p.HasDefault {
+						names = append(names, fmt.Sprintf("%s (default %q)", p.Name, p.Default))
+					} else {
+						names = append(names, p.Name)
+					}
+				}
+				rows[i] = []string{e.Name, e.Command, strings.Join(names, ", ")}
+			}
+			ui.RenderTable([]string{"Query", "Command", "Placeholders"}, rows)
+		},
+		entries,
+		func() {
+			for _, e := range entries {
+				ui.PrintPlain("%s=%s", e.Name, e.Command)
+			}
+		},
+	)
+}
+
+func runQueryDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	queries := copyAliases(GetConfig().Queries)
+	if _, ok := queries[name]; !ok {
+		return fmt.Errorf("no query named %q", name)
+	}
+	delete(queries, name)
+
+	if err := config.SaveQueries(queries); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			PrintSuccess(fmt.Sprintf("Deleted query %s", name))
+		},
+		map[string]string{"name": name, "status": "deleted"},
+		func() {
+			ui.PrintPlain("deleted=%s", name)
+		},
+	)
+}
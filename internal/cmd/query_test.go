@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"markets list --status open", []string{"markets", "list", "--status", "open"}},
+		{`markets note X --add "watch CPI print"`, []string{"markets", "note", "X", "--add", "watch CPI print"}},
+		{`a 'it''s' b\ c ""`, []string{"a", "its", "b c", ""}},
+		{`a "say \"hi\""`, []string{"a", `say "hi"`}},
+	}
+	for _, tt := range tests {
+		got, err := splitCommandLine(tt.line)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.line, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+	if _, err := splitCommandLine(`a "b`); err == nil {
+		t.Error("unterminated quote should fail")
+	}
+}
+
+func TestQueryPlaceholders(t *testing.T) {
+	got := queryPlaceholders("markets list --series {series} --limit {limit=20} --status {series}")
+	want := []queryPlaceholder{{Name: "series"}, {Name: "limit", Default: "20", HasDefault: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("placeholders = %+v, want %+v", got, want)
+	}
+	if got := queryPlaceholders("x {empty=}"); !got[0].HasDefault || got[0].Default != "" {
+		t.Errorf("empty default = %+v", got)
+	}
+}
+
+func TestExpandQuery(t *testing.T) {
+	command := `markets note {ticker} --add "{text}" --tag {tag=todo}`
+
+	got, err := expandQuery(command, map[string]string{"ticker": "KXCPI", "text": "two words"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"markets", "note", "KXCPI", "--add", "two words", "--tag", "todo"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expanded = %q, want %q", got, want)
+	}
+
+	if _, err := expandQuery(command, map[string]string{"ticker": "KXCPI"}); err == nil || !strings.Contains(err.Error(), "text") {
+		t.Errorf("missing value error = %v", err)
+	}
+	if _, err := expandQuery(command, map[string]string{"ticker": "A", "text": "b", "other": "c"}); err == nil || !strings.Contains(err.Error(), "other") {
+		t.Errorf("unknown value error = %v", err)
+	}
+}
+
+func TestCheckQueryCommand(t *testing.T) {
+	if err := checkQueryCommand([]string{"markets", "list"}); err != nil {
+		t.Errorf("markets list: %v", err)
+	}
+	if err := checkQueryCommand([]string{"nosuch"}); err == nil {
+		t.Error("unknown command should fail")
+	}
+	if err := checkQueryCommand([]string{"query", "run", "x"}); err == nil {
+		t.Error("a query running a query should fail")
+	}
+}
+
+func TestParseQuerySet(t *testing.T) {
+	got, err := parseQuerySet([]string{"Series=KXBTC", "q=a=b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["series"] != "KXBTC" || got["q"] != "a=b" {
+		t.Errorf("values = %v", got)
+	}
+	if _, err := parseQuerySet([]string{"novalue"}); err == nil {
+		t.Error("missing = should fail")
+	}
+}
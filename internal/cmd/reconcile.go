@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/audit"
+	"github.com/6missedcalls/kalshi-cli/internal/reconcile"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Cross-check settlements and fills against the audit log",
+	Long: `Reconcile settlements, fills and the local audit log for a time window and
+report every mismatch:
+
+  missing_fills     fills do not add up to a settled position
+  cost_mismatch     fills do not add up to a settlement's total cost
+  revenue_mismatch  a settlement's revenue does not match its winning contracts
+  unaudited_fill    a fill in a market with no audited order before it
+
+Settled markets are checked against their full fill history, so positions
+opened before --since are still accounted for. Unaudited fills usually mean
+orders placed on the website or with audit.enabled turned off.
+
+Exits non-zero if any mismatch is found.`,
+	Example: `  kalshi-cli reconcile --since 30d
+  kalshi-cli reconcile --since 7d --json`,
+	RunE: runReconcile,
+}
+
+var reconcileSince string
+
+// reconcilePageSize is the page size used when paging settlements and fills
+const reconcilePageSize = 200
+
+func init() {
+	rootCmd.AddCommand(reconcileCmd)
+	reconcileCmd.Flags().StringVar(&reconcileSince, "since", "30d", "reconcile activity at or after this time (e.g. 7d, 720h, last monday)")
+	addConcurrencyFlag(reconcileCmd)
+}
+
+func runReconcile(cmd *cobra.Command, args []string) error {
+	since, err := parseSinceArg(reconcileSince)
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	settlements, err := settlementsSince(ctx, client, since)
+	if err != nil {
+		return fmt.Errorf("failed to get settlements: %w", err)
+	}
+
+	windowFills, err := allFills(ctx, client, api.FillsOptions{MinTS: since.Unix()})
+	if err != nil {
+		return fmt.Errorf("failed to get fills: %w", err)
+	}
+
+	var settlementFills []models.Fill
+	for _, s := range settlements {
+		fills, err := allFills(ctx, client, api.FillsOptions{Ticker: s.Ticker})
+		if err != nil {
+			return fmt.Errorf("failed to get fills for %s: %w", s.Ticker, err)
+		}
+		settlementFills = append(settlementFills, fills...)
+	}
+
+	log, err := auditLog()
+	if err != nil {
+		return err
+	}
+	entries, err := log.Load()
+	if err != nil {
+		return err
+	}
+
+	report := reconcile.Run(reconcile.Input{
+		Settlements:     settlements,
+		SettlementFills: settlementFills,
+		WindowFills:     windowFills,
+		Orders:          auditedOrders(entries, cfg.Environment()),
+	})
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() { renderReconcileTable(report, since) },
+		report,
+		func() { renderReconcilePlain(report) },
+	); err != nil {
+		return err
+	}
+
+	if !report.Clean() {
+		return fmt.Errorf("reconciliation found %d mismatches", len(report.Mismatches))
+	}
+	return nil
+}
+
+// historyConcurrency is the number of time windows --concurrency fetches
+// settlements and fills in at once
+var historyConcurrency int
+
+// addConcurrencyFlag registers --concurrency on a command that pages deep
+// settlement or fill history
+func addConcurrencyFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&historyConcurrency, "concurrency", 1, "split the time range into windows and fetch this many at once")
+}
+
+// historyOptions applies --concurrency and reports pages to progress
+func historyOptions(progress *ui.Progress) api.HistoryOptions {
+	return api.HistoryOptions{Concurrency: historyConcurrency, OnPage: progress.Page}
+}
+
+// settlementsSince pages through settlements (newest first) back to since
+func settlementsSince(ctx context.Context, client *api.Client, since time.Time) ([]models.Settlement, error) {
+	opts := api.SettlementsOptions{Limit: reconcilePageSize, MinTS: since.Unix(), SubaccountID: ActiveSubaccount()}
+	progress := ui.NewProgress("settlements", 0)
+	defer progress.Done()
+	all, err := client.GetAllSettlements(ctx, opts, historyOptions(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	settlements := all[:0]
+	for _, s := range all {
+		if !s.SettledTime.Before(since) {
+			settlements = append(settlements, s)
+		}
+	}
+	return settlements, nil
+}
+
+// allFills pages through every fill matching opts
+func allFills(ctx context.Context, client *api.Client, opts api.FillsOptions) ([]models.Fill, error) {
+	opts.Limit = reconcilePageSize
+	opts.SubaccountID = ActiveSubaccount()
+	progress := ui.NewProgress("fills", 0)
+	defer progress.Done()
+	return client.GetAllFills(ctx, opts, historyOptions(progress))
+}
+
+// auditedOrders extracts successful order creations for env from the audit log
+func auditedOrders(entries []audit.Entry, env string) []reconcile.AuditedOrder {
+	var orders []reconcile.AuditedOrder
+	for _, e := range entries {
+		if e.Environment != env || e.Method != "POST" || e.Error != "" || e.Status < 200 || e.Status >= 300 {
+			continue
+		}
+
+		var requests []models.CreateOrderRequest
+		switch strings.TrimSuffix(e.Path, "/") {
+		case api.TradeAPIPrefix + "/portfolio/orders":
+			var req models.CreateOrderRequest
+			if json.Unmarshal(e.Body, &req) != nil {
+				continue
+			}
+			requests = []models.CreateOrderRequest{req}
+		case api.TradeAPIPrefix + "/portfolio/orders/batched", api.TradeAPIPrefix + "/portfolio/orders/batch":
+			var req models.BatchCreateOrdersRequest
+			if json.Unmarshal(e.Body, &req) != nil {
+				continue
+			}
+			requests = req.Orders
+		default:
+			continue
+		}
+
+		for _, r := range requests {
+			orders = append(orders, reconcile.AuditedOrder{Ticker: r.Ticker, Time: e.Time})
+		}
+	}
+	return orders
+}
+
+func renderReconcileTable(report reconcile.Report, since time.Time) {
+	fmt.Fprintf(ui.Writer(), "Reconciled since %s: %d settlements, %d fills, %d audited orders\n\n",
+		formatTimeStr(since), report.Settlements, report.Fills, report.Orders)
+
+	if report.Clean() {
+		PrintSuccess("No mismatches found")
+		return
+	}
+
+	headers := []string{"Time", "Kind", "Market", "Expected", "Actual", "Detail"}
+	rows := make([][]string, 0, len(report.Mismatches))
+	for _, m := range report.Mismatches {
+		expected, actual := formatMismatchValue(m.Kind, m.Expected), formatMismatchValue(m.Kind, m.Actual)
+		if m.Kind == reconcile.UnauditedFill {
+			expected = "-"
+		}
+		rows = append(rows, []string{
+			formatTimeStr(m.Time.Local()),
+			ui.WarningStyle.Render(string(m.Kind)),
+			m.Ticker,
+			expected,
+			actual,
+			m.Detail,
+		})
+	}
+	ui.RenderTable(headers, rows)
+}
+
+func renderReconcilePlain(report reconcile.Report) {
+	for _, m := range report.Mismatches {
+		ui.PrintPlain("%s\t%s\t%s\t%d\t%d\t%s",
+			m.Time.Format(time.RFC3339), m.Kind, m.Ticker, m.Expected, m.Actual, m.TradeID)
+	}
+}
+
+// formatMismatchValue renders contracts as counts and everything else as money
+func formatMismatchValue(kind reconcile.Kind, v int) string {
+	if kind == reconcile.MissingFills || kind == reconcile.UnauditedFill {
+		return fmt.Sprintf("%d", v)
+	}
+	return formatCents(v)
+}
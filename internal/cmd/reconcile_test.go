@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/audit"
+)
+
+func TestAuditedOrders(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ordersPath := api.TradeAPIPrefix + "/portfolio/orders"
+
+	entries := []audit.Entry{
+		{Time: now, Environment: "demo", Method: "POST", Path: ordersPath, Status: 201,
+			Body: json.RawMessage(`{"ticker":"A","side":"yes","action":"buy","count":1}`)},
+		{Time: now, Environment: "demo", Method: "POST", Path: ordersPath + "/batch", Status: 201,
+			Body: json.RawMessage(`{"orders":[{"ticker":"B"},{"ticker":"C"}]}`)},
+		{Time: now, Environment: "demo", Method: "POST", Path: ordersPath + "/batched", Status: 201,
+			Body: json.RawMessage(`{"orders":[{"ticker":"D"}]}`)},
+		{Time: now, Environment: "demo", Method: "POST", Path: ordersPath, Status: 400,
+			Body: json.RawMessage(`{"ticker":"REJECTED"}`)},
+		{Time: now, Environment: "demo", Method: "POST", Path: ordersPath, Error: "read-only mode",
+			Body: json.RawMessage(`{"ticker":"REFUSED"}`)},
+		{Time: now, Environment: "prod", Method: "POST", Path: ordersPath, Status: 201,
+			Body: json.RawMessage(`{"ticker":"PROD"}`)},
+		{Time: now, Environment: "demo", Method: "DELETE", Path: ordersPath + "/abc", Status: 200},
+	}
+
+	orders := auditedOrders(entries, "demo")
+	var tickers []string
+	for _, o := range orders {
+		tickers = append(tickers, o.Ticker)
+	}
+	if strings.Join(tickers, ",") != "A,B,C,D" {
+		t.Errorf("auditedOrders() tickers = %v, want [A B C D]", tickers)
+	}
+}
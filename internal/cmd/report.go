@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/pnl"
+	"github.com/6missedcalls/kalshi-cli/internal/report"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate portfolio reports",
+}
+
+var reportGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Write a P&L report as a standalone HTML file",
+	Long: `Render realized P&L for a period into a single HTML file that can be opened
+in a browser or pasted into an email: net and gross P&L, fees, win rate, daily
+and cumulative P&L charts, and the best and worst markets.
+
+P&L is realized from settlements in the period. Fees are those paid on fills
+in the period. Periods end now: day is the last 24 hours, week the last 7
+days, month the last 30 days. --start (and optionally --end) report on any
+other range instead.`,
+	Example: `  kalshi-cli report generate --period week --out report.html
+  kalshi-cli report generate --period day --out daily.html --json
+  kalshi-cli report generate --start "last monday" --end today`,
+	RunE: runReportGenerate,
+}
+
+var (
+	reportPeriod string
+	reportOut    string
+	reportStart  string
+	reportEnd    string
+)
+
+// reportPeriodSpec is a --period value's length and report title adjective
+type reportPeriodSpec struct {
+	length time.Duration
+	label  string
+}
+
+var reportPeriods = map[string]reportPeriodSpec{
+	"day":   {24 * time.Hour, "Daily"},
+	"week":  {7 * 24 * time.Hour, "Weekly"},
+	"month": {30 * 24 * time.Hour, "Monthly"},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportGenerateCmd)
+
+	reportGenerateCmd.Flags().StringVar(&reportPeriod, "period", "week", "report period: day, week, or month")
+	reportGenerateCmd.Flags().StringVar(&reportOut, "out", "report.html", "output HTML file")
+	reportGenerateCmd.Flags().StringVar(&reportStart, "start", "", "report from this time instead of --period: "+timeArgHelp)
+	reportGenerateCmd.Flags().StringVar(&reportEnd, "end", "", "with --start, report up to this time (default now)")
+	addConcurrencyFlag(reportGenerateCmd)
+}
+
+// realizedPnL fetches settlements and fills for [from, to) and summarizes them
+func realizedPnL(ctx context.Context, client *api.Client, from, to time.Time) (pnl.Summary, error) {
+	settlements, err := settlementsSince(ctx, client, from)
+	if err != nil {
+		return pnl.Summary{}, fmt.Errorf("failed to get settlements: %w", err)
+	}
+
+	fills, err := allFills(ctx, client, api.FillsOptions{MinTS: from.Unix(), MaxTS: to.Unix()})
+	if err != nil {
+		return pnl.Summary{}, fmt.Errorf("failed to get fills: %w", err)
+	}
+
+	return pnl.Summarize(settlements, fills, from, to, time.Local), nil
+}
+
+// reportWindow resolves --period, or --start and --end, into the range to
+// report on. A --start range is the "custom" period.
+func reportWindow(now time.Time) (from, to time.Time, period reportPeriodSpec, err error) {
+	period, ok := reportPeriods[reportPeriod]
+	if !ok {
+		return from, to, period, fmt.Errorf("invalid --period %q: use day, week, or month", reportPeriod)
+	}
+	if reportEnd != "" && reportStart == "" {
+		return from, to, period, fmt.Errorf("--end requires --start")
+	}
+	if reportStart == "" {
+		return now.Add(-period.length), now, period, nil
+	}
+
+	start, end, err := timeRangeArgs(reportStart, reportEnd, now)
+	if err != nil {
+		return from, to, period, err
+	}
+	if end.IsZero() {
+		end = now
+	}
+	if !end.After(start) {
+		return from, to, period, fmt.Errorf("--start must be in the past")
+	}
+	reportPeriod = "custom"
+	return start, end, reportPeriodSpec{length: end.Sub(start), label: "Custom"}, nil
+}
+
+func runReportGenerate(cmd *cobra.Command, args []string) error {
+	from, to, period, err := reportWindow(time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	summary, err := realizedPnL(context.Background(), client, from, to)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(reportOut)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close()
+
+	data := report.Data{
+		Title:       fmt.Sprintf("Kalshi %s P&L Report", period.label),
+		Period:      reportPeriod,
+		Environment: cfg.Environment(),
+		Generated:   outputNow(),
+		Summary:     summary,
+	}
+	if err := report.Render(f, data); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+
+	result := map[string]any{
+		"file":     reportOut,
+		"period":   reportPeriod,
+		"pnl":      summary.PnL,
+		"fees":     summary.Fees,
+		"markets":  len(summary.Markets),
+		"win_rate": summary.WinRate,
+	}
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			PrintSuccess(fmt.Sprintf("Wrote %s report to %s (net P&L %s across %d markets)",
+				reportPeriod, reportOut, formatCents(summary.PnL), len(summary.Markets)))
+		},
+		result,
+		func() {
+			ui.PrintPlain("%s", reportOut)
+		},
+	)
+}
+
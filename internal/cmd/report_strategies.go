@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/notes"
+	"github.com/6missedcalls/kalshi-cli/internal/pnl"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var reportStrategiesCmd = &cobra.Command{
+	Use:   "strategies",
+	Short: "Attribute realized P&L to strategies by market tag",
+	Long: `Break realized P&L down by strategy: win rate, average win and loss,
+expectancy (mean P&L per settled market) and max drawdown for each.
+
+A market's strategies are its tags from markets note --tag. A market with
+several tags counts toward each; one with none is "untagged". Max drawdown is
+the largest fall of the strategy's cumulative P&L from a peak, taking markets
+in settlement order.
+
+The period is chosen as for report generate.`,
+	Example: `  kalshi-cli markets note KXCPI-26MAR --tag momentum
+  kalshi-cli report strategies --period month
+  kalshi-cli report strategies --start 2026-01-01 --json`,
+	Args: cobra.NoArgs,
+	RunE: runReportStrategies,
+}
+
+// strategyReport is the report strategies --json output
+type strategyReport struct {
+	From       time.Time      `json:"from"`
+	To         time.Time      `json:"to"`
+	Strategies []pnl.Strategy `json:"strategies"`
+}
+
+func init() {
+	reportCmd.AddCommand(reportStrategiesCmd)
+
+	reportStrategiesCmd.Flags().StringVar(&reportPeriod, "period", "week", "report period: day, week, or month")
+	reportStrategiesCmd.Flags().StringVar(&reportStart, "start", "", "report from this time instead of --period: "+timeArgHelp)
+	reportStrategiesCmd.Flags().StringVar(&reportEnd, "end", "", "with --start, report up to this time (default now)")
+	addConcurrencyFlag(reportStrategiesCmd)
+}
+
+// strategyLabels returns a market's tags from the notes book
+func strategyLabels(book *notes.Book) func(string) []string {
+	return func(ticker string) []string {
+		return book.Get(ticker).Tags
+	}
+}
+
+func runReportStrategies(cmd *cobra.Command, args []string) error {
+	from, to, _, err := reportWindow(time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	summary, err := realizedPnL(context.Background(), client, from, to)
+	if err != nil {
+		return err
+	}
+
+	result := strategyReport{
+		From:       from,
+		To:         to,
+		Strategies: pnl.Attribute(summary.Markets, strategyLabels(loadNotes())),
+	}
+	if emptyListWarning(len(result.Strategies), "No markets settled in this period") {
+		return nil
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderStrategiesTable(result.Strategies) },
+		result,
+		func() {
+			for _, s := range result.Strategies {
+				ui.PrintPlain("%s\t%d\t%d\t%d\t%.4f\t%.2f\t%.2f\t%.2f\t%d\t%d",
+					s.Label, s.Markets, s.Wins, s.Losses, s.WinRate, s.AvgWin, s.AvgLoss, s.Expectancy, s.PnL, s.MaxDrawdown)
+			}
+		},
+	)
+}
+
+func renderStrategiesTable(strategies []pnl.Strategy) {
+	headers := []string{"Strategy", "Markets", "Win Rate", "Avg Win", "Avg Loss", "Expectancy", "P&L", "Max DD"}
+	rows := make([][]string, 0, len(strategies))
+	for _, s := range strategies {
+		rows = append(rows, []string{
+			s.Label,
+			strconv.Itoa(s.Markets),
+			fmt.Sprintf("%s (%d-%d)", ui.FormatPercent(s.WinRate), s.Wins, s.Losses),
+			formatCents(int(math.Round(s.AvgWin))),
+			formatCents(int(math.Round(s.AvgLoss))),
+			formatCents(int(math.Round(s.Expectancy))),
+			formatCents(s.PnL),
+			formatCents(-s.MaxDrawdown),
+		})
+	}
+	ui.RenderTable(headers, rows)
+}
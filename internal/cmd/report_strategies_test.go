@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/notes"
+	"github.com/6missedcalls/kalshi-cli/internal/pnl"
+)
+
+func TestStrategyLabels(t *testing.T) {
+	book := &notes.Book{Markets: map[string]notes.Entry{}}
+	if err := book.AddTags("KXA", "momentum", "macro"); err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	strategies := pnl.Attribute([]pnl.Market{
+		{Ticker: "KXA", PnL: 120, Settled: day},
+		{Ticker: "KXB", PnL: -30, Settled: day},
+	}, strategyLabels(book))
+
+	got := map[string]int{}
+	for _, s := range strategies {
+		got[s.Label] = s.PnL
+	}
+	want := map[string]int{"momentum": 120, "macro": 120, pnl.Untagged: -30}
+	if len(got) != len(want) {
+		t.Fatalf("strategies = %v, want %v", got, want)
+	}
+	for label, p := range want {
+		if got[label] != p {
+			t.Errorf("%s P&L = %d, want %d", label, got[label], p)
+		}
+	}
+}
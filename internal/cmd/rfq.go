@@ -15,9 +15,11 @@ import (
 
 var (
 	rfqStatus         string
+	rfqCursor         string
 	rfqMarket         string
 	rfqQuantity       int
 	quotesListRFQID   string
+	quotesCursor      string
 	quotesCreateRFQID string
 	quotePrice        int
 )
@@ -107,6 +109,7 @@ var quotesConfirmCmd = &cobra.Command{
 func init() {
 	// RFQ list flags
 	rfqListCmd.Flags().StringVar(&rfqStatus, "status", "", "Filter by status (e.g., open, closed)")
+	rfqListCmd.Flags().StringVar(&rfqCursor, "cursor", "", "Pagination cursor")
 
 	// RFQ create flags
 	rfqCreateCmd.Flags().StringVar(&rfqMarket, "market", "", "Market ticker (required)")
@@ -116,6 +119,7 @@ func init() {
 
 	// Quotes list flags
 	quotesListCmd.Flags().StringVar(&quotesListRFQID, "rfq-id", "", "Filter by RFQ ID")
+	quotesListCmd.Flags().StringVar(&quotesCursor, "cursor", "", "Pagination cursor")
 
 	// Quotes create flags
 	quotesCreateCmd.Flags().StringVar(&quotesCreateRFQID, "rfq", "", "RFQ ID (required)")
@@ -151,6 +155,7 @@ func runRFQList(cmd *cobra.Command, args []string) error {
 
 	result, err := client.GetRFQs(ctx, api.RFQsOptions{
 		Status: rfqStatus,
+		Cursor: rfqCursor,
 	})
 	if err != nil {
 		return err
@@ -159,7 +164,7 @@ func runRFQList(cmd *cobra.Command, args []string) error {
 	return ui.Output(
 		GetOutputFormat(),
 		func() { renderRFQsTable(result.RFQs) },
-		result.RFQs,
+		listJSON(result.RFQs, result.RFQs, result.Cursor),
 		func() { renderRFQsPlain(result.RFQs) },
 	)
 }
@@ -222,7 +227,11 @@ func runRFQCreate(cmd *cobra.Command, args []string) error {
 func runRFQDelete(cmd *cobra.Command, args []string) error {
 	rfqID := args[0]
 
-	if !confirmAction(fmt.Sprintf("Delete RFQ %s?", rfqID)) {
+	confirmed, err := confirmAction(fmt.Sprintf("Delete RFQ %s?", rfqID))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		PrintWarning("Cancelled")
 		return nil
 	}
@@ -253,7 +262,8 @@ func runQuotesList(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	result, err := client.GetQuotes(ctx, api.QuotesOptions{
-		RFQID: quotesListRFQID,
+		RFQID:  quotesListRFQID,
+		Cursor: quotesCursor,
 	})
 	if err != nil {
 		return err
@@ -262,7 +272,7 @@ func runQuotesList(cmd *cobra.Command, args []string) error {
 	return ui.Output(
 		GetOutputFormat(),
 		func() { renderQuotesTable(result.Quotes) },
-		result.Quotes,
+		listJSON(result.Quotes, result.Quotes, result.Cursor),
 		func() { renderQuotesPlain(result.Quotes) },
 	)
 }
@@ -301,7 +311,11 @@ func runQuotesCreate(cmd *cobra.Command, args []string) error {
 func runQuotesAccept(cmd *cobra.Command, args []string) error {
 	quoteID := args[0]
 
-	if !confirmAction(fmt.Sprintf("Accept quote %s?", quoteID)) {
+	confirmed, err := confirmAction(fmt.Sprintf("Accept quote %s?", quoteID))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		PrintWarning("Cancelled")
 		return nil
 	}
@@ -332,7 +346,11 @@ func runQuotesAccept(cmd *cobra.Command, args []string) error {
 func runQuotesConfirm(cmd *cobra.Command, args []string) error {
 	quoteID := args[0]
 
-	if !confirmAction(fmt.Sprintf("Confirm quote %s?", quoteID)) {
+	confirmed, err := confirmAction(fmt.Sprintf("Confirm quote %s?", quoteID))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		PrintWarning("Cancelled")
 		return nil
 	}
@@ -364,7 +382,7 @@ func runQuotesConfirm(cmd *cobra.Command, args []string) error {
 
 func renderRFQsTable(rfqs []models.RFQ) {
 	if len(rfqs) == 0 {
-		fmt.Println("No RFQs found")
+		fmt.Fprintln(ui.Writer(), "No RFQs found")
 		return
 	}
 
@@ -386,7 +404,7 @@ func renderRFQsTable(rfqs []models.RFQ) {
 
 func renderRFQsPlain(rfqs []models.RFQ) {
 	for _, rfq := range rfqs {
-		fmt.Printf("%s\t%s\t%d\t%s\n",
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%d\t%s\n",
 			rfq.ID, rfq.MarketTicker, rfq.Contracts, rfq.Status)
 	}
 }
@@ -403,7 +421,7 @@ func renderRFQDetail(rfq *models.RFQ) {
 }
 
 func renderRFQDetailPlain(rfq *models.RFQ) {
-	fmt.Printf("rfq_id=%s market=%s qty=%d status=%s\n",
+	fmt.Fprintf(ui.Writer(), "rfq_id=%s market=%s qty=%d status=%s\n",
 		rfq.ID, rfq.MarketTicker, rfq.Contracts, rfq.Status)
 }
 
@@ -411,7 +429,7 @@ func renderRFQDetailPlain(rfq *models.RFQ) {
 
 func renderQuotesTable(quotes []models.Quote) {
 	if len(quotes) == 0 {
-		fmt.Println("No quotes found")
+		fmt.Fprintln(ui.Writer(), "No quotes found")
 		return
 	}
 
@@ -436,7 +454,7 @@ func renderQuotesTable(quotes []models.Quote) {
 
 func renderQuotesPlain(quotes []models.Quote) {
 	for _, quote := range quotes {
-		fmt.Printf("%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+		fmt.Fprintf(ui.Writer(), "%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
 			quote.ID, quote.RFQID, quote.MarketTicker,
 			quote.YesBid, quote.NoBid, quote.Contracts, quote.Status)
 	}
@@ -457,7 +475,7 @@ func renderQuoteDetail(quote *models.Quote) {
 }
 
 func renderQuoteDetailPlain(quote *models.Quote) {
-	fmt.Printf("quote_id=%s rfq_id=%s market=%s yes_bid=%d no_bid=%d qty=%d status=%s\n",
+	fmt.Fprintf(ui.Writer(), "quote_id=%s rfq_id=%s market=%s yes_bid=%d no_bid=%d qty=%d status=%s\n",
 		quote.ID, quote.RFQID, quote.MarketTicker,
 		quote.YesBid, quote.NoBid, quote.Contracts, quote.Status)
 }
@@ -1,25 +1,40 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/6missedcalls/kalshi-cli/internal/api"
 	"github.com/6missedcalls/kalshi-cli/internal/config"
 	"github.com/6missedcalls/kalshi-cli/internal/ui"
 )
 
 var (
-	cfgFile   string
-	useProd   bool
-	jsonOut   bool
-	plainOut  bool
-	yesFlag   bool
-	verbose   bool
-	cfg       *config.Config
-	outputFmt ui.OutputFormat
+	cfgFile       string
+	cfgDir        string
+	useProd       bool
+	jsonOut       bool
+	plainOut      bool
+	outputFile    string
+	noProgress    bool
+	yesFlag       bool
+	noInput       bool
+	noKeyring     bool
+	deterministic bool
+	envelope      bool
+	strictDecode  bool
+	verbose       bool
+	maxReqs       int64
+	readOnly      bool
+	subaccount    int
+	cfg           *config.Config
+	outputFmt     ui.OutputFormat
 
 	buildVersion = "dev"
 	buildCommit  = "none"
@@ -35,52 +50,136 @@ real-time WebSocket streaming, and a first-class trading experience.
 
 By default, commands use the demo API. Use --prod for production.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return initConfig()
+		if err := initConfig(cmd); err != nil {
+			return err
+		}
+		startHealthReporter(cmd)
+		printEnvBanner(cmd)
+		return nil
 	},
 	SilenceUsage:  true,
 	SilenceErrors: true,
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	start := time.Now()
+	rootCmd.SetArgs(aliasArgs())
+	executed, err := rootCmd.ExecuteC()
+	if closeErr := closeOutputFile(); err == nil {
+		err = closeErr
+	}
+	recordUsage(executed, start, err)
+	if IsVerbose() {
+		if m := sessionMetrics.Snapshot(); m.Requests > 0 {
+			pace := ""
+			if sessionLimiter != nil {
+				p := sessionLimiter.Pace()
+				pace = fmt.Sprintf(", pace %s (lowest %s)", formatPaceOf(p.Rate, p.Limit), formatPace(p.Lowest))
+			}
+			fmt.Fprintf(os.Stderr, "[session] %d requests, %d retries, %d rate limited%s\n", m.Requests, m.Retries, m.RateLimited, pace)
+		}
+	}
+	if errors.Is(err, api.ErrRequestBudgetExceeded) {
+		err = fmt.Errorf("%w (raise or remove --max-requests)", err)
+	}
+	if errors.Is(err, api.ErrReadOnly) {
+		err = fmt.Errorf("%w (read-only mode is enabled by --read-only or api.read_only)", err)
+	}
+	return err
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.kalshi/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is config.yaml in the config directory, see 'config paths')")
+	rootCmd.PersistentFlags().StringVar(&cfgDir, "config-dir", "", "keep config, logs, snapshots and cache in this directory (or set KALSHI_CONFIG_DIR)")
 	rootCmd.PersistentFlags().BoolVar(&useProd, "prod", false, "use production API (default: demo)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "output as JSON")
 	rootCmd.PersistentFlags().BoolVar(&plainOut, "plain", false, "output as plain text (for pipes)")
+	rootCmd.PersistentFlags().StringVar(&outputFile, "output-file", "", "write output to this file instead of stdout, replacing it")
+	rootCmd.PersistentFlags().BoolVar(&noProgress, "no-progress", false, "do not report progress on stderr while paging through long listings and exports")
 	rootCmd.PersistentFlags().BoolVarP(&yesFlag, "yes", "y", false, "skip confirmation prompts")
+	rootCmd.PersistentFlags().BoolVar(&noInput, "no-input", false, "never prompt; fail if input or confirmation is required")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().IntVar(&subaccount, "subaccount", 0, "subaccount for orders, positions, fills and balance (default: defaults.subaccount)")
+	rootCmd.PersistentFlags().BoolVar(&readOnly, "read-only", false, "refuse all write requests such as orders, transfers and key changes (default: api.read_only)")
+	rootCmd.PersistentFlags().Int64Var(&maxReqs, "max-requests", 0, "abort once this command has issued N HTTP requests (0 = unlimited)")
+	rootCmd.PersistentFlags().BoolVar(&noKeyring, "no-keyring", false, "never use the system keyring; take credentials from config or the environment (or set KALSHI_NO_KEYRING)")
+
+	rootCmd.PersistentFlags().BoolVar(&strictDecode, "strict-decode", false, "fail on API responses with unknown fields or unexpected types, to catch API changes")
+	rootCmd.PersistentFlags().BoolVar(&deterministic, "deterministic", false, "stable output for scripts and tests: fixed timestamps, sorted lists, times in UTC, no colors")
+	rootCmd.PersistentFlags().BoolVar(&envelope, "envelope", false, "with --json, wrap lists as {items, cursor, count, fetched_at} and watch lines as {received_at, channel, seq, data}")
 
 	viper.BindPFlag("api.production", rootCmd.PersistentFlags().Lookup("prod"))
 	viper.BindPFlag("output.json", rootCmd.PersistentFlags().Lookup("json"))
 	viper.BindPFlag("output.plain", rootCmd.PersistentFlags().Lookup("plain"))
+	viper.BindPFlag("no_keyring", rootCmd.PersistentFlags().Lookup("no-keyring"))
 
 	rootCmd.AddCommand(versionCmd)
 }
 
-func initConfig() error {
+func initConfig(cmd *cobra.Command) error {
 	var err error
+	config.SetDirOverride(cfgDir)
 	cfg, err = config.Load(cfgFile)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	currentCommand = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+
+	if viper.GetBool("no_keyring") {
+		config.DisableKeyring()
+	}
+	if deterministic {
+		applyDeterministic()
+	}
+	ui.SetProgress(!noProgress)
+
 	if useProd {
 		cfg.API.Production = true
 	}
+	if readOnly {
+		cfg.API.ReadOnly = true
+	}
+
+	if cmd.Flags().Changed("subaccount") {
+		if subaccount < 0 {
+			return fmt.Errorf("--subaccount must be zero or a positive number")
+		}
+		cfg.Defaults.Subaccount = subaccount
+	}
+
+	if err := initPaper(); err != nil {
+		return err
+	}
+
+	if err := cfg.CheckEndpoints(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := initNetwork(cmd); err != nil {
+		return err
+	}
+
+	if maxReqs < 0 {
+		return fmt.Errorf("--max-requests must be zero or positive")
+	}
+	sessionMetrics.SetLimit(maxReqs)
 
 	switch {
 	case jsonOut:
 		outputFmt = ui.FormatJSON
 	case plainOut:
 		outputFmt = ui.FormatPlain
-	default:
+	case cfg.Output.Format == "json":
+		outputFmt = ui.FormatJSON
+	case cfg.Output.Format == "plain":
+		outputFmt = ui.FormatPlain
+	case cfg.Output.Format == "table", cfg.Output.Format == "":
 		outputFmt = ui.FormatTable
+	default:
+		return fmt.Errorf("invalid output.format %q: use table, json or plain", cfg.Output.Format)
 	}
 
-	return nil
+	return openOutputFile()
 }
 
 func GetConfig() *config.Config {
@@ -91,6 +190,14 @@ func GetOutputFormat() ui.OutputFormat {
 	return outputFmt
 }
 
+// ActiveSubaccount returns the subaccount commands are scoped to (0 = primary)
+func ActiveSubaccount() int {
+	if cfg == nil {
+		return 0
+	}
+	return cfg.Defaults.Subaccount
+}
+
 func IsVerbose() bool {
 	return verbose
 }
@@ -99,16 +206,31 @@ func SkipConfirmation() bool {
 	return yesFlag
 }
 
+// NoInput reports whether --no-input forbids interactive prompts
+func NoInput() bool {
+	return noInput
+}
+
+// ExitStatusError ends the process with Code and no message, for a command
+// whose failure was already reported, such as a child kalshi-cli run by query run
+type ExitStatusError struct {
+	Code int
+}
+
+func (e *ExitStatusError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
 func PrintError(err error) {
 	fmt.Fprintf(os.Stderr, "%s %s\n", ui.ErrorStyle.Render("Error:"), err.Error())
 }
 
 func PrintSuccess(msg string) {
-	fmt.Println(ui.SuccessStyle.Render(msg))
+	fmt.Fprintln(ui.Writer(), ui.SuccessStyle.Render(msg))
 }
 
 func PrintWarning(msg string) {
-	fmt.Println(ui.WarningStyle.Render(msg))
+	fmt.Fprintln(ui.Terminal(), ui.WarningStyle.Render(msg))
 }
 
 // SetVersionInfo is called from main to inject build-time variables.
@@ -123,8 +245,8 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("kalshi-cli %s\n", buildVersion)
-		fmt.Printf("  commit:  %s\n", buildCommit)
-		fmt.Printf("  built:   %s\n", buildDate)
+		fmt.Fprintf(ui.Writer(), "kalshi-cli %s\n", buildVersion)
+		fmt.Fprintf(ui.Writer(), "  commit:  %s\n", buildCommit)
+		fmt.Fprintf(ui.Writer(), "  built:   %s\n", buildDate)
 	},
 }
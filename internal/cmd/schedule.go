@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run commands on market lifecycle events",
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run hooks when a market opens or nears close",
+	Long: `Run shell commands when a market opens or nears its close.
+
+Hooks are timed from the market's open_time and close_time and also follow the
+market lifecycle WebSocket channel, so a market that opens early, closes early,
+or has its close time moved still triggers them. Each hook runs at most once;
+the command exits after every configured hook has run.
+
+A hook that comes due during known exchange downtime, a maintenance window
+or outside trading hours from the exchange schedule, is held until the
+downtime ends.
+
+Hooks run through the shell with these environment variables:
+  KALSHI_MARKET   market ticker
+  KALSHI_HOOK     open or close
+  KALSHI_ENV      demo or production
+  KALSHI_CLI      path to this kalshi-cli binary, for order templates`,
+	Example: `  kalshi-cli schedule run --market KXBTC-26FEB12-B97000 --on-open 'notify-send "$KALSHI_MARKET open"'
+  kalshi-cli schedule run --market KXBTC-26FEB12-B97000 --before-close 5m \
+    --on-close '$KALSHI_CLI orders cancel-all --market $KALSHI_MARKET --yes'`,
+	RunE: runScheduleRun,
+}
+
+var (
+	scheduleMarket      string
+	scheduleOnOpen      string
+	scheduleOnClose     string
+	scheduleBeforeClose time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+
+	scheduleRunCmd.Flags().StringVar(&scheduleMarket, "market", "", "market ticker (required)")
+	scheduleRunCmd.Flags().StringVar(&scheduleOnOpen, "on-open", "", "command to run when the market opens")
+	scheduleRunCmd.Flags().StringVar(&scheduleOnClose, "on-close", "", "command to run when the market nears close")
+	scheduleRunCmd.Flags().DurationVar(&scheduleBeforeClose, "before-close", 0, "run --on-close this long before close_time")
+	scheduleRunCmd.MarkFlagRequired("market")
+}
+
+const (
+	hookOpen  = "open"
+	hookClose = "close"
+)
+
+// marketScheduler fires the open and close hooks of one market exactly once,
+// whichever of the timers or the lifecycle channel gets there first
+type marketScheduler struct {
+	ticker      string
+	commands    map[string]string
+	beforeClose time.Duration
+	run         func(hook, command string)
+	calendar    *exchangeCalendar
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+	fired  map[string]bool
+	done   chan struct{}
+}
+
+func newMarketScheduler(ticker, onOpen, onClose string, beforeClose time.Duration, run func(hook, command string)) *marketScheduler {
+	commands := make(map[string]string)
+	if onOpen != "" {
+		commands[hookOpen] = onOpen
+	}
+	if onClose != "" {
+		commands[hookClose] = onClose
+	}
+	return &marketScheduler{
+		ticker:      ticker,
+		commands:    commands,
+		beforeClose: beforeClose,
+		run:         run,
+		timers:      make(map[string]*time.Timer),
+		fired:       make(map[string]bool),
+		done:        make(chan struct{}),
+	}
+}
+
+// schedule (re)arms the hook timers from market metadata. The close hook
+// fires immediately if the market is already inside the --before-close window;
+// the open hook is skipped if the market is already open.
+func (s *marketScheduler) schedule(market *models.Market, now time.Time) {
+	open := marketIsOpen(market.Status)
+
+	if marketIsClosed(market.Status) {
+		s.skip(hookOpen)
+		s.fire(hookClose)
+		return
+	}
+
+	if open {
+		s.skip(hookOpen)
+	} else if !market.OpenTime.IsZero() {
+		s.arm(hookOpen, market.OpenTime.Sub(now))
+	}
+
+	if !market.CloseTime.IsZero() {
+		at := market.CloseTime.Add(-s.beforeClose)
+		if open || !at.Before(now) {
+			s.arm(hookClose, at.Sub(now))
+		}
+	}
+}
+
+// observeStatus reacts to a lifecycle status change
+func (s *marketScheduler) observeStatus(status string) {
+	switch {
+	case marketIsOpen(status):
+		s.fire(hookOpen)
+	case marketIsClosed(status):
+		s.fire(hookClose)
+	}
+}
+
+func (s *marketScheduler) arm(hook string, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.commands[hook]; !ok || s.fired[hook] {
+		return
+	}
+	if t, ok := s.timers[hook]; ok {
+		t.Stop()
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	s.timers[hook] = time.AfterFunc(delay, func() { s.fire(hook) })
+}
+
+func (s *marketScheduler) fire(hook string) {
+	s.mu.Lock()
+	command, ok := s.commands[hook]
+	if !ok || s.fired[hook] {
+		s.mu.Unlock()
+		return
+	}
+	// A hook due in known exchange downtime waits for it to end, since the
+	// exchange would reject its orders anyway
+	if reason, until, down := s.calendar.downtime(time.Now()); down && !until.IsZero() {
+		s.mu.Unlock()
+		fmt.Fprintf(os.Stderr, "Holding %s --on-%s: %s\n", s.ticker, hook, describeDowntime(reason, until))
+		s.arm(hook, time.Until(until))
+		return
+	}
+	s.fired[hook] = true
+	if t, ok := s.timers[hook]; ok {
+		t.Stop()
+	}
+	s.mu.Unlock()
+
+	s.run(hook, command)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkDone()
+}
+
+// skip marks a hook as done without running it
+func (s *marketScheduler) skip(hook string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.commands[hook]; !ok || s.fired[hook] {
+		return
+	}
+	s.fired[hook] = true
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "%s is already past its %s time, skipping --on-%s\n", s.ticker, hook, hook)
+	}
+	s.checkDone()
+}
+
+// checkDone closes done once every configured hook has run; s.mu must be held
+func (s *marketScheduler) checkDone() {
+	if len(s.fired) < len(s.commands) {
+		return
+	}
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
+
+// stop cancels pending timers
+func (s *marketScheduler) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.timers {
+		t.Stop()
+	}
+}
+
+func marketIsOpen(status string) bool {
+	switch status {
+	case "open", "active", "activated":
+		return true
+	}
+	return false
+}
+
+func marketIsClosed(status string) bool {
+	switch status {
+	case "closed", "deactivated", "determined", "settled", "finalized":
+		return true
+	}
+	return false
+}
+
+// runScheduleHook runs a hook command through the shell and reports the result
+func runScheduleHook(ctx context.Context, ticker, hook, command string) {
+	started := time.Now()
+	reportHook(ticker, hook, command, "running", nil, 0)
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	c := exec.CommandContext(ctx, shell, flag, command)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(),
+		"KALSHI_MARKET="+ticker,
+		"KALSHI_HOOK="+hook,
+		"KALSHI_ENV="+cfg.Environment(),
+	)
+	if exe, err := os.Executable(); err == nil {
+		c.Env = append(c.Env, "KALSHI_CLI="+exe)
+	}
+
+	err := c.Run()
+	reportHook(ticker, hook, command, "finished", err, time.Since(started))
+}
+
+func reportHook(ticker, hook, command, state string, err error, elapsed time.Duration) {
+	exitCode := 0
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		exitCode = -1
+	}
+
+	switch GetOutputFormat() {
+	case ui.FormatJSON:
+		line := map[string]any{
+			"type":    "hook",
+			"state":   state,
+			"hook":    hook,
+			"market":  ticker,
+			"command": command,
+			"time":    outputNow().UTC(),
+		}
+		if state == "finished" {
+			line["exit_code"] = exitCode
+			line["duration"] = elapsed.String()
+			if err != nil {
+				line["error"] = err.Error()
+			}
+		}
+		printJSONLine(line)
+	case ui.FormatPlain:
+		if state == "finished" {
+			fmt.Fprintf(ui.Writer(), "%s hook=%s market=%s state=%s exit_code=%d\n", formatTimestamp(), hook, ticker, state, exitCode)
+		} else {
+			fmt.Fprintf(ui.Writer(), "%s hook=%s market=%s state=%s command=%q\n", formatTimestamp(), hook, ticker, state, command)
+		}
+	default:
+		switch {
+		case state == "running":
+			fmt.Fprintf(ui.Writer(), "[%s] %s on-%s: %s\n", formatTimestamp(), ticker, hook, ui.MutedStyle.Render(command))
+		case err != nil:
+			fmt.Fprintln(ui.Writer(), ui.ErrorStyle.Render(fmt.Sprintf("[%s] %s on-%s failed: %v", formatTimestamp(), ticker, hook, err)))
+		default:
+			fmt.Fprintln(ui.Writer(), ui.SuccessStyle.Render(fmt.Sprintf("[%s] %s on-%s finished in %s", formatTimestamp(), ticker, hook, elapsed.Round(time.Millisecond))))
+		}
+	}
+}
+
+func runScheduleRun(cmd *cobra.Command, args []string) error {
+	if scheduleOnOpen == "" && scheduleOnClose == "" {
+		return fmt.Errorf("at least one of --on-open or --on-close is required")
+	}
+	if scheduleBeforeClose < 0 {
+		return fmt.Errorf("--before-close must be zero or positive")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := alertContext()
+	defer stop()
+
+	market, err := fetchScheduleMarket(ctx, client)
+	if err != nil {
+		return err
+	}
+	if marketIsClosed(market.Status) {
+		return fmt.Errorf("market %s is already %s", market.Ticker, market.Status)
+	}
+	if scheduleOnClose == "" && marketIsOpen(market.Status) {
+		return fmt.Errorf("market %s is already open", market.Ticker)
+	}
+
+	scheduler := newMarketScheduler(scheduleMarket, scheduleOnOpen, scheduleOnClose, scheduleBeforeClose,
+		func(hook, command string) { runScheduleHook(ctx, scheduleMarket, hook, command) })
+	scheduler.calendar = loadExchangeCalendar(ctx, client)
+	defer scheduler.stop()
+
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "%s is %s (opens %s, closes %s)\n",
+			market.Ticker, market.Status, formatTimeStr(market.OpenTime), formatTimeStr(market.CloseTime))
+	}
+
+	wsClient, err := subscribeLifecycle(ctx, scheduler, client)
+	if err != nil {
+		return err
+	}
+	defer wsClient.Close()
+
+	// Arm timers after subscribing so a transition in between is not missed
+	scheduler.schedule(market, time.Now())
+
+	select {
+	case <-ctx.Done():
+	case <-scheduler.done:
+	}
+	return nil
+}
+
+func fetchScheduleMarket(ctx context.Context, client *api.Client) (*models.Market, error) {
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	return client.GetMarket(reqCtx, scheduleMarket)
+}
+
+// subscribeLifecycle follows the market's lifecycle channel, firing hooks on
+// status changes and re-reading market metadata when close times move
+func subscribeLifecycle(ctx context.Context, scheduler *marketScheduler, client *api.Client) (*websocket.Client, error) {
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	wsClient := newWebSocketClient(opts)
+	wsClient.OnError(func(err error) {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	})
+	wsClient.RegisterHandler(websocket.ChannelMarketLifecycle, websocket.HandlerFunc(func(msg websocket.Message) error {
+		var data websocket.MarketLifecycleData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse lifecycle data: %w", err)
+		}
+		if data.Ticker != "" && data.Ticker != scheduleMarket {
+			return nil
+		}
+
+		scheduler.observeStatus(data.Status)
+		if market, err := fetchScheduleMarket(ctx, client); err == nil {
+			scheduler.schedule(market, time.Now())
+		}
+		return nil
+	}))
+
+	if err := wsClient.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	params := map[string]string{"market_tickers": scheduleMarket}
+	if err := wsClient.Subscribe(ctx, websocket.ChannelMarketLifecycle, params); err != nil {
+		wsClient.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", websocket.ChannelMarketLifecycle, err)
+	}
+	return wsClient, nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+type hookRecorder struct {
+	mu    sync.Mutex
+	hooks []string
+}
+
+func (r *hookRecorder) run(hook, _ string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, hook)
+}
+
+func (r *hookRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.hooks...)
+}
+
+func waitDone(t *testing.T, s *marketScheduler) {
+	t.Helper()
+	select {
+	case <-s.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("scheduler did not finish")
+	}
+}
+
+func TestMarketSchedulerTimers(t *testing.T) {
+	rec := &hookRecorder{}
+	s := newMarketScheduler("MKT", "echo open", "echo close", time.Hour, rec.run)
+	defer s.stop()
+
+	now := time.Now()
+	s.schedule(&models.Market{
+		Status:    "initialized",
+		OpenTime:  now.Add(10 * time.Millisecond),
+		CloseTime: now.Add(time.Hour + 30*time.Millisecond), // --before-close 1h
+	}, now)
+
+	waitDone(t, s)
+	if got := rec.get(); len(got) != 2 || got[0] != hookOpen || got[1] != hookClose {
+		t.Errorf("hooks = %v, expected [open close]", got)
+	}
+}
+
+func TestMarketSchedulerLifecycleFiresOnce(t *testing.T) {
+	rec := &hookRecorder{}
+	s := newMarketScheduler("MKT", "echo open", "echo close", 0, rec.run)
+	defer s.stop()
+
+	now := time.Now()
+	s.schedule(&models.Market{Status: "initialized", OpenTime: now.Add(time.Hour), CloseTime: now.Add(2 * time.Hour)}, now)
+
+	s.observeStatus("activated") // opened early
+	s.observeStatus("activated")
+	s.observeStatus("deactivated") // closed early
+	s.observeStatus("settled")
+
+	waitDone(t, s)
+	if got := rec.get(); len(got) != 2 || got[0] != hookOpen || got[1] != hookClose {
+		t.Errorf("hooks = %v, expected [open close]", got)
+	}
+}
+
+func TestMarketSchedulerSkipsOpenWhenAlreadyOpen(t *testing.T) {
+	rec := &hookRecorder{}
+	s := newMarketScheduler("MKT", "echo open", "echo close", 0, rec.run)
+	defer s.stop()
+
+	now := time.Now()
+	s.schedule(&models.Market{Status: "active", OpenTime: now.Add(-time.Hour), CloseTime: now.Add(-time.Second)}, now)
+
+	waitDone(t, s)
+	if got := rec.get(); len(got) != 1 || got[0] != hookClose {
+		t.Errorf("hooks = %v, expected [close]", got)
+	}
+}
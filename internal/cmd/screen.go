@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+// screen is the terminal loop of the full-screen views, watch orderbook
+// --ladder and tui. It runs on bubbletea, which owns raw mode, restoring the
+// terminal, resizes and key input; each view only supplies how it handles
+// keys and renders.
+type screen struct {
+	// redraw is how often render is called
+	redraw time.Duration
+	// render writes the view, returning false when nothing changed since the
+	// last call
+	render func(w io.Writer) bool
+	// key handles one key and returns true to quit
+	key func(key string) bool
+
+	// refresh is how often onRefresh is called, and wake calls it between
+	// refreshes; both are optional
+	refresh   time.Duration
+	wake      <-chan struct{}
+	onRefresh func()
+}
+
+// requireScreen returns an error unless the command runs in an interactive
+// terminal with table output and may read keys from it. what names the
+// command in the error and keys what the keys are for.
+func requireScreen(what, keys string) error {
+	if GetOutputFormat() != ui.FormatTable || !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("%s needs an interactive terminal and table output", what)
+	}
+	return requireInput(keys)
+}
+
+// run takes over the terminal until a key quits
+func (s screen) run() error {
+	if _, err := tea.NewProgram(&screenModel{screen: s}, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("failed to run the terminal view: %w", err)
+	}
+	return nil
+}
+
+// Messages driving screenModel besides keys
+type (
+	screenDrawMsg    struct{}
+	screenRefreshMsg struct{}
+	screenWakeMsg    struct{}
+)
+
+// screenModel adapts a screen to bubbletea, keeping the last frame the view
+// rendered since render only writes when something changed
+type screenModel struct {
+	screen
+	frame string
+}
+
+// redrawCodes are the in-place redraw codes the views share with the
+// line-based live views; bubbletea clears lines itself
+var redrawCodes = strings.NewReplacer(redrawEOL, "", redrawBelow, "")
+
+func (m *screenModel) Init() tea.Cmd {
+	m.draw()
+	cmds := []tea.Cmd{m.tick(m.redraw, screenDrawMsg{})}
+	if m.refresh > 0 {
+		cmds = append(cmds, m.tick(m.refresh, screenRefreshMsg{}))
+	}
+	if m.wake != nil {
+		cmds = append(cmds, m.waitWake)
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *screenModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var next tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		for _, key := range keyNames(msg) {
+			if m.key(key) {
+				return m, tea.Quit
+			}
+		}
+	case screenDrawMsg:
+		next = m.tick(m.redraw, screenDrawMsg{})
+	case screenRefreshMsg:
+		m.onRefresh()
+		next = m.tick(m.refresh, screenRefreshMsg{})
+	case screenWakeMsg:
+		m.onRefresh()
+		next = m.waitWake
+	}
+	m.draw()
+	return m, next
+}
+
+func (m *screenModel) View() string {
+	return m.frame
+}
+
+// draw keeps the view's latest frame if it rendered one
+func (m *screenModel) draw() {
+	var b strings.Builder
+	if m.render(&b) {
+		m.frame = redrawCodes.Replace(b.String())
+	}
+}
+
+func (m *screenModel) tick(d time.Duration, msg tea.Msg) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return msg })
+}
+
+// waitWake blocks until the view asks for a refresh. A closed wake channel
+// stops waking rather than spinning.
+func (m *screenModel) waitWake() tea.Msg {
+	if _, ok := <-m.wake; !ok {
+		return nil
+	}
+	return screenWakeMsg{}
+}
+
+// keyNames turns a key message into the key names views handle. Typed or
+// pasted text arrives as several runes at once.
+func keyNames(msg tea.KeyMsg) []string {
+	if msg.Type != tea.KeyRunes || msg.Alt {
+		return []string{msg.String()}
+	}
+	keys := make([]string, len(msg.Runes))
+	for i, r := range msg.Runes {
+		keys[i] = string(r)
+	}
+	return keys
+}
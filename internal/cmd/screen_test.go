@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyNames(t *testing.T) {
+	for _, tt := range []struct {
+		msg  tea.KeyMsg
+		want []string
+	}{
+		{tea.KeyMsg{Type: tea.KeyUp}, []string{"up"}},
+		{tea.KeyMsg{Type: tea.KeyTab}, []string{"tab"}},
+		{tea.KeyMsg{Type: tea.KeyEnter}, []string{"enter"}},
+		{tea.KeyMsg{Type: tea.KeyBackspace}, []string{"backspace"}},
+		{tea.KeyMsg{Type: tea.KeyEsc}, []string{"esc"}},
+		{tea.KeyMsg{Type: tea.KeyCtrlC}, []string{"ctrl+c"}},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b12")}, []string{"b", "1", "2"}},
+	} {
+		if got := keyNames(tt.msg); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("keyNames(%v) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestScreenModel(t *testing.T) {
+	var pressed []string
+	frames, refreshes := 0, 0
+	m := &screenModel{screen: screen{
+		redraw: time.Second,
+		render: func(w io.Writer) bool {
+			frames++
+			fmt.Fprintf(w, "frame %d%s\n%s", frames, redrawEOL, redrawBelow)
+			return true
+		},
+		key: func(key string) bool {
+			pressed = append(pressed, key)
+			return key == "q"
+		},
+		refresh:   time.Second,
+		onRefresh: func() { refreshes++ },
+	}}
+
+	m.Init()
+	if m.View() != "frame 1\n" {
+		t.Errorf("first frame = %q", m.View())
+	}
+	if _, cmd := m.Update(screenRefreshMsg{}); cmd == nil || refreshes != 1 {
+		t.Errorf("refresh did not reload and reschedule (refreshes = %d)", refreshes)
+	}
+	if !strings.Contains(m.View(), "frame 2") {
+		t.Errorf("frame after refresh = %q", m.View())
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("xq")})
+	if !reflect.DeepEqual(pressed, []string{"x", "q"}) {
+		t.Errorf("pressed = %v", pressed)
+	}
+	if cmd == nil {
+		t.Fatal("q did not quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q did not quit")
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var seriesStatsCmd = &cobra.Command{
+	Use:   "stats <series-ticker>",
+	Short: "Aggregate statistics across a series' past markets",
+	Long: `Summarize a series' settled markets: volume per market and per event, the
+typical bid/ask spread of its open markets, how markets settled, and how often
+the favorite won.
+
+The favorite is the side priced above 50¢ at --favorite-at before close, taken
+from hourly candlesticks. Comparing the favorite win rate with the average
+favorite price shows whether favorites in this series are over- or under-priced.
+Use --favorite-at 0 to skip the candlestick lookups.`,
+	Example: `  kalshi-cli markets series stats KXBTC
+  kalshi-cli markets series stats INXD --limit 200 --favorite-at 2h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSeriesStats,
+}
+
+var (
+	seriesStatsLimit      int
+	seriesStatsFavoriteAt time.Duration
+)
+
+// seriesStatsPageSize is the page size used when listing a series' markets
+const seriesStatsPageSize = 200
+
+func init() {
+	seriesCmd.AddCommand(seriesStatsCmd)
+
+	seriesStatsCmd.Flags().IntVar(&seriesStatsLimit, "limit", 100, "number of most recent settled markets to analyze")
+	seriesStatsCmd.Flags().DurationVar(&seriesStatsFavoriteAt, "favorite-at", 24*time.Hour, "how long before close the favorite is picked (0 to skip)")
+}
+
+// seriesStats is the output of 'markets series stats'
+type seriesStats struct {
+	SeriesTicker     string         `json:"series_ticker"`
+	SettledMarkets   int            `json:"settled_markets"`
+	Events           int            `json:"events"`
+	AvgVolume        float64        `json:"avg_volume"`
+	AvgEventVolume   float64        `json:"avg_event_volume"`
+	OpenMarkets      int            `json:"open_markets"`
+	MedianSpread     int            `json:"median_spread"`
+	AvgSpread        float64        `json:"avg_spread"`
+	Results          map[string]int `json:"results"`
+	FavoriteMarkets  int            `json:"favorite_markets"`
+	FavoriteWins     int            `json:"favorite_wins"`
+	FavoriteWinRate  float64        `json:"favorite_win_rate"`
+	AvgFavoritePrice float64        `json:"avg_favorite_price"`
+}
+
+// summarizeSeries aggregates settled and open markets. yesPrices holds the
+// yes price each settled market traded at when its favorite was picked.
+func summarizeSeries(series string, settled, open []models.Market, yesPrices map[string]int) seriesStats {
+	stats := seriesStats{
+		SeriesTicker:   series,
+		SettledMarkets: len(settled),
+		OpenMarkets:    len(open),
+		Results:        make(map[string]int),
+	}
+
+	events := make(map[string]bool)
+	volume := 0
+	for _, m := range settled {
+		events[m.EventTicker] = true
+		volume += m.Volume
+		result := m.Result
+		if result == "" {
+			result = "unknown"
+		}
+		stats.Results[result]++
+	}
+	stats.Events = len(events)
+	if len(settled) > 0 {
+		stats.AvgVolume = float64(volume) / float64(len(settled))
+		stats.AvgEventVolume = float64(volume) / float64(len(events))
+	}
+
+	var spreads []int
+	for _, m := range open {
+		if m.YesBid > 0 && m.YesAsk > 0 {
+			spreads = append(spreads, m.YesAsk-m.YesBid)
+		}
+	}
+	if len(spreads) > 0 {
+		sort.Ints(spreads)
+		stats.MedianSpread = spreads[len(spreads)/2]
+		total := 0
+		for _, s := range spreads {
+			total += s
+		}
+		stats.AvgSpread = float64(total) / float64(len(spreads))
+	}
+
+	favoritePriceTotal := 0
+	for _, m := range settled {
+		price, ok := yesPrices[m.Ticker]
+		if !ok || price == 50 || (m.Result != "yes" && m.Result != "no") {
+			continue
+		}
+
+		favorite, favoritePrice := "yes", price
+		if price < 50 {
+			favorite, favoritePrice = "no", 100-price
+		}
+		stats.FavoriteMarkets++
+		favoritePriceTotal += favoritePrice
+		if m.Result == favorite {
+			stats.FavoriteWins++
+		}
+	}
+	if stats.FavoriteMarkets > 0 {
+		stats.FavoriteWinRate = float64(stats.FavoriteWins) / float64(stats.FavoriteMarkets)
+		stats.AvgFavoritePrice = float64(favoritePriceTotal) / float64(stats.FavoriteMarkets)
+	}
+
+	return stats
+}
+
+func runSeriesStats(cmd *cobra.Command, args []string) error {
+	series := args[0]
+	if seriesStatsLimit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	settled, err := listSeriesMarkets(ctx, client, series, "settled", seriesStatsLimit)
+	if err != nil {
+		return err
+	}
+	if len(settled) == 0 {
+		PrintWarning(fmt.Sprintf("No settled markets found for series %s", series))
+		return nil
+	}
+
+	open, err := listSeriesMarkets(ctx, client, series, "open", seriesStatsPageSize)
+	if err != nil {
+		return err
+	}
+
+	yesPrices := make(map[string]int)
+	if seriesStatsFavoriteAt > 0 {
+		for _, m := range settled {
+			price, err := priceBeforeClose(ctx, client, series, m, seriesStatsFavoriteAt)
+			if err != nil {
+				return err
+			}
+			if price > 0 {
+				yesPrices[m.Ticker] = price
+			}
+		}
+	}
+
+	stats := summarizeSeries(series, settled, open, yesPrices)
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderSeriesStats(stats) },
+		stats,
+		func() {
+			ui.PrintPlain("series=%s markets=%d events=%d avg_volume=%.1f median_spread=%d favorite_win_rate=%.3f avg_favorite_price=%.1f",
+				stats.SeriesTicker, stats.SettledMarkets, stats.Events, stats.AvgVolume,
+				stats.MedianSpread, stats.FavoriteWinRate, stats.AvgFavoritePrice)
+		},
+	)
+}
+
+// listSeriesMarkets pages through a series' markets with the given status
+func listSeriesMarkets(ctx context.Context, client *api.Client, series, status string, limit int) ([]models.Market, error) {
+	var markets []models.Market
+	params := api.ListMarketsParams{SeriesTicker: series, Status: status}
+	progress := ui.NewProgress(status+" markets", limit)
+	defer progress.Done()
+	for len(markets) < limit {
+		params.Limit = min(seriesStatsPageSize, limit-len(markets))
+		page, err := client.ListMarkets(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s markets: %w", status, err)
+		}
+
+		markets = append(markets, page.Markets...)
+		progress.Page(len(page.Markets), page.Cursor)
+		if page.Cursor == "" || len(page.Markets) == 0 {
+			break
+		}
+		params.Cursor = page.Cursor
+	}
+	return markets, nil
+}
+
+// priceBeforeClose returns the last hourly close at least `before` ahead of
+// the market's close, or 0 if it did not trade then
+func priceBeforeClose(ctx context.Context, client *api.Client, series string, m models.Market, before time.Duration) (int, error) {
+	at := m.CloseTime.Add(-before)
+	result, err := client.GetCandlesticks(ctx, api.GetCandlesticksParams{
+		SeriesTicker: series,
+		Ticker:       m.Ticker,
+		Period:       "1h",
+		StartTime:    at.Add(-6 * time.Hour).Unix(),
+		EndTime:      at.Unix(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get candlesticks for %s: %w", m.Ticker, err)
+	}
+
+	for i := len(result.Candlesticks) - 1; i >= 0; i-- {
+		if c := result.Candlesticks[i].Close; c > 0 {
+			return c, nil
+		}
+	}
+	return 0, nil
+}
+
+func renderSeriesStats(s seriesStats) {
+	results := make([]string, 0, len(s.Results))
+	for result := range s.Results {
+		results = append(results, result)
+	}
+	sort.Strings(results)
+
+	pairs := [][]string{
+		{"Series", s.SeriesTicker},
+		{"Settled Markets", fmt.Sprintf("%d across %d events", s.SettledMarkets, s.Events)},
+		{"Avg Volume / Market", fmt.Sprintf("%.0f", s.AvgVolume)},
+		{"Avg Volume / Event", fmt.Sprintf("%.0f", s.AvgEventVolume)},
+	}
+
+	if s.AvgSpread > 0 {
+		pairs = append(pairs, []string{"Typical Spread", fmt.Sprintf("%d¢ median, %.1f¢ avg (%d open markets)", s.MedianSpread, s.AvgSpread, s.OpenMarkets)})
+	} else {
+		pairs = append(pairs, []string{"Typical Spread", "n/a (no open two-sided markets)"})
+	}
+
+	for _, result := range results {
+		n := s.Results[result]
+		pairs = append(pairs, []string{"Settled " + result, fmt.Sprintf("%d (%.0f%%)", n, float64(n)/float64(s.SettledMarkets)*100)})
+	}
+
+	if s.FavoriteMarkets > 0 {
+		pairs = append(pairs,
+			[]string{"Favorite Won", fmt.Sprintf("%d of %d (%.0f%%)", s.FavoriteWins, s.FavoriteMarkets, s.FavoriteWinRate*100)},
+			[]string{"Avg Favorite Price", fmt.Sprintf("%.1f¢", s.AvgFavoritePrice)},
+		)
+	}
+
+	ui.RenderKeyValue(pairs)
+}
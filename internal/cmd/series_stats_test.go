@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestSummarizeSeries(t *testing.T) {
+	settled := []models.Market{
+		{Ticker: "E1-A", EventTicker: "E1", Volume: 100, Result: "yes"},
+		{Ticker: "E1-B", EventTicker: "E1", Volume: 300, Result: "no"},
+		{Ticker: "E2-A", EventTicker: "E2", Volume: 200, Result: "no"},
+		{Ticker: "E2-B", EventTicker: "E2", Volume: 0, Result: "yes"},
+	}
+	open := []models.Market{
+		{Ticker: "E3-A", YesBid: 40, YesAsk: 42},
+		{Ticker: "E3-B", YesBid: 10, YesAsk: 16},
+		{Ticker: "E3-C", YesBid: 50, YesAsk: 53},
+		{Ticker: "E3-D", YesBid: 0, YesAsk: 5},
+	}
+	yesPrices := map[string]int{
+		"E1-A": 80, // yes favorite, won
+		"E1-B": 30, // no favorite, won
+		"E2-A": 60, // yes favorite, lost
+		"E2-B": 50, // no favorite
+	}
+
+	s := summarizeSeries("E", settled, open, yesPrices)
+
+	if s.SettledMarkets != 4 || s.Events != 2 || s.AvgVolume != 150 || s.AvgEventVolume != 300 {
+		t.Errorf("volume stats = %+v", s)
+	}
+	if s.MedianSpread != 3 || s.AvgSpread != 11.0/3 {
+		t.Errorf("spread median/avg = %d/%v, want 3/%v", s.MedianSpread, s.AvgSpread, 11.0/3)
+	}
+	if s.Results["yes"] != 2 || s.Results["no"] != 2 {
+		t.Errorf("results = %v", s.Results)
+	}
+	if s.FavoriteMarkets != 3 || s.FavoriteWins != 2 || s.AvgFavoritePrice != 70 {
+		t.Errorf("favorites = %d markets, %d wins, %v avg price; want 3, 2, 70", s.FavoriteMarkets, s.FavoriteWins, s.AvgFavoritePrice)
+	}
+}
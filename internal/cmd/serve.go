@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/grafana"
+	"github.com/6missedcalls/kalshi-cli/internal/seriescache"
+	"github.com/6missedcalls/kalshi-cli/internal/snapshot"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve portfolio and market data to other tools",
+}
+
+var serveGrafanaCmd = &cobra.Command{
+	Use:   "grafana",
+	Short: "Serve a Grafana JSON datasource",
+	Long: `Serve price history, positions and P&L over HTTP for Grafana dashboards,
+using the SimpleJSON datasource contract. Point the JSON (SimpleJSON) or
+Infinity datasource plugin at the server URL.
+
+Metrics:
+  price:<TICKER>     close price in cents, from live candlesticks
+  positions          open positions as a table, live
+  position:<TICKER>  contracts held, from saved position snapshots
+  exposure           total market exposure in cents, from saved snapshots
+  pnl                cumulative realized P&L in cents, by day
+  pnl_daily          realized P&L in cents, by day
+
+Position history comes from the snapshots saved by portfolio positions
+--diff-from, so run that on a schedule to build it up. Everything else is
+fetched from the API on each query.
+
+The server has no authentication and listens on localhost by default. Only
+bind it to another address on a network you trust.`,
+	Example: `  kalshi-cli serve grafana
+  kalshi-cli serve grafana --listen 0.0.0.0:8089 --prod
+  curl 'http://localhost:8089/query?target=pnl&from=2026-01-01T00:00:00Z'`,
+	Args: cobra.NoArgs,
+	RunE: runServeGrafana,
+}
+
+var serveListen string
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.AddCommand(serveGrafanaCmd)
+
+	serveGrafanaCmd.Flags().StringVar(&serveListen, "listen", "127.0.0.1:8089", "address to listen on")
+}
+
+func runServeGrafana(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	store, err := snapshotStore()
+	if err != nil {
+		return err
+	}
+	cache := loadSeriesCache()
+	defer saveSeriesCache(cache)
+
+	cfg := GetConfig()
+	backend := &grafanaBackend{
+		client:      client,
+		snapshots:   store,
+		series:      cache,
+		environment: cfg.Environment(),
+		subaccount:  ActiveSubaccount(),
+	}
+
+	ln, err := net.Listen("tcp", serveListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", serveListen, err)
+	}
+	server := &http.Server{
+		Handler:           grafana.NewHandler(backend, nil),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := alertContext()
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving Grafana datasource for %s at http://%s (Ctrl+C to stop)\n", cfg.Environment(), ln.Addr())
+	if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server failed: %w", err)
+	}
+	return nil
+}
+
+// grafanaMetrics are the metrics that take no ticker
+var grafanaMetrics = []string{"exposure", "pnl", "pnl_daily", "positions"}
+
+// grafanaBackend answers datasource queries from the API and the position
+// snapshot store
+type grafanaBackend struct {
+	client      *api.Client
+	snapshots   *snapshot.Store
+	series      *seriescache.Cache
+	environment string
+	subaccount  int
+}
+
+// Search lists the fixed metrics and the per-ticker metrics of every open
+// position
+func (b *grafanaBackend) Search(ctx context.Context, query string) ([]string, error) {
+	metrics := append([]string{}, grafanaMetrics...)
+	positions, err := openPositions(ctx, b.client)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range positions {
+		metrics = append(metrics, "price:"+p.Ticker, "position:"+p.Ticker)
+	}
+
+	var matched []string
+	for _, m := range metrics {
+		if strings.Contains(strings.ToLower(m), strings.ToLower(query)) {
+			matched = append(matched, m)
+		}
+	}
+	sort.Strings(matched)
+	return matched, nil
+}
+
+func (b *grafanaBackend) Query(ctx context.Context, target string, r grafana.Range) (interface{}, error) {
+	switch {
+	case target == "positions":
+		return b.positionsTable(ctx)
+	case target == "exposure":
+		return b.snapshotSeries(target, r, func(p models.MarketPosition) int { return p.MarketExposure })
+	case target == "pnl", target == "pnl_daily":
+		return b.pnlSeries(ctx, target, r)
+	case strings.HasPrefix(target, "price:"):
+		return b.priceSeries(ctx, target, strings.TrimPrefix(target, "price:"), r)
+	case strings.HasPrefix(target, "position:"):
+		ticker := strings.TrimPrefix(target, "position:")
+		return b.snapshotSeries(target, r, func(p models.MarketPosition) int {
+			if p.Ticker != ticker {
+				return 0
+			}
+			return p.Position
+		})
+	}
+	return nil, fmt.Errorf("unknown metric %q: use price:<TICKER>, position:<TICKER>, %s", target, strings.Join(grafanaMetrics, ", "))
+}
+
+func (b *grafanaBackend) positionsTable(ctx context.Context) (*grafana.Table, error) {
+	positions, err := openPositions(ctx, b.client)
+	if err != nil {
+		return nil, err
+	}
+	table := grafana.NewTable(
+		grafana.Column{Text: "ticker", Type: "string"},
+		grafana.Column{Text: "position", Type: "number"},
+		grafana.Column{Text: "exposure", Type: "number"},
+		grafana.Column{Text: "realized_pnl", Type: "number"},
+		grafana.Column{Text: "fees_paid", Type: "number"},
+	)
+	for _, p := range positions {
+		table.Rows = append(table.Rows, []interface{}{p.Ticker, p.Position, p.MarketExposure, p.RealizedPnl, p.FeesPaid})
+	}
+	return table, nil
+}
+
+// snapshotSeries sums value over the positions of each snapshot in range
+func (b *grafanaBackend) snapshotSeries(target string, r grafana.Range, value func(models.MarketPosition) int) (*grafana.Series, error) {
+	snaps, err := b.snapshots.History(b.environment, b.subaccount, r.From, r.To)
+	if err != nil {
+		return nil, err
+	}
+	series := &grafana.Series{Target: target, Datapoints: [][2]float64{}}
+	for _, snap := range snaps {
+		total := 0
+		for _, p := range snap.Positions {
+			total += value(p)
+		}
+		series.Add(snap.Time, float64(total))
+	}
+	return series, nil
+}
+
+func (b *grafanaBackend) pnlSeries(ctx context.Context, target string, r grafana.Range) (*grafana.Series, error) {
+	summary, err := realizedPnL(ctx, b.client, r.From, r.To)
+	if err != nil {
+		return nil, err
+	}
+	series := &grafana.Series{Target: target, Datapoints: [][2]float64{}}
+	for _, day := range summary.Daily {
+		t, err := time.ParseInLocation("2006-01-02", day.Date, time.Local)
+		if err != nil {
+			continue
+		}
+		if target == "pnl" {
+			series.Add(t, float64(day.Cumulative))
+		} else {
+			series.Add(t, float64(day.PnL))
+		}
+	}
+	return series, nil
+}
+
+func (b *grafanaBackend) priceSeries(ctx context.Context, target, ticker string, r grafana.Range) (*grafana.Series, error) {
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	market, err := b.client.GetMarket(reqCtx, ticker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", ticker, err)
+	}
+	seriesTicker, err := resolveSeriesTicker(reqCtx, b.client, b.series, market.EventTicker, "")
+	if err != nil {
+		return nil, err
+	}
+	result, err := b.client.GetCandlesticks(reqCtx, api.GetCandlesticksParams{
+		SeriesTicker: seriesTicker,
+		Ticker:       ticker,
+		Period:       candlePeriodFor(r.To.Sub(r.From)),
+		StartTime:    r.From.Unix(),
+		EndTime:      r.To.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candlesticks for %s: %w", ticker, err)
+	}
+
+	series := &grafana.Series{Target: target, Datapoints: [][2]float64{}}
+	for _, c := range result.Candlesticks {
+		series.Add(c.PeriodEnd, float64(c.Close))
+	}
+	return series, nil
+}
+
+// candlePeriodFor picks the finest candle period that keeps a span within a
+// few thousand candles
+func candlePeriodFor(span time.Duration) string {
+	switch {
+	case span <= 3*24*time.Hour:
+		return "1m"
+	case span <= 120*24*time.Hour:
+		return "1h"
+	}
+	return "1d"
+}
+
+// openPositions returns every position of the active subaccount
+func openPositions(ctx context.Context, client *api.Client) ([]models.MarketPosition, error) {
+	all, err := allPositions(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	var positions []models.MarketPosition
+	for _, p := range all {
+		if p.Position != 0 {
+			positions = append(positions, p)
+		}
+	}
+	return positions, nil
+}
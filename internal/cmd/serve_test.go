@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/grafana"
+	"github.com/6missedcalls/kalshi-cli/internal/snapshot"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestCandlePeriodFor(t *testing.T) {
+	tests := []struct {
+		span time.Duration
+		want string
+	}{
+		{6 * time.Hour, "1m"},
+		{3 * 24 * time.Hour, "1m"},
+		{30 * 24 * time.Hour, "1h"},
+		{365 * 24 * time.Hour, "1d"},
+	}
+	for _, tt := range tests {
+		if got := candlePeriodFor(tt.span); got != tt.want {
+			t.Errorf("candlePeriodFor(%v) = %q, want %q", tt.span, got, tt.want)
+		}
+	}
+}
+
+func TestGrafanaSnapshotMetrics(t *testing.T) {
+	store := snapshot.NewStore(t.TempDir())
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, positions := range [][]models.MarketPosition{
+		{{Ticker: "KXA", Position: 5, MarketExposure: 200}, {Ticker: "KXB", Position: -2, MarketExposure: 80}},
+		{{Ticker: "KXB", Position: -4, MarketExposure: 160}},
+	} {
+		if _, err := store.Save(snapshot.Snapshot{Time: start.Add(time.Duration(i) * time.Hour), Environment: "demo", Positions: positions}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b := &grafanaBackend{snapshots: store, environment: "demo"}
+	r := grafana.Range{From: start, To: start.Add(2 * time.Hour)}
+	ms := func(h int) float64 { return float64(start.Add(time.Duration(h) * time.Hour).UnixMilli()) }
+
+	got, err := b.Query(context.Background(), "position:KXA", r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][2]float64{{5, ms(0)}, {0, ms(1)}}; !reflect.DeepEqual(got.(*grafana.Series).Datapoints, want) {
+		t.Errorf("position:KXA = %v, want %v", got.(*grafana.Series).Datapoints, want)
+	}
+
+	got, err = b.Query(context.Background(), "exposure", r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][2]float64{{280, ms(0)}, {160, ms(1)}}; !reflect.DeepEqual(got.(*grafana.Series).Datapoints, want) {
+		t.Errorf("exposure = %v, want %v", got.(*grafana.Series).Datapoints, want)
+	}
+
+	if _, err := b.Query(context.Background(), "volume", r); err == nil {
+		t.Error("unknown metric: expected error")
+	}
+}
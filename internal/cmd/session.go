@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/notify"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+// summaryWebhook is the URL the end-of-session summary is POSTed to, if set
+var summaryWebhook string
+
+// sessionTracker counts activity of a long-running command for its exit summary
+type sessionTracker struct {
+	command string
+	start   time.Time
+
+	messages        atomic.Int64
+	reconnects      atomic.Int64
+	ordersPlaced    atomic.Int64
+	ordersCancelled atomic.Int64
+	fills           atomic.Int64
+
+	seenOrders   sync.Map
+	startBalance *int
+}
+
+func newSessionTracker() *sessionTracker {
+	return &sessionTracker{
+		command: currentCommand,
+		start:   time.Now(),
+	}
+}
+
+// sessionSummary is printed (and optionally POSTed) when a daemon exits
+type sessionSummary struct {
+	Command         string    `json:"command"`
+	Environment     string    `json:"environment"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	Runtime         string    `json:"runtime"`
+	Messages        int64     `json:"messages"`
+	Reconnects      int64     `json:"reconnects"`
+	OrdersPlaced    int64     `json:"orders_placed"`
+	OrdersCancelled int64     `json:"orders_cancelled"`
+	Fills           int64     `json:"fills"`
+	APICalls        int64     `json:"api_calls"`
+	PnLDelta        *int      `json:"pnl_delta,omitempty"`
+}
+
+// recordMessage counts an incoming message and derives order/fill activity
+// from user channels. A new order ID seen on user_orders counts as placed.
+func (t *sessionTracker) recordMessage(channel websocket.Channel, msg websocket.Message) {
+	t.messages.Add(1)
+
+	switch channel {
+	case websocket.ChannelUserFills:
+		t.fills.Add(1)
+	case websocket.ChannelUserOrders:
+		var data websocket.OrderUpdateData
+		if err := json.Unmarshal(msg.Data, &data); err != nil || data.OrderID == "" {
+			return
+		}
+		if _, seen := t.seenOrders.LoadOrStore(data.OrderID, true); !seen && data.Status != "canceled" {
+			t.ordersPlaced.Add(1)
+		}
+		if data.Status == "canceled" {
+			t.ordersCancelled.Add(1)
+		}
+	}
+}
+
+// trackingHandler counts messages before passing them to the wrapped handler
+type trackingHandler struct {
+	next    websocket.Handler
+	channel websocket.Channel
+	tracker *sessionTracker
+}
+
+func (h *trackingHandler) HandleMessage(msg websocket.Message) error {
+	h.tracker.recordMessage(h.channel, msg)
+	return h.next.HandleMessage(msg)
+}
+
+// totalValue returns balance plus portfolio value, or nil if unavailable
+func totalValue(ctx context.Context, client *api.Client) *int {
+	if client == nil {
+		return nil
+	}
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	balance, err := client.GetBalance(reqCtx)
+	if err != nil {
+		return nil
+	}
+	total := balance.Balance + balance.PortfolioValue
+	return &total
+}
+
+// captureStartBalance snapshots account value so the summary can report P&L
+func (t *sessionTracker) captureStartBalance(client *api.Client) {
+	t.startBalance = totalValue(context.Background(), client)
+}
+
+// finish builds the summary, prints it, and POSTs it to --summary-webhook
+func (t *sessionTracker) finish(client *api.Client) sessionSummary {
+	end := time.Now()
+	summary := sessionSummary{
+		Command:         t.command,
+		Environment:     cfg.Environment(),
+		Start:           t.start.UTC(),
+		End:             end.UTC(),
+		Runtime:         end.Sub(t.start).Round(time.Second).String(),
+		Messages:        t.messages.Load(),
+		Reconnects:      t.reconnects.Load(),
+		OrdersPlaced:    t.ordersPlaced.Load(),
+		OrdersCancelled: t.ordersCancelled.Load(),
+		Fills:           t.fills.Load(),
+	}
+
+	if t.startBalance != nil {
+		if endBalance := totalValue(context.Background(), client); endBalance != nil {
+			delta := *endBalance - *t.startBalance
+			summary.PnLDelta = &delta
+		}
+	}
+	summary.APICalls = sessionMetrics.Snapshot().Requests
+
+	renderSessionSummary(summary)
+
+	if summaryWebhook != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		webhook := &notify.Webhook{URL: summaryWebhook}
+		msg := notify.Message{
+			Title: fmt.Sprintf("kalshi-cli %s session ended", summary.Command),
+			Body:  fmt.Sprintf("Ran %s, %d messages, %d fills", summary.Runtime, summary.Messages, summary.Fills),
+			Time:  summary.End,
+			Data:  map[string]any{"summary": summary},
+		}
+		if err := webhook.Notify(ctx, msg); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to send session summary: %v\n", err)
+		}
+	}
+
+	return summary
+}
+
+func renderSessionSummary(s sessionSummary) {
+	switch GetOutputFormat() {
+	case ui.FormatJSON:
+		printJSONLine(map[string]any{"type": "session_summary", "summary": s})
+	case ui.FormatPlain:
+		pnl := ""
+		if s.PnLDelta != nil {
+			pnl = strconv.Itoa(*s.PnLDelta)
+		}
+		fmt.Fprintf(ui.Writer(), "session command=%q runtime=%s messages=%d reconnects=%d orders_placed=%d orders_cancelled=%d fills=%d api_calls=%d pnl_delta=%s\n",
+			s.Command, s.Runtime, s.Messages, s.Reconnects, s.OrdersPlaced, s.OrdersCancelled, s.Fills, s.APICalls, pnl)
+	default:
+		pnl := "n/a"
+		if s.PnLDelta != nil {
+			pnl = ui.FormatPriceStyled(*s.PnLDelta, *s.PnLDelta >= 0)
+		}
+		fmt.Fprintln(ui.Writer())
+		fmt.Fprintln(ui.Writer(), ui.HeaderStyle.Render("Session Summary"))
+		ui.RenderKeyValue([][]string{
+			{"Command", s.Command},
+			{"Environment", s.Environment},
+			{"Runtime", s.Runtime},
+			{"Messages", strconv.FormatInt(s.Messages, 10)},
+			{"Reconnects", strconv.FormatInt(s.Reconnects, 10)},
+			{"Orders Placed", strconv.FormatInt(s.OrdersPlaced, 10)},
+			{"Orders Cancelled", strconv.FormatInt(s.OrdersCancelled, 10)},
+			{"Fills", strconv.FormatInt(s.Fills, 10)},
+			{"API Calls", strconv.FormatInt(s.APICalls, 10)},
+			{"P&L Delta", pnl},
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestSessionTrackerRecordMessage(t *testing.T) {
+	tracker := &sessionTracker{}
+
+	order := func(id, status string) websocket.Message {
+		data, _ := json.Marshal(websocket.OrderUpdateData{OrderID: id, Status: status})
+		return websocket.Message{Type: "user_order", Data: data}
+	}
+
+	tracker.recordMessage(websocket.ChannelUserOrders, order("o1", "resting"))
+	tracker.recordMessage(websocket.ChannelUserOrders, order("o1", "executed"))
+	tracker.recordMessage(websocket.ChannelUserOrders, order("o2", "resting"))
+	tracker.recordMessage(websocket.ChannelUserOrders, order("o2", "canceled"))
+	tracker.recordMessage(websocket.ChannelUserFills, websocket.Message{Type: "fill"})
+	tracker.recordMessage(websocket.ChannelMarketTicker, websocket.Message{Type: "ticker"})
+
+	if got := tracker.messages.Load(); got != 6 {
+		t.Errorf("messages = %d, expected 6", got)
+	}
+	if got := tracker.ordersPlaced.Load(); got != 2 {
+		t.Errorf("ordersPlaced = %d, expected 2", got)
+	}
+	if got := tracker.ordersCancelled.Load(); got != 1 {
+		t.Errorf("ordersCancelled = %d, expected 1", got)
+	}
+	if got := tracker.fills.Load(); got != 1 {
+		t.Errorf("fills = %d, expected 1", got)
+	}
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/hsm"
+	"github.com/6missedcalls/kalshi-cli/internal/secrets"
+)
+
+// pkcs11ConfigFromViper reads the PKCS#11 key location from config.
+// The PIN is only taken from KALSHI_PKCS11_PIN so it is never written to disk.
+func pkcs11ConfigFromViper() hsm.PKCS11Config {
+	return hsm.PKCS11Config{
+		Module:     viper.GetString("pkcs11.module"),
+		TokenLabel: viper.GetString("pkcs11.token_label"),
+		KeyLabel:   viper.GetString("pkcs11.key_label"),
+		PIN:        os.Getenv("KALSHI_PKCS11_PIN"),
+	}
+}
+
+// configuredSigner returns a signer from an explicitly configured backend:
+// a PKCS#11 token or an external credentials provider. ok is false when
+// neither is configured and the default credential chain should be used.
+func configuredSigner() (signer *api.Signer, ok bool, err error) {
+	if signer, ok, err := hardwareSigner(); ok {
+		return signer, ok, err
+	}
+	return providerSigner()
+}
+
+// providerSigner fetches credentials from the configured credentials_provider.
+// ok is false when no provider is configured.
+func providerSigner() (signer *api.Signer, ok bool, err error) {
+	kind := viper.GetString("credentials_provider")
+	if kind == "" {
+		return nil, false, nil
+	}
+
+	provider, err := secrets.New(kind, viper.GetString("credentials_path"), viper.GetString("credentials_region"))
+	if err != nil {
+		return nil, true, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	creds, err := provider.Fetch(ctx)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to fetch credentials from %s: %w", provider.Name(), err)
+	}
+
+	signer, err = api.NewSignerFromPEM(creds.APIKeyID, creds.PrivateKey)
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid private key from %s: %w", provider.Name(), err)
+	}
+	return signer, true, nil
+}
+
+// hardwareSigner returns a signer backed by a configured hardware token.
+// ok is false when no hardware signer is configured.
+func hardwareSigner() (signer *api.Signer, ok bool, err error) {
+	p11 := pkcs11ConfigFromViper()
+	if p11.Module == "" {
+		return nil, false, nil
+	}
+
+	apiKeyID := viper.GetString("api_key_id")
+	if apiKeyID == "" {
+		apiKeyID = os.Getenv("KALSHI_API_KEY_ID")
+	}
+	if apiKeyID == "" {
+		return nil, true, fmt.Errorf("pkcs11.module is set but api_key_id is missing")
+	}
+
+	key, err := hsm.OpenPKCS11(p11)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to open PKCS#11 key: %w", err)
+	}
+
+	signer, err = api.NewSignerFromCrypto(apiKeyID, key)
+	if err != nil {
+		key.Close()
+		return nil, true, fmt.Errorf("failed to create signer from PKCS#11 key: %w", err)
+	}
+	return signer, true, nil
+}
+
+// credentialSource describes where the active credentials were found
+type credentialSource struct {
+	Backend  string `json:"backend"`
+	APIKeyID string `json:"api_key_id"`
+	Detail   string `json:"detail"`
+}
+
+// resolveSigner walks the credential chain and reports which source was used:
+// a PKCS#11 token or credentials provider if configured, then api_key_id +
+// private_key_path from config or KALSHI_API_KEY_ID + KALSHI_PRIVATE_KEY_FILE,
+// then KALSHI_PRIVATE_KEY, then the keyring as a last resort. Config/env is
+// checked before the keyring because the keyring can hang in headless
+// environments.
+func resolveSigner() (*api.Signer, credentialSource, error) {
+	if p11 := pkcs11ConfigFromViper(); p11.Module != "" {
+		src := credentialSource{Backend: "pkcs11", Detail: "PKCS#11 module " + p11.Module}
+		signer, _, err := hardwareSigner()
+		if err != nil {
+			return nil, src, err
+		}
+		src.APIKeyID = signer.APIKeyID()
+		return signer, src, nil
+	}
+	if kind := viper.GetString("credentials_provider"); kind != "" {
+		src := credentialSource{Backend: "provider", Detail: "credentials_provider " + kind}
+		signer, _, err := providerSigner()
+		if err != nil {
+			return nil, src, err
+		}
+		src.APIKeyID = signer.APIKeyID()
+		return signer, src, nil
+	}
+
+	apiKeyID, idFrom := viper.GetString("api_key_id"), "config api_key_id"
+	if apiKeyID == "" {
+		apiKeyID, idFrom = os.Getenv("KALSHI_API_KEY_ID"), "KALSHI_API_KEY_ID"
+	}
+	privateKeyPath, pathFrom := viper.GetString("private_key_path"), "config private_key_path"
+	if privateKeyPath == "" {
+		privateKeyPath, pathFrom = os.Getenv("KALSHI_PRIVATE_KEY_FILE"), "KALSHI_PRIVATE_KEY_FILE"
+	}
+
+	if apiKeyID != "" && privateKeyPath != "" {
+		src := credentialSource{
+			Backend:  "config",
+			APIKeyID: apiKeyID,
+			Detail:   fmt.Sprintf("%s, %s (%s)", idFrom, pathFrom, privateKeyPath),
+		}
+		if idFrom == "KALSHI_API_KEY_ID" && pathFrom == "KALSHI_PRIVATE_KEY_FILE" {
+			src.Backend = "env"
+		} else if idFrom == "KALSHI_API_KEY_ID" || pathFrom == "KALSHI_PRIVATE_KEY_FILE" {
+			src.Backend = "config+env"
+		}
+
+		pemData, err := os.ReadFile(privateKeyPath)
+		if err != nil {
+			return nil, src, fmt.Errorf("failed to read private key file %s: %w", privateKeyPath, err)
+		}
+		signer, err := api.NewSignerFromPEM(apiKeyID, string(pemData))
+		if err != nil {
+			return nil, src, fmt.Errorf("failed to create signer from key file: %w", err)
+		}
+		return signer, src, nil
+	}
+
+	if privateKeyPEM := os.Getenv("KALSHI_PRIVATE_KEY"); apiKeyID != "" && privateKeyPEM != "" {
+		src := credentialSource{Backend: "env", APIKeyID: apiKeyID, Detail: idFrom + ", KALSHI_PRIVATE_KEY"}
+		if idFrom != "KALSHI_API_KEY_ID" {
+			src.Backend = "config+env"
+		}
+		signer, err := api.NewSignerFromPEM(apiKeyID, privateKeyPEM)
+		if err != nil {
+			return nil, src, fmt.Errorf("failed to create signer from env var: %w", err)
+		}
+		return signer, src, nil
+	}
+
+	// Last resort: keyring (may hang in headless environments)
+	src := credentialSource{Backend: "keyring", Detail: "system keyring"}
+	if keyring, err := config.NewKeyringStore(); err == nil {
+		creds, err := keyring.GetCredentials()
+		if err == nil && creds != nil {
+			src.APIKeyID = creds.APIKeyID
+			signer, err := api.NewSignerFromPEM(creds.APIKeyID, creds.PrivateKey)
+			if err == nil {
+				return signer, src, nil
+			}
+		}
+	}
+
+	if config.KeyringDisabled() {
+		return nil, credentialSource{}, fmt.Errorf("not logged in and the keyring is disabled. Set KALSHI_API_KEY_ID with KALSHI_PRIVATE_KEY or KALSHI_PRIVATE_KEY_FILE (see 'kalshi-cli config env')")
+	}
+	return nil, credentialSource{}, fmt.Errorf("not logged in. Set api_key_id + private_key_path in ~/.kalshi/config.yaml, or run 'kalshi-cli auth login'")
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/usage"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show local usage statistics",
+	Long: `Summarize locally tracked CLI usage: commands run, API calls made,
+rate-limit hits, and error counts.
+
+Usage is recorded in ~/.kalshi/usage.jsonl and never leaves this machine.
+Disable tracking with 'kalshi-cli config set usage.enabled false'.`,
+	Example: `  kalshi-cli stats
+  kalshi-cli stats --since 7d
+  kalshi-cli stats --command orders --json`,
+	RunE: runStats,
+}
+
+var statsClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete recorded usage statistics",
+	RunE:  runStatsClear,
+}
+
+var (
+	statsSince   string
+	statsCommand string
+)
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.AddCommand(statsClearCmd)
+
+	statsCmd.Flags().StringVar(&statsSince, "since", "", "only include runs at or after this time (e.g. 24h, 7d, yesterday, last monday)")
+	statsCmd.Flags().StringVar(&statsCommand, "command", "", "only include commands starting with this prefix (e.g. 'orders')")
+}
+
+func usageLog() (*usage.Log, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return usage.NewLog(usage.DefaultPath(dir)), nil
+}
+
+// recordUsage appends the finished invocation to the local usage log.
+// Failures are never fatal; usage tracking must not break the command itself.
+func recordUsage(executed *cobra.Command, start time.Time, runErr error) {
+	if cfg == nil || !cfg.Usage.Enabled || executed == nil || executed == rootCmd {
+		return
+	}
+
+	log, err := usageLog()
+	if err != nil {
+		return
+	}
+
+	snapshot := sessionMetrics.Snapshot()
+	record := usage.Record{
+		Time:        start.UTC(),
+		Command:     strings.TrimPrefix(executed.CommandPath(), rootCmd.Name()+" "),
+		Environment: cfg.Environment(),
+		Duration:    time.Since(start),
+		APICalls:    snapshot.Requests,
+		RateLimited: snapshot.RateLimited,
+		APIErrors:   snapshot.Errors,
+		Failed:      runErr != nil,
+	}
+	if sessionLimiter != nil && snapshot.Requests > 0 {
+		pace := sessionLimiter.Pace()
+		record.Pace, record.PaceLimit, record.LowestPace, record.PaceBackoffs = pace.Rate, pace.Limit, pace.Lowest, pace.Backoffs
+	}
+
+	if err := log.Append(record); err != nil && IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	var since time.Time
+	if statsSince != "" {
+		t, err := parseSinceArg(statsSince)
+		if err != nil {
+			return err
+		}
+		since = t
+	}
+
+	log, err := usageLog()
+	if err != nil {
+		return err
+	}
+
+	records, err := log.Load(since)
+	if err != nil {
+		return err
+	}
+
+	if statsCommand != "" {
+		filtered := records[:0]
+		for _, r := range records {
+			if strings.HasPrefix(r.Command, statsCommand) {
+				filtered = append(filtered, r)
+			}
+		}
+		records = filtered
+	}
+
+	summary := usage.Summarize(records)
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() { renderStatsTable(summary) },
+		summary,
+		func() { renderStatsPlain(summary) },
+	)
+}
+
+func renderStatsTable(s usage.Summary) {
+	if s.Runs == 0 {
+		PrintWarning("No usage recorded")
+		return
+	}
+
+	ui.RenderKeyValue([][]string{
+		{"Period", fmt.Sprintf("%s - %s", formatTimeStr(s.From.Local()), formatTimeStr(s.To.Local()))},
+		{"Commands Run", strconv.Itoa(s.Runs)},
+		{"Failed", strconv.Itoa(s.Failures)},
+		{"API Calls", strconv.FormatInt(s.APICalls, 10)},
+		{"Rate-Limit Hits", strconv.FormatInt(s.RateLimited, 10)},
+		{"API Errors", strconv.FormatInt(s.APIErrors, 10)},
+		{"Pace Backoffs", strconv.FormatInt(s.Backoffs, 10)},
+		{"Lowest Pace", formatPace(s.LowestPace)},
+		{"Last Run Pace", formatPaceOf(s.LastPace, s.LastPaceLimit)},
+	})
+	fmt.Fprintln(ui.Writer())
+
+	headers := []string{"Command", "Runs", "Failed", "API Calls", "429s", "API Errors", "Avg Time", "Last Run"}
+	rows := make([][]string, 0, len(s.Commands))
+	for _, c := range s.Commands {
+		rows = append(rows, []string{
+			c.Command,
+			strconv.Itoa(c.Runs),
+			strconv.Itoa(c.Failures),
+			strconv.FormatInt(c.APICalls, 10),
+			strconv.FormatInt(c.RateLimited, 10),
+			strconv.FormatInt(c.APIErrors, 10),
+			c.AvgDuration.Round(time.Millisecond).String(),
+			formatTimeStr(c.LastRun.Local()),
+		})
+	}
+	ui.RenderTable(headers, rows)
+}
+
+// formatPace renders a request rate, or - when there is none
+func formatPace(rate float64) string {
+	if rate <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f req/s", rate)
+}
+
+// formatPaceOf renders a request rate against its configured limit
+func formatPaceOf(rate, limit float64) string {
+	if rate <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f of %.1f req/s", rate, limit)
+}
+
+func renderStatsPlain(s usage.Summary) {
+	for _, c := range s.Commands {
+		fmt.Fprintf(ui.Writer(), "%s\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			c.Command,
+			c.Runs,
+			c.Failures,
+			c.APICalls,
+			c.RateLimited,
+			c.APIErrors,
+			c.AvgDuration.Round(time.Millisecond),
+		)
+	}
+}
+
+func runStatsClear(cmd *cobra.Command, args []string) error {
+	confirmed, err := confirmAction("Delete all recorded usage statistics?")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		PrintWarning("Clear cancelled")
+		return nil
+	}
+
+	log, err := usageLog()
+	if err != nil {
+		return err
+	}
+
+	if err := log.Clear(); err != nil {
+		return err
+	}
+
+	PrintSuccess("Usage statistics cleared")
+	return nil
+}
+
+// parseLookback parses a duration that additionally accepts a day suffix (e.g. "7d")
+func parseLookback(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || days <= 0 {
+			return 0, fmt.Errorf("expected a positive number of days, got %q", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive, got %q", s)
+	}
+	return d, nil
+}
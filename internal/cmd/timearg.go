@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeArgHelp describes the values parseTimeArg accepts, for flag help text
+const timeArgHelp = "RFC3339, a date, now, today, yesterday, a weekday, or a relative time like -7d or 2h ago"
+
+// timeArgLayouts are the absolute formats parseTimeArg accepts. Layouts without
+// a zone are read in local time.
+var timeArgLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTimeArg reads a human-friendly time relative to now:
+//
+//	2026-03-01T12:00:00Z, 2026-03-01 12:00, 2026-03-01  absolute
+//	1767225600                                          unix seconds
+//	now, today, yesterday                               today and yesterday start at midnight
+//	monday, last monday                                 midnight on the most recent Monday;
+//	                                                    "last" skips today
+//	-7d, 7d, 7d ago, 1h30m, 2w                          that long before now
+//	+2h                                                 that long after now
+func parseTimeArg(s string, now time.Time) (time.Time, error) {
+	arg := strings.ToLower(strings.TrimSpace(s))
+	if arg == "" {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+
+	switch arg {
+	case "now":
+		return now, nil
+	case "today":
+		return midnight(now), nil
+	case "yesterday":
+		return midnight(now).AddDate(0, 0, -1), nil
+	}
+
+	if day, ok := parseWeekdayArg(arg, now); ok {
+		return day, nil
+	}
+
+	for _, layout := range timeArgLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if unix, err := strconv.ParseInt(arg, 10, 64); err == nil && len(arg) >= 9 {
+		return time.Unix(unix, 0).In(now.Location()), nil
+	}
+
+	if d, ok := parseRelativeArg(arg); ok {
+		return now.Add(d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use %s", s, timeArgHelp)
+}
+
+// parseWeekdayArg reads "monday" or "last monday" as midnight on that day
+func parseWeekdayArg(arg string, now time.Time) (time.Time, bool) {
+	name, last := strings.CutPrefix(arg, "last ")
+	name = strings.TrimSpace(name)
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		full := strings.ToLower(wd.String())
+		if name != full && name != full[:3] {
+			continue
+		}
+		back := (int(now.Weekday()) - int(wd) + 7) % 7
+		if last && back == 0 {
+			back = 7
+		}
+		return midnight(now).AddDate(0, 0, -back), true
+	}
+	return time.Time{}, false
+}
+
+// parseRelativeArg reads a signed offset from now. Unsigned offsets and
+// offsets ending in "ago" point into the past; a leading "+" points forward.
+func parseRelativeArg(arg string) (time.Duration, bool) {
+	arg, ago := strings.CutSuffix(arg, " ago")
+	arg = strings.TrimSpace(arg)
+
+	sign := time.Duration(-1)
+	if !ago {
+		switch {
+		case strings.HasPrefix(arg, "+"):
+			sign = 1
+			arg = arg[1:]
+		case strings.HasPrefix(arg, "-"):
+			arg = arg[1:]
+		}
+	}
+
+	d, err := parseSpan(arg)
+	if err != nil || strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-") {
+		return 0, false
+	}
+	return sign * d, true
+}
+
+// parseSpan parses a positive duration that also accepts day and week units,
+// alone or leading a Go duration (e.g. "7d", "2w", "1d12h")
+func parseSpan(s string) (time.Duration, error) {
+	var total time.Duration
+	for _, unit := range []struct {
+		suffix string
+		length time.Duration
+	}{{"w", 7 * 24 * time.Hour}, {"d", 24 * time.Hour}} {
+		i := strings.Index(s, unit.suffix)
+		if i < 0 {
+			continue
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		total += time.Duration(n) * unit.length
+		s = s[i+1:]
+	}
+	if s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		total += d
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return total, nil
+}
+
+// timeRangeArgs parses optional --start and --end values. Unset values
+// stay zero. The end must fall after the start.
+func timeRangeArgs(start, end string, now time.Time) (time.Time, time.Time, error) {
+	var from, to time.Time
+	if start != "" {
+		t, err := parseTimeArg(start, now)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid --start value: %w", err)
+		}
+		from = t
+	}
+	if end != "" {
+		t, err := parseTimeArg(end, now)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid --end value: %w", err)
+		}
+		to = t
+	}
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		return from, to, fmt.Errorf("--end (%s) must be after --start (%s)", to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+	return from, to, nil
+}
+
+// parseSinceArg parses a --since value into the start of its window
+func parseSinceArg(s string) (time.Time, error) {
+	since, err := parseTimeArg(s, time.Now())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since value: %w", err)
+	}
+	return since, nil
+}
+
+// midnight returns the start of t's day in t's location
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeArg(t *testing.T) {
+	loc := time.FixedZone("EST", -5*3600)
+	// Wednesday
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, loc)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, loc) }
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"now", now},
+		{"NOW", now},
+		{"today", day(4)},
+		{"yesterday", day(3)},
+		{"wednesday", day(4)},
+		{"last wednesday", day(4).AddDate(0, 0, -7)},
+		{"monday", day(2)},
+		{"last mon", day(2)},
+		{"thursday", day(4).AddDate(0, 0, -6)},
+		{"-7d", now.Add(-7 * 24 * time.Hour)},
+		{"7d", now.Add(-7 * 24 * time.Hour)},
+		{"7d ago", now.Add(-7 * 24 * time.Hour)},
+		{"2w", now.Add(-14 * 24 * time.Hour)},
+		{"1d12h", now.Add(-36 * time.Hour)},
+		{"1h30m", now.Add(-90 * time.Minute)},
+		{"+2h", now.Add(2 * time.Hour)},
+		{"2026-03-01", day(1)},
+		{"2026-03-01 09:15", time.Date(2026, 3, 1, 9, 15, 0, 0, loc)},
+		{"2026-03-01T12:00:00Z", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"1767225600", time.Unix(1767225600, 0)},
+	}
+	for _, tt := range tests {
+		got, err := parseTimeArg(tt.in, now)
+		if err != nil {
+			t.Errorf("parseTimeArg(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseTimeArg(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "soon", "-0d", "7x", "2026-13-01", "+7d ago", "20260301", "last"} {
+		if _, err := parseTimeArg(in, now); err == nil {
+			t.Errorf("parseTimeArg(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestTimeRangeArgs(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	from, to, err := timeRangeArgs("-1d", "now", now)
+	if err != nil || !from.Equal(now.Add(-24*time.Hour)) || !to.Equal(now) {
+		t.Errorf("timeRangeArgs(-1d, now) = %v, %v, %v", from, to, err)
+	}
+	if from, to, err := timeRangeArgs("", "", now); err != nil || !from.IsZero() || !to.IsZero() {
+		t.Errorf("expected unset values to stay zero, got %v, %v, %v", from, to, err)
+	}
+	if _, _, err := timeRangeArgs("now", "yesterday", now); err == nil {
+		t.Error("expected an end before the start to be rejected")
+	}
+	if _, _, err := timeRangeArgs("someday", "", now); err == nil {
+		t.Error("expected an invalid start to be rejected")
+	}
+}
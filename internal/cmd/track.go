@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/equity"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+)
+
+var trackCmd = &cobra.Command{
+	Use:   "track",
+	Short: "Track account value over time",
+	Long: `Keep a local history of balance, portfolio value and exposure, which the
+Kalshi API does not provide. Run track snapshot on a schedule (cron, a
+daemon, or schedule) and chart the history with track chart.`,
+}
+
+var trackSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record balance, portfolio value and exposure",
+	Long: `Append the current balance, portfolio value, market exposure and number of
+open positions to the equity history in the data directory. Records are kept
+per environment and subaccount.
+
+For a subaccount the API reports no portfolio value, so market exposure
+(the cost of open positions) is recorded in its place.`,
+	Example: `  kalshi-cli track snapshot
+  # hourly, from crontab
+  0 * * * * kalshi-cli track snapshot --prod --json >/dev/null`,
+	Args: cobra.NoArgs,
+	RunE: runTrackSnapshot,
+}
+
+var trackChartCmd = &cobra.Command{
+	Use:   "chart",
+	Short: "Chart account equity over time",
+	Long: `Chart equity (balance plus portfolio value) from the history recorded by
+track snapshot, with the change over the period. Long histories are
+thinned to the last snapshot in each of 40 equal time slices.`,
+	Example: `  kalshi-cli track chart
+  kalshi-cli track chart --since 7d
+  kalshi-cli track chart --since 2026-01-01 --json`,
+	Args: cobra.NoArgs,
+	RunE: runTrackChart,
+}
+
+var trackSince string
+
+// trackChartPoints is how many snapshots track chart plots at most
+const trackChartPoints = 40
+
+func init() {
+	rootCmd.AddCommand(trackCmd)
+	trackCmd.AddCommand(trackSnapshotCmd)
+	trackCmd.AddCommand(trackChartCmd)
+
+	trackChartCmd.Flags().StringVar(&trackSince, "since", "90d", "chart history from this time: "+timeArgHelp)
+}
+
+// equityPath returns the equity history location
+func equityPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return equity.DefaultPath(dir), nil
+}
+
+// currentEquity reads the account's balance, portfolio value and exposure
+func currentEquity(ctx context.Context, client *api.Client) (equity.Record, error) {
+	r := equity.Record{
+		Time:        time.Now().UTC(),
+		Environment: GetConfig().Environment(),
+		Subaccount:  ActiveSubaccount(),
+	}
+
+	positions, err := openPositions(ctx, client)
+	if err != nil {
+		return r, err
+	}
+	for _, p := range positions {
+		r.Exposure += p.MarketExposure
+	}
+	r.Positions = len(positions)
+
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	if r.Subaccount > 0 {
+		balance, err := getSubaccountBalance(reqCtx, client, r.Subaccount)
+		if err != nil {
+			return r, err
+		}
+		r.Balance = balance.Balance
+		r.PortfolioValue = r.Exposure
+		return r, nil
+	}
+	balance, err := client.GetBalance(reqCtx)
+	if err != nil {
+		return r, fmt.Errorf("failed to get balance: %w", err)
+	}
+	r.Balance = balance.Balance
+	r.PortfolioValue = balance.PortfolioValue
+	return r, nil
+}
+
+func runTrackSnapshot(cmd *cobra.Command, args []string) error {
+	path, err := equityPath()
+	if err != nil {
+		return err
+	}
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	record, err := currentEquity(context.Background(), client)
+	if err != nil {
+		return err
+	}
+	if err := equity.Append(path, record); err != nil {
+		return err
+	}
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			ui.RenderKeyValue([][]string{
+				{ui.BoldStyle.Render("Recorded:"), record.Time.Local().Format("2006-01-02 15:04:05")},
+				{ui.BoldStyle.Render("Balance:"), ui.FormatPrice(record.Balance)},
+				{ui.BoldStyle.Render("Portfolio Value:"), ui.FormatPrice(record.PortfolioValue)},
+				{ui.BoldStyle.Render("Equity:"), ui.FormatPrice(record.Equity())},
+				{ui.BoldStyle.Render("Exposure:"), ui.FormatPrice(record.Exposure)},
+				{ui.BoldStyle.Render("Positions:"), strconv.Itoa(record.Positions)},
+			})
+		},
+		record,
+		func() {
+			ui.PrintPlain("%s\t%d\t%d\t%d\t%d\t%d", record.Time.Format(time.RFC3339),
+				record.Balance, record.PortfolioValue, record.Equity(), record.Exposure, record.Positions)
+		},
+	)
+}
+
+// equityHistory is the track chart --json output
+type equityHistory struct {
+	Since   time.Time      `json:"since"`
+	Start   int            `json:"start_equity"`
+	End     int            `json:"end_equity"`
+	Change  int            `json:"change"`
+	Records []equityRecord `json:"records"`
+}
+
+// equityRecord is a history record with its equity spelled out
+type equityRecord struct {
+	equity.Record
+	Equity int `json:"equity"`
+}
+
+func newEquityHistory(since time.Time, records []equity.Record) equityHistory {
+	h := equityHistory{Since: since, Records: make([]equityRecord, len(records))}
+	for i, r := range records {
+		h.Records[i] = equityRecord{Record: r, Equity: r.Equity()}
+	}
+	if len(records) > 0 {
+		h.Start = records[0].Equity()
+		h.End = records[len(records)-1].Equity()
+		h.Change = h.End - h.Start
+	}
+	return h
+}
+
+// equityChartSeries labels and values the records for the chart, with
+// times when the history spans under two days and dates otherwise
+func equityChartSeries(records []equity.Record) ([]string, []ui.LineSeries) {
+	layout := "01-02"
+	if len(records) > 1 && records[len(records)-1].Time.Sub(records[0].Time) < 48*time.Hour {
+		layout = "15:04"
+	}
+	labels := make([]string, len(records))
+	values := make([]int, len(records))
+	for i, r := range records {
+		labels[i] = r.Time.Local().Format(layout)
+		values[i] = r.Equity()
+	}
+	return labels, []ui.LineSeries{{Name: "Equity", Values: values}}
+}
+
+func runTrackChart(cmd *cobra.Command, args []string) error {
+	since, err := parseSinceArg(trackSince)
+	if err != nil {
+		return err
+	}
+	path, err := equityPath()
+	if err != nil {
+		return err
+	}
+
+	records, err := equity.Load(path, GetConfig().Environment(), ActiveSubaccount(), since)
+	if err != nil {
+		return err
+	}
+	if emptyListWarning(len(records), "No equity history in this period. Record some with 'kalshi-cli track snapshot'.") {
+		return nil
+	}
+	history := newEquityHistory(since, records)
+
+	return ui.Output(
+		GetOutputFormat(),
+		func() {
+			ui.RenderKeyValue([][]string{
+				{ui.BoldStyle.Render("Since:"), records[0].Time.Local().Format("2006-01-02 15:04")},
+				{ui.BoldStyle.Render("Start Equity:"), ui.FormatPrice(history.Start)},
+				{ui.BoldStyle.Render("End Equity:"), ui.FormatPrice(history.End)},
+				{ui.BoldStyle.Render("Change:"), ui.FormatPriceStyled(history.Change, history.Change >= 0)},
+				{ui.BoldStyle.Render("Snapshots:"), strconv.Itoa(len(records))},
+			})
+			labels, series := equityChartSeries(equity.Downsample(records, trackChartPoints))
+			ui.RenderOverlayChart("Equity", labels, series)
+		},
+		history,
+		func() {
+			for _, r := range records {
+				ui.PrintPlain("%s\t%d\t%d\t%d\t%d\t%d", r.Time.Format(time.RFC3339),
+					r.Balance, r.PortfolioValue, r.Equity(), r.Exposure, r.Positions)
+			}
+		},
+	)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/equity"
+)
+
+func TestNewEquityHistory(t *testing.T) {
+	start := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	records := []equity.Record{
+		{Time: start, Balance: 1000, PortfolioValue: 500},
+		{Time: start.Add(time.Hour), Balance: 900, PortfolioValue: 450},
+	}
+
+	h := newEquityHistory(start, records)
+	if h.Start != 1500 || h.End != 1350 || h.Change != -150 {
+		t.Errorf("history = start %d end %d change %d, want 1500 1350 -150", h.Start, h.End, h.Change)
+	}
+	if h.Records[1].Equity != 1350 {
+		t.Errorf("record equity = %d, want 1350", h.Records[1].Equity)
+	}
+
+	labels, series := equityChartSeries(records)
+	if want := start.Local().Format("15:04"); labels[0] != want {
+		t.Errorf("short history label = %q, want %q", labels[0], want)
+	}
+	if len(series) != 1 || series[0].Values[0] != 1500 {
+		t.Errorf("series = %+v", series)
+	}
+
+	records[1].Time = start.AddDate(0, 0, 5)
+	if labels, _ := equityChartSeries(records); labels[1] != records[1].Time.Local().Format("01-02") {
+		t.Errorf("long history label = %q, want a date", labels[1])
+	}
+}
@@ -0,0 +1,453 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui <market-ticker>",
+	Short: "Live dashboard of a market's ticker and book with your orders and positions",
+	Long: `Show a live terminal dashboard with four panels: the market's ticker, its
+orderbook, your resting orders and your open positions.
+
+The ticker and orderbook follow the WebSocket channels. Orders and positions
+are re-read from the API every --refresh and whenever one of your orders
+changes or fills. Your resting orders in the market are marked on the book.
+
+Keys:
+  1-4   show one panel full screen (ticker, orderbook, orders, positions)
+  0     show every panel
+  Tab   next panel
+  q     quit
+
+Needs an interactive terminal and table output.`,
+	Example: `  kalshi-cli tui KXBTC-26FEB12-B97000
+  kalshi-cli tui KXBTC-26FEB12-B97000 --refresh 10s`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTUI,
+}
+
+var tuiRefresh time.Duration
+
+const (
+	// tuiRedraw is how often the dashboard is redrawn when something changed
+	tuiRedraw = 200 * time.Millisecond
+	// tuiRows is how many rows a panel shows when every panel is on screen
+	tuiRows = 5
+	// tuiFullRows is how many rows a panel shows on its own
+	tuiFullRows = 20
+)
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+
+	tuiCmd.Flags().DurationVar(&tuiRefresh, "refresh", 5*time.Second, "how often to re-read orders and positions")
+}
+
+// tuiPanel is one of the dashboard's panels
+type tuiPanel int
+
+const (
+	tuiAll tuiPanel = iota
+	tuiTicker
+	tuiBook
+	tuiOrders
+	tuiPositions
+)
+
+var tuiPanelNames = map[tuiPanel]string{
+	tuiTicker:    "Ticker",
+	tuiBook:      "Orderbook",
+	tuiOrders:    "Orders",
+	tuiPositions: "Positions",
+}
+
+// tuiKeyHelp is the key reference under the dashboard
+const tuiKeyHelp = "1 ticker · 2 orderbook · 3 orders · 4 positions · 0 all · Tab next · q quit"
+
+// dashboard holds what the tui shows. Feeds update it from their own
+// goroutines; render draws it when something changed.
+type dashboard struct {
+	ticker string
+
+	mu        sync.Mutex
+	panel     tuiPanel
+	quote     websocket.TickerData
+	quoted    time.Time
+	open      int
+	bids      []models.OrderbookLevel
+	asks      []models.OrderbookLevel
+	orders    []models.Order
+	positions []models.MarketPosition
+	status    string
+	dirty     bool
+}
+
+func newDashboard(ticker string) *dashboard {
+	return &dashboard{ticker: ticker, dirty: true}
+}
+
+func (d *dashboard) setQuote(data websocket.TickerData, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.open == 0 {
+		d.open = data.YesPrice
+	}
+	d.quote = data
+	d.quoted = now
+	d.dirty = true
+}
+
+func (d *dashboard) setBook(bids, asks []models.OrderbookLevel) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.bids, d.asks = bids, asks
+	d.dirty = true
+}
+
+func (d *dashboard) setOrders(orders []models.Order) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.orders = orders
+	d.dirty = true
+}
+
+func (d *dashboard) setPositions(positions []models.MarketPosition) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.positions = positions
+	d.dirty = true
+}
+
+func (d *dashboard) setStatus(status string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.status = status
+	d.dirty = true
+}
+
+// press handles one key, reporting whether it quits
+func (d *dashboard) press(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch key {
+	case "q", "ctrl+c":
+		return true
+	case "0":
+		d.panel = tuiAll
+	case "1", "2", "3", "4":
+		n, _ := strconv.Atoi(key)
+		d.panel = tuiPanel(n)
+	case "tab":
+		d.panel = (d.panel + 1) % (tuiPositions + 1)
+	default:
+		return false
+	}
+	d.dirty = true
+	return false
+}
+
+// render writes the dashboard to w if anything changed since the last call
+func (d *dashboard) render(w io.Writer, env string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.dirty {
+		return false
+	}
+	d.dirty = false
+
+	fmt.Fprintf(w, "%s  %s  %s%s\n", ui.TitleStyle.Render(d.ticker), env, d.tabs(), redrawEOL)
+	fmt.Fprint(w, redrawEOL+"\n")
+
+	rows := tuiRows
+	if d.panel != tuiAll {
+		rows = tuiFullRows
+	}
+	for _, p := range []tuiPanel{tuiTicker, tuiBook, tuiOrders, tuiPositions} {
+		if d.panel != tuiAll && d.panel != p {
+			continue
+		}
+		fmt.Fprint(w, ui.BoldStyle.Render(d.title(p))+redrawEOL+"\n")
+		switch p {
+		case tuiTicker:
+			d.renderTicker(w)
+		case tuiBook:
+			d.renderBook(w, rows)
+		case tuiOrders:
+			d.renderOrders(w, rows)
+		case tuiPositions:
+			d.renderPositions(w, rows)
+		}
+		fmt.Fprint(w, redrawEOL+"\n")
+	}
+
+	if d.status != "" {
+		fmt.Fprint(w, d.status+redrawEOL+"\n")
+	}
+	fmt.Fprint(w, ui.MutedStyle.Render(tuiKeyHelp)+redrawEOL+"\n")
+	fmt.Fprint(w, redrawBelow)
+	return true
+}
+
+// tabs lists the panels with the one on screen highlighted
+func (d *dashboard) tabs() string {
+	parts := make([]string, 0, 5)
+	for _, p := range []tuiPanel{tuiAll, tuiTicker, tuiBook, tuiOrders, tuiPositions} {
+		name := "All"
+		if p != tuiAll {
+			name = tuiPanelNames[p]
+		}
+		label := fmt.Sprintf("%d %s", p, name)
+		if p == d.panel {
+			parts = append(parts, ui.HeaderStyle.Render("["+label+"]"))
+		} else {
+			parts = append(parts, ui.MutedStyle.Render(label))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (d *dashboard) title(p tuiPanel) string {
+	switch p {
+	case tuiOrders:
+		return fmt.Sprintf("%s (%d)", tuiPanelNames[p], len(d.orders))
+	case tuiPositions:
+		return fmt.Sprintf("%s (%d)", tuiPanelNames[p], len(d.positions))
+	}
+	return tuiPanelNames[p]
+}
+
+func (d *dashboard) renderTicker(w io.Writer) {
+	q := d.quote
+	if d.quoted.IsZero() {
+		fmt.Fprint(w, ui.MutedStyle.Render("Waiting for the first ticker update")+redrawEOL+"\n")
+		return
+	}
+	change := q.YesPrice - d.open
+	changeStr := fmt.Sprintf("%+d¢", change)
+	switch {
+	case change > 0:
+		changeStr = ui.PriceUpStyle.Render(changeStr)
+	case change < 0:
+		changeStr = ui.PriceDownStyle.Render(changeStr)
+	}
+	fmt.Fprintf(w, "Last %s (%s)  Bid %s  Ask %s  Volume %d  OI %d  %s%s\n",
+		formatCents(q.YesPrice), changeStr, formatCents(q.YesBid), formatCents(q.YesAsk),
+		q.Volume, q.OpenInterest, ui.MutedStyle.Render(d.quoted.Format("15:04:05")), redrawEOL)
+}
+
+func (d *dashboard) renderBook(w io.Writer, rows int) {
+	var here []models.Order
+	for _, o := range d.orders {
+		if o.Ticker == d.ticker {
+			here = append(here, o)
+		}
+	}
+	book := &models.Orderbook{
+		YesBids: d.bids[:min(rows, len(d.bids))],
+		YesAsks: d.asks[:min(rows, len(d.asks))],
+	}
+	cells := ctxBookRows(book, &myMarket{Orders: here})
+	if len(cells) == 0 {
+		fmt.Fprint(w, ui.MutedStyle.Render("The book is empty")+redrawEOL+"\n")
+		return
+	}
+	renderColumns(w, []string{"MINE", "BID QTY", "BID", "ASK", "ASK QTY", "MINE"}, cells, func(_, col int, s string) string {
+		switch col {
+		case 0, 5:
+			return ui.WarningStyle.Render(s)
+		case 1, 2:
+			return ui.PriceUpStyle.Render(s)
+		}
+		return ui.PriceDownStyle.Render(s)
+	}, redrawEOL)
+}
+
+func (d *dashboard) renderOrders(w io.Writer, rows int) {
+	if len(d.orders) == 0 {
+		fmt.Fprint(w, ui.MutedStyle.Render("No resting orders")+redrawEOL+"\n")
+		return
+	}
+	cells := make([][]string, 0, min(rows, len(d.orders)))
+	for _, o := range d.orders[:min(rows, len(d.orders))] {
+		cells = append(cells, []string{o.Ticker, orderLabel(o), truncateID(o.OrderID, 8)})
+	}
+	renderColumns(w, []string{"MARKET", "ORDER", "ID"}, cells, func(_, _ int, s string) string { return s }, redrawEOL)
+	if more := len(d.orders) - len(cells); more > 0 {
+		fmt.Fprint(w, ui.MutedStyle.Render(fmt.Sprintf("… %d more", more))+redrawEOL+"\n")
+	}
+}
+
+func (d *dashboard) renderPositions(w io.Writer, rows int) {
+	if len(d.positions) == 0 {
+		fmt.Fprint(w, ui.MutedStyle.Render("No open positions")+redrawEOL+"\n")
+		return
+	}
+	cells := make([][]string, 0, min(rows, len(d.positions)))
+	for _, p := range d.positions[:min(rows, len(d.positions))] {
+		cells = append(cells, []string{p.Ticker, formatPosition(p.Position), ui.FormatPrice(calculateAvgCost(p)), ui.FormatPrice(p.MarketExposure), ui.FormatPrice(p.RealizedPnl)})
+	}
+	renderColumns(w, []string{"MARKET", "POSITION", "AVG COST", "EXPOSURE", "P&L"}, cells, func(row, col int, s string) string {
+		switch pnl := d.positions[row].RealizedPnl; {
+		case col != 4:
+			return s
+		case pnl < 0:
+			return ui.PriceDownStyle.Render(s)
+		}
+		return ui.PriceUpStyle.Render(s)
+	}, redrawEOL)
+	if more := len(d.positions) - len(cells); more > 0 {
+		fmt.Fprint(w, ui.MutedStyle.Render(fmt.Sprintf("… %d more", more))+redrawEOL+"\n")
+	}
+}
+
+// tuiRunner connects the dashboard to the API
+type tuiRunner struct {
+	client *api.Client
+	board  *dashboard
+}
+
+// loadBook reads the orderbook over REST so the book shows before the first
+// WebSocket snapshot
+func (r *tuiRunner) loadBook(ctx context.Context) error {
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	ob, err := r.client.GetOrderbook(reqCtx, r.board.ticker)
+	if err != nil {
+		return fmt.Errorf("failed to get orderbook: %w", marketNotFound(ctx, r.client, r.board.ticker, err))
+	}
+	r.board.setBook(ob.YesBids, ob.YesAsks)
+	return nil
+}
+
+// loadAccount re-reads your resting orders and open positions
+func (r *tuiRunner) loadAccount(ctx context.Context) error {
+	orders, err := restingOrders(ctx, r.client, "")
+	if err != nil {
+		return err
+	}
+	positions, err := openPositions(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	r.board.setOrders(orders)
+	r.board.setPositions(positions)
+	return nil
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	ticker, err := normalizeTicker(args[0])
+	if err != nil {
+		return err
+	}
+	if err := requireScreen("tui", "dashboard keys"); err != nil {
+		return err
+	}
+	if tuiRefresh <= 0 {
+		return fmt.Errorf("--refresh must be positive")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	r := &tuiRunner{client: client, board: newDashboard(ticker)}
+	if err := r.loadBook(ctx); err != nil {
+		return err
+	}
+	if err := r.loadAccount(ctx); err != nil {
+		return err
+	}
+
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return err
+	}
+	// Order and fill messages only prompt a reload, so a burst of them
+	// collapses into one
+	reload := make(chan struct{}, 1)
+	signalReload := websocket.HandlerFunc(func(websocket.Message) error {
+		select {
+		case reload <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	wsClient := newWebSocketClient(opts)
+	wsClient.OnError(func(err error) { r.board.setStatus(ui.WarningStyle.Render(err.Error())) })
+	wsClient.RegisterHandler(websocket.ChannelMarketTicker, websocket.HandlerFunc(func(msg websocket.Message) error {
+		var data websocket.TickerData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse ticker data: %w", err)
+		}
+		if data.Ticker == ticker {
+			r.board.setQuote(data, time.Now())
+		}
+		return nil
+	}))
+	wsClient.RegisterHandler(websocket.ChannelOrderbook, websocket.HandlerFunc(func(msg websocket.Message) error {
+		var data websocket.OrderbookData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse orderbook data: %w", err)
+		}
+		if data.Ticker != "" && data.Ticker != ticker {
+			return nil
+		}
+		r.board.setBook(wsLevels(data.YesBids), wsLevels(data.YesAsks))
+		return nil
+	}))
+	wsClient.RegisterHandler(websocket.ChannelUserOrders, signalReload)
+	wsClient.RegisterHandler(websocket.ChannelUserFills, signalReload)
+	if err := wsClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer wsClient.Close()
+	for _, ch := range []websocket.Channel{websocket.ChannelMarketTicker, websocket.ChannelOrderbook} {
+		if err := wsClient.Subscribe(ctx, ch, map[string]string{"market_tickers": ticker}); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", ch, err)
+		}
+	}
+	for _, ch := range []websocket.Channel{websocket.ChannelUserOrders, websocket.ChannelUserFills} {
+		if err := wsClient.Subscribe(ctx, ch, nil); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", ch, err)
+		}
+	}
+
+	env := envPrompt(strings.ToUpper(GetConfig().Environment()))
+	return screen{
+		redraw:    tuiRedraw,
+		render:    func(w io.Writer) bool { return r.board.render(w, env) },
+		key:       r.board.press,
+		refresh:   tuiRefresh,
+		wake:      reload,
+		onRefresh: func() { r.reloadAccount(ctx) },
+	}.run()
+}
+
+// reloadAccount refreshes orders and positions, reporting a failure in the
+// status line
+func (r *tuiRunner) reloadAccount(ctx context.Context) {
+	if err := r.loadAccount(ctx); err != nil {
+		r.board.setStatus(ui.WarningStyle.Render(err.Error()))
+		return
+	}
+	r.board.setStatus("")
+}
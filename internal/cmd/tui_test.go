@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestDashboard_Press(t *testing.T) {
+	d := newDashboard("KXA")
+	for _, tt := range []struct {
+		key  string
+		want tuiPanel
+	}{
+		{"3", tuiOrders},
+		{"tab", tuiPositions},
+		{"tab", tuiAll},
+		{"tab", tuiTicker},
+		{"x", tuiTicker},
+		{"0", tuiAll},
+	} {
+		if d.press(tt.key) || d.panel != tt.want {
+			t.Errorf("after %q panel = %d, want %d", tt.key, d.panel, tt.want)
+		}
+	}
+	if !d.press("q") {
+		t.Error("q did not quit")
+	}
+}
+
+func TestDashboard_Render(t *testing.T) {
+	d := newDashboard("KXA")
+	d.setQuote(websocket.TickerData{Ticker: "KXA", YesPrice: 45}, time.Now())
+	d.setQuote(websocket.TickerData{Ticker: "KXA", YesPrice: 47, YesBid: 46, YesAsk: 48}, time.Now())
+	d.setBook([]models.OrderbookLevel{{Price: 46, Quantity: 10}}, []models.OrderbookLevel{{Price: 48, Quantity: 5}})
+	d.setOrders([]models.Order{
+		{OrderID: "o1", Ticker: "KXA", Side: models.OrderSideYes, Action: models.OrderActionBuy, YesPrice: 46, RemainingCount: 3},
+		{OrderID: "o2", Ticker: "KXB", Side: models.OrderSideYes, Action: models.OrderActionBuy, YesPrice: 20, RemainingCount: 1},
+	})
+	d.setPositions([]models.MarketPosition{{Ticker: "KXB", Position: -4}})
+
+	var b strings.Builder
+	if !d.render(&b, "DEMO") {
+		t.Fatal("nothing rendered")
+	}
+	out := b.String()
+	for _, want := range []string{"+2¢", "Orders (2)", "Positions (1)", "KXB", "-4"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if d.render(&b, "DEMO") {
+		t.Error("rendered again without a change")
+	}
+
+	d.press("4")
+	b.Reset()
+	d.render(&b, "DEMO")
+	if out := b.String(); strings.Contains(out, "Orders (2)") || !strings.Contains(out, "Positions (1)") {
+		t.Errorf("positions panel shows other panels:\n%s", out)
+	}
+}
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var warmCmd = &cobra.Command{
+	Use:   "warm [market-ticker...]",
+	Short: "Prepare caches and connections before a trading session",
+	Long: `Do the slow first-time work of a trading session up front, so the first
+real order is not held up by it:
+
+  markets    fetch the markets given and those in --watchlist in one request,
+             failing if any is missing and noting any not open
+  events     look up each market's event, filling the series cache on disk
+  websocket  open a signed WebSocket connection, subscribe to the markets'
+             ticker channel and wait for the first message
+  balance    fetch the balance, which proves the API key is accepted
+
+Every step is timed and the command exits non-zero if any failed, so it can
+gate the start of a bot. Without tickers or --watchlist only the WebSocket
+and balance steps run.`,
+	Example: `  kalshi-cli warm --watchlist mine
+  kalshi-cli warm KXBTC-26FEB12-B97000 KXBTC-26FEB12-B98000
+  kalshi-cli warm --watchlist mine --json && ./start-bot.sh`,
+	RunE: runWarm,
+}
+
+var warmWatchlist string
+
+func init() {
+	rootCmd.AddCommand(warmCmd)
+
+	warmCmd.Flags().StringVar(&warmWatchlist, "watchlist", "", "also warm the markets in a watchlist from the config file")
+}
+
+// warmStep is the outcome of one warm-up step
+type warmStep struct {
+	Step     string        `json:"step"`
+	OK       bool          `json:"ok"`
+	Duration time.Duration `json:"-"`
+	Ms       float64       `json:"ms"`
+	Detail   string        `json:"detail"`
+}
+
+// warmResult is the output of warm
+type warmResult struct {
+	Environment string     `json:"environment"`
+	Tickers     []string   `json:"tickers"`
+	Ready       bool       `json:"ready"`
+	Steps       []warmStep `json:"steps"`
+}
+
+// add records a step timed from start
+func (r *warmResult) add(name string, start time.Time, detail string, err error) {
+	step := warmStep{Step: name, OK: err == nil, Duration: time.Since(start), Detail: detail}
+	if err != nil {
+		step.Detail = err.Error()
+	}
+	step.Ms = float64(step.Duration.Microseconds()) / 1000
+	r.Steps = append(r.Steps, step)
+}
+
+// failures counts the steps that failed
+func (r *warmResult) failures() int {
+	n := 0
+	for _, s := range r.Steps {
+		if !s.OK {
+			n++
+		}
+	}
+	return n
+}
+
+// warmMarketsDetail describes the markets fetched for tickers, failing when
+// any ticker was not returned
+func warmMarketsDetail(tickers []string, markets []models.Market) (string, error) {
+	found := make(map[string]bool, len(markets))
+	var closed []string
+	for _, m := range markets {
+		found[m.Ticker] = true
+		if m.Status != "" && m.Status != "open" && m.Status != "active" {
+			closed = append(closed, fmt.Sprintf("%s (%s)", m.Ticker, m.Status))
+		}
+	}
+	var missing []string
+	for _, t := range tickers {
+		if !found[t] {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("not found: %s", strings.Join(missing, ", "))
+	}
+	detail := fmt.Sprintf("%d markets", len(markets))
+	if len(closed) > 0 {
+		detail += "; not open: " + strings.Join(closed, ", ")
+	}
+	return detail, nil
+}
+
+// warmEvents resolves the series of each market's event through the series
+// cache, returning how many events there were and how many were cached
+func warmEvents(ctx context.Context, client *api.Client, markets []models.Market) (int, int, error) {
+	cache := loadSeriesCache()
+	defer saveSeriesCache(cache)
+
+	seen := make(map[string]bool)
+	cached := 0
+	for _, m := range markets {
+		if m.EventTicker == "" || seen[m.EventTicker] {
+			continue
+		}
+		seen[m.EventTicker] = true
+		if _, ok := cache.Get(m.EventTicker); ok {
+			cached++
+			continue
+		}
+		if _, err := resolveSeriesTicker(ctx, client, cache, m.EventTicker, ""); err != nil {
+			return len(seen), cached, err
+		}
+	}
+	return len(seen), cached, nil
+}
+
+// warmWebSocket opens a signed connection, subscribes to the ticker channel
+// for tickers (all markets when empty) and waits for the first message
+func warmWebSocket(ctx context.Context, tickers []string) (string, error) {
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return "", err
+	}
+	client := websocket.NewClient(opts)
+
+	first := make(chan struct{}, 1)
+	client.RegisterHandler(websocket.ChannelMarketTicker, websocket.HandlerFunc(func(websocket.Message) error {
+		select {
+		case first <- struct{}{}:
+		default:
+		}
+		return nil
+	}))
+
+	start := time.Now()
+	if err := client.Connect(ctx); err != nil {
+		return "", fmt.Errorf("failed to connect: %w", err)
+	}
+	defer client.Close()
+	connected := time.Since(start)
+
+	var params map[string]string
+	if len(tickers) > 0 {
+		params = map[string]string{"market_tickers": strings.Join(tickers, ",")}
+	}
+	subscribed := time.Now()
+	if err := client.Subscribe(ctx, websocket.ChannelMarketTicker, params); err != nil {
+		return "", fmt.Errorf("failed to subscribe: %w", err)
+	}
+	select {
+	case <-first:
+		return fmt.Sprintf("connected in %s, first ticker after %s",
+			connected.Round(time.Millisecond), time.Since(subscribed).Round(time.Millisecond)), nil
+	case <-time.After(pingWSMessageTimeout):
+		// Quiet markets may not tick; the connection itself is what matters
+		return fmt.Sprintf("connected in %s, no ticker within %s", connected.Round(time.Millisecond), pingWSMessageTimeout), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func runWarm(cmd *cobra.Command, args []string) error {
+	var tickers []string
+	if len(args) > 0 || warmWatchlist != "" {
+		var err error
+		if tickers, err = gridTickers(args, warmWatchlist, GetConfig().Watchlists); err != nil {
+			return err
+		}
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := alertContext()
+	defer cancel()
+
+	result := warmResult{Environment: GetConfig().Environment(), Tickers: tickers}
+	if len(tickers) > 0 {
+		start := time.Now()
+		reqCtx, reqCancel := withTimeout(ctx)
+		resp, err := client.ListMarkets(reqCtx, api.ListMarketsParams{Tickers: tickers, Limit: len(tickers)})
+		reqCancel()
+		var markets []models.Market
+		detail := ""
+		if err == nil {
+			markets = resp.Markets
+			detail, err = warmMarketsDetail(tickers, markets)
+		}
+		result.add("markets", start, detail, err)
+
+		if err == nil {
+			start = time.Now()
+			reqCtx, reqCancel = withTimeout(ctx)
+			events, cached, err := warmEvents(reqCtx, client, markets)
+			reqCancel()
+			result.add("events", start, fmt.Sprintf("%d events, %d already cached", events, cached), err)
+		}
+	}
+
+	start := time.Now()
+	reqCtx, reqCancel := withTimeout(ctx)
+	detail, err := warmWebSocket(reqCtx, tickers)
+	reqCancel()
+	result.add("websocket", start, detail, err)
+
+	start = time.Now()
+	reqCtx, reqCancel = withTimeout(ctx)
+	balance, err := client.GetBalance(reqCtx)
+	reqCancel()
+	detail = ""
+	if err == nil {
+		detail = formatCents(balance.Balance) + " available"
+	}
+	result.add("balance", start, detail, err)
+
+	failed := result.failures()
+	result.Ready = failed == 0
+
+	if err := ui.Output(
+		GetOutputFormat(),
+		func() { renderWarmTable(result) },
+		result,
+		func() {
+			for _, s := range result.Steps {
+				fmt.Fprintf(ui.Writer(), "%s\t%v\t%.0f\t%s\n", s.Step, s.OK, s.Ms, s.Detail)
+			}
+		},
+	); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("warm-up failed: %d of %d steps", failed, len(result.Steps))
+	}
+	return nil
+}
+
+func renderWarmTable(r warmResult) {
+	rows := make([][]string, len(r.Steps))
+	var total time.Duration
+	for i, s := range r.Steps {
+		status := ui.SuccessStyle.Render("ok")
+		if !s.OK {
+			status = ui.ErrorStyle.Render("failed")
+		}
+		rows[i] = []string{s.Step, status, s.Duration.Round(time.Millisecond).String(), s.Detail}
+		total += s.Duration
+	}
+	ui.RenderTable([]string{"Step", "Result", "Time", "Detail"}, rows)
+	if r.Ready {
+		PrintSuccess(fmt.Sprintf("Ready for %s in %s", r.Environment, total.Round(time.Millisecond)))
+	}
+}
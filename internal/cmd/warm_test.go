@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestWarmMarketsDetail(t *testing.T) {
+	markets := []models.Market{
+		{Ticker: "KXA", Status: "active"},
+		{Ticker: "KXB", Status: "closed"},
+	}
+	detail, err := warmMarketsDetail([]string{"KXA", "KXB"}, markets)
+	if err != nil || detail != "2 markets; not open: KXB (closed)" {
+		t.Errorf("detail = %q, %v", detail, err)
+	}
+	if _, err := warmMarketsDetail([]string{"KXA", "KXC"}, markets); err == nil || !strings.Contains(err.Error(), "not found: KXC") {
+		t.Errorf("err = %v, want KXC not found", err)
+	}
+}
+
+func TestWarmResultFailures(t *testing.T) {
+	var r warmResult
+	r.add("markets", time.Now(), "1 markets", nil)
+	r.add("balance", time.Now(), "", errors.New("unauthorized"))
+	if r.failures() != 1 || !r.Steps[0].OK || r.Steps[1].Detail != "unauthorized" {
+		t.Errorf("steps = %+v", r.Steps)
+	}
+}
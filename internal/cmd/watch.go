@@ -7,14 +7,15 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/6missedcalls/kalshi-cli/internal/api"
 	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/fillstore"
 	"github.com/6missedcalls/kalshi-cli/internal/ui"
 	"github.com/6missedcalls/kalshi-cli/internal/websocket"
 	"github.com/6missedcalls/kalshi-cli/pkg/models"
@@ -34,15 +35,24 @@ func init() {
 	watchCmd.AddCommand(watchPositionsCmd)
 
 	watchTradesCmd.Flags().StringVar(&watchMarketFlag, "market", "", "filter trades by market ticker")
+	watchCmd.PersistentFlags().StringVar(&summaryWebhook, "summary-webhook", "", "POST the end-of-session summary as JSON to this URL")
 }
 
 var watchCmd = &cobra.Command{
-	Use:   "watch",
-	Short: "Watch live market data and account updates",
+	Use:     "watch",
+	Aliases: []string{"w"},
+	Short:   "Watch live market data and account updates",
 	Long: `Stream real-time data from Kalshi via WebSocket.
 
 All watch commands require authentication (API credentials).
-Press Ctrl+C to stop watching.
+Press Ctrl+C to stop watching. On exit a session summary is printed with the
+runtime, messages processed, reconnects, order and fill counts, and P&L delta.
+
+With --json --envelope every line is wrapped as {received_at, channel, seq,
+data} so several streams can be merged and ordered downstream.
+
+ticker, orderbook and trades can also write every update to InfluxDB,
+TimescaleDB or PostgreSQL with --sink, for dashboards such as Grafana.
 
 Available streams:
   ticker      Live price updates for a market (requires <market-ticker>)
@@ -50,13 +60,27 @@ Available streams:
   trades      Public trades feed (optional --market filter)
   orders      Your order status changes
   fills       Your fill notifications
-  positions   Your position changes`,
+  positions   Your position changes
+  risk        Halts, early closes and settlement of markets you hold
+  grid        Several markets in a grid that updates in place
+  raw         Frames exactly as received, for any channels
+  record      Frames written to a file, for replay
+  replay      A recording fed back through these handlers, offline
+
+Several of ticker, orderbook, trades, orders, fills and positions can share
+one connection: name them comma-separated (or with repeated --channel) and
+give the market with --market. Each line is prefixed with its stream, such as
+[ticker]; with --json the lines are wrapped in the --envelope form instead.`,
 	Example: `  kalshi-cli watch ticker INXD-25FEB07-B5523.99
   kalshi-cli watch orderbook INXD-25FEB07-B5523.99
   kalshi-cli watch trades --market INXD-25FEB07-B5523.99
   kalshi-cli watch orders
   kalshi-cli watch fills --json
-  kalshi-cli watch positions`,
+  kalshi-cli watch trades --json --envelope
+  kalshi-cli watch trades --sink influx --sink-url http://localhost:8086 --sink-bucket kalshi
+  kalshi-cli watch positions
+  kalshi-cli watch ticker,orderbook,trades --market INXD-25FEB07-B5523.99
+  kalshi-cli watch --channel orders --channel fills --json`,
 }
 
 var watchTickerCmd = &cobra.Command{
@@ -65,8 +89,13 @@ var watchTickerCmd = &cobra.Command{
 	Long: `Stream real-time price updates for a specific market.
 
 Output includes bid/ask prices, volume, and open interest.
-Use 'kalshi-cli markets list' to find available market tickers.`,
+Use 'kalshi-cli markets list' to find available market tickers.
+
+Alerts are highlighted inline when the spread widens (--alert-spread-above) or
+the price moves N cents within a window (--alert-move, --alert-window). Add
+--notify to also deliver them to the alerts.* webhook, Slack or desktop.`,
 	Example: `  kalshi-cli watch ticker INXD-25FEB07-B5523.99
+  kalshi-cli watch ticker INXD-25FEB07-B5523.99 --alert-move 5 --alert-window 2m
   kalshi-cli watch ticker INXD-25FEB07-B5523.99 --json
   kalshi-cli watch ticker INXD-25FEB07-B5523.99 --plain`,
 	Args: cobra.ExactArgs(1),
@@ -79,8 +108,28 @@ var watchOrderbookCmd = &cobra.Command{
 	Long: `Stream real-time orderbook delta updates for a specific market.
 
 Shows best bid/ask, depth, and orderbook changes as they occur.
-Use 'kalshi-cli markets list' to find available market tickers.`,
+Use 'kalshi-cli markets list' to find available market tickers.
+
+Alerts are highlighted inline when the spread widens (--alert-spread-above),
+either side's depth drops below N contracts (--alert-depth-below), or the mid
+price moves N cents within a window (--alert-move, --alert-window). Add
+--notify to also deliver them to the alerts.* webhook, Slack or desktop.
+
+--ladder turns the view into a minimal trading ladder: one row per YES price
+around a cursor with the book's bid and ask size and your own resting orders,
+redrawn in place. Keys:
+
+  up/down, k/j  move the cursor          m  cursor to the mid
+  b / a         join the best bid / ask  l  lift asks up to the cursor
+  h             hit bids down to cursor  c  cancel your orders at the cursor
+  C             cancel all your orders   q  quit
+
+Orders ask for a size first (Enter for --size, Esc to back out) and go
+through --precheck like orders create. Offering or selling YES is done by
+buying NO at the complementary price.`,
 	Example: `  kalshi-cli watch orderbook INXD-25FEB07-B5523.99
+  kalshi-cli watch orderbook INXD-25FEB07-B5523.99 --ladder --size 10
+  kalshi-cli watch orderbook INXD-25FEB07-B5523.99 --alert-spread-above 4 --alert-depth-below 100 --notify
   kalshi-cli watch orderbook INXD-25FEB07-B5523.99 --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWatchOrderbook,
@@ -91,8 +140,29 @@ var watchTradesCmd = &cobra.Command{
 	Short: "Watch public trades feed",
 	Long: `Stream real-time public trades across all markets.
 
-Optionally filter to a single market using the --market flag.`,
+Optionally filter to a single market using the --market flag.
+
+With --detect-anomalies each print is scored against rolling per-market
+statistics of trade size and price change. Prints at or above --anomaly-z
+standard deviations are flagged as large blocks or price jumps; add --notify
+to also deliver them to the alerts.* webhook, Slack or desktop.
+
+Each print shows the aggressor (BUY for yes takers, SELL for no takers) and
+the market's session volume. Prints of at least --block-size contracts are
+highlighted as blocks and prints under --odd-lot are dimmed. --vwap appends the
+running session VWAP for the market.
+
+The unfiltered feed can be thinned: --top-markets keeps only the markets with
+the most volume over the last 5 minutes, re-ranked as activity shifts, and
+--sample 1/N prints one in every N of the remaining trades. --summary replaces
+the prints with one line per market (trades, volume, last, VWAP and the share
+bought by YES takers), redrawn every --refresh. Session volume, VWAP and
+anomaly detection still see every trade.`,
 	Example: `  kalshi-cli watch trades
+  kalshi-cli watch trades --detect-anomalies --anomaly-z 4 --notify
+  kalshi-cli watch trades --block-size 500 --odd-lot 5 --vwap
+  kalshi-cli watch trades --top-markets 20 --sample 1/10
+  kalshi-cli watch trades --summary --top-markets 20 --refresh 3s
   kalshi-cli watch trades --market INXD-25FEB07-B5523.99
   kalshi-cli watch trades --json`,
 	RunE: runWatchTrades,
@@ -114,9 +184,16 @@ var watchFillsCmd = &cobra.Command{
 	Short: "Watch your fill notifications",
 	Long: `Stream real-time fill notifications for your orders.
 
-Shows each individual fill as it occurs, including price, count, and taker/maker status.`,
+Shows each individual fill as it occurs, including price, count, and taker/maker status.
+
+--persist appends every new fill to a local JSONL fill store and syncs it to
+disk before printing. --dedupe skips fills whose ID is already in the store
+or was seen earlier in the session, so a watcher restarted by a supervisor
+does not report a fill twice.`,
 	Example: `  kalshi-cli watch fills
-  kalshi-cli watch fills --json`,
+  kalshi-cli watch fills --json
+  kalshi-cli watch fills --json --dedupe --persist
+  kalshi-cli watch fills --dedupe --persist --store /srv/kalshi-bot/fills.jsonl`,
 	RunE: runWatchFills,
 }
 
@@ -132,22 +209,49 @@ Shows changes to your positions including realized PnL, exposure, and total cost
 }
 
 func runWatchTicker(_ *cobra.Command, args []string) error {
+	if err := validateMarketAlertFlags(); err != nil {
+		return err
+	}
 	ticker := args[0]
 	params := map[string]string{"market_tickers": ticker}
 	return runWatch(websocket.ChannelMarketTicker, params)
 }
 
 func runWatchOrderbook(_ *cobra.Command, args []string) error {
+	if err := validateMarketAlertFlags(); err != nil {
+		return err
+	}
 	ticker := args[0]
+	if watchLadder {
+		return runWatchLadder(ticker)
+	}
 	params := map[string]string{"market_tickers": ticker}
 	return runWatch(websocket.ChannelOrderbook, params)
 }
 
 func runWatchTrades(_ *cobra.Command, _ []string) error {
+	if err := validateAnomalyFlags(); err != nil {
+		return err
+	}
+	if err := validateTapeFlags(); err != nil {
+		return err
+	}
+	if err := validateFirehoseFlags(); err != nil {
+		return err
+	}
 	params := make(map[string]string)
 	if watchMarketFlag != "" {
 		params["market_tickers"] = watchMarketFlag
 	}
+
+	firehose := newTradeFirehoseFromFlags()
+	activeFirehose = firehose
+	defer func() { activeFirehose = nil }()
+	if watchTradesSummary {
+		prices := watchPricesFromFlags()
+		stop := startRedraw(watchSummaryRefresh, func(inPlace bool) { drawTradeSummary(firehose, prices, inPlace) })
+		defer stop()
+	}
 	return runWatch(websocket.ChannelPublicTrades, params)
 }
 
@@ -156,6 +260,17 @@ func runWatchOrders(_ *cobra.Command, _ []string) error {
 }
 
 func runWatchFills(_ *cobra.Command, _ []string) error {
+	store, err := openFillStore()
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		defer store.Close()
+		activeFillStore = store
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Fill store: %s (%d fills)\n", store.Path(), store.Len())
+		}
+	}
 	return runWatch(websocket.ChannelUserFills, nil)
 }
 
@@ -168,6 +283,23 @@ func runWatch(channel websocket.Channel, params map[string]string) error {
 }
 
 func runWatchMultiple(channels []websocket.Channel, params map[string]string) error {
+	if err := validateWatchPriceFlags(); err != nil {
+		return err
+	}
+	if err := validateEnvelopeFlag(); err != nil {
+		return err
+	}
+	sink, err := openWatchSink()
+	if err != nil {
+		return err
+	}
+	if sink != nil {
+		defer sink.Close()
+		activeSink = sink
+	}
+	if len(channels) > 1 && GetOutputFormat() != ui.FormatJSON {
+		defer prefixStreamOutput()()
+	}
 	cfg := GetConfig()
 
 	opts, err := buildClientOptions(cfg)
@@ -189,9 +321,15 @@ func runWatchMultiple(channels []websocket.Channel, params map[string]string) er
 		cancel()
 	}()
 
-	client := websocket.NewClient(opts)
+	client := newWebSocketClient(opts)
+	tracker := newSessionTracker()
+
+	// The summary P&L delta needs a REST client; watching still works without one
+	restClient, _ := createClient()
+	tracker.captureStartBalance(restClient)
 
 	client.OnReconnect(func() {
+		tracker.reconnects.Add(1)
 		if IsVerbose() {
 			fmt.Fprintln(os.Stderr, "Reconnected")
 		}
@@ -203,7 +341,7 @@ func runWatchMultiple(channels []websocket.Channel, params map[string]string) er
 		}
 	})
 
-	registerHandlers(client, channels)
+	registerHandlers(client, channels, tracker)
 
 	if err := client.Connect(ctx); err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
@@ -213,6 +351,9 @@ func runWatchMultiple(channels []websocket.Channel, params map[string]string) er
 	if IsVerbose() {
 		fmt.Fprintf(os.Stderr, "Connected to %s\n", cfg.Environment())
 	}
+	if GetOutputFormat() == ui.FormatTable && showEnvBanner() {
+		fmt.Fprintln(os.Stderr, envStatusLine())
+	}
 
 	for _, ch := range channels {
 		if err := client.Subscribe(ctx, ch, params); err != nil {
@@ -229,12 +370,15 @@ func runWatchMultiple(channels []websocket.Channel, params map[string]string) er
 	}
 
 	<-ctx.Done()
+	tracker.finish(restClient)
 	return nil
 }
 
 func buildClientOptions(cfg *config.Config) (websocket.ClientOptions, error) {
 	opts := websocket.ClientOptions{
-		URL: cfg.WebSocketURL(),
+		URL:        cfg.WebSocketURL(),
+		HTTPClient: websocketHTTPClient(),
+		Headers:    cfg.ExtraHeaders,
 	}
 
 	signer, err := getSigner(cfg)
@@ -255,35 +399,63 @@ func buildClientOptions(cfg *config.Config) (websocket.ClientOptions, error) {
 	return opts, nil
 }
 
-func registerHandlers(client *websocket.Client, channels []websocket.Channel) {
+func registerHandlers(client *websocket.Client, channels []websocket.Channel, tracker *sessionTracker) {
 	outputFormat := GetOutputFormat()
+	var seq atomic.Int64
+	var prefixes map[websocket.Channel]string
+	if len(channels) > 1 && outputFormat != ui.FormatJSON {
+		prefixes = streamPrefixes(channels)
+	}
 
 	for _, ch := range channels {
-		switch ch {
-		case websocket.ChannelMarketTicker:
-			client.RegisterHandler(ch, &tickerHandler{format: outputFormat})
-		case websocket.ChannelMarketTickerV2:
-			client.RegisterHandler(ch, &tickerV2Handler{format: outputFormat})
-		case websocket.ChannelOrderbook:
-			client.RegisterHandler(ch, &orderbookHandler{format: outputFormat})
-		case websocket.ChannelPublicTrades:
-			client.RegisterHandler(ch, &tradesHandler{format: outputFormat, filterTicker: watchMarketFlag})
-		case websocket.ChannelUserOrders:
-			client.RegisterHandler(ch, &ordersHandler{format: outputFormat})
-		case websocket.ChannelUserFills:
-			client.RegisterHandler(ch, &fillsHandler{format: outputFormat})
-		case websocket.ChannelMarketPositions:
-			client.RegisterHandler(ch, &positionsHandler{format: outputFormat})
-		case websocket.ChannelMarketLifecycle:
-			client.RegisterHandler(ch, &lifecycleHandler{format: outputFormat})
-		case websocket.ChannelOrderGroupUpdates:
-			client.RegisterHandler(ch, &orderGroupHandler{format: outputFormat})
-		case websocket.ChannelCommunications:
-			client.RegisterHandler(ch, &communicationsHandler{format: outputFormat})
+		next := newWatchHandler(ch, outputFormat)
+		if prefix, ok := prefixes[ch]; ok {
+			next = &prefixHandler{next: next, prefix: prefix}
 		}
+		if envelope && outputFormat == ui.FormatJSON {
+			next = &envelopeHandler{next: next, channel: ch, seq: &seq}
+		}
+		if activeSink != nil {
+			next = &sinkHandler{next: next, channel: ch, sink: activeSink}
+		}
+		client.RegisterHandler(ch, &trackingHandler{
+			next:    next,
+			channel: ch,
+			tracker: tracker,
+		})
 	}
 }
 
+// newWatchHandler returns the output handler for a channel
+func newWatchHandler(ch websocket.Channel, outputFormat ui.OutputFormat) websocket.Handler {
+	switch ch {
+	case websocket.ChannelMarketTicker:
+		if tickerOverride != nil {
+			return tickerOverride
+		}
+		return &tickerHandler{format: outputFormat, prices: watchPricesFromFlags(), alerts: newMarketAlertsFromFlags()}
+	case websocket.ChannelMarketTickerV2:
+		return &tickerV2Handler{format: outputFormat, prices: watchPricesFromFlags()}
+	case websocket.ChannelOrderbook:
+		return &orderbookHandler{format: outputFormat, prices: watchPricesFromFlags(), alerts: newMarketAlertsFromFlags()}
+	case websocket.ChannelPublicTrades:
+		return &tradesHandler{format: outputFormat, prices: watchPricesFromFlags(), tape: newTradeTapeFromFlags(), filterTicker: watchMarketFlag, anomalies: newAnomalyDetectorFromFlags(), firehose: activeFirehose}
+	case websocket.ChannelUserOrders:
+		return &ordersHandler{format: outputFormat}
+	case websocket.ChannelUserFills:
+		return newFillsHandler(outputFormat)
+	case websocket.ChannelMarketPositions:
+		return &positionsHandler{format: outputFormat}
+	case websocket.ChannelMarketLifecycle:
+		return &lifecycleHandler{format: outputFormat}
+	case websocket.ChannelOrderGroupUpdates:
+		return &orderGroupHandler{format: outputFormat}
+	case websocket.ChannelCommunications:
+		return &communicationsHandler{format: outputFormat}
+	}
+	return websocket.HandlerFunc(func(websocket.Message) error { return nil })
+}
+
 func requiresAuth(channels []websocket.Channel) bool {
 	for _, ch := range channels {
 		if websocket.ChannelRequiresAuth(ch) {
@@ -294,49 +466,12 @@ func requiresAuth(channels []websocket.Channel) bool {
 }
 
 func getSigner(_ *config.Config) (*api.Signer, error) {
-	// Try config file first (fast, no GUI prompts)
-	apiKeyID := viper.GetString("api_key_id")
-	privateKeyPath := viper.GetString("private_key_path")
-
-	// Check env vars
-	if apiKeyID == "" {
-		apiKeyID = os.Getenv("KALSHI_API_KEY_ID")
-	}
-	if privateKeyPath == "" {
-		privateKeyPath = os.Getenv("KALSHI_PRIVATE_KEY_FILE")
-	}
-
-	if apiKeyID != "" && privateKeyPath != "" {
-		pemData, err := os.ReadFile(privateKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read private key file: %w", err)
-		}
-		return api.NewSignerFromPEM(apiKeyID, string(pemData))
-	}
-
-	// Check KALSHI_PRIVATE_KEY env var (PEM content directly)
-	privateKeyPEM := os.Getenv("KALSHI_PRIVATE_KEY")
-	if apiKeyID != "" && privateKeyPEM != "" {
-		return api.NewSignerFromPEM(apiKeyID, privateKeyPEM)
-	}
-
-	// Last resort: keyring
-	keyringStore, err := config.NewKeyringStore()
-	if err != nil {
-		return nil, fmt.Errorf("failed to access keyring: %w", err)
-	}
-	creds, err := keyringStore.GetCredentials()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get credentials: %w", err)
-	}
-	if creds == nil {
-		return nil, fmt.Errorf("no credentials configured")
-	}
-	return api.NewSignerFromPEM(creds.APIKeyID, creds.PrivateKey)
+	signer, _, err := resolveSigner()
+	return signer, err
 }
 
 func formatTimestamp() string {
-	return time.Now().Format("15:04:05")
+	return outputNow().Format("15:04:05")
 }
 
 
@@ -353,6 +488,8 @@ func formatVolume(vol int) string {
 // tickerHandler handles market ticker messages
 type tickerHandler struct {
 	format ui.OutputFormat
+	prices watchPrices
+	alerts *marketAlerts
 }
 
 func (h *tickerHandler) HandleMessage(msg websocket.Message) error {
@@ -361,7 +498,20 @@ func (h *tickerHandler) HandleMessage(msg websocket.Message) error {
 		return fmt.Errorf("failed to parse ticker data: %w", err)
 	}
 
-	return h.output(data)
+	if err := h.output(data); err != nil {
+		return err
+	}
+
+	if h.alerts != nil {
+		h.alerts.emit(h.alerts.observe(marketQuote{
+			ticker: data.Ticker,
+			bid:    data.YesBid,
+			ask:    data.YesAsk,
+			price:  data.YesPrice,
+			time:   time.Now(),
+		}))
+	}
+	return nil
 }
 
 func (h *tickerHandler) output(data websocket.TickerData) error {
@@ -369,17 +519,18 @@ func (h *tickerHandler) output(data websocket.TickerData) error {
 	case ui.FormatJSON:
 		return printJSONLine(data)
 	case ui.FormatPlain:
-		fmt.Printf("%s %s yes=%d no=%d vol=%d oi=%d\n",
-			formatTimestamp(), data.Ticker, data.YesPrice, data.NoPrice, data.Volume, data.OpenInterest)
+		fmt.Fprintf(ui.Writer(), "%s %s yes=%d no=%d vol=%d oi=%d%s\n",
+			formatTimestamp(), data.Ticker, data.YesPrice, data.NoPrice, data.Volume, data.OpenInterest,
+			h.prices.midField(data.YesBid, data.YesAsk))
 	default:
 		spread := ""
 		if data.YesBid > 0 && data.YesAsk > 0 {
-			spread = fmt.Sprintf("Yes %s / %s", formatCents(data.YesBid), formatCents(data.YesAsk))
+			spread = fmt.Sprintf("Yes %s / %s", h.prices.price(data.YesBid), h.prices.price(data.YesAsk))
 		} else {
-			spread = fmt.Sprintf("Yes %s", formatCents(data.YesPrice))
+			spread = fmt.Sprintf("Yes %s", h.prices.price(data.YesPrice))
 		}
-		fmt.Printf("[%s] %s: %s | Vol: %s\n",
-			formatTimestamp(), data.Ticker, spread, formatVolume(data.Volume))
+		fmt.Fprintf(ui.Writer(), "[%s] %s: %s%s | Vol: %s\n",
+			formatTimestamp(), data.Ticker, spread, h.prices.midSuffix(data.YesBid, data.YesAsk), formatVolume(data.Volume))
 	}
 	return nil
 }
@@ -387,6 +538,8 @@ func (h *tickerHandler) output(data websocket.TickerData) error {
 // orderbookHandler handles orderbook messages
 type orderbookHandler struct {
 	format ui.OutputFormat
+	prices watchPrices
+	alerts *marketAlerts
 }
 
 func (h *orderbookHandler) HandleMessage(msg websocket.Message) error {
@@ -395,7 +548,40 @@ func (h *orderbookHandler) HandleMessage(msg websocket.Message) error {
 		return fmt.Errorf("failed to parse orderbook data: %w", err)
 	}
 
-	return h.output(data)
+	if err := h.output(data); err != nil {
+		return err
+	}
+
+	if h.alerts != nil {
+		h.alerts.emit(h.alerts.observe(orderbookQuote(data, time.Now())))
+	}
+	return nil
+}
+
+// orderbookQuote summarizes an orderbook update for alerting. Depth is the
+// thinner side so the alert fires when either side dries up.
+func orderbookQuote(data websocket.OrderbookData, now time.Time) marketQuote {
+	q := marketQuote{ticker: data.Ticker, time: now}
+
+	bidDepth, askDepth := 0, 0
+	for _, l := range data.YesBids {
+		bidDepth += l.Quantity
+	}
+	for _, l := range data.YesAsks {
+		askDepth += l.Quantity
+	}
+	q.depth = min(bidDepth, askDepth)
+
+	if len(data.YesBids) > 0 {
+		q.bid = data.YesBids[0].Price
+	}
+	if len(data.YesAsks) > 0 {
+		q.ask = data.YesAsks[0].Price
+	}
+	if q.bid > 0 && q.ask > 0 {
+		q.price = (q.bid + q.ask) / 2
+	}
+	return q
 }
 
 func (h *orderbookHandler) output(data websocket.OrderbookData) error {
@@ -405,8 +591,8 @@ func (h *orderbookHandler) output(data websocket.OrderbookData) error {
 	case ui.FormatPlain:
 		bids := formatLevels(data.YesBids, 3)
 		asks := formatLevels(data.YesAsks, 3)
-		fmt.Printf("%s %s bids=[%s] asks=[%s]\n",
-			formatTimestamp(), data.Ticker, bids, asks)
+		fmt.Fprintf(ui.Writer(), "%s %s bids=[%s] asks=[%s]%s\n",
+			formatTimestamp(), data.Ticker, bids, asks, h.prices.midField(bestLevelPrice(data.YesBids), bestLevelPrice(data.YesAsks)))
 	default:
 		bestBid := "-"
 		bestAsk := "-"
@@ -414,24 +600,33 @@ func (h *orderbookHandler) output(data websocket.OrderbookData) error {
 		askDepth := 0
 
 		if len(data.YesBids) > 0 {
-			bestBid = formatCents(data.YesBids[0].Price)
+			bestBid = h.prices.price(data.YesBids[0].Price)
 			for _, l := range data.YesBids {
 				bidDepth += l.Quantity
 			}
 		}
 		if len(data.YesAsks) > 0 {
-			bestAsk = formatCents(data.YesAsks[0].Price)
+			bestAsk = h.prices.price(data.YesAsks[0].Price)
 			for _, l := range data.YesAsks {
 				askDepth += l.Quantity
 			}
 		}
 
-		fmt.Printf("[%s] %s: Bid %s (%d) | Ask %s (%d)\n",
-			formatTimestamp(), data.Ticker, bestBid, bidDepth, bestAsk, askDepth)
+		fmt.Fprintf(ui.Writer(), "[%s] %s: Bid %s (%d) | Ask %s (%d)%s\n",
+			formatTimestamp(), data.Ticker, bestBid, bidDepth, bestAsk, askDepth,
+			h.prices.midSuffix(bestLevelPrice(data.YesBids), bestLevelPrice(data.YesAsks)))
 	}
 	return nil
 }
 
+// bestLevelPrice is the price of the first level, or 0 for an empty side
+func bestLevelPrice(levels []websocket.OrderbookLevel) int {
+	if len(levels) == 0 {
+		return 0
+	}
+	return levels[0].Price
+}
+
 func formatLevels(levels []websocket.OrderbookLevel, max int) string {
 	if len(levels) == 0 {
 		return "-"
@@ -452,7 +647,11 @@ func formatLevels(levels []websocket.OrderbookLevel, max int) string {
 // tradesHandler handles public trades messages
 type tradesHandler struct {
 	format       ui.OutputFormat
+	prices       watchPrices
+	tape         *tradeTape
 	filterTicker string
+	anomalies    *anomalyDetector
+	firehose     *tradeFirehose
 }
 
 func (h *tradesHandler) HandleMessage(msg websocket.Message) error {
@@ -465,25 +664,29 @@ func (h *tradesHandler) HandleMessage(msg websocket.Message) error {
 		return nil
 	}
 
-	return h.output(data)
+	volume, vwap := h.tape.observe(data)
+	if h.firehose == nil || h.firehose.observe(data, time.Now()) {
+		if err := h.output(data, volume, vwap); err != nil {
+			return err
+		}
+	}
+
+	if h.anomalies != nil {
+		emitWatchAlerts(h.anomalies.notifier, h.anomalies.observe(data, time.Now()))
+	}
+	return nil
 }
 
-func (h *tradesHandler) output(data websocket.TradeData) error {
+func (h *tradesHandler) output(data websocket.TradeData, volume int, vwap float64) error {
 	switch h.format {
 	case ui.FormatJSON:
 		return printJSONLine(data)
 	case ui.FormatPlain:
-		fmt.Printf("%s %s %s price=%d count=%d\n",
-			formatTimestamp(), data.Ticker, data.TakerSide, data.Price, data.Count)
+		fmt.Fprintf(ui.Writer(), "%s %s %s price=%d count=%d%s\n",
+			formatTimestamp(), data.Ticker, data.TakerSide, data.Price, data.Count, h.tape.plainFields(volume, vwap))
 	default:
-		side := data.TakerSide
-		if side == "yes" {
-			side = ui.PriceUpStyle.Render("BUY")
-		} else {
-			side = ui.PriceDownStyle.Render("SELL")
-		}
-		fmt.Printf("[%s] %s: %s %d @ %s\n",
-			formatTimestamp(), data.Ticker, side, data.Count, formatCents(data.Price))
+		fmt.Fprintf(ui.Writer(), "[%s] %s: %s\n",
+			formatTimestamp(), data.Ticker, h.tape.tableLine(data, volume, vwap, h.prices))
 	}
 	return nil
 }
@@ -508,7 +711,7 @@ func (h *ordersHandler) output(data websocket.OrderUpdateData) error {
 		return printJSONLine(data)
 	case ui.FormatPlain:
 		orderID := truncateID(data.OrderID, 8)
-		fmt.Printf("%s order=%s ticker=%s status=%s side=%s action=%s qty=%d/%d\n",
+		fmt.Fprintf(ui.Writer(), "%s order=%s ticker=%s status=%s side=%s action=%s qty=%d/%d\n",
 			formatTimestamp(), orderID, data.Ticker, data.Status,
 			data.Side, data.Action, data.FilledQuantity, data.InitialQuantity)
 	default:
@@ -518,7 +721,7 @@ func (h *ordersHandler) output(data websocket.OrderUpdateData) error {
 		if data.Side == "no" {
 			price = data.NoPrice
 		}
-		fmt.Printf("[%s] Order %s: %s %s %s @ %s | %s (%d/%d filled)\n",
+		fmt.Fprintf(ui.Writer(), "[%s] Order %s: %s %s %s @ %s | %s (%d/%d filled)\n",
 			formatTimestamp(), orderID, strings.ToUpper(data.Action),
 			data.Ticker, strings.ToUpper(data.Side), formatCents(price),
 			status, data.FilledQuantity, data.InitialQuantity)
@@ -543,7 +746,11 @@ func formatOrderStatus(status string) string {
 
 // fillsHandler handles user fill messages
 type fillsHandler struct {
-	format ui.OutputFormat
+	format      ui.OutputFormat
+	store       *fillstore.Store
+	dedupe      bool
+	persist     bool
+	environment string
 }
 
 func (h *fillsHandler) HandleMessage(msg websocket.Message) error {
@@ -552,6 +759,9 @@ func (h *fillsHandler) HandleMessage(msg websocket.Message) error {
 		return fmt.Errorf("failed to parse fill data: %w", err)
 	}
 
+	if !h.admit(data, msg.Data) {
+		return nil
+	}
 	return h.output(data)
 }
 
@@ -562,7 +772,7 @@ func (h *fillsHandler) output(data websocket.FillData) error {
 	case ui.FormatPlain:
 		fillID := truncateID(data.FillID, 8)
 		orderID := truncateID(data.OrderID, 8)
-		fmt.Printf("%s fill=%s order=%s ticker=%s side=%s action=%s price=%d count=%d taker=%v\n",
+		fmt.Fprintf(ui.Writer(), "%s fill=%s order=%s ticker=%s side=%s action=%s price=%d count=%d taker=%v\n",
 			formatTimestamp(), fillID, orderID, data.Ticker,
 			data.Side, data.Action, data.YesPrice, data.Count, data.IsTaker)
 	default:
@@ -574,7 +784,7 @@ func (h *fillsHandler) output(data websocket.FillData) error {
 		if data.Side == "no" {
 			price = data.NoPrice
 		}
-		fmt.Printf("[%s] FILL: %s %s %s @ %s x%d (%s)\n",
+		fmt.Fprintf(ui.Writer(), "[%s] FILL: %s %s %s @ %s x%d (%s)\n",
 			formatTimestamp(), strings.ToUpper(data.Action), data.Ticker,
 			strings.ToUpper(data.Side), formatCents(price), data.Count, takerMaker)
 	}
@@ -589,17 +799,18 @@ func truncateID(id string, length int) string {
 }
 
 func printJSONLine(v interface{}) error {
-	data, err := json.Marshal(v)
+	data, err := json.Marshal(envelop(v))
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(ui.Writer(), string(data))
 	return nil
 }
 
 // tickerV2Handler handles market_ticker_v2 incremental delta messages
 type tickerV2Handler struct {
 	format ui.OutputFormat
+	prices watchPrices
 }
 
 func (h *tickerV2Handler) HandleMessage(msg websocket.Message) error {
@@ -616,12 +827,12 @@ func (h *tickerV2Handler) output(data websocket.TickerV2Data) error {
 	case ui.FormatJSON:
 		return printJSONLine(data)
 	case ui.FormatPlain:
-		fmt.Printf("%s %s delta_type=%s yes=%d no=%d delta=%d\n",
+		fmt.Fprintf(ui.Writer(), "%s %s delta_type=%s yes=%d no=%d delta=%d\n",
 			formatTimestamp(), data.Ticker, data.DeltaType, data.YesPrice, data.NoPrice, data.Delta)
 	default:
-		fmt.Printf("[%s] %s: %s (delta: %+d) Yes %s / No %s\n",
+		fmt.Fprintf(ui.Writer(), "[%s] %s: %s (delta: %+d) Yes %s / No %s\n",
 			formatTimestamp(), data.Ticker, data.DeltaType, data.Delta,
-			formatCents(data.YesPrice), formatCents(data.NoPrice))
+			h.prices.price(data.YesPrice), h.prices.price(data.NoPrice))
 	}
 	return nil
 }
@@ -645,7 +856,7 @@ func (h *positionsHandler) output(data websocket.PositionData) error {
 	case ui.FormatJSON:
 		return printJSONLine(data)
 	case ui.FormatPlain:
-		fmt.Printf("%s ticker=%s position=%d cost=%d pnl=%d exposure=%d\n",
+		fmt.Fprintf(ui.Writer(), "%s ticker=%s position=%d cost=%d pnl=%d exposure=%d\n",
 			formatTimestamp(), data.Ticker, data.Position, data.TotalCost, data.RealizedPnl, data.Exposure)
 	default:
 		pnlStyle := ui.MutedStyle
@@ -654,7 +865,7 @@ func (h *positionsHandler) output(data websocket.PositionData) error {
 		} else if data.RealizedPnl < 0 {
 			pnlStyle = ui.PriceDownStyle
 		}
-		fmt.Printf("[%s] %s: Position %d | Cost %s | PnL %s | Exposure %s\n",
+		fmt.Fprintf(ui.Writer(), "[%s] %s: Position %d | Cost %s | PnL %s | Exposure %s\n",
 			formatTimestamp(), data.Ticker, data.Position,
 			formatCents(data.TotalCost), pnlStyle.Render(formatCents(data.RealizedPnl)),
 			formatCents(data.Exposure))
@@ -681,10 +892,10 @@ func (h *lifecycleHandler) output(data websocket.MarketLifecycleData) error {
 	case ui.FormatJSON:
 		return printJSONLine(data)
 	case ui.FormatPlain:
-		fmt.Printf("%s ticker=%s status=%s old_status=%s\n",
+		fmt.Fprintf(ui.Writer(), "%s ticker=%s status=%s old_status=%s\n",
 			formatTimestamp(), data.Ticker, data.Status, data.OldStatus)
 	default:
-		fmt.Printf("[%s] %s: %s -> %s\n",
+		fmt.Fprintf(ui.Writer(), "[%s] %s: %s -> %s\n",
 			formatTimestamp(), data.Ticker, data.OldStatus, data.Status)
 	}
 	return nil
@@ -709,10 +920,10 @@ func (h *orderGroupHandler) output(data websocket.OrderGroupUpdateData) error {
 	case ui.FormatJSON:
 		return printJSONLine(data)
 	case ui.FormatPlain:
-		fmt.Printf("%s order_group=%s status=%s total=%d filled=%d\n",
+		fmt.Fprintf(ui.Writer(), "%s order_group=%s status=%s total=%d filled=%d\n",
 			formatTimestamp(), data.OrderGroupID, data.Status, data.TotalOrders, data.FilledOrders)
 	default:
-		fmt.Printf("[%s] Order Group %s: %s (%d/%d filled)\n",
+		fmt.Fprintf(ui.Writer(), "[%s] Order Group %s: %s (%d/%d filled)\n",
 			formatTimestamp(), truncateID(data.OrderGroupID, 8), data.Status,
 			data.FilledOrders, data.TotalOrders)
 	}
@@ -738,10 +949,10 @@ func (h *communicationsHandler) output(data websocket.CommunicationData) error {
 	case ui.FormatJSON:
 		return printJSONLine(data)
 	case ui.FormatPlain:
-		fmt.Printf("%s type=%s ticker=%s qty=%d price=%d side=%s\n",
+		fmt.Fprintf(ui.Writer(), "%s type=%s ticker=%s qty=%d price=%d side=%s\n",
 			formatTimestamp(), data.Type, data.Ticker, data.Quantity, data.Price, data.Side)
 	default:
-		fmt.Printf("[%s] %s: %s %s %d @ %s\n",
+		fmt.Fprintf(ui.Writer(), "[%s] %s: %s %s %d @ %s\n",
 			formatTimestamp(), strings.ToUpper(data.Type), data.Ticker,
 			strings.ToUpper(data.Side), data.Quantity, formatCents(data.Price))
 	}
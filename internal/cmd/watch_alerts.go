@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/notify"
+)
+
+var (
+	watchAlertSpreadAbove int
+	watchAlertDepthBelow  int
+	watchAlertMove        int
+	watchAlertWindow      time.Duration
+	watchAlertNotify      bool
+)
+
+func init() {
+	watchTickerCmd.Flags().IntVar(&watchAlertSpreadAbove, "alert-spread-above", 0, "alert when the yes bid/ask spread widens above N cents")
+	watchTickerCmd.Flags().IntVar(&watchAlertMove, "alert-move", 0, "alert when the price moves N cents or more within --alert-window")
+	watchTickerCmd.Flags().DurationVar(&watchAlertWindow, "alert-window", time.Minute, "time window for --alert-move")
+	watchTickerCmd.Flags().BoolVar(&watchAlertNotify, "notify", false, "also deliver alerts to the destinations configured under alerts.*")
+
+	watchOrderbookCmd.Flags().IntVar(&watchAlertSpreadAbove, "alert-spread-above", 0, "alert when the yes bid/ask spread widens above N cents")
+	watchOrderbookCmd.Flags().IntVar(&watchAlertDepthBelow, "alert-depth-below", 0, "alert when bid or ask depth drops below N contracts")
+	watchOrderbookCmd.Flags().IntVar(&watchAlertMove, "alert-move", 0, "alert when the mid price moves N cents or more within --alert-window")
+	watchOrderbookCmd.Flags().DurationVar(&watchAlertWindow, "alert-window", time.Minute, "time window for --alert-move")
+	watchOrderbookCmd.Flags().BoolVar(&watchAlertNotify, "notify", false, "also deliver alerts to the destinations configured under alerts.*")
+}
+
+// validateMarketAlertFlags checks the --alert-* flags of watch ticker/orderbook
+func validateMarketAlertFlags() error {
+	if watchAlertSpreadAbove < 0 || watchAlertDepthBelow < 0 || watchAlertMove < 0 {
+		return fmt.Errorf("--alert-* thresholds must be zero or positive")
+	}
+	if watchAlertMove > 0 && watchAlertWindow <= 0 {
+		return fmt.Errorf("--alert-window must be positive")
+	}
+	return nil
+}
+
+// marketAlerts evaluates spread, depth and price-move thresholds per market.
+// Each alert fires once per crossing and re-arms once the condition clears.
+type marketAlerts struct {
+	spreadAbove int
+	depthBelow  int
+	move        int
+	window      time.Duration
+	notifier    notify.Notifier
+
+	mu      sync.Mutex
+	markets map[string]*marketAlertState
+}
+
+type marketAlertState struct {
+	spread thresholdTrigger
+	depth  thresholdTrigger
+	prices []pricePoint
+}
+
+type pricePoint struct {
+	at    time.Time
+	price int
+}
+
+// marketQuote is the part of a ticker or orderbook update the alerts look at.
+// Zero prices are unknown and skipped.
+type marketQuote struct {
+	ticker string
+	bid    int
+	ask    int
+	price  int
+	depth  int
+	time   time.Time
+}
+
+// newMarketAlertsFromFlags returns nil when no --alert-* flag is set
+func newMarketAlertsFromFlags() *marketAlerts {
+	if watchAlertSpreadAbove == 0 && watchAlertDepthBelow == 0 && watchAlertMove == 0 {
+		return nil
+	}
+
+	alerts := &marketAlerts{
+		spreadAbove: watchAlertSpreadAbove,
+		depthBelow:  watchAlertDepthBelow,
+		move:        watchAlertMove,
+		window:      watchAlertWindow,
+		notifier:    notify.Multi{},
+		markets:     make(map[string]*marketAlertState),
+	}
+	if watchAlertNotify {
+		alerts.notifier = buildNotifier()
+	}
+	return alerts
+}
+
+// observe returns the alerts triggered by a quote
+func (a *marketAlerts) observe(q marketQuote) []notify.Message {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.markets[q.ticker]
+	if !ok {
+		// The spread trigger watches the negated spread so that the
+		// fire-below thresholdTrigger fires when the spread is above the limit
+		state = &marketAlertState{
+			spread: thresholdTrigger{threshold: -a.spreadAbove},
+			depth:  thresholdTrigger{threshold: a.depthBelow},
+		}
+		a.markets[q.ticker] = state
+	}
+
+	var alerts []notify.Message
+	newAlert := func(title, body string, data map[string]any) {
+		data["ticker"] = q.ticker
+		alerts = append(alerts, notify.Message{
+			Title: title,
+			Body:  fmt.Sprintf("%s: %s", q.ticker, body),
+			Time:  q.time.UTC(),
+			Data:  data,
+		})
+	}
+
+	if a.spreadAbove > 0 && q.bid > 0 && q.ask > 0 {
+		spread := q.ask - q.bid
+		if state.spread.observe(-spread) {
+			newAlert("Spread widened",
+				fmt.Sprintf("spread %d¢ is above %d¢ (bid %s / ask %s)", spread, a.spreadAbove, formatCents(q.bid), formatCents(q.ask)),
+				map[string]any{"alert": "spread", "spread": spread, "threshold": a.spreadAbove})
+		}
+	}
+
+	if a.depthBelow > 0 {
+		if state.depth.observe(q.depth) {
+			newAlert("Liquidity low",
+				fmt.Sprintf("depth %d is below %d contracts", q.depth, a.depthBelow),
+				map[string]any{"alert": "depth", "depth": q.depth, "threshold": a.depthBelow})
+		}
+	}
+
+	if a.move > 0 && q.price > 0 {
+		if from, moved := state.recordPrice(q.time, q.price, a.window, a.move); moved {
+			newAlert("Price moved",
+				fmt.Sprintf("price moved %+d¢ (%s -> %s) within %s", q.price-from, formatCents(from), formatCents(q.price), a.window),
+				map[string]any{"alert": "move", "from": from, "to": q.price, "threshold": a.move, "window": a.window.String()})
+		}
+	}
+
+	return alerts
+}
+
+// recordPrice adds a price to the rolling window and reports whether it is at
+// least move cents away from any price in the window. The window restarts
+// after a move so a single jump alerts once.
+func (s *marketAlertState) recordPrice(now time.Time, price int, window time.Duration, move int) (int, bool) {
+	cutoff := now.Add(-window)
+	kept := s.prices[:0]
+	for _, p := range s.prices {
+		if !p.at.Before(cutoff) {
+			kept = append(kept, p)
+		}
+	}
+	s.prices = kept
+
+	for _, p := range s.prices {
+		if abs(price-p.price) >= move {
+			s.prices = []pricePoint{{at: now, price: price}}
+			return p.price, true
+		}
+	}
+
+	s.prices = append(s.prices, pricePoint{at: now, price: price})
+	return 0, false
+}
+
+// emit prints triggered alerts and delivers them to the notifier
+func (a *marketAlerts) emit(alerts []notify.Message) {
+	emitWatchAlerts(a.notifier, alerts)
+}
+
+// emitWatchAlerts prints alerts inline and delivers them in the background so
+// a slow webhook never stalls the stream
+func emitWatchAlerts(notifier notify.Notifier, alerts []notify.Message) {
+	for _, msg := range alerts {
+		emitAlert(context.Background(), notify.Multi{}, msg)
+
+		go func(msg notify.Message) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := notifier.Notify(ctx, msg); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to deliver alert: %v\n", err)
+			}
+		}(msg)
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/notify"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func newTestMarketAlerts(spread, depth, move int, window time.Duration) *marketAlerts {
+	return &marketAlerts{
+		spreadAbove: spread,
+		depthBelow:  depth,
+		move:        move,
+		window:      window,
+		notifier:    notify.Multi{},
+		markets:     make(map[string]*marketAlertState),
+	}
+}
+
+func alertKinds(msgs []notify.Message) []string {
+	kinds := make([]string, len(msgs))
+	for i, m := range msgs {
+		kinds[i] = m.Data["alert"].(string)
+	}
+	return kinds
+}
+
+func TestMarketAlertsSpread(t *testing.T) {
+	alerts := newTestMarketAlerts(4, 0, 0, 0)
+	now := time.Now()
+
+	steps := []struct {
+		bid, ask int
+		fires    bool
+	}{
+		{bid: 40, ask: 43, fires: false},
+		{bid: 40, ask: 45, fires: true},
+		{bid: 40, ask: 46, fires: false}, // still wide, already fired
+		{bid: 40, ask: 44, fires: false}, // back at threshold, re-arms
+		{bid: 39, ask: 44, fires: true},
+	}
+
+	for i, step := range steps {
+		got := alerts.observe(marketQuote{ticker: "MKT", bid: step.bid, ask: step.ask, time: now})
+		if (len(got) == 1) != step.fires {
+			t.Errorf("step %d: got alerts %v, expected fire=%v", i, alertKinds(got), step.fires)
+		}
+	}
+}
+
+func TestMarketAlertsMove(t *testing.T) {
+	alerts := newTestMarketAlerts(0, 0, 5, time.Minute)
+	start := time.Now()
+
+	steps := []struct {
+		offset time.Duration
+		price  int
+		fires  bool
+	}{
+		{offset: 0, price: 50, fires: false},
+		{offset: 10 * time.Second, price: 53, fires: false},
+		{offset: 20 * time.Second, price: 55, fires: true},
+		{offset: 30 * time.Second, price: 56, fires: false}, // window restarted at 55
+		{offset: 3 * time.Minute, price: 61, fires: false},  // earlier prices expired
+	}
+
+	for i, step := range steps {
+		got := alerts.observe(marketQuote{ticker: "MKT", price: step.price, time: start.Add(step.offset)})
+		if (len(got) == 1) != step.fires {
+			t.Errorf("step %d: got alerts %v, expected fire=%v", i, alertKinds(got), step.fires)
+		}
+	}
+}
+
+func TestOrderbookQuoteDepth(t *testing.T) {
+	data := websocket.OrderbookData{
+		Ticker:  "MKT",
+		YesBids: []websocket.OrderbookLevel{{Price: 40, Quantity: 100}, {Price: 39, Quantity: 50}},
+		YesAsks: []websocket.OrderbookLevel{{Price: 44, Quantity: 20}},
+	}
+
+	q := orderbookQuote(data, time.Now())
+	if q.bid != 40 || q.ask != 44 || q.price != 42 || q.depth != 20 {
+		t.Errorf("unexpected quote: %+v", q)
+	}
+
+	alerts := newTestMarketAlerts(0, 25, 0, 0)
+	if got := alertKinds(alerts.observe(q)); len(got) != 1 || got[0] != "depth" {
+		t.Errorf("expected depth alert, got %v", got)
+	}
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/notify"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var (
+	watchDetectAnomalies bool
+	watchAnomalyZ        float64
+	watchAnomalyWindow   int
+	watchAnomalyNotify   bool
+)
+
+// minAnomalySamples is the history a market needs before prints are scored
+const minAnomalySamples = 20
+
+func init() {
+	watchTradesCmd.Flags().BoolVar(&watchDetectAnomalies, "detect-anomalies", false, "flag unusually large prints and price jumps per market")
+	watchTradesCmd.Flags().Float64Var(&watchAnomalyZ, "anomaly-z", 3, "z-score at or above which a print is flagged")
+	watchTradesCmd.Flags().IntVar(&watchAnomalyWindow, "anomaly-window", 100, "number of recent trades per market used for the rolling statistics")
+	watchTradesCmd.Flags().BoolVar(&watchAnomalyNotify, "notify", false, "also deliver anomalies to the destinations configured under alerts.*")
+}
+
+// validateAnomalyFlags checks the anomaly detection flags of watch trades
+func validateAnomalyFlags() error {
+	if !watchDetectAnomalies {
+		return nil
+	}
+	if watchAnomalyZ <= 0 {
+		return fmt.Errorf("--anomaly-z must be positive")
+	}
+	if watchAnomalyWindow < minAnomalySamples {
+		return fmt.Errorf("--anomaly-window must be at least %d trades", minAnomalySamples)
+	}
+	return nil
+}
+
+// rollingStats keeps the mean and standard deviation of the last n values
+type rollingStats struct {
+	values []float64
+	next   int
+	full   bool
+}
+
+func newRollingStats(n int) *rollingStats {
+	return &rollingStats{values: make([]float64, n)}
+}
+
+func (r *rollingStats) add(v float64) {
+	r.values[r.next] = v
+	r.next = (r.next + 1) % len(r.values)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *rollingStats) len() int {
+	if r.full {
+		return len(r.values)
+	}
+	return r.next
+}
+
+// zscore returns how many standard deviations v is from the window mean.
+// A flat window scores 0 so identical prints never divide by zero.
+func (r *rollingStats) zscore(v float64) float64 {
+	n := r.len()
+	if n == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, x := range r.values[:n] {
+		sum += x
+	}
+	mean := sum / float64(n)
+
+	var sq float64
+	for _, x := range r.values[:n] {
+		sq += (x - mean) * (x - mean)
+	}
+	stddev := math.Sqrt(sq / float64(n))
+	if stddev == 0 {
+		return 0
+	}
+	return (v - mean) / stddev
+}
+
+// anomalyDetector scores each trade against per-market rolling statistics of
+// trade size and price change
+type anomalyDetector struct {
+	threshold float64
+	window    int
+	notifier  notify.Notifier
+
+	mu      sync.Mutex
+	markets map[string]*marketTradeStats
+}
+
+type marketTradeStats struct {
+	sizes     *rollingStats
+	moves     *rollingStats
+	lastPrice int
+}
+
+func newAnomalyDetector(threshold float64, window int, notifier notify.Notifier) *anomalyDetector {
+	return &anomalyDetector{
+		threshold: threshold,
+		window:    window,
+		notifier:  notifier,
+		markets:   make(map[string]*marketTradeStats),
+	}
+}
+
+// newAnomalyDetectorFromFlags returns nil unless --detect-anomalies is set
+func newAnomalyDetectorFromFlags() *anomalyDetector {
+	if !watchDetectAnomalies {
+		return nil
+	}
+	var notifier notify.Notifier = notify.Multi{}
+	if watchAnomalyNotify {
+		notifier = buildNotifier()
+	}
+	return newAnomalyDetector(watchAnomalyZ, watchAnomalyWindow, notifier)
+}
+
+// observe scores a trade, then adds it to the market's history
+func (d *anomalyDetector) observe(trade websocket.TradeData, now time.Time) []notify.Message {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats, ok := d.markets[trade.Ticker]
+	if !ok {
+		stats = &marketTradeStats{
+			sizes:     newRollingStats(d.window),
+			moves:     newRollingStats(d.window),
+			lastPrice: trade.Price,
+		}
+		d.markets[trade.Ticker] = stats
+	}
+
+	size := float64(trade.Count)
+	move := float64(trade.Price - stats.lastPrice)
+
+	var anomalies []notify.Message
+	if stats.sizes.len() >= minAnomalySamples {
+		if z := stats.sizes.zscore(size); z >= d.threshold {
+			anomalies = append(anomalies, notify.Message{
+				Title: "Large block trade",
+				Body:  fmt.Sprintf("%s: %d contracts @ %s (volume z=%.1f)", trade.Ticker, trade.Count, formatCents(trade.Price), z),
+				Time:  now.UTC(),
+				Data: map[string]any{
+					"anomaly": "volume", "ticker": trade.Ticker, "trade_id": trade.TradeID,
+					"count": trade.Count, "price": trade.Price, "zscore": round1(z),
+				},
+			})
+		}
+	}
+	if stats.moves.len() >= minAnomalySamples {
+		if z := stats.moves.zscore(move); math.Abs(z) >= d.threshold {
+			anomalies = append(anomalies, notify.Message{
+				Title: "Price jump",
+				Body: fmt.Sprintf("%s: %s -> %s (%+d¢, price z=%.1f)", trade.Ticker,
+					formatCents(stats.lastPrice), formatCents(trade.Price), trade.Price-stats.lastPrice, z),
+				Time: now.UTC(),
+				Data: map[string]any{
+					"anomaly": "price", "ticker": trade.Ticker, "trade_id": trade.TradeID,
+					"from": stats.lastPrice, "price": trade.Price, "zscore": round1(z),
+				},
+			})
+		}
+	}
+
+	stats.sizes.add(size)
+	stats.moves.add(move)
+	stats.lastPrice = trade.Price
+
+	return anomalies
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
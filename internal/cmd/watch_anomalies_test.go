@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/notify"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestRollingStatsZScore(t *testing.T) {
+	stats := newRollingStats(4)
+	for _, v := range []float64{100, 2, 4, 4, 6} { // 100 is evicted
+		stats.add(v)
+	}
+
+	if stats.len() != 4 {
+		t.Fatalf("len = %d, expected 4", stats.len())
+	}
+	// mean 4, population stddev sqrt(2)
+	if z := stats.zscore(8); math.Abs(z-4/math.Sqrt2) > 1e-9 {
+		t.Errorf("zscore(8) = %v, expected %v", z, 4/math.Sqrt2)
+	}
+
+	flat := newRollingStats(3)
+	flat.add(5)
+	flat.add(5)
+	if z := flat.zscore(50); z != 0 {
+		t.Errorf("flat window zscore = %v, expected 0", z)
+	}
+}
+
+func TestAnomalyDetector(t *testing.T) {
+	detector := newAnomalyDetector(3, 50, notify.Multi{})
+	now := time.Now()
+
+	for i := 0; i < minAnomalySamples; i++ {
+		trade := websocket.TradeData{Ticker: "MKT", Price: 50 + i%2, Count: 10 + i%3}
+		if got := detector.observe(trade, now); len(got) != 0 {
+			t.Fatalf("trade %d flagged during warm-up: %v", i, got)
+		}
+	}
+
+	got := detector.observe(websocket.TradeData{Ticker: "MKT", Price: 51, Count: 500}, now)
+	if len(got) != 1 || got[0].Data["anomaly"] != "volume" {
+		t.Errorf("expected a volume anomaly, got %v", got)
+	}
+
+	got = detector.observe(websocket.TradeData{Ticker: "MKT", Price: 70, Count: 10}, now)
+	if len(got) != 1 || got[0].Data["anomaly"] != "price" {
+		t.Errorf("expected a price anomaly, got %v", got)
+	}
+
+	// Other markets keep their own history
+	if got := detector.observe(websocket.TradeData{Ticker: "OTHER", Price: 10, Count: 5000}, now); len(got) != 0 {
+		t.Errorf("new market flagged without history: %v", got)
+	}
+}
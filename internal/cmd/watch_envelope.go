@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+// jsonEnvelope wraps a watch JSON line so several streams can be merged
+type jsonEnvelope struct {
+	ReceivedAt time.Time         `json:"received_at"`
+	Channel    websocket.Channel `json:"channel"`
+	Seq        int64             `json:"seq"`
+	Data       interface{}       `json:"data"`
+}
+
+// activeEnvelope is the envelope of the message being handled; lines printed
+// by printJSONLine while it is set are wrapped in it
+var activeEnvelope atomic.Pointer[jsonEnvelope]
+
+// validateEnvelopeFlag rejects --envelope without JSON output
+func validateEnvelopeFlag() error {
+	if envelope && GetOutputFormat() != ui.FormatJSON {
+		return fmt.Errorf("--envelope requires --json")
+	}
+	return nil
+}
+
+// envelopeHandler stamps each message with its receive time, channel and a
+// sequence number shared by every channel of the session
+type envelopeHandler struct {
+	next    websocket.Handler
+	channel websocket.Channel
+	seq     *atomic.Int64
+}
+
+func (h *envelopeHandler) HandleMessage(msg websocket.Message) error {
+	activeEnvelope.Store(&jsonEnvelope{
+		ReceivedAt: outputNow().UTC(),
+		Channel:    h.channel,
+		Seq:        h.seq.Add(1),
+	})
+	defer activeEnvelope.Store(nil)
+	return h.next.HandleMessage(msg)
+}
+
+// envelop wraps v in the active envelope, if any
+func envelop(v interface{}) interface{} {
+	env := activeEnvelope.Load()
+	if env == nil {
+		return v
+	}
+	wrapped := *env
+	wrapped.Data = v
+	return wrapped
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestEnvelopeHandler_WrapsLinesWithSharedSeq(t *testing.T) {
+	var seq atomic.Int64
+	var lines [][]byte
+	capture := func(ch websocket.Channel) websocket.Handler {
+		inner := websocket.HandlerFunc(func(msg websocket.Message) error {
+			data, err := json.Marshal(envelop(map[string]string{"ticker": "A"}))
+			lines = append(lines, data)
+			return err
+		})
+		return &envelopeHandler{next: inner, channel: ch, seq: &seq}
+	}
+
+	capture(websocket.ChannelMarketTicker).HandleMessage(websocket.Message{})
+	capture(websocket.ChannelPublicTrades).HandleMessage(websocket.Message{})
+
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		var env struct {
+			ReceivedAt string            `json:"received_at"`
+			Channel    string            `json:"channel"`
+			Seq        int64             `json:"seq"`
+			Data       map[string]string `json:"data"`
+		}
+		if err := json.Unmarshal(line, &env); err != nil {
+			t.Fatalf("line %d is not an envelope: %v", i, err)
+		}
+		if env.Seq != int64(i+1) || env.ReceivedAt == "" || env.Data["ticker"] != "A" {
+			t.Errorf("unexpected envelope %s", line)
+		}
+	}
+	if string(lines[1]) == string(lines[0]) {
+		t.Error("expected distinct envelopes per message")
+	}
+
+	if _, ok := envelop("summary").(string); !ok {
+		t.Error("expected lines outside a message to be left unwrapped")
+	}
+}
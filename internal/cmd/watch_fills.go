@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+	"github.com/6missedcalls/kalshi-cli/internal/fillstore"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var (
+	watchFillsDedupe  bool
+	watchFillsPersist bool
+	watchFillsStore   string
+)
+
+// activeFillStore is the fill store of the running watch fills, if any
+var activeFillStore *fillstore.Store
+
+func init() {
+	watchFillsCmd.Flags().BoolVar(&watchFillsDedupe, "dedupe", false, "skip fills whose ID was already seen, including in earlier runs recorded with --persist")
+	watchFillsCmd.Flags().BoolVar(&watchFillsPersist, "persist", false, "append each new fill to the local fill store, synced to disk before it is printed")
+	watchFillsCmd.Flags().StringVar(&watchFillsStore, "store", "", "fill store file (default: fills.jsonl in the data directory)")
+}
+
+// openFillStore opens the fill store for --dedupe or --persist. It returns
+// nil when neither is set.
+func openFillStore() (*fillstore.Store, error) {
+	if !watchFillsDedupe && !watchFillsPersist {
+		if watchFillsStore != "" {
+			return nil, fmt.Errorf("--store requires --dedupe or --persist")
+		}
+		return nil, nil
+	}
+
+	path := watchFillsStore
+	if path == "" {
+		dir, err := config.DataDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get data directory: %w", err)
+		}
+		path = fillstore.DefaultPath(dir)
+	}
+	return fillstore.Open(path)
+}
+
+// newFillsHandler returns the watch fills handler, using the open fill store
+func newFillsHandler(format ui.OutputFormat) *fillsHandler {
+	h := &fillsHandler{format: format}
+	if activeFillStore != nil {
+		h.store = activeFillStore
+		h.dedupe = watchFillsDedupe
+		h.persist = watchFillsPersist
+		h.environment = GetConfig().Environment()
+	}
+	return h
+}
+
+// fillID is the key fills are deduplicated on
+func fillID(data websocket.FillData) string {
+	if data.FillID != "" {
+		return data.FillID
+	}
+	return data.TradeID
+}
+
+// admit applies --dedupe and --persist to a fill and reports whether it
+// should be printed. A fill that cannot be persisted is still printed, with
+// the error on stderr, and is not marked seen.
+func (h *fillsHandler) admit(data websocket.FillData, raw json.RawMessage) bool {
+	if h.store == nil {
+		return true
+	}
+	id := fillID(data)
+	if id == "" {
+		return true
+	}
+	if h.store.Seen(id) {
+		return !h.dedupe
+	}
+
+	if !h.persist {
+		h.store.Mark(id)
+		return true
+	}
+	err := h.store.Append(fillstore.Record{
+		ID:          id,
+		Environment: h.environment,
+		ReceivedAt:  time.Now().UTC(),
+		Fill:        raw,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: fill %s was not persisted: %v\n", id, err)
+	}
+	return true
+}
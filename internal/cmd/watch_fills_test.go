@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/fillstore"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestFillsHandlerAdmit_DedupeAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fills.jsonl")
+	fill := websocket.FillData{FillID: "f1", Ticker: "MKT", Count: 3}
+	raw, _ := json.Marshal(fill)
+
+	store, err := fillstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	h := &fillsHandler{store: store, dedupe: true, persist: true, environment: "demo"}
+	if !h.admit(fill, raw) {
+		t.Error("first sighting of f1 should be admitted")
+	}
+	if h.admit(fill, raw) {
+		t.Error("repeat of f1 in the same session should be skipped")
+	}
+	store.Close()
+
+	restarted, err := fillstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer restarted.Close()
+	h = &fillsHandler{store: restarted, dedupe: true, persist: true}
+	if h.admit(fill, raw) {
+		t.Error("f1 should be skipped after a restart")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "\n"); n != 1 {
+		t.Errorf("store has %d lines, want 1", n)
+	}
+}
+
+func TestFillsHandlerAdmit_PersistWithoutDedupe(t *testing.T) {
+	store, err := fillstore.Open(filepath.Join(t.TempDir(), "fills.jsonl"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer store.Close()
+
+	fill := websocket.FillData{TradeID: "t1"}
+	h := &fillsHandler{store: store, persist: true}
+	if !h.admit(fill, json.RawMessage(`{}`)) || !h.admit(fill, json.RawMessage(`{}`)) {
+		t.Error("without --dedupe every fill should be printed")
+	}
+	if store.Len() != 1 {
+		t.Errorf("store has %d fills, want 1", store.Len())
+	}
+}
+
+func TestOpenFillStore_StoreRequiresMode(t *testing.T) {
+	defer func() { watchFillsDedupe, watchFillsPersist, watchFillsStore = false, false, "" }()
+
+	watchFillsStore = filepath.Join(t.TempDir(), "fills.jsonl")
+	if _, err := openFillStore(); err == nil {
+		t.Error("--store without --dedupe or --persist should fail")
+	}
+
+	watchFillsDedupe = true
+	store, err := openFillStore()
+	if err != nil || store == nil {
+		t.Fatalf("openFillStore() = %v, %v; want a store", store, err)
+	}
+}
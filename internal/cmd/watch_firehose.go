@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var (
+	watchSample         string
+	watchTopMarkets     int
+	watchTradesSummary  bool
+	watchSummaryRefresh time.Duration
+)
+
+// firehoseWindow is how far back market activity is measured for
+// --top-markets and the summary's recent volume
+const firehoseWindow = 5 * time.Minute
+
+// firehoseRerank is how often the most active markets are recomputed
+const firehoseRerank = time.Second
+
+func init() {
+	watchTradesCmd.Flags().StringVar(&watchSample, "sample", "", "print one in every N trades, e.g. 1/10")
+	watchTradesCmd.Flags().IntVar(&watchTopMarkets, "top-markets", 0, "only show the N markets with the most volume in the last 5 minutes (0 = all)")
+	watchTradesCmd.Flags().BoolVar(&watchTradesSummary, "summary", false, "show one line per market, redrawn every --refresh, instead of each trade")
+	watchTradesCmd.Flags().DurationVar(&watchSummaryRefresh, "refresh", 5*time.Second, "with --summary, how often to redraw")
+}
+
+// parseSample parses a --sample rate of the form 1/N, returning N
+func parseSample(s string) (int, error) {
+	if s == "" {
+		return 1, nil
+	}
+	num, den, ok := strings.Cut(s, "/")
+	n, err := strconv.Atoi(den)
+	if !ok || num != "1" || err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid --sample %q: use 1/N, e.g. 1/10", s)
+	}
+	return n, nil
+}
+
+// validateFirehoseFlags checks the sampling and summary flags of watch trades
+func validateFirehoseFlags() error {
+	if _, err := parseSample(watchSample); err != nil {
+		return err
+	}
+	if watchTopMarkets < 0 {
+		return fmt.Errorf("--top-markets cannot be negative")
+	}
+	if watchTradesSummary {
+		if watchSample != "" {
+			return fmt.Errorf("--sample cannot be used with --summary")
+		}
+		if watchSummaryRefresh <= 0 {
+			return fmt.Errorf("--refresh must be positive")
+		}
+	}
+	return nil
+}
+
+// recentTrade is a print inside the activity window
+type recentTrade struct {
+	ticker string
+	count  int
+	at     time.Time
+}
+
+// tradeSummaryRow is one market's session activity on the trades feed
+type tradeSummaryRow struct {
+	Ticker string `json:"ticker"`
+	Trades int    `json:"trades"`
+	Volume int    `json:"volume"`
+	// RecentVolume is the volume over the last five minutes
+	RecentVolume int     `json:"recent_volume"`
+	Last         int     `json:"last"`
+	VWAP         float64 `json:"vwap"`
+	// BuyShare is the share of volume with a YES taker
+	BuyShare  float64   `json:"buy_share"`
+	LastTrade time.Time `json:"last_trade"`
+
+	notional int
+	buys     int
+}
+
+// tradeFirehose thins the unfiltered trades feed. It keeps only the most
+// active markets, samples prints, or folds them into per-market summary rows.
+type tradeFirehose struct {
+	mu          sync.Mutex
+	sampleEvery int
+	top         int
+	summary     bool
+
+	recent       []recentTrade
+	recentVolume map[string]int
+	topSet       map[string]bool
+	rankedAt     time.Time
+	shown        int
+
+	rows  map[string]*tradeSummaryRow
+	dirty bool
+}
+
+func newTradeFirehose(sampleEvery, top int, summary bool) *tradeFirehose {
+	return &tradeFirehose{
+		sampleEvery:  sampleEvery,
+		top:          top,
+		summary:      summary,
+		recentVolume: make(map[string]int),
+		rows:         make(map[string]*tradeSummaryRow),
+	}
+}
+
+// activeFirehose is the firehose of the running watch trades, shared with
+// the summary redraw; nil when no thinning flag is set
+var activeFirehose *tradeFirehose
+
+// newTradeFirehoseFromFlags returns the firehose for watch trades, or nil
+// when every trade is printed
+func newTradeFirehoseFromFlags() *tradeFirehose {
+	n, _ := parseSample(watchSample)
+	if n == 1 && watchTopMarkets == 0 && !watchTradesSummary {
+		return nil
+	}
+	return newTradeFirehose(n, watchTopMarkets, watchTradesSummary)
+}
+
+// observe records a print and reports whether it should be printed
+func (f *tradeFirehose) observe(trade websocket.TradeData, now time.Time) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.recent = append(f.recent, recentTrade{ticker: trade.Ticker, count: trade.Count, at: now})
+	f.recentVolume[trade.Ticker] += trade.Count
+	f.expire(now)
+
+	if f.summary {
+		row, ok := f.rows[trade.Ticker]
+		if !ok {
+			row = &tradeSummaryRow{Ticker: trade.Ticker}
+			f.rows[trade.Ticker] = row
+		}
+		row.Trades++
+		row.Volume += trade.Count
+		row.notional += trade.Count * trade.Price
+		if trade.TakerSide == "yes" {
+			row.buys += trade.Count
+		}
+		row.Last = trade.Price
+		row.LastTrade = now
+		f.dirty = true
+		return false
+	}
+
+	if f.top > 0 && !f.inTop(trade.Ticker, now) {
+		return false
+	}
+	f.shown++
+	return (f.shown-1)%f.sampleEvery == 0
+}
+
+// expire drops prints that have left the activity window
+func (f *tradeFirehose) expire(now time.Time) {
+	cutoff := now.Add(-firehoseWindow)
+	i := 0
+	for ; i < len(f.recent) && f.recent[i].at.Before(cutoff); i++ {
+		t := f.recent[i]
+		if f.recentVolume[t.ticker] -= t.count; f.recentVolume[t.ticker] <= 0 {
+			delete(f.recentVolume, t.ticker)
+		}
+	}
+	f.recent = f.recent[i:]
+}
+
+// inTop reports whether a market is among the most active, reranking at
+// most once per firehoseRerank
+func (f *tradeFirehose) inTop(ticker string, now time.Time) bool {
+	if f.topSet == nil || now.Sub(f.rankedAt) >= firehoseRerank {
+		f.topSet = make(map[string]bool, f.top)
+		for _, t := range f.ranked() {
+			if len(f.topSet) == f.top {
+				break
+			}
+			f.topSet[t] = true
+		}
+		f.rankedAt = now
+	}
+	return f.topSet[ticker]
+}
+
+// ranked returns the markets traded in the window, most recent volume first
+func (f *tradeFirehose) ranked() []string {
+	tickers := make([]string, 0, len(f.recentVolume))
+	for t := range f.recentVolume {
+		tickers = append(tickers, t)
+	}
+	sort.Slice(tickers, func(i, j int) bool {
+		vi, vj := f.recentVolume[tickers[i]], f.recentVolume[tickers[j]]
+		if vi != vj {
+			return vi > vj
+		}
+		return tickers[i] < tickers[j]
+	})
+	return tickers
+}
+
+// snapshot returns the summary rows, most recent volume then session volume
+// first and limited to the top markets, if anything changed since the last
+// snapshot
+func (f *tradeFirehose) snapshot(now time.Time) ([]tradeSummaryRow, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.expire(now)
+	if !f.dirty {
+		return nil, false
+	}
+	f.dirty = false
+
+	rows := make([]tradeSummaryRow, 0, len(f.rows))
+	for _, r := range f.rows {
+		row := *r
+		row.RecentVolume = f.recentVolume[r.Ticker]
+		if row.Volume > 0 {
+			row.VWAP = roundCents(float64(row.notional) / float64(row.Volume))
+			row.BuyShare = float64(row.buys) / float64(row.Volume)
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].RecentVolume != rows[j].RecentVolume {
+			return rows[i].RecentVolume > rows[j].RecentVolume
+		}
+		if rows[i].Volume != rows[j].Volume {
+			return rows[i].Volume > rows[j].Volume
+		}
+		return rows[i].Ticker < rows[j].Ticker
+	})
+	if f.top > 0 && len(rows) > f.top {
+		rows = rows[:f.top]
+	}
+	return rows, true
+}
+
+// renderTradeSummary writes one line per market. Each line is followed by
+// eol, which clears the rest of the line when redrawing in place.
+func renderTradeSummary(w io.Writer, rows []tradeSummaryRow, prices watchPrices, now time.Time, eol string) {
+	cells := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		cells = append(cells, []string{
+			r.Ticker,
+			strconv.Itoa(r.Trades),
+			formatVolume(r.Volume),
+			formatVolume(r.RecentVolume),
+			prices.price(r.Last),
+			formatPriceAs(r.VWAP, prices.format),
+			ui.FormatPercent(r.BuyShare),
+			now.Sub(r.LastTrade).Round(time.Second).String(),
+		})
+	}
+	renderColumns(w, []string{"TICKER", "TRADES", "VOL", "VOL 5M", "LAST", "VWAP", "BUY", "AGO"}, cells, func(row, col int, s string) string {
+		if col == 6 && rows[row].BuyShare > 0.5 {
+			return ui.PriceUpStyle.Render(s)
+		}
+		if col == 6 && rows[row].BuyShare < 0.5 {
+			return ui.PriceDownStyle.Render(s)
+		}
+		return s
+	}, eol)
+}
+
+// drawTradeSummary writes one refresh of the summary in the current output
+// format
+func drawTradeSummary(f *tradeFirehose, prices watchPrices, inPlace bool) {
+	now := time.Now()
+	rows, changed := f.snapshot(now)
+	if !changed {
+		return
+	}
+	switch GetOutputFormat() {
+	case ui.FormatJSON:
+		printJSONLine(rows)
+	case ui.FormatPlain:
+		ts := formatTimestamp()
+		for _, r := range rows {
+			fmt.Fprintf(ui.Writer(), "%s %s trades=%d vol=%d vol5m=%d last=%d vwap=%s buy=%.2f\n", ts, r.Ticker, r.Trades, r.Volume,
+				r.RecentVolume, r.Last, strconv.FormatFloat(r.VWAP, 'f', 2, 64), r.BuyShare)
+		}
+	default:
+		if !inPlace {
+			renderTradeSummary(ui.Writer(), rows, prices, now, "")
+			fmt.Fprintln(ui.Writer())
+			return
+		}
+		var b strings.Builder
+		b.WriteString(redrawHome)
+		renderTradeSummary(&b, rows, prices, now, redrawEOL)
+		b.WriteString(redrawFooter())
+		fmt.Fprint(ui.Writer(), b.String())
+	}
+}
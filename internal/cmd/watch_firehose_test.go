@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestParseSample(t *testing.T) {
+	if n, err := parseSample("1/10"); err != nil || n != 10 {
+		t.Errorf("parseSample(1/10) = %d, %v", n, err)
+	}
+	if n, err := parseSample(""); err != nil || n != 1 {
+		t.Errorf("parseSample(\"\") = %d, %v", n, err)
+	}
+	for _, bad := range []string{"10", "2/10", "1/0", "1/x"} {
+		if _, err := parseSample(bad); err == nil {
+			t.Errorf("parseSample(%q): expected error", bad)
+		}
+	}
+}
+
+func TestTradeFirehose_Sample(t *testing.T) {
+	f := newTradeFirehose(3, 0, false)
+	now := time.Now()
+	var shown []bool
+	for i := 0; i < 7; i++ {
+		shown = append(shown, f.observe(websocket.TradeData{Ticker: "A", Count: 1}, now))
+	}
+	want := []bool{true, false, false, true, false, false, true}
+	for i := range want {
+		if shown[i] != want[i] {
+			t.Fatalf("shown = %v, want %v", shown, want)
+		}
+	}
+}
+
+func TestTradeFirehose_TopMarkets(t *testing.T) {
+	f := newTradeFirehose(1, 1, false)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	f.observe(websocket.TradeData{Ticker: "A", Count: 50}, start)
+	if f.observe(websocket.TradeData{Ticker: "B", Count: 10}, start) {
+		t.Error("B shown while A is more active")
+	}
+	if !f.observe(websocket.TradeData{Ticker: "A", Count: 1}, start) {
+		t.Error("A not shown")
+	}
+
+	// B overtakes A after a rerank
+	f.observe(websocket.TradeData{Ticker: "B", Count: 100}, start.Add(2*time.Second))
+	if !f.observe(websocket.TradeData{Ticker: "B", Count: 1}, start.Add(3*time.Second)) {
+		t.Error("B not shown after overtaking A")
+	}
+
+	// once A's prints leave the window, only B's recent volume counts
+	f.observe(websocket.TradeData{Ticker: "C", Count: 1}, start.Add(firehoseWindow+5*time.Second))
+	if f.recentVolume["A"] != 0 {
+		t.Errorf("A recent volume = %d after the window, want 0", f.recentVolume["A"])
+	}
+}
+
+func TestTradeFirehose_Summary(t *testing.T) {
+	f := newTradeFirehose(1, 1, true)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	if f.observe(websocket.TradeData{Ticker: "A", Price: 40, Count: 10, TakerSide: "yes"}, now) {
+		t.Error("summary mode printed a trade")
+	}
+	f.observe(websocket.TradeData{Ticker: "A", Price: 50, Count: 30, TakerSide: "no"}, now)
+	f.observe(websocket.TradeData{Ticker: "B", Price: 90, Count: 5, TakerSide: "yes"}, now)
+
+	rows, changed := f.snapshot(now)
+	if !changed || len(rows) != 1 {
+		t.Fatalf("rows = %+v, changed = %v", rows, changed)
+	}
+	r := rows[0]
+	if r.Ticker != "A" || r.Trades != 2 || r.Volume != 40 || r.Last != 50 || r.VWAP != 47.5 || r.BuyShare != 0.25 {
+		t.Errorf("row = %+v", r)
+	}
+	if _, changed := f.snapshot(now); changed {
+		t.Error("snapshot reported a change without new trades")
+	}
+
+	var b strings.Builder
+	renderTradeSummary(&b, rows, watchPrices{format: "cents"}, now, "")
+	if out := b.String(); !strings.Contains(out, "VOL 5M") || !strings.Contains(out, "47.5¢") {
+		t.Errorf("render = %q", out)
+	}
+}
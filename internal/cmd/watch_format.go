@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// priceFormats are the accepted output.price_format and --price-format values
+var priceFormats = []string{"dollars", "cents", "percent", "decimal"}
+
+var (
+	watchPriceFormat string
+	watchShowMid     bool
+)
+
+func init() {
+	watchCmd.PersistentFlags().StringVar(&watchPriceFormat, "price-format", "", "price display: dollars, cents, percent or decimal (default: output.price_format)")
+	watchCmd.PersistentFlags().BoolVar(&watchShowMid, "mid", false, "show the bid/ask mid-price for ticker and orderbook (default: output.show_mid)")
+}
+
+// watchPrices formats prices in watch output. Only table output is affected;
+// plain and JSON keep integer cents, with mid= added to plain when showMid is set.
+type watchPrices struct {
+	format  string
+	showMid bool
+}
+
+// watchPricesFromFlags applies --price-format and --mid over config
+func watchPricesFromFlags() watchPrices {
+	p := watchPrices{format: cfg.Output.PriceFormat, showMid: cfg.Output.ShowMid}
+	flags := watchCmd.PersistentFlags()
+	if flags.Changed("price-format") {
+		p.format = watchPriceFormat
+	}
+	if flags.Changed("mid") {
+		p.showMid = watchShowMid
+	}
+	if p.format == "" {
+		p.format = "dollars"
+	}
+	return p
+}
+
+// validateWatchPriceFlags rejects an unknown price format before connecting
+func validateWatchPriceFlags() error {
+	format := watchPricesFromFlags().format
+	if err := validatePriceFormat(format); err != nil {
+		return fmt.Errorf("invalid price format %q: %w", format, err)
+	}
+	return nil
+}
+
+// price formats a whole-cent price
+func (p watchPrices) price(cents int) string {
+	return formatPriceAs(float64(cents), p.format)
+}
+
+// mid returns the midpoint of a two-sided market in cents
+func (p watchPrices) mid(bid, ask int) (float64, bool) {
+	if bid <= 0 || ask <= 0 {
+		return 0, false
+	}
+	return float64(bid+ask) / 2, true
+}
+
+// midSuffix is appended to table output, e.g. " | Mid $0.455"
+func (p watchPrices) midSuffix(bid, ask int) string {
+	if !p.showMid {
+		return ""
+	}
+	m, ok := p.mid(bid, ask)
+	if !ok {
+		return " | Mid -"
+	}
+	return " | Mid " + formatPriceAs(m, p.format)
+}
+
+// midField is appended to plain output, e.g. " mid=45.5"
+func (p watchPrices) midField(bid, ask int) string {
+	if !p.showMid {
+		return ""
+	}
+	m, ok := p.mid(bid, ask)
+	if !ok {
+		return " mid=-"
+	}
+	return " mid=" + strconv.FormatFloat(m, 'f', -1, 64)
+}
+
+// formatPriceAs renders a price in cents, which may be a half-cent mid, as
+// $0.45, 45¢, 45% or 0.45
+func formatPriceAs(cents float64, format string) string {
+	whole := cents == float64(int(cents))
+	switch format {
+	case "cents":
+		return strconv.FormatFloat(cents, 'f', -1, 64) + "¢"
+	case "percent":
+		return strconv.FormatFloat(cents, 'f', -1, 64) + "%"
+	case "decimal":
+		if whole {
+			return fmt.Sprintf("%.2f", cents/100)
+		}
+		return fmt.Sprintf("%.3f", cents/100)
+	default:
+		if whole {
+			return fmt.Sprintf("$%.2f", cents/100)
+		}
+		return fmt.Sprintf("$%.3f", cents/100)
+	}
+}
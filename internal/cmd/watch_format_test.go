@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+)
+
+func TestFormatPriceAs(t *testing.T) {
+	tests := []struct {
+		cents    float64
+		format   string
+		expected string
+	}{
+		{45, "dollars", "$0.45"},
+		{45.5, "dollars", "$0.455"},
+		{45, "cents", "45¢"},
+		{45.5, "cents", "45.5¢"},
+		{45, "percent", "45%"},
+		{45.5, "percent", "45.5%"},
+		{45, "decimal", "0.45"},
+		{45.5, "decimal", "0.455"},
+		{100, "decimal", "1.00"},
+	}
+	for _, tt := range tests {
+		if got := formatPriceAs(tt.cents, tt.format); got != tt.expected {
+			t.Errorf("formatPriceAs(%v, %s) = %q, expected %q", tt.cents, tt.format, got, tt.expected)
+		}
+	}
+}
+
+func TestWatchPrices_Mid(t *testing.T) {
+	p := watchPrices{format: "percent", showMid: true}
+
+	if got := p.midSuffix(44, 47); got != " | Mid 45.5%" {
+		t.Errorf("midSuffix = %q", got)
+	}
+	if got := p.midField(44, 47); got != " mid=45.5" {
+		t.Errorf("midField = %q", got)
+	}
+	if got := p.midSuffix(0, 47); got != " | Mid -" {
+		t.Errorf("expected no mid for a one-sided market, got %q", got)
+	}
+
+	p.showMid = false
+	if p.midSuffix(44, 47) != "" || p.midField(44, 47) != "" {
+		t.Error("expected no mid when disabled")
+	}
+}
+
+func TestWatchPricesFromFlags(t *testing.T) {
+	defer func(c *config.Config) { cfg = c }(cfg)
+	flags := watchCmd.PersistentFlags()
+	defer func() {
+		flags.Set("price-format", "")
+		flags.Set("mid", "false")
+		flags.Lookup("price-format").Changed = false
+		flags.Lookup("mid").Changed = false
+	}()
+
+	cfg = &config.Config{Output: config.OutputConfig{PriceFormat: "cents", ShowMid: true}}
+	if p := watchPricesFromFlags(); p.format != "cents" || !p.showMid {
+		t.Errorf("expected config values, got %+v", p)
+	}
+
+	flags.Set("price-format", "decimal")
+	flags.Set("mid", "false")
+	if p := watchPricesFromFlags(); p.format != "decimal" || p.showMid {
+		t.Errorf("expected flags to override config, got %+v", p)
+	}
+
+	flags.Set("price-format", "fraction")
+	if err := validateWatchPriceFlags(); err == nil {
+		t.Error("expected an unknown price format to be rejected")
+	}
+}
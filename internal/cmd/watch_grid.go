@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var watchGridCmd = &cobra.Command{
+	Use:   "grid [market-ticker...]",
+	Short: "Watch several markets in a compact grid that updates in place",
+	Long: `Show one row per market with the yes bid, yes ask, last price and the
+change in last price since the grid started, redrawn in place every --refresh.
+
+Markets are given as arguments, with --watchlist naming a list under
+'watchlists' in ~/.kalshi/config.yaml, or both:
+
+  watchlists:
+    mine: [KXBTC-26FEB12-B97000, INXD-25FEB07-B5523.99]
+
+Rows start from a REST snapshot and then follow the ticker feed. In-place
+redraws need a terminal; with output redirected, or with --plain or --json,
+a snapshot of every row is written each time something changes.`,
+	Example: `  kalshi-cli watch grid --watchlist mine
+  kalshi-cli watch grid KXBTC-26FEB12-B97000 KXBTC-26FEB12-B98000 --refresh 500ms
+  kalshi-cli watch grid --watchlist mine --json`,
+	RunE: runWatchGrid,
+}
+
+var (
+	watchGridWatchlist string
+	watchGridRefresh   time.Duration
+)
+
+func init() {
+	watchCmd.AddCommand(watchGridCmd)
+
+	watchGridCmd.Flags().StringVar(&watchGridWatchlist, "watchlist", "", "name of a watchlist from the config file")
+	watchGridCmd.Flags().DurationVar(&watchGridRefresh, "refresh", time.Second, "how often to redraw changed rows")
+}
+
+// gridTickers combines ticker arguments with a named watchlist, normalized
+// and without duplicates, in the order given
+func gridTickers(args []string, watchlist string, watchlists map[string][]string) ([]string, error) {
+	names := append([]string(nil), args...)
+	if watchlist != "" {
+		list, ok := watchlists[watchlist]
+		if !ok {
+			return nil, fmt.Errorf("no watchlist %q in config (have: %s)", watchlist, strings.Join(watchlistNames(watchlists), ", "))
+		}
+		names = append(names, list...)
+	}
+
+	seen := make(map[string]bool)
+	var tickers []string
+	for _, name := range names {
+		ticker, err := normalizeTicker(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[ticker] {
+			continue
+		}
+		seen[ticker] = true
+		tickers = append(tickers, ticker)
+	}
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("no markets to watch: pass tickers or --watchlist")
+	}
+	return tickers, nil
+}
+
+// watchlistNames returns the configured watchlist names, sorted
+func watchlistNames(watchlists map[string][]string) []string {
+	names := make([]string, 0, len(watchlists))
+	for name := range watchlists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
+
+// gridRow is one market in the grid. Change is the last price minus the
+// first last price seen this session.
+type gridRow struct {
+	Ticker  string    `json:"ticker"`
+	YesBid  int       `json:"yes_bid"`
+	YesAsk  int       `json:"yes_ask"`
+	Last    int       `json:"last"`
+	Change  int       `json:"change"`
+	Updated time.Time `json:"updated,omitzero"`
+
+	open int
+}
+
+// setLast records a last price, taking the first non-zero one as the open
+func (r *gridRow) setLast(last int) {
+	if last <= 0 {
+		return
+	}
+	if r.open == 0 {
+		r.open = last
+	}
+	r.Last = last
+	r.Change = last - r.open
+}
+
+// tickerGrid holds the latest quote of every watched market
+type tickerGrid struct {
+	mu    sync.Mutex
+	rows  []*gridRow
+	index map[string]*gridRow
+	dirty bool
+}
+
+func newTickerGrid(tickers []string) *tickerGrid {
+	g := &tickerGrid{index: make(map[string]*gridRow), dirty: true}
+	for _, t := range tickers {
+		row := &gridRow{Ticker: t}
+		g.rows = append(g.rows, row)
+		g.index[t] = row
+	}
+	return g
+}
+
+// seed fills a row from a REST market snapshot
+func (g *tickerGrid) seed(m models.Market) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	row, ok := g.index[m.Ticker]
+	if !ok {
+		return
+	}
+	row.YesBid, row.YesAsk = m.YesBid, m.YesAsk
+	row.setLast(m.LastPrice)
+	g.dirty = true
+}
+
+// observe applies a ticker update and reports whether the market is in the grid
+func (g *tickerGrid) observe(data websocket.TickerData, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	row, ok := g.index[data.Ticker]
+	if !ok {
+		return false
+	}
+	row.YesBid, row.YesAsk = data.YesBid, data.YesAsk
+	row.setLast(data.YesPrice)
+	row.Updated = now
+	g.dirty = true
+	return true
+}
+
+// snapshot copies the rows if anything changed since the last snapshot
+func (g *tickerGrid) snapshot() ([]gridRow, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.dirty {
+		return nil, false
+	}
+	g.dirty = false
+	rows := make([]gridRow, len(g.rows))
+	for i, r := range g.rows {
+		rows[i] = *r
+	}
+	return rows, true
+}
+
+// gridHandler feeds ticker messages into the active grid
+type gridHandler struct {
+	grid *tickerGrid
+}
+
+func (h *gridHandler) HandleMessage(msg websocket.Message) error {
+	var data websocket.TickerData
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return fmt.Errorf("failed to parse ticker data: %w", err)
+	}
+	h.grid.observe(data, time.Now())
+	return nil
+}
+
+// formatPriceChange renders a signed change in the watch price format
+func formatPriceChange(cents int, prices watchPrices) string {
+	switch {
+	case cents > 0:
+		return "+" + prices.price(cents)
+	case cents < 0:
+		return "-" + prices.price(-cents)
+	}
+	return prices.price(0)
+}
+
+// gridQuote renders a price, or "-" when there is none
+func gridQuote(cents int, prices watchPrices) string {
+	if cents <= 0 {
+		return "-"
+	}
+	return prices.price(cents)
+}
+
+// renderGrid writes rows as fixed-width columns. Each line is followed by
+// eol, which clears the rest of the line when redrawing in place.
+func renderGrid(w io.Writer, rows []gridRow, prices watchPrices, eol string) {
+	cells := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		cells = append(cells, []string{
+			r.Ticker,
+			gridQuote(r.YesBid, prices),
+			gridQuote(r.YesAsk, prices),
+			gridQuote(r.Last, prices),
+			formatPriceChange(r.Change, prices),
+		})
+	}
+
+	renderColumns(w, []string{"TICKER", "BID", "ASK", "LAST", "CHG"}, cells, func(row, col int, s string) string {
+		change := rows[row].Change
+		switch {
+		case col != 4:
+			return s
+		case change > 0:
+			return ui.PriceUpStyle.Render(s)
+		case change < 0:
+			return ui.PriceDownStyle.Render(s)
+		}
+		return s
+	}, eol)
+}
+
+// renderColumns writes a bold header and rows of cells as fixed-width
+// columns, the first left-aligned and the rest right-aligned. style colors
+// each padded cell; eol follows every line.
+func renderColumns(w io.Writer, headers []string, cells [][]string, style func(row, col int, s string) string, eol string) {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range cells {
+		for i, c := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+
+	line := func(row []string, style func(i int, s string) string) string {
+		parts := make([]string, len(row))
+		for i, c := range row {
+			if i == 0 {
+				parts[i] = style(i, fmt.Sprintf("%-*s", widths[i], c))
+			} else {
+				parts[i] = style(i, fmt.Sprintf("%*s", widths[i], c))
+			}
+		}
+		return strings.Join(parts, "  ")
+	}
+
+	fmt.Fprint(w, line(headers, func(_ int, s string) string { return ui.BoldStyle.Render(s) })+eol+"\n")
+	for i, row := range cells {
+		fmt.Fprint(w, line(row, func(col int, s string) string { return style(i, col, s) })+eol+"\n")
+	}
+}
+
+// drawGrid writes one refresh of the grid in the current output format
+func drawGrid(rows []gridRow, prices watchPrices, inPlace bool) {
+	switch GetOutputFormat() {
+	case ui.FormatJSON:
+		printJSONLine(rows)
+	case ui.FormatPlain:
+		ts := formatTimestamp()
+		for _, r := range rows {
+			fmt.Fprintf(ui.Writer(), "%s %s bid=%d ask=%d last=%d chg=%d\n", ts, r.Ticker, r.YesBid, r.YesAsk, r.Last, r.Change)
+		}
+	default:
+		if !inPlace {
+			renderGrid(ui.Writer(), rows, prices, "")
+			fmt.Fprintln(ui.Writer())
+			return
+		}
+		var b strings.Builder
+		b.WriteString(redrawHome)
+		renderGrid(&b, rows, prices, redrawEOL)
+		b.WriteString(redrawFooter())
+		fmt.Fprint(ui.Writer(), b.String())
+	}
+}
+
+// ANSI sequences for redrawing a live view in place: home the cursor, clear
+// the rest of each line, and clear everything below the view
+const (
+	redrawClear = "\033[2J"
+	redrawHome  = "\033[H"
+	redrawEOL   = "\033[K"
+	redrawBelow = "\033[J"
+)
+
+// redrawFooter is the last line of a view redrawn in place
+func redrawFooter() string {
+	return ui.MutedStyle.Render("Updated "+formatTimestamp()+" · Ctrl+C to stop") + redrawEOL + "\n" + redrawBelow
+}
+
+// tickerOverride replaces the ticker channel's output handler for commands
+// that draw their own live view
+var tickerOverride websocket.Handler
+
+// runLiveView streams the ticker channel into handler and calls draw every
+// interval until interrupted. inPlace tells draw whether it may redraw over
+// its previous output: only for table output on a terminal.
+func runLiveView(handler websocket.Handler, interval time.Duration, draw func(inPlace bool)) error {
+	tickerOverride = handler
+	defer func() { tickerOverride = nil }()
+
+	stop := startRedraw(interval, draw)
+	defer stop()
+
+	// The ticker channel is subscribed unfiltered, since a subscription
+	// carries a single market_tickers value; handlers drop other markets
+	return runWatch(websocket.ChannelMarketTicker, nil)
+}
+
+// startRedraw calls draw now and every interval after until the returned
+// stop is called, clearing the screen first when redrawing in place
+func startRedraw(interval time.Duration, draw func(inPlace bool)) func() {
+	inPlace := GetOutputFormat() == ui.FormatTable && ui.WriterIsTerminal()
+	if inPlace {
+		fmt.Fprint(ui.Writer(), redrawClear)
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			draw(inPlace)
+			select {
+			case <-done:
+				return
+			case <-t.C:
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+func runWatchGrid(_ *cobra.Command, args []string) error {
+	if watchGridRefresh <= 0 {
+		return fmt.Errorf("--refresh must be positive")
+	}
+	tickers, err := gridTickers(args, watchGridWatchlist, GetConfig().Watchlists)
+	if err != nil {
+		return err
+	}
+
+	grid := newTickerGrid(tickers)
+	if client, err := createClient(); err == nil {
+		ctx, cancel := withTimeout(context.Background())
+		for _, t := range tickers {
+			if m, err := client.GetMarket(ctx, t); err == nil {
+				grid.seed(*m)
+			} else if IsVerbose() {
+				fmt.Fprintf(os.Stderr, "Could not load %s: %v\n", t, err)
+			}
+		}
+		cancel()
+	}
+
+	prices := watchPricesFromFlags()
+	return runLiveView(&gridHandler{grid: grid}, watchGridRefresh, func(inPlace bool) {
+		if rows, changed := grid.snapshot(); changed {
+			drawGrid(rows, prices, inPlace)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestGridTickers(t *testing.T) {
+	lists := map[string][]string{"mine": {"kxbtc-26feb12-b97000", "INXD-25FEB07-B5523.99"}}
+
+	got, err := gridTickers([]string{"KXBTC-26FEB12-B97000", "MKT-1"}, "mine", lists)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"KXBTC-26FEB12-B97000", "MKT-1", "INXD-25FEB07-B5523.99"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gridTickers() = %v, want %v", got, want)
+	}
+
+	if _, err := gridTickers(nil, "theirs", lists); err == nil || !strings.Contains(err.Error(), "mine") {
+		t.Errorf("unknown watchlist error = %v, want it to list the configured names", err)
+	}
+	if _, err := gridTickers(nil, "", lists); err == nil {
+		t.Error("no tickers and no watchlist should fail")
+	}
+}
+
+func TestTickerGrid_SessionChange(t *testing.T) {
+	grid := newTickerGrid([]string{"A", "B"})
+	grid.seed(models.Market{Ticker: "A", YesBid: 40, YesAsk: 42, LastPrice: 41})
+
+	now := time.Now()
+	if grid.observe(websocket.TickerData{Ticker: "C", YesPrice: 10}, now) {
+		t.Error("markets outside the grid should be ignored")
+	}
+	grid.observe(websocket.TickerData{Ticker: "A", YesBid: 44, YesAsk: 46, YesPrice: 45}, now)
+	grid.observe(websocket.TickerData{Ticker: "B", YesBid: 20, YesAsk: 22, YesPrice: 21}, now)
+	grid.observe(websocket.TickerData{Ticker: "B", YesBid: 18, YesAsk: 20, YesPrice: 19}, now)
+
+	rows, changed := grid.snapshot()
+	if !changed || len(rows) != 2 {
+		t.Fatalf("snapshot() = %d rows, changed %v", len(rows), changed)
+	}
+	if rows[0].Last != 45 || rows[0].Change != 4 || rows[0].YesBid != 44 {
+		t.Errorf("row A = %+v, want last 45 change +4 from the seeded 41", rows[0])
+	}
+	if rows[1].Change != -2 {
+		t.Errorf("row B change = %d, want -2 from the first update", rows[1].Change)
+	}
+	if _, changed := grid.snapshot(); changed {
+		t.Error("snapshot() with no new updates should report no change")
+	}
+}
+
+func TestRenderGrid(t *testing.T) {
+	rows := []gridRow{
+		{Ticker: "KXBTC-26FEB12-B97000", YesBid: 44, YesAsk: 46, Last: 45, Change: 4},
+		{Ticker: "MKT", Change: 0},
+	}
+	var b strings.Builder
+	renderGrid(&b, rows, watchPrices{format: "cents"}, "")
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("renderGrid() wrote %d lines, want 3:\n%s", len(lines), b.String())
+	}
+	if !strings.Contains(lines[1], "44¢") || !strings.Contains(lines[1], "+4¢") {
+		t.Errorf("row = %q, want bid 44¢ and change +4¢", lines[1])
+	}
+	if !strings.Contains(lines[2], "-") {
+		t.Errorf("row without quotes = %q, want placeholders", lines[2])
+	}
+}
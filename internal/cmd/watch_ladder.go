@@ -0,0 +1,563 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var (
+	watchLadder     bool
+	watchLadderSize int
+	watchLadderRows int
+)
+
+const (
+	// ladderRedraw is how often the ladder is redrawn when something changed
+	ladderRedraw = 200 * time.Millisecond
+	// ladderOrdersRefresh is how often your resting orders are re-read
+	ladderOrdersRefresh = 2 * time.Second
+)
+
+func init() {
+	watchOrderbookCmd.Flags().BoolVar(&watchLadder, "ladder", false, "show an interactive price ladder with keys to join, hit, lift and cancel (needs a terminal)")
+	watchOrderbookCmd.Flags().IntVar(&watchLadderSize, "size", 1, "with --ladder, the order size offered at the size prompt")
+	watchOrderbookCmd.Flags().IntVar(&watchLadderRows, "rows", 10, "with --ladder, price rows shown above and below the cursor")
+}
+
+// ladderKeyHelp is the key reference under the ladder
+const ladderKeyHelp = "↑/↓ move · m mid · b/a join bid/ask · l lift · h hit · c cancel level · C cancel all · q quit"
+
+// ladderTrade is an order the trading ladder can place
+type ladderTrade int
+
+const (
+	ladderNone ladderTrade = iota
+	ladderJoinBid
+	ladderJoinAsk
+	ladderLift
+	ladderHit
+)
+
+// ladderOrder builds the limit order for an action at a YES price. Offering
+// or selling YES is done by buying NO at the complementary price, which nets
+// against a YES position.
+func ladderOrder(ticker string, action ladderTrade, yesPrice, qty int) models.CreateOrderRequest {
+	req := models.CreateOrderRequest{
+		Ticker: ticker,
+		Action: models.OrderActionBuy,
+		Type:   models.OrderTypeLimit,
+		Count:  qty,
+	}
+	if action == ladderJoinBid || action == ladderLift {
+		req.Side = models.OrderSideYes
+		req.YesPrice = yesPrice
+	} else {
+		req.Side = models.OrderSideNo
+		req.NoPrice = 100 - yesPrice
+	}
+	return req
+}
+
+// describeLadderAction says what an action at a YES price will do
+func describeLadderAction(action ladderTrade, yesPrice int) string {
+	switch action {
+	case ladderJoinBid:
+		return fmt.Sprintf("Join bid: buy YES @ %s", formatCents(yesPrice))
+	case ladderJoinAsk:
+		return fmt.Sprintf("Join ask: buy NO @ %s (offers YES @ %s)", formatCents(100-yesPrice), formatCents(yesPrice))
+	case ladderLift:
+		return fmt.Sprintf("Lift: buy YES @ %s (takes asks up to %s)", formatCents(yesPrice), formatCents(yesPrice))
+	case ladderHit:
+		return fmt.Sprintf("Hit: buy NO @ %s (sells YES down to %s)", formatCents(100-yesPrice), formatCents(yesPrice))
+	}
+	return ""
+}
+
+// ladderRow is one price of the ladder in YES cents: book quantity and your
+// own resting quantity on each side
+type ladderRow struct {
+	Price int
+	Bid   int
+	Ask   int
+	MyBid int
+	MyAsk int
+}
+
+// ladderCommand is what a key press asks the runner to do
+type ladderCommand struct {
+	quit   bool
+	order  *models.CreateOrderRequest
+	cancel []string
+}
+
+// ladder is the state of the trading ladder: the book, your resting orders,
+// the cursor and the size prompt
+type ladder struct {
+	ticker      string
+	defaultSize int
+
+	mu       sync.Mutex
+	bids     map[int]int
+	asks     map[int]int
+	orders   []models.Order
+	myBids   map[int]int
+	myAsks   map[int]int
+	cursor   int
+	prompt   ladderTrade
+	price    int
+	input    string
+	status   string
+	dirty    bool
+	centered bool
+}
+
+func newLadder(ticker string, defaultSize int) *ladder {
+	return &ladder{
+		ticker:      ticker,
+		defaultSize: defaultSize,
+		bids:        make(map[int]int),
+		asks:        make(map[int]int),
+		myBids:      make(map[int]int),
+		myAsks:      make(map[int]int),
+		cursor:      50,
+		dirty:       true,
+	}
+}
+
+// setBook replaces the YES bids and asks. The cursor starts at the mid of
+// the first two-sided book.
+func (l *ladder) setBook(bids, asks []models.OrderbookLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bids = levelMap(bids)
+	l.asks = levelMap(asks)
+	if !l.centered {
+		if mid := l.mid(); mid > 0 {
+			l.cursor = mid
+			l.centered = true
+		}
+	}
+	l.dirty = true
+}
+
+func levelMap(levels []models.OrderbookLevel) map[int]int {
+	m := make(map[int]int, len(levels))
+	for _, lvl := range levels {
+		if lvl.Quantity > 0 {
+			m[lvl.Price] += lvl.Quantity
+		}
+	}
+	return m
+}
+
+// setOrders replaces your resting orders on the market, placed on the YES
+// ladder by yesBid
+func (l *ladder) setOrders(orders []models.Order) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.orders = orders
+	l.myBids = make(map[int]int)
+	l.myAsks = make(map[int]int)
+	for _, o := range orders {
+		if bid, price := yesBid(o); bid {
+			l.myBids[price] += o.RemainingCount
+		} else {
+			l.myAsks[price] += o.RemainingCount
+		}
+	}
+	l.dirty = true
+}
+
+// setStatus shows a message under the ladder
+func (l *ladder) setStatus(status string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.status = status
+	l.dirty = true
+}
+
+// bestBid and bestAsk return 0 for an empty side; l.mu must be held
+func (l *ladder) bestBid() int {
+	best := 0
+	for p := range l.bids {
+		best = max(best, p)
+	}
+	return best
+}
+
+func (l *ladder) bestAsk() int {
+	best := 0
+	for p := range l.asks {
+		if best == 0 || p < best {
+			best = p
+		}
+	}
+	return best
+}
+
+// mid is the midpoint of a two-sided book, or 0; l.mu must be held
+func (l *ladder) mid() int {
+	bid, ask := l.bestBid(), l.bestAsk()
+	if bid == 0 || ask == 0 {
+		return 0
+	}
+	return (bid + ask) / 2
+}
+
+// rows returns the prices from n above the cursor to n below it, highest
+// first; l.mu must be held
+func (l *ladder) rows(n int) []ladderRow {
+	top := min(99, max(l.cursor+n, 1+2*n))
+	bottom := max(1, top-2*n)
+	rows := make([]ladderRow, 0, top-bottom+1)
+	for p := top; p >= bottom; p-- {
+		rows = append(rows, ladderRow{Price: p, Bid: l.bids[p], Ask: l.asks[p], MyBid: l.myBids[p], MyAsk: l.myAsks[p]})
+	}
+	return rows
+}
+
+// press handles one key and returns what the runner should do
+func (l *ladder) press(key string) ladderCommand {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dirty = true
+
+	if key == "ctrl+c" {
+		return ladderCommand{quit: true}
+	}
+	if l.prompt != ladderNone {
+		return l.pressPrompt(key)
+	}
+
+	switch key {
+	case "up", "k":
+		l.cursor = min(99, l.cursor+1)
+	case "down", "j":
+		l.cursor = max(1, l.cursor-1)
+	case "m":
+		if mid := l.mid(); mid > 0 {
+			l.cursor = mid
+		}
+	case "b":
+		if bid := l.bestBid(); bid > 0 {
+			l.startPrompt(ladderJoinBid, bid)
+		} else {
+			l.status = "No bid to join"
+		}
+	case "a":
+		if ask := l.bestAsk(); ask > 0 {
+			l.startPrompt(ladderJoinAsk, ask)
+		} else {
+			l.status = "No ask to join"
+		}
+	case "l":
+		l.startPrompt(ladderLift, l.cursor)
+	case "h":
+		l.startPrompt(ladderHit, l.cursor)
+	case "c":
+		ids := l.orderIDs(func(price int) bool { return price == l.cursor })
+		if len(ids) == 0 {
+			l.status = "No orders of yours at " + formatCents(l.cursor)
+			return ladderCommand{}
+		}
+		return ladderCommand{cancel: ids}
+	case "C":
+		ids := l.orderIDs(func(int) bool { return true })
+		if len(ids) == 0 {
+			l.status = "No resting orders of yours"
+			return ladderCommand{}
+		}
+		return ladderCommand{cancel: ids}
+	case "q":
+		return ladderCommand{quit: true}
+	}
+	return ladderCommand{}
+}
+
+// startPrompt asks for the size of an order; l.mu must be held
+func (l *ladder) startPrompt(action ladderTrade, price int) {
+	l.prompt, l.price, l.input, l.status = action, price, "", ""
+}
+
+// pressPrompt handles a key while the size prompt is open; l.mu must be held
+func (l *ladder) pressPrompt(key string) ladderCommand {
+	switch {
+	case key == "esc":
+		l.prompt, l.status = ladderNone, "Cancelled"
+	case key == "backspace":
+		if l.input != "" {
+			l.input = l.input[:len(l.input)-1]
+		}
+	case key == "enter":
+		qty := l.defaultSize
+		if l.input != "" {
+			qty, _ = strconv.Atoi(l.input)
+		}
+		action, price := l.prompt, l.price
+		l.prompt = ladderNone
+		if qty <= 0 {
+			l.status = "Size must be positive"
+			return ladderCommand{}
+		}
+		req := ladderOrder(l.ticker, action, price, qty)
+		return ladderCommand{order: &req}
+	case len(key) == 1 && key[0] >= '0' && key[0] <= '9' && len(l.input) < 6:
+		l.input += key
+	}
+	return ladderCommand{}
+}
+
+// orderIDs returns your resting orders whose YES price matches; l.mu must be
+// held
+func (l *ladder) orderIDs(match func(yesPrice int) bool) []string {
+	var ids []string
+	for _, o := range l.orders {
+		if _, price := yesBid(o); match(price) {
+			ids = append(ids, o.OrderID)
+		}
+	}
+	return ids
+}
+
+// render writes the ladder if anything changed since the last render
+func (l *ladder) render(w io.Writer, env string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.dirty {
+		return false
+	}
+	l.dirty = false
+
+	quote := func(p int) string {
+		if p == 0 {
+			return "-"
+		}
+		return formatCents(p)
+	}
+	spread := "-"
+	if bid, ask := l.bestBid(), l.bestAsk(); bid > 0 && ask > 0 {
+		spread = formatCents(ask - bid)
+	}
+	fmt.Fprintf(w, "%s  %s  bid %s · ask %s · spread %s%s\n", ui.TitleStyle.Render(l.ticker), env,
+		quote(l.bestBid()), quote(l.bestAsk()), spread, redrawEOL)
+
+	rows := l.rows(watchLadderRows)
+	qty := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	cells := make([][]string, len(rows))
+	for i, r := range rows {
+		marker := " "
+		if r.Price == l.cursor {
+			marker = ">"
+		}
+		cells[i] = []string{qty(r.MyBid), qty(r.Bid), marker + formatCents(r.Price), qty(r.Ask), qty(r.MyAsk)}
+	}
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+	renderColumns(w, []string{"MINE", "BID", "PRICE", "ASK", "MINE"}, cells, func(row, col int, s string) string {
+		switch {
+		case col == 2 && rows[row].Price == l.cursor:
+			return cursorStyle.Render(s)
+		case col == 0 || col == 4:
+			return ui.WarningStyle.Render(s)
+		case col == 1:
+			return ui.PriceUpStyle.Render(s)
+		case col == 3:
+			return ui.PriceDownStyle.Render(s)
+		}
+		return s
+	}, redrawEOL)
+
+	fmt.Fprint(w, redrawEOL+"\n")
+	if l.prompt != ladderNone {
+		fmt.Fprintf(w, "%s · size [%d]: %s_%s\n", envPrompt(describeLadderAction(l.prompt, l.price)), l.defaultSize, l.input, redrawEOL)
+		fmt.Fprint(w, ui.MutedStyle.Render("Enter to place · Esc to cancel")+redrawEOL+"\n")
+	} else {
+		fmt.Fprint(w, l.status+redrawEOL+"\n")
+		fmt.Fprint(w, ui.MutedStyle.Render(ladderKeyHelp)+redrawEOL+"\n")
+	}
+	fmt.Fprint(w, redrawBelow)
+	return true
+}
+
+// ladderRunner connects the ladder to the API
+type ladderRunner struct {
+	client *api.Client
+	ladder *ladder
+}
+
+// loadBook reads the orderbook over REST
+func (r *ladderRunner) loadBook(ctx context.Context) error {
+	reqCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	book, err := r.client.GetOrderbook(reqCtx, r.ladder.ticker)
+	if err != nil {
+		return fmt.Errorf("failed to get orderbook: %w", err)
+	}
+	r.ladder.setBook(book.YesBids, book.YesAsks)
+	return nil
+}
+
+// loadOrders re-reads your resting orders on the market
+func (r *ladderRunner) loadOrders(ctx context.Context) error {
+	var orders []models.Order
+	opts := api.OrdersOptions{Ticker: r.ladder.ticker, Status: string(models.OrderStatusResting), Limit: reconcilePageSize, SubaccountID: ActiveSubaccount()}
+	for {
+		reqCtx, cancel := withTimeout(ctx)
+		page, err := r.client.GetOrders(reqCtx, opts)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get resting orders: %w", err)
+		}
+		orders = append(orders, page.Orders...)
+		if page.Cursor == "" || len(page.Orders) == 0 {
+			break
+		}
+		opts.Cursor = page.Cursor
+	}
+	r.ladder.setOrders(orders)
+	return nil
+}
+
+// execute carries out a key's command and reports the result in the status
+// line
+func (r *ladderRunner) execute(ctx context.Context, cmd ladderCommand) {
+	switch {
+	case cmd.order != nil:
+		req := *cmd.order
+		req.SubaccountID = ActiveSubaccount()
+		if err := runPrecheck([]models.CreateOrderRequest{req}); err != nil {
+			r.ladder.setStatus(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+		reqCtx, cancel := withTimeout(ctx)
+		resp, err := r.client.CreateOrder(reqCtx, req)
+		cancel()
+		if err != nil {
+			r.ladder.setStatus(ui.ErrorStyle.Render(fmt.Sprintf("Order failed: %v", err)))
+			return
+		}
+		o := resp.Order
+		r.ladder.setStatus(ui.SuccessStyle.Render(fmt.Sprintf("Placed %s %d %s @ %s: %s, %d filled (%s)",
+			o.Action, req.Count, strings.ToUpper(string(o.Side)), formatCents(orderPrice(o)), o.Status, o.FillCount, truncateID(o.OrderID, 8))))
+	case len(cmd.cancel) > 0:
+		cancelled := 0
+		for _, id := range cmd.cancel {
+			reqCtx, cancel := withTimeout(ctx)
+			_, err := r.client.CancelOrder(reqCtx, id)
+			cancel()
+			if err == nil {
+				cancelled++
+			}
+		}
+		status := fmt.Sprintf("Cancelled %d of %d orders", cancelled, len(cmd.cancel))
+		if cancelled < len(cmd.cancel) {
+			r.ladder.setStatus(ui.WarningStyle.Render(status))
+		} else {
+			r.ladder.setStatus(ui.SuccessStyle.Render(status))
+		}
+	default:
+		return
+	}
+	if err := r.loadOrders(ctx); err != nil {
+		r.ladder.setStatus(ui.ErrorStyle.Render(err.Error()))
+	}
+}
+
+// runWatchLadder shows an interactive trading ladder for one market
+func runWatchLadder(ticker string) error {
+	if err := requireScreen("--ladder", "ladder keys"); err != nil {
+		return err
+	}
+	if watchLadderSize < 1 {
+		return fmt.Errorf("--size must be at least 1")
+	}
+	if watchLadderRows < 1 {
+		return fmt.Errorf("--rows must be at least 1")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	r := &ladderRunner{client: client, ladder: newLadder(ticker, watchLadderSize)}
+	if err := r.loadBook(ctx); err != nil {
+		return err
+	}
+	if err := r.loadOrders(ctx); err != nil {
+		return err
+	}
+
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return err
+	}
+	wsClient := newWebSocketClient(opts)
+	wsClient.OnError(func(err error) { r.ladder.setStatus(ui.WarningStyle.Render(err.Error())) })
+	wsClient.RegisterHandler(websocket.ChannelOrderbook, websocket.HandlerFunc(func(msg websocket.Message) error {
+		var data websocket.OrderbookData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse orderbook data: %w", err)
+		}
+		if data.Ticker != "" && data.Ticker != ticker {
+			return nil
+		}
+		r.ladder.setBook(wsLevels(data.YesBids), wsLevels(data.YesAsks))
+		return nil
+	}))
+	if err := wsClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer wsClient.Close()
+	if err := wsClient.Subscribe(ctx, websocket.ChannelOrderbook, map[string]string{"market_tickers": ticker}); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", websocket.ChannelOrderbook, err)
+	}
+
+	env := envPrompt(strings.ToUpper(GetConfig().Environment()))
+	return screen{
+		redraw: ladderRedraw,
+		render: func(w io.Writer) bool { return r.ladder.render(w, env) },
+		key: func(key string) bool {
+			cmd := r.ladder.press(key)
+			if cmd.quit {
+				return true
+			}
+			r.execute(ctx, cmd)
+			return false
+		},
+		refresh: ladderOrdersRefresh,
+		onRefresh: func() {
+			if err := r.loadOrders(ctx); err != nil {
+				r.ladder.setStatus(ui.WarningStyle.Render(err.Error()))
+			}
+		},
+	}.run()
+}
+
+// wsLevels converts WebSocket orderbook levels to the REST model
+func wsLevels(levels []websocket.OrderbookLevel) []models.OrderbookLevel {
+	out := make([]models.OrderbookLevel, len(levels))
+	for i, l := range levels {
+		out[i] = models.OrderbookLevel{Price: l.Price, Quantity: l.Quantity}
+	}
+	return out
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func testLadder() *ladder {
+	l := newLadder("KXA", 5)
+	l.setBook(
+		[]models.OrderbookLevel{{Price: 45, Quantity: 100}, {Price: 44, Quantity: 20}},
+		[]models.OrderbookLevel{{Price: 48, Quantity: 30}},
+	)
+	l.setOrders([]models.Order{
+		{OrderID: "bid", Side: models.OrderSideYes, Action: models.OrderActionBuy, YesPrice: 44, RemainingCount: 3},
+		{OrderID: "offer", Side: models.OrderSideNo, Action: models.OrderActionBuy, NoPrice: 52, RemainingCount: 7},
+	})
+	return l
+}
+
+func TestLadder_BookAndOrders(t *testing.T) {
+	l := testLadder()
+	if l.cursor != 46 {
+		t.Errorf("cursor = %d, want the mid 46", l.cursor)
+	}
+
+	rows := l.rows(2)
+	if len(rows) != 5 || rows[0].Price != 48 || rows[4].Price != 44 {
+		t.Fatalf("rows = %+v", rows)
+	}
+	if rows[0].Ask != 30 || rows[0].MyAsk != 7 || rows[4].Bid != 20 || rows[4].MyBid != 3 {
+		t.Errorf("rows = %+v", rows)
+	}
+
+	l.cursor = 99
+	if rows := l.rows(2); rows[0].Price != 99 || len(rows) != 5 {
+		t.Errorf("rows at the top = %+v", rows)
+	}
+	l.cursor = 1
+	if rows := l.rows(2); rows[len(rows)-1].Price != 1 || len(rows) != 5 {
+		t.Errorf("rows at the bottom = %+v", rows)
+	}
+}
+
+func TestLadder_Press(t *testing.T) {
+	l := testLadder()
+
+	// Join the bid at the default size
+	l.press("b")
+	cmd := l.press("enter")
+	want := models.CreateOrderRequest{Ticker: "KXA", Side: models.OrderSideYes, Action: models.OrderActionBuy, Type: models.OrderTypeLimit, Count: 5, YesPrice: 45}
+	if cmd.order == nil || !reflect.DeepEqual(*cmd.order, want) {
+		t.Errorf("join bid = %+v", cmd.order)
+	}
+
+	// Hit two levels down with a typed size, correcting a typo
+	l.press("down")
+	l.press("down")
+	l.press("h")
+	for _, k := range []string{"1", "2", "9", "backspace"} {
+		l.press(k)
+	}
+	cmd = l.press("enter")
+	if cmd.order == nil || cmd.order.Side != models.OrderSideNo || cmd.order.NoPrice != 56 || cmd.order.Count != 12 {
+		t.Errorf("hit = %+v", cmd.order)
+	}
+
+	// Esc backs out of the prompt without an order
+	l.press("l")
+	if cmd := l.press("esc"); cmd.order != nil || l.prompt != ladderNone {
+		t.Errorf("esc = %+v", cmd)
+	}
+
+	// Cancel at the cursor, then everything
+	l.cursor = 44
+	if cmd := l.press("c"); !reflect.DeepEqual(cmd.cancel, []string{"bid"}) {
+		t.Errorf("cancel level = %v", cmd.cancel)
+	}
+	l.cursor = 50
+	if cmd := l.press("c"); cmd.cancel != nil || !strings.Contains(l.status, "No orders") {
+		t.Errorf("cancel empty level = %v, status %q", cmd.cancel, l.status)
+	}
+	if cmd := l.press("C"); len(cmd.cancel) != 2 {
+		t.Errorf("cancel all = %v", cmd.cancel)
+	}
+
+	if !l.press("q").quit {
+		t.Error("q did not quit")
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var watchChannelsFlag []string
+
+func init() {
+	watchCmd.Args = cobra.MaximumNArgs(1)
+	watchCmd.RunE = runWatchStreams
+
+	watchCmd.Flags().StringSliceVar(&watchChannelsFlag, "channel", nil, "stream to watch; repeat or comma-separate for several on one connection")
+	watchCmd.Flags().StringVar(&watchMarketFlag, "market", "", "market ticker for the ticker and orderbook streams, and trades filter")
+}
+
+// watchStreams maps the stream names accepted by watch to their channels
+var watchStreams = map[string]websocket.Channel{
+	"ticker":    websocket.ChannelMarketTicker,
+	"orderbook": websocket.ChannelOrderbook,
+	"trades":    websocket.ChannelPublicTrades,
+	"orders":    websocket.ChannelUserOrders,
+	"fills":     websocket.ChannelUserFills,
+	"positions": websocket.ChannelMarketPositions,
+}
+
+// streamName is the watch stream name of a channel, or the channel itself
+func streamName(ch websocket.Channel) string {
+	for name, c := range watchStreams {
+		if c == ch {
+			return name
+		}
+	}
+	return string(ch)
+}
+
+// parseWatchStreams turns the positional list and --channel values into a
+// deduplicated channel list. Channel names such as trade or fill are
+// accepted as well as stream names.
+func parseWatchStreams(names []string, market string) ([]websocket.Channel, error) {
+	seen := make(map[websocket.Channel]bool)
+	var channels []websocket.Channel
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		ch, ok := watchStreams[name]
+		if !ok {
+			for _, c := range watchStreams {
+				if string(c) == name {
+					ch, ok = c, true
+				}
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown stream %q (want ticker, orderbook, trades, orders, fills or positions)", name)
+		}
+		if seen[ch] {
+			continue
+		}
+		seen[ch] = true
+		channels = append(channels, ch)
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("name at least one stream, e.g. watch ticker,trades --market <ticker>")
+	}
+	if market == "" {
+		for _, ch := range channels {
+			if ch == websocket.ChannelMarketTicker || ch == websocket.ChannelOrderbook {
+				return nil, fmt.Errorf("the %s stream requires --market", streamName(ch))
+			}
+		}
+	}
+	return channels, nil
+}
+
+func runWatchStreams(cmd *cobra.Command, args []string) error {
+	names := append([]string(nil), watchChannelsFlag...)
+	if len(args) == 1 {
+		names = append(names, strings.Split(args[0], ",")...)
+	}
+	if len(names) == 0 {
+		return cmd.Help()
+	}
+	channels, err := parseWatchStreams(names, watchMarketFlag)
+	if err != nil {
+		return err
+	}
+	if err := validateMarketAlertFlags(); err != nil {
+		return err
+	}
+
+	params := make(map[string]string)
+	if watchMarketFlag != "" {
+		params["market_tickers"] = watchMarketFlag
+	}
+	if len(channels) > 1 && GetOutputFormat() == ui.FormatJSON {
+		// JSON lines carry their channel in the envelope rather than a prefix
+		envelope = true
+	}
+	return runWatchMultiple(channels, params)
+}
+
+// activeStream is the prefix of the channel whose message is being handled;
+// lines written through a streamPrefixWriter while it is set start with it
+var activeStream atomic.Pointer[string]
+
+// prefixHandler marks the output of one channel's handler with its prefix
+type prefixHandler struct {
+	next   websocket.Handler
+	prefix string
+}
+
+func (h *prefixHandler) HandleMessage(msg websocket.Message) error {
+	activeStream.Store(&h.prefix)
+	defer activeStream.Store(nil)
+	return h.next.HandleMessage(msg)
+}
+
+// streamPrefixes returns the prefix of each channel, padded to a common
+// width so the streams line up
+func streamPrefixes(channels []websocket.Channel) map[websocket.Channel]string {
+	width := 0
+	for _, ch := range channels {
+		width = max(width, len(streamName(ch))+2)
+	}
+	prefixes := make(map[websocket.Channel]string, len(channels))
+	for _, ch := range channels {
+		prefixes[ch] = fmt.Sprintf("%-*s ", width, "["+streamName(ch)+"]")
+	}
+	return prefixes
+}
+
+// streamPrefixWriter starts every line written during a message with the
+// active stream's prefix. Lines written outside a message, such as the
+// session summary, pass through unchanged.
+type streamPrefixWriter struct {
+	w         io.Writer
+	midLine   bool
+	tableMode bool
+}
+
+func (p *streamPrefixWriter) Write(b []byte) (int, error) {
+	prefix := activeStream.Load()
+	if prefix == nil {
+		if len(b) > 0 {
+			p.midLine = b[len(b)-1] != '\n'
+		}
+		return p.w.Write(b)
+	}
+
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if !p.midLine {
+			label := *prefix
+			if p.tableMode {
+				label = ui.MutedStyle.Render(label)
+			}
+			buf.WriteString(label)
+		}
+		buf.Write(line)
+		p.midLine = line[len(line)-1] != '\n'
+	}
+	if _, err := p.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// prefixStreamOutput sends rendered output through a streamPrefixWriter and
+// returns a func restoring the previous writer
+func prefixStreamOutput() func() {
+	prev := ui.Writer()
+	ui.SetWriter(&streamPrefixWriter{w: prev, tableMode: GetOutputFormat() == ui.FormatTable})
+	return func() {
+		if prev == os.Stdout {
+			prev = nil
+		}
+		ui.SetWriter(prev)
+	}
+}
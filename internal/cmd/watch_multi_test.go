@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestParseWatchStreams(t *testing.T) {
+	channels, err := parseWatchStreams([]string{"ticker", "Orderbook", "trade", "ticker", " fills "}, "KXA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []websocket.Channel{websocket.ChannelMarketTicker, websocket.ChannelOrderbook, websocket.ChannelPublicTrades, websocket.ChannelUserFills}
+	if fmt.Sprint(channels) != fmt.Sprint(want) {
+		t.Errorf("channels = %v, want %v", channels, want)
+	}
+
+	if _, err := parseWatchStreams([]string{"trades", "orderbook"}, ""); err == nil || !strings.Contains(err.Error(), "orderbook stream requires --market") {
+		t.Errorf("err = %v, want --market required", err)
+	}
+	if _, err := parseWatchStreams([]string{"trades", "quotes"}, ""); err == nil || !strings.Contains(err.Error(), `unknown stream "quotes"`) {
+		t.Errorf("err = %v, want unknown stream", err)
+	}
+	if channels, err := parseWatchStreams([]string{"orders", "fills"}, ""); err != nil || len(channels) != 2 {
+		t.Errorf("channels = %v, %v", channels, err)
+	}
+}
+
+func TestStreamPrefixWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &streamPrefixWriter{w: &buf}
+	prefixes := streamPrefixes([]websocket.Channel{websocket.ChannelMarketTicker, websocket.ChannelOrderbook})
+	if prefixes[websocket.ChannelMarketTicker] != "[ticker]    " {
+		t.Errorf("prefix = %q, want padded to [orderbook]", prefixes[websocket.ChannelMarketTicker])
+	}
+
+	write := func(ch websocket.Channel, text ...string) websocket.Handler {
+		return &prefixHandler{prefix: prefixes[ch], next: websocket.HandlerFunc(func(websocket.Message) error {
+			for _, s := range text {
+				fmt.Fprint(w, s)
+			}
+			return nil
+		})}
+	}
+	write(websocket.ChannelMarketTicker, "a ", "b\n").HandleMessage(websocket.Message{})
+	write(websocket.ChannelOrderbook, "c\nd\n").HandleMessage(websocket.Message{})
+	fmt.Fprint(w, "summary\n")
+
+	want := "[ticker]    a b\n[orderbook] c\n[orderbook] d\nsummary\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
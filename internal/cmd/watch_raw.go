@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var watchRawCmd = &cobra.Command{
+	Use:   "raw",
+	Short: "Dump WebSocket frames exactly as received",
+	Long: `Subscribe to one or more channels and print every frame exactly as the
+server sent it, one per line, with no per-channel decoding. Unknown channels
+and fields are passed through, which makes this useful for debugging parsing
+problems and tracking API changes.
+
+Subscription acknowledgements and errors are printed too. Output is always the
+raw frame; --json, --plain and --price-format have no effect.`,
+	Example: `  kalshi-cli watch raw --channels ticker,trade --market KXBTC-26FEB12-B97000
+  kalshi-cli watch raw --channels fill,user_orders > frames.ndjson`,
+	RunE: runWatchRaw,
+}
+
+var (
+	watchRawChannels []string
+	watchRawMarket   string
+)
+
+func init() {
+	watchCmd.AddCommand(watchRawCmd)
+
+	watchRawCmd.Flags().StringSliceVar(&watchRawChannels, "channels", nil, "comma-separated channels to subscribe to, e.g. ticker,trade (required)")
+	watchRawCmd.Flags().StringVar(&watchRawMarket, "market", "", "market ticker to subscribe to (default: all markets)")
+	watchRawCmd.MarkFlagRequired("channels")
+}
+
+// rawChannels turns --channels into a deduplicated channel list
+func rawChannels(names []string) ([]websocket.Channel, error) {
+	seen := make(map[string]bool)
+	var channels []websocket.Channel
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		channels = append(channels, websocket.Channel(name))
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("--channels must name at least one channel")
+	}
+	return channels, nil
+}
+
+func runWatchRaw(_ *cobra.Command, _ []string) error {
+	channels, err := rawChannels(watchRawChannels)
+	if err != nil {
+		return err
+	}
+	params := make(map[string]string)
+	if watchRawMarket != "" {
+		params["market_tickers"] = watchRawMarket
+	}
+
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	client := newWebSocketClient(opts)
+
+	var mu sync.Mutex
+	frames := 0
+	client.OnRawMessage(func(data []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		frames++
+		ui.Writer().Write(data)
+		ui.Writer().Write([]byte("\n"))
+	})
+	client.OnError(func(err error) {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	})
+
+	if err := client.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer client.Close()
+
+	for _, ch := range channels {
+		if err := client.Subscribe(ctx, ch, params); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", ch, err)
+		}
+	}
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Subscribed to: %s\n", strings.Join(watchRawChannels, ", "))
+	}
+
+	<-ctx.Done()
+	if IsVerbose() {
+		mu.Lock()
+		fmt.Fprintf(os.Stderr, "%d frames received\n", frames)
+		mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestRawChannels(t *testing.T) {
+	got, err := rawChannels([]string{"ticker", " trade ", "ticker", "", "future_channel"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []websocket.Channel{"ticker", "trade", "future_channel"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("rawChannels = %v, expected %v", got, expected)
+	}
+
+	if _, err := rawChannels([]string{" ", ""}); err == nil {
+		t.Error("expected an error when no channel is named")
+	}
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var watchRecordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Record WebSocket frames to a file for replay",
+	Long: `Subscribe to one or more channels and write every frame the server sends to
+a file, one JSON object per line with the time it was received:
+
+  {"received_at": "2026-03-01T12:00:00.123Z", "frame": {...}}
+
+Frames are kept exactly as received, including subscription acknowledgements
+and errors, and each is flushed as it arrives. Replay the file with
+'watch replay'. Stop with Ctrl+C.`,
+	Example: `  kalshi-cli watch record --file session.jsonl --channels ticker,trade --market KXBTC-26FEB12-B97000
+  kalshi-cli watch record --file fills.jsonl --channels fill,user_orders --append`,
+	Args: cobra.NoArgs,
+	RunE: runWatchRecord,
+}
+
+var watchReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay recorded WebSocket frames through the watch handlers",
+	Long: `Feed a file written by 'watch record' back through the same handlers the
+watch streams use, without connecting, for offline analysis and for
+debugging handlers against frames that misbehaved live.
+
+Frames are replayed with their recorded spacing divided by --speed, such as
+2x for twice as fast; --speed max replays without waiting. Output follows the
+usual watch flags: --json, --envelope, --price-format and so on. With
+several channels in the recording each line is prefixed with its stream, as
+in 'watch ticker,trades'. --channels replays only the channels named.`,
+	Example: `  kalshi-cli watch replay --file session.jsonl
+  kalshi-cli watch replay --file session.jsonl --speed 2x
+  kalshi-cli watch replay --file session.jsonl --speed max --channels trade --json`,
+	Args: cobra.NoArgs,
+	RunE: runWatchReplay,
+}
+
+var (
+	watchRecordFile     string
+	watchRecordChannels []string
+	watchRecordMarket   string
+	watchRecordAppend   bool
+	watchReplayFile     string
+	watchReplaySpeed    string
+	watchReplayChannels []string
+)
+
+func init() {
+	watchCmd.AddCommand(watchRecordCmd)
+	watchCmd.AddCommand(watchReplayCmd)
+
+	watchRecordCmd.Flags().StringVar(&watchRecordFile, "file", "", "file to record to (required)")
+	watchRecordCmd.Flags().StringSliceVar(&watchRecordChannels, "channels", nil, "comma-separated channels to subscribe to, e.g. ticker,trade (required)")
+	watchRecordCmd.Flags().StringVar(&watchRecordMarket, "market", "", "market ticker to subscribe to (default: all markets)")
+	watchRecordCmd.Flags().BoolVar(&watchRecordAppend, "append", false, "add to the file instead of replacing it")
+	watchRecordCmd.MarkFlagRequired("file")
+	watchRecordCmd.MarkFlagRequired("channels")
+
+	watchReplayCmd.Flags().StringVar(&watchReplayFile, "file", "", "recording to replay (required)")
+	watchReplayCmd.Flags().StringVar(&watchReplaySpeed, "speed", "1x", "replay speed, e.g. 2x or 0.5x, or max for no waiting")
+	watchReplayCmd.Flags().StringSliceVar(&watchReplayChannels, "channels", nil, "only replay these channels (default: every channel recorded)")
+	watchReplayCmd.MarkFlagRequired("file")
+}
+
+// parseReplaySpeed parses --speed: a positive multiplier with an optional x
+// suffix, or max, which is returned as zero
+func parseReplaySpeed(s string) (float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "max" {
+		return 0, nil
+	}
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(s, "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid --speed %q: use a multiplier such as 2x or 0.5x, or max", s)
+	}
+	return speed, nil
+}
+
+// recordedChannels returns the channels with messages in a recording, in the
+// order they first appear, keeping only those in only when it is not empty
+func recordedChannels(path string, only []websocket.Channel) ([]websocket.Channel, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer f.Close()
+
+	keep := make(map[websocket.Channel]bool, len(only))
+	for _, ch := range only {
+		keep[ch] = true
+	}
+	seen := make(map[websocket.Channel]bool)
+	var channels []websocket.Channel
+	err = websocket.ReadFrames(f, func(frame websocket.Frame) error {
+		msg, err := websocket.ParseMessage(frame.Frame)
+		if err != nil || msg.Channel == "" || seen[msg.Channel] {
+			return nil
+		}
+		seen[msg.Channel] = true
+		if len(only) == 0 || keep[msg.Channel] {
+			channels = append(channels, msg.Channel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return channels, nil
+}
+
+func runWatchRecord(_ *cobra.Command, _ []string) error {
+	channels, err := rawChannels(watchRecordChannels)
+	if err != nil {
+		return err
+	}
+	params := make(map[string]string)
+	if watchRecordMarket != "" {
+		params["market_tickers"] = watchRecordMarket
+	}
+
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return err
+	}
+
+	mode := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if watchRecordAppend {
+		mode = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(watchRecordFile, mode, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", watchRecordFile, err)
+	}
+	defer f.Close()
+	recorder := websocket.NewRecorder(f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	client := newWebSocketClient(opts)
+	client.OnRawMessage(func(data []byte) {
+		if err := recorder.Record(time.Now(), data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			cancel()
+		}
+	})
+	client.OnError(func(err error) {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	})
+
+	if err := client.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer client.Close()
+
+	for _, ch := range channels {
+		if err := client.Subscribe(ctx, ch, params); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", ch, err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Recording %s to %s (Ctrl+C to stop)\n", strings.Join(watchRecordChannels, ", "), watchRecordFile)
+
+	<-ctx.Done()
+	client.Close()
+	frames := recorder.Frames()
+	return ui.Output(
+		GetOutputFormat(),
+		func() { PrintSuccess(fmt.Sprintf("Recorded %d frames to %s", frames, watchRecordFile)) },
+		map[string]any{"file": watchRecordFile, "frames": frames},
+		func() { fmt.Fprintf(ui.Writer(), "%s\t%d\n", watchRecordFile, frames) },
+	)
+}
+
+func runWatchReplay(_ *cobra.Command, _ []string) error {
+	speed, err := parseReplaySpeed(watchReplaySpeed)
+	if err != nil {
+		return err
+	}
+	if err := validateWatchPriceFlags(); err != nil {
+		return err
+	}
+	if err := validateEnvelopeFlag(); err != nil {
+		return err
+	}
+	var only []websocket.Channel
+	if len(watchReplayChannels) > 0 {
+		if only, err = rawChannels(watchReplayChannels); err != nil {
+			return err
+		}
+	}
+	channels, err := recordedChannels(watchReplayFile, only)
+	if err != nil {
+		return err
+	}
+	if len(channels) == 0 {
+		return fmt.Errorf("no channel messages to replay in %s", watchReplayFile)
+	}
+	if len(channels) > 1 && GetOutputFormat() != ui.FormatJSON {
+		defer prefixStreamOutput()()
+	}
+
+	f, err := os.Open(watchReplayFile)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer f.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The client is never connected; it only routes the recorded frames
+	client := websocket.NewClient(websocket.ClientOptions{})
+	client.OnError(func(err error) {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	})
+	tracker := newSessionTracker()
+	registerHandlers(client, channels, tracker)
+
+	if _, err := websocket.Replay(ctx, f, speed, client.HandleFrame); err != nil {
+		return fmt.Errorf("failed to replay %s: %w", watchReplayFile, err)
+	}
+	tracker.finish(nil)
+	return nil
+}
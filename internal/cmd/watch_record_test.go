@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestParseReplaySpeed(t *testing.T) {
+	tests := map[string]float64{"1x": 1, "2x": 2, "0.5X": 0.5, "3": 3, "max": 0}
+	for in, want := range tests {
+		if got, err := parseReplaySpeed(in); err != nil || got != want {
+			t.Errorf("parseReplaySpeed(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"fast", "0x", "-2x", ""} {
+		if _, err := parseReplaySpeed(in); err == nil {
+			t.Errorf("parseReplaySpeed(%q) should fail", in)
+		}
+	}
+}
+
+func TestRecordedChannels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recording := `{"received_at":"2026-03-01T12:00:00Z","frame":{"type":"subscribed","id":1}}
+{"received_at":"2026-03-01T12:00:01Z","frame":{"channel":"trade","data":{}}}
+{"received_at":"2026-03-01T12:00:02Z","frame":{"channel":"ticker","data":{}}}
+{"received_at":"2026-03-01T12:00:03Z","frame":{"channel":"trade","data":{}}}
+{"received_at":"2026-03-01T12:00:04Z","frame":"not json"}
+`
+	if err := os.WriteFile(path, []byte(recording), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	channels, err := recordedChannels(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(channels) != "[trade ticker]" {
+		t.Errorf("channels = %v, want trade then ticker", channels)
+	}
+	channels, _ = recordedChannels(path, []websocket.Channel{websocket.ChannelMarketTicker})
+	if fmt.Sprint(channels) != "[ticker]" {
+		t.Errorf("filtered channels = %v, want ticker", channels)
+	}
+	if _, err := recordedChannels(filepath.Join(t.TempDir(), "missing.jsonl"), nil); err == nil {
+		t.Error("expected an error for a missing recording")
+	}
+}
@@ -0,0 +1,422 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/api"
+	"github.com/6missedcalls/kalshi-cli/internal/notify"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var watchRiskCmd = &cobra.Command{
+	Use:   "risk",
+	Short: "Warn when markets you hold are halted, close early or settle",
+	Long: `Follow the market lifecycle channel for every market you hold a position
+in, and warn when one is:
+
+  halted        trading is paused (deactivated)
+  closing early its close time moves earlier, or it closes before it
+  settling      it closes, is determined or settles
+
+Held markets are re-read from your positions every --interval, along with
+their close times, so a close time moved without a lifecycle message is
+caught too. Markets already halted or settling at start are reported once.
+
+With --cancel-orders your resting orders in a market are cancelled as soon
+as it is halted, closing early or settling. --notify also delivers warnings
+to the destinations configured under alerts.*.`,
+	Example: `  kalshi-cli watch risk
+  kalshi-cli watch risk --cancel-orders --notify
+  kalshi-cli watch risk --interval 30s --json`,
+	Args: cobra.NoArgs,
+	RunE: runWatchRisk,
+}
+
+var (
+	watchRiskInterval time.Duration
+	watchRiskCancel   bool
+	watchRiskNotify   bool
+)
+
+// riskCloseSlack is how much earlier a close time must move, or a market
+// must close, to count as closing early
+const riskCloseSlack = time.Minute
+
+func init() {
+	watchCmd.AddCommand(watchRiskCmd)
+
+	watchRiskCmd.Flags().DurationVar(&watchRiskInterval, "interval", time.Minute, "how often to re-read positions and close times")
+	watchRiskCmd.Flags().BoolVar(&watchRiskCancel, "cancel-orders", false, "cancel your resting orders in a market when it is halted, closing early or settling")
+	watchRiskCmd.Flags().BoolVar(&watchRiskNotify, "notify", false, "also deliver warnings to the destinations configured under alerts.*")
+}
+
+// Kinds of risk warning
+const (
+	riskHalted     = "halted"
+	riskResumed    = "resumed"
+	riskEarlyClose = "early_close"
+	riskSettling   = "settling"
+)
+
+// riskEvent is a warning about one held market
+type riskEvent struct {
+	kind      string
+	ticker    string
+	status    string
+	closeTime time.Time
+	// scheduled is the close time before it moved, for early closes
+	scheduled time.Time
+}
+
+// cancels reports whether the event should cancel resting orders
+func (e riskEvent) cancels() bool {
+	return e.kind != riskResumed
+}
+
+// message renders the event as an alert
+func (e riskEvent) message(now time.Time) notify.Message {
+	data := map[string]any{"alert": e.kind, "ticker": e.ticker, "status": e.status}
+	if !e.closeTime.IsZero() {
+		data["close_time"] = e.closeTime.UTC()
+	}
+
+	var title, body string
+	switch e.kind {
+	case riskHalted:
+		title, body = "Market halted", "trading is halted ("+e.status+")"
+	case riskResumed:
+		title, body = "Market resumed", "trading resumed ("+e.status+")"
+	case riskEarlyClose:
+		title = "Market closing early"
+		body = fmt.Sprintf("now closes %s, was %s", formatMarketTime(e.closeTime), formatMarketTime(e.scheduled))
+		if !e.closeTime.After(now) {
+			body = fmt.Sprintf("closed %s, ahead of %s", formatMarketTime(e.closeTime), formatMarketTime(e.scheduled))
+		}
+		data["scheduled_close_time"] = e.scheduled.UTC()
+	case riskSettling:
+		title, body = "Market settling", "market is "+e.status
+	}
+	return notify.Message{Title: title, Body: fmt.Sprintf("%s: %s", e.ticker, body), Time: now.UTC(), Data: data}
+}
+
+// riskMarket is what is known of a held market
+type riskMarket struct {
+	status    string
+	closeTime time.Time
+	halted    bool
+	settling  bool
+	early     bool
+}
+
+// riskMonitor tracks the lifecycle of held markets and turns changes into
+// warnings. Each condition warns once per market; a halt re-arms on resume.
+type riskMonitor struct {
+	mu      sync.Mutex
+	markets map[string]*riskMarket
+}
+
+func newRiskMonitor() *riskMonitor {
+	return &riskMonitor{markets: make(map[string]*riskMarket)}
+}
+
+// held reports whether a market is being watched
+func (r *riskMonitor) held(ticker string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.markets[ticker]
+	return ok
+}
+
+// count returns how many markets are watched
+func (r *riskMonitor) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.markets)
+}
+
+// hold replaces the set of watched markets, keeping what is known of those
+// still held
+func (r *riskMonitor) hold(tickers []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	markets := make(map[string]*riskMarket, len(tickers))
+	for _, t := range tickers {
+		if m, ok := r.markets[t]; ok {
+			markets[t] = m
+		} else {
+			markets[t] = &riskMarket{}
+		}
+	}
+	r.markets = markets
+}
+
+// observeMarket applies market metadata: its status and close time
+func (r *riskMonitor) observeMarket(m models.Market, now time.Time) []riskEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state, ok := r.markets[m.Ticker]
+	if !ok {
+		return nil
+	}
+
+	var events []riskEvent
+	if !m.CloseTime.IsZero() {
+		if !state.closeTime.IsZero() && !state.early && m.CloseTime.Before(state.closeTime.Add(-riskCloseSlack)) {
+			state.early = true
+			events = append(events, riskEvent{kind: riskEarlyClose, ticker: m.Ticker, status: m.Status, closeTime: m.CloseTime, scheduled: state.closeTime})
+		}
+		state.closeTime = m.CloseTime
+	}
+	return append(events, r.status(m.Ticker, state, m.Status, now)...)
+}
+
+// observeStatus applies a lifecycle status change
+func (r *riskMonitor) observeStatus(ticker, status string, now time.Time) []riskEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state, ok := r.markets[ticker]
+	if !ok || status == "" {
+		return nil
+	}
+	return r.status(ticker, state, status, now)
+}
+
+// status moves a market to a new status; r.mu must be held
+func (r *riskMonitor) status(ticker string, state *riskMarket, status string, now time.Time) []riskEvent {
+	if status == state.status {
+		return nil
+	}
+	state.status = status
+	event := riskEvent{ticker: ticker, status: status, closeTime: state.closeTime}
+
+	var events []riskEvent
+	switch {
+	case marketIsHalted(status):
+		if !state.halted {
+			state.halted = true
+			event.kind = riskHalted
+			events = append(events, event)
+		}
+	case marketIsSettling(status):
+		state.halted = false
+		if status == "closed" && !state.early && state.closeTime.After(now.Add(riskCloseSlack)) {
+			state.early = true
+			early := event
+			early.kind, early.closeTime, early.scheduled = riskEarlyClose, now, state.closeTime
+			events = append(events, early)
+		}
+		if !state.settling {
+			state.settling = true
+			event.kind = riskSettling
+			events = append(events, event)
+		}
+	case marketIsOpen(status):
+		if state.halted {
+			state.halted = false
+			event.kind = riskResumed
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// marketIsHalted reports whether a status means trading is paused
+func marketIsHalted(status string) bool {
+	switch status {
+	case "deactivated", "paused", "halted":
+		return true
+	}
+	return false
+}
+
+// marketIsSettling reports whether a status means the market has closed and
+// is on its way to settlement
+func marketIsSettling(status string) bool {
+	switch status {
+	case "closed", "determined", "settled", "finalized":
+		return true
+	}
+	return false
+}
+
+// riskWatch wires the monitor to the API: it refreshes held markets, emits
+// warnings and cancels orders
+type riskWatch struct {
+	client   *api.Client
+	monitor  *riskMonitor
+	notifier notify.Notifier
+	cancel   bool
+}
+
+// refresh re-reads positions and the metadata of every held market
+func (w *riskWatch) refresh(ctx context.Context) error {
+	positions, err := openPositions(ctx, w.client)
+	if err != nil {
+		return err
+	}
+	tickers := make([]string, len(positions))
+	for i, p := range positions {
+		tickers[i] = p.Ticker
+	}
+	w.monitor.hold(tickers)
+
+	for _, t := range tickers {
+		w.check(ctx, t)
+	}
+	return nil
+}
+
+// check re-reads one market's metadata and handles any warnings
+func (w *riskWatch) check(ctx context.Context, ticker string) {
+	reqCtx, cancel := withTimeout(ctx)
+	market, err := w.client.GetMarket(reqCtx, ticker)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get market %s: %v\n", ticker, err)
+		return
+	}
+	w.handle(ctx, w.monitor.observeMarket(*market, time.Now()))
+}
+
+// handle emits warnings and, with --cancel-orders, cancels resting orders in
+// the affected markets
+func (w *riskWatch) handle(ctx context.Context, events []riskEvent) {
+	now := time.Now()
+	cancelled := make(map[string]bool)
+	for _, e := range events {
+		emitWatchAlerts(w.notifier, []notify.Message{e.message(now)})
+		if w.cancel && e.cancels() && !cancelled[e.ticker] {
+			cancelled[e.ticker] = true
+			w.cancelResting(ctx, e.ticker)
+		}
+	}
+}
+
+// cancelResting cancels every resting order in a market
+func (w *riskWatch) cancelResting(ctx context.Context, ticker string) {
+	var ids []string
+	opts := api.OrdersOptions{Ticker: ticker, Status: string(models.OrderStatusResting), Limit: reconcilePageSize, SubaccountID: ActiveSubaccount()}
+	for {
+		reqCtx, cancel := withTimeout(ctx)
+		page, err := w.client.GetOrders(reqCtx, opts)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get resting orders on %s: %v\n", ticker, err)
+			return
+		}
+		for _, o := range page.Orders {
+			ids = append(ids, o.OrderID)
+		}
+		if page.Cursor == "" || len(page.Orders) == 0 {
+			break
+		}
+		opts.Cursor = page.Cursor
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	cancelled, failed := 0, 0
+	for _, id := range ids {
+		reqCtx, cancel := withTimeout(ctx)
+		_, err := w.client.CancelOrder(reqCtx, id)
+		cancel()
+		if err != nil {
+			failed++
+			if IsVerbose() {
+				fmt.Fprintf(os.Stderr, "Warning: failed to cancel order %s: %v\n", id, err)
+			}
+			continue
+		}
+		cancelled++
+	}
+
+	body := fmt.Sprintf("%s: cancelled %d resting orders", ticker, cancelled)
+	if failed > 0 {
+		body += fmt.Sprintf(", %d failed", failed)
+	}
+	emitWatchAlerts(w.notifier, []notify.Message{{
+		Title: "Orders cancelled",
+		Body:  body,
+		Time:  time.Now().UTC(),
+		Data:  map[string]any{"alert": "cancelled", "ticker": ticker, "cancelled": cancelled, "failed": failed},
+	}})
+}
+
+func runWatchRisk(_ *cobra.Command, _ []string) error {
+	if watchRiskInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := alertContext()
+	defer stop()
+
+	w := &riskWatch{client: client, monitor: newRiskMonitor(), notifier: notify.Multi{}, cancel: watchRiskCancel}
+	if watchRiskNotify {
+		w.notifier = buildNotifier()
+	}
+	if err := w.refresh(ctx); err != nil {
+		return err
+	}
+
+	opts, err := buildClientOptions(GetConfig())
+	if err != nil {
+		return err
+	}
+	wsClient := newWebSocketClient(opts)
+	wsClient.OnError(func(err error) {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	})
+	wsClient.RegisterHandler(websocket.ChannelMarketLifecycle, websocket.HandlerFunc(func(msg websocket.Message) error {
+		var data websocket.MarketLifecycleData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse lifecycle data: %w", err)
+		}
+		if !w.monitor.held(data.Ticker) {
+			return nil
+		}
+		w.handle(ctx, w.monitor.observeStatus(data.Ticker, data.Status, time.Now()))
+		// Close times move without a status change, so re-read them too
+		w.check(ctx, data.Ticker)
+		return nil
+	}))
+
+	if err := wsClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer wsClient.Close()
+	// The lifecycle channel is subscribed unfiltered, since a subscription
+	// carries a single market_tickers value and held markets change
+	if err := wsClient.Subscribe(ctx, websocket.ChannelMarketLifecycle, nil); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", websocket.ChannelMarketLifecycle, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Watching %d held markets for halts, early closes and settlement (Ctrl+C to stop)\n", w.monitor.count())
+
+	ticker := time.NewTicker(watchRiskInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.refresh(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func riskKinds(events []riskEvent) []string {
+	kinds := make([]string, len(events))
+	for i, e := range events {
+		kinds[i] = e.kind
+	}
+	return kinds
+}
+
+func TestRiskMonitor_HaltAndResume(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	r := newRiskMonitor()
+	r.hold([]string{"KXA"})
+
+	if got := r.observeMarket(models.Market{Ticker: "KXA", Status: "active", CloseTime: now.Add(time.Hour)}, now); len(got) != 0 {
+		t.Errorf("open market: %v", riskKinds(got))
+	}
+	if got := r.observeStatus("KXA", "deactivated", now); len(got) != 1 || got[0].kind != riskHalted {
+		t.Errorf("halt: %v", riskKinds(got))
+	}
+	if got := r.observeMarket(models.Market{Ticker: "KXA", Status: "deactivated", CloseTime: now.Add(time.Hour)}, now); len(got) != 0 {
+		t.Errorf("repeated halt: %v", riskKinds(got))
+	}
+	if got := r.observeStatus("KXA", "active", now); len(got) != 1 || got[0].kind != riskResumed || got[0].cancels() {
+		t.Errorf("resume: %v", riskKinds(got))
+	}
+	if got := r.observeStatus("KXB", "deactivated", now); len(got) != 0 {
+		t.Errorf("market not held: %v", riskKinds(got))
+	}
+}
+
+func TestRiskMonitor_EarlyCloseAndSettlement(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("close time moved", func(t *testing.T) {
+		r := newRiskMonitor()
+		r.hold([]string{"KXA"})
+		r.observeMarket(models.Market{Ticker: "KXA", Status: "active", CloseTime: now.Add(24 * time.Hour)}, now)
+
+		got := r.observeMarket(models.Market{Ticker: "KXA", Status: "active", CloseTime: now.Add(time.Hour)}, now)
+		if len(got) != 1 || got[0].kind != riskEarlyClose || !got[0].scheduled.Equal(now.Add(24*time.Hour)) {
+			t.Fatalf("early close: %+v", got)
+		}
+		if got := r.observeStatus("KXA", "closed", now.Add(time.Hour)); len(got) != 1 || got[0].kind != riskSettling {
+			t.Errorf("close at the new time: %v", riskKinds(got))
+		}
+		if got := r.observeStatus("KXA", "settled", now.Add(2*time.Hour)); len(got) != 0 {
+			t.Errorf("settlement warned twice: %v", riskKinds(got))
+		}
+	})
+
+	t.Run("closed ahead of schedule", func(t *testing.T) {
+		r := newRiskMonitor()
+		r.hold([]string{"KXA"})
+		r.observeMarket(models.Market{Ticker: "KXA", Status: "active", CloseTime: now.Add(24 * time.Hour)}, now)
+
+		got := r.observeStatus("KXA", "closed", now)
+		if kinds := riskKinds(got); len(kinds) != 2 || kinds[0] != riskEarlyClose || kinds[1] != riskSettling {
+			t.Errorf("closed early: %v", kinds)
+		}
+	})
+
+	t.Run("already settling at start", func(t *testing.T) {
+		r := newRiskMonitor()
+		r.hold([]string{"KXA"})
+		got := r.observeMarket(models.Market{Ticker: "KXA", Status: "determined", CloseTime: now.Add(-time.Hour)}, now)
+		if len(got) != 1 || got[0].kind != riskSettling {
+			t.Errorf("settling at start: %v", riskKinds(got))
+		}
+	})
+}
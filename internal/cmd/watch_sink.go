@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/6missedcalls/kalshi-cli/internal/tsdb"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var (
+	watchSink       string
+	watchSinkURL    string
+	watchSinkBucket string
+	watchSinkOrg    string
+	watchSinkToken  string
+)
+
+const (
+	// sinkBatchSize and sinkFlushInterval bound how long points wait before
+	// they are written
+	sinkBatchSize     = 500
+	sinkFlushInterval = time.Second
+	// sinkWarnInterval limits how often sink failures are reported
+	sinkWarnInterval = 10 * time.Second
+)
+
+// activeSink receives points from the running watch, if --sink is set
+var activeSink *tsdb.Batcher
+
+func init() {
+	addSinkFlags(watchTickerCmd)
+	addSinkFlags(watchOrderbookCmd)
+	addSinkFlags(watchTradesCmd)
+}
+
+// addSinkFlags registers the time-series sink flags on a watch command
+func addSinkFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&watchSink, "sink", "", "also write each update as a time-series point: influx, timescale or postgres")
+	cmd.Flags().StringVar(&watchSinkURL, "sink-url", "", "sink URL: the InfluxDB base URL, or a postgres:// connection URL")
+	cmd.Flags().StringVar(&watchSinkBucket, "sink-bucket", "kalshi", "InfluxDB bucket")
+	cmd.Flags().StringVar(&watchSinkOrg, "sink-org", "", "InfluxDB organization")
+	cmd.Flags().StringVar(&watchSinkToken, "sink-token", "", "InfluxDB API token (default: $INFLUX_TOKEN)")
+}
+
+// openWatchSink starts the sink chosen with --sink. It returns nil when no
+// sink is set.
+func openWatchSink() (*tsdb.Batcher, error) {
+	if watchSink == "" {
+		if watchSinkURL != "" {
+			return nil, fmt.Errorf("--sink-url requires --sink")
+		}
+		return nil, nil
+	}
+	token := watchSinkToken
+	if token == "" {
+		token = os.Getenv("INFLUX_TOKEN")
+	}
+	sink, err := tsdb.Open(watchSink, tsdb.Options{
+		URL:    watchSinkURL,
+		Bucket: watchSinkBucket,
+		Org:    watchSinkOrg,
+		Token:  token,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tsdb.NewBatcher(sink, sinkBatchSize, sinkFlushInterval, throttledWarning(sinkWarnInterval)), nil
+}
+
+// throttledWarning prints errors to stderr, at most one per interval, so a
+// database outage does not flood the stream
+func throttledWarning(interval time.Duration) func(error) {
+	var mu sync.Mutex
+	var last time.Time
+	return func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if now := time.Now(); now.Sub(last) >= interval {
+			last = now
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+// sinkHandler writes each message to the sink as points, then passes it on
+type sinkHandler struct {
+	next    websocket.Handler
+	channel websocket.Channel
+	sink    *tsdb.Batcher
+}
+
+func (h *sinkHandler) HandleMessage(msg websocket.Message) error {
+	for _, p := range sinkPoints(h.channel, msg.Data, time.Now()) {
+		h.sink.Add(p)
+	}
+	return h.next.HandleMessage(msg)
+}
+
+// sinkPoints maps a ticker, orderbook or trade message to points, stamped
+// with now unless the message carries its own time. Other channels and
+// messages that do not parse give no points.
+func sinkPoints(ch websocket.Channel, data json.RawMessage, now time.Time) []tsdb.Point {
+	switch ch {
+	case websocket.ChannelMarketTicker:
+		var d websocket.TickerData
+		if json.Unmarshal(data, &d) != nil || d.Ticker == "" {
+			return nil
+		}
+		return []tsdb.Point{{
+			Measurement: "kalshi_ticker",
+			Tags:        map[string]string{"ticker": d.Ticker},
+			Fields: map[string]interface{}{
+				"yes_price":     d.YesPrice,
+				"no_price":      d.NoPrice,
+				"yes_bid":       d.YesBid,
+				"yes_ask":       d.YesAsk,
+				"volume":        d.Volume,
+				"open_interest": d.OpenInterest,
+			},
+			Time: now,
+		}}
+	case websocket.ChannelOrderbook:
+		var d websocket.OrderbookData
+		if json.Unmarshal(data, &d) != nil || d.Ticker == "" {
+			return nil
+		}
+		bid, ask := 0, 0
+		if len(d.YesBids) > 0 {
+			bid = d.YesBids[0].Price
+		}
+		if len(d.YesAsks) > 0 {
+			ask = d.YesAsks[0].Price
+		}
+		return []tsdb.Point{{
+			Measurement: "kalshi_orderbook",
+			Tags:        map[string]string{"ticker": d.Ticker},
+			Fields: map[string]interface{}{
+				"yes_bid":   bid,
+				"yes_ask":   ask,
+				"bid_depth": levelsDepth(d.YesBids),
+				"ask_depth": levelsDepth(d.YesAsks),
+			},
+			Time: now,
+		}}
+	case websocket.ChannelPublicTrades:
+		var d websocket.TradeData
+		if json.Unmarshal(data, &d) != nil || d.Ticker == "" {
+			return nil
+		}
+		return []tsdb.Point{{
+			Measurement: "kalshi_trade",
+			Tags:        map[string]string{"ticker": d.Ticker, "taker_side": d.TakerSide},
+			Fields: map[string]interface{}{
+				"price":    d.Price,
+				"count":    d.Count,
+				"trade_id": d.TradeID,
+			},
+			Time: tradeTime(d.Timestamp, now),
+		}}
+	}
+	return nil
+}
+
+// levelsDepth returns the contracts resting across levels
+func levelsDepth(levels []websocket.OrderbookLevel) int {
+	depth := 0
+	for _, l := range levels {
+		depth += l.Quantity
+	}
+	return depth
+}
+
+// tradeTime parses a trade's ts, in unix seconds or RFC3339, falling back to
+// now
+func tradeTime(ts string, now time.Time) time.Time {
+	if secs, err := strconv.ParseInt(ts, 10, 64); err == nil && secs > 0 {
+		return time.Unix(secs, 0).UTC()
+	}
+	if t, err := time.Parse(time.RFC3339, ts); err == nil {
+		return t
+	}
+	return now
+}
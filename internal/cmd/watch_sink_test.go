@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/tsdb"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestSinkPoints(t *testing.T) {
+	now := time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)
+
+	ticker := sinkPoints(websocket.ChannelMarketTicker,
+		json.RawMessage(`{"ticker":"KXCPI-26MAR-T0.3","yes_price":42,"yes_bid":41,"yes_ask":44,"volume":1520}`), now)
+	if len(ticker) != 1 {
+		t.Fatalf("ticker points = %v", ticker)
+	}
+	if got := tsdb.LineProtocol(ticker[0]); got != "kalshi_ticker,ticker=KXCPI-26MAR-T0.3 no_price=0i,open_interest=0i,volume=1520i,yes_ask=44i,yes_bid=41i,yes_price=42i 1772375400000000000" {
+		t.Errorf("ticker point = %s", got)
+	}
+
+	book := sinkPoints(websocket.ChannelOrderbook,
+		json.RawMessage(`{"ticker":"KXCPI-26MAR-T0.3","yes_bids":[{"price":41,"quantity":100},{"price":40,"quantity":50}],"yes_asks":[{"price":44,"quantity":20}]}`), now)
+	if len(book) != 1 || book[0].Fields["bid_depth"] != 150 || book[0].Fields["ask_depth"] != 20 || book[0].Fields["yes_bid"] != 41 {
+		t.Errorf("orderbook points = %+v", book)
+	}
+
+	trade := sinkPoints(websocket.ChannelPublicTrades,
+		json.RawMessage(`{"trade_id":"t1","ticker":"KXCPI-26MAR-T0.3","price":43,"count":10,"taker_side":"yes","ts":"1772375000"}`), now)
+	if len(trade) != 1 || !trade[0].Time.Equal(time.Unix(1772375000, 0)) || trade[0].Tags["taker_side"] != "yes" {
+		t.Errorf("trade points = %+v", trade)
+	}
+
+	if got := sinkPoints(websocket.ChannelUserFills, json.RawMessage(`{"ticker":"X"}`), now); got != nil {
+		t.Errorf("fills should give no points, got %v", got)
+	}
+	if got := sinkPoints(websocket.ChannelMarketTicker, json.RawMessage(`not json`), now); got != nil {
+		t.Errorf("a bad message should give no points, got %v", got)
+	}
+}
+
+func TestTradeTime(t *testing.T) {
+	now := time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)
+	if got := tradeTime("2026-03-01T14:29:00Z", now); !got.Equal(now.Add(-time.Minute)) {
+		t.Errorf("RFC3339 ts = %v", got)
+	}
+	if got := tradeTime("", now); !got.Equal(now) {
+		t.Errorf("missing ts = %v, want now", got)
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/6missedcalls/kalshi-cli/internal/ui"
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+var (
+	watchBlockSize int
+	watchOddLot    int
+	watchVWAP      bool
+)
+
+func init() {
+	watchTradesCmd.Flags().IntVar(&watchBlockSize, "block-size", 100, "highlight prints of at least this many contracts as blocks")
+	watchTradesCmd.Flags().IntVar(&watchOddLot, "odd-lot", 10, "dim prints of fewer than this many contracts (0 = never)")
+	watchTradesCmd.Flags().BoolVar(&watchVWAP, "vwap", false, "append the running session VWAP per market to each trade")
+}
+
+// validateTapeFlags checks the size bucket flags of watch trades
+func validateTapeFlags() error {
+	if watchBlockSize < 1 {
+		return fmt.Errorf("--block-size must be at least 1")
+	}
+	if watchOddLot < 0 {
+		return fmt.Errorf("--odd-lot cannot be negative")
+	}
+	if watchOddLot > watchBlockSize {
+		return fmt.Errorf("--odd-lot (%d) cannot exceed --block-size (%d)", watchOddLot, watchBlockSize)
+	}
+	return nil
+}
+
+// tradeSize is the size bucket of a print
+type tradeSize int
+
+const (
+	tradeSizeRound tradeSize = iota
+	tradeSizeOddLot
+	tradeSizeBlock
+)
+
+// tradeTape keeps per-market session volume and VWAP for the trades feed
+type tradeTape struct {
+	blockSize int
+	oddLot    int
+	vwap      bool
+
+	volume   map[string]int
+	notional map[string]int
+}
+
+func newTradeTape(blockSize, oddLot int, vwap bool) *tradeTape {
+	return &tradeTape{
+		blockSize: blockSize,
+		oddLot:    oddLot,
+		vwap:      vwap,
+		volume:    make(map[string]int),
+		notional:  make(map[string]int),
+	}
+}
+
+// newTradeTapeFromFlags returns the tape for watch trades
+func newTradeTapeFromFlags() *tradeTape {
+	return newTradeTape(watchBlockSize, watchOddLot, watchVWAP)
+}
+
+// observe adds a print and returns the market's session volume and VWAP in cents
+func (t *tradeTape) observe(trade websocket.TradeData) (int, float64) {
+	t.volume[trade.Ticker] += trade.Count
+	t.notional[trade.Ticker] += trade.Count * trade.Price
+
+	volume := t.volume[trade.Ticker]
+	if volume == 0 {
+		return 0, 0
+	}
+	return volume, float64(t.notional[trade.Ticker]) / float64(volume)
+}
+
+// bucket classifies a print by size
+func (t *tradeTape) bucket(count int) tradeSize {
+	switch {
+	case count >= t.blockSize:
+		return tradeSizeBlock
+	case count < t.oddLot:
+		return tradeSizeOddLot
+	}
+	return tradeSizeRound
+}
+
+// tableLine renders a print for table output: aggressor color, size bucket
+// highlighting, session volume and optionally VWAP
+func (t *tradeTape) tableLine(trade websocket.TradeData, volume int, vwap float64, prices watchPrices) string {
+	side := ui.PriceDownStyle.Render("SELL")
+	if trade.TakerSide == "yes" {
+		side = ui.PriceUpStyle.Render("BUY")
+	}
+
+	size := strconv.Itoa(trade.Count)
+	switch t.bucket(trade.Count) {
+	case tradeSizeBlock:
+		size = ui.BoldStyle.Render(size + " BLOCK")
+	case tradeSizeOddLot:
+		size = ui.MutedStyle.Render(size)
+	}
+
+	line := fmt.Sprintf("%s %s @ %s | Vol %s", side, size, prices.price(trade.Price), formatVolume(volume))
+	if t.vwap {
+		line += " | VWAP " + formatPriceAs(roundCents(vwap), prices.format)
+	}
+	return line
+}
+
+// plainFields renders the fields appended to plain output
+func (t *tradeTape) plainFields(volume int, vwap float64) string {
+	if !t.vwap {
+		return ""
+	}
+	return fmt.Sprintf(" vol=%d vwap=%s", volume, strconv.FormatFloat(vwap, 'f', 2, 64))
+}
+
+// roundCents rounds a price to hundredths of a cent
+func roundCents(cents float64) float64 {
+	return float64(int(cents*100+0.5)) / 100
+}
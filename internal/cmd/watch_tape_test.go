@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/6missedcalls/kalshi-cli/internal/websocket"
+)
+
+func TestTradeTape_VolumeAndVWAPPerMarket(t *testing.T) {
+	tape := newTradeTape(100, 10, true)
+
+	tape.observe(websocket.TradeData{Ticker: "A", Price: 40, Count: 10})
+	tape.observe(websocket.TradeData{Ticker: "B", Price: 90, Count: 1})
+	volume, vwap := tape.observe(websocket.TradeData{Ticker: "A", Price: 50, Count: 30})
+
+	if volume != 40 {
+		t.Errorf("expected session volume 40 for A, got %d", volume)
+	}
+	if vwap != 47.5 {
+		t.Errorf("expected VWAP 47.5 for A, got %v", vwap)
+	}
+	if got := tape.plainFields(volume, vwap); got != " vol=40 vwap=47.50" {
+		t.Errorf("plainFields = %q", got)
+	}
+}
+
+func TestTradeTape_Bucket(t *testing.T) {
+	tape := newTradeTape(100, 10, false)
+
+	tests := []struct {
+		count    int
+		expected tradeSize
+	}{
+		{1, tradeSizeOddLot},
+		{9, tradeSizeOddLot},
+		{10, tradeSizeRound},
+		{99, tradeSizeRound},
+		{100, tradeSizeBlock},
+		{5000, tradeSizeBlock},
+	}
+	for _, tt := range tests {
+		if got := tape.bucket(tt.count); got != tt.expected {
+			t.Errorf("bucket(%d) = %v, expected %v", tt.count, got, tt.expected)
+		}
+	}
+
+	if newTradeTape(100, 0, false).bucket(1) != tradeSizeRound {
+		t.Error("expected --odd-lot 0 to disable odd-lot dimming")
+	}
+}
+
+func TestTradeTape_TableLine(t *testing.T) {
+	tape := newTradeTape(100, 10, true)
+	trade := websocket.TradeData{Ticker: "A", Price: 45, Count: 250, TakerSide: "yes"}
+	volume, vwap := tape.observe(trade)
+
+	line := tape.tableLine(trade, volume, vwap, watchPrices{format: "cents"})
+	for _, want := range []string{"BUY", "250 BLOCK", "@ 45¢", "Vol 250", "VWAP 45¢"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("expected %q in %q", want, line)
+		}
+	}
+}
+
+func TestValidateTapeFlags(t *testing.T) {
+	defer func(b, o int) { watchBlockSize, watchOddLot = b, o }(watchBlockSize, watchOddLot)
+
+	watchBlockSize, watchOddLot = 100, 10
+	if err := validateTapeFlags(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	watchBlockSize, watchOddLot = 5, 10
+	if err := validateTapeFlags(); err == nil {
+		t.Error("expected --odd-lot above --block-size to be rejected")
+	}
+	watchBlockSize, watchOddLot = 0, 0
+	if err := validateTapeFlags(); err == nil {
+		t.Error("expected --block-size 0 to be rejected")
+	}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	bundleFormat     = "kalshi-cli-bundle"
+	bundleVersion    = 1
+	bundleKDF        = "pbkdf2-sha256"
+	bundleIterations = 600000
+	// bundleMaxIterations bounds the work a crafted bundle can demand before
+	// its passphrase is even checked
+	bundleMaxIterations = 10 * bundleIterations
+	bundleSaltSize      = 16
+	bundleKeySize       = 32
+)
+
+// ErrBadPassphrase is returned when a bundle cannot be decrypted
+var ErrBadPassphrase = errors.New("wrong passphrase or corrupted bundle")
+
+// Bundle is everything needed to move a kalshi-cli setup to another machine
+type Bundle struct {
+	CreatedAt   time.Time    `json:"created_at"`
+	Credentials *Credentials `json:"credentials,omitempty"`
+	Config      []byte       `json:"config,omitempty"`
+	KeyFile     *KeyFile     `json:"key_file,omitempty"`
+}
+
+// KeyFile is a private key file referenced by private_key_path
+type KeyFile struct {
+	Path string `json:"path"`
+	PEM  string `json:"pem"`
+}
+
+// bundleEnvelope is the on-disk form of an encrypted bundle
+type bundleEnvelope struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// SealBundle encrypts a bundle with AES-256-GCM under a key derived from
+// passphrase
+func SealBundle(b Bundle, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase is required")
+	}
+
+	plaintext, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	env := bundleEnvelope{
+		Format:     bundleFormat,
+		Version:    bundleVersion,
+		KDF:        bundleKDF,
+		Iterations: bundleIterations,
+		Salt:       make([]byte, bundleSaltSize),
+	}
+	if _, err := rand.Read(env.Salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	aead, err := bundleCipher(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	env.Ciphertext = aead.Seal(nil, env.Nonce, plaintext, bundleAAD())
+
+	return json.MarshalIndent(env, "", "  ")
+}
+
+// OpenBundle decrypts a bundle written by SealBundle
+func OpenBundle(data []byte, passphrase string) (*Bundle, error) {
+	var env bundleEnvelope
+	if err := json.Unmarshal(data, &env); err != nil || env.Format != bundleFormat {
+		return nil, fmt.Errorf("not a kalshi-cli bundle")
+	}
+	if env.Version != bundleVersion || env.KDF != bundleKDF {
+		return nil, fmt.Errorf("unsupported bundle version %d (%s)", env.Version, env.KDF)
+	}
+	if env.Iterations <= 0 || env.Iterations > bundleMaxIterations {
+		return nil, fmt.Errorf("unsupported bundle: %d key derivation iterations (at most %d)", env.Iterations, bundleMaxIterations)
+	}
+
+	aead, err := bundleCipher(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, ErrBadPassphrase
+	}
+	plaintext, err := aead.Open(nil, env.Nonce, env.Ciphertext, bundleAAD())
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(plaintext, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	return &b, nil
+}
+
+func bundleCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, bundleKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// bundleAAD binds the ciphertext to the envelope format and version
+func bundleAAD() []byte {
+	return []byte(fmt.Sprintf("%s/%d", bundleFormat, bundleVersion))
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	b := Bundle{
+		CreatedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Credentials: &Credentials{APIKeyID: "key-1", PrivateKey: "pem"},
+		Config:      []byte("api:\n  production: true\n"),
+		KeyFile:     &KeyFile{Path: "/tmp/key.pem", PEM: "key pem"},
+	}
+
+	data, err := SealBundle(b, "correct horse")
+	if err != nil {
+		t.Fatalf("SealBundle: %v", err)
+	}
+
+	got, err := OpenBundle(data, "correct horse")
+	if err != nil {
+		t.Fatalf("OpenBundle: %v", err)
+	}
+	if got.Credentials.APIKeyID != "key-1" || string(got.Config) != string(b.Config) || got.KeyFile.PEM != "key pem" {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+
+	if _, err := OpenBundle(data, "wrong"); !errors.Is(err, ErrBadPassphrase) {
+		t.Errorf("wrong passphrase: got %v, want ErrBadPassphrase", err)
+	}
+	if _, err := OpenBundle([]byte("not json"), "correct horse"); err == nil {
+		t.Error("expected an error for garbage input")
+	}
+}
+
+func TestOpenBundle_RefusesExcessiveIterations(t *testing.T) {
+	data, err := SealBundle(Bundle{CreatedAt: time.Now()}, "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env bundleEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+	env.Iterations = math.MaxInt32
+	crafted, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := OpenBundle(crafted, "pass"); err == nil || !strings.Contains(err.Error(), "iterations") {
+		t.Errorf("OpenBundle() = %v, want the iteration count refused", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("OpenBundle() derived a key before refusing the bundle")
+	}
+}
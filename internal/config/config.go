@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -23,23 +24,93 @@ type Config struct {
 	API     APIConfig     `mapstructure:"api"`
 	Output  OutputConfig  `mapstructure:"output"`
 	Defaults DefaultsConfig `mapstructure:"defaults"`
+	Usage    UsageConfig    `mapstructure:"usage"`
+	Alerts   AlertsConfig   `mapstructure:"alerts"`
+	Audit    AuditConfig    `mapstructure:"audit"`
+	Risk     RiskConfig     `mapstructure:"risk"`
+	Network  NetworkConfig  `mapstructure:"network"`
+	WebSocket WebSocketConfig `mapstructure:"websocket"`
+	Aliases  map[string]string `mapstructure:"aliases"`
+
+	// Queries are saved command lines run by 'query run'
+	Queries map[string]string `mapstructure:"queries"`
+
+	// Watchlists are named lists of market tickers (see 'watch grid')
+	Watchlists map[string][]string `mapstructure:"watchlists"`
+
+	// ExtraHeaders are added to every REST request and the WebSocket handshake
+	ExtraHeaders map[string]string `mapstructure:"extra_headers"`
+
+	// RequireEnvBanner makes the production banner ignore --no-banner
+	RequireEnvBanner bool `mapstructure:"require_env_banner"`
 }
 
 type APIConfig struct {
 	Production bool          `mapstructure:"production"`
 	Timeout    time.Duration `mapstructure:"timeout"`
+	ReadOnly   bool          `mapstructure:"read_only"`
+	// RateLimit caps requests per second across the session (0 = no limit)
+	RateLimit int `mapstructure:"rate_limit"`
+	// MaxRetries is how often a 429, 5xx or network error is retried
+	MaxRetries int `mapstructure:"max_retries"`
+	// BaseURL replaces the Kalshi REST host, e.g. with an internal gateway
+	BaseURL string `mapstructure:"base_url"`
+}
+
+// WebSocketConfig overrides the WebSocket endpoint
+type WebSocketConfig struct {
+	URL string `mapstructure:"url"`
 }
 
 type OutputConfig struct {
-	Format string `mapstructure:"format"`
-	Color  bool   `mapstructure:"color"`
+	Format      string `mapstructure:"format"`
+	Color       bool   `mapstructure:"color"`
+	PriceFormat string `mapstructure:"price_format"`
+	ShowMid     bool   `mapstructure:"show_mid"`
 }
 
 type DefaultsConfig struct {
-	Limit int `mapstructure:"limit"`
+	Limit      int `mapstructure:"limit"`
+	Subaccount int `mapstructure:"subaccount"`
+}
+
+// UsageConfig controls local usage statistics (see 'kalshi-cli stats')
+type UsageConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// AuditConfig controls the local audit log of mutating requests (see 'kalshi-cli audit')
+type AuditConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// RiskConfig holds limits checked by --precheck before orders are submitted
+type RiskConfig struct {
+	// MaxExposure caps positions plus resting orders, in cents (0 = no cap)
+	MaxExposure int `mapstructure:"max_exposure"`
+}
+
+// NetworkConfig controls how REST and WebSocket connections reach Kalshi
+type NetworkConfig struct {
+	// Proxy is an http, https or socks5 proxy URL; empty honors HTTP(S)_PROXY
+	Proxy string `mapstructure:"proxy"`
+	// CABundle is a PEM file of extra root certificates to trust
+	CABundle string `mapstructure:"ca_bundle"`
+	// InsecureSkipVerify disables TLS verification; refused in production
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+}
+
+// AlertsConfig holds default notification destinations for 'kalshi-cli alerts'
+type AlertsConfig struct {
+	WebhookURL      string `mapstructure:"webhook_url"`
+	SlackWebhookURL string `mapstructure:"slack_webhook_url"`
+	Desktop         bool   `mapstructure:"desktop"`
 }
 
 func (c *Config) BaseURL() string {
+	if c.API.BaseURL != "" {
+		return strings.TrimSuffix(c.API.BaseURL, "/")
+	}
 	if c.API.Production {
 		return ProdBaseURL
 	}
@@ -47,6 +118,9 @@ func (c *Config) BaseURL() string {
 }
 
 func (c *Config) WebSocketURL() string {
+	if c.WebSocket.URL != "" {
+		return c.WebSocket.URL
+	}
 	if c.API.Production {
 		return ProdWSURL
 	}
@@ -64,25 +138,23 @@ func Load(cfgFile string) (*Config, error) {
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
-		home, err := os.UserHomeDir()
+		configDir, err := ConfigDir()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
+			return nil, err
 		}
 
-		configDir := filepath.Join(home, ".kalshi")
-		if err := os.MkdirAll(configDir, 0700); err != nil {
-			return nil, fmt.Errorf("failed to create config directory: %w", err)
+		// On a read-only filesystem, as in a container, there can be no
+		// config file: settings come from the environment alone
+		if err := os.MkdirAll(configDir, 0700); err == nil {
+			viper.AddConfigPath(configDir)
 		}
-
-		viper.AddConfigPath(configDir)
 		viper.SetConfigName("config")
 		viper.SetConfigType("yaml")
 	}
 
 	setDefaults()
 
-	viper.AutomaticEnv()
-	viper.SetEnvPrefix("KALSHI")
+	bindEnv()
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -94,6 +166,9 @@ func Load(cfgFile string) (*Config, error) {
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	if err := applyEnvironment(&cfg, os.Getenv); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
@@ -101,32 +176,111 @@ func Load(cfgFile string) (*Config, error) {
 func setDefaults() {
 	viper.SetDefault("api.production", false)
 	viper.SetDefault("api.timeout", 30*time.Second)
+	viper.SetDefault("api.read_only", false)
+	viper.SetDefault("api.rate_limit", 10)
+	viper.SetDefault("api.max_retries", 5)
+	viper.SetDefault("api.base_url", "")
+	viper.SetDefault("websocket.url", "")
 	viper.SetDefault("output.format", "table")
 	viper.SetDefault("output.color", true)
+	viper.SetDefault("output.price_format", "dollars")
+	viper.SetDefault("output.show_mid", false)
 	viper.SetDefault("defaults.limit", 50)
+	viper.SetDefault("defaults.subaccount", 0)
+	viper.SetDefault("usage.enabled", true)
+	viper.SetDefault("audit.enabled", true)
+	viper.SetDefault("alerts.webhook_url", "")
+	viper.SetDefault("alerts.slack_webhook_url", "")
+	viper.SetDefault("alerts.desktop", false)
+	viper.SetDefault("risk.max_exposure", 0)
+	viper.SetDefault("network.proxy", "")
+	viper.SetDefault("network.ca_bundle", "")
+	viper.SetDefault("network.insecure_skip_verify", false)
+	viper.SetDefault("require_env_banner", false)
 }
 
-func Save(cfg *Config) error {
-	home, err := os.UserHomeDir()
+// Save writes values to the config file, each under its dotted key such as
+// "output.format", and leaves the rest of the file as it is. Only the file
+// is read back, so flag and environment overrides in effect for this run,
+// such as --prod, --read-only, --subaccount or KALSHI_API_KEY_ID, never
+// end up in it.
+func Save(values map[string]any) error {
+	configPath, err := ConfigFile()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
-	configPath := filepath.Join(home, ".kalshi", "config.yaml")
+	file, out := viper.New(), viper.New()
+	if filepath.Ext(configPath) == "" {
+		file.SetConfigType("yaml")
+		out.SetConfigType("yaml")
+	}
+	file.SetConfigFile(configPath)
+	if err := file.ReadInConfig(); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	settings := file.AllSettings()
+	for key, value := range values {
+		setPath(settings, strings.Split(key, "."), value)
+		// Keep this run's settings in step with the file
+		viper.Set(key, value)
+	}
+
+	if err := out.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return out.WriteConfigAs(configPath)
+}
+
+// setPath sets value at path in nested settings, replacing anything that
+// is in the way
+func setPath(settings map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := settings[key].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			settings[key] = next
+		}
+		settings = next
+	}
+	settings[path[len(path)-1]] = value
+}
+
+// SaveAliases replaces the user-defined command aliases in the config file
+func SaveAliases(aliases map[string]string) error {
+	return Save(map[string]any{"aliases": aliases})
+}
+
+// SaveQueries replaces the saved queries in the config file
+func SaveQueries(queries map[string]string) error {
+	return Save(map[string]any{"queries": queries})
+}
 
-	viper.Set("api.production", cfg.API.Production)
-	viper.Set("api.timeout", cfg.API.Timeout)
-	viper.Set("output.format", cfg.Output.Format)
-	viper.Set("output.color", cfg.Output.Color)
-	viper.Set("defaults.limit", cfg.Defaults.Limit)
+// ConfigFile returns the config file settings are read from and saved to:
+// the --config file if one was given, otherwise config.yaml in ConfigDir
+func ConfigFile() (string, error) {
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+	return configFilePath()
+}
 
-	return viper.WriteConfigAs(configPath)
+func configFilePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
 }
 
+// ConfigDir returns the directory holding config.yaml (see ResolvePaths)
 func ConfigDir() (string, error) {
-	home, err := os.UserHomeDir()
+	p, err := ResolvePaths()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".kalshi"), nil
+	return p.Config, nil
 }
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// loadForSave loads config from a temporary directory holding contents as
+// config.yaml and returns the file's path
+func loadForSave(t *testing.T, contents string) string {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+	SetDirOverride(dir)
+	t.Cleanup(func() { SetDirOverride("") })
+
+	path := filepath.Join(dir, "config.yaml")
+	if contents != "" {
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Load(""); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readConfigFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSave_WritesOnlyTheFileAndTheChangedKey(t *testing.T) {
+	t.Setenv("KALSHI_API_KEY_ID", "envkey")
+	t.Setenv("KALSHI_PROXY", "http://corp:3128")
+	t.Setenv("KALSHI_NO_KEYRING", "1")
+	path := loadForSave(t, "aliases:\n  bal: portfolio balance\noutput:\n  color: false\n")
+
+	if err := Save(map[string]any{"output.format": "json"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := readConfigFile(t, path)
+	for _, leaked := range []string{"envkey", "corp:3128", "no_keyring", "production", "rate_limit"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("config file picked up %q:\n%s", leaked, got)
+		}
+	}
+	for _, want := range []string{"format: json", "color: false", "bal: portfolio balance"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config file missing %q:\n%s", want, got)
+		}
+	}
+	if viper.GetString("output.format") != "json" {
+		t.Error("Save did not update the running settings")
+	}
+}
+
+func TestSaveAliases_RemovesDeletedAliases(t *testing.T) {
+	path := loadForSave(t, "aliases:\n  bal: portfolio balance\n  pos: portfolio positions\n")
+
+	if err := SaveAliases(map[string]string{"pos": "portfolio positions"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readConfigFile(t, path); strings.Contains(got, "bal") || !strings.Contains(got, "pos: portfolio positions") {
+		t.Errorf("aliases not replaced:\n%s", got)
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CheckEndpoints rejects base URL, WebSocket URL and header settings that
+// cannot work or would point a production config at demo (or the reverse)
+func (c *Config) CheckEndpoints() error {
+	if c.API.BaseURL != "" {
+		u, err := url.Parse(c.API.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("api.base_url must be an absolute http(s) URL, got %q", c.API.BaseURL)
+		}
+		if u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("api.base_url must not have a query or fragment")
+		}
+		if strings.Contains(u.Path, "/trade-api") {
+			return fmt.Errorf("api.base_url must not include /trade-api/v2; it is added to every request path")
+		}
+		if err := c.checkKalshiHost("api.base_url", u.Host); err != nil {
+			return err
+		}
+	}
+
+	if c.WebSocket.URL != "" {
+		u, err := url.Parse(c.WebSocket.URL)
+		if err != nil || (u.Scheme != "ws" && u.Scheme != "wss") || u.Host == "" {
+			return fmt.Errorf("websocket.url must be an absolute ws(s) URL, got %q", c.WebSocket.URL)
+		}
+		if err := c.checkKalshiHost("websocket.url", u.Host); err != nil {
+			return err
+		}
+	}
+
+	for name, value := range c.ExtraHeaders {
+		if !validHeaderName(name) {
+			return fmt.Errorf("extra_headers: %q is not a valid header name", name)
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return fmt.Errorf("extra_headers: value of %s contains invalid characters", name)
+		}
+		canonical := http.CanonicalHeaderKey(name)
+		if strings.HasPrefix(canonical, "Kalshi-Access-") || canonical == "Host" || canonical == "Content-Type" {
+			return fmt.Errorf("extra_headers: %s is set by kalshi-cli and cannot be overridden", canonical)
+		}
+	}
+	return nil
+}
+
+// checkKalshiHost catches a Kalshi host from the other environment
+func (c *Config) checkKalshiHost(key, host string) error {
+	const demoHost, prodHost = "demo-api.kalshi.co", "api.elections.kalshi.com"
+	host = strings.ToLower(host)
+	if c.API.Production && host == demoHost {
+		return fmt.Errorf("%s points at the demo host %s but production is selected", key, demoHost)
+	}
+	if !c.API.Production && host == prodHost {
+		return fmt.Errorf("%s points at the production host %s but demo is selected (use --prod)", key, prodHost)
+	}
+	return nil
+}
+
+// validHeaderName reports whether name is an RFC 7230 token
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > 0x7e || r <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckEndpoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "defaults"},
+		{
+			name: "gateway",
+			cfg: Config{
+				API:          APIConfig{BaseURL: "https://gw.corp.example/kalshi"},
+				WebSocket:    WebSocketConfig{URL: "wss://gw.corp.example/kalshi/ws"},
+				ExtraHeaders: map[string]string{"X-Gateway-Token": "t"},
+			},
+		},
+		{name: "relative base url", cfg: Config{API: APIConfig{BaseURL: "gw.corp/kalshi"}}, wantErr: "absolute http(s) URL"},
+		{name: "base url with prefix", cfg: Config{API: APIConfig{BaseURL: "https://gw/trade-api/v2"}}, wantErr: "must not include /trade-api/v2"},
+		{name: "prod host on demo", cfg: Config{API: APIConfig{BaseURL: "https://api.elections.kalshi.com"}}, wantErr: "production host"},
+		{name: "demo host on prod", cfg: Config{API: APIConfig{Production: true}, WebSocket: WebSocketConfig{URL: "wss://demo-api.kalshi.co/trade-api/ws/v2"}}, wantErr: "demo host"},
+		{name: "http websocket", cfg: Config{WebSocket: WebSocketConfig{URL: "https://gw/ws"}}, wantErr: "ws(s) URL"},
+		{name: "signing header", cfg: Config{ExtraHeaders: map[string]string{"kalshi-access-key": "x"}}, wantErr: "cannot be overridden"},
+		{name: "bad header name", cfg: Config{ExtraHeaders: map[string]string{"x gateway": "x"}}, wantErr: "not a valid header name"},
+		{name: "header injection", cfg: Config{ExtraHeaders: map[string]string{"x-a": "1\r\nx-b: 2"}}, wantErr: "invalid characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.CheckEndpoints()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
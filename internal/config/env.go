@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EnvEnvironment selects demo or production, e.g. in a container
+const EnvEnvironment = "KALSHI_ENV"
+
+// ErrKeyringDisabled is returned by NewKeyringStore under --no-keyring
+var ErrKeyringDisabled = errors.New("the system keyring is disabled by --no-keyring or KALSHI_NO_KEYRING")
+
+// keyringDisabled is set by --no-keyring
+var keyringDisabled bool
+
+// DisableKeyring makes NewKeyringStore fail, so credentials can only come
+// from config, the environment or a credentials provider
+func DisableKeyring() {
+	keyringDisabled = true
+}
+
+// KeyringDisabled reports whether DisableKeyring has been called
+func KeyringDisabled() bool {
+	return keyringDisabled
+}
+
+// envAliases are short names for the settings most often set from the
+// environment. Every setting can also be set as KALSHI_<SECTION>_<KEY>.
+var envAliases = map[string]string{
+	"api.base_url":        "KALSHI_BASE_URL",
+	"api.timeout":         "KALSHI_TIMEOUT",
+	"api.read_only":       "KALSHI_READ_ONLY",
+	"websocket.url":       "KALSHI_WS_URL",
+	"output.format":       "KALSHI_OUTPUT",
+	"defaults.subaccount": "KALSHI_SUBACCOUNT",
+	"network.proxy":       "KALSHI_PROXY",
+	"network.ca_bundle":   "KALSHI_CA_BUNDLE",
+}
+
+// EnvName returns the environment variable for a config key, e.g.
+// KALSHI_OUTPUT_FORMAT for output.format
+func EnvName(key string) string {
+	return "KALSHI_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// EnvAlias returns the short environment variable for a config key, if any
+func EnvAlias(key string) string {
+	return envAliases[key]
+}
+
+// envOnlyKeys are settings without defaults that are read from the
+// environment too
+var envOnlyKeys = []string{
+	"api_key_id", "private_key_path",
+	"credentials_provider", "credentials_path", "credentials_region",
+	"pkcs11.module", "pkcs11.token_label", "pkcs11.key_label",
+	"no_keyring",
+}
+
+// bindEnv lets every setting with a default be set from the environment,
+// by its full name first and then by its alias. Settings are bound one by
+// one rather than with viper.AutomaticEnv, which would let KALSHI_OUTPUT
+// hide every output.* setting.
+func bindEnv() {
+	for _, key := range append(viper.AllKeys(), envOnlyKeys...) {
+		names := []string{key, EnvName(key)}
+		if alias := envAliases[key]; alias != "" {
+			names = append(names, alias)
+		}
+		viper.BindEnv(names...)
+	}
+}
+
+// applyEnvironment applies KALSHI_ENV: demo, prod or production
+func applyEnvironment(cfg *Config, getenv func(string) string) error {
+	switch strings.ToLower(getenv(EnvEnvironment)) {
+	case "":
+	case "demo":
+		cfg.API.Production = false
+	case "prod", "production":
+		cfg.API.Production = true
+	default:
+		return fmt.Errorf("invalid %s %q: use demo or production", EnvEnvironment, getenv(EnvEnvironment))
+	}
+	return nil
+}
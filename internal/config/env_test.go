@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestEnvName(t *testing.T) {
+	if got := EnvName("output.price_format"); got != "KALSHI_OUTPUT_PRICE_FORMAT" {
+		t.Errorf("EnvName() = %s", got)
+	}
+	if got := EnvAlias("api.base_url"); got != "KALSHI_BASE_URL" {
+		t.Errorf("EnvAlias() = %s", got)
+	}
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	tests := []struct {
+		value   string
+		start   bool
+		want    bool
+		wantErr bool
+	}{
+		{"", true, true, false},
+		{"demo", true, false, false},
+		{"PROD", false, true, false},
+		{"production", false, true, false},
+		{"staging", false, false, true},
+	}
+	for _, tt := range tests {
+		cfg := &Config{API: APIConfig{Production: tt.start}}
+		err := applyEnvironment(cfg, func(string) string { return tt.value })
+		if (err != nil) != tt.wantErr {
+			t.Errorf("applyEnvironment(%q) error = %v", tt.value, err)
+			continue
+		}
+		if err == nil && cfg.API.Production != tt.want {
+			t.Errorf("applyEnvironment(%q) production = %v, want %v", tt.value, cfg.API.Production, tt.want)
+		}
+	}
+}
+
+func TestLoad_FromEnvironment(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	SetDirOverride(t.TempDir())
+	t.Cleanup(func() { SetDirOverride("") })
+
+	t.Setenv("KALSHI_ENV", "production")
+	t.Setenv("KALSHI_OUTPUT", "json")
+	t.Setenv("KALSHI_BASE_URL", "https://gateway.example.com/")
+	t.Setenv("KALSHI_TIMEOUT", "5s")
+	t.Setenv("KALSHI_OUTPUT_PRICE_FORMAT", "cents")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.API.Production || cfg.Output.Format != "json" || cfg.Output.PriceFormat != "cents" {
+		t.Errorf("Load() = %+v", cfg)
+	}
+	if cfg.BaseURL() != "https://gateway.example.com" || cfg.API.Timeout != 5*time.Second {
+		t.Errorf("BaseURL() = %s, timeout = %s", cfg.BaseURL(), cfg.API.Timeout)
+	}
+}
@@ -22,6 +22,9 @@ type KeyringStore struct {
 }
 
 func NewKeyringStore() (*KeyringStore, error) {
+	if keyringDisabled {
+		return nil, ErrKeyringDisabled
+	}
 	ring, err := keyring.Open(keyring.Config{
 		ServiceName: serviceName,
 		KeychainTrustApplication: true,
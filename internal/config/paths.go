@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const appName = "kalshi-cli"
+
+// Paths are the directories kalshi-cli reads and writes
+type Paths struct {
+	// Config holds config.yaml
+	Config string `json:"config"`
+	// Data holds the audit and usage logs and position snapshots
+	Data string `json:"data"`
+	// Cache holds files that can be deleted at any time
+	Cache string `json:"cache"`
+	// Source says how the paths were chosen
+	Source string `json:"source"`
+}
+
+// dirOverride is set by --config-dir
+var dirOverride string
+
+// SetDirOverride puts config, data and cache under dir, as --config-dir does.
+// An empty dir restores the default resolution.
+func SetDirOverride(dir string) {
+	dirOverride = dir
+}
+
+// ResolvePaths returns the directories for this machine: --config-dir or
+// KALSHI_CONFIG_DIR if set, then ~/.kalshi if it already exists, then the
+// XDG base directories or the platform equivalents
+func ResolvePaths() (Paths, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Paths{}, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	_, statErr := os.Stat(filepath.Join(home, ".kalshi"))
+	return resolvePaths(runtime.GOOS, os.Getenv, home, dirOverride, statErr == nil), nil
+}
+
+func resolvePaths(goos string, getenv func(string) string, home, override string, legacyExists bool) Paths {
+	single := func(dir, source string) Paths {
+		return Paths{Config: dir, Data: dir, Cache: filepath.Join(dir, "cache"), Source: source}
+	}
+
+	if override != "" {
+		return single(override, "--config-dir")
+	}
+	if dir := getenv("KALSHI_CONFIG_DIR"); dir != "" {
+		return single(dir, "KALSHI_CONFIG_DIR")
+	}
+	// Installs from before XDG support keep everything in ~/.kalshi
+	if legacyExists {
+		return single(filepath.Join(home, ".kalshi"), "~/.kalshi")
+	}
+
+	var config, data, cache string
+	switch goos {
+	case "windows":
+		roaming, local := getenv("APPDATA"), getenv("LOCALAPPDATA")
+		if roaming == "" {
+			roaming = filepath.Join(home, "AppData", "Roaming")
+		}
+		if local == "" {
+			local = filepath.Join(home, "AppData", "Local")
+		}
+		config, data, cache = roaming, local, local
+	case "darwin":
+		support := filepath.Join(home, "Library", "Application Support")
+		config, data, cache = support, support, filepath.Join(home, "Library", "Caches")
+	default:
+		config = filepath.Join(home, ".config")
+		data = filepath.Join(home, ".local", "share")
+		cache = filepath.Join(home, ".cache")
+	}
+
+	// XDG variables win on every platform when set to an absolute path
+	source := "platform default"
+	xdg := func(name string, dir *string) {
+		if v := getenv(name); filepath.IsAbs(v) {
+			*dir = v
+			source = "XDG"
+		}
+	}
+	xdg("XDG_CONFIG_HOME", &config)
+	xdg("XDG_DATA_HOME", &data)
+	xdg("XDG_CACHE_HOME", &cache)
+
+	p := Paths{
+		Config: filepath.Join(config, appName),
+		Data:   filepath.Join(data, appName),
+		Cache:  filepath.Join(cache, appName),
+		Source: source,
+	}
+	if goos == "windows" {
+		p.Cache = filepath.Join(cache, appName, "cache")
+	}
+	return p
+}
+
+// DataDir returns the directory for the audit and usage logs and snapshots
+func DataDir() (string, error) {
+	p, err := ResolvePaths()
+	if err != nil {
+		return "", err
+	}
+	return p.Data, nil
+}
+
+// CacheDir returns the directory for disposable cached files
+func CacheDir() (string, error) {
+	p, err := ResolvePaths()
+	if err != nil {
+		return "", err
+	}
+	return p.Cache, nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePaths(t *testing.T) {
+	home := "/home/u"
+	tests := []struct {
+		name     string
+		goos     string
+		env      map[string]string
+		override string
+		legacy   bool
+		want     Paths
+	}{
+		{
+			name:     "override wins",
+			goos:     "linux",
+			env:      map[string]string{"KALSHI_CONFIG_DIR": "/env", "XDG_CONFIG_HOME": "/xdg"},
+			override: "/srv/bot",
+			legacy:   true,
+			want:     Paths{Config: "/srv/bot", Data: "/srv/bot", Cache: "/srv/bot/cache", Source: "--config-dir"},
+		},
+		{
+			name: "env dir",
+			goos: "linux",
+			env:  map[string]string{"KALSHI_CONFIG_DIR": "/env"},
+			want: Paths{Config: "/env", Data: "/env", Cache: "/env/cache", Source: "KALSHI_CONFIG_DIR"},
+		},
+		{
+			name:   "existing ~/.kalshi",
+			goos:   "linux",
+			env:    map[string]string{"XDG_CONFIG_HOME": "/xdg"},
+			legacy: true,
+			want:   Paths{Config: "/home/u/.kalshi", Data: "/home/u/.kalshi", Cache: "/home/u/.kalshi/cache", Source: "~/.kalshi"},
+		},
+		{
+			name: "linux defaults",
+			goos: "linux",
+			want: Paths{Config: "/home/u/.config/kalshi-cli", Data: "/home/u/.local/share/kalshi-cli", Cache: "/home/u/.cache/kalshi-cli", Source: "platform default"},
+		},
+		{
+			name: "xdg",
+			goos: "linux",
+			env:  map[string]string{"XDG_CONFIG_HOME": "/c", "XDG_DATA_HOME": "/d", "XDG_CACHE_HOME": "relative"},
+			want: Paths{Config: "/c/kalshi-cli", Data: "/d/kalshi-cli", Cache: "/home/u/.cache/kalshi-cli", Source: "XDG"},
+		},
+		{
+			name: "macos defaults",
+			goos: "darwin",
+			want: Paths{
+				Config: "/home/u/Library/Application Support/kalshi-cli",
+				Data:   "/home/u/Library/Application Support/kalshi-cli",
+				Cache:  "/home/u/Library/Caches/kalshi-cli",
+				Source: "platform default",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			got := resolvePaths(tt.goos, getenv, home, tt.override, tt.legacy)
+			want := tt.want
+			want.Config, want.Data, want.Cache = filepath.FromSlash(want.Config), filepath.FromSlash(want.Data), filepath.FromSlash(want.Cache)
+			if got != want {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Package daemon keeps the state of kalshi-cli jobs running in the
+// background: a spec, a pid file and a log file per job, all in one
+// directory under the data directory.
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dirName = "daemons"
+
+// Restart policies
+const (
+	RestartOnFailure = "on-failure"
+	RestartAlways    = "always"
+	RestartNever     = "never"
+)
+
+// Spec describes a job and what has happened to it so far
+type Spec struct {
+	Name     string    `json:"name"`
+	Args     []string  `json:"args"`
+	Restart  string    `json:"restart"`
+	Created  time.Time `json:"created"`
+	Started  time.Time `json:"started,omitzero"`
+	Restarts int       `json:"restarts"`
+	ChildPID int       `json:"child_pid,omitempty"`
+	LastExit string    `json:"last_exit,omitempty"`
+	ExitedAt time.Time `json:"exited_at,omitzero"`
+	Stopped  bool      `json:"stopped,omitempty"`
+	LogFile  string    `json:"log_file"`
+	PIDFile  string    `json:"pid_file"`
+	WorkDir  string    `json:"work_dir"`
+}
+
+// DefaultDir returns the daemon state location inside the data directory
+func DefaultDir(dataDir string) string {
+	return filepath.Join(dataDir, dirName)
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidName checks that a job name is safe to use in file names
+func ValidName(name string) error {
+	if !namePattern.MatchString(name) || len(name) > 64 {
+		return fmt.Errorf("invalid daemon name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// ValidRestart checks a restart policy
+func ValidRestart(policy string) error {
+	switch policy {
+	case RestartOnFailure, RestartAlways, RestartNever:
+		return nil
+	}
+	return fmt.Errorf("invalid restart policy %q: use %s, %s or %s", policy, RestartOnFailure, RestartAlways, RestartNever)
+}
+
+// Manager reads and writes job state in a directory
+type Manager struct {
+	dir string
+}
+
+// NewManager returns a manager rooted at dir
+func NewManager(dir string) *Manager {
+	return &Manager{dir: dir}
+}
+
+// Dir returns the state directory
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+func (m *Manager) specPath(name string) string {
+	return filepath.Join(m.dir, name+".json")
+}
+
+// PIDPath returns the pid file of a job's supervisor
+func (m *Manager) PIDPath(name string) string {
+	return filepath.Join(m.dir, name+".pid")
+}
+
+// LogPath returns the log file of a job
+func (m *Manager) LogPath(name string) string {
+	return filepath.Join(m.dir, name+".log")
+}
+
+// Save writes a job spec, replacing any previous one atomically
+func (m *Manager) Save(spec *Spec) error {
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create daemon directory: %w", err)
+	}
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode daemon spec: %w", err)
+	}
+	if err := writeFileAtomic(m.specPath(spec.Name), data); err != nil {
+		return fmt.Errorf("failed to write daemon spec: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic replaces path with data so readers never see a partial file
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads a job spec. It returns an error wrapping os.ErrNotExist for an
+// unknown job.
+func (m *Manager) Load(name string) (*Spec, error) {
+	data, err := os.ReadFile(m.specPath(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no daemon named %q: %w", name, err)
+		}
+		return nil, fmt.Errorf("failed to read daemon spec: %w", err)
+	}
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse daemon spec %s: %w", m.specPath(name), err)
+	}
+	return &spec, nil
+}
+
+// List returns every job spec, sorted by name
+func (m *Manager) List() ([]Spec, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read daemon directory: %w", err)
+	}
+	var specs []Spec
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		spec, err := m.Load(name)
+		if err != nil {
+			continue
+		}
+		specs = append(specs, *spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+	return specs, nil
+}
+
+// WritePID records the pid of a job's supervisor
+func (m *Manager) WritePID(name string, pid int) error {
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create daemon directory: %w", err)
+	}
+	return os.WriteFile(m.PIDPath(name), []byte(strconv.Itoa(pid)+"\n"), 0600)
+}
+
+// ReadPID returns the pid of a job's supervisor, or 0 if it has none
+func (m *Manager) ReadPID(name string) int {
+	data, err := os.ReadFile(m.PIDPath(name))
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0
+	}
+	return pid
+}
+
+// RemovePID deletes a job's pid file
+func (m *Manager) RemovePID(name string) {
+	os.Remove(m.PIDPath(name))
+}
+
+// Running returns the pid of a job's supervisor if it is alive, or 0. A pid
+// file left behind by a supervisor that died is removed.
+func (m *Manager) Running(name string) int {
+	pid := m.ReadPID(name)
+	if pid == 0 {
+		return 0
+	}
+	if !Alive(pid) {
+		m.RemovePID(name)
+		return 0
+	}
+	return pid
+}
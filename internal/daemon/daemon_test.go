@@ -0,0 +1,179 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{7, time.Minute},
+		{50, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.failures); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestShouldRestart(t *testing.T) {
+	failed := errors.New("exit 1")
+	tests := []struct {
+		policy string
+		err    error
+		want   bool
+	}{
+		{RestartOnFailure, failed, true},
+		{RestartOnFailure, nil, false},
+		{RestartAlways, nil, true},
+		{RestartNever, failed, false},
+	}
+	for _, tt := range tests {
+		if got := shouldRestart(tt.policy, tt.err); got != tt.want {
+			t.Errorf("shouldRestart(%q, %v) = %v, want %v", tt.policy, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for _, name := range []string{"fills", "btc-alerts", "mm_1.demo"} {
+		if err := ValidName(name); err != nil {
+			t.Errorf("ValidName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "../etc", "a/b", "-x", "with space"} {
+		if err := ValidName(name); err == nil {
+			t.Errorf("ValidName(%q) should fail", name)
+		}
+	}
+}
+
+func TestManager_SpecsAndPIDs(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "daemons"))
+
+	if specs, err := m.List(); err != nil || len(specs) != 0 {
+		t.Fatalf("List() on a missing directory = %v, %v", specs, err)
+	}
+	for _, name := range []string{"b", "a"} {
+		if err := m.Save(&Spec{Name: name, Args: []string{"watch", "fills"}, Restart: RestartOnFailure}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	specs, err := m.List()
+	if err != nil || len(specs) != 2 || specs[0].Name != "a" || specs[1].Args[1] != "fills" {
+		t.Fatalf("List() = %+v, %v", specs, err)
+	}
+	if _, err := m.Load("c"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load(unknown) error = %v, want ErrNotExist", err)
+	}
+
+	if m.Running("a") != 0 {
+		t.Error("a job without a pid file should not be running")
+	}
+	if err := m.WritePID("a", os.Getpid()); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Running("a"); got != os.Getpid() {
+		t.Errorf("Running() = %d, want %d", got, os.Getpid())
+	}
+}
+
+func TestManager_Health(t *testing.T) {
+	m := NewManager(filepath.Join(t.TempDir(), "daemons"))
+	if _, err := m.LoadHealth("fills"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadHealth(unreported) error = %v, want ErrNotExist", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	want := Health{PID: 42, Started: now, Updated: now, LastSuccess: now, LastUnauthorized: now.Add(-time.Minute)}
+	if err := m.SaveHealth("fills", want); err != nil {
+		t.Fatalf("SaveHealth failed: %v", err)
+	}
+	got, err := m.LoadHealth("fills")
+	if err != nil || !got.LastSuccess.Equal(want.LastSuccess) || got.PID != 42 {
+		t.Fatalf("LoadHealth() = %+v, %v", got, err)
+	}
+	if got.AuthFailed() {
+		t.Error("a success after the 401 should clear AuthFailed")
+	}
+	got.LastUnauthorized = now.Add(time.Second)
+	if !got.AuthFailed() {
+		t.Error("a 401 after the last success should set AuthFailed")
+	}
+
+	// The health file must not be listed as a job
+	if specs, _ := m.List(); len(specs) != 0 {
+		t.Errorf("List() = %+v, want no jobs", specs)
+	}
+}
+
+func TestSupervisor_RestartsOnFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	m := NewManager(t.TempDir())
+	marker := filepath.Join(t.TempDir(), "ran-once")
+	spec := &Spec{Name: "flaky", Restart: RestartOnFailure}
+
+	// Fails the first time, succeeds the second
+	script := `if [ -e "$1" ]; then exit 0; fi; touch "$1"; exit 3`
+	sup := &Supervisor{
+		Manager: m,
+		Spec:    spec,
+		Command: func(ctx context.Context) *exec.Cmd {
+			return exec.CommandContext(ctx, "sh", "-c", script, "sh", marker)
+		},
+	}
+	if err := sup.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if spec.Restarts != 1 || spec.LastExit != "exit 0" || spec.ChildPID != 0 {
+		t.Errorf("spec after run = restarts %d, last exit %q, child %d", spec.Restarts, spec.LastExit, spec.ChildPID)
+	}
+	saved, err := m.Load("flaky")
+	if err != nil || saved.Restarts != 1 {
+		t.Errorf("saved spec = %+v, %v", saved, err)
+	}
+}
+
+func TestSupervisor_StopsOnCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	spec := &Spec{Name: "long", Restart: RestartAlways}
+	ctx, cancel := context.WithCancel(context.Background())
+	sup := &Supervisor{
+		Manager: NewManager(t.TempDir()),
+		Spec:    spec,
+		Command: func(ctx context.Context) *exec.Cmd {
+			return exec.CommandContext(ctx, "sleep", "30")
+		},
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- sup.Run(ctx) }()
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil || !spec.Stopped || spec.Restarts != 0 {
+			t.Errorf("Run() = %v, stopped %v, restarts %d", err, spec.Stopped, spec.Restarts)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("supervisor did not stop")
+	}
+}
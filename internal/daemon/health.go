@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Health is what a running job last reported about its connection to Kalshi
+type Health struct {
+	PID     int       `json:"pid"`
+	Started time.Time `json:"started"`
+	Updated time.Time `json:"updated"`
+	// LastSuccess is the last successful REST response or WebSocket frame
+	LastSuccess time.Time `json:"last_success,omitzero"`
+	// LastUnauthorized is the last 401 response; auth is bad while it is
+	// later than LastSuccess
+	LastUnauthorized time.Time `json:"last_unauthorized,omitzero"`
+}
+
+// AuthFailed reports whether the latest word on the credentials was a 401
+func (h Health) AuthFailed() bool {
+	return !h.LastUnauthorized.IsZero() && h.LastUnauthorized.After(h.LastSuccess)
+}
+
+// HealthPath returns the health file a job writes while it runs
+func (m *Manager) HealthPath(name string) string {
+	return filepath.Join(m.dir, name+".health")
+}
+
+// SaveHealth writes a job's health report
+func (m *Manager) SaveHealth(name string, h Health) error {
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create daemon directory: %w", err)
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to encode health: %w", err)
+	}
+	if err := writeFileAtomic(m.HealthPath(name), data); err != nil {
+		return fmt.Errorf("failed to write health: %w", err)
+	}
+	return nil
+}
+
+// LoadHealth reads a job's health report. It returns an error wrapping
+// os.ErrNotExist if the job has not reported yet.
+func (m *Manager) LoadHealth(name string) (*Health, error) {
+	data, err := os.ReadFile(m.HealthPath(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s has not reported its health: %w", name, err)
+		}
+		return nil, fmt.Errorf("failed to read health: %w", err)
+	}
+	var h Health
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("failed to parse health %s: %w", m.HealthPath(name), err)
+	}
+	return &h, nil
+}
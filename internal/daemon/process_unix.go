@@ -0,0 +1,30 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// DetachAttr starts a process in its own session, so it survives the
+// terminal that started it
+func DetachAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// Alive reports whether a process with pid exists
+func Alive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// Terminate asks a process to exit
+func Terminate(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
@@ -0,0 +1,34 @@
+//go:build windows
+
+package daemon
+
+import (
+	"os"
+	"syscall"
+)
+
+const (
+	createNewProcessGroup = 0x00000200
+	detachedProcess       = 0x00000008
+)
+
+// DetachAttr starts a process without a console, so it survives the
+// terminal that started it
+func DetachAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: createNewProcessGroup | detachedProcess}
+}
+
+// Alive reports whether a process with pid exists
+func Alive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	p.Release()
+	return true
+}
+
+// Terminate stops a process. Windows has no SIGTERM, so it is killed.
+func Terminate(p *os.Process) error {
+	return p.Kill()
+}
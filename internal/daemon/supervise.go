@@ -0,0 +1,155 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const (
+	// minBackoff and maxBackoff bound the wait before a restart
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+
+	// stableAfter is how long a run must last to reset the backoff
+	stableAfter = time.Minute
+
+	// stopGrace is how long a job has to exit after being asked to stop
+	stopGrace = 10 * time.Second
+)
+
+// Backoff returns the wait before restart number failures in a row
+func Backoff(failures int) time.Duration {
+	d := minBackoff
+	for i := 1; i < failures && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
+
+// shouldRestart applies a restart policy to how a run ended
+func shouldRestart(policy string, err error) bool {
+	switch policy {
+	case RestartAlways:
+		return true
+	case RestartNever:
+		return false
+	}
+	return err != nil
+}
+
+// Supervisor runs a job's command until ctx is done, restarting it according
+// to the job's policy and recording each run in the spec
+type Supervisor struct {
+	Manager *Manager
+	Spec    *Spec
+	// Command builds the process for one run with exec.CommandContext, so
+	// cancelling ctx stops it
+	Command func(ctx context.Context) *exec.Cmd
+	// Log receives the supervisor's own notes
+	Log io.Writer
+}
+
+// Run supervises the job until ctx is done or the policy says to stop
+func (s *Supervisor) Run(ctx context.Context) error {
+	failures := 0
+	for {
+		cmd := s.Command(ctx)
+		cmd.Cancel = func() error { return Terminate(cmd.Process) }
+		cmd.WaitDelay = stopGrace
+
+		started := time.Now()
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start %s: %w", s.Spec.Name, err)
+		}
+		s.Spec.ChildPID = cmd.Process.Pid
+		s.Spec.Started = started
+		s.save()
+		s.note("started pid %d", cmd.Process.Pid)
+
+		err := cmd.Wait()
+		s.Spec.ChildPID = 0
+		s.Spec.ExitedAt = time.Now()
+		s.Spec.LastExit = exitText(err)
+
+		if ctx.Err() != nil {
+			s.Spec.Stopped = true
+			s.save()
+			s.note("stopped (%s)", s.Spec.LastExit)
+			return nil
+		}
+		if !shouldRestart(s.Spec.Restart, err) {
+			s.save()
+			s.note("exited (%s), not restarting (restart=%s)", s.Spec.LastExit, s.Spec.Restart)
+			return nil
+		}
+
+		if time.Since(started) >= stableAfter {
+			failures = 0
+		}
+		failures++
+		wait := Backoff(failures)
+		s.Spec.Restarts++
+		s.save()
+		s.note("exited (%s), restarting in %s", s.Spec.LastExit, wait)
+
+		select {
+		case <-ctx.Done():
+			s.Spec.Stopped = true
+			s.save()
+			s.note("stopped")
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (s *Supervisor) save() {
+	if err := s.Manager.Save(s.Spec); err != nil {
+		s.note("%v", err)
+	}
+}
+
+func (s *Supervisor) note(format string, args ...any) {
+	if s.Log == nil {
+		return
+	}
+	fmt.Fprintf(s.Log, "%s [daemon %s] %s\n", time.Now().UTC().Format(time.RFC3339), s.Spec.Name, fmt.Sprintf(format, args...))
+}
+
+// exitText describes how a run ended
+func exitText(err error) string {
+	if err == nil {
+		return "exit 0"
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if code := exitErr.ExitCode(); code >= 0 {
+			return fmt.Sprintf("exit %d", code)
+		}
+		return exitErr.String()
+	}
+	return err.Error()
+}
+
+// Stop asks a supervisor to stop its job and waits up to timeout for it to
+// exit. It reports whether the supervisor is gone.
+func Stop(pid int, timeout time.Duration) (bool, error) {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return true, nil
+	}
+	if err := Terminate(p); err != nil && Alive(pid) {
+		return false, fmt.Errorf("failed to signal pid %d: %w", pid, err)
+	}
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !Alive(pid) {
+			return true, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return !Alive(pid), nil
+}
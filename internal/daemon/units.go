@@ -0,0 +1,159 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Service manager formats for Unit
+const (
+	FormatSystemd = "systemd"
+	FormatLaunchd = "launchd"
+)
+
+// Unit describes a job to be run by the system's service manager instead of
+// a kalshi-cli supervisor
+type Unit struct {
+	Name    string
+	Exec    []string // binary followed by its arguments
+	Env     []string // KEY=value pairs
+	WorkDir string
+	Restart string
+	LogFile string // launchd only; systemd logs to the journal
+}
+
+// ServiceName returns the systemd unit name for a job
+func ServiceName(name string) string {
+	return "kalshi-cli-" + name + ".service"
+}
+
+// LaunchdLabel returns the launchd label for a job
+func LaunchdLabel(name string) string {
+	return "com.kalshi-cli." + name
+}
+
+// InstallPath returns where a unit for the current user belongs: the systemd
+// user unit directory or ~/Library/LaunchAgents
+func InstallPath(format, name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	switch format {
+	case FormatSystemd:
+		dir := os.Getenv("XDG_CONFIG_HOME")
+		if dir == "" {
+			dir = filepath.Join(home, ".config")
+		}
+		return filepath.Join(dir, "systemd", "user", ServiceName(name)), nil
+	case FormatLaunchd:
+		return filepath.Join(home, "Library", "LaunchAgents", LaunchdLabel(name)+".plist"), nil
+	}
+	return "", fmt.Errorf("invalid format %q: use systemd or launchd", format)
+}
+
+// Render returns the unit in the given format
+func (u Unit) Render(format string) (string, error) {
+	if err := ValidRestart(u.Restart); err != nil {
+		return "", err
+	}
+	switch format {
+	case FormatSystemd:
+		return u.systemd(), nil
+	case FormatLaunchd:
+		return u.launchd(), nil
+	}
+	return "", fmt.Errorf("invalid format %q: use systemd or launchd", format)
+}
+
+func (u Unit) systemd() string {
+	restart := map[string]string{
+		RestartOnFailure: "on-failure",
+		RestartAlways:    "always",
+		RestartNever:     "no",
+	}[u.Restart]
+
+	var b strings.Builder
+	b.WriteString("[Unit]\n")
+	fmt.Fprintf(&b, "Description=kalshi-cli %s\n", u.Name)
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("Wants=network-online.target\n")
+	b.WriteString("\n[Service]\n")
+	b.WriteString("Type=simple\n")
+	args := make([]string, len(u.Exec))
+	for i, a := range u.Exec {
+		args[i] = systemdQuote(a)
+	}
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(args, " "))
+	if u.WorkDir != "" {
+		fmt.Fprintf(&b, "WorkingDirectory=%s\n", systemdQuote(u.WorkDir))
+	}
+	for _, kv := range u.Env {
+		fmt.Fprintf(&b, "Environment=%s\n", systemdQuote(kv))
+	}
+	fmt.Fprintf(&b, "Restart=%s\n", restart)
+	b.WriteString("RestartSec=5\n")
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=default.target\n")
+	return b.String()
+}
+
+// systemdQuote quotes a word for a unit file when it needs it. '%' and '$'
+// are doubled so systemd does not expand them.
+func systemdQuote(s string) string {
+	s = strings.NewReplacer("%", "%%", "$", "$$").Replace(s)
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\;") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func (u Unit) launchd() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	plistString(&b, "Label", LaunchdLabel(u.Name))
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, a := range u.Exec {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(a))
+	}
+	b.WriteString("\t</array>\n")
+	if u.WorkDir != "" {
+		plistString(&b, "WorkingDirectory", u.WorkDir)
+	}
+	if len(u.Env) > 0 {
+		b.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		for _, kv := range u.Env {
+			k, v, _ := strings.Cut(kv, "=")
+			fmt.Fprintf(&b, "\t\t<key>%s</key>\n\t\t<string>%s</string>\n", xmlEscape(k), xmlEscape(v))
+		}
+		b.WriteString("\t</dict>\n")
+	}
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	switch u.Restart {
+	case RestartAlways:
+		b.WriteString("\t<key>KeepAlive</key>\n\t<true/>\n")
+	case RestartOnFailure:
+		b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	}
+	b.WriteString("\t<key>ThrottleInterval</key>\n\t<integer>5</integer>\n")
+	if u.LogFile != "" {
+		plistString(&b, "StandardOutPath", u.LogFile)
+		plistString(&b, "StandardErrorPath", u.LogFile)
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+func plistString(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "\t<key>%s</key>\n\t<string>%s</string>\n", key, xmlEscape(value))
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+
+func xmlEscape(s string) string {
+	return xmlEscaper.Replace(s)
+}
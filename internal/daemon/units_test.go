@@ -0,0 +1,72 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnit_Systemd(t *testing.T) {
+	u := Unit{
+		Name:    "fills",
+		Exec:    []string{"/usr/local/bin/kalshi-cli", "--no-input", "watch", "fills", "--label", "50% off $5"},
+		Env:     []string{"KALSHI_DAEMON=fills", "KALSHI_CONFIG_DIR=/home/me/my config"},
+		WorkDir: "/home/me",
+		Restart: RestartNever,
+	}
+	got, err := u.Render(FormatSystemd)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	for _, want := range []string{
+		"Description=kalshi-cli fills\n",
+		`ExecStart=/usr/local/bin/kalshi-cli --no-input watch fills --label "50%% off $$5"` + "\n",
+		"WorkingDirectory=/home/me\n",
+		"Environment=KALSHI_DAEMON=fills\n",
+		`Environment="KALSHI_CONFIG_DIR=/home/me/my config"` + "\n",
+		"Restart=no\n",
+		"WantedBy=default.target\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("systemd unit missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestUnit_Launchd(t *testing.T) {
+	u := Unit{
+		Name:    "fills",
+		Exec:    []string{"/usr/local/bin/kalshi-cli", "watch", "a&b"},
+		Env:     []string{"KALSHI_DAEMON=fills"},
+		Restart: RestartOnFailure,
+		LogFile: "/tmp/fills.log",
+	}
+	got, err := u.Render(FormatLaunchd)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	for _, want := range []string{
+		"<string>com.kalshi-cli.fills</string>",
+		"<string>a&amp;b</string>",
+		"<key>KALSHI_DAEMON</key>\n\t\t<string>fills</string>",
+		"<key>SuccessfulExit</key>\n\t\t<false/>",
+		"<key>StandardOutPath</key>\n\t<string>/tmp/fills.log</string>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("launchd plist missing %q:\n%s", want, got)
+		}
+	}
+
+	u.Restart = RestartNever
+	if got, _ := u.Render(FormatLaunchd); strings.Contains(got, "KeepAlive") {
+		t.Error("never restart should not set KeepAlive")
+	}
+}
+
+func TestUnit_RenderErrors(t *testing.T) {
+	if _, err := (Unit{Name: "x", Restart: RestartAlways}).Render("upstart"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if _, err := (Unit{Name: "x", Restart: "sometimes"}).Render(FormatSystemd); err == nil {
+		t.Error("expected an error for an unknown restart policy")
+	}
+}
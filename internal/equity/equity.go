@@ -0,0 +1,121 @@
+// Package equity keeps a local history of account balance and portfolio
+// value, which the Kalshi API only reports for the present moment.
+package equity
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const fileName = "equity.jsonl"
+
+// Record is the account's value at one point in time. Money is in cents.
+type Record struct {
+	Time           time.Time `json:"time"`
+	Environment    string    `json:"environment"`
+	Subaccount     int       `json:"subaccount"`
+	Balance        int       `json:"balance"`
+	PortfolioValue int       `json:"portfolio_value"`
+	Exposure       int       `json:"exposure"`
+	Positions      int       `json:"positions"`
+}
+
+// Equity is cash plus the value of open positions
+func (r Record) Equity() int {
+	return r.Balance + r.PortfolioValue
+}
+
+// DefaultPath returns the equity history location inside the data directory
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, fileName)
+}
+
+// Append adds a record to the history at path and syncs it to disk
+func Append(path string, r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal equity record: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create equity history directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open equity history: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write equity history: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync equity history: %w", err)
+	}
+	return nil
+}
+
+// Load returns the records for the environment and subaccount taken at or
+// after since, oldest first. A missing file is an empty history, and
+// malformed lines are skipped so one bad write never hides the rest.
+func Load(path, environment string, subaccount int, since time.Time) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open equity history: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil || r.Time.IsZero() {
+			continue
+		}
+		if r.Environment != environment || r.Subaccount != subaccount || r.Time.Before(since) {
+			continue
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read equity history: %w", err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	return records, nil
+}
+
+// Downsample splits the time spanned by records into n equal buckets and
+// keeps the last record of each, so a long history fits a chart. Records must
+// be oldest first; n or fewer records are returned unchanged.
+func Downsample(records []Record, n int) []Record {
+	if n <= 0 || len(records) <= n {
+		return records
+	}
+	first, last := records[0].Time, records[len(records)-1].Time
+	width := last.Sub(first) / time.Duration(n)
+	if width <= 0 {
+		return records[len(records)-n:]
+	}
+
+	var out []Record
+	bucket := -1
+	for _, r := range records {
+		b := min(int(r.Time.Sub(first)/width), n-1)
+		if b == bucket {
+			out[len(out)-1] = r
+			continue
+		}
+		bucket = b
+		out = append(out, r)
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package equity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if records, err := Load(path, "demo", 0, start); err != nil || records != nil {
+		t.Fatalf("Load on missing file = %v, %v; want nil, nil", records, err)
+	}
+
+	for _, r := range []Record{
+		{Time: start.Add(2 * time.Hour), Environment: "demo", Balance: 300},
+		{Time: start, Environment: "demo", Balance: 100, PortfolioValue: 50},
+		{Time: start.Add(time.Hour), Environment: "prod", Balance: 999},
+		{Time: start.Add(time.Hour), Environment: "demo", Subaccount: 1, Balance: 999},
+		{Time: start.Add(-time.Hour), Environment: "demo", Balance: 1},
+	} {
+		if err := Append(path, r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	f.WriteString("{not json\n")
+	f.Close()
+
+	records, err := Load(path, "demo", 0, start)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Load() returned %d records, want 2: %+v", len(records), records)
+	}
+	if records[0].Equity() != 150 || records[1].Balance != 300 {
+		t.Errorf("Load() = %+v, want oldest first", records)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("history file mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+	if filepath.Base(path) != "equity.jsonl" {
+		t.Errorf("DefaultPath() = %s", path)
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var records []Record
+	for i := 0; i < 100; i++ {
+		records = append(records, Record{Time: start.Add(time.Duration(i) * time.Hour), Balance: i})
+	}
+
+	got := Downsample(records, 10)
+	if len(got) != 10 {
+		t.Fatalf("Downsample() returned %d records, want 10", len(got))
+	}
+	if got[len(got)-1].Balance != 99 {
+		t.Errorf("last bucket ends at %d, want the newest record", got[len(got)-1].Balance)
+	}
+	for i := 1; i < len(got); i++ {
+		if !got[i].Time.After(got[i-1].Time) {
+			t.Fatalf("Downsample() out of order at %d: %+v", i, got)
+		}
+	}
+
+	if got := Downsample(records[:5], 10); len(got) != 5 {
+		t.Errorf("Downsample() of a short history = %d records, want 5", len(got))
+	}
+}
@@ -0,0 +1,48 @@
+// Package filelock takes exclusive locks on files shared between processes,
+// such as the audit log and the exit triggers that the CLI, the daemon and
+// monitors all write to.
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Lock blocks until it holds an exclusive lock on f. The lock is advisory:
+// it only keeps out other processes that also take it.
+func Lock(f *os.File) error {
+	if err := lock(f); err != nil {
+		return fmt.Errorf("failed to lock %s: %w", f.Name(), err)
+	}
+	return nil
+}
+
+// Unlock releases a lock taken with Lock
+func Unlock(f *os.File) error {
+	return unlock(f)
+}
+
+// Acquire locks the file at path, creating it and its directory if needed,
+// and returns a function that releases the lock. Use it to guard files that
+// are replaced by rename, whose own lock would not survive the rename.
+func Acquire(path string) (func() error, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := Lock(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() error {
+		err := Unlock(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	}, nil
+}
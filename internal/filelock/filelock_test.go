@@ -0,0 +1,39 @@
+package filelock
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAcquire_Excludes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "x.lock")
+	release, err := Acquire(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan func() error)
+	go func() {
+		r, err := Acquire(path)
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- r
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second Acquire succeeded while the lock was held")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err := release(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case r := <-acquired:
+		r()
+	case <-time.After(2 * time.Second):
+		t.Fatal("second Acquire did not get the lock after release")
+	}
+}
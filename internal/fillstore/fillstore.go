@@ -0,0 +1,139 @@
+// Package fillstore keeps a local, append-only record of fills seen by
+// watch fills so a restarted watcher can skip fills it already handled.
+package fillstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const fileName = "fills.jsonl"
+
+// Record is one fill written to the store
+type Record struct {
+	ID          string          `json:"id"`
+	Environment string          `json:"environment"`
+	ReceivedAt  time.Time       `json:"received_at"`
+	Fill        json.RawMessage `json:"fill"`
+}
+
+// Store is an append-only JSONL file of fills plus the set of fill IDs
+// already in it
+type Store struct {
+	path string
+
+	mu   sync.Mutex
+	seen map[string]bool
+	file *os.File
+}
+
+// DefaultPath returns the fill store location inside the data directory
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, fileName)
+}
+
+// Open loads the IDs of every fill already stored at path. A missing file is
+// an empty store. Malformed lines are skipped so a write cut short by a crash
+// never keeps the watcher from starting.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, seen: make(map[string]bool)}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to open fill store: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil || r.ID == "" {
+			continue
+		}
+		s.seen[r.ID] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read fill store: %w", err)
+	}
+	return s, nil
+}
+
+// Path returns the store file location
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Len returns the number of fill IDs known to the store
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.seen)
+}
+
+// Seen reports whether a fill ID is stored or was marked this session
+func (s *Store) Seen(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen[id]
+}
+
+// Mark remembers a fill ID for this session without writing it
+func (s *Store) Mark(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen[id] = true
+}
+
+// Append writes a record and syncs it to disk before returning, then marks
+// its ID as seen. A record whose write fails is not marked.
+func (s *Store) Append(r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fill record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+			return fmt.Errorf("failed to create fill store directory: %w", err)
+		}
+		f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open fill store: %w", err)
+		}
+		s.file = f
+	}
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write fill store: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync fill store: %w", err)
+	}
+	s.seen[r.ID] = true
+	return nil
+}
+
+// Close closes the store file, if one was opened for writing
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
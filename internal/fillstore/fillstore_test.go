@@ -0,0 +1,78 @@
+package fillstore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_SeenAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fills.jsonl")
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if store.Seen("f1") {
+		t.Fatal("empty store should not have seen f1")
+	}
+	for _, id := range []string{"f1", "f2"} {
+		r := Record{ID: id, Environment: "demo", ReceivedAt: time.Now().UTC(), Fill: json.RawMessage(`{"fill_id":"` + id + `"}`)}
+		if err := store.Append(r); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	if !store.Seen("f1") {
+		t.Error("f1 should be seen after Append")
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer reopened.Close()
+	if !reopened.Seen("f1") || !reopened.Seen("f2") || reopened.Seen("f3") {
+		t.Errorf("reopened store seen f1=%v f2=%v f3=%v, want true true false",
+			reopened.Seen("f1"), reopened.Seen("f2"), reopened.Seen("f3"))
+	}
+	if reopened.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", reopened.Len())
+	}
+}
+
+func TestStore_SkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fills.jsonl")
+	data := `{"id":"f1","fill":{}}` + "\n" + `{"id":"f2","fi` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !store.Seen("f1") || store.Seen("f2") {
+		t.Errorf("seen f1=%v f2=%v, want true false", store.Seen("f1"), store.Seen("f2"))
+	}
+}
+
+func TestStore_MarkDoesNotWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fills.jsonl")
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	store.Mark("f1")
+	if !store.Seen("f1") {
+		t.Error("f1 should be seen after Mark")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Mark should not create the store file, stat err = %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package golden compares command output with golden files under testdata,
+// so renderers can be snapshot-tested. Run tests with -update to rewrite the
+// golden files from the current output.
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update is set by go test ./... -update
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// Dir is where golden files are kept, relative to the package under test
+const Dir = "testdata/golden"
+
+// Path returns the golden file for a test name
+func Path(name string) string {
+	return filepath.Join(Dir, name+".golden")
+}
+
+// Assert fails t when got differs from the golden file for name, showing the
+// first differing line. With -update the golden file is rewritten instead.
+func Assert(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := Path(name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if bytes.Equal(got, want) {
+		return
+	}
+	line, gotLine, wantLine := firstDiff(got, want)
+	t.Errorf("%s differs at line %d (run with -update to accept):\n got: %q\nwant: %q\n\nfull output:\n%s", path, line, gotLine, wantLine, got)
+}
+
+// firstDiff returns the first line number, from 1, where a and b differ
+func firstDiff(a, b []byte) (int, string, string) {
+	al, bl := bytes.Split(a, []byte("\n")), bytes.Split(b, []byte("\n"))
+	for i := 0; i < len(al) || i < len(bl); i++ {
+		var x, y string
+		if i < len(al) {
+			x = string(al[i])
+		}
+		if i < len(bl) {
+			y = string(bl[i])
+		}
+		if i >= len(al) || i >= len(bl) || x != y {
+			return i + 1, x, y
+		}
+	}
+	return 0, "", ""
+}
+
+// Capture returns everything fn writes to os.Stdout
+func Capture(t testing.TB, fn func()) []byte {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("create pipe: %v", err)
+	}
+	old := os.Stdout
+	os.Stdout = w
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+
+	defer func() {
+		os.Stdout = old
+	}()
+	fn()
+	w.Close()
+	os.Stdout = old
+	return <-done
+}
@@ -0,0 +1,53 @@
+package golden
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCapture(t *testing.T) {
+	got := Capture(t, func() {
+		fmt.Println("hello")
+		fmt.Print("world")
+	})
+	if string(got) != "hello\nworld" {
+		t.Errorf("captured %q", got)
+	}
+}
+
+func TestAssert(t *testing.T) {
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := os.MkdirAll(Dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(Dir, "sample.golden"), []byte("a\nb\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	Assert(t, "sample", []byte("a\nb\n"))
+
+	*update = true
+	defer func() { *update = false }()
+	Assert(t, "created", []byte("new\n"))
+	data, err := os.ReadFile(Path("created"))
+	if err != nil || string(data) != "new\n" {
+		t.Errorf("update wrote %q, %v", data, err)
+	}
+}
+
+func TestFirstDiff(t *testing.T) {
+	line, got, want := firstDiff([]byte("a\nb\nc"), []byte("a\nx\nc"))
+	if line != 2 || got != "b" || want != "x" {
+		t.Errorf("firstDiff = %d %q %q", line, got, want)
+	}
+	if line, _, want := firstDiff([]byte("a"), []byte("a\nb")); line != 2 || want != "b" {
+		t.Errorf("firstDiff on shorter output = %d %q", line, want)
+	}
+}
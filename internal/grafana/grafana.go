@@ -0,0 +1,248 @@
+// Package grafana serves data to Grafana over the SimpleJSON datasource
+// contract, which the JSON and Infinity datasource plugins can both query.
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRange is how far back a query without a time range looks
+const defaultRange = 24 * time.Hour
+
+// Range is the dashboard time range of a query
+type Range struct {
+	From time.Time
+	To   time.Time
+}
+
+// Series is a time series: datapoints are [value, unix milliseconds] pairs
+type Series struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// Add appends a datapoint
+func (s *Series) Add(t time.Time, value float64) {
+	s.Datapoints = append(s.Datapoints, [2]float64{value, float64(t.UnixMilli())})
+}
+
+// Column is a table column. Type is "string", "number" or "time".
+type Column struct {
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+// Table is a query result shown as rows rather than over time
+type Table struct {
+	Type    string          `json:"type"`
+	Columns []Column        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// NewTable returns an empty table with the given columns
+func NewTable(columns ...Column) *Table {
+	return &Table{Type: "table", Columns: columns, Rows: [][]interface{}{}}
+}
+
+// Backend answers the metric queries of a dashboard
+type Backend interface {
+	// Search returns the metrics whose names contain query
+	Search(ctx context.Context, query string) ([]string, error)
+	// Query returns a *Series or *Table for one metric
+	Query(ctx context.Context, target string, r Range) (interface{}, error)
+}
+
+// queryRequest is the body of a SimpleJSON /query request
+type queryRequest struct {
+	Range struct {
+		From time.Time `json:"from"`
+		To   time.Time `json:"to"`
+	} `json:"range"`
+	Targets []struct {
+		Target string `json:"target"`
+		Hide   bool   `json:"hide"`
+	} `json:"targets"`
+}
+
+// NewHandler returns the datasource endpoints for b:
+//
+//	GET  /             connection test
+//	POST /search       metric names, for the query editor
+//	POST /query        SimpleJSON query: series and tables for each target
+//	POST /annotations  no annotations
+//	GET  /query        one target as rows, for Infinity: ?target=&from=&to=
+func NewHandler(b Backend, now func() time.Time) http.Handler {
+	if now == nil {
+		now = time.Now
+	}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "OK")
+	})
+
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Target string `json:"target"`
+		}
+		if r.Method == http.MethodPost && r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid search request: %w", err))
+				return
+			}
+		}
+		metrics, err := b.Search(r.Context(), req.Target)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		if metrics == nil {
+			metrics = []string{}
+		}
+		writeJSON(w, metrics)
+	})
+
+	mux.HandleFunc("/annotations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []struct{}{})
+	})
+
+	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handleQuery(w, r, b, now)
+		case http.MethodGet:
+			handleRows(w, r, b, now)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("use GET or POST"))
+		}
+	})
+
+	return mux
+}
+
+func handleQuery(w http.ResponseWriter, r *http.Request, b Backend, now func() time.Time) {
+	var req queryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid query request: %w", err))
+		return
+	}
+	rng := normalizeRange(Range{From: req.Range.From, To: req.Range.To}, now())
+
+	results := []interface{}{}
+	for _, t := range req.Targets {
+		if t.Hide || strings.TrimSpace(t.Target) == "" {
+			continue
+		}
+		result, err := b.Query(r.Context(), strings.TrimSpace(t.Target), rng)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		results = append(results, result)
+	}
+	writeJSON(w, results)
+}
+
+// handleRows answers GET /query with one object per datapoint or table row,
+// the shape the Infinity datasource reads without any parsing options
+func handleRows(w http.ResponseWriter, r *http.Request, b Backend, now func() time.Time) {
+	q := r.URL.Query()
+	target := strings.TrimSpace(q.Get("target"))
+	if target == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("target is required"))
+		return
+	}
+	var rng Range
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &rng.From}, {"to", &rng.To}} {
+		if v := q.Get(p.name); v != "" {
+			t, err := ParseTime(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid %s: %w", p.name, err))
+				return
+			}
+			*p.dst = t
+		}
+	}
+
+	result, err := b.Query(r.Context(), target, normalizeRange(rng, now()))
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, Rows(result))
+}
+
+// Rows flattens a *Series into {time, value} objects and a *Table into one
+// object per row keyed by column
+func Rows(result interface{}) []map[string]interface{} {
+	rows := []map[string]interface{}{}
+	switch res := result.(type) {
+	case *Series:
+		for _, dp := range res.Datapoints {
+			rows = append(rows, map[string]interface{}{
+				"time":  time.UnixMilli(int64(dp[1])).UTC().Format(time.RFC3339),
+				"value": dp[0],
+			})
+		}
+	case *Table:
+		for _, row := range res.Rows {
+			obj := make(map[string]interface{}, len(res.Columns))
+			for i, col := range res.Columns {
+				if i < len(row) {
+					obj[col.Text] = row[i]
+				}
+			}
+			rows = append(rows, obj)
+		}
+	}
+	return rows
+}
+
+// ParseTime reads a time as RFC3339 or as unix milliseconds, which is how
+// Grafana's ${__from} and ${__to} expand
+func ParseTime(s string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not RFC3339 or unix milliseconds", s)
+	}
+	return t, nil
+}
+
+// normalizeRange fills a missing end with now and a missing start with a day
+// before the end
+func normalizeRange(r Range, now time.Time) Range {
+	if r.To.IsZero() {
+		r.To = now
+	}
+	if r.From.IsZero() || !r.From.Before(r.To) {
+		r.From = r.To.Add(-defaultRange)
+	}
+	return r
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
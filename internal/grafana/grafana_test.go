@@ -0,0 +1,117 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeBackend struct {
+	ranges []Range
+}
+
+func (f *fakeBackend) Search(ctx context.Context, query string) ([]string, error) {
+	return []string{"pnl:" + query}, nil
+}
+
+func (f *fakeBackend) Query(ctx context.Context, target string, r Range) (interface{}, error) {
+	f.ranges = append(f.ranges, r)
+	switch target {
+	case "pnl":
+		s := &Series{Target: target}
+		s.Add(r.From, 10)
+		s.Add(r.To, -5)
+		return s, nil
+	case "positions":
+		t := NewTable(Column{Text: "ticker", Type: "string"}, Column{Text: "position", Type: "number"})
+		t.Rows = append(t.Rows, []interface{}{"KXA", 3})
+		return t, nil
+	}
+	return nil, fmt.Errorf("unknown metric %q", target)
+}
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func serve(t *testing.T, b Backend, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	NewHandler(b, func() time.Time { return testNow }).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerConnectionTestAndSearch(t *testing.T) {
+	b := &fakeBackend{}
+	if rec := serve(t, b, http.MethodGet, "/", ""); rec.Code != http.StatusOK {
+		t.Errorf("GET / = %d, want 200", rec.Code)
+	}
+	if rec := serve(t, b, http.MethodGet, "/missing", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /missing = %d, want 404", rec.Code)
+	}
+
+	rec := serve(t, b, http.MethodPost, "/search", `{"target":"x"}`)
+	var metrics []string
+	if err := json.Unmarshal(rec.Body.Bytes(), &metrics); err != nil || !reflect.DeepEqual(metrics, []string{"pnl:x"}) {
+		t.Errorf("POST /search = %s", rec.Body)
+	}
+}
+
+func TestHandlerQuery(t *testing.T) {
+	b := &fakeBackend{}
+	body := `{"range":{"from":"2026-02-01T00:00:00Z","to":"2026-02-02T00:00:00Z"},
+		"targets":[{"target":"pnl"},{"target":"positions"},{"target":"hidden","hide":true}]}`
+	rec := serve(t, b, http.MethodPost, "/query", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /query = %d: %s", rec.Code, rec.Body)
+	}
+
+	want := `[{"target":"pnl","datapoints":[[10,1769904000000],[-5,1769990400000]]},` +
+		`{"type":"table","columns":[{"text":"ticker","type":"string"},{"text":"position","type":"number"}],"rows":[["KXA",3]]}]`
+	if got := strings.TrimSpace(rec.Body.String()); got != want {
+		t.Errorf("POST /query =\n%s\nwant\n%s", got, want)
+	}
+
+	rec = serve(t, b, http.MethodPost, "/query", `{"targets":[{"target":"nope"}]}`)
+	if rec.Code != http.StatusBadGateway || !strings.Contains(rec.Body.String(), `unknown metric`) {
+		t.Errorf("POST /query unknown = %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestHandlerRows(t *testing.T) {
+	b := &fakeBackend{}
+	rec := serve(t, b, http.MethodGet, "/query?target=pnl&from=1769904000000", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /query = %d: %s", rec.Code, rec.Body)
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0]["time"] != "2026-02-01T00:00:00Z" || rows[1]["value"] != -5.0 {
+		t.Errorf("GET /query rows = %v", rows)
+	}
+	if got := b.ranges[0]; !got.To.Equal(testNow) {
+		t.Errorf("range end = %v, want now", got.To)
+	}
+
+	rec = serve(t, b, http.MethodGet, "/query?target=positions", "")
+	if got := strings.TrimSpace(rec.Body.String()); got != `[{"position":3,"ticker":"KXA"}]` {
+		t.Errorf("GET /query table = %s", got)
+	}
+	if got := b.ranges[1]; !got.From.Equal(testNow.Add(-defaultRange)) {
+		t.Errorf("default range start = %v", got.From)
+	}
+
+	if rec := serve(t, b, http.MethodGet, "/query", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("GET /query without target = %d, want 400", rec.Code)
+	}
+	if rec := serve(t, b, http.MethodGet, "/query?target=pnl&to=yesterday", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("GET /query bad time = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,30 @@
+// Package hsm provides crypto.Signer implementations backed by hardware
+// tokens, so API private keys can stay non-exportable.
+package hsm
+
+import (
+	"crypto"
+	"errors"
+)
+
+// ErrUnsupported is returned when the binary was built without PKCS#11 support
+var ErrUnsupported = errors.New("PKCS#11 support not compiled in (rebuild with CGO_ENABLED=1 and -tags pkcs11)")
+
+// PKCS11Config identifies an RSA private key on a PKCS#11 token
+type PKCS11Config struct {
+	// Module is the path to the vendor PKCS#11 library (e.g. libykcs11.so, libsofthsm2.so, libtpm2_pkcs11.so)
+	Module string
+	// TokenLabel selects the token; empty uses the first token present
+	TokenLabel string
+	// KeyLabel is the CKA_LABEL of the private key; empty uses the only RSA key on the token
+	KeyLabel string
+	// PIN is the user PIN; empty skips login
+	PIN string
+}
+
+// Key is a handle to a private key held by a hardware token
+type Key interface {
+	crypto.Signer
+	// Close releases the token session and unloads the module
+	Close() error
+}
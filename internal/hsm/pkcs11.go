@@ -0,0 +1,176 @@
+//go:build pkcs11 && cgo
+
+package hsm
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+type pkcs11Key struct {
+	mu        sync.Mutex
+	ctx       *pkcs11.Ctx
+	session   pkcs11.SessionHandle
+	handle    pkcs11.ObjectHandle
+	publicKey *rsa.PublicKey
+}
+
+// OpenPKCS11 loads the PKCS#11 module and locates the configured RSA private key
+func OpenPKCS11(cfg PKCS11Config) (Key, error) {
+	if cfg.Module == "" {
+		return nil, errors.New("PKCS#11 module path is required")
+	}
+
+	ctx := pkcs11.New(cfg.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s", cfg.Module)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+
+	key := &pkcs11Key{ctx: ctx}
+	if err := key.open(cfg); err != nil {
+		key.Close()
+		return nil, err
+	}
+	return key, nil
+}
+
+func (k *pkcs11Key) open(cfg PKCS11Config) error {
+	slot, err := findSlot(k.ctx, cfg.TokenLabel)
+	if err != nil {
+		return err
+	}
+
+	k.session, err = k.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("failed to open token session: %w", err)
+	}
+
+	if cfg.PIN != "" {
+		err := k.ctx.Login(k.session, pkcs11.CKU_USER, cfg.PIN)
+		if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+			return fmt.Errorf("failed to log in to token: %w", err)
+		}
+	}
+
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+	}
+	if cfg.KeyLabel != "" {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel))
+	}
+
+	if err := k.ctx.FindObjectsInit(k.session, template); err != nil {
+		return fmt.Errorf("failed to search token: %w", err)
+	}
+	handles, _, err := k.ctx.FindObjects(k.session, 2)
+	k.ctx.FindObjectsFinal(k.session)
+	if err != nil {
+		return fmt.Errorf("failed to search token: %w", err)
+	}
+
+	switch len(handles) {
+	case 0:
+		return fmt.Errorf("no RSA private key found on token (label %q)", cfg.KeyLabel)
+	case 1:
+		k.handle = handles[0]
+	default:
+		return errors.New("multiple RSA private keys found on token; set a key label")
+	}
+
+	attrs, err := k.ctx.GetAttributeValue(k.session, k.handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read public key from token: %w", err)
+	}
+
+	k.publicKey = &rsa.PublicKey{
+		N: new(big.Int).SetBytes(attrs[0].Value),
+		E: int(new(big.Int).SetBytes(attrs[1].Value).Int64()),
+	}
+	return nil
+}
+
+func findSlot(ctx *pkcs11.Ctx, label string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list token slots: %w", err)
+	}
+
+	for _, slot := range slots {
+		if label == "" {
+			return slot, nil
+		}
+		info, err := ctx.GetTokenInfo(slot)
+		if err == nil && info.Label == label {
+			return slot, nil
+		}
+	}
+
+	if label == "" {
+		return 0, errors.New("no PKCS#11 token present")
+	}
+	return 0, fmt.Errorf("no PKCS#11 token labelled %q", label)
+}
+
+// Public returns the RSA public key of the token key
+func (k *pkcs11Key) Public() crypto.PublicKey {
+	return k.publicKey
+}
+
+// Sign signs digest on the token. Only RSA-PSS with SHA-256 is supported,
+// which is what Kalshi request signing requires.
+func (k *pkcs11Key) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	pss, ok := opts.(*rsa.PSSOptions)
+	if !ok || pss.HashFunc() != crypto.SHA256 {
+		return nil, errors.New("PKCS#11 signer only supports RSA-PSS with SHA-256")
+	}
+
+	saltLength := pss.SaltLength
+	if saltLength == rsa.PSSSaltLengthEqualsHash || saltLength == rsa.PSSSaltLengthAuto {
+		saltLength = crypto.SHA256.Size()
+	}
+
+	params := pkcs11.NewPSSParams(pkcs11.CKM_SHA256, pkcs11.CKG_MGF1_SHA256, uint(saltLength))
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_PSS, params)}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.ctx.SignInit(k.session, mechanism, k.handle); err != nil {
+		return nil, fmt.Errorf("token sign init failed: %w", err)
+	}
+	signature, err := k.ctx.Sign(k.session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("token sign failed: %w", err)
+	}
+	return signature, nil
+}
+
+// Close ends the session and unloads the module
+func (k *pkcs11Key) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.session != 0 {
+		k.ctx.Logout(k.session)
+		k.ctx.CloseSession(k.session)
+		k.session = 0
+	}
+	k.ctx.Finalize()
+	k.ctx.Destroy()
+	return nil
+}
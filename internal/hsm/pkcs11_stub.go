@@ -0,0 +1,8 @@
+//go:build !pkcs11 || !cgo
+
+package hsm
+
+// OpenPKCS11 always fails in builds without the pkcs11 tag
+func OpenPKCS11(cfg PKCS11Config) (Key, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,197 @@
+package jobs
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// CandleFunc fetches a market's candles with period ends in [start, end]
+type CandleFunc func(ctx context.Context, ticker string, start, end time.Time) ([]models.Candlestick, error)
+
+// ChunkFunc is told about each checkpoint as it is saved
+type ChunkFunc func(job *Job)
+
+var csvHeader = []string{"ticker", "period_end", "open", "high", "low", "close", "volume", "open_interest", "yes_bid", "yes_ask"}
+
+// Run exports a job's candles from its checkpoint on, one chunk of periods
+// per request. After each chunk the rows are synced to the destination and
+// the checkpoint saved. Run stops early when ctx is done, leaving the job
+// interrupted, or when the job is canceled from another process. A failed
+// fetch leaves the job failed at its last checkpoint.
+func Run(ctx context.Context, m *Manager, job *Job, fetch CandleFunc, onChunk ChunkFunc) error {
+	f, err := openDestination(job)
+	if err != nil {
+		return finish(m, job, StatusFailed, err)
+	}
+	defer f.Close()
+
+	job.Status = StatusRunning
+	job.PID = os.Getpid()
+	job.Error = ""
+	if err := m.Save(job); err != nil {
+		return err
+	}
+
+	for job.Ticker < len(job.Tickers) {
+		ticker := job.Tickers[job.Ticker]
+		from := job.Start
+		if !job.Cursor.IsZero() {
+			from = job.Cursor
+		}
+		if !from.Before(job.End) {
+			job.Ticker++
+			job.Cursor = time.Time{}
+			continue
+		}
+		to := from.Add(time.Duration(job.Chunk) * job.Step)
+		if to.After(job.End) {
+			to = job.End
+		}
+
+		if ctx.Err() != nil {
+			return finish(m, job, StatusInterrupted, nil)
+		}
+		candles, err := fetch(ctx, ticker, from, to)
+		if err != nil {
+			if ctx.Err() != nil {
+				return finish(m, job, StatusInterrupted, nil)
+			}
+			return finish(m, job, StatusFailed, fmt.Errorf("failed to fetch %s candles from %s: %w", ticker, from.Format(time.RFC3339), err))
+		}
+
+		rows, err := writeCandles(f, job.Format, ticker, candles, from, to)
+		if err != nil {
+			return finish(m, job, StatusFailed, fmt.Errorf("failed to write %s: %w", job.Destination, err))
+		}
+		if err := f.Sync(); err != nil {
+			return finish(m, job, StatusFailed, fmt.Errorf("failed to write %s: %w", job.Destination, err))
+		}
+		offset, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return finish(m, job, StatusFailed, fmt.Errorf("failed to write %s: %w", job.Destination, err))
+		}
+
+		job.Cursor = to
+		job.Offset = offset
+		job.Rows += int64(rows)
+		job.Chunks++
+		if !to.Before(job.End) {
+			job.Ticker++
+			job.Cursor = time.Time{}
+		}
+		if canceled(m, job.Name) {
+			return finish(m, job, StatusCanceled, nil)
+		}
+		job.Updated = time.Now().UTC()
+		if err := m.Save(job); err != nil {
+			return err
+		}
+		if onChunk != nil {
+			onChunk(job)
+		}
+	}
+	return finish(m, job, StatusDone, nil)
+}
+
+// finish saves the job's final status for this run and returns err
+func finish(m *Manager, job *Job, status string, err error) error {
+	job.Status = status
+	job.PID = 0
+	job.Updated = time.Now().UTC()
+	if err != nil {
+		job.Error = err.Error()
+	}
+	if saveErr := m.Save(job); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+// canceled reports whether the saved job was canceled while it ran
+func canceled(m *Manager, name string) bool {
+	saved, err := m.Load(name)
+	return err == nil && saved.Status == StatusCanceled
+}
+
+// openDestination opens the destination at the job's checkpoint, dropping
+// anything written after it. A new CSV file gets a header.
+func openDestination(job *Job) (*os.File, error) {
+	flags := os.O_RDWR | os.O_CREATE
+	if job.Offset == 0 {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(job.Destination, flags, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", job.Destination, err)
+	}
+	info, err := f.Stat()
+	if err == nil && info.Size() < job.Offset {
+		err = fmt.Errorf("%s is shorter than the checkpoint (%d < %d bytes); it was changed outside the job", job.Destination, info.Size(), job.Offset)
+	}
+	if err == nil {
+		err = f.Truncate(job.Offset)
+	}
+	if err == nil {
+		_, err = f.Seek(job.Offset, io.SeekStart)
+	}
+	if err == nil && job.Offset == 0 && job.Format == FormatCSV {
+		w := csv.NewWriter(f)
+		w.Write(csvHeader)
+		w.Flush()
+		if err = w.Error(); err == nil {
+			job.Offset, err = f.Seek(0, io.SeekCurrent)
+		}
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// writeCandles appends the candles with period ends in (from, to], so that
+// candles on a chunk boundary are written once
+func writeCandles(w io.Writer, format, ticker string, candles []models.Candlestick, from, to time.Time) (int, error) {
+	bw := bufio.NewWriter(w)
+	cw := csv.NewWriter(bw)
+	rows := 0
+	for _, c := range candles {
+		if !c.PeriodEnd.After(from) || c.PeriodEnd.After(to) {
+			continue
+		}
+		c.Ticker = ticker
+		switch format {
+		case FormatJSONL:
+			data, err := json.Marshal(c)
+			if err != nil {
+				return 0, err
+			}
+			bw.Write(append(data, '\n'))
+		default:
+			cw.Write([]string{
+				ticker, c.PeriodEnd.UTC().Format(time.RFC3339),
+				strconv.Itoa(c.Open), strconv.Itoa(c.High), strconv.Itoa(c.Low), strconv.Itoa(c.Close),
+				strconv.Itoa(c.Volume), strconv.Itoa(c.OpenInterest),
+				strconv.Itoa(c.YesBid), strconv.Itoa(c.YesAsk),
+			})
+		}
+		rows++
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return 0, err
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,205 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var testStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testJob(t *testing.T) (*Manager, *Job) {
+	t.Helper()
+	dir := t.TempDir()
+	job := &Job{
+		Name:        "test",
+		Kind:        KindExportCandles,
+		Tickers:     []string{"KXA", "KXB"},
+		Period:      "1h",
+		Step:        time.Hour,
+		Start:       testStart,
+		End:         testStart.Add(5 * time.Hour),
+		Destination: filepath.Join(dir, "out.csv"),
+		Format:      FormatCSV,
+		Chunk:       2,
+	}
+	if err := job.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return NewManager(filepath.Join(dir, "jobs")), job
+}
+
+// hourly returns a candle for every hour ending in [start, end], as the API
+// includes both bounds
+func hourly(ctx context.Context, ticker string, start, end time.Time) ([]models.Candlestick, error) {
+	var candles []models.Candlestick
+	for t := start; !t.After(end); t = t.Add(time.Hour) {
+		candles = append(candles, models.Candlestick{PeriodEnd: t, Close: 50, Volume: 1})
+	}
+	return candles, nil
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestRunExportsEveryPeriodOnce(t *testing.T) {
+	m, job := testJob(t)
+	var requests int
+	fetch := func(ctx context.Context, ticker string, start, end time.Time) ([]models.Candlestick, error) {
+		requests++
+		return hourly(ctx, ticker, start, end)
+	}
+	if err := Run(context.Background(), m, job, fetch, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if job.Status != StatusDone || job.Rows != 10 || requests != 6 {
+		t.Errorf("job = %s with %d rows after %d requests, want done with 10 rows after 6", job.Status, job.Rows, requests)
+	}
+	lines := readLines(t, job.Destination)
+	if len(lines) != 11 || lines[0] != strings.Join(csvHeader, ",") {
+		t.Fatalf("got %d lines:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if lines[1] != "KXA,2026-01-01T01:00:00Z,0,0,0,50,1,0,0,0" {
+		t.Errorf("first row = %q", lines[1])
+	}
+	saved, err := m.Load("test")
+	if err != nil || saved.Status != StatusDone || saved.Progress() != 1 {
+		t.Errorf("saved = %+v, %v", saved, err)
+	}
+}
+
+func TestRunResumesFromCheckpoint(t *testing.T) {
+	m, job := testJob(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	fetch := func(ctx context.Context, ticker string, start, end time.Time) ([]models.Candlestick, error) {
+		if ticker == "KXB" {
+			cancel()
+			return nil, ctx.Err()
+		}
+		return hourly(ctx, ticker, start, end)
+	}
+	if err := Run(ctx, m, job, fetch, nil); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != StatusInterrupted || job.Ticker != 1 || job.Rows != 5 {
+		t.Fatalf("job = %s at ticker %d with %d rows, want interrupted at 1 with 5", job.Status, job.Ticker, job.Rows)
+	}
+
+	// A partial write after the checkpoint is dropped on resume
+	f, err := os.OpenFile(job.Destination, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("KXB,partial\n")
+	f.Close()
+
+	saved, err := m.Load("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Run(context.Background(), m, saved, hourly, nil); err != nil {
+		t.Fatal(err)
+	}
+	lines := readLines(t, job.Destination)
+	if saved.Status != StatusDone || saved.Rows != 10 || len(lines) != 11 {
+		t.Errorf("resumed = %s with %d rows and %d lines, want done with 10 rows and 11 lines", saved.Status, saved.Rows, len(lines))
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "partial") {
+			t.Errorf("partial row kept: %q", line)
+		}
+	}
+}
+
+func TestRunFailureKeepsCheckpoint(t *testing.T) {
+	m, job := testJob(t)
+	calls := 0
+	fetch := func(ctx context.Context, ticker string, start, end time.Time) ([]models.Candlestick, error) {
+		calls++
+		if calls == 2 {
+			return nil, errors.New("boom")
+		}
+		return hourly(ctx, ticker, start, end)
+	}
+	err := Run(context.Background(), m, job, fetch, nil)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("err = %v, want the fetch error", err)
+	}
+	saved, _ := m.Load("test")
+	if saved.Status != StatusFailed || saved.Rows != 2 || !saved.Cursor.Equal(testStart.Add(2*time.Hour)) {
+		t.Errorf("saved = %s with %d rows at %v", saved.Status, saved.Rows, saved.Cursor)
+	}
+}
+
+func TestRunStopsWhenCanceledElsewhere(t *testing.T) {
+	m, job := testJob(t)
+	fetch := func(ctx context.Context, ticker string, start, end time.Time) ([]models.Candlestick, error) {
+		saved, err := m.Load("test")
+		if err != nil {
+			return nil, err
+		}
+		saved.Status = StatusCanceled
+		if err := m.Save(saved); err != nil {
+			return nil, err
+		}
+		return hourly(ctx, ticker, start, end)
+	}
+	if err := Run(context.Background(), m, job, fetch, nil); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != StatusCanceled || job.Chunks != 1 {
+		t.Errorf("job = %s after %d chunks, want canceled after 1", job.Status, job.Chunks)
+	}
+}
+
+func TestRunJSONL(t *testing.T) {
+	m, job := testJob(t)
+	job.Format = FormatJSONL
+	job.Tickers = job.Tickers[:1]
+	if err := Run(context.Background(), m, job, hourly, nil); err != nil {
+		t.Fatal(err)
+	}
+	lines := readLines(t, job.Destination)
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], `{"ticker":"KXA"`) {
+		t.Errorf("got:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestProgress(t *testing.T) {
+	job := &Job{Tickers: []string{"A", "B"}, Start: testStart, End: testStart.Add(4 * time.Hour), Ticker: 1, Cursor: testStart.Add(time.Hour)}
+	if got := job.Progress(); got != 0.625 {
+		t.Errorf("progress = %v, want 0.625", got)
+	}
+}
+
+func TestListAndValidName(t *testing.T) {
+	m, job := testJob(t)
+	if jobs, err := m.List(); err != nil || len(jobs) != 0 {
+		t.Fatalf("empty list = %v, %v", jobs, err)
+	}
+	if err := m.Save(job); err != nil {
+		t.Fatal(err)
+	}
+	if jobs, err := m.List(); err != nil || len(jobs) != 1 || jobs[0].Name != "test" {
+		t.Errorf("list = %v, %v", jobs, err)
+	}
+	if _, err := m.Load("missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v, want not exist", err)
+	}
+	if err := ValidName("../escape"); err == nil {
+		t.Error("expected invalid name")
+	}
+}
@@ -0,0 +1,209 @@
+// Package jobs keeps resumable export jobs: large historical pulls split
+// into chunks, with a checkpoint saved after each one so a job stopped by
+// Ctrl+C, a crash or a reboot picks up where it left off.
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const dirName = "jobs"
+
+// KindExportCandles exports candlesticks for a list of markets
+const KindExportCandles = "export-candles"
+
+// Job statuses
+const (
+	StatusRunning     = "running"
+	StatusInterrupted = "interrupted"
+	StatusFailed      = "failed"
+	StatusCanceled    = "canceled"
+	StatusDone        = "done"
+)
+
+// Destination formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// DefaultChunk is the number of periods fetched per request
+const DefaultChunk = 1000
+
+// Job is a job's spec and its checkpoint
+type Job struct {
+	Name        string        `json:"name"`
+	Kind        string        `json:"kind"`
+	Tickers     []string      `json:"tickers"`
+	Series      string        `json:"series,omitempty"`
+	Period      string        `json:"period"`
+	Step        time.Duration `json:"step"`
+	Start       time.Time     `json:"start"`
+	End         time.Time     `json:"end"`
+	Destination string        `json:"destination"`
+	Format      string        `json:"format"`
+	Chunk       int           `json:"chunk"`
+
+	Status  string    `json:"status"`
+	PID     int       `json:"pid,omitempty"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+	Error   string    `json:"error,omitempty"`
+
+	// Ticker is the index in Tickers being exported and Cursor the end of
+	// the last period written for it; zero means it has not started
+	Ticker int       `json:"ticker"`
+	Cursor time.Time `json:"cursor,omitzero"`
+	// Offset is the destination size at the last checkpoint; anything
+	// written after it is discarded on resume
+	Offset int64 `json:"offset"`
+	Rows   int64 `json:"rows"`
+	Chunks int   `json:"chunks"`
+}
+
+// Finished reports whether the job will not run again
+func (j *Job) Finished() bool {
+	return j.Status == StatusDone || j.Status == StatusCanceled
+}
+
+// Progress returns the fraction of the job done, from 0 to 1
+func (j *Job) Progress() float64 {
+	if j.Status == StatusDone {
+		return 1
+	}
+	if len(j.Tickers) == 0 {
+		return 0
+	}
+	done := float64(j.Ticker)
+	if span := j.End.Sub(j.Start); span > 0 && !j.Cursor.IsZero() {
+		done += min(float64(j.Cursor.Sub(j.Start))/float64(span), 1)
+	}
+	return done / float64(len(j.Tickers))
+}
+
+// Validate checks a new job's spec
+func (j *Job) Validate() error {
+	if err := ValidName(j.Name); err != nil {
+		return err
+	}
+	if j.Kind != KindExportCandles {
+		return fmt.Errorf("unknown job kind %q: use %s", j.Kind, KindExportCandles)
+	}
+	if len(j.Tickers) == 0 {
+		return fmt.Errorf("job has no tickers")
+	}
+	if j.Step <= 0 {
+		return fmt.Errorf("job has no period")
+	}
+	if j.Start.IsZero() || j.End.IsZero() || !j.Start.Before(j.End) {
+		return fmt.Errorf("job needs a start before its end")
+	}
+	if j.Destination == "" {
+		return fmt.Errorf("job has no destination")
+	}
+	if j.Format != FormatCSV && j.Format != FormatJSONL {
+		return fmt.Errorf("invalid format %q: use %s or %s", j.Format, FormatCSV, FormatJSONL)
+	}
+	if j.Chunk < 1 {
+		return fmt.Errorf("chunk must be positive")
+	}
+	return nil
+}
+
+// DefaultDir returns the job state location inside the data directory
+func DefaultDir(dataDir string) string {
+	return filepath.Join(dataDir, dirName)
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidName checks that a job name is safe to use as a file name
+func ValidName(name string) error {
+	if !namePattern.MatchString(name) || len(name) > 64 {
+		return fmt.Errorf("invalid job name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// Manager reads and writes job state in a directory
+type Manager struct {
+	dir string
+}
+
+// NewManager returns a manager rooted at dir
+func NewManager(dir string) *Manager {
+	return &Manager{dir: dir}
+}
+
+func (m *Manager) path(name string) string {
+	return filepath.Join(m.dir, name+".json")
+}
+
+// Save writes a job, replacing any previous state atomically
+func (m *Manager) Save(job *Job) error {
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create jobs directory: %w", err)
+	}
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+	tmp := m.path(job.Name) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write job: %w", err)
+	}
+	if err := os.Rename(tmp, m.path(job.Name)); err != nil {
+		return fmt.Errorf("failed to write job: %w", err)
+	}
+	return nil
+}
+
+// Load reads a job. It returns an error wrapping os.ErrNotExist for an
+// unknown job.
+func (m *Manager) Load(name string) (*Job, error) {
+	data, err := os.ReadFile(m.path(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no job named %q: %w", name, err)
+		}
+		return nil, fmt.Errorf("failed to read job: %w", err)
+	}
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to parse job %s: %w", m.path(name), err)
+	}
+	return &job, nil
+}
+
+// List returns every job, sorted by name
+func (m *Manager) List() ([]Job, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read jobs directory: %w", err)
+	}
+	var jobs []Job
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		job, err := m.Load(name)
+		if err != nil {
+			continue
+		}
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs, nil
+}
@@ -0,0 +1,199 @@
+// Package notes keeps free-text notes and tags on markets, stored locally so
+// research context can be shown next to market data.
+package notes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const fileName = "notes.json"
+
+// Note is one dated note on a market
+type Note struct {
+	Text  string    `json:"text"`
+	Added time.Time `json:"added"`
+}
+
+// Entry is everything recorded about one market
+type Entry struct {
+	Notes []Note   `json:"notes,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// Empty reports whether the entry holds nothing
+func (e Entry) Empty() bool {
+	return len(e.Notes) == 0 && len(e.Tags) == 0
+}
+
+// Summary renders the tags and the latest note on one line, for a table
+// column
+func (e Entry) Summary() string {
+	var parts []string
+	for _, tag := range e.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if n := len(e.Notes); n > 0 {
+		parts = append(parts, e.Notes[n-1].Text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Book is the notes and tags on every market, keyed by ticker
+type Book struct {
+	Markets map[string]Entry `json:"markets"`
+}
+
+// Get returns the entry for a ticker, which is empty if there is none
+func (b *Book) Get(ticker string) Entry {
+	return b.Markets[ticker]
+}
+
+// AddNote appends a note to a ticker
+func (b *Book) AddNote(ticker, text string, now time.Time) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("note is empty")
+	}
+	e := b.Markets[ticker]
+	e.Notes = append(e.Notes, Note{Text: text, Added: now.UTC()})
+	b.set(ticker, e)
+	return nil
+}
+
+// DeleteNote removes a ticker's note by its 1-based number
+func (b *Book) DeleteNote(ticker string, n int) error {
+	e := b.Markets[ticker]
+	if n < 1 || n > len(e.Notes) {
+		return fmt.Errorf("%s has no note %d", ticker, n)
+	}
+	e.Notes = append(e.Notes[:n-1:n-1], e.Notes[n:]...)
+	b.set(ticker, e)
+	return nil
+}
+
+// AddTags adds tags to a ticker. Tags are lowercased, may not contain spaces
+// and are kept sorted without duplicates.
+func (b *Book) AddTags(ticker string, tags ...string) error {
+	e := b.Markets[ticker]
+	for _, tag := range tags {
+		tag, err := cleanTag(tag)
+		if err != nil {
+			return err
+		}
+		if !contains(e.Tags, tag) {
+			e.Tags = append(e.Tags, tag)
+		}
+	}
+	sort.Strings(e.Tags)
+	b.set(ticker, e)
+	return nil
+}
+
+// RemoveTags removes tags from a ticker; tags it does not have are ignored
+func (b *Book) RemoveTags(ticker string, tags ...string) {
+	e := b.Markets[ticker]
+	kept := e.Tags[:0:0]
+	for _, tag := range e.Tags {
+		if !contains(tags, tag) {
+			kept = append(kept, tag)
+		}
+	}
+	e.Tags = kept
+	b.set(ticker, e)
+}
+
+// Clear removes every note and tag on a ticker
+func (b *Book) Clear(ticker string) {
+	delete(b.Markets, ticker)
+}
+
+// Tickers returns the tickers with an entry, sorted, optionally only those
+// carrying tag
+func (b *Book) Tickers(tag string) []string {
+	var tickers []string
+	for ticker, e := range b.Markets {
+		if tag == "" || contains(e.Tags, strings.ToLower(tag)) {
+			tickers = append(tickers, ticker)
+		}
+	}
+	sort.Strings(tickers)
+	return tickers
+}
+
+func (b *Book) set(ticker string, e Entry) {
+	if e.Empty() {
+		delete(b.Markets, ticker)
+		return
+	}
+	if b.Markets == nil {
+		b.Markets = make(map[string]Entry)
+	}
+	b.Markets[ticker] = e
+}
+
+func cleanTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	if tag == "" || strings.ContainsAny(tag, " \t,") {
+		return "", fmt.Errorf("invalid tag %q: use a single word", tag)
+	}
+	return tag, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPath returns the notes file location inside the data directory
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, fileName)
+}
+
+// Load reads the notes file at path. A missing file is an empty book.
+func Load(path string) (*Book, error) {
+	b := &Book{Markets: make(map[string]Entry)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return b, nil
+		}
+		return nil, fmt.Errorf("failed to read notes: %w", err)
+	}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to parse notes %s: %w", path, err)
+	}
+	if b.Markets == nil {
+		b.Markets = make(map[string]Entry)
+	}
+	return b, nil
+}
+
+// Save writes the book to path, replacing the previous file atomically
+func Save(path string, b *Book) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create notes directory: %w", err)
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode notes: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write notes: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write notes: %w", err)
+	}
+	return nil
+}
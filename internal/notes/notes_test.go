@@ -0,0 +1,79 @@
+package notes
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBook_NotesAndTags(t *testing.T) {
+	b := &Book{}
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	if err := b.AddNote("KXCPI-26MAR", "  watch CPI print ", now); err != nil {
+		t.Fatalf("AddNote failed: %v", err)
+	}
+	if err := b.AddNote("KXCPI-26MAR", "consensus 0.3%", now.Add(time.Hour)); err != nil {
+		t.Fatalf("AddNote failed: %v", err)
+	}
+	if err := b.AddNote("KXCPI-26MAR", " ", now); err == nil {
+		t.Error("expected an error for an empty note")
+	}
+	if err := b.AddTags("KXCPI-26MAR", "Macro", "#cpi", "macro"); err != nil {
+		t.Fatalf("AddTags failed: %v", err)
+	}
+	if err := b.AddTags("KXCPI-26MAR", "two words"); err == nil {
+		t.Error("expected an error for a tag with a space")
+	}
+
+	e := b.Get("KXCPI-26MAR")
+	if len(e.Notes) != 2 || e.Notes[0].Text != "watch CPI print" {
+		t.Errorf("notes = %+v", e.Notes)
+	}
+	if got := e.Summary(); got != "#cpi #macro consensus 0.3%" {
+		t.Errorf("Summary() = %q", got)
+	}
+	if got := b.Tickers("CPI"); len(got) != 1 {
+		t.Errorf("Tickers(cpi) = %v", got)
+	}
+	if got := b.Tickers("fed"); len(got) != 0 {
+		t.Errorf("Tickers(fed) = %v", got)
+	}
+
+	if err := b.DeleteNote("KXCPI-26MAR", 3); err == nil {
+		t.Error("expected an error deleting a missing note")
+	}
+	if err := b.DeleteNote("KXCPI-26MAR", 1); err != nil {
+		t.Fatalf("DeleteNote failed: %v", err)
+	}
+	if e := b.Get("KXCPI-26MAR"); len(e.Notes) != 1 || e.Notes[0].Text != "consensus 0.3%" {
+		t.Errorf("notes after delete = %+v", e.Notes)
+	}
+
+	b.RemoveTags("KXCPI-26MAR", "cpi", "macro")
+	b.DeleteNote("KXCPI-26MAR", 1)
+	if len(b.Markets) != 0 {
+		t.Errorf("an emptied entry should be dropped, got %+v", b.Markets)
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "notes.json")
+
+	b, err := Load(path)
+	if err != nil || len(b.Markets) != 0 {
+		t.Fatalf("Load(missing) = %+v, %v", b, err)
+	}
+	b.AddTags("INXD-25FEB07-B5523.99", "spx")
+	if err := Save(path, b); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if tags := got.Get("INXD-25FEB07-B5523.99").Tags; len(tags) != 1 || tags[0] != "spx" {
+		t.Errorf("tags = %v", tags)
+	}
+}
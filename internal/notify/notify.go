@@ -0,0 +1,116 @@
+// Package notify delivers alert notifications to webhooks, Slack, and the desktop.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// Message is a single alert notification
+type Message struct {
+	Title string         `json:"title"`
+	Body  string         `json:"body"`
+	Time  time.Time      `json:"time"`
+	Data  map[string]any `json:"data,omitempty"`
+}
+
+// Notifier delivers a message to one destination
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Webhook POSTs the message as JSON to URL
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+// Notify sends msg to the webhook
+func (w *Webhook) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, w.Client, w.URL, msg)
+}
+
+// Slack posts the message to a Slack incoming webhook
+type Slack struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// Notify sends msg to Slack
+func (s *Slack) Notify(ctx context.Context, msg Message) error {
+	payload := map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", msg.Title, msg.Body),
+	}
+	return postJSON(ctx, s.Client, s.WebhookURL, payload)
+}
+
+// Desktop shows a native desktop notification (notify-send on Linux, osascript on macOS)
+type Desktop struct{}
+
+// Notify displays msg on the desktop
+func (Desktop) Notify(ctx context.Context, msg Message) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", msg.Body, msg.Title)
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.CommandContext(ctx, "notify-send", msg.Title, msg.Body)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("desktop notification failed: %w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// Multi fans a message out to several notifiers
+type Multi []Notifier
+
+// Notify delivers msg to every notifier, returning the joined errors
+func (m Multi) Notify(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned %s", resp.Status)
+	}
+	return nil
+}
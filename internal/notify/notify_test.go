@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhook_PostsMessageJSON(t *testing.T) {
+	var got Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %s", ct)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	msg := Message{Title: "Low balance", Body: "below $50.00", Time: time.Unix(1700000000, 0).UTC()}
+	if err := (&Webhook{URL: server.URL}).Notify(context.Background(), msg); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if got.Title != msg.Title || got.Body != msg.Body || !got.Time.Equal(msg.Time) {
+		t.Errorf("unexpected payload: %+v", got)
+	}
+}
+
+func TestSlack_PostsText(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	err := (&Slack{WebhookURL: server.URL}).Notify(context.Background(), Message{Title: "Alert", Body: "details"})
+	if err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if !strings.Contains(got["text"], "Alert") || !strings.Contains(got["text"], "details") {
+		t.Errorf("unexpected slack text: %q", got["text"])
+	}
+}
+
+func TestWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := (&Webhook{URL: server.URL}).Notify(context.Background(), Message{Title: "x"})
+	if err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+}
+
+type stubNotifier struct {
+	calls int
+	err   error
+}
+
+func (s *stubNotifier) Notify(ctx context.Context, msg Message) error {
+	s.calls++
+	return s.err
+}
+
+func TestMulti_DeliversToAllAndJoinsErrors(t *testing.T) {
+	failing := &stubNotifier{err: errors.New("boom")}
+	ok := &stubNotifier{}
+
+	err := Multi{failing, ok}.Notify(context.Background(), Message{})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected joined error, got %v", err)
+	}
+	if failing.calls != 1 || ok.calls != 1 {
+		t.Errorf("expected both notifiers called once, got %d and %d", failing.calls, ok.calls)
+	}
+}
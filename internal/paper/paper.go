@@ -0,0 +1,550 @@
+// Package paper simulates order entry locally. Orders are filled against the
+// live orderbook and the resulting balance, positions, orders and fills are
+// kept in a local file, so strategies can be exercised without sending
+// anything to the exchange.
+package paper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/filelock"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+const fileName = "paper.json"
+
+// DefaultBalance is the starting balance in cents of a new paper account
+const DefaultBalance = 100000
+
+// ErrNotFound is returned for an order ID the paper account does not have
+var ErrNotFound = errors.New("paper order not found")
+
+// Position is the simulated holding in one market. Position is positive for
+// YES contracts and negative for NO, and Cost is what was paid for them.
+type Position struct {
+	Position    int `json:"position"`
+	Cost        int `json:"cost"`
+	RealizedPnl int `json:"realized_pnl"`
+	TotalTraded int `json:"total_traded"`
+}
+
+// State is everything the paper account holds
+type State struct {
+	StartingBalance int                  `json:"starting_balance"`
+	Balance         int                  `json:"balance"`
+	Positions       map[string]*Position `json:"positions"`
+	Orders          []models.Order       `json:"orders"`
+	Fills           []models.Fill        `json:"fills"`
+	NextID          int                  `json:"next_id"`
+	// Taken is how much each resting order has already filled at each YES
+	// price, so it does not fill twice against liquidity the live book
+	// still shows
+	Taken map[string]map[int]int `json:"taken,omitempty"`
+}
+
+// NewState returns an empty paper account holding balance cents
+func NewState(balance int) *State {
+	return &State{
+		StartingBalance: balance,
+		Balance:         balance,
+		Positions:       make(map[string]*Position),
+	}
+}
+
+// BookFunc fetches the live orderbook for a market
+type BookFunc func(ctx context.Context, ticker string) (*models.Orderbook, error)
+
+// Create validates and places an order, filling what it can against book.
+// Limit orders rest with any remainder; market orders cancel it.
+func (s *State) Create(req models.CreateOrderRequest, book *models.Orderbook, now time.Time) (models.Order, error) {
+	if req.Ticker == "" {
+		return models.Order{}, fmt.Errorf("ticker is required")
+	}
+	if req.Count <= 0 {
+		return models.Order{}, fmt.Errorf("count must be positive")
+	}
+	if req.Side != models.OrderSideYes && req.Side != models.OrderSideNo {
+		return models.Order{}, fmt.Errorf("side must be yes or no")
+	}
+	if req.Action != models.OrderActionBuy && req.Action != models.OrderActionSell {
+		return models.Order{}, fmt.Errorf("action must be buy or sell")
+	}
+
+	price := req.YesPrice
+	if req.Side == models.OrderSideNo {
+		price = req.NoPrice
+	}
+	if req.Type == models.OrderTypeMarket {
+		// Cross the whole book; the remainder is cancelled below
+		price = 99
+		if req.Action == models.OrderActionSell {
+			price = 1
+		}
+	}
+	if price < 1 || price > 99 {
+		return models.Order{}, fmt.Errorf("price must be between 1 and 99 cents")
+	}
+
+	s.NextID++
+	o := models.Order{
+		OrderID:        "paper-" + strconv.Itoa(s.NextID),
+		Ticker:         req.Ticker,
+		Status:         models.OrderStatusResting,
+		Type:           req.Type,
+		Side:           req.Side,
+		Action:         req.Action,
+		InitialCount:   req.Count,
+		RemainingCount: req.Count,
+		CreatedTime:    now.UTC(),
+		LastUpdateTime: now.UTC(),
+		ClientOrderID:  req.ClientOrderID,
+	}
+	if o.Type == "" {
+		o.Type = models.OrderTypeLimit
+	}
+	setPrice(&o, price)
+
+	if need := s.reserve(o); need > s.Available() {
+		return models.Order{}, fmt.Errorf("insufficient paper balance: order needs %d cents, %d available", need, s.Available())
+	}
+
+	s.match(&o, book, true, now)
+	if o.RemainingCount > 0 && o.Type == models.OrderTypeMarket {
+		o.Status = models.OrderStatusCanceled
+	}
+	if o.RemainingCount == 0 {
+		o.Status = models.OrderStatusExecuted
+	}
+	s.Orders = append(s.Orders, o)
+	return o, nil
+}
+
+// Cancel cancels a resting order
+func (s *State) Cancel(id string, now time.Time) (models.Order, error) {
+	o, err := s.resting(id)
+	if err != nil {
+		return models.Order{}, err
+	}
+	o.Status = models.OrderStatusCanceled
+	o.LastUpdateTime = now.UTC()
+	delete(s.Taken, id)
+	return *o, nil
+}
+
+// Amend changes a resting order's price, on its own side, and remaining
+// count, then fills what now crosses book
+func (s *State) Amend(id string, req models.AmendOrderRequest, book *models.Orderbook, now time.Time) (models.Order, error) {
+	o, err := s.resting(id)
+	if err != nil {
+		return models.Order{}, err
+	}
+	if req.Price != 0 && (req.Price < 1 || req.Price > 99) {
+		return models.Order{}, fmt.Errorf("price must be between 1 and 99 cents")
+	}
+	if req.Count < 0 {
+		return models.Order{}, fmt.Errorf("count cannot be negative")
+	}
+
+	amended := *o
+	if req.Price != 0 {
+		setPrice(&amended, req.Price)
+	}
+	if req.Count != 0 {
+		amended.InitialCount += req.Count - amended.RemainingCount
+		amended.RemainingCount = req.Count
+	}
+	if need := s.reserve(amended) - s.reserve(*o); need > s.Available() {
+		return models.Order{}, fmt.Errorf("insufficient paper balance: amendment needs %d cents, %d available", need, s.Available())
+	}
+	amended.LastUpdateTime = now.UTC()
+	*o = amended
+
+	s.match(o, book, true, now)
+	if o.RemainingCount == 0 {
+		o.Status = models.OrderStatusExecuted
+	}
+	return *o, nil
+}
+
+// Decrease reduces a resting order's remaining count, cancelling it when
+// nothing is left
+func (s *State) Decrease(id string, reduceBy int, now time.Time) (models.Order, error) {
+	o, err := s.resting(id)
+	if err != nil {
+		return models.Order{}, err
+	}
+	if reduceBy <= 0 {
+		return models.Order{}, fmt.Errorf("reduce_by must be positive")
+	}
+	reduceBy = min(reduceBy, o.RemainingCount)
+	o.RemainingCount -= reduceBy
+	o.InitialCount -= reduceBy
+	if o.RemainingCount == 0 {
+		o.Status = models.OrderStatusCanceled
+		delete(s.Taken, id)
+	}
+	o.LastUpdateTime = now.UTC()
+	return *o, nil
+}
+
+// Sweep fills resting orders in one market that the current book crosses.
+// Resting orders are passive, so they fill as maker at their own price.
+func (s *State) Sweep(ticker string, book *models.Orderbook, now time.Time) {
+	for i := range s.Orders {
+		o := &s.Orders[i]
+		if o.Ticker != ticker || o.Status != models.OrderStatusResting {
+			continue
+		}
+		s.match(o, book, false, now)
+		if o.RemainingCount == 0 {
+			o.Status = models.OrderStatusExecuted
+		}
+	}
+}
+
+// RestingTickers lists the markets with resting orders, sorted
+func (s *State) RestingTickers() []string {
+	seen := map[string]bool{}
+	var tickers []string
+	for _, o := range s.Orders {
+		if o.Status == models.OrderStatusResting && !seen[o.Ticker] {
+			seen[o.Ticker] = true
+			tickers = append(tickers, o.Ticker)
+		}
+	}
+	sort.Strings(tickers)
+	return tickers
+}
+
+// Order returns an order by ID
+func (s *State) Order(id string) (models.Order, error) {
+	for _, o := range s.Orders {
+		if o.OrderID == id {
+			return o, nil
+		}
+	}
+	return models.Order{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Available is the balance not set aside for resting orders
+func (s *State) Available() int {
+	available := s.Balance
+	for _, o := range s.Orders {
+		if o.Status == models.OrderStatusResting {
+			available -= s.reserve(o)
+		}
+	}
+	return available
+}
+
+// MarketPositions returns the open and previously traded positions, sorted
+// by ticker, in the API's shape
+func (s *State) MarketPositions() []models.MarketPosition {
+	resting := map[string]int{}
+	for _, o := range s.Orders {
+		if o.Status == models.OrderStatusResting {
+			resting[o.Ticker]++
+		}
+	}
+	positions := make([]models.MarketPosition, 0, len(s.Positions))
+	for ticker, p := range s.Positions {
+		positions = append(positions, models.MarketPosition{
+			Ticker:             ticker,
+			Position:           p.Position,
+			MarketExposure:     p.Cost,
+			RealizedPnl:        p.RealizedPnl,
+			TotalTraded:        p.TotalTraded,
+			RestingOrdersCount: resting[ticker],
+		})
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i].Ticker < positions[j].Ticker })
+	return positions
+}
+
+// Exposure is the total cost of every open position
+func (s *State) Exposure() int {
+	total := 0
+	for _, p := range s.Positions {
+		total += p.Cost
+	}
+	return total
+}
+
+func (s *State) resting(id string) (*models.Order, error) {
+	for i := range s.Orders {
+		o := &s.Orders[i]
+		if o.OrderID != id {
+			continue
+		}
+		if o.Status != models.OrderStatusResting {
+			return nil, fmt.Errorf("paper order %s is %s, not resting", id, o.Status)
+		}
+		return o, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// match fills o against the levels of book it crosses, less what o has
+// already taken there. Paper fills do not deplete the live book, so separate
+// orders may still fill against the same liquidity.
+func (s *State) match(o *models.Order, book *models.Orderbook, taker bool, now time.Time) {
+	defer s.untake(*o)
+	if book == nil || o.RemainingCount == 0 {
+		return
+	}
+	buysYes := isYesBid(*o)
+	var levels []models.OrderbookLevel
+	if buysYes {
+		levels = yesLevels(book.YesAsks, book.NoBids)
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+	} else {
+		levels = yesLevels(book.YesBids, book.NoAsks)
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+	}
+
+	limit := o.YesPrice
+	for _, level := range levels {
+		if o.RemainingCount == 0 {
+			break
+		}
+		if (buysYes && level.Price > limit) || (!buysYes && level.Price < limit) {
+			break
+		}
+		available := level.Quantity - s.Taken[o.OrderID][level.Price]
+		if available <= 0 {
+			continue
+		}
+		count := min(available, o.RemainingCount)
+		if s.Taken == nil {
+			s.Taken = make(map[string]map[int]int)
+		}
+		if s.Taken[o.OrderID] == nil {
+			s.Taken[o.OrderID] = make(map[int]int)
+		}
+		s.Taken[o.OrderID][level.Price] += count
+
+		price := level.Price
+		if !taker {
+			price = limit
+		}
+		s.fill(o, count, price, taker, now)
+	}
+}
+
+// untake forgets what o has taken once it can no longer fill
+func (s *State) untake(o models.Order) {
+	if o.RemainingCount == 0 || o.Type == models.OrderTypeMarket {
+		delete(s.Taken, o.OrderID)
+	}
+}
+
+// yesLevels copies the YES levels of one side of the book, deriving them
+// from the opposite NO levels when the book only carries those
+func yesLevels(yes, no []models.OrderbookLevel) []models.OrderbookLevel {
+	if len(yes) > 0 {
+		return append([]models.OrderbookLevel(nil), yes...)
+	}
+	levels := make([]models.OrderbookLevel, 0, len(no))
+	for _, l := range no {
+		levels = append(levels, models.OrderbookLevel{Price: 100 - l.Price, Quantity: l.Quantity})
+	}
+	return levels
+}
+
+// fill records count contracts of o trading at yesPrice
+func (s *State) fill(o *models.Order, count, yesPrice int, taker bool, now time.Time) {
+	delta := count
+	if !isYesBid(*o) {
+		delta = -count
+	}
+	p := s.Positions[o.Ticker]
+	if p == nil {
+		p = &Position{}
+		s.Positions[o.Ticker] = p
+	}
+	s.Balance += p.trade(delta, yesPrice)
+
+	o.RemainingCount -= count
+	o.FillCount += count
+	cost := count * sidePrice(*o, yesPrice)
+	if taker {
+		o.TakerFillCount += count
+		o.TakerFillCost += cost
+	} else {
+		o.MakerFillCount += count
+		o.MakerFillCost += cost
+	}
+	o.LastUpdateTime = now.UTC()
+
+	s.Fills = append(s.Fills, models.Fill{
+		TradeID:     fmt.Sprintf("%s-%d", o.OrderID, len(s.Fills)+1),
+		OrderID:     o.OrderID,
+		Ticker:      o.Ticker,
+		Side:        string(o.Side),
+		Action:      string(o.Action),
+		Type:        string(o.Type),
+		YesPrice:    yesPrice,
+		NoPrice:     100 - yesPrice,
+		Count:       count,
+		IsTaker:     taker,
+		CreatedTime: now.UTC(),
+	})
+}
+
+// trade applies delta YES contracts (negative for NO) at yesPrice to the
+// position and returns the change in cash. Trades against the position
+// close it first, realizing P&L against the average cost.
+func (p *Position) trade(delta, yesPrice int) int {
+	cash := 0
+	p.TotalTraded += abs(delta)
+	if p.Position != 0 && (p.Position > 0) != (delta > 0) {
+		n := min(abs(delta), abs(p.Position))
+		proceeds := yesPrice
+		if p.Position < 0 {
+			proceeds = 100 - yesPrice
+		}
+		basis := p.Cost * n / abs(p.Position)
+		p.Cost -= basis
+		p.RealizedPnl += n*proceeds - basis
+		cash += n * proceeds
+		if delta > 0 {
+			p.Position += n
+			delta -= n
+		} else {
+			p.Position -= n
+			delta += n
+		}
+	}
+	if delta != 0 {
+		price := yesPrice
+		if delta < 0 {
+			price = 100 - yesPrice
+		}
+		cost := abs(delta) * price
+		p.Cost += cost
+		p.Position += delta
+		cash -= cost
+	}
+	return cash
+}
+
+// isYesBid reports whether o buys YES exposure: buying YES or selling NO
+func isYesBid(o models.Order) bool {
+	return (o.Side == models.OrderSideYes) == (o.Action == models.OrderActionBuy)
+}
+
+// setPrice sets o's limit from a price on its own side
+func setPrice(o *models.Order, price int) {
+	if o.Side == models.OrderSideNo {
+		o.NoPrice, o.YesPrice = price, 100-price
+		return
+	}
+	o.YesPrice, o.NoPrice = price, 100-price
+}
+
+// sidePrice converts a YES price to the price on o's side
+func sidePrice(o models.Order, yesPrice int) int {
+	if o.Side == models.OrderSideNo {
+		return 100 - yesPrice
+	}
+	return yesPrice
+}
+
+// reserve is the most the remainder of o can cost. Buys pay their price;
+// sells beyond the contracts held open the opposite side at its complement.
+func (s *State) reserve(o models.Order) int {
+	price := sidePrice(o, o.YesPrice)
+	if o.Action == models.OrderActionBuy {
+		return o.RemainingCount * price
+	}
+	held := 0
+	if p := s.Positions[o.Ticker]; p != nil {
+		if o.Side == models.OrderSideYes {
+			held = max(p.Position, 0)
+		} else {
+			held = max(-p.Position, 0)
+		}
+	}
+	return max(o.RemainingCount-held, 0) * (100 - price)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// DefaultPath returns the paper account file inside the data directory
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, fileName)
+}
+
+// Load reads the paper account at path. A missing file is a new account
+// holding DefaultBalance.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return NewState(DefaultBalance), nil
+		}
+		return nil, fmt.Errorf("failed to read paper account: %w", err)
+	}
+	s := NewState(0)
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse paper account %s: %w", path, err)
+	}
+	if s.Positions == nil {
+		s.Positions = make(map[string]*Position)
+	}
+	return s, nil
+}
+
+// Save writes the paper account to path, replacing the previous file
+// atomically
+func Save(path string, s *State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create paper directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode paper account: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write paper account: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write paper account: %w", err)
+	}
+	return nil
+}
+
+// Update loads the paper account at path, applies fn and saves the result,
+// holding a lock on the file throughout so paper commands running in other
+// processes cannot overwrite each other's orders, fills and balance. Nothing
+// is saved if fn fails.
+func Update(path string, fn func(*State) error) error {
+	release, err := filelock.Acquire(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	s, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(s); err != nil {
+		return err
+	}
+	return Save(path, s)
+}
@@ -0,0 +1,194 @@
+package paper
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testBook() *models.Orderbook {
+	return &models.Orderbook{
+		Ticker:  "KXTEST",
+		YesBids: []models.OrderbookLevel{{Price: 40, Quantity: 5}, {Price: 38, Quantity: 10}},
+		YesAsks: []models.OrderbookLevel{{Price: 45, Quantity: 3}, {Price: 47, Quantity: 10}},
+	}
+}
+
+func buyYes(count, price int) models.CreateOrderRequest {
+	return models.CreateOrderRequest{Ticker: "KXTEST", Side: models.OrderSideYes, Action: models.OrderActionBuy, Type: models.OrderTypeLimit, Count: count, YesPrice: price}
+}
+
+func TestCreateFillsAcrossLevelsAndRests(t *testing.T) {
+	s := NewState(10000)
+	o, err := s.Create(buyYes(8, 46), testBook(), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.FillCount != 3 || o.RemainingCount != 5 || o.Status != models.OrderStatusResting {
+		t.Fatalf("order = %+v, want 3 filled and 5 resting", o)
+	}
+	if s.Balance != 10000-3*45 {
+		t.Errorf("balance = %d, want %d", s.Balance, 10000-3*45)
+	}
+	if got := s.Available(); got != s.Balance-5*46 {
+		t.Errorf("available = %d, want %d", got, s.Balance-5*46)
+	}
+	if p := s.Positions["KXTEST"]; p.Position != 3 || p.Cost != 135 {
+		t.Errorf("position = %+v, want 3 YES costing 135", p)
+	}
+
+	// The offer drops to the resting bid, which fills as maker at its price
+	book := testBook()
+	book.YesAsks = []models.OrderbookLevel{{Price: 46, Quantity: 20}}
+	s.Sweep("KXTEST", book, testNow)
+	got, _ := s.Order(o.OrderID)
+	if got.Status != models.OrderStatusExecuted || got.MakerFillCount != 5 {
+		t.Errorf("after sweep order = %+v, want executed with 5 maker fills", got)
+	}
+	if len(s.Fills) != 2 || s.Fills[1].IsTaker {
+		t.Errorf("fills = %+v", s.Fills)
+	}
+}
+
+func TestSweepSkipsLiquidityAlreadyTaken(t *testing.T) {
+	s := NewState(10000)
+	o, err := s.Create(buyYes(5, 45), testBook(), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The live book still shows the 3 at 45 this order took
+	s.Sweep("KXTEST", testBook(), testNow)
+	if got, _ := s.Order(o.OrderID); got.FillCount != 3 {
+		t.Errorf("fill count = %d, want 3", got.FillCount)
+	}
+
+	book := testBook()
+	book.YesAsks[0].Quantity = 4
+	s.Sweep("KXTEST", book, testNow)
+	if got, _ := s.Order(o.OrderID); got.FillCount != 4 {
+		t.Errorf("fill count = %d, want 4 after one more at 45", got.FillCount)
+	}
+}
+
+func TestMarketOrderCancelsRemainder(t *testing.T) {
+	s := NewState(10000)
+	req := buyYes(20, 0)
+	req.Type = models.OrderTypeMarket
+	o, err := s.Create(req, testBook(), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.FillCount != 13 || o.Status != models.OrderStatusCanceled {
+		t.Errorf("order = %+v, want 13 filled and the rest canceled", o)
+	}
+}
+
+func TestBuyYesUsesNoBidsWhenAsksMissing(t *testing.T) {
+	s := NewState(10000)
+	book := &models.Orderbook{NoBids: []models.OrderbookLevel{{Price: 60, Quantity: 4}}}
+	o, err := s.Create(buyYes(4, 40), book, testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.FillCount != 4 || s.Fills[0].YesPrice != 40 {
+		t.Errorf("order = %+v fills = %+v, want 4 filled at 40", o, s.Fills)
+	}
+}
+
+func TestClosingRealizesPnl(t *testing.T) {
+	s := NewState(10000)
+	if _, err := s.Create(buyYes(3, 45), testBook(), testNow); err != nil {
+		t.Fatal(err)
+	}
+	sell := models.CreateOrderRequest{Ticker: "KXTEST", Side: models.OrderSideYes, Action: models.OrderActionSell, Count: 3, YesPrice: 40}
+	o, err := s.Create(sell, testBook(), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != models.OrderStatusExecuted {
+		t.Fatalf("sell = %+v, want executed", o)
+	}
+	p := s.Positions["KXTEST"]
+	if p.Position != 0 || p.Cost != 0 || p.RealizedPnl != -15 {
+		t.Errorf("position = %+v, want flat with -15 realized", p)
+	}
+	if s.Balance != 10000-15 {
+		t.Errorf("balance = %d, want %d", s.Balance, 10000-15)
+	}
+}
+
+func TestBuyingNoOpensShortYes(t *testing.T) {
+	s := NewState(10000)
+	req := models.CreateOrderRequest{Ticker: "KXTEST", Side: models.OrderSideNo, Action: models.OrderActionBuy, Count: 5, NoPrice: 60}
+	if _, err := s.Create(req, testBook(), testNow); err != nil {
+		t.Fatal(err)
+	}
+	p := s.Positions["KXTEST"]
+	if p.Position != -5 || p.Cost != 5*60 {
+		t.Errorf("position = %+v, want 5 NO costing 300", p)
+	}
+}
+
+func TestCreateRejectsInsufficientBalance(t *testing.T) {
+	s := NewState(100)
+	if _, err := s.Create(buyYes(10, 30), testBook(), testNow); err == nil {
+		t.Error("expected insufficient balance error")
+	}
+	if len(s.Orders) != 0 {
+		t.Errorf("orders = %d, want none", len(s.Orders))
+	}
+}
+
+func TestAmendAndCancel(t *testing.T) {
+	s := NewState(10000)
+	o, err := s.Create(buyYes(5, 30), testBook(), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amended, err := s.Amend(o.OrderID, models.AmendOrderRequest{Price: 45}, testBook(), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amended.FillCount != 3 || amended.RemainingCount != 2 {
+		t.Errorf("amended = %+v, want 3 filled and 2 resting", amended)
+	}
+	if _, err := s.Cancel(o.OrderID, testNow); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Cancel(o.OrderID, testNow); err == nil {
+		t.Error("expected error cancelling a canceled order")
+	}
+	if _, err := s.Cancel("paper-99", testNow); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "paper.json")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Balance != DefaultBalance {
+		t.Errorf("new balance = %d, want %d", s.Balance, DefaultBalance)
+	}
+	if _, err := s.Create(buyYes(3, 45), testBook(), testNow); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(path, s); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Balance != s.Balance || len(loaded.Orders) != 1 || loaded.Positions["KXTEST"].Position != 3 {
+		t.Errorf("loaded = %+v", loaded)
+	}
+}
@@ -0,0 +1,321 @@
+package paper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// Transport answers order and portfolio requests from the paper account at
+// path and forwards other reads to next. Writes it does not simulate are
+// refused, so nothing in paper mode can change the real account.
+type Transport struct {
+	next http.RoundTripper
+	path string
+	book BookFunc
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+// NewTransport wraps next, reading books through book to fill orders.
+// A nil next uses http.DefaultTransport.
+func NewTransport(next http.RoundTripper, path string, book BookFunc) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{next: next, path: path, book: book, now: time.Now}
+}
+
+// httpError is a simulated API error response
+type httpError struct {
+	status int
+	code   string
+	err    error
+}
+
+func (e *httpError) Error() string { return e.err.Error() }
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route, ok := portfolioRoute(req.URL.Path)
+	if !ok {
+		if req.Method == http.MethodGet || req.Method == http.MethodHead {
+			return t.next.RoundTrip(req)
+		}
+		return respond(req, http.StatusForbidden, errorBody("paper_unsupported",
+			fmt.Sprintf("%s %s is not simulated in paper mode", req.Method, req.URL.Path)))
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var result any
+	var reply *httpError
+	err := Update(t.path, func(state *State) error {
+		if err := t.sweep(req.Context(), state); err != nil {
+			reply = &httpError{status: http.StatusFailedDependency, code: "paper_book", err: err}
+			return reply
+		}
+		var err error
+		if result, err = t.handle(req, route, body, state); err != nil {
+			if !errors.As(err, &reply) {
+				reply = &httpError{status: http.StatusBadRequest, code: "paper_invalid", err: err}
+			}
+			return reply
+		}
+		return nil
+	})
+	if reply != nil {
+		return respond(req, reply.status, errorBody(reply.code, reply.Error()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return respond(req, http.StatusOK, result)
+}
+
+// portfolioRoute returns the part of an API path after /portfolio
+func portfolioRoute(path string) (string, bool) {
+	i := strings.Index(path, "/portfolio/")
+	if i < 0 {
+		return "", false
+	}
+	route := path[i+len("/portfolio"):]
+	switch {
+	case route == "/balance", route == "/positions", route == "/fills", strings.HasPrefix(route, "/orders"):
+		return route, true
+	}
+	return "", false
+}
+
+// sweep fills resting orders that the current books cross
+func (t *Transport) sweep(ctx context.Context, state *State) error {
+	now := t.now()
+	for _, ticker := range state.RestingTickers() {
+		book, err := t.book(ctx, ticker)
+		if err != nil {
+			return fmt.Errorf("failed to get orderbook for %s: %w", ticker, err)
+		}
+		state.Sweep(ticker, book, now)
+	}
+	return nil
+}
+
+func (t *Transport) handle(req *http.Request, route string, body []byte, state *State) (any, error) {
+	q := req.URL.Query()
+	now := t.now()
+	switch {
+	case route == "/balance" && req.Method == http.MethodGet:
+		return models.BalanceResponse{Balance: state.Balance, PortfolioValue: state.Exposure(), UpdatedTs: now.Unix()}, nil
+
+	case route == "/positions" && req.Method == http.MethodGet:
+		positions := []models.MarketPosition{}
+		for _, p := range state.MarketPositions() {
+			if q.Get("ticker") == "" || p.Ticker == q.Get("ticker") {
+				positions = append(positions, p)
+			}
+		}
+		return models.PositionsResponse{Positions: positions}, nil
+
+	case route == "/fills" && req.Method == http.MethodGet:
+		fills := []models.Fill{}
+		for i := len(state.Fills) - 1; i >= 0; i-- {
+			f := state.Fills[i]
+			if (q.Get("ticker") == "" || f.Ticker == q.Get("ticker")) && (q.Get("order_id") == "" || f.OrderID == q.Get("order_id")) {
+				fills = append(fills, f)
+			}
+		}
+		return models.FillsResponse{Fills: fills}, nil
+
+	case route == "/orders" && req.Method == http.MethodGet:
+		return models.OrdersResponse{Orders: listOrders(state, q.Get("ticker"), q.Get("status"))}, nil
+
+	case route == "/orders" && req.Method == http.MethodPost:
+		var create models.CreateOrderRequest
+		if err := json.Unmarshal(body, &create); err != nil {
+			return nil, err
+		}
+		order, err := t.create(req.Context(), state, create, now)
+		if err != nil {
+			return nil, err
+		}
+		return models.CreateOrderResponse{Order: order}, nil
+
+	case isBatchRoute(route) && req.Method == http.MethodPost:
+		var batch models.BatchCreateOrdersRequest
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil, err
+		}
+		orders := []models.Order{}
+		for _, create := range batch.Orders {
+			order, err := t.create(req.Context(), state, create, now)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
+		}
+		return models.BatchCreateOrdersResponse{Orders: orders}, nil
+
+	case isBatchRoute(route) && req.Method == http.MethodDelete:
+		var batch models.BatchCancelOrdersRequest
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil, err
+		}
+		ids := batch.OrderIDs
+		if len(ids) == 0 {
+			for _, o := range listOrders(state, batch.Ticker, string(models.OrderStatusResting)) {
+				ids = append(ids, o.OrderID)
+			}
+		}
+		orders := []models.Order{}
+		for _, id := range ids {
+			order, err := state.Cancel(id, now)
+			if err != nil {
+				return nil, notFound(err)
+			}
+			orders = append(orders, order)
+		}
+		return models.BatchCancelOrdersResponse{Orders: orders}, nil
+	}
+
+	id, action, _ := strings.Cut(strings.TrimPrefix(route, "/orders/"), "/")
+	switch {
+	case action == "" && req.Method == http.MethodGet:
+		order, err := state.Order(id)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		return models.OrderResponse{Order: order}, nil
+
+	case action == "" && req.Method == http.MethodDelete:
+		order, err := state.Cancel(id, now)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		return models.OrderResponse{Order: order}, nil
+
+	case action == "" && req.Method == http.MethodPatch:
+		var amend models.AmendOrderRequest
+		if err := json.Unmarshal(body, &amend); err != nil {
+			return nil, err
+		}
+		existing, err := state.Order(id)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		book, err := t.fetchBook(req.Context(), existing.Ticker)
+		if err != nil {
+			return nil, err
+		}
+		order, err := state.Amend(id, amend, book, now)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		return models.OrderResponse{Order: order}, nil
+
+	case action == "decrease" && req.Method == http.MethodPatch:
+		var decrease models.DecreaseOrderRequest
+		if err := json.Unmarshal(body, &decrease); err != nil {
+			return nil, err
+		}
+		order, err := state.Decrease(id, decrease.ReduceBy, now)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		return models.OrderResponse{Order: order}, nil
+	}
+
+	return nil, &httpError{
+		status: http.StatusForbidden,
+		code:   "paper_unsupported",
+		err:    fmt.Errorf("%s %s is not simulated in paper mode", req.Method, req.URL.Path),
+	}
+}
+
+// isBatchRoute reports whether route takes batches of orders. Batches are
+// sent to /orders/batched by the order commands and to /orders/batch by the
+// API methods.
+func isBatchRoute(route string) bool {
+	return route == "/orders/batch" || route == "/orders/batched"
+}
+
+func (t *Transport) create(ctx context.Context, state *State, req models.CreateOrderRequest, now time.Time) (models.Order, error) {
+	book, err := t.fetchBook(ctx, req.Ticker)
+	if err != nil {
+		return models.Order{}, err
+	}
+	return state.Create(req, book, now)
+}
+
+func (t *Transport) fetchBook(ctx context.Context, ticker string) (*models.Orderbook, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("ticker is required")
+	}
+	book, err := t.book(ctx, ticker)
+	if err != nil {
+		return nil, &httpError{status: http.StatusFailedDependency, code: "paper_book", err: fmt.Errorf("failed to get orderbook for %s: %w", ticker, err)}
+	}
+	return book, nil
+}
+
+// listOrders returns orders newest first, optionally in one market or status
+func listOrders(state *State, ticker, status string) []models.Order {
+	orders := []models.Order{}
+	for _, o := range state.Orders {
+		if (ticker == "" || o.Ticker == ticker) && (status == "" || string(o.Status) == status) {
+			orders = append(orders, o)
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool { return orders[i].CreatedTime.After(orders[j].CreatedTime) })
+	return orders
+}
+
+// notFound marks unknown order IDs as 404s
+func notFound(err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return &httpError{status: http.StatusNotFound, code: "not_found", err: err}
+	}
+	return err
+}
+
+func errorBody(code, message string) map[string]string {
+	return map[string]string{"code": code, "message": message}
+}
+
+func respond(req *http.Request, status int, v any) (*http.Response, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
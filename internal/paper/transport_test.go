@@ -0,0 +1,201 @@
+package paper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func newTestTransport(t *testing.T) (*Transport, *[]string) {
+	t.Helper()
+	var forwarded []string
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		forwarded = append(forwarded, req.Method+" "+req.URL.Path)
+		return respond(req, http.StatusOK, map[string]any{})
+	})
+	book := func(ctx context.Context, ticker string) (*models.Orderbook, error) {
+		return testBook(), nil
+	}
+	tr := NewTransport(next, filepath.Join(t.TempDir(), "paper.json"), book)
+	tr.now = func() time.Time { return testNow }
+	return tr, &forwarded
+}
+
+func do(t *testing.T, tr *Transport, method, path, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, "https://demo-api.kalshi.co/trade-api/v2"+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestTransportSimulatesOrders(t *testing.T) {
+	tr, forwarded := newTestTransport(t)
+
+	var created models.CreateOrderResponse
+	status := do(t, tr, http.MethodPost, "/portfolio/orders", `{"ticker":"KXTEST","side":"yes","action":"buy","type":"limit","count":5,"yes_price":45}`, &created)
+	if status != http.StatusOK || created.Order.FillCount != 3 {
+		t.Fatalf("create = %d %+v", status, created.Order)
+	}
+
+	var positions models.PositionsResponse
+	do(t, tr, http.MethodGet, "/portfolio/positions?ticker=KXTEST", "", &positions)
+	if len(positions.Positions) != 1 || positions.Positions[0].Position != 3 {
+		t.Errorf("positions = %+v", positions.Positions)
+	}
+
+	var balance models.BalanceResponse
+	do(t, tr, http.MethodGet, "/portfolio/balance", "", &balance)
+	if balance.Balance != DefaultBalance-135 {
+		t.Errorf("balance = %d, want %d", balance.Balance, DefaultBalance-135)
+	}
+
+	var cancelled models.OrderResponse
+	status = do(t, tr, http.MethodDelete, "/portfolio/orders/"+created.Order.OrderID, "", &cancelled)
+	if status != http.StatusOK || cancelled.Order.Status != models.OrderStatusCanceled {
+		t.Errorf("cancel = %d %+v", status, cancelled.Order)
+	}
+
+	if status := do(t, tr, http.MethodDelete, "/portfolio/orders/paper-99", "", nil); status != http.StatusNotFound {
+		t.Errorf("cancel unknown = %d, want 404", status)
+	}
+	if len(*forwarded) != 0 {
+		t.Errorf("forwarded %v, want nothing sent upstream", *forwarded)
+	}
+}
+
+func TestTransportForwardsReadsAndRefusesOtherWrites(t *testing.T) {
+	tr, forwarded := newTestTransport(t)
+
+	do(t, tr, http.MethodGet, "/markets/KXTEST", "", nil)
+	if len(*forwarded) != 1 {
+		t.Errorf("forwarded = %v, want the market read", *forwarded)
+	}
+
+	var apiErr struct{ Code string }
+	status := do(t, tr, http.MethodPost, "/communications/rfqs", `{}`, &apiErr)
+	if status != http.StatusForbidden || apiErr.Code != "paper_unsupported" {
+		t.Errorf("rfq create = %d %+v, want 403 paper_unsupported", status, apiErr)
+	}
+	if len(*forwarded) != 1 {
+		t.Errorf("forwarded = %v, want the write refused", *forwarded)
+	}
+}
+
+func TestTransportSimulatesBatches(t *testing.T) {
+	tr, forwarded := newTestTransport(t)
+
+	var created models.BatchCreateOrdersResponse
+	status := do(t, tr, http.MethodPost, "/portfolio/orders/batched", `{"orders":[
+		{"ticker":"KXTEST","side":"yes","action":"buy","type":"limit","count":2,"yes_price":40},
+		{"ticker":"KXTEST","side":"yes","action":"buy","type":"limit","count":2,"yes_price":41}]}`, &created)
+	if status != http.StatusOK || len(created.Orders) != 2 {
+		t.Fatalf("batched create = %d %+v", status, created.Orders)
+	}
+	for _, o := range created.Orders {
+		if o.Status != models.OrderStatusResting {
+			t.Errorf("order %s = %s, want resting below the ask", o.OrderID, o.Status)
+		}
+	}
+
+	var cancelled models.BatchCancelOrdersResponse
+	body := `{"order_ids":["` + created.Orders[0].OrderID + `","` + created.Orders[1].OrderID + `"]}`
+	status = do(t, tr, http.MethodDelete, "/portfolio/orders/batched", body, &cancelled)
+	if status != http.StatusOK || len(cancelled.Orders) != 2 {
+		t.Fatalf("batched cancel = %d %+v", status, cancelled.Orders)
+	}
+	for _, o := range cancelled.Orders {
+		if o.Status != models.OrderStatusCanceled {
+			t.Errorf("order %s = %s, want canceled", o.OrderID, o.Status)
+		}
+	}
+
+	var orders models.OrdersResponse
+	do(t, tr, http.MethodGet, "/portfolio/orders?status=resting", "", &orders)
+	if len(orders.Orders) != 0 {
+		t.Errorf("resting after cancel = %+v", orders.Orders)
+	}
+	if len(*forwarded) != 0 {
+		t.Errorf("forwarded %v, want nothing sent upstream", *forwarded)
+	}
+}
+
+func TestTransportsSharingAFileKeepEveryOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "paper.json")
+	book := func(ctx context.Context, ticker string) (*models.Orderbook, error) {
+		return testBook(), nil
+	}
+	// Two processes running with --paper each have their own Transport
+	transports := []*Transport{NewTransport(nil, path, book), NewTransport(nil, path, book)}
+
+	const perTransport = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, len(transports)*perTransport)
+	for _, tr := range transports {
+		for i := 0; i < perTransport; i++ {
+			wg.Add(1)
+			go func(tr *Transport) {
+				defer wg.Done()
+				req, err := http.NewRequest(http.MethodPost, "https://demo-api.kalshi.co/trade-api/v2/portfolio/orders",
+					strings.NewReader(`{"ticker":"KXTEST","side":"yes","action":"buy","type":"limit","count":1,"yes_price":30}`))
+				if err != nil {
+					errs <- err
+					return
+				}
+				resp, err := tr.RoundTrip(req)
+				if err != nil {
+					errs <- err
+					return
+				}
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusOK {
+					errs <- fmt.Errorf("create = %d", resp.StatusCode)
+				}
+			}(tr)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	state, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := len(transports) * perTransport
+	if len(state.Orders) != want {
+		t.Errorf("paper account has %d orders, want %d", len(state.Orders), want)
+	}
+	ids := make(map[string]bool)
+	for _, o := range state.Orders {
+		ids[o.OrderID] = true
+	}
+	if len(ids) != want {
+		t.Errorf("paper account has %d distinct order IDs, want %d", len(ids), want)
+	}
+}
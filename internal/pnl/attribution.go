@@ -0,0 +1,95 @@
+package pnl
+
+import "sort"
+
+// Untagged is the strategy of markets that carry no label
+const Untagged = "untagged"
+
+// Strategy is the realized performance of the markets carrying one label.
+// Money is in cents.
+type Strategy struct {
+	Label   string  `json:"strategy"`
+	Markets int     `json:"markets"`
+	Wins    int     `json:"wins"`
+	Losses  int     `json:"losses"`
+	WinRate float64 `json:"win_rate"`
+	// AvgWin and AvgLoss are the mean P&L of winning and losing markets;
+	// AvgLoss is negative
+	AvgWin  float64 `json:"avg_win"`
+	AvgLoss float64 `json:"avg_loss"`
+	// Expectancy is the mean P&L per settled market
+	Expectancy float64 `json:"expectancy"`
+	PnL        int     `json:"pnl"`
+	Fees       int     `json:"fees"`
+	// MaxDrawdown is the largest fall of cumulative P&L from a peak, taking
+	// markets in settlement order
+	MaxDrawdown int `json:"max_drawdown"`
+}
+
+// Attribute groups settled markets by the labels returned for each ticker
+// and measures each group. A market with several labels counts toward each;
+// one with none counts toward Untagged. Strategies are ordered by P&L, best
+// first.
+func Attribute(markets []Market, labels func(ticker string) []string) []Strategy {
+	groups := make(map[string][]Market)
+	for _, m := range markets {
+		ls := labels(m.Ticker)
+		if len(ls) == 0 {
+			ls = []string{Untagged}
+		}
+		for _, l := range ls {
+			groups[l] = append(groups[l], m)
+		}
+	}
+
+	strategies := make([]Strategy, 0, len(groups))
+	for label, ms := range groups {
+		strategies = append(strategies, measure(label, ms))
+	}
+	sort.Slice(strategies, func(i, j int) bool {
+		if strategies[i].PnL != strategies[j].PnL {
+			return strategies[i].PnL > strategies[j].PnL
+		}
+		return strategies[i].Label < strategies[j].Label
+	})
+	return strategies
+}
+
+func measure(label string, markets []Market) Strategy {
+	sorted := append([]Market(nil), markets...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Settled.Before(sorted[j].Settled) })
+
+	s := Strategy{Label: label, Markets: len(sorted)}
+	won, lost := 0, 0
+	cumulative, peak := 0, 0
+	for _, m := range sorted {
+		s.PnL += m.PnL
+		s.Fees += m.Fees
+		switch {
+		case m.PnL > 0:
+			s.Wins++
+			won += m.PnL
+		case m.PnL < 0:
+			s.Losses++
+			lost += m.PnL
+		}
+
+		cumulative += m.PnL
+		peak = max(peak, cumulative)
+		s.MaxDrawdown = max(s.MaxDrawdown, peak-cumulative)
+	}
+
+	if decided := s.Wins + s.Losses; decided > 0 {
+		s.WinRate = float64(s.Wins) / float64(decided)
+	}
+	if s.Wins > 0 {
+		s.AvgWin = float64(won) / float64(s.Wins)
+	}
+	if s.Losses > 0 {
+		s.AvgLoss = float64(lost) / float64(s.Losses)
+	}
+	if s.Markets > 0 {
+		s.Expectancy = float64(s.PnL) / float64(s.Markets)
+	}
+	return s
+}
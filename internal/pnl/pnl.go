@@ -0,0 +1,152 @@
+// Package pnl computes profit and loss from positions, settlements and fills.
+package pnl
+
+import (
+	"sort"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// dateLayout is the day bucket format of Summary.Daily
+const dateLayout = "2006-01-02"
+
+// Market is the realized result of one settled market. Money is in cents.
+type Market struct {
+	Ticker  string    `json:"ticker"`
+	Result  string    `json:"result"`
+	Cost    int       `json:"cost"`
+	Revenue int       `json:"revenue"`
+	Fees    int       `json:"fees"`
+	PnL     int       `json:"pnl"`
+	Settled time.Time `json:"settled_time"`
+}
+
+// Day is the realized P&L of the markets that settled on one day
+type Day struct {
+	Date       string `json:"date"`
+	PnL        int    `json:"pnl"`
+	Cumulative int    `json:"cumulative"`
+}
+
+// Summary is realized P&L over a period. PnL is net of the fees paid on
+// fills within the period; Gross is before fees.
+type Summary struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Gross     int       `json:"gross"`
+	Fees      int       `json:"fees"`
+	PnL       int       `json:"pnl"`
+	Wins      int       `json:"wins"`
+	Losses    int       `json:"losses"`
+	WinRate   float64   `json:"win_rate"`
+	Markets   []Market  `json:"markets"`
+	Daily     []Day     `json:"daily"`
+	Fills     int       `json:"fills"`
+	Contracts int       `json:"contracts"`
+}
+
+// Summarize computes realized P&L for settlements and fills within
+// [from, to). Days are bucketed in loc. Markets are ordered by P&L, best first.
+func Summarize(settlements []models.Settlement, fills []models.Fill, from, to time.Time, loc *time.Location) Summary {
+	s := Summary{From: from, To: to, Markets: []Market{}}
+
+	// Fees are summed exactly and rounded to cents once per total
+	fees := make(map[string]models.SubCents)
+	var totalFees models.SubCents
+	for _, f := range fills {
+		if f.CreatedTime.Before(from) || !f.CreatedTime.Before(to) {
+			continue
+		}
+		fee := f.Fee()
+		fees[f.Ticker] += fee
+		totalFees += fee
+		s.Fills++
+		s.Contracts += f.Count
+	}
+	s.Fees = int(totalFees.Cents())
+
+	daily := make(map[string]int)
+	for _, st := range settlements {
+		if st.SettledTime.Before(from) || !st.SettledTime.Before(to) {
+			continue
+		}
+
+		m := Market{
+			Ticker:  st.Ticker,
+			Result:  st.MarketResult,
+			Cost:    st.YesTotalCost + st.NoTotalCost,
+			Revenue: st.Revenue,
+			Fees:    int(fees[st.Ticker].Cents()),
+			Settled: st.SettledTime,
+		}
+		m.PnL = m.Revenue - m.Cost - m.Fees
+		s.Markets = append(s.Markets, m)
+
+		s.Gross += m.Revenue - m.Cost
+		daily[st.SettledTime.In(loc).Format(dateLayout)] += m.PnL
+		switch {
+		case m.PnL > 0:
+			s.Wins++
+		case m.PnL < 0:
+			s.Losses++
+		}
+	}
+
+	s.PnL = s.Gross - s.Fees
+	if decided := s.Wins + s.Losses; decided > 0 {
+		s.WinRate = float64(s.Wins) / float64(decided)
+	}
+
+	sort.SliceStable(s.Markets, func(i, j int) bool {
+		return s.Markets[i].PnL > s.Markets[j].PnL
+	})
+
+	// Fees on unsettled markets are charged to the day they were paid, so the
+	// cumulative line ends at the net total
+	unsettled := s.Fees
+	for _, m := range s.Markets {
+		unsettled -= m.Fees
+	}
+	if unsettled != 0 {
+		daily[to.Add(-time.Nanosecond).In(loc).Format(dateLayout)] -= unsettled
+	}
+
+	cumulative := 0
+	for day := startOfDay(from.In(loc)); day.Before(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(dateLayout)
+		cumulative += daily[key]
+		s.Daily = append(s.Daily, Day{Date: key, PnL: daily[key], Cumulative: cumulative})
+	}
+
+	return s
+}
+
+// Best returns up to n markets with the highest P&L
+func (s Summary) Best(n int) []Market {
+	var best []Market
+	for _, m := range s.Markets {
+		if len(best) == n || m.PnL <= 0 {
+			break
+		}
+		best = append(best, m)
+	}
+	return best
+}
+
+// Worst returns up to n markets with the lowest P&L, worst first
+func (s Summary) Worst(n int) []Market {
+	var worst []Market
+	for i := len(s.Markets) - 1; i >= 0; i-- {
+		if len(worst) == n || s.Markets[i].PnL >= 0 {
+			break
+		}
+		worst = append(worst, s.Markets[i])
+	}
+	return worst
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,118 @@
+package pnl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestSummarize(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 3)
+
+	settlements := []models.Settlement{
+		{Ticker: "WIN", MarketResult: "yes", YesCount: 10, YesTotalCost: 400, Revenue: 1000, SettledTime: from.Add(10 * time.Hour)},
+		{Ticker: "LOSE", MarketResult: "yes", NoCount: 5, NoTotalCost: 300, SettledTime: from.Add(34 * time.Hour)},
+		{Ticker: "OLD", MarketResult: "no", NoCount: 1, Revenue: 100, SettledTime: from.Add(-time.Hour)},
+	}
+	fills := []models.Fill{
+		{Ticker: "WIN", Count: 10, FeeCost: "0.07", CreatedTime: from.Add(time.Hour)},
+		{Ticker: "LOSE", Count: 5, FeeCost: "0.03", CreatedTime: from.Add(2 * time.Hour)},
+		{Ticker: "OPEN", Count: 2, FeeCost: "0.02", CreatedTime: from.Add(50 * time.Hour)},
+		{Ticker: "WIN", Count: 1, FeeCost: "1.00", CreatedTime: from.Add(-time.Hour)},
+	}
+
+	s := Summarize(settlements, fills, from, to, time.UTC)
+
+	if s.Gross != 300 || s.Fees != 12 || s.PnL != 288 {
+		t.Errorf("gross/fees/pnl = %d/%d/%d, want 300/12/288", s.Gross, s.Fees, s.PnL)
+	}
+	if s.Wins != 1 || s.Losses != 1 || s.WinRate != 0.5 {
+		t.Errorf("wins/losses/rate = %d/%d/%v, want 1/1/0.5", s.Wins, s.Losses, s.WinRate)
+	}
+	if s.Fills != 3 || s.Contracts != 17 {
+		t.Errorf("fills/contracts = %d/%d, want 3/17", s.Fills, s.Contracts)
+	}
+	if len(s.Markets) != 2 || s.Markets[0].Ticker != "WIN" || s.Markets[0].PnL != 593 || s.Markets[1].PnL != -303 {
+		t.Errorf("markets = %+v", s.Markets)
+	}
+
+	wantDaily := []Day{
+		{Date: "2026-03-01", PnL: 593, Cumulative: 593},
+		{Date: "2026-03-02", PnL: -303, Cumulative: 290},
+		{Date: "2026-03-03", PnL: -2, Cumulative: 288},
+	}
+	if len(s.Daily) != len(wantDaily) {
+		t.Fatalf("daily = %+v, want %+v", s.Daily, wantDaily)
+	}
+	for i := range wantDaily {
+		if s.Daily[i] != wantDaily[i] {
+			t.Errorf("daily[%d] = %+v, want %+v", i, s.Daily[i], wantDaily[i])
+		}
+	}
+
+	if best := s.Best(5); len(best) != 1 || best[0].Ticker != "WIN" {
+		t.Errorf("Best() = %+v", best)
+	}
+	if worst := s.Worst(5); len(worst) != 1 || worst[0].Ticker != "LOSE" {
+		t.Errorf("Worst() = %+v", worst)
+	}
+}
+
+func TestSummarize_SubCentFees(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	var fills []models.Fill
+	for i := 0; i < 11; i++ {
+		fills = append(fills, models.Fill{Ticker: "A", Count: 1, FeeCost: "0.0050", CreatedTime: from.Add(time.Hour)})
+	}
+
+	// 11 x $0.005 is exactly 5.5 cents, which rounds up; summing float
+	// dollars lands just below the midpoint and rounds down
+	if s := Summarize(nil, fills, from, to, time.UTC); s.Fees != 6 {
+		t.Errorf("Fees = %d, want 6", s.Fees)
+	}
+}
+
+func TestAttribute(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	markets := []Market{
+		{Ticker: "A", PnL: 300, Fees: 5, Settled: day},
+		{Ticker: "B", PnL: -200, Fees: 3, Settled: day.Add(time.Hour)},
+		{Ticker: "C", PnL: -150, Fees: 2, Settled: day.Add(2 * time.Hour)},
+		{Ticker: "D", PnL: 100, Settled: day.Add(3 * time.Hour)},
+		{Ticker: "E", PnL: -40, Settled: day.Add(4 * time.Hour)},
+	}
+	tags := map[string][]string{
+		"A": {"momentum"}, "B": {"momentum"}, "C": {"momentum", "fade"}, "D": {"momentum"},
+	}
+
+	got := Attribute(markets, func(ticker string) []string { return tags[ticker] })
+	if len(got) != 3 {
+		t.Fatalf("Attribute() returned %d strategies, want 3: %+v", len(got), got)
+	}
+
+	m := got[0]
+	if m.Label != "momentum" || m.Markets != 4 || m.PnL != 50 || m.Fees != 10 {
+		t.Errorf("momentum = %+v", m)
+	}
+	if m.Wins != 2 || m.Losses != 2 || m.WinRate != 0.5 {
+		t.Errorf("momentum wins/losses/rate = %d/%d/%v, want 2/2/0.5", m.Wins, m.Losses, m.WinRate)
+	}
+	if m.AvgWin != 200 || m.AvgLoss != -175 || m.Expectancy != 12.5 {
+		t.Errorf("momentum avg win/loss/expectancy = %v/%v/%v, want 200/-175/12.5", m.AvgWin, m.AvgLoss, m.Expectancy)
+	}
+	// Peak 300 after A, trough -50 after C
+	if m.MaxDrawdown != 350 {
+		t.Errorf("momentum max drawdown = %d, want 350", m.MaxDrawdown)
+	}
+
+	if got[1].Label != Untagged || got[1].PnL != -40 || got[1].MaxDrawdown != 40 {
+		t.Errorf("untagged = %+v", got[1])
+	}
+	if got[2].Label != "fade" || got[2].WinRate != 0 || got[2].AvgWin != 0 {
+		t.Errorf("fade = %+v", got[2])
+	}
+}
@@ -0,0 +1,168 @@
+package pnl
+
+import (
+	"sort"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// Ways to mark open positions to market
+const (
+	// MarkBid values held contracts at the best bid for their side, what
+	// selling them now would fetch
+	MarkBid = "bid"
+	// MarkMid values held contracts midway between bid and ask for their side
+	MarkMid = "mid"
+)
+
+// Holding statuses
+const (
+	StatusOpen    = "open"
+	StatusClosed  = "closed"
+	StatusSettled = "settled"
+)
+
+// Totals is profit and loss in cents. Cost is what the contracts still held
+// cost and Value is what they are marked at. PnL is realized plus unrealized,
+// net of fees.
+type Totals struct {
+	Cost       int `json:"cost"`
+	Value      int `json:"value"`
+	Realized   int `json:"realized"`
+	Unrealized int `json:"unrealized"`
+	Fees       int `json:"fees"`
+	PnL        int `json:"pnl"`
+}
+
+func (t *Totals) add(o Totals) {
+	t.Cost += o.Cost
+	t.Value += o.Value
+	t.Realized += o.Realized
+	t.Unrealized += o.Unrealized
+	t.Fees += o.Fees
+	t.PnL += o.PnL
+}
+
+// Holding is the P&L of one market. Side, Contracts and Mark are set only
+// while contracts are held.
+type Holding struct {
+	Ticker    string `json:"ticker"`
+	Event     string `json:"event_ticker"`
+	Status    string `json:"status"`
+	Side      string `json:"side,omitempty"`
+	Contracts int    `json:"contracts"`
+	Mark      int    `json:"mark"`
+	Totals
+}
+
+// Event is the P&L of the markets in one event
+type Event struct {
+	Event   string `json:"event_ticker"`
+	Markets int    `json:"markets"`
+	Totals
+}
+
+// Portfolio is P&L per market, per event and overall
+type Portfolio struct {
+	Mark    string    `json:"mark"`
+	Markets []Holding `json:"markets"`
+	Events  []Event   `json:"events"`
+	Total   Totals    `json:"total"`
+}
+
+// Join combines positions, settlements and fills into a portfolio. Unsettled
+// markets take realized P&L and fees from their position, and held contracts
+// are marked with the quotes in markets using mark. Settled markets realize
+// revenue less cost, with fees summed from fills. Markets are grouped by the
+// event in markets, or their own ticker when not found there.
+func Join(positions []models.MarketPosition, settlements []models.Settlement, fills []models.Fill, markets map[string]models.Market, mark string) Portfolio {
+	fees := make(map[string]models.SubCents)
+	for _, f := range fills {
+		fees[f.Ticker] += f.Fee()
+	}
+
+	var holdings []Holding
+	settled := make(map[string]bool, len(settlements))
+	for _, s := range settlements {
+		settled[s.Ticker] = true
+		h := Holding{Ticker: s.Ticker, Status: StatusSettled}
+		h.Realized = s.Revenue - s.YesTotalCost - s.NoTotalCost
+		h.Fees = int(fees[s.Ticker].Cents())
+		holdings = append(holdings, h)
+	}
+
+	for _, p := range positions {
+		if settled[p.Ticker] || (p.Position == 0 && p.RealizedPnl == 0 && p.FeesPaid == 0) {
+			continue
+		}
+		h := Holding{Ticker: p.Ticker, Status: StatusClosed}
+		h.Realized = p.RealizedPnl
+		h.Fees = p.FeesPaid
+		if p.Position != 0 {
+			h.Status = StatusOpen
+			h.Side = "yes"
+			if p.Position < 0 {
+				h.Side = "no"
+			}
+			h.Contracts = max(p.Position, -p.Position)
+			h.Mark = MarkPrice(markets[p.Ticker], h.Side, mark)
+			h.Cost = p.MarketExposure
+			h.Value = h.Contracts * h.Mark
+			h.Unrealized = h.Value - h.Cost
+		}
+		holdings = append(holdings, h)
+	}
+
+	byEvent := make(map[string]*Event)
+	portfolio := Portfolio{Mark: mark, Markets: make([]Holding, 0, len(holdings)), Events: []Event{}}
+	for _, h := range holdings {
+		h.Event = h.Ticker
+		if m, ok := markets[h.Ticker]; ok && m.EventTicker != "" {
+			h.Event = m.EventTicker
+		}
+		h.PnL = h.Realized + h.Unrealized - h.Fees
+		portfolio.Markets = append(portfolio.Markets, h)
+		portfolio.Total.add(h.Totals)
+
+		e, ok := byEvent[h.Event]
+		if !ok {
+			e = &Event{Event: h.Event}
+			byEvent[h.Event] = e
+		}
+		e.Markets++
+		e.add(h.Totals)
+	}
+
+	sort.Slice(portfolio.Markets, func(i, j int) bool {
+		a, b := portfolio.Markets[i], portfolio.Markets[j]
+		if a.Event != b.Event {
+			return a.Event < b.Event
+		}
+		return a.Ticker < b.Ticker
+	})
+	for _, e := range byEvent {
+		portfolio.Events = append(portfolio.Events, *e)
+	}
+	sort.Slice(portfolio.Events, func(i, j int) bool { return portfolio.Events[i].Event < portfolio.Events[j].Event })
+	return portfolio
+}
+
+// MarkPrice returns the price in cents that contracts on side of m are marked
+// at. A side with no bid is marked at zero; with MarkMid and no ask, it is
+// marked at the bid.
+func MarkPrice(m models.Market, side, mark string) int {
+	bid, ask := m.YesBid, m.YesAsk
+	if side == "no" {
+		bid, ask = 0, 0
+		if m.YesAsk > 0 {
+			bid = 100 - m.YesAsk
+		}
+		if m.YesBid > 0 {
+			ask = 100 - m.YesBid
+		}
+	}
+	if mark == MarkMid && bid > 0 && ask > 0 {
+		return (bid + ask) / 2
+	}
+	return bid
+}
@@ -0,0 +1,90 @@
+package pnl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestJoin(t *testing.T) {
+	positions := []models.MarketPosition{
+		{Ticker: "EVA-YES", Position: 10, MarketExposure: 400, RealizedPnl: 50, FeesPaid: 7},
+		{Ticker: "EVA-NO", Position: -5, MarketExposure: 300, FeesPaid: 3},
+		{Ticker: "EVB-FLAT", RealizedPnl: -20, FeesPaid: 2},
+		{Ticker: "EVB-IDLE"},
+		{Ticker: "EVB-DONE", Position: 3, MarketExposure: 90},
+	}
+	settlements := []models.Settlement{
+		{Ticker: "EVB-DONE", MarketResult: "yes", YesCount: 3, YesTotalCost: 90, Revenue: 300, SettledTime: time.Now()},
+	}
+	fills := []models.Fill{
+		{Ticker: "EVB-DONE", Count: 3, FeeCost: "0.04"},
+		{Ticker: "EVA-YES", Count: 10, FeeCost: "0.07"},
+	}
+	markets := map[string]models.Market{
+		"EVA-YES":  {EventTicker: "EVA", YesBid: 45, YesAsk: 47},
+		"EVA-NO":   {EventTicker: "EVA", YesBid: 30, YesAsk: 36},
+		"EVB-FLAT": {EventTicker: "EVB"},
+		"EVB-DONE": {EventTicker: "EVB"},
+	}
+
+	p := Join(positions, settlements, fills, markets, MarkBid)
+	if len(p.Markets) != 4 {
+		t.Fatalf("markets = %+v, want 4 without the idle one", p.Markets)
+	}
+
+	byTicker := make(map[string]Holding)
+	for _, h := range p.Markets {
+		byTicker[h.Ticker] = h
+	}
+	// 10 YES at the 45 bid cost 400: 450 - 400 unrealized, plus 50 realized, less 7 fees
+	if h := byTicker["EVA-YES"]; h.Status != StatusOpen || h.Mark != 45 || h.Value != 450 || h.Unrealized != 50 || h.PnL != 93 {
+		t.Errorf("EVA-YES = %+v", h)
+	}
+	// 5 NO at 100 - 36 = 64
+	if h := byTicker["EVA-NO"]; h.Side != "no" || h.Contracts != 5 || h.Mark != 64 || h.Unrealized != 20 || h.PnL != 17 {
+		t.Errorf("EVA-NO = %+v", h)
+	}
+	if h := byTicker["EVB-FLAT"]; h.Status != StatusClosed || h.Contracts != 0 || h.PnL != -22 {
+		t.Errorf("EVB-FLAT = %+v", h)
+	}
+	// The settlement replaces the stale position; fees come from fills
+	if h := byTicker["EVB-DONE"]; h.Status != StatusSettled || h.Realized != 210 || h.Fees != 4 || h.Unrealized != 0 || h.PnL != 206 {
+		t.Errorf("EVB-DONE = %+v", h)
+	}
+
+	if len(p.Events) != 2 || p.Events[0].Event != "EVA" || p.Events[0].Markets != 2 || p.Events[0].PnL != 110 || p.Events[1].PnL != 184 {
+		t.Errorf("events = %+v", p.Events)
+	}
+	if p.Total.PnL != 294 || p.Total.Cost != 700 || p.Total.Value != 770 || p.Total.Fees != 16 {
+		t.Errorf("total = %+v", p.Total)
+	}
+	if p.Markets[0].Event != "EVA" || p.Markets[2].Ticker != "EVB-DONE" {
+		t.Errorf("markets not ordered by event then ticker: %+v", p.Markets)
+	}
+}
+
+func TestMarkPrice(t *testing.T) {
+	m := models.Market{YesBid: 40, YesAsk: 44}
+	tests := []struct {
+		side, mark string
+		want       int
+	}{
+		{"yes", MarkBid, 40},
+		{"yes", MarkMid, 42},
+		{"no", MarkBid, 56},
+		{"no", MarkMid, 58},
+	}
+	for _, tt := range tests {
+		if got := MarkPrice(m, tt.side, tt.mark); got != tt.want {
+			t.Errorf("MarkPrice(%s, %s) = %d, want %d", tt.side, tt.mark, got, tt.want)
+		}
+	}
+	if got := MarkPrice(models.Market{YesBid: 40}, "no", MarkMid); got != 0 {
+		t.Errorf("NO with no YES ask = %d, want 0", got)
+	}
+	if got := MarkPrice(models.Market{YesBid: 40}, "yes", MarkMid); got != 40 {
+		t.Errorf("YES mid with no ask = %d, want the bid", got)
+	}
+}
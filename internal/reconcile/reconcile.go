@@ -0,0 +1,176 @@
+// Package reconcile cross-checks settlements, fills and the local audit log
+// so that gaps and P&L discrepancies can be reported before the books close.
+package reconcile
+
+import (
+	"sort"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// contractPayout is what a winning contract settles for, in cents
+const contractPayout = 100
+
+// Kind classifies a reconciliation mismatch
+type Kind string
+
+const (
+	// MissingFills means the fills do not add up to the settled position
+	MissingFills Kind = "missing_fills"
+	// CostMismatch means the fills do not add up to the settled cost
+	CostMismatch Kind = "cost_mismatch"
+	// RevenueMismatch means the payout does not match the settled position
+	RevenueMismatch Kind = "revenue_mismatch"
+	// UnauditedFill means a fill has no audited order behind it
+	UnauditedFill Kind = "unaudited_fill"
+)
+
+// Mismatch is one discrepancy. Expected and Actual are contracts for
+// MissingFills and cents for the cost and revenue kinds.
+type Mismatch struct {
+	Kind     Kind      `json:"kind"`
+	Ticker   string    `json:"ticker"`
+	TradeID  string    `json:"trade_id,omitempty"`
+	OrderID  string    `json:"order_id,omitempty"`
+	Time     time.Time `json:"time"`
+	Expected int       `json:"expected"`
+	Actual   int       `json:"actual"`
+	Detail   string    `json:"detail"`
+}
+
+// AuditedOrder is a successful order creation recorded in the audit log
+type AuditedOrder struct {
+	Ticker string
+	Time   time.Time
+}
+
+// Input is everything a reconciliation looks at. SettlementFills must hold
+// the complete fill history of every settled market, since positions may
+// have been opened before the window; WindowFills are the fills inside it.
+type Input struct {
+	Settlements     []models.Settlement
+	SettlementFills []models.Fill
+	WindowFills     []models.Fill
+	Orders          []AuditedOrder
+}
+
+// Report is the outcome of a reconciliation
+type Report struct {
+	Settlements int        `json:"settlements"`
+	Fills       int        `json:"fills"`
+	Orders      int        `json:"audited_orders"`
+	Mismatches  []Mismatch `json:"mismatches"`
+}
+
+// Clean reports whether no mismatches were found
+func (r Report) Clean() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Run compares settlements with fills and fills with audited orders
+func Run(in Input) Report {
+	report := Report{
+		Settlements: len(in.Settlements),
+		Fills:       len(in.WindowFills),
+		Orders:      len(in.Orders),
+		Mismatches:  []Mismatch{},
+	}
+
+	byTicker := make(map[string][]models.Fill)
+	for _, f := range in.SettlementFills {
+		byTicker[f.Ticker] = append(byTicker[f.Ticker], f)
+	}
+	for _, s := range in.Settlements {
+		report.Mismatches = append(report.Mismatches, checkSettlement(s, byTicker[s.Ticker])...)
+	}
+
+	report.Mismatches = append(report.Mismatches, checkAudit(in.WindowFills, in.Orders)...)
+
+	sort.SliceStable(report.Mismatches, func(i, j int) bool {
+		return report.Mismatches[i].Time.Before(report.Mismatches[j].Time)
+	})
+	return report
+}
+
+// position is the net holding implied by a set of fills. Positive counts
+// are yes contracts, negative counts are no contracts.
+type position struct {
+	count int
+	cost  int
+}
+
+func positionFromFills(fills []models.Fill) position {
+	var p position
+	for _, f := range fills {
+		count, price := f.Count, f.YesPrice
+		if f.Side == "no" {
+			count, price = -f.Count, f.NoPrice
+		}
+		if f.Action == "sell" {
+			p.count -= count
+			p.cost -= f.Count * price
+		} else {
+			p.count += count
+			p.cost += f.Count * price
+		}
+	}
+	return p
+}
+
+func checkSettlement(s models.Settlement, fills []models.Fill) []Mismatch {
+	var mismatches []Mismatch
+	add := func(kind Kind, expected, actual int, detail string) {
+		mismatches = append(mismatches, Mismatch{
+			Kind: kind, Ticker: s.Ticker, Time: s.SettledTime,
+			Expected: expected, Actual: actual, Detail: detail,
+		})
+	}
+
+	held := s.YesCount - s.NoCount
+	fromFills := positionFromFills(fills)
+	if fromFills.count != held {
+		add(MissingFills, held, fromFills.count, "fills do not add up to the settled position")
+	} else if cost := s.YesTotalCost + s.NoTotalCost; fromFills.cost != cost {
+		add(CostMismatch, cost, fromFills.cost, "fills do not add up to the settled cost")
+	}
+
+	var payout int
+	switch s.MarketResult {
+	case "yes":
+		payout = s.YesCount * contractPayout
+	case "no":
+		payout = s.NoCount * contractPayout
+	default:
+		// Voided and scalar markets pay out differently; skip the check
+		return mismatches
+	}
+	if payout != s.Revenue {
+		add(RevenueMismatch, payout, s.Revenue, "revenue does not match the settled position")
+	}
+	return mismatches
+}
+
+// checkAudit flags fills in markets where no order was audited at or before
+// the fill, i.e. orders placed outside this CLI or with auditing disabled
+func checkAudit(fills []models.Fill, orders []AuditedOrder) []Mismatch {
+	first := make(map[string]time.Time)
+	for _, o := range orders {
+		if t, ok := first[o.Ticker]; !ok || o.Time.Before(t) {
+			first[o.Ticker] = o.Time
+		}
+	}
+
+	var mismatches []Mismatch
+	for _, f := range fills {
+		if t, ok := first[f.Ticker]; ok && !f.CreatedTime.Before(t) {
+			continue
+		}
+		mismatches = append(mismatches, Mismatch{
+			Kind: UnauditedFill, Ticker: f.Ticker, TradeID: f.TradeID, OrderID: f.OrderID,
+			Time: f.CreatedTime, Actual: f.Count,
+			Detail: "no audited order for this market before the fill",
+		})
+	}
+	return mismatches
+}
@@ -0,0 +1,93 @@
+package reconcile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+var t0 = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestRunCleanSettlement(t *testing.T) {
+	report := Run(Input{
+		Settlements: []models.Settlement{
+			{Ticker: "A", MarketResult: "yes", YesCount: 8, YesTotalCost: 360, Revenue: 800, SettledTime: t0},
+		},
+		SettlementFills: []models.Fill{
+			{Ticker: "A", Side: "yes", Action: "buy", Count: 10, YesPrice: 40, CreatedTime: t0.Add(-2 * time.Hour)},
+			{Ticker: "A", Side: "yes", Action: "sell", Count: 2, YesPrice: 20, CreatedTime: t0.Add(-time.Hour)},
+		},
+		WindowFills: []models.Fill{
+			{Ticker: "A", Side: "yes", Action: "sell", Count: 2, YesPrice: 20, CreatedTime: t0.Add(-time.Hour)},
+		},
+		Orders: []AuditedOrder{{Ticker: "A", Time: t0.Add(-3 * time.Hour)}},
+	})
+
+	if !report.Clean() {
+		t.Fatalf("Run() mismatches = %+v, want none", report.Mismatches)
+	}
+	if report.Settlements != 1 || report.Fills != 1 || report.Orders != 1 {
+		t.Errorf("Run() counts = %+v", report)
+	}
+}
+
+func TestRunSettlementMismatches(t *testing.T) {
+	tests := []struct {
+		name       string
+		settlement models.Settlement
+		fills      []models.Fill
+		want       Kind
+	}{
+		{
+			name:       "missing fills",
+			settlement: models.Settlement{Ticker: "A", MarketResult: "no", NoCount: 5, NoTotalCost: 250, Revenue: 500},
+			fills:      []models.Fill{{Ticker: "A", Side: "no", Action: "buy", Count: 3, NoPrice: 50}},
+			want:       MissingFills,
+		},
+		{
+			name:       "cost mismatch",
+			settlement: models.Settlement{Ticker: "A", MarketResult: "no", NoCount: 5, NoTotalCost: 300, Revenue: 500},
+			fills:      []models.Fill{{Ticker: "A", Side: "no", Action: "buy", Count: 5, NoPrice: 50}},
+			want:       CostMismatch,
+		},
+		{
+			name:       "revenue mismatch",
+			settlement: models.Settlement{Ticker: "A", MarketResult: "yes", YesCount: 5, YesTotalCost: 250, Revenue: 400},
+			fills:      []models.Fill{{Ticker: "A", Side: "yes", Action: "buy", Count: 5, YesPrice: 50}},
+			want:       RevenueMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Run(Input{Settlements: []models.Settlement{tt.settlement}, SettlementFills: tt.fills})
+			if len(report.Mismatches) != 1 || report.Mismatches[0].Kind != tt.want {
+				t.Fatalf("Run() mismatches = %+v, want one %s", report.Mismatches, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunUnauditedFills(t *testing.T) {
+	report := Run(Input{
+		WindowFills: []models.Fill{
+			{TradeID: "t1", Ticker: "A", Count: 1, CreatedTime: t0},
+			{TradeID: "t2", Ticker: "B", Count: 2, CreatedTime: t0},
+			{TradeID: "t3", Ticker: "C", Count: 3, CreatedTime: t0},
+		},
+		Orders: []AuditedOrder{
+			{Ticker: "A", Time: t0.Add(-time.Minute)},
+			{Ticker: "C", Time: t0.Add(time.Minute)},
+		},
+	})
+
+	if len(report.Mismatches) != 2 {
+		t.Fatalf("Run() mismatches = %+v, want 2", report.Mismatches)
+	}
+	for i, id := range []string{"t2", "t3"} {
+		if m := report.Mismatches[i]; m.Kind != UnauditedFill || m.TradeID != id {
+			t.Errorf("mismatch %d = %+v, want unaudited fill %s", i, m, id)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Package report renders P&L summaries as standalone, email-ready HTML.
+// Styles are inline and charts are inline SVG, so the file has no external
+// dependencies and survives being pasted into a mail client.
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/pnl"
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+// topMarkets is how many best and worst markets the report lists
+const topMarkets = 5
+
+// Data is everything shown in a report
+type Data struct {
+	Title       string
+	Period      string
+	Environment string
+	Generated   time.Time
+	Summary     pnl.Summary
+}
+
+// Render writes the report as a complete HTML document
+func Render(w io.Writer, data Data) error {
+	view := struct {
+		Data
+		Best   []pnl.Market
+		Worst  []pnl.Market
+		Daily  chart
+		Equity chart
+	}{
+		Data:   data,
+		Best:   data.Summary.Best(topMarkets),
+		Worst:  data.Summary.Worst(topMarkets),
+		Daily:  barChart(data.Summary.Daily),
+		Equity: lineChart(data.Summary.Daily),
+	}
+
+	if err := page.Execute(w, view); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
+
+// Chart geometry, in SVG user units
+const (
+	chartWidth  = 640
+	chartHeight = 200
+	chartPad    = 10
+)
+
+type bar struct {
+	X, Y, W, H float64
+	Color      string
+	Label      string
+}
+
+type chart struct {
+	Width, Height int
+	Zero          float64
+	Bars          []bar
+	Points        string
+	Empty         bool
+}
+
+// scale maps cents onto the chart's vertical axis, always including zero
+func scale(values []int) func(int) float64 {
+	lo, hi := 0, 0
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	if lo == hi {
+		hi = lo + 1
+	}
+	span := float64(chartHeight - 2*chartPad)
+	return func(v int) float64 {
+		return chartPad + span*float64(hi-v)/float64(hi-lo)
+	}
+}
+
+func barChart(days []pnl.Day) chart {
+	c := chart{Width: chartWidth, Height: chartHeight, Empty: len(days) == 0}
+	if c.Empty {
+		return c
+	}
+
+	values := make([]int, len(days))
+	for i, d := range days {
+		values[i] = d.PnL
+	}
+	y := scale(values)
+	c.Zero = y(0)
+
+	slot := float64(chartWidth) / float64(len(days))
+	for i, d := range days {
+		top, bottom := y(d.PnL), c.Zero
+		color := "#16a34a"
+		if d.PnL < 0 {
+			top, bottom = c.Zero, y(d.PnL)
+			color = "#dc2626"
+		}
+		c.Bars = append(c.Bars, bar{
+			X: float64(i)*slot + slot*0.15, Y: top,
+			W: slot * 0.7, H: max(bottom-top, 1),
+			Color: color,
+			Label: fmt.Sprintf("%s: %s", d.Date, money(d.PnL)),
+		})
+	}
+	return c
+}
+
+func lineChart(days []pnl.Day) chart {
+	c := chart{Width: chartWidth, Height: chartHeight, Empty: len(days) == 0}
+	if c.Empty {
+		return c
+	}
+
+	values := make([]int, len(days))
+	for i, d := range days {
+		values[i] = d.Cumulative
+	}
+	y := scale(values)
+	c.Zero = y(0)
+
+	step := float64(chartWidth)
+	if len(days) > 1 {
+		step = float64(chartWidth) / float64(len(days)-1)
+	}
+	for i, v := range values {
+		c.Points += fmt.Sprintf("%.1f,%.1f ", float64(i)*step, y(v))
+	}
+	return c
+}
+
+// money formats cents as signed dollars
+func money(cents int) string {
+	return models.Cents(cents).String()
+}
+
+// pnlColor picks green for gains and red for losses
+func pnlColor(cents int) string {
+	switch {
+	case cents > 0:
+		return "#16a34a"
+	case cents < 0:
+		return "#dc2626"
+	default:
+		return "#6b7280"
+	}
+}
+
+// marketSection is one titled market table
+type marketSection struct {
+	Title   string
+	Markets []pnl.Market
+}
+
+var page = template.Must(template.New("report").Funcs(template.FuncMap{
+	"money":    money,
+	"pnlColor": pnlColor,
+	"percent":  func(f float64) string { return fmt.Sprintf("%.0f%%", f*100) },
+	"date":     func(t time.Time) string { return t.Format("Jan 2, 2006") },
+	"datetime": func(t time.Time) string { return t.Format("Jan 2, 2006 15:04 MST") },
+	"section":  func(title string, markets []pnl.Market) marketSection { return marketSection{title, markets} },
+}).Parse(pageTemplate))
+
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+</head>
+<body style="margin:0;padding:24px;background:#f3f4f6;font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif;color:#111827;">
+<div style="max-width:680px;margin:0 auto;background:#ffffff;border-radius:8px;padding:24px;">
+  <h1 style="margin:0 0 4px;font-size:22px;">{{.Title}}</h1>
+  <p style="margin:0 0 20px;color:#6b7280;font-size:13px;">{{date .Summary.From}} – {{date .Summary.To}} · {{.Environment}} · generated {{datetime .Generated}}</p>
+
+  <table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="margin-bottom:24px;">
+    <tr>
+      <td style="padding:8px;">
+        <div style="color:#6b7280;font-size:12px;">Net P&amp;L</div>
+        <div style="font-size:24px;font-weight:600;color:{{pnlColor .Summary.PnL}};">{{money .Summary.PnL}}</div>
+      </td>
+      <td style="padding:8px;">
+        <div style="color:#6b7280;font-size:12px;">Gross P&amp;L</div>
+        <div style="font-size:18px;color:{{pnlColor .Summary.Gross}};">{{money .Summary.Gross}}</div>
+      </td>
+      <td style="padding:8px;">
+        <div style="color:#6b7280;font-size:12px;">Fees</div>
+        <div style="font-size:18px;">{{money .Summary.Fees}}</div>
+      </td>
+      <td style="padding:8px;">
+        <div style="color:#6b7280;font-size:12px;">Win rate</div>
+        <div style="font-size:18px;">{{percent .Summary.WinRate}} <span style="font-size:12px;color:#6b7280;">({{.Summary.Wins}}W / {{.Summary.Losses}}L)</span></div>
+      </td>
+    </tr>
+  </table>
+  <p style="margin:0 0 24px;color:#6b7280;font-size:13px;">{{len .Summary.Markets}} markets settled · {{.Summary.Fills}} fills · {{.Summary.Contracts}} contracts traded</p>
+
+  <h2 style="font-size:16px;margin:0 0 8px;">Daily P&amp;L</h2>
+  {{if .Daily.Empty}}<p style="color:#6b7280;">No data</p>{{else}}
+  <svg width="100%" viewBox="0 0 {{.Daily.Width}} {{.Daily.Height}}" xmlns="http://www.w3.org/2000/svg" style="margin-bottom:24px;">
+    <line x1="0" y1="{{.Daily.Zero}}" x2="{{.Daily.Width}}" y2="{{.Daily.Zero}}" stroke="#d1d5db"/>
+    {{range .Daily.Bars}}<rect x="{{.X}}" y="{{.Y}}" width="{{.W}}" height="{{.H}}" fill="{{.Color}}"><title>{{.Label}}</title></rect>
+    {{end}}
+  </svg>{{end}}
+
+  <h2 style="font-size:16px;margin:0 0 8px;">Cumulative P&amp;L</h2>
+  {{if .Equity.Empty}}<p style="color:#6b7280;">No data</p>{{else}}
+  <svg width="100%" viewBox="0 0 {{.Equity.Width}} {{.Equity.Height}}" xmlns="http://www.w3.org/2000/svg" style="margin-bottom:24px;">
+    <line x1="0" y1="{{.Equity.Zero}}" x2="{{.Equity.Width}}" y2="{{.Equity.Zero}}" stroke="#d1d5db"/>
+    <polyline points="{{.Equity.Points}}" fill="none" stroke="#2563eb" stroke-width="2"/>
+  </svg>{{end}}
+
+  {{template "markets" (section "Best markets" .Best)}}
+  {{template "markets" (section "Worst markets" .Worst)}}
+
+  <p style="margin:24px 0 0;color:#9ca3af;font-size:11px;">Realized P&amp;L from settlements; fees are those paid on fills within the period. Generated by kalshi-cli.</p>
+</div>
+</body>
+</html>
+{{define "markets"}}
+  <h2 style="font-size:16px;margin:0 0 8px;">{{.Title}}</h2>
+  {{if .Markets}}
+  <table width="100%" cellpadding="6" cellspacing="0" style="border-collapse:collapse;font-size:13px;margin-bottom:24px;">
+    <tr style="background:#f9fafb;text-align:left;"><th>Market</th><th>Result</th><th style="text-align:right;">Cost</th><th style="text-align:right;">Revenue</th><th style="text-align:right;">Fees</th><th style="text-align:right;">P&amp;L</th></tr>
+    {{range .Markets}}<tr style="border-top:1px solid #e5e7eb;">
+      <td>{{.Ticker}}</td><td>{{.Result}}</td>
+      <td style="text-align:right;">{{money .Cost}}</td>
+      <td style="text-align:right;">{{money .Revenue}}</td>
+      <td style="text-align:right;">{{money .Fees}}</td>
+      <td style="text-align:right;color:{{pnlColor .PnL}};">{{money .PnL}}</td>
+    </tr>{{end}}
+  </table>
+  {{else}}<p style="color:#6b7280;margin:0 0 24px;">None</p>{{end}}
+{{end}}`
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/pnl"
+)
+
+func TestRender(t *testing.T) {
+	summary := pnl.Summary{
+		From:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		Gross:   300,
+		Fees:    12,
+		PnL:     288,
+		Wins:    1,
+		Losses:  1,
+		WinRate: 0.5,
+		Markets: []pnl.Market{
+			{Ticker: "WIN", Result: "yes", Cost: 400, Revenue: 1000, PnL: 593},
+			{Ticker: "LOSE<script>", Result: "yes", Cost: 300, PnL: -303},
+		},
+		Daily: []pnl.Day{
+			{Date: "2026-03-01", PnL: 593, Cumulative: 593},
+			{Date: "2026-03-02", PnL: -303, Cumulative: 290},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := Render(&buf, Data{Title: "Weekly", Environment: "demo", Generated: summary.To, Summary: summary})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	html := buf.String()
+	for _, want := range []string{"<!DOCTYPE html>", "$2.88", "-$3.03", "50%", "<polyline", "<rect", "color:#16a34a"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(html, "LOSE<script>") {
+		t.Error("report did not escape market tickers")
+	}
+	if strings.Contains(html, "ZgotmplZ") {
+		t.Error("report contains a value rejected by html/template")
+	}
+}
+
+func TestMoney(t *testing.T) {
+	tests := map[int]string{0: "$0.00", 5: "$0.05", 1234: "$12.34", -250: "-$2.50"}
+	for in, want := range tests {
+		if got := money(in); got != want {
+			t.Errorf("money(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Package secrets fetches API credentials at runtime from external secret
+// managers (1Password, HashiCorp Vault, AWS Secrets Manager) through their CLIs,
+// so the private key never has to be stored on disk or in the OS keyring.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/6missedcalls/kalshi-cli/internal/config"
+)
+
+// Secret field names expected in every provider
+const (
+	FieldAPIKeyID   = "api_key_id"
+	FieldPrivateKey = "private_key"
+)
+
+// Provider names accepted by credentials_provider
+const (
+	ProviderOnePassword = "1password"
+	ProviderVault       = "vault"
+	ProviderAWS         = "aws"
+)
+
+// Provider fetches credentials from an external secret store
+type Provider interface {
+	Name() string
+	Fetch(ctx context.Context) (*config.Credentials, error)
+}
+
+// runner executes a CLI and returns its stdout
+type runner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func execRunner(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+	return out, nil
+}
+
+// New returns the provider for kind reading the secret at path.
+// region is only used by the AWS provider and may be empty.
+func New(kind, path, region string) (Provider, error) {
+	if path == "" {
+		return nil, fmt.Errorf("credentials_path is required for provider %q", kind)
+	}
+
+	switch strings.ToLower(kind) {
+	case ProviderOnePassword, "op":
+		return &OnePassword{Path: path, run: execRunner}, nil
+	case ProviderVault:
+		return &Vault{Path: path, run: execRunner}, nil
+	case ProviderAWS, "aws-secrets-manager":
+		return &AWS{SecretID: path, Region: region, run: execRunner}, nil
+	default:
+		return nil, fmt.Errorf("unknown credentials provider %q (expected 1password, vault, or aws)", kind)
+	}
+}
+
+// OnePassword reads fields of a 1Password item with `op read`.
+// Path is the item reference, e.g. op://Private/Kalshi.
+type OnePassword struct {
+	Path string
+	run  runner
+}
+
+// Name returns the provider name
+func (p *OnePassword) Name() string {
+	return ProviderOnePassword
+}
+
+// Fetch reads the api_key_id and private_key fields of the item
+func (p *OnePassword) Fetch(ctx context.Context) (*config.Credentials, error) {
+	base := strings.TrimSuffix(p.Path, "/")
+
+	apiKeyID, err := p.run(ctx, "op", "read", base+"/"+FieldAPIKeyID)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := p.run(ctx, "op", "read", base+"/"+FieldPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCredentials(string(apiKeyID), string(privateKey))
+}
+
+// Vault reads a KV secret with `vault kv get`. Path is the secret path,
+// e.g. secret/kalshi. VAULT_ADDR and VAULT_TOKEN come from the environment.
+type Vault struct {
+	Path string
+	run  runner
+}
+
+// Name returns the provider name
+func (p *Vault) Name() string {
+	return ProviderVault
+}
+
+// Fetch reads the api_key_id and private_key keys of the secret
+func (p *Vault) Fetch(ctx context.Context) (*config.Credentials, error) {
+	out, err := p.run(ctx, "vault", "kv", "get", "-format=json", p.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// KV v2 nests values under data.data, KV v1 directly under data
+	var resp struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse vault output: %w", err)
+	}
+
+	fields := resp.Data
+	if nested, ok := resp.Data["data"]; ok {
+		if err := json.Unmarshal(nested, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse vault secret: %w", err)
+		}
+	}
+
+	var apiKeyID, privateKey string
+	json.Unmarshal(fields[FieldAPIKeyID], &apiKeyID)
+	json.Unmarshal(fields[FieldPrivateKey], &privateKey)
+
+	return newCredentials(apiKeyID, privateKey)
+}
+
+// AWS reads a JSON secret from AWS Secrets Manager with the aws CLI.
+// The SecretString must be an object with api_key_id and private_key.
+type AWS struct {
+	SecretID string
+	Region   string
+	run      runner
+}
+
+// Name returns the provider name
+func (p *AWS) Name() string {
+	return ProviderAWS
+}
+
+// Fetch reads and decodes the secret string
+func (p *AWS) Fetch(ctx context.Context) (*config.Credentials, error) {
+	args := []string{"secretsmanager", "get-secret-value",
+		"--secret-id", p.SecretID,
+		"--query", "SecretString",
+		"--output", "text",
+	}
+	if p.Region != "" {
+		args = append(args, "--region", p.Region)
+	}
+
+	out, err := p.run(ctx, "aws", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret map[string]string
+	if err := json.Unmarshal(bytes.TrimSpace(out), &secret); err != nil {
+		return nil, fmt.Errorf("failed to parse secret %s: expected JSON with %s and %s", p.SecretID, FieldAPIKeyID, FieldPrivateKey)
+	}
+
+	return newCredentials(secret[FieldAPIKeyID], secret[FieldPrivateKey])
+}
+
+func newCredentials(apiKeyID, privateKey string) (*config.Credentials, error) {
+	apiKeyID = strings.TrimSpace(apiKeyID)
+	privateKey = strings.TrimSpace(privateKey)
+
+	if apiKeyID == "" {
+		return nil, fmt.Errorf("secret is missing %s", FieldAPIKeyID)
+	}
+	if privateKey == "" {
+		return nil, fmt.Errorf("secret is missing %s", FieldPrivateKey)
+	}
+
+	return &config.Credentials{
+		APIKeyID:   apiKeyID,
+		PrivateKey: privateKey + "\n",
+	}, nil
+}
@@ -0,0 +1,91 @@
+// Package seriescache remembers which series each event belongs to, so
+// commands that need an event's series do not look the event up every time.
+package seriescache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const fileName = "series.json"
+
+// Cache maps event tickers to series tickers. An event never moves to
+// another series, so entries do not expire. It is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	series  map[string]string
+	changed bool
+}
+
+// Get returns the series of an event, if it is cached
+func (c *Cache) Get(event string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	series, ok := c.series[event]
+	return series, ok
+}
+
+// Put records the series of an event
+func (c *Cache) Put(event, series string) {
+	if event == "" || series == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.series[event] == series {
+		return
+	}
+	if c.series == nil {
+		c.series = make(map[string]string)
+	}
+	c.series[event] = series
+	c.changed = true
+}
+
+// DefaultPath returns the cache file location inside the cache directory
+func DefaultPath(cacheDir string) string {
+	return filepath.Join(cacheDir, fileName)
+}
+
+// Load reads the cache at path. A missing or unreadable file is an empty
+// cache, since the file can be deleted or replaced at any time.
+func Load(path string) *Cache {
+	c := &Cache{series: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	if err := json.Unmarshal(data, &c.series); err != nil || c.series == nil {
+		c.series = make(map[string]string)
+	}
+	return c
+}
+
+// Save writes the cache to path if it has changed since it was loaded,
+// replacing the previous file atomically
+func Save(path string, c *Cache) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.changed {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	data, err := json.MarshalIndent(c.series, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode series cache: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write series cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write series cache: %w", err)
+	}
+	c.changed = false
+	return nil
+}
@@ -0,0 +1,55 @@
+package seriescache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCache_SaveAndLoad(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+
+	c := Load(path)
+	if _, ok := c.Get("KXCPI-26MAR"); ok {
+		t.Fatal("a new cache should be empty")
+	}
+	c.Put("KXCPI-26MAR", "KXCPI")
+	c.Put("", "KXCPI")
+	c.Put("KXFED-26MAR", "")
+	if err := Save(path, c); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded := Load(path)
+	if series, ok := loaded.Get("KXCPI-26MAR"); !ok || series != "KXCPI" {
+		t.Errorf("Get = %q, %v", series, ok)
+	}
+	if len(loaded.series) != 1 {
+		t.Errorf("series = %v, want only KXCPI-26MAR", loaded.series)
+	}
+}
+
+func TestSave_Unchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "series.json")
+	if err := Save(path, Load(path)); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("an unchanged cache should not be written")
+	}
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c := Load(path)
+	c.Put("KXCPI-26MAR", "KXCPI")
+	if err := Save(path, c); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if series, _ := Load(path).Get("KXCPI-26MAR"); series != "KXCPI" {
+		t.Errorf("a corrupt cache should be replaced, got %q", series)
+	}
+}
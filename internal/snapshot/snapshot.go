@@ -0,0 +1,226 @@
+// Package snapshot persists point-in-time copies of portfolio positions so
+// later runs can report what changed.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+const (
+	dirName      = "snapshots"
+	timeLayout   = "20060102T150405Z"
+	fileSuffix   = ".json"
+	positionsKey = "positions"
+)
+
+// Snapshot is the set of open positions at a point in time
+type Snapshot struct {
+	Time        time.Time               `json:"time"`
+	Environment string                  `json:"environment"`
+	Subaccount  int                     `json:"subaccount"`
+	Positions   []models.MarketPosition `json:"positions"`
+}
+
+// Store keeps position snapshots as one JSON file each, separated by
+// environment and subaccount so demo and production never mix
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the snapshot location inside the data directory
+func DefaultDir(dataDir string) string {
+	return filepath.Join(dataDir, dirName)
+}
+
+func (s *Store) scopeDir(environment string, subaccount int) string {
+	return filepath.Join(s.dir, positionsKey, fmt.Sprintf("%s-%d", environment, subaccount))
+}
+
+// Save writes the snapshot and returns its path. Closed (zero) positions are
+// dropped so a snapshot only describes what is held.
+func (s *Store) Save(snap Snapshot) (string, error) {
+	open := make([]models.MarketPosition, 0, len(snap.Positions))
+	for _, p := range snap.Positions {
+		if p.Position != 0 {
+			open = append(open, p)
+		}
+	}
+	snap.Positions = open
+
+	dir := s.scopeDir(snap.Environment, snap.Subaccount)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	path := filepath.Join(dir, snap.Time.UTC().Format(timeLayout)+fileSuffix)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return path, nil
+}
+
+// Latest returns the most recent snapshot for the environment and
+// subaccount, or nil if there is none
+func (s *Store) Latest(environment string, subaccount int) (*Snapshot, error) {
+	names, err := s.names(environment, subaccount)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+	return Load(filepath.Join(s.scopeDir(environment, subaccount), names[len(names)-1]))
+}
+
+// History returns the snapshots for the environment and subaccount taken
+// within [from, to), oldest first
+func (s *Store) History(environment string, subaccount int, from, to time.Time) ([]Snapshot, error) {
+	names, err := s.names(environment, subaccount)
+	if err != nil {
+		return nil, err
+	}
+
+	var snaps []Snapshot
+	for _, name := range names {
+		taken, err := time.Parse(timeLayout, strings.TrimSuffix(name, fileSuffix))
+		if err != nil || taken.Before(from) || !taken.Before(to) {
+			continue
+		}
+		snap, err := Load(filepath.Join(s.scopeDir(environment, subaccount), name))
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, *snap)
+	}
+	return snaps, nil
+}
+
+// names returns the snapshot file names for the environment and subaccount,
+// oldest first
+func (s *Store) names(environment string, subaccount int) ([]string, error) {
+	entries, err := os.ReadDir(s.scopeDir(environment, subaccount))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), fileSuffix) {
+			names = append(names, e.Name())
+		}
+	}
+	// Timestamps in file names sort chronologically
+	sort.Strings(names)
+	return names, nil
+}
+
+// Load reads a snapshot file
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// ChangeKind classifies a position change between two snapshots
+type ChangeKind string
+
+const (
+	ChangeNew     ChangeKind = "new"
+	ChangeResized ChangeKind = "changed"
+	ChangeClosed  ChangeKind = "closed"
+)
+
+// Change is one market whose position or exposure differs between snapshots
+type Change struct {
+	Ticker           string     `json:"ticker"`
+	Kind             ChangeKind `json:"change"`
+	PreviousPosition int        `json:"previous_position"`
+	Position         int        `json:"position"`
+	PreviousExposure int        `json:"previous_exposure"`
+	Exposure         int        `json:"exposure"`
+}
+
+// PositionDelta is the change in contracts held
+func (c Change) PositionDelta() int {
+	return c.Position - c.PreviousPosition
+}
+
+// ExposureDelta is the change in market exposure in cents
+func (c Change) ExposureDelta() int {
+	return c.Exposure - c.PreviousExposure
+}
+
+// Diff compares two position lists, ignoring zero positions. Changes are
+// ordered new, changed, closed and then by ticker.
+func Diff(previous, current []models.MarketPosition) []Change {
+	prev := indexOpen(previous)
+	curr := indexOpen(current)
+
+	var changes []Change
+	for ticker, p := range curr {
+		old, ok := prev[ticker]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Ticker: ticker, Kind: ChangeNew, Position: p.Position, Exposure: p.MarketExposure})
+		case old.Position != p.Position || old.MarketExposure != p.MarketExposure:
+			changes = append(changes, Change{
+				Ticker: ticker, Kind: ChangeResized,
+				PreviousPosition: old.Position, Position: p.Position,
+				PreviousExposure: old.MarketExposure, Exposure: p.MarketExposure,
+			})
+		}
+	}
+	for ticker, old := range prev {
+		if _, ok := curr[ticker]; !ok {
+			changes = append(changes, Change{
+				Ticker: ticker, Kind: ChangeClosed,
+				PreviousPosition: old.Position, PreviousExposure: old.MarketExposure,
+			})
+		}
+	}
+
+	order := map[ChangeKind]int{ChangeNew: 0, ChangeResized: 1, ChangeClosed: 2}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return order[changes[i].Kind] < order[changes[j].Kind]
+		}
+		return changes[i].Ticker < changes[j].Ticker
+	})
+	return changes
+}
+
+func indexOpen(positions []models.MarketPosition) map[string]models.MarketPosition {
+	index := make(map[string]models.MarketPosition, len(positions))
+	for _, p := range positions {
+		if p.Position != 0 {
+			index[p.Ticker] = p
+		}
+	}
+	return index
+}
@@ -0,0 +1,111 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
+)
+
+func TestStoreSaveAndLatest(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	latest, err := store.Latest("demo", 0)
+	if err != nil || latest != nil {
+		t.Fatalf("Latest on empty store = %v, %v; want nil, nil", latest, err)
+	}
+
+	first := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	if _, err := store.Save(Snapshot{Time: first, Environment: "demo", Positions: []models.MarketPosition{
+		{Ticker: "A", Position: 5, MarketExposure: 250},
+		{Ticker: "B", Position: 0},
+	}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := store.Save(Snapshot{Time: first.Add(time.Hour), Environment: "demo", Positions: []models.MarketPosition{
+		{Ticker: "A", Position: 7, MarketExposure: 350},
+	}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := store.Save(Snapshot{Time: first.Add(2 * time.Hour), Environment: "prod"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	latest, err = store.Latest("demo", 0)
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if !latest.Time.Equal(first.Add(time.Hour)) {
+		t.Errorf("Latest().Time = %v, want %v", latest.Time, first.Add(time.Hour))
+	}
+	if len(latest.Positions) != 1 || latest.Positions[0].Position != 7 {
+		t.Errorf("Latest().Positions = %+v", latest.Positions)
+	}
+
+	if other, _ := store.Latest("demo", 1); other != nil {
+		t.Errorf("Latest() for another subaccount = %+v, want nil", other)
+	}
+}
+
+func TestStoreHistory(t *testing.T) {
+	store := NewStore(t.TempDir())
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		if _, err := store.Save(Snapshot{Time: start.Add(time.Duration(i) * time.Hour), Environment: "demo", Positions: []models.MarketPosition{
+			{Ticker: "A", Position: i + 1},
+		}}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	snaps, err := store.History("demo", 0, start.Add(time.Hour), start.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(snaps) != 2 {
+		t.Fatalf("History() returned %d snapshots, want 2", len(snaps))
+	}
+	if !snaps[0].Time.Equal(start.Add(time.Hour)) || snaps[1].Positions[0].Position != 3 {
+		t.Errorf("History() = %+v", snaps)
+	}
+
+	if snaps, err := store.History("prod", 0, start, start.Add(24*time.Hour)); err != nil || len(snaps) != 0 {
+		t.Errorf("History() for empty scope = %v, %v; want none", snaps, err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	previous := []models.MarketPosition{
+		{Ticker: "KEEP", Position: 3, MarketExposure: 150},
+		{Ticker: "GROW", Position: 2, MarketExposure: 100},
+		{Ticker: "GONE", Position: -4, MarketExposure: 200},
+	}
+	current := []models.MarketPosition{
+		{Ticker: "KEEP", Position: 3, MarketExposure: 150},
+		{Ticker: "GROW", Position: 6, MarketExposure: 300},
+		{Ticker: "FRESH", Position: 1, MarketExposure: 40},
+		{Ticker: "FLAT", Position: 0},
+	}
+
+	changes := Diff(previous, current)
+	want := []Change{
+		{Ticker: "FRESH", Kind: ChangeNew, Position: 1, Exposure: 40},
+		{Ticker: "GROW", Kind: ChangeResized, PreviousPosition: 2, Position: 6, PreviousExposure: 100, Exposure: 300},
+		{Ticker: "GONE", Kind: ChangeClosed, PreviousPosition: -4, PreviousExposure: 200},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Diff() = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Diff()[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	if d := changes[1].PositionDelta(); d != 4 {
+		t.Errorf("PositionDelta() = %d, want 4", d)
+	}
+	if d := changes[2].ExposureDelta(); d != -200 {
+		t.Errorf("ExposureDelta() = %d, want -200", d)
+	}
+}
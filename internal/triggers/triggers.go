@@ -0,0 +1,265 @@
+// Package triggers keeps stop-loss and take-profit exit triggers on
+// positions, stored locally so a monitor can be stopped and restarted
+// without losing or re-firing them.
+package triggers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/6missedcalls/kalshi-cli/internal/filelock"
+)
+
+const fileName = "triggers.json"
+
+// Status is where a trigger is in its life
+type Status string
+
+// Trigger statuses. A trigger is marked firing before its exit order is
+// submitted, so a monitor restarted mid-exit never submits it twice.
+const (
+	StatusActive   Status = "active"
+	StatusFiring   Status = "firing"
+	StatusFired    Status = "fired"
+	StatusFailed   Status = "failed"
+	StatusCanceled Status = "canceled"
+)
+
+// Reasons a trigger fires
+const (
+	ReasonStopLoss   = "stop_loss"
+	ReasonTakeProfit = "take_profit"
+)
+
+// Prices a trigger can watch
+const (
+	OnLast = "last"
+	OnBid  = "bid"
+)
+
+// Trigger exits a position when the price of the side held crosses a stop
+// loss or take profit, whichever comes first. Prices are in cents.
+type Trigger struct {
+	ID          int       `json:"id"`
+	Ticker      string    `json:"ticker"`
+	Side        string    `json:"side"`
+	Count       int       `json:"count,omitempty"`
+	StopLoss    int       `json:"stop_loss,omitempty"`
+	TakeProfit  int       `json:"take_profit,omitempty"`
+	OrderType   string    `json:"order_type"`
+	Slippage    int       `json:"slippage,omitempty"`
+	On          string    `json:"on"`
+	Environment string    `json:"environment"`
+	Created     time.Time `json:"created"`
+	Status      Status    `json:"status"`
+	Reason      string    `json:"reason,omitempty"`
+	Price       int       `json:"price,omitempty"`
+	FiredAt     time.Time `json:"fired_at,omitzero"`
+	OrderID     string    `json:"order_id,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Validate checks that a new trigger can fire
+func (t Trigger) Validate() error {
+	if t.Ticker == "" {
+		return fmt.Errorf("trigger has no market")
+	}
+	if t.Side != "yes" && t.Side != "no" {
+		return fmt.Errorf("side must be 'yes' or 'no', got '%s'", t.Side)
+	}
+	if t.StopLoss == 0 && t.TakeProfit == 0 {
+		return fmt.Errorf("set a stop loss, a take profit or both")
+	}
+	for _, p := range []int{t.StopLoss, t.TakeProfit} {
+		if p < 0 || p > 99 {
+			return fmt.Errorf("trigger prices must be between 1 and 99 cents, got %d", p)
+		}
+	}
+	if t.StopLoss > 0 && t.TakeProfit > 0 && t.StopLoss >= t.TakeProfit {
+		return fmt.Errorf("stop loss (%d) must be below take profit (%d)", t.StopLoss, t.TakeProfit)
+	}
+	if t.Count < 0 {
+		return fmt.Errorf("count must not be negative, got %d", t.Count)
+	}
+	return nil
+}
+
+// Check returns the reason the trigger fires at price, the price of the side
+// held, or "" if it does not. A zero price is no quote and never fires.
+func (t Trigger) Check(price int) string {
+	if price <= 0 {
+		return ""
+	}
+	if t.StopLoss > 0 && price <= t.StopLoss {
+		return ReasonStopLoss
+	}
+	if t.TakeProfit > 0 && price >= t.TakeProfit {
+		return ReasonTakeProfit
+	}
+	return ""
+}
+
+// Book is every trigger, active or not
+type Book struct {
+	NextID   int       `json:"next_id"`
+	Triggers []Trigger `json:"triggers"`
+}
+
+// Add stores a new active trigger, assigning its ID
+func (b *Book) Add(t Trigger, now time.Time) (Trigger, error) {
+	if err := t.Validate(); err != nil {
+		return t, err
+	}
+	if b.NextID < 1 {
+		b.NextID = 1
+	}
+	t.ID = b.NextID
+	b.NextID++
+	t.Status = StatusActive
+	t.Created = now.UTC()
+	b.Triggers = append(b.Triggers, t)
+	return t, nil
+}
+
+// Get returns the trigger with id
+func (b *Book) Get(id int) (*Trigger, error) {
+	for i := range b.Triggers {
+		if b.Triggers[i].ID == id {
+			return &b.Triggers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no trigger %d", id)
+}
+
+// Active returns the active triggers in an environment
+func (b *Book) Active(env string) []Trigger {
+	var active []Trigger
+	for _, t := range b.Triggers {
+		if t.Status == StatusActive && t.Environment == env {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// Claim marks an active trigger as firing for reason at price. It fails if
+// the trigger is no longer active, so only one monitor exits a position.
+func (b *Book) Claim(id int, reason string, price int, now time.Time) (Trigger, error) {
+	t, err := b.Get(id)
+	if err != nil {
+		return Trigger{}, err
+	}
+	if t.Status != StatusActive {
+		return *t, fmt.Errorf("trigger %d is %s", id, t.Status)
+	}
+	t.Status = StatusFiring
+	t.Reason = reason
+	t.Price = price
+	t.FiredAt = now.UTC()
+	return *t, nil
+}
+
+// Finish records the outcome of a firing trigger's exit order
+func (b *Book) Finish(id int, orderID string, exitErr error) error {
+	t, err := b.Get(id)
+	if err != nil {
+		return err
+	}
+	t.OrderID = orderID
+	t.Status = StatusFired
+	if exitErr != nil {
+		t.Status = StatusFailed
+		t.Error = exitErr.Error()
+	}
+	return nil
+}
+
+// Cancel stops a trigger that is active, or stuck firing after a monitor
+// stopped mid-exit
+func (b *Book) Cancel(id int) error {
+	t, err := b.Get(id)
+	if err != nil {
+		return err
+	}
+	if t.Status != StatusActive && t.Status != StatusFiring {
+		return fmt.Errorf("trigger %d is already %s", id, t.Status)
+	}
+	t.Status = StatusCanceled
+	return nil
+}
+
+// DefaultPath returns the triggers file location inside the data directory
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, fileName)
+}
+
+// Load reads the triggers file at path. A missing file is an empty book.
+func Load(path string) (*Book, error) {
+	b := &Book{NextID: 1}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return b, nil
+		}
+		return nil, fmt.Errorf("failed to read triggers: %w", err)
+	}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to parse triggers %s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Save writes the book to path, replacing the previous file atomically.
+// Callers that change a book another process may also be changing should
+// use Update instead.
+func Save(path string, b *Book) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create triggers directory: %w", err)
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode triggers: %w", err)
+	}
+	// A temp file of its own, so concurrent saves never write into each other
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write triggers: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write triggers: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write triggers: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write triggers: %w", err)
+	}
+	return nil
+}
+
+// Update loads the book at path, applies fn and saves the result, holding a
+// lock on the file throughout so the monitor, its cancel command and other
+// monitors cannot overwrite each other's changes. Nothing is saved if fn
+// fails.
+func Update(path string, fn func(*Book) error) error {
+	release, err := filelock.Acquire(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	b, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(b); err != nil {
+		return err
+	}
+	return Save(path, b)
+}
@@ -0,0 +1,174 @@
+package triggers
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTrigger_Check(t *testing.T) {
+	tr := Trigger{Ticker: "KXA", Side: "yes", StopLoss: 30, TakeProfit: 80}
+	tests := []struct {
+		price int
+		want  string
+	}{
+		{0, ""},
+		{29, ReasonStopLoss},
+		{30, ReasonStopLoss},
+		{31, ""},
+		{79, ""},
+		{80, ReasonTakeProfit},
+		{95, ReasonTakeProfit},
+	}
+	for _, tt := range tests {
+		if got := tr.Check(tt.price); got != tt.want {
+			t.Errorf("Check(%d) = %q, want %q", tt.price, got, tt.want)
+		}
+	}
+
+	if got := (Trigger{TakeProfit: 80}).Check(10); got != "" {
+		t.Errorf("take profit only fired at 10: %q", got)
+	}
+}
+
+func TestTrigger_Validate(t *testing.T) {
+	bad := []Trigger{
+		{Side: "yes", StopLoss: 30},
+		{Ticker: "KXA", Side: "up", StopLoss: 30},
+		{Ticker: "KXA", Side: "yes"},
+		{Ticker: "KXA", Side: "yes", StopLoss: 80, TakeProfit: 30},
+		{Ticker: "KXA", Side: "no", TakeProfit: 100},
+		{Ticker: "KXA", Side: "no", StopLoss: 30, Count: -1},
+	}
+	for _, tr := range bad {
+		if err := tr.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", tr)
+		}
+	}
+}
+
+func TestBook_Lifecycle(t *testing.T) {
+	b := &Book{}
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	first, err := b.Add(Trigger{Ticker: "KXA", Side: "yes", StopLoss: 30, Environment: "demo"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := b.Add(Trigger{Ticker: "KXB", Side: "no", TakeProfit: 70, Environment: "prod"}, now)
+	if first.ID != 1 || second.ID != 2 || first.Status != StatusActive {
+		t.Errorf("added %+v and %+v", first, second)
+	}
+	if got := b.Active("demo"); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("Active(demo) = %+v", got)
+	}
+
+	claimed, err := b.Claim(1, ReasonStopLoss, 28, now.Add(time.Minute))
+	if err != nil || claimed.Status != StatusFiring || claimed.Price != 28 {
+		t.Fatalf("Claim = %+v, %v", claimed, err)
+	}
+	if _, err := b.Claim(1, ReasonStopLoss, 27, now); err == nil {
+		t.Error("expected a firing trigger not to be claimed again")
+	}
+	if len(b.Active("demo")) != 0 {
+		t.Error("a firing trigger should not be active")
+	}
+
+	if err := b.Finish(1, "", errors.New("no position")); err != nil {
+		t.Fatal(err)
+	}
+	if tr, _ := b.Get(1); tr.Status != StatusFailed || tr.Error != "no position" {
+		t.Errorf("finished = %+v", tr)
+	}
+	if err := b.Cancel(1); err == nil {
+		t.Error("expected a failed trigger not to be cancelled")
+	}
+	if err := b.Cancel(2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Get(3); err == nil {
+		t.Error("expected an error for a missing trigger")
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	path := DefaultPath(filepath.Join(t.TempDir(), "data"))
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of a missing file failed: %v", err)
+	}
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if _, err := b.Add(Trigger{Ticker: "KXA", Side: "yes", StopLoss: 30, OrderType: "market", Environment: "demo"}, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(path, b); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.NextID != 2 || len(loaded.Triggers) != 1 || !loaded.Triggers[0].Created.Equal(now) {
+		t.Errorf("loaded %+v", loaded)
+	}
+	if !loaded.Triggers[0].FiredAt.IsZero() {
+		t.Error("an unfired trigger should have no fire time")
+	}
+}
+
+func TestUpdate_ClaimsOnce(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	err := Update(path, func(b *Book) error {
+		for i := 0; i < 5; i++ {
+			if _, err := b.Add(Trigger{Ticker: "KXA", Side: "yes", StopLoss: 30, Environment: "demo"}, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Several monitors race to claim each trigger; exactly one wins each
+	var claims atomic.Int32
+	var wg sync.WaitGroup
+	for m := 0; m < 4; m++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := 1; id <= 5; id++ {
+				err := Update(path, func(b *Book) error {
+					_, err := b.Claim(id, ReasonStopLoss, 28, now)
+					return err
+				})
+				if err == nil {
+					claims.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := claims.Load(); got != 5 {
+		t.Errorf("%d claims succeeded, want one per trigger", got)
+	}
+	b, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tr := range b.Triggers {
+		if tr.Status != StatusFiring {
+			t.Errorf("trigger %d is %s, want firing", tr.ID, tr.Status)
+		}
+	}
+	if matches, _ := filepath.Glob(path + ".*.tmp"); len(matches) != 0 {
+		t.Errorf("temp files left behind: %v", matches)
+	}
+}
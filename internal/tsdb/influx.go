@@ -0,0 +1,122 @@
+package tsdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Influx writes points to the InfluxDB v2 write API in line protocol. It
+// also works with InfluxDB 1.8+ and other servers that accept /api/v2/write.
+type Influx struct {
+	URL    string
+	Bucket string
+	Org    string
+	Token  string
+	Client *http.Client
+}
+
+// Write sends points in one request
+func (i *Influx) Write(ctx context.Context, points []Point) error {
+	var body bytes.Buffer
+	for _, p := range points {
+		body.WriteString(LineProtocol(p))
+		body.WriteByte('\n')
+	}
+
+	q := url.Values{}
+	q.Set("bucket", i.Bucket)
+	if i.Org != "" {
+		q.Set("org", i.Org)
+	}
+	q.Set("precision", "ns")
+	endpoint := strings.TrimRight(i.URL, "/") + "/api/v2/write?" + q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("invalid InfluxDB URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if i.Token != "" {
+		req.Header.Set("Authorization", "Token "+i.Token)
+	}
+
+	client := i.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("InfluxDB write failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("InfluxDB write failed: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Close does nothing; each write is its own request
+func (i *Influx) Close() error {
+	return nil
+}
+
+// LineProtocol renders p as one line of InfluxDB line protocol, e.g.
+// kalshi_ticker,ticker=KXCPI-26MAR yes_bid=41i,yes_ask=44i 1767225600000000000
+func LineProtocol(p Point) string {
+	var b strings.Builder
+	b.WriteString(escape(p.Measurement, ", "))
+	for _, k := range sortedKeys(p.Tags) {
+		if p.Tags[k] == "" {
+			continue
+		}
+		b.WriteString("," + escape(k, ",= ") + "=" + escape(p.Tags[k], ",= "))
+	}
+	for i, k := range sortedKeys(p.Fields) {
+		if i == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(escape(k, ",= ") + "=" + fieldValue(p.Fields[k]))
+	}
+	b.WriteString(" " + strconv.FormatInt(p.Time.UnixNano(), 10))
+	return b.String()
+}
+
+func fieldValue(v interface{}) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v) + "i"
+	case int64:
+		return strconv.FormatInt(v, 10) + "i"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+	}
+	return `"` + fmt.Sprint(v) + `"`
+}
+
+// escape backslash-escapes the characters in special, and newlines, which
+// line protocol cannot carry
+func escape(s, special string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(special, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
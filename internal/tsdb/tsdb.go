@@ -0,0 +1,151 @@
+// Package tsdb streams time-series points to InfluxDB and to
+// TimescaleDB/PostgreSQL, so dashboards can be built from watch data.
+package tsdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Point is one sample: a measurement at a time, identified by its tags
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	// Fields hold int, int64, float64, bool or string values
+	Fields map[string]interface{}
+	Time   time.Time
+}
+
+// Sink writes points to a database
+type Sink interface {
+	Write(ctx context.Context, points []Point) error
+	Close() error
+}
+
+// Sink kinds accepted by Open
+const (
+	KindInflux    = "influx"
+	KindTimescale = "timescale"
+	KindPostgres  = "postgres"
+)
+
+// Options configure a sink
+type Options struct {
+	// URL is the InfluxDB base URL or the PostgreSQL connection URL
+	URL string
+	// Bucket, Org and Token are used by InfluxDB only
+	Bucket string
+	Org    string
+	Token  string
+}
+
+// Open returns the sink of the given kind
+func Open(kind string, opts Options) (Sink, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("--sink-url is required for --sink %s", kind)
+	}
+	switch kind {
+	case KindInflux:
+		if opts.Bucket == "" {
+			return nil, fmt.Errorf("--sink-bucket is required for --sink influx")
+		}
+		return &Influx{URL: opts.URL, Bucket: opts.Bucket, Org: opts.Org, Token: opts.Token}, nil
+	case KindTimescale, KindPostgres:
+		return OpenPostgres(opts.URL, kind == KindTimescale)
+	}
+	return nil, fmt.Errorf("unknown sink %q: use influx, timescale or postgres", kind)
+}
+
+// sortedKeys returns the keys of m in order, so output is stable
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Batcher buffers points and writes them to a sink in the background, every
+// size points or interval, so a slow database never holds up the stream
+type Batcher struct {
+	sink     Sink
+	size     int
+	onError  func(error)
+	points   chan Point
+	done     chan struct{}
+	closeOne sync.Once
+}
+
+// NewBatcher starts writing points added to it to sink. Write failures are
+// passed to onError, and those points are dropped.
+func NewBatcher(sink Sink, size int, interval time.Duration, onError func(error)) *Batcher {
+	if onError == nil {
+		onError = func(err error) { fmt.Fprintf(os.Stderr, "Warning: %v\n", err) }
+	}
+	b := &Batcher{
+		sink:    sink,
+		size:    size,
+		onError: onError,
+		points:  make(chan Point, size*4),
+		done:    make(chan struct{}),
+	}
+	go b.run(interval)
+	return b
+}
+
+// Add queues a point. If the queue is full because the database is not
+// keeping up, the point is dropped and reported.
+func (b *Batcher) Add(p Point) {
+	select {
+	case b.points <- p:
+	default:
+		b.onError(fmt.Errorf("time-series sink is behind; dropped a %s point", p.Measurement))
+	}
+}
+
+// Close writes the queued points and closes the sink
+func (b *Batcher) Close() error {
+	b.closeOne.Do(func() { close(b.points) })
+	<-b.done
+	return b.sink.Close()
+}
+
+func (b *Batcher) run(interval time.Duration) {
+	defer close(b.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]Point, 0, b.size)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := b.sink.Write(ctx, batch); err != nil {
+			b.onError(fmt.Errorf("failed to write %d points: %w", len(batch), err))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case p, ok := <-b.points:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, p)
+			if len(batch) >= b.size {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
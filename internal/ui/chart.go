@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"strings"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // CandleData holds OHLCV data for chart rendering.
@@ -24,7 +26,7 @@ var volumeBars = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 // RenderCandlestickChart prints an ASCII candlestick chart to stdout.
 func RenderCandlestickChart(candles []CandleData, title string) {
 	if len(candles) == 0 {
-		fmt.Println(MutedStyle.Render("  No candlestick data to chart."))
+		fmt.Fprintln(Writer(), MutedStyle.Render("  No candlestick data to chart."))
 		return
 	}
 
@@ -40,8 +42,8 @@ func RenderCandlestickChart(candles []CandleData, title string) {
 	}
 
 	// Summary header
-	fmt.Println()
-	fmt.Print("  " + TitleStyle.Render(title))
+	fmt.Fprintln(Writer())
+	fmt.Fprint(Writer(), "  "+TitleStyle.Render(title))
 	lastClose := visible[len(visible)-1].Close
 	firstOpen := visible[0].Open
 	change := lastClose - firstOpen
@@ -55,8 +57,8 @@ func RenderCandlestickChart(candles []CandleData, title string) {
 	} else {
 		summary += "  " + PriceDownStyle.Render(fmt.Sprintf("%s (%.1f%%)", FormatPrice(change), changePct))
 	}
-	fmt.Println(summary)
-	fmt.Println()
+	fmt.Fprintln(Writer(), summary)
+	fmt.Fprintln(Writer())
 
 	// Build chart grid
 	grid := buildGrid(visible, priceMin, priceMax)
@@ -66,26 +68,26 @@ func RenderCandlestickChart(candles []CandleData, title string) {
 	for row := 0; row < chartHeight; row++ {
 		price := rowToPrice(row, priceMin, priceMax)
 		if row == 0 || row == chartHeight-1 || row%labelInterval == 0 {
-			fmt.Printf("  %7s │", FormatPrice(price))
+			fmt.Fprintf(Writer(), "  %7s │", FormatPrice(price))
 		} else {
-			fmt.Print("          │")
+			fmt.Fprint(Writer(), "          │")
 		}
 		for col := 0; col < len(visible); col++ {
-			fmt.Print(grid[row][col])
+			fmt.Fprint(Writer(), grid[row][col])
 		}
-		fmt.Println()
+		fmt.Fprintln(Writer())
 	}
 
 	// X-axis line
-	fmt.Print("          └")
-	fmt.Println(strings.Repeat("─", len(visible)*2))
+	fmt.Fprint(Writer(), "          └")
+	fmt.Fprintln(Writer(), strings.Repeat("─", len(visible)*2))
 
 	// X-axis labels
 	renderXLabels(visible)
 
 	// Volume sparkline
 	renderVolumeLine(visible)
-	fmt.Println()
+	fmt.Fprintln(Writer())
 }
 
 func priceBounds(candles []CandleData) (int, int) {
@@ -239,7 +241,7 @@ func renderXLabels(candles []CandleData) {
 		lastEnd = end + 1
 	}
 
-	fmt.Println(string(buf))
+	fmt.Fprintln(Writer(), string(buf))
 }
 
 func renderVolumeLine(candles []CandleData) {
@@ -254,16 +256,16 @@ func renderVolumeLine(candles []CandleData) {
 		}
 	}
 
-	fmt.Print("  " + MutedStyle.Render("Vol") + "     ")
+	fmt.Fprint(Writer(), "  "+MutedStyle.Render("Vol")+"     ")
 	for _, c := range candles {
 		bar := volumeBar(c.Volume, maxVol)
 		if c.Close >= c.Open {
-			fmt.Print(PriceUpStyle.Render(string(bar)) + " ")
+			fmt.Fprint(Writer(), PriceUpStyle.Render(string(bar))+" ")
 		} else {
-			fmt.Print(PriceDownStyle.Render(string(bar)) + " ")
+			fmt.Fprint(Writer(), PriceDownStyle.Render(string(bar))+" ")
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(Writer())
 }
 
 func volumeBar(vol, maxVol int) rune {
@@ -279,3 +281,212 @@ func volumeBar(vol, maxVol int) rune {
 	}
 	return volumeBars[idx]
 }
+
+// LineSeries is one named line of an overlay chart. Values are in cents and
+// line up with the chart labels; 0 marks a missing point.
+type LineSeries struct {
+	Name   string
+	Values []int
+}
+
+var seriesStyles = []lipgloss.Style{
+	lipgloss.NewStyle().Foreground(accentColor),
+	lipgloss.NewStyle().Foreground(warningColor),
+	lipgloss.NewStyle().Foreground(successColor),
+	lipgloss.NewStyle().Foreground(primaryColor),
+	lipgloss.NewStyle().Foreground(errorColor),
+}
+
+var seriesMarkers = []rune{'●', '■', '▲', '◆', '✚'}
+
+// overlapCell marks a grid cell shared by more than one series
+const overlapCell = -1
+
+// RenderOverlayChart prints several price series on a shared axis, one
+// colored marker per series, followed by a legend.
+func RenderOverlayChart(title string, labels []string, series []LineSeries) {
+	if len(labels) == 0 || len(series) == 0 {
+		fmt.Fprintln(Writer(), MutedStyle.Render("  No candlestick data to chart."))
+		return
+	}
+
+	// Trim to the most recent points if too many
+	if len(labels) > maxChartCandles {
+		start := len(labels) - maxChartCandles
+		labels = labels[start:]
+		trimmed := make([]LineSeries, len(series))
+		for i, s := range series {
+			trimmed[i] = LineSeries{Name: s.Name, Values: s.Values[start:]}
+		}
+		series = trimmed
+	}
+
+	priceMin, priceMax := seriesBounds(series)
+	grid := buildOverlayGrid(series, len(labels), priceMin, priceMax)
+
+	fmt.Fprintln(Writer())
+	fmt.Fprintln(Writer(), "  "+TitleStyle.Render(title))
+	fmt.Fprintln(Writer())
+
+	labelInterval := labelStep(chartHeight)
+	for row := 0; row < chartHeight; row++ {
+		price := rowToPrice(row, priceMin, priceMax)
+		if row == 0 || row == chartHeight-1 || row%labelInterval == 0 {
+			fmt.Fprintf(Writer(), "  %7s │", FormatPrice(price))
+		} else {
+			fmt.Fprint(Writer(), "          │")
+		}
+		for _, cell := range grid[row] {
+			switch {
+			case cell == overlapCell:
+				fmt.Fprint(Writer(), MutedStyle.Render("✱")+" ")
+			case cell > 0:
+				i := (cell - 1) % len(seriesMarkers)
+				fmt.Fprint(Writer(), seriesStyles[i].Render(string(seriesMarkers[i]))+" ")
+			default:
+				fmt.Fprint(Writer(), "  ")
+			}
+		}
+		fmt.Fprintln(Writer())
+	}
+
+	fmt.Fprint(Writer(), "          └")
+	fmt.Fprintln(Writer(), strings.Repeat("─", len(labels)*2))
+
+	points := make([]CandleData, len(labels))
+	for i, l := range labels {
+		points[i] = CandleData{Label: l}
+	}
+	renderXLabels(points)
+
+	fmt.Fprintln(Writer())
+	for i, s := range series {
+		idx := i % len(seriesMarkers)
+		fmt.Fprintf(Writer(), "  %s %s\n", seriesStyles[idx].Render(string(seriesMarkers[idx])), s.Name)
+	}
+	fmt.Fprintln(Writer())
+}
+
+// seriesBounds returns the padded price range across every present value
+func seriesBounds(series []LineSeries) (int, int) {
+	lo, hi := math.MaxInt, math.MinInt
+	for _, s := range series {
+		for _, v := range s.Values {
+			if v == 0 {
+				continue
+			}
+			lo = min(lo, v)
+			hi = max(hi, v)
+		}
+	}
+	if lo > hi {
+		return 0, 100
+	}
+	if lo > 0 {
+		lo--
+	}
+	return lo, hi + 1
+}
+
+// buildOverlayGrid places each series on the grid. A cell holds the 1-based
+// series index, 0 when empty, or overlapCell when series collide.
+func buildOverlayGrid(series []LineSeries, width, priceMin, priceMax int) [][]int {
+	grid := make([][]int, chartHeight)
+	for row := range grid {
+		grid[row] = make([]int, width)
+	}
+
+	for i, s := range series {
+		for col := 0; col < width && col < len(s.Values); col++ {
+			if s.Values[col] == 0 {
+				continue
+			}
+			row := priceToRow(s.Values[col], priceMin, priceMax)
+			if grid[row][col] == 0 {
+				grid[row][col] = i + 1
+			} else if grid[row][col] != i+1 {
+				grid[row][col] = overlapCell
+			}
+		}
+	}
+	return grid
+}
+
+// barChartHeight is the number of rows used by RenderBarChart
+const barChartHeight = 8
+
+// eighthBlocks are partial bar tops, from 1/8 to a full block
+var eighthBlocks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// RenderBarChart prints a vertical bar chart of non-negative counts, such as
+// volume or open interest, with the most recent values on the right.
+func RenderBarChart(title string, labels []string, values []int) {
+	if len(values) == 0 {
+		fmt.Fprintln(Writer(), MutedStyle.Render("  No data to chart."))
+		return
+	}
+
+	if len(values) > maxChartCandles {
+		start := len(values) - maxChartCandles
+		values = values[start:]
+		labels = labels[start:]
+	}
+
+	maxValue := 0
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+
+	fmt.Fprintln(Writer())
+	fmt.Fprintln(Writer(), "  "+TitleStyle.Render(title))
+	fmt.Fprintln(Writer())
+
+	for _, line := range barChartRows(values, maxValue) {
+		fmt.Fprintln(Writer(), line)
+	}
+
+	fmt.Fprint(Writer(), "          └")
+	fmt.Fprintln(Writer(), strings.Repeat("─", len(values)*2))
+
+	points := make([]CandleData, len(labels))
+	for i, l := range labels {
+		points[i] = CandleData{Label: l}
+	}
+	renderXLabels(points)
+	fmt.Fprintln(Writer())
+}
+
+// barChartRows renders the bars top to bottom, labelling the top and bottom rows
+func barChartRows(values []int, maxValue int) []string {
+	lines := make([]string, barChartHeight)
+	for row := 0; row < barChartHeight; row++ {
+		var b strings.Builder
+		switch row {
+		case 0:
+			fmt.Fprintf(&b, "  %7d │", maxValue)
+		case barChartHeight - 1:
+			fmt.Fprintf(&b, "  %7d │", 0)
+		default:
+			b.WriteString("          │")
+		}
+
+		// Each row covers eight sub-steps of the bar height
+		floor := (barChartHeight - 1 - row) * len(eighthBlocks)
+		for _, v := range values {
+			eighths := 0
+			if maxValue > 0 {
+				eighths = int(math.Round(float64(v) / float64(maxValue) * barChartHeight * float64(len(eighthBlocks))))
+			}
+			switch fill := eighths - floor; {
+			case fill <= 0:
+				b.WriteString("  ")
+			case fill >= len(eighthBlocks):
+				b.WriteString(seriesStyles[0].Render("█") + " ")
+			default:
+				b.WriteString(seriesStyles[0].Render(string(eighthBlocks[fill-1])) + " ")
+			}
+		}
+		lines[row] = b.String()
+	}
+	return lines
+}
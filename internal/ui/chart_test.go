@@ -198,3 +198,61 @@ func TestRenderCandlestickChart_ExceedsMaxCandles(t *testing.T) {
 		t.Fatal("expected output for many candles")
 	}
 }
+
+func TestBuildOverlayGrid(t *testing.T) {
+	series := []LineSeries{
+		{Name: "A", Values: []int{10, 50, 0}},
+		{Name: "B", Values: []int{90, 50, 30}},
+	}
+	grid := buildOverlayGrid(series, 3, 10, 90)
+
+	if got := grid[chartHeight-1][0]; got != 1 {
+		t.Errorf("A at its low = %d, want 1", got)
+	}
+	if got := grid[0][0]; got != 2 {
+		t.Errorf("B at its high = %d, want 2", got)
+	}
+	if got := grid[priceToRow(50, 10, 90)][1]; got != overlapCell {
+		t.Errorf("shared point = %d, want overlapCell", got)
+	}
+	for row := range grid {
+		if grid[row][2] == 1 {
+			t.Error("missing value of A was plotted")
+		}
+	}
+}
+
+func TestRenderOverlayChart_Legend(t *testing.T) {
+	out := captureOutput(func() {
+		RenderOverlayChart("Closes", []string{"03/01 10:00", "03/01 11:00"}, []LineSeries{
+			{Name: "MKT-A", Values: []int{40, 42}},
+			{Name: "MKT-B", Values: []int{60, 0}},
+		})
+	})
+	for _, want := range []string{"Closes", "MKT-A", "MKT-B", "03/01 10:00"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
+func TestBarChartRows(t *testing.T) {
+	rows := barChartRows([]int{0, 50, 100}, 100)
+	if len(rows) != barChartHeight {
+		t.Fatalf("got %d rows, want %d", len(rows), barChartHeight)
+	}
+
+	// The tallest bar reaches the top row; the half bar stops midway
+	if !strings.Contains(rows[0], "█") {
+		t.Errorf("top row %q has no full block", rows[0])
+	}
+	if strings.Count(rows[barChartHeight/2-1], "█") != 1 {
+		t.Errorf("row above the midpoint %q should only hold the tallest bar", rows[barChartHeight/2-1])
+	}
+	if strings.Count(rows[barChartHeight-1], "█") != 2 {
+		t.Errorf("bottom row %q should hold two bars", rows[barChartHeight-1])
+	}
+	if !strings.Contains(rows[0], "100") {
+		t.Errorf("top row %q missing max label", rows[0])
+	}
+}
@@ -3,17 +3,16 @@ package ui
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 )
 
 func PrintJSON(v interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
+	encoder := json.NewEncoder(Writer())
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(v)
 }
 
 func PrintJSONCompact(v interface{}) error {
-	return json.NewEncoder(os.Stdout).Encode(v)
+	return json.NewEncoder(Writer()).Encode(v)
 }
 
 func ToJSONString(v interface{}) (string, error) {
@@ -25,7 +24,7 @@ func ToJSONString(v interface{}) (string, error) {
 }
 
 func PrintPlain(format string, args ...interface{}) {
-	fmt.Printf(format+"\n", args...)
+	fmt.Fprintf(Writer(), format+"\n", args...)
 }
 
 func Output(format OutputFormat, tableFunc func(), jsonData interface{}, plainFunc func()) error {
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// progressEnabled turns progress reporting on stderr on or off
+var progressEnabled = true
+
+// SetProgress enables or disables progress reporting
+func SetProgress(enabled bool) {
+	progressEnabled = enabled
+}
+
+// Progress reports how far a paged job has got on stderr: pages fetched,
+// items, rate, the ETA when the total is known and the current cursor. On a
+// terminal the line is redrawn in place; otherwise each page is a new line.
+// A nil *Progress reports nothing.
+type Progress struct {
+	w       io.Writer
+	label   string
+	total   int
+	pages   int
+	items   int
+	start   time.Time
+	now     func() time.Time
+	inPlace bool
+	drawn   bool
+}
+
+// NewProgress starts reporting a job; total is the expected number of items,
+// or 0 when it is not known. It returns nil when progress is disabled.
+func NewProgress(label string, total int) *Progress {
+	if !progressEnabled {
+		return nil
+	}
+	return &Progress{
+		w:       os.Stderr,
+		label:   label,
+		total:   total,
+		start:   time.Now(),
+		now:     time.Now,
+		inPlace: term.IsTerminal(int(os.Stderr.Fd())),
+	}
+}
+
+// Page records a page of items and the cursor for the next one. A job that
+// ends on its first page reports nothing.
+func (p *Progress) Page(items int, cursor string) {
+	if p == nil {
+		return
+	}
+	p.pages++
+	p.items += items
+	if p.pages == 1 && cursor == "" {
+		return
+	}
+	line := p.line(cursor)
+	if p.inPlace {
+		fmt.Fprint(p.w, "\r"+line+"\x1b[K")
+		p.drawn = true
+		return
+	}
+	fmt.Fprintln(p.w, line)
+}
+
+// Clear erases the in-place line so that output streamed to the same
+// terminal does not run into it; the next Page draws it again
+func (p *Progress) Clear() {
+	if p == nil || !p.drawn {
+		return
+	}
+	fmt.Fprint(p.w, "\r\x1b[K")
+	p.drawn = false
+}
+
+// Done ends the in-place line so later output starts on its own line
+func (p *Progress) Done() {
+	if p == nil || !p.drawn {
+		return
+	}
+	fmt.Fprintln(p.w)
+	p.drawn = false
+}
+
+// line renders the current state, e.g.
+// "markets: 3 pages, 600/1000 items, 240/s, ETA 2s, cursor abc"
+func (p *Progress) line(cursor string) string {
+	pages := "pages"
+	if p.pages == 1 {
+		pages = "page"
+	}
+	parts := []string{fmt.Sprintf("%d %s", p.pages, pages)}
+	if p.total > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d items", p.items, p.total))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d items", p.items))
+	}
+
+	elapsed := p.now().Sub(p.start)
+	if elapsed > 0 && p.items > 0 {
+		rate := float64(p.items) / elapsed.Seconds()
+		parts = append(parts, fmt.Sprintf("%.0f/s", rate))
+		if p.total > p.items {
+			eta := time.Duration(float64(p.total-p.items) / rate * float64(time.Second))
+			parts = append(parts, "ETA "+eta.Round(time.Second).String())
+		}
+	}
+	if cursor != "" {
+		if len(cursor) > 16 {
+			cursor = cursor[:16] + "..."
+		}
+		parts = append(parts, "cursor "+cursor)
+	}
+	return p.label + ": " + strings.Join(parts, ", ")
+}
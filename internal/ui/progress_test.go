@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testProgress(buf *bytes.Buffer, total int, inPlace bool) *Progress {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := start
+	return &Progress{
+		w:       buf,
+		label:   "markets",
+		total:   total,
+		start:   start,
+		inPlace: inPlace,
+		now: func() time.Time {
+			clock = clock.Add(time.Second)
+			return clock
+		},
+	}
+}
+
+func TestProgressLines(t *testing.T) {
+	var buf bytes.Buffer
+	p := testProgress(&buf, 400, false)
+	p.Page(100, "cursor-one")
+	p.Page(100, "a-very-long-cursor-value")
+	p.Done()
+
+	want := "markets: 1 page, 100/400 items, 100/s, ETA 3s, cursor cursor-one\n" +
+		"markets: 2 pages, 200/400 items, 100/s, ETA 2s, cursor a-very-long-curs...\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestProgressSinglePageIsSilent(t *testing.T) {
+	var buf bytes.Buffer
+	p := testProgress(&buf, 0, true)
+	p.Page(20, "")
+	p.Done()
+	if buf.Len() != 0 {
+		t.Errorf("got %q, want nothing", buf.String())
+	}
+}
+
+func TestProgressInPlace(t *testing.T) {
+	var buf bytes.Buffer
+	p := testProgress(&buf, 0, true)
+	p.Page(10, "next")
+	p.Clear()
+	p.Page(5, "")
+	p.Done()
+
+	got := buf.String()
+	if !strings.HasPrefix(got, "\rmarkets: 1 page, 10 items") || !strings.HasSuffix(got, "15 items, 8/s\x1b[K\n") {
+		t.Errorf("got %q", got)
+	}
+	if !strings.Contains(got, "\x1b[K\r\x1b[K\r") {
+		t.Errorf("expected the line cleared between pages, got %q", got)
+	}
+}
+
+func TestProgressDisabled(t *testing.T) {
+	SetProgress(false)
+	defer SetProgress(true)
+	p := NewProgress("markets", 0)
+	if p != nil {
+		t.Fatal("expected nil progress when disabled")
+	}
+	p.Page(10, "next")
+	p.Clear()
+	p.Done()
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/6missedcalls/kalshi-cli/pkg/models"
 )
 
 type OutputFormat int
@@ -78,28 +81,32 @@ var (
 			Foreground(lipgloss.Color("#991B1B")).
 			Padding(0, 1).
 			Bold(true)
+
+	// ProdBannerStyle is the full-strength banner shown before production writes
+	ProdBannerStyle = lipgloss.NewStyle().
+			Background(errorColor).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Padding(0, 1).
+			Bold(true)
 )
 
+// DisableColor renders every style as plain text, whatever the terminal
+func DisableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
 func FormatPrice(cents int) string {
-	if cents < 0 {
-		return fmt.Sprintf("-$%.2f", float64(-cents)/100.0)
-	}
-	return fmt.Sprintf("$%.2f", float64(cents)/100.0)
+	return models.Cents(cents).String()
 }
 
 func FormatPriceStyled(cents int, positive bool) string {
-	absCents := cents
-	if absCents < 0 {
-		absCents = -absCents
-	}
-	dollars := float64(absCents) / 100.0
 	style := PriceDownStyle
 	prefix := "-"
 	if positive {
 		style = PriceUpStyle
 		prefix = "+"
 	}
-	return style.Render(fmt.Sprintf("%s$%.2f", prefix, dollars))
+	return style.Render(prefix + models.Cents(cents).Abs().String())
 }
 
 func FormatPercent(value float64) string {
@@ -109,3 +116,24 @@ func FormatPercent(value float64) string {
 func FormatQuantity(qty int) string {
 	return fmt.Sprintf("%d", qty)
 }
+
+// heatColors run from cold to hot for probabilities 0-100, one per band
+var heatColors = []lipgloss.Color{"#1E293B", "#1E3A8A", "#2563EB", "#0D9488", "#CA8A04", "#EA580C", "#DC2626"}
+
+// heatBands are the upper bounds, in percent, of every heat color but the last
+var heatBands = []float64{2, 10, 20, 35, 50, 70}
+
+// HeatColor returns the color for an implied probability in percent
+func HeatColor(pct float64) lipgloss.Color {
+	for i, bound := range heatBands {
+		if pct < bound {
+			return heatColors[i]
+		}
+	}
+	return heatColors[len(heatColors)-1]
+}
+
+// HeatStyle returns white text on the HeatColor of an implied probability
+func HeatStyle(pct float64) lipgloss.Style {
+	return lipgloss.NewStyle().Background(HeatColor(pct)).Foreground(lipgloss.Color("#FFFFFF"))
+}
@@ -2,7 +2,6 @@ package ui
 
 import (
 	"io"
-	"os"
 
 	"github.com/olekukonko/tablewriter"
 )
@@ -15,7 +14,7 @@ type TableOptions struct {
 }
 
 func NewTable(opts TableOptions) *tablewriter.Table {
-	return NewTableWriter(os.Stdout, opts)
+	return NewTableWriter(Writer(), opts)
 }
 
 func NewTableWriter(w io.Writer, opts TableOptions) *tablewriter.Table {
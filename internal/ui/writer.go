@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// out is where rendered output goes. Nil means os.Stdout, looked up on every
+// write so that code swapping os.Stdout (such as golden.Capture) still sees
+// the output.
+var out io.Writer
+
+// terminal is where previews, warnings and prompts go. Nil means os.Stdout,
+// looked up on every write like out.
+var terminal io.Writer
+
+// SetWriter sends rendered output to w; nil restores os.Stdout
+func SetWriter(w io.Writer) {
+	out = w
+}
+
+// Writer returns where rendered output goes
+func Writer() io.Writer {
+	if out != nil {
+		return out
+	}
+	return os.Stdout
+}
+
+// SetTerminal sends previews, warnings and prompts to w; nil restores
+// os.Stdout
+func SetTerminal(w io.Writer) {
+	terminal = w
+}
+
+// Terminal returns where previews, warnings and prompts go. It stays on the
+// terminal when SetWriter redirects a command's result, so a user confirming
+// an order still sees what they are confirming.
+func Terminal() io.Writer {
+	if terminal != nil {
+		return terminal
+	}
+	return os.Stdout
+}
+
+// WriterIsTerminal reports whether rendered output goes to a terminal, so
+// live views know whether they can redraw in place
+func WriterIsTerminal() bool {
+	f, ok := Writer().(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	SetWriter(&buf)
+	defer SetWriter(nil)
+
+	RenderTable([]string{"Ticker"}, [][]string{{"KXA"}})
+	PrintPlain("%s\t%d", "KXB", 2)
+	if err := PrintJSON(map[string]int{"n": 1}); err != nil {
+		t.Fatal(err)
+	}
+	RenderBarChart("Volume", []string{"a"}, []int{3})
+
+	out := buf.String()
+	for _, want := range []string{"KXA", "KXB\t2", `"n": 1`, "Volume"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if WriterIsTerminal() {
+		t.Error("a buffer is not a terminal")
+	}
+}
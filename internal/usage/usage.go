@@ -0,0 +1,217 @@
+// Package usage records local, per-invocation CLI usage statistics.
+// Nothing recorded here ever leaves the machine.
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const fileName = "usage.jsonl"
+
+// Record describes a single CLI invocation
+type Record struct {
+	Time        time.Time     `json:"time"`
+	Command     string        `json:"command"`
+	Environment string        `json:"environment"`
+	Duration    time.Duration `json:"duration"`
+	APICalls    int64         `json:"api_calls"`
+	RateLimited int64         `json:"rate_limited"`
+	APIErrors   int64         `json:"api_errors"`
+	Failed      bool          `json:"failed"`
+	// Pace is the adaptive request rate at the end of the run, PaceLimit
+	// the configured rate and LowestPace the lowest reached, in requests
+	// per second; all are zero without a rate limit
+	Pace         float64 `json:"pace,omitempty"`
+	PaceLimit    float64 `json:"pace_limit,omitempty"`
+	LowestPace   float64 `json:"lowest_pace,omitempty"`
+	PaceBackoffs int64   `json:"pace_backoffs,omitempty"`
+}
+
+// Log is an append-only JSONL file of usage records
+type Log struct {
+	path string
+}
+
+// NewLog returns a log stored at path
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// DefaultPath returns the usage log location inside the data directory
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, fileName)
+}
+
+// Path returns the log file location
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append writes a record to the end of the log
+func (l *Log) Append(r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage record: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("failed to create usage log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open usage log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write usage log: %w", err)
+	}
+	return nil
+}
+
+// Load reads all records at or after since. A zero since returns everything.
+// Malformed lines are skipped so a truncated write never breaks reporting.
+func (l *Log) Load(since time.Time) ([]Record, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open usage log: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		if !since.IsZero() && r.Time.Before(since) {
+			continue
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage log: %w", err)
+	}
+
+	return records, nil
+}
+
+// Clear removes the log file
+func (l *Log) Clear() error {
+	if err := os.Remove(l.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove usage log: %w", err)
+	}
+	return nil
+}
+
+// CommandSummary aggregates usage for a single command path
+type CommandSummary struct {
+	Command     string        `json:"command"`
+	Runs        int           `json:"runs"`
+	Failures    int           `json:"failures"`
+	APICalls    int64         `json:"api_calls"`
+	RateLimited int64         `json:"rate_limited"`
+	APIErrors   int64         `json:"api_errors"`
+	Backoffs    int64         `json:"pace_backoffs"`
+	AvgDuration time.Duration `json:"avg_duration"`
+	LastRun     time.Time     `json:"last_run"`
+}
+
+// Summary aggregates usage across all commands. LastPace and LastPaceLimit
+// are the adaptive rate the latest paced run ended at and its configured
+// rate.
+type Summary struct {
+	From          time.Time        `json:"from"`
+	To            time.Time        `json:"to"`
+	Runs          int              `json:"runs"`
+	Failures      int              `json:"failures"`
+	APICalls      int64            `json:"api_calls"`
+	RateLimited   int64            `json:"rate_limited"`
+	APIErrors     int64            `json:"api_errors"`
+	Backoffs      int64            `json:"pace_backoffs"`
+	LowestPace    float64          `json:"lowest_pace"`
+	LastPace      float64          `json:"last_pace"`
+	LastPaceLimit float64          `json:"last_pace_limit"`
+	Commands      []CommandSummary `json:"commands"`
+}
+
+// Summarize aggregates records per command, ordered by API calls then runs
+func Summarize(records []Record) Summary {
+	var s Summary
+	byCommand := make(map[string]*CommandSummary)
+	totalDuration := make(map[string]time.Duration)
+	var lastPaced time.Time
+
+	for _, r := range records {
+		if s.From.IsZero() || r.Time.Before(s.From) {
+			s.From = r.Time
+		}
+		if r.Time.After(s.To) {
+			s.To = r.Time
+		}
+
+		s.Runs++
+		s.APICalls += r.APICalls
+		s.RateLimited += r.RateLimited
+		s.APIErrors += r.APIErrors
+		s.Backoffs += r.PaceBackoffs
+		if r.Failed {
+			s.Failures++
+		}
+		if r.LowestPace > 0 && (s.LowestPace == 0 || r.LowestPace < s.LowestPace) {
+			s.LowestPace = r.LowestPace
+		}
+		if r.Pace > 0 && !r.Time.Before(lastPaced) {
+			lastPaced = r.Time
+			s.LastPace, s.LastPaceLimit = r.Pace, r.PaceLimit
+		}
+
+		cs, ok := byCommand[r.Command]
+		if !ok {
+			cs = &CommandSummary{Command: r.Command}
+			byCommand[r.Command] = cs
+		}
+		cs.Runs++
+		cs.APICalls += r.APICalls
+		cs.RateLimited += r.RateLimited
+		cs.APIErrors += r.APIErrors
+		cs.Backoffs += r.PaceBackoffs
+		if r.Failed {
+			cs.Failures++
+		}
+		if r.Time.After(cs.LastRun) {
+			cs.LastRun = r.Time
+		}
+		totalDuration[r.Command] += r.Duration
+	}
+
+	s.Commands = make([]CommandSummary, 0, len(byCommand))
+	for name, cs := range byCommand {
+		cs.AvgDuration = totalDuration[name] / time.Duration(cs.Runs)
+		s.Commands = append(s.Commands, *cs)
+	}
+
+	sort.Slice(s.Commands, func(i, j int) bool {
+		a, b := s.Commands[i], s.Commands[j]
+		if a.APICalls != b.APICalls {
+			return a.APICalls > b.APICalls
+		}
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return a.Command < b.Command
+	})
+
+	return s
+}
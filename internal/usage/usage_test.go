@@ -0,0 +1,138 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLog_AppendAndLoad(t *testing.T) {
+	log := NewLog(filepath.Join(t.TempDir(), "usage.jsonl"))
+
+	now := time.Now().UTC()
+	records := []Record{
+		{Time: now.Add(-48 * time.Hour), Command: "markets list", APICalls: 3},
+		{Time: now.Add(-time.Hour), Command: "orders create", APICalls: 1, Failed: true},
+		{Time: now, Command: "markets list", APICalls: 5, RateLimited: 2},
+	}
+	for _, r := range records {
+		if err := log.Append(r); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	all, err := log.Load(time.Time{})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(all))
+	}
+
+	recent, err := log.Load(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(recent) != 2 {
+		t.Errorf("expected 2 recent records, got %d", len(recent))
+	}
+}
+
+func TestLog_LoadMissingFile(t *testing.T) {
+	log := NewLog(filepath.Join(t.TempDir(), "missing.jsonl"))
+
+	records, err := log.Load(time.Time{})
+	if err != nil {
+		t.Fatalf("expected no error for missing file, got %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("expected no records, got %d", len(records))
+	}
+}
+
+func TestLog_SkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.jsonl")
+	content := `{"command":"markets list","api_calls":1}
+not-json
+{"command":"orders list","api_calls":2}
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := NewLog(path).Load(time.Time{})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("expected 2 records, got %d", len(records))
+	}
+}
+
+func TestLog_Clear(t *testing.T) {
+	log := NewLog(filepath.Join(t.TempDir(), "usage.jsonl"))
+	if err := log.Append(Record{Command: "stats"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if err := log.Clear(); err != nil {
+		t.Fatalf("Clear on missing file should succeed, got %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Time: base, Command: "markets list", APICalls: 3, Duration: time.Second},
+		{Time: base.Add(time.Hour), Command: "markets list", APICalls: 5, RateLimited: 2, Duration: 3 * time.Second},
+		{Time: base.Add(2 * time.Hour), Command: "orders create", APICalls: 1, APIErrors: 1, Failed: true},
+	}
+
+	s := Summarize(records)
+
+	if s.Runs != 3 || s.Failures != 1 || s.APICalls != 9 || s.RateLimited != 2 || s.APIErrors != 1 {
+		t.Errorf("unexpected totals: %+v", s)
+	}
+	if !s.From.Equal(base) || !s.To.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("unexpected period: %v - %v", s.From, s.To)
+	}
+	if len(s.Commands) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(s.Commands))
+	}
+
+	first := s.Commands[0]
+	if first.Command != "markets list" {
+		t.Errorf("expected busiest command first, got %s", first.Command)
+	}
+	if first.Runs != 2 || first.AvgDuration != 2*time.Second {
+		t.Errorf("unexpected markets list summary: %+v", first)
+	}
+}
+
+func TestSummarize_Pace(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Time: base.Add(time.Hour), Command: "markets list", Pace: 10, PaceLimit: 10, LowestPace: 10},
+		{Time: base, Command: "orders create", Pace: 5, PaceLimit: 10, LowestPace: 2.5, PaceBackoffs: 2},
+		{Time: base.Add(2 * time.Hour), Command: "version"},
+	}
+
+	s := Summarize(records)
+
+	if s.Backoffs != 2 || s.LowestPace != 2.5 {
+		t.Errorf("backoffs = %d, lowest = %v", s.Backoffs, s.LowestPace)
+	}
+	if s.LastPace != 10 || s.LastPaceLimit != 10 {
+		t.Errorf("last pace = %v of %v, want the latest paced run", s.LastPace, s.LastPaceLimit)
+	}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	s := Summarize(nil)
+	if s.Runs != 0 || len(s.Commands) != 0 {
+		t.Errorf("expected empty summary, got %+v", s)
+	}
+}
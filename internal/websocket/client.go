@@ -33,6 +33,10 @@ type ClientOptions struct {
 	ReconnectMaxDelay  time.Duration
 	WriteTimeout       time.Duration
 	ReadTimeout        time.Duration
+	// HTTPClient performs the handshake; nil uses http.DefaultClient
+	HTTPClient *http.Client
+	// Headers are added to the handshake, e.g. for an internal gateway
+	Headers map[string]string
 }
 
 // Validate checks that required options are set
@@ -65,8 +69,11 @@ type Client struct {
 	signature string
 	timestamp string
 
+	httpClient    *http.Client
+	headers       map[string]string
 	conn          *websocket.Conn
 	connected     atomic.Bool
+	lastMessage   atomic.Int64 // Unix nanoseconds
 	subscriptions *SubscriptionManager
 	router        *MessageRouter
 
@@ -87,6 +94,7 @@ type Client struct {
 
 	onReconnect func()
 	onError     func(error)
+	onRaw       func([]byte)
 }
 
 // NewClient creates a new WebSocket client
@@ -121,6 +129,8 @@ func NewClient(opts ClientOptions) *Client {
 		apiKeyID:           opts.APIKeyID,
 		signature:          opts.Signature,
 		timestamp:          opts.Timestamp,
+		httpClient:         opts.HTTPClient,
+		headers:            opts.Headers,
 		subscriptions:      NewSubscriptionManager(),
 		router:             NewMessageRouter(),
 		pingInterval:       pingInterval,
@@ -136,12 +146,17 @@ func NewClient(opts ClientOptions) *Client {
 // buildDialOptions constructs WebSocket dial options with authentication headers.
 // Kalshi requires these headers on the HTTP upgrade request.
 func (c *Client) buildDialOptions() *websocket.DialOptions {
+	header := http.Header{}
+	for name, value := range c.headers {
+		header.Set(name, value)
+	}
+	header["KALSHI-ACCESS-KEY"] = []string{c.apiKeyID}
+	header["KALSHI-ACCESS-SIGNATURE"] = []string{c.signature}
+	header["KALSHI-ACCESS-TIMESTAMP"] = []string{c.timestamp}
+
 	return &websocket.DialOptions{
-		HTTPHeader: http.Header{
-			"KALSHI-ACCESS-KEY":       []string{c.apiKeyID},
-			"KALSHI-ACCESS-SIGNATURE": []string{c.signature},
-			"KALSHI-ACCESS-TIMESTAMP": []string{c.timestamp},
-		},
+		HTTPClient: c.httpClient,
+		HTTPHeader: header,
 	}
 }
 
@@ -226,16 +241,26 @@ func (c *Client) readLoop(ctx context.Context) {
 			continue
 		}
 
-		msg, err := ParseMessage(data)
-		if err != nil {
-			if c.onError != nil {
-				c.onError(fmt.Errorf("parse error: %w", err))
-			}
-			continue
+		c.lastMessage.Store(time.Now().UnixNano())
+		if c.onRaw != nil {
+			c.onRaw(data)
 		}
+		c.HandleFrame(data)
+	}
+}
 
-		c.handleMessage(msg)
+// HandleFrame parses a frame and routes it to the registered handlers, just
+// as frames read from the connection are. It lets recorded frames be
+// replayed through the same handlers without a connection.
+func (c *Client) HandleFrame(data []byte) {
+	msg, err := ParseMessage(data)
+	if err != nil {
+		if c.onError != nil {
+			c.onError(fmt.Errorf("parse error: %w", err))
+		}
+		return
 	}
+	c.handleMessage(msg)
 }
 
 // handleMessage processes an incoming message
@@ -392,11 +417,26 @@ func (c *Client) Close() {
 	c.wg.Wait()
 }
 
+// LastMessage returns when a frame was last read, or the zero time
+func (c *Client) LastMessage() time.Time {
+	n := c.lastMessage.Load()
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
 // OnReconnect sets a callback for when the client reconnects
 func (c *Client) OnReconnect(fn func()) {
 	c.onReconnect = fn
 }
 
+// OnRawMessage sets a callback that receives every frame exactly as read,
+// before it is parsed or routed
+func (c *Client) OnRawMessage(fn func([]byte)) {
+	c.onRaw = fn
+}
+
 // OnError sets a callback for error handling
 func (c *Client) OnError(fn func(error)) {
 	c.onError = fn
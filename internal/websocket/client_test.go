@@ -654,3 +654,52 @@ func newTestWSServer(t *testing.T, handler func(conn *websocket.Conn)) *httptest
 		handler(conn)
 	}))
 }
+
+func TestClient_OnRawMessage_ReceivesUnparsedFrames(t *testing.T) {
+	frames := []string{
+		`{"type":"ticker","sid":1,"msg":{"market_ticker":"BTC-100K","new_field":true}}`,
+		`not json`,
+	}
+
+	server := newTestWSServer(t, func(conn *websocket.Conn) {
+		ctx := context.Background()
+		for _, f := range frames {
+			conn.Write(ctx, websocket.MessageText, []byte(f))
+		}
+		<-ctx.Done()
+	})
+	defer server.Close()
+
+	client := NewClient(ClientOptions{
+		URL:       "ws" + strings.TrimPrefix(server.URL, "http"),
+		APIKeyID:  "test-key",
+		Signature: "test-sig",
+		Timestamp: "2024-01-15T12:00:00Z",
+	})
+
+	received := make(chan string, len(frames))
+	client.OnRawMessage(func(data []byte) {
+		received <- string(data)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer client.Close()
+
+	for _, want := range frames {
+		select {
+		case got := <-received:
+			if got != want {
+				t.Errorf("expected frame %q, got %q", want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("frame %q was not delivered", want)
+		}
+	}
+	if client.LastMessage().IsZero() {
+		t.Error("expected LastMessage to be set after frames were read")
+	}
+}
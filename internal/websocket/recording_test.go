@@ -0,0 +1,80 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	frames := []string{
+		`{"type":"ticker","channel":"ticker","data":{"ticker":"KXA","yes_price":40}}`,
+		`not json`,
+	}
+	for i, f := range frames {
+		if err := rec.Record(start.Add(time.Duration(i)*time.Second), []byte(f)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rec.Frames() != 2 {
+		t.Errorf("Frames() = %d, want 2", rec.Frames())
+	}
+
+	var got []Frame
+	if err := ReadFrames(&buf, func(f Frame) error {
+		got = append(got, f)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || string(got[0].Frame) != frames[0] || !got[1].ReceivedAt.Equal(start.Add(time.Second)) {
+		t.Fatalf("read back %+v", got)
+	}
+	if string(got[1].Frame) != `"not json"` {
+		t.Errorf("non-JSON frame = %s, want it kept as a string", got[1].Frame)
+	}
+
+	err := ReadFrames(strings.NewReader("{}\nbroken\n"), func(Frame) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("err = %v, want line 2", err)
+	}
+}
+
+func TestReplay_RoutesThroughHandlers(t *testing.T) {
+	recording := strings.Join([]string{
+		`{"received_at":"2026-03-01T12:00:00Z","frame":{"channel":"ticker","data":{"ticker":"KXA"}}}`,
+		`{"received_at":"2026-03-01T12:00:00.040Z","frame":{"channel":"trade","data":{"ticker":"KXA"}}}`,
+		`{"received_at":"2026-03-01T12:00:00.080Z","frame":{"channel":"ticker","data":{"ticker":"KXB"}}}`,
+	}, "\n")
+
+	client := NewClient(ClientOptions{})
+	var seen []string
+	client.RegisterHandler(ChannelMarketTicker, HandlerFunc(func(msg Message) error {
+		seen = append(seen, string(msg.Channel))
+		return nil
+	}))
+
+	start := time.Now()
+	n, err := Replay(context.Background(), strings.NewReader(recording), 2, client.HandleFrame)
+	if err != nil || n != 3 {
+		t.Fatalf("Replay = %d, %v; want 3 frames", n, err)
+	}
+	if len(seen) != 2 {
+		t.Errorf("ticker handler saw %v, want 2 messages", seen)
+	}
+	// 80ms recorded at 2x takes about 40ms
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("replay took %v, want the recorded gaps halved", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if n, err := Replay(ctx, strings.NewReader(recording), 1, func([]byte) {}); err != nil || n != 0 {
+		t.Errorf("cancelled Replay = %d, %v; want nothing replayed", n, err)
+	}
+}
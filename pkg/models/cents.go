@@ -0,0 +1,143 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Cents is an amount of money in whole cents. Arithmetic stays in integers so
+// sums never drift the way float dollars do.
+type Cents int64
+
+// Add returns c + o
+func (c Cents) Add(o Cents) Cents {
+	return c + o
+}
+
+// Sub returns c - o
+func (c Cents) Sub(o Cents) Cents {
+	return c - o
+}
+
+// Mul returns c times n, e.g. a price times a contract count
+func (c Cents) Mul(n int) Cents {
+	return c * Cents(n)
+}
+
+// Abs returns the magnitude of c
+func (c Cents) Abs() Cents {
+	if c < 0 {
+		return -c
+	}
+	return c
+}
+
+// Dollars renders c as a decimal dollar amount, e.g. "-1.05"
+func (c Cents) Dollars() string {
+	sign := ""
+	if c < 0 {
+		sign = "-"
+	}
+	abs := c.Abs()
+	return fmt.Sprintf("%s%d.%02d", sign, abs/100, abs%100)
+}
+
+// String renders c as dollars with the sign before the symbol, e.g. "-$1.05"
+func (c Cents) String() string {
+	if c < 0 {
+		return "-$" + c.Abs().Dollars()
+	}
+	return "$" + c.Dollars()
+}
+
+// subCentDigits is the number of decimal places of a SubCents dollar amount
+const subCentDigits = 4
+
+// SubCents is an amount of money in hundredths of a cent, the precision of
+// the API's fixed-point dollar strings such as fee_cost ("0.0175")
+type SubCents int64
+
+// ParseSubCents reads a fixed-point dollar string exactly. Digits beyond the
+// fourth decimal place are rounded half away from zero.
+func ParseSubCents(s string) (SubCents, error) {
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return 0, fmt.Errorf("invalid dollar amount %q", s)
+	}
+
+	whole, frac, _ := strings.Cut(digits, ".")
+	if whole == "" && frac == "" || strings.ContainsAny(whole+frac, "+-") {
+		return 0, fmt.Errorf("invalid dollar amount %q", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	roundUp := false
+	if len(frac) > subCentDigits {
+		if _, err := strconv.ParseUint(frac, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid dollar amount %q", s)
+		}
+		roundUp = frac[subCentDigits] >= '5'
+		frac = frac[:subCentDigits]
+	}
+	frac += strings.Repeat("0", subCentDigits-len(frac))
+
+	v, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid dollar amount %q", s)
+	}
+	if roundUp {
+		v++
+	}
+	if neg {
+		v = -v
+	}
+	return SubCents(v), nil
+}
+
+// Cents rounds s to whole cents, half away from zero
+func (s SubCents) Cents() Cents {
+	if s < 0 {
+		return -(-s).Cents()
+	}
+	return Cents((s + 50) / 100)
+}
+
+// FixedPoint renders s with four decimal places, as the API does, e.g. "0.0250"
+func (s SubCents) FixedPoint() string {
+	sign := ""
+	abs := s
+	if s < 0 {
+		sign = "-"
+		abs = -s
+	}
+	return fmt.Sprintf("%s%d.%04d", sign, abs/10000, abs%10000)
+}
+
+// decimal renders s with at least two decimal places and no trailing zeros
+// beyond them, e.g. "0.025"
+func (s SubCents) decimal() string {
+	d := strings.TrimRight(s.FixedPoint(), "0")
+	if i := strings.Index(d, "."); len(d)-i < 3 {
+		d += strings.Repeat("0", 3-(len(d)-i))
+	}
+	return d
+}
+
+// String renders s as dollars with cent precision, keeping sub-cent digits,
+// e.g. "$0.025"
+func (s SubCents) String() string {
+	if s < 0 {
+		return "-$" + (-s).decimal()
+	}
+	return "$" + s.decimal()
+}
+
+// MarshalJSON writes s as a decimal dollar number, e.g. 0.025
+func (s SubCents) MarshalJSON() ([]byte, error) {
+	return []byte(s.decimal()), nil
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCents_String(t *testing.T) {
+	tests := map[Cents]string{0: "$0.00", 5: "$0.05", 123: "$1.23", -50: "-$0.50", -1005: "-$10.05"}
+	for in, want := range tests {
+		if got := in.String(); got != want {
+			t.Errorf("Cents(%d).String() = %q, want %q", in, got, want)
+		}
+	}
+	if got := Cents(45).Mul(10).Sub(Cents(100)).Add(Cents(-400)).Abs(); got != 50 {
+		t.Errorf("arithmetic = %d, want 50", got)
+	}
+}
+
+func TestParseSubCents(t *testing.T) {
+	tests := map[string]SubCents{
+		"0":       0,
+		"0.0175":  175,
+		"1.5":     15000,
+		".25":     2500,
+		"-0.02":   -200,
+		"0.00005": 1,
+		"0.00004": 0,
+		"2.":      20000,
+	}
+	for in, want := range tests {
+		got, err := ParseSubCents(in)
+		if err != nil || got != want {
+			t.Errorf("ParseSubCents(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", ".", "abc", "1.2.3", "--1", "1.-5", "1.00x00"} {
+		if _, err := ParseSubCents(in); err == nil {
+			t.Errorf("ParseSubCents(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestSubCents_Format(t *testing.T) {
+	tests := []struct {
+		in    SubCents
+		str   string
+		fixed string
+		cents Cents
+	}{
+		{0, "$0.00", "0.0000", 0},
+		{15000, "$1.50", "1.5000", 150},
+		{175, "$0.0175", "0.0175", 2},
+		{250, "$0.025", "0.0250", 3},
+		{-250, "-$0.025", "-0.0250", -3},
+		{149, "$0.0149", "0.0149", 1},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.str {
+			t.Errorf("SubCents(%d).String() = %q, want %q", tt.in, got, tt.str)
+		}
+		if got := tt.in.FixedPoint(); got != tt.fixed {
+			t.Errorf("SubCents(%d).FixedPoint() = %q, want %q", tt.in, got, tt.fixed)
+		}
+		if got := tt.in.Cents(); got != tt.cents {
+			t.Errorf("SubCents(%d).Cents() = %d, want %d", tt.in, got, tt.cents)
+		}
+	}
+
+	out, err := json.Marshal(map[string]SubCents{"fee": 250, "total": 20000})
+	if err != nil || string(out) != `{"fee":0.025,"total":2.00}` {
+		t.Errorf("json.Marshal = %s, %v", out, err)
+	}
+}
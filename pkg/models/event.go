@@ -15,6 +15,11 @@ type Event struct {
 	StrikePeriod         string     `json:"strike_period,omitempty"`
 	AvailableOnBrokers   bool       `json:"available_on_brokers"`
 	Markets              []string   `json:"markets,omitempty"`
+	// NestedMarkets holds the full markets when the event was requested with
+	// with_nested_markets; Markets then lists their tickers
+	NestedMarkets []Market `json:"-"`
+	// Extra holds fields not declared above
+	Extra Extra `json:"-"`
 }
 
 // EventResponse is the API response for a single event
@@ -0,0 +1,241 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Extra holds response fields a model does not declare, so data Kalshi adds
+// later still reaches --json output
+type Extra map[string]json.RawMessage
+
+// knownFieldsCache maps a struct type to the JSON names of its fields
+var knownFieldsCache sync.Map
+
+// knownFields returns the JSON names declared by struct type t
+func knownFields(t reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	known := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		known[name] = true
+	}
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+// unknownFields returns the members of the JSON object data that struct v
+// does not declare, or nil if there are none
+func unknownFields(data []byte, v interface{}) (Extra, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	known := knownFields(reflect.TypeOf(v))
+	var extra Extra
+	for name, value := range all {
+		if known[name] {
+			continue
+		}
+		if extra == nil {
+			extra = make(Extra)
+		}
+		extra[name] = value
+	}
+	return extra, nil
+}
+
+// appendExtra adds extra to the end of the JSON object data, in key order
+func appendExtra(data []byte, extra Extra) ([]byte, error) {
+	if len(extra) == 0 {
+		return data, nil
+	}
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSuffix(bytes.TrimSpace(data), []byte("}")))
+	empty := bytes.Equal(bytes.TrimSpace(buf.Bytes()), []byte("{"))
+	for _, name := range names {
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(extra[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeDeclared decodes the declared fields of a model. A value of the
+// wrong type is left empty rather than losing the whole object.
+func decodeDeclared(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return nil
+	}
+	return err
+}
+
+// UnmarshalJSON keeps undeclared fields in Extra
+func (m *Market) UnmarshalJSON(data []byte) error {
+	type market Market
+	var v market
+	if err := decodeDeclared(data, &v); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, v)
+	if err != nil {
+		return err
+	}
+	*m = Market(v)
+	m.Extra = extra
+	return nil
+}
+
+// MarshalJSON writes Extra after the declared fields
+func (m Market) MarshalJSON() ([]byte, error) {
+	type market Market
+	data, err := json.Marshal(market(m))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtra(data, m.Extra)
+}
+
+// UnmarshalJSON keeps undeclared fields in Extra. markets may be a list of
+// tickers or, with nested markets, a list of market objects.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	var v struct {
+		event
+		Markets json.RawMessage `json:"markets"`
+	}
+	if err := decodeDeclared(data, &v); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, v.event)
+	if err != nil {
+		return err
+	}
+	*e = Event(v.event)
+	e.Extra = extra
+	return e.unmarshalMarkets(v.Markets)
+}
+
+// unmarshalMarkets fills Markets, and NestedMarkets if raw holds objects
+func (e *Event) unmarshalMarkets(raw json.RawMessage) error {
+	e.Markets, e.NestedMarkets = nil, nil
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(raw, &e.Markets); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, &e.NestedMarkets); err != nil {
+		return err
+	}
+	e.Markets = make([]string, len(e.NestedMarkets))
+	for i, m := range e.NestedMarkets {
+		e.Markets[i] = m.Ticker
+	}
+	return nil
+}
+
+// MarshalJSON writes Extra after the declared fields. Nested markets are
+// written as objects.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+	if len(e.NestedMarkets) == 0 {
+		data, err := json.Marshal(event(e))
+		if err != nil {
+			return nil, err
+		}
+		return appendExtra(data, e.Extra)
+	}
+
+	data, err := json.Marshal(struct {
+		event
+		Markets []Market `json:"markets"`
+	}{event(e), e.NestedMarkets})
+	if err != nil {
+		return nil, err
+	}
+	return appendExtra(data, e.Extra)
+}
+
+// UnmarshalJSON keeps undeclared fields in Extra
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type order Order
+	var v order
+	if err := decodeDeclared(data, &v); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, v)
+	if err != nil {
+		return err
+	}
+	*o = Order(v)
+	o.Extra = extra
+	return nil
+}
+
+// MarshalJSON writes Extra after the declared fields
+func (o Order) MarshalJSON() ([]byte, error) {
+	type order Order
+	data, err := json.Marshal(order(o))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtra(data, o.Extra)
+}
+
+// UnmarshalJSON keeps undeclared fields in Extra
+func (f *Fill) UnmarshalJSON(data []byte) error {
+	type fill Fill
+	var v fill
+	if err := decodeDeclared(data, &v); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, v)
+	if err != nil {
+		return err
+	}
+	*f = Fill(v)
+	f.Extra = extra
+	return nil
+}
+
+// MarshalJSON writes Extra after the declared fields
+func (f Fill) MarshalJSON() ([]byte, error) {
+	type fill Fill
+	data, err := json.Marshal(fill(f))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtra(data, f.Extra)
+}
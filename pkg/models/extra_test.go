@@ -0,0 +1,109 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMarket_PreservesUnknownFields(t *testing.T) {
+	in := `{"ticker":"KXBTC-26FEB12-B97000","yes_bid":45,"new_field":{"a":1},"price_ranges":[1,2]}`
+
+	var m Market
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if m.Ticker != "KXBTC-26FEB12-B97000" || m.YesBid != 45 {
+		t.Errorf("declared fields not decoded: %+v", m)
+	}
+	if len(m.Extra) != 2 || string(m.Extra["new_field"]) != `{"a":1}` {
+		t.Fatalf("expected 2 extra fields, got %v", m.Extra)
+	}
+
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.HasPrefix(string(out), `{"ticker":"KXBTC-26FEB12-B97000"`) {
+		t.Errorf("expected declared fields first, got %s", out)
+	}
+	if !strings.HasSuffix(string(out), `,"new_field":{"a":1},"price_ranges":[1,2]}`) {
+		t.Errorf("expected extra fields appended in key order, got %s", out)
+	}
+
+	var roundTrip map[string]json.RawMessage
+	if err := json.Unmarshal(out, &roundTrip); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+}
+
+func TestModels_NoExtraWhenAllFieldsKnown(t *testing.T) {
+	var o Order
+	if err := json.Unmarshal([]byte(`{"order_id":"abc","status":"resting"}`), &o); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if o.Extra != nil {
+		t.Errorf("expected nil Extra, got %v", o.Extra)
+	}
+	out, _ := json.Marshal(o)
+	if strings.Contains(string(out), "Extra") {
+		t.Errorf("Extra must not be written as a field: %s", out)
+	}
+}
+
+func TestEventAndFill_PreserveUnknownFields(t *testing.T) {
+	var e Event
+	if err := json.Unmarshal([]byte(`{"event_ticker":"E","product_metadata":{"x":"y"}}`), &e); err != nil {
+		t.Fatalf("unmarshal event: %v", err)
+	}
+	var f Fill
+	if err := json.Unmarshal([]byte(`{"trade_id":"T","fee_cost":"0.01","purchased_side":"yes"}`), &f); err != nil {
+		t.Fatalf("unmarshal fill: %v", err)
+	}
+
+	eOut, _ := json.Marshal(e)
+	fOut, _ := json.Marshal(f)
+	if !strings.Contains(string(eOut), `"product_metadata":{"x":"y"}`) {
+		t.Errorf("event extra lost: %s", eOut)
+	}
+	if !strings.Contains(string(fOut), `"purchased_side":"yes"`) || f.FeeCost != "0.01" {
+		t.Errorf("fill extra lost: %s", fOut)
+	}
+}
+
+func TestAppendExtra_EmptyObject(t *testing.T) {
+	out, err := appendExtra([]byte(`{}`), Extra{"a": json.RawMessage(`1`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"a":1}` {
+		t.Errorf("appendExtra = %s", out)
+	}
+}
+
+func TestEvent_NestedMarkets(t *testing.T) {
+	var tickers Event
+	if err := json.Unmarshal([]byte(`{"event_ticker":"E","markets":["E-A","E-B"]}`), &tickers); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if len(tickers.Markets) != 2 || tickers.NestedMarkets != nil {
+		t.Errorf("ticker list = %v / %v", tickers.Markets, tickers.NestedMarkets)
+	}
+
+	var nested Event
+	in := `{"event_ticker":"E","markets":[{"ticker":"E-A","status":"active"}],"new_field":1}`
+	if err := json.Unmarshal([]byte(in), &nested); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if len(nested.NestedMarkets) != 1 || nested.Markets[0] != "E-A" || nested.Extra["new_field"] == nil {
+		t.Fatalf("nested = %+v", nested)
+	}
+
+	out, err := json.Marshal(nested)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if !strings.Contains(string(out), `"markets":[{"ticker":"E-A"`) || !strings.Contains(string(out), `"new_field":1`) {
+		t.Errorf("Marshal() = %s, want nested market objects and extra fields", out)
+	}
+}
@@ -44,6 +44,13 @@ type Market struct {
 	Rules               string    `json:"rules"`
 	RulesSecondary      string    `json:"rules_secondary"`
 	SettlementTimerSeconds int    `json:"settlement_timer_seconds"`
+	// StrikeType says how FloorStrike and CapStrike bound a bracket, e.g.
+	// "between", "greater" or "less"
+	StrikeType  string   `json:"strike_type,omitempty"`
+	FloorStrike *float64 `json:"floor_strike,omitempty"`
+	CapStrike   *float64 `json:"cap_strike,omitempty"`
+	// Extra holds fields not declared above
+	Extra Extra `json:"-"`
 }
 
 // MarketResponse is the API response for a single market
@@ -105,6 +112,10 @@ type Candlestick struct {
 	Volume       int       `json:"volume"`
 	OpenInterest int       `json:"open_interest"`
 	PeriodEnd    time.Time `json:"-"`
+	// YesBid and YesAsk are the best quotes at the end of the period, or 0
+	// when the side was empty
+	YesBid int `json:"-"`
+	YesAsk int `json:"-"`
 }
 
 // candlestickJSON is the structure used for JSON output (--json flag)
@@ -148,6 +159,12 @@ type candlestickWire struct {
 	} `json:"price"`
 	Volume       int `json:"volume"`
 	OpenInterest int `json:"open_interest"`
+	YesBid       struct {
+		Close int `json:"close"`
+	} `json:"yes_bid"`
+	YesAsk struct {
+		Close int `json:"close"`
+	} `json:"yes_ask"`
 }
 
 // UnmarshalJSON implements json.Unmarshaler for Candlestick.
@@ -164,6 +181,8 @@ func (c *Candlestick) UnmarshalJSON(data []byte) error {
 	c.Close = w.Price.Close
 	c.Volume = w.Volume
 	c.OpenInterest = w.OpenInterest
+	c.YesBid = w.YesBid.Close
+	c.YesAsk = w.YesAsk.Close
 
 	if w.EndPeriodTs > 0 {
 		c.PeriodEnd = time.Unix(w.EndPeriodTs, 0).UTC()
@@ -204,11 +223,32 @@ func (r *EventCandlesticksResponse) AllCandlesticks() []Candlestick {
 
 // Series represents a market series
 type Series struct {
-	Ticker      string `json:"ticker"`
-	Title       string `json:"title"`
-	Category    string `json:"category"`
-	Frequency   string `json:"frequency"`
-	Tags        []string `json:"tags"`
+	Ticker                 string             `json:"ticker"`
+	Title                  string             `json:"title"`
+	Category               string             `json:"category"`
+	Frequency              string             `json:"frequency"`
+	Tags                   []string           `json:"tags"`
+	SettlementSources      []SettlementSource `json:"settlement_sources,omitempty"`
+	ContractURL            string             `json:"contract_url,omitempty"`
+	ContractTermsURL       string             `json:"contract_terms_url,omitempty"`
+	FeeType                string             `json:"fee_type,omitempty"`
+	FeeMultiplier          float64            `json:"fee_multiplier,omitempty"`
+	AdditionalProhibitions []string           `json:"additional_prohibitions,omitempty"`
+	// ProductMetadata is only returned when requested with include_product_metadata
+	ProductMetadata map[string]interface{} `json:"product_metadata,omitempty"`
+}
+
+// Series fee types
+const (
+	FeeTypeQuadratic              = "quadratic"
+	FeeTypeQuadraticWithMakerFees = "quadratic_with_maker_fees"
+	FeeTypeFlat                   = "flat"
+)
+
+// SettlementSource is a source a series settles against
+type SettlementSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 // SeriesResponse is the API response for series
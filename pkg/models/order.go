@@ -65,6 +65,8 @@ type Order struct {
 	ClientOrderID           string      `json:"client_order_id,omitempty"`
 	SubaccountNumber        int         `json:"subaccount_number,omitempty"`
 	SelfTradePreventionType string      `json:"self_trade_prevention_type,omitempty"`
+	// Extra holds fields not declared above
+	Extra Extra `json:"-"`
 }
 
 // OrderResponse is the API response for a single order
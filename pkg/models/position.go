@@ -63,7 +63,19 @@ type Fill struct {
 	NoPrice     int       `json:"no_price"`
 	Count       int       `json:"count"`
 	IsTaker     bool      `json:"is_taker"`
+	FeeCost     string    `json:"fee_cost,omitempty"` // fixed-point dollars
 	CreatedTime time.Time `json:"created_time"`
+	// Extra holds fields not declared above
+	Extra Extra `json:"-"`
+}
+
+// Fee returns FeeCost exactly, treating a missing or malformed value as zero
+func (f Fill) Fee() SubCents {
+	fee, err := ParseSubCents(f.FeeCost)
+	if err != nil {
+		return 0
+	}
+	return fee
 }
 
 // FillsResponse is the API response for fills